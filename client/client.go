@@ -38,7 +38,9 @@
 // context, enhancing the client.Info with an implementation of client.AuthData,
 // and storing a new client.Info into the context that it passes down. The
 // attribute names should be documented with their return types and considered
-// part of the public API for the authenticator.
+// part of the public API for the authenticator. Authenticators identifying a
+// subject should use client.Principal, whose well-known "subject", "groups"
+// and "tenant" attributes can be used whatever the authenticator.
 //
 // # Consumers
 //
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client // import "go.opentelemetry.io/collector/client"
+
+import "sort"
+
+const (
+	// AuthAttributeSubject is the name of the Principal attribute holding the authenticated subject, as a string.
+	AuthAttributeSubject = "subject"
+	// AuthAttributeGroups is the name of the Principal attribute holding the groups of the subject, as a []string.
+	AuthAttributeGroups = "groups"
+	// AuthAttributeTenant is the name of the Principal attribute holding the tenant of the subject, as a string.
+	AuthAttributeTenant = "tenant"
+)
+
+var _ AuthData = (*Principal)(nil)
+
+// Principal is an AuthData implementation for authenticators identifying a subject, allowing processors and
+// exporters to use the well-known AuthAttributeSubject, AuthAttributeGroups and AuthAttributeTenant attributes
+// whatever the authenticator, e.g. for tenant-aware routing:
+//
+//	tenant, _ := client.FromContext(ctx).Auth.GetAttribute(client.AuthAttributeTenant).(string)
+//
+// Empty fields are not reported as attributes.
+type Principal struct {
+	// Subject is the identity of the authenticated client, e.g. a user name or the subject of a token.
+	Subject string
+
+	// Groups are the groups the subject is a member of.
+	Groups []string
+
+	// Tenant is the tenant the subject belongs to.
+	Tenant string
+
+	// Attributes are additional attributes specific to the authenticator. They do not override the
+	// well-known attributes.
+	Attributes map[string]interface{}
+}
+
+// GetAttribute returns the value of the given attribute, or nil if the attribute is not set or p is nil.
+func (p *Principal) GetAttribute(name string) interface{} {
+	if p == nil {
+		return nil
+	}
+	switch name {
+	case AuthAttributeSubject:
+		if p.Subject != "" {
+			return p.Subject
+		}
+		return nil
+	case AuthAttributeGroups:
+		if len(p.Groups) != 0 {
+			return p.Groups
+		}
+		return nil
+	case AuthAttributeTenant:
+		if p.Tenant != "" {
+			return p.Tenant
+		}
+		return nil
+	}
+	return p.Attributes[name]
+}
+
+// GetAttributeNames returns the sorted names of the attributes that are set, or nil if p is nil.
+func (p *Principal) GetAttributeNames() []string {
+	if p == nil {
+		return nil
+	}
+	var names []string
+	if p.Subject != "" {
+		names = append(names, AuthAttributeSubject)
+	}
+	if len(p.Groups) != 0 {
+		names = append(names, AuthAttributeGroups)
+	}
+	if p.Tenant != "" {
+		names = append(names, AuthAttributeTenant)
+	}
+	for name := range p.Attributes {
+		switch name {
+		case AuthAttributeSubject, AuthAttributeGroups, AuthAttributeTenant:
+		default:
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
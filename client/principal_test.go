@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipal(t *testing.T) {
+	p := &Principal{
+		Subject: "jdoe",
+		Groups:  []string{"admins", "devs"},
+		Tenant:  "acme",
+		Attributes: map[string]interface{}{
+			"email":              "jdoe@example.com",
+			AuthAttributeSubject: "ignored",
+		},
+	}
+	assert.Equal(t, "jdoe", p.GetAttribute(AuthAttributeSubject))
+	assert.Equal(t, []string{"admins", "devs"}, p.GetAttribute(AuthAttributeGroups))
+	assert.Equal(t, "acme", p.GetAttribute(AuthAttributeTenant))
+	assert.Equal(t, "jdoe@example.com", p.GetAttribute("email"))
+	assert.Nil(t, p.GetAttribute("unknown"))
+	assert.Equal(t, []string{"email", "groups", "subject", "tenant"}, p.GetAttributeNames())
+}
+
+func TestPrincipalEmpty(t *testing.T) {
+	p := &Principal{}
+	assert.Nil(t, p.GetAttribute(AuthAttributeSubject))
+	assert.Nil(t, p.GetAttribute(AuthAttributeGroups))
+	assert.Nil(t, p.GetAttribute(AuthAttributeTenant))
+	assert.Empty(t, p.GetAttributeNames())
+}
+
+func TestPrincipalNil(t *testing.T) {
+	var p *Principal
+	assert.Nil(t, p.GetAttribute(AuthAttributeSubject))
+	assert.Nil(t, p.GetAttribute("email"))
+	assert.Nil(t, p.GetAttributeNames())
+}
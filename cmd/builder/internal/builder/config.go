@@ -42,6 +42,7 @@ type Config struct {
 	Extensions   []Module     `mapstructure:"extensions"`
 	Receivers    []Module     `mapstructure:"receivers"`
 	Processors   []Module     `mapstructure:"processors"`
+	Connectors   []Module     `mapstructure:"connectors"`
 	Replaces     []string     `mapstructure:"replaces"`
 	Excludes     []string     `mapstructure:"excludes"`
 }
@@ -66,6 +67,19 @@ type Module struct {
 	Path   string `mapstructure:"path"`   // an optional path to the local version of this module
 }
 
+// ModulePath returns the path part of the gomod spec of the module.
+func (m Module) ModulePath() string {
+	return strings.Fields(m.GoMod)[0]
+}
+
+// ModuleVersion returns the version part of the gomod spec of the module, empty if there is none.
+func (m Module) ModuleVersion() string {
+	if parts := strings.Fields(m.GoMod); len(parts) > 1 {
+		return parts[1]
+	}
+	return ""
+}
+
 // NewDefaultConfig creates a new config, with default values
 func NewDefaultConfig() Config {
 	log, err := zap.NewDevelopment()
@@ -90,7 +104,7 @@ func NewDefaultConfig() Config {
 
 // Validate checks whether the current configuration is valid
 func (c *Config) Validate() error {
-	return multierr.Combine(validateModules(c.Extensions), validateModules(c.Receivers), validateModules(c.Exporters), validateModules(c.Processors))
+	return multierr.Combine(validateModules(c.Extensions), validateModules(c.Receivers), validateModules(c.Exporters), validateModules(c.Processors), validateModules(c.Connectors))
 }
 
 // SetGoPath sets go path
@@ -133,6 +147,11 @@ func (c *Config) ParseModules() error {
 		return err
 	}
 
+	c.Connectors, err = parseModules(c.Connectors)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
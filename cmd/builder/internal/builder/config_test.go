@@ -46,6 +46,8 @@ func TestParseModules(t *testing.T) {
 	assert.Equal(t, "github.com/org/repo v0.1.2", cfg.Extensions[0].GoMod)
 	assert.Equal(t, "github.com/org/repo", cfg.Extensions[0].Import)
 	assert.Equal(t, "repo", cfg.Extensions[0].Name)
+	assert.Equal(t, "github.com/org/repo", cfg.Extensions[0].ModulePath())
+	assert.Equal(t, "v0.1.2", cfg.Extensions[0].ModuleVersion())
 }
 
 func TestRelativePath(t *testing.T) {
@@ -133,6 +135,15 @@ func TestInvalidModule(t *testing.T) {
 			},
 			err: ErrInvalidGoMod,
 		},
+		{
+			cfg: Config{
+				Logger: zap.NewNop(),
+				Connectors: []Module{{
+					Import: "invalid",
+				}},
+			},
+			err: ErrInvalidGoMod,
+		},
 	}
 
 	for _, test := range configurations {
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// templateContext is the data the templates are executed with.
+type templateContext struct {
+	metadata
+	// Package is the name of the component package.
+	Package string
+	// ImportPath is the import path of the component package.
+	ImportPath string
+}
+
+// generatedFile is a file generated from a template.
+type generatedFile struct {
+	path     string
+	template *template.Template
+	// scaffold files are only generated if they do not exist, so they can be edited by the component authors.
+	scaffold bool
+}
+
+func run(ymlPath string) error {
+	if ymlPath == "" {
+		return errors.New("argument must be metadata.yaml file")
+	}
+	ymlPath, err := filepath.Abs(ymlPath)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for %v: %w", ymlPath, err)
+	}
+	md, err := loadMetadata(ymlPath)
+	if err != nil {
+		return err
+	}
+	return generate(md, filepath.Dir(ymlPath))
+}
+
+func generate(md metadata, dir string) error {
+	importPath, err := packageImportPath(dir)
+	if err != nil {
+		return err
+	}
+	tmplCtx := templateContext{
+		metadata:   md,
+		Package:    filepath.Base(dir),
+		ImportPath: importPath,
+	}
+
+	files := []generatedFile{
+		{path: filepath.Join("internal", "metadata", "generated_status.go"), template: statusTemplate},
+		{path: "documentation.md", template: documentationTemplate},
+		{path: "config.go", template: configTemplate, scaffold: true},
+		{path: "factory.go", template: factoryTemplate, scaffold: true},
+		{path: md.Class + ".go", template: componentTemplates[md.Class], scaffold: true},
+	}
+	for _, f := range files {
+		if err = generateFile(tmplCtx, filepath.Join(dir, f.path), f.template, f.scaffold); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func generateFile(tmplCtx templateContext, path string, tmpl *template.Template, scaffold bool) error {
+	if scaffold {
+		if _, err := os.Stat(path); err == nil {
+			return nil
+		}
+	}
+
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, tmplCtx); err != nil {
+		return fmt.Errorf("failed executing template %s: %w", tmpl.Name(), err)
+	}
+	result := buf.Bytes()
+	if strings.HasSuffix(path, ".go") {
+		formatted, err := format.Source(result)
+		if err != nil {
+			return fmt.Errorf("failed to format %s: %w", path, err)
+		}
+		result = formatted
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("unable to create output directory %q: %w", filepath.Dir(path), err)
+	}
+	return os.WriteFile(path, result, 0600)
+}
+
+// packageImportPath returns the import path of the package in dir, based on the module of the closest go.mod.
+func packageImportPath(dir string) (string, error) {
+	for modDir := dir; ; modDir = filepath.Dir(modDir) {
+		module, err := readModulePath(filepath.Join(modDir, "go.mod"))
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+		if module != "" {
+			rel, err := filepath.Rel(modDir, dir)
+			if err != nil {
+				return "", err
+			}
+			if rel == "." {
+				return module, nil
+			}
+			return module + "/" + filepath.ToSlash(rel), nil
+		}
+		if filepath.Dir(modDir) == modDir {
+			return "", fmt.Errorf("no go.mod found for %s", dir)
+		}
+	}
+}
+
+func readModulePath(goModPath string) (string, error) {
+	f, err := os.Open(goModPath) // #nosec G304
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`), nil
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no module directive in %s", goModPath)
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunContents(t *testing.T) {
+	tests := []struct {
+		class     string
+		signals   []string
+		constants []string
+		files     []string
+	}{
+		{
+			class:     classReceiver,
+			signals:   []string{signalTraces, signalMetrics, signalLogs},
+			constants: []string{"TracesStability", "MetricsStability", "LogsStability"},
+			files:     []string{"receiver.go"},
+		},
+		{
+			class:     classProcessor,
+			signals:   []string{signalTraces},
+			constants: []string{"TracesStability"},
+			files:     []string{"processor.go"},
+		},
+		{
+			class:     classExporter,
+			signals:   []string{signalMetrics, signalLogs},
+			constants: []string{"MetricsStability", "LogsStability"},
+			files:     []string{"exporter.go"},
+		},
+		{
+			class:     classExtension,
+			signals:   []string{signalExtension},
+			constants: []string{"ExtensionStability"},
+			files:     []string{"extension.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.class, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "sample"+tt.class)
+			require.NoError(t, os.MkdirAll(dir, 0700))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/sample\n"), 0600))
+
+			md := metadata{
+				Type:   "sample",
+				Class:  tt.class,
+				Status: status{Stability: map[string][]string{"alpha": tt.signals}},
+				Config: []field{{Name: "interval", Type: "duration"}},
+			}
+			if tt.class == classReceiver {
+				md.Telemetry.Transport = "http"
+			}
+			require.NoError(t, generate(md, dir))
+
+			for _, f := range append([]string{"internal/metadata/generated_status.go", "documentation.md", "config.go", "factory.go"}, tt.files...) {
+				assert.FileExists(t, filepath.Join(dir, f))
+			}
+
+			status, err := os.ReadFile(filepath.Join(dir, "internal", "metadata", "generated_status.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(status), `Type = "sample"`)
+			for _, constant := range tt.constants {
+				assert.Contains(t, string(status), constant+" = component.StabilityLevelAlpha")
+			}
+
+			factory, err := os.ReadFile(filepath.Join(dir, "factory.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(factory), `"example.com/sample/internal/metadata"`)
+			assert.Contains(t, string(factory), "package sample"+tt.class+" // import \"example.com/sample\"")
+		})
+	}
+}
+
+func TestRunKeepsScaffoldFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "samplereceiver")
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/sample\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "factory.go"), []byte("package sample\n"), 0600))
+	metadataFile := filepath.Join(dir, "metadata.yaml")
+	metadataYaml, err := os.ReadFile(filepath.Join("testdata", "metadata.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(metadataFile, metadataYaml, 0600))
+
+	require.NoError(t, run(metadataFile))
+
+	factory, err := os.ReadFile(filepath.Join(dir, "factory.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package sample\n", string(factory))
+	assert.FileExists(t, filepath.Join(dir, "config.go"))
+	assert.FileExists(t, filepath.Join(dir, "receiver.go"))
+}
+
+func TestRunInvalid(t *testing.T) {
+	assert.EqualError(t, run(""), "argument must be metadata.yaml file")
+	assert.Error(t, run(filepath.Join("testdata", "missing.yaml")))
+}
+
+func TestPackageImportPath(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("// comment\nmodule \"example.com/root\"\n\ngo 1.18\n"), 0600))
+	sub := filepath.Join(dir, "receiver", "samplereceiver")
+	require.NoError(t, os.MkdirAll(sub, 0700))
+
+	importPath, err := packageImportPath(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/root", importPath)
+
+	importPath, err = packageImportPath(sub)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/root/receiver/samplereceiver", importPath)
+}
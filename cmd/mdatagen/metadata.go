@@ -0,0 +1,311 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	classReceiver  = "receiver"
+	classProcessor = "processor"
+	classExporter  = "exporter"
+	classExtension = "extension"
+
+	signalTraces    = "traces"
+	signalMetrics   = "metrics"
+	signalLogs      = "logs"
+	signalExtension = "extension"
+)
+
+var (
+	// stabilityLevels are the levels supported in status.stability, mapped to their component.StabilityLevel name.
+	stabilityLevels = map[string]string{
+		"unmaintained": "Unmaintained",
+		"deprecated":   "Deprecated",
+		"development":  "Development",
+		"alpha":        "Alpha",
+		"beta":         "Beta",
+		"stable":       "Stable",
+	}
+
+	// classSignals are the signals each class of component can support, in the order they are generated.
+	classSignals = map[string][]string{
+		classReceiver:  {signalTraces, signalMetrics, signalLogs},
+		classProcessor: {signalTraces, signalMetrics, signalLogs},
+		classExporter:  {signalTraces, signalMetrics, signalLogs},
+		classExtension: {signalExtension},
+	}
+
+	// fieldTypes maps the supported config field types to their Go type.
+	fieldTypes = map[string]string{
+		"string":   "string",
+		"int":      "int",
+		"bool":     "bool",
+		"duration": "time.Duration",
+	}
+
+	typeRegexp  = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	fieldRegexp = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+)
+
+type metadata struct {
+	// Type of the component, as used in the collector configuration.
+	Type string `yaml:"type"`
+	// Class of the component: receiver, processor, exporter or extension.
+	Class string `yaml:"class"`
+	// Status of the component.
+	Status status `yaml:"status"`
+	// Telemetry of the component.
+	Telemetry telemetry `yaml:"telemetry"`
+	// Config is the list of the component specific configuration fields.
+	Config []field `yaml:"config"`
+}
+
+type status struct {
+	// Stability maps a stability level to the signals supported at that level.
+	Stability map[string][]string `yaml:"stability"`
+}
+
+type telemetry struct {
+	// Transport reported by the obsreport of receivers, e.g. "http" or "grpc".
+	Transport string `yaml:"transport"`
+}
+
+type field struct {
+	// Name of the field in the configuration, in snake case.
+	Name string `yaml:"name"`
+	// Type of the field: string, int, bool or duration.
+	Type string `yaml:"type"`
+	// Default value of the field, unset fields default to the zero value.
+	Default *string `yaml:"default"`
+	// Description of the field.
+	Description string `yaml:"description"`
+}
+
+// signalStability is the stability level of a single signal.
+type signalStability struct {
+	Signal string
+	Level  string
+}
+
+func loadMetadata(filePath string) (metadata, error) {
+	buf, err := os.ReadFile(filePath) // #nosec G304
+	if err != nil {
+		return metadata{}, err
+	}
+
+	var md metadata
+	if err = yaml.Unmarshal(buf, &md); err != nil {
+		return metadata{}, fmt.Errorf("failed to parse %s: %w", filePath, err)
+	}
+	if err = md.Validate(); err != nil {
+		return metadata{}, fmt.Errorf("invalid metadata %s: %w", filePath, err)
+	}
+	return md, nil
+}
+
+// Validate checks if the metadata is valid.
+func (md metadata) Validate() error {
+	if !typeRegexp.MatchString(md.Type) {
+		return fmt.Errorf("type %q must start with a lowercase letter and contain only lowercase letters, digits and underscores", md.Type)
+	}
+	signals, ok := classSignals[md.Class]
+	if !ok {
+		return fmt.Errorf("class %q is not supported, must be one of receiver, processor, exporter or extension", md.Class)
+	}
+	if err := md.validateStability(signals); err != nil {
+		return err
+	}
+	switch {
+	case md.Class == classReceiver && md.Telemetry.Transport == "":
+		return errors.New("telemetry transport must not be empty for a receiver")
+	case md.Class != classReceiver && md.Telemetry.Transport != "":
+		return errors.New("telemetry transport is only supported by receivers")
+	}
+	seen := make(map[string]bool, len(md.Config))
+	for _, f := range md.Config {
+		if err := f.validate(); err != nil {
+			return err
+		}
+		if seen[f.Name] {
+			return fmt.Errorf("config field %q is defined more than once", f.Name)
+		}
+		seen[f.Name] = true
+	}
+	return nil
+}
+
+func (md metadata) validateStability(supported []string) error {
+	if len(md.Status.Stability) == 0 {
+		return errors.New("status stability must not be empty")
+	}
+	seen := map[string]bool{}
+	for level, signals := range md.Status.Stability {
+		if _, ok := stabilityLevels[level]; !ok {
+			return fmt.Errorf("stability level %q is not supported", level)
+		}
+		for _, signal := range signals {
+			if !contains(supported, signal) {
+				return fmt.Errorf("signal %q is not supported by a %s, must be one of %s", signal, md.Class, strings.Join(supported, ", "))
+			}
+			if seen[signal] {
+				return fmt.Errorf("signal %q has more than one stability level", signal)
+			}
+			seen[signal] = true
+		}
+	}
+	if len(seen) == 0 {
+		return errors.New("status stability must list at least one signal")
+	}
+	return nil
+}
+
+// Signals returns the stability of the supported signals in a stable order.
+func (md metadata) Signals() []signalStability {
+	var ret []signalStability
+	for _, signal := range classSignals[md.Class] {
+		for level, signals := range md.Status.Stability {
+			if contains(signals, signal) {
+				ret = append(ret, signalStability{Signal: signal, Level: level})
+			}
+		}
+	}
+	return ret
+}
+
+// StructName returns the name of the struct implementing the component, e.g. "memoryBallastExtension".
+func (md metadata) StructName() string {
+	name := field{Name: md.Type}.GoName() + strings.ToUpper(md.Class[:1]) + md.Class[1:]
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// HasDuration returns true if a config field is a duration, requiring to import the "time" package.
+func (md metadata) HasDuration() bool {
+	for _, f := range md.Config {
+		if f.Type == "duration" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasDurationDefault returns true if a duration config field has a non-zero default.
+func (md metadata) HasDurationDefault() bool {
+	for _, f := range md.Config {
+		if f.Type == "duration" && f.GoDefault() != "" && f.GoDefault() != "0" {
+			return true
+		}
+	}
+	return false
+}
+
+func (f field) validate() error {
+	if !fieldRegexp.MatchString(f.Name) {
+		return fmt.Errorf("config field name %q must be in snake case", f.Name)
+	}
+	if _, ok := fieldTypes[f.Type]; !ok {
+		return fmt.Errorf("config field %q has unsupported type %q, must be one of string, int, bool or duration", f.Name, f.Type)
+	}
+	if f.Default == nil {
+		return nil
+	}
+	var err error
+	switch f.Type {
+	case "int":
+		_, err = strconv.Atoi(*f.Default)
+	case "bool":
+		_, err = strconv.ParseBool(*f.Default)
+	case "duration":
+		_, err = time.ParseDuration(*f.Default)
+	}
+	if err != nil {
+		return fmt.Errorf("config field %q has invalid default %q: %w", f.Name, *f.Default, err)
+	}
+	return nil
+}
+
+// GoName returns the name of the Go struct field, e.g. "CollectionInterval" for "collection_interval".
+func (f field) GoName() string {
+	var sb strings.Builder
+	for _, part := range strings.Split(f.Name, "_") {
+		if part == "" {
+			continue
+		}
+		sb.WriteString(strings.ToUpper(part[:1]))
+		sb.WriteString(part[1:])
+	}
+	return sb.String()
+}
+
+// GoType returns the Go type of the struct field.
+func (f field) GoType() string {
+	return fieldTypes[f.Type]
+}
+
+// GoDefault returns the Go expression of the default value, or an empty string if the field has no default.
+func (f field) GoDefault() string {
+	if f.Default == nil {
+		return ""
+	}
+	switch f.Type {
+	case "string":
+		return strconv.Quote(*f.Default)
+	case "duration":
+		d, _ := time.ParseDuration(*f.Default)
+		return durationExpr(d)
+	}
+	return *f.Default
+}
+
+// durationExpr returns the Go expression of d using the largest unit it is a multiple of.
+func durationExpr(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	if d == 0 {
+		return "0"
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("%d * time.Nanosecond", d)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
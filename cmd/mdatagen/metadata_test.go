@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMetadata(t *testing.T) {
+	md, err := loadMetadata(filepath.Join("testdata", "metadata.yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "sample", md.Type)
+	assert.Equal(t, classReceiver, md.Class)
+	assert.Equal(t, "http", md.Telemetry.Transport)
+	assert.Equal(t, []signalStability{
+		{Signal: signalTraces, Level: "alpha"},
+		{Signal: signalMetrics, Level: "beta"},
+		{Signal: signalLogs, Level: "alpha"},
+	}, md.Signals())
+	require.Len(t, md.Config, 4)
+	assert.Equal(t, "CollectionInterval", md.Config[1].GoName())
+	assert.Equal(t, "time.Duration", md.Config[1].GoType())
+	assert.Equal(t, "10 * time.Second", md.Config[1].GoDefault())
+	assert.Equal(t, "", md.Config[2].GoDefault())
+	assert.True(t, md.HasDuration())
+	assert.Equal(t, "sampleReceiver", md.StructName())
+}
+
+func TestLoadMetadataInvalidFile(t *testing.T) {
+	_, err := loadMetadata(filepath.Join("testdata", "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	validConfig := func() metadata {
+		return metadata{
+			Type:      "sample",
+			Class:     classReceiver,
+			Status:    status{Stability: map[string][]string{"beta": {signalMetrics}}},
+			Telemetry: telemetry{Transport: "http"},
+		}
+	}
+	stringPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name     string
+		mutate   func(md *metadata)
+		expected string
+	}{
+		{
+			name:   "valid",
+			mutate: func(md *metadata) {},
+		},
+		{
+			name:     "invalid type",
+			mutate:   func(md *metadata) { md.Type = "Sample" },
+			expected: `type "Sample" must start with a lowercase letter and contain only lowercase letters, digits and underscores`,
+		},
+		{
+			name:     "invalid class",
+			mutate:   func(md *metadata) { md.Class = "connector" },
+			expected: `class "connector" is not supported, must be one of receiver, processor, exporter or extension`,
+		},
+		{
+			name:     "empty stability",
+			mutate:   func(md *metadata) { md.Status.Stability = nil },
+			expected: "status stability must not be empty",
+		},
+		{
+			name:     "no signals",
+			mutate:   func(md *metadata) { md.Status.Stability = map[string][]string{"beta": {}} },
+			expected: "status stability must list at least one signal",
+		},
+		{
+			name:     "invalid stability level",
+			mutate:   func(md *metadata) { md.Status.Stability = map[string][]string{"experimental": {signalMetrics}} },
+			expected: `stability level "experimental" is not supported`,
+		},
+		{
+			name:     "unsupported signal",
+			mutate:   func(md *metadata) { md.Status.Stability = map[string][]string{"beta": {signalExtension}} },
+			expected: `signal "extension" is not supported by a receiver, must be one of traces, metrics, logs`,
+		},
+		{
+			name: "duplicated signal",
+			mutate: func(md *metadata) {
+				md.Status.Stability = map[string][]string{"beta": {signalMetrics}, "alpha": {signalMetrics}}
+			},
+			expected: `signal "metrics" has more than one stability level`,
+		},
+		{
+			name:     "receiver without transport",
+			mutate:   func(md *metadata) { md.Telemetry.Transport = "" },
+			expected: "telemetry transport must not be empty for a receiver",
+		},
+		{
+			name:     "exporter with transport",
+			mutate:   func(md *metadata) { md.Class = classExporter },
+			expected: "telemetry transport is only supported by receivers",
+		},
+		{
+			name:     "invalid field name",
+			mutate:   func(md *metadata) { md.Config = []field{{Name: "maxItems", Type: "int"}} },
+			expected: `config field name "maxItems" must be in snake case`,
+		},
+		{
+			name:     "invalid field type",
+			mutate:   func(md *metadata) { md.Config = []field{{Name: "max_items", Type: "float"}} },
+			expected: `config field "max_items" has unsupported type "float", must be one of string, int, bool or duration`,
+		},
+		{
+			name: "invalid field default",
+			mutate: func(md *metadata) {
+				md.Config = []field{{Name: "interval", Type: "duration", Default: stringPtr("10")}}
+			},
+			expected: `config field "interval" has invalid default "10": time: missing unit in duration "10"`,
+		},
+		{
+			name: "duplicated field",
+			mutate: func(md *metadata) {
+				md.Config = []field{{Name: "endpoint", Type: "string"}, {Name: "endpoint", Type: "string"}}
+			},
+			expected: `config field "endpoint" is defined more than once`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := validConfig()
+			tt.mutate(&md)
+			err := md.Validate()
+			if tt.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestDurationExpr(t *testing.T) {
+	tests := map[string]string{
+		"0s":     "0",
+		"2h":     "2 * time.Hour",
+		"90m":    "90 * time.Minute",
+		"1m30s":  "90 * time.Second",
+		"250ms":  "250 * time.Millisecond",
+		"1500us": "1500 * time.Microsecond",
+		"10ns":   "10 * time.Nanosecond",
+	}
+	for in, expected := range tests {
+		f := field{Name: "interval", Type: "duration", Default: &in}
+		assert.Equal(t, expected, f.GoDefault(), in)
+	}
+}
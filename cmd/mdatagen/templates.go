@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	_ "embed"
+	"strings"
+	"text/template"
+)
+
+var (
+	//go:embed templates/status.go.tmpl
+	statusBytes    []byte
+	statusTemplate = parseTemplate("status.go", statusBytes)
+
+	//go:embed templates/documentation.md.tmpl
+	documentationBytes    []byte
+	documentationTemplate = parseTemplate("documentation.md", documentationBytes)
+
+	//go:embed templates/config.go.tmpl
+	configBytes    []byte
+	configTemplate = parseTemplate("config.go", configBytes)
+
+	//go:embed templates/factory.go.tmpl
+	factoryBytes    []byte
+	factoryTemplate = parseTemplate("factory.go", factoryBytes)
+
+	//go:embed templates/receiver.go.tmpl
+	receiverBytes []byte
+	//go:embed templates/processor.go.tmpl
+	processorBytes []byte
+	//go:embed templates/exporter.go.tmpl
+	exporterBytes []byte
+	//go:embed templates/extension.go.tmpl
+	extensionBytes []byte
+
+	// componentTemplates are the templates of the component implementation, by class.
+	componentTemplates = map[string]*template.Template{
+		classReceiver:  parseTemplate("receiver.go", receiverBytes),
+		classProcessor: parseTemplate("processor.go", processorBytes),
+		classExporter:  parseTemplate("exporter.go", exporterBytes),
+		classExtension: parseTemplate("extension.go", extensionBytes),
+	}
+)
+
+var templateFuncs = template.FuncMap{
+	// publicVar capitalizes the first letter, e.g. "Traces" for "traces".
+	"publicVar": func(s string) string {
+		return strings.ToUpper(s[:1]) + s[1:]
+	},
+	// stabilityLevel returns the name of the component.StabilityLevel constant of a level.
+	"stabilityLevel": func(level string) string {
+		return "StabilityLevel" + stabilityLevels[level]
+	},
+	"pdataPackage": func(signal string) string {
+		return pdataSignals[signal].pkg
+	},
+	"pdataType": func(signal string) string {
+		return pdataSignals[signal].pkg + "." + pdataSignals[signal].typ
+	},
+	"pdataVar": func(signal string) string {
+		return pdataSignals[signal].variable
+	},
+}
+
+// pdataSignals describes the pdata type of each signal.
+var pdataSignals = map[string]struct {
+	pkg      string
+	typ      string
+	variable string
+}{
+	signalTraces:  {pkg: "ptrace", typ: "Traces", variable: "td"},
+	signalMetrics: {pkg: "pmetric", typ: "Metrics", variable: "md"},
+	signalLogs:    {pkg: "plog", typ: "Logs", variable: "ld"},
+}
+
+func parseTemplate(name string, bytes []byte) *template.Template {
+	return template.Must(template.New(name).Funcs(templateFuncs).Parse(string(bytes)))
+}
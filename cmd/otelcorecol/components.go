@@ -50,5 +50,32 @@ func components() (component.Factories, error) {
 		return component.Factories{}, err
 	}
 
+	factories.Connectors, err = component.MakeConnectorFactoryMap()
+	if err != nil {
+		return component.Factories{}, err
+	}
+
 	return factories, nil
 }
+
+func manifest() component.Manifest {
+	return component.Manifest{
+		Receivers: map[component.Type]component.ModuleInfo{
+			otlpreceiver.NewFactory().Type(): {Module: "go.opentelemetry.io/collector/receiver/otlpreceiver", Version: "v0.65.0"},
+		},
+		Processors: map[component.Type]component.ModuleInfo{
+			batchprocessor.NewFactory().Type():         {Module: "go.opentelemetry.io/collector/processor/batchprocessor", Version: "v0.65.0"},
+			memorylimiterprocessor.NewFactory().Type(): {Module: "go.opentelemetry.io/collector/processor/memorylimiterprocessor", Version: "v0.65.0"},
+		},
+		Exporters: map[component.Type]component.ModuleInfo{
+			loggingexporter.NewFactory().Type():  {Module: "go.opentelemetry.io/collector/exporter/loggingexporter", Version: "v0.65.0"},
+			otlpexporter.NewFactory().Type():     {Module: "go.opentelemetry.io/collector/exporter/otlpexporter", Version: "v0.65.0"},
+			otlphttpexporter.NewFactory().Type(): {Module: "go.opentelemetry.io/collector/exporter/otlphttpexporter", Version: "v0.65.0"},
+		},
+		Extensions: map[component.Type]component.ModuleInfo{
+			ballastextension.NewFactory().Type(): {Module: "go.opentelemetry.io/collector/extension/ballastextension", Version: "v0.65.0"},
+			zpagesextension.NewFactory().Type():  {Module: "go.opentelemetry.io/collector/extension/zpagesextension", Version: "v0.65.0"},
+		},
+		Connectors: map[component.Type]component.ModuleInfo{},
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 )
 
@@ -26,4 +27,34 @@ func TestValidateConfigs(t *testing.T) {
 	for _, factory := range factories.Extensions {
 		assert.NoError(t, componenttest.CheckConfigStruct(factory.CreateDefaultConfig()))
 	}
+	for _, factory := range factories.Connectors {
+		assert.NoError(t, componenttest.CheckConfigStruct(factory.CreateDefaultConfig()))
+	}
+}
+
+func TestManifest(t *testing.T) {
+	factories, err := components()
+	assert.NoError(t, err)
+
+	m := manifest()
+	for typ := range factories.Receivers {
+		_, ok := m.Module(component.KindReceiver, typ)
+		assert.True(t, ok)
+	}
+	for typ := range factories.Processors {
+		_, ok := m.Module(component.KindProcessor, typ)
+		assert.True(t, ok)
+	}
+	for typ := range factories.Exporters {
+		_, ok := m.Module(component.KindExporter, typ)
+		assert.True(t, ok)
+	}
+	for typ := range factories.Extensions {
+		_, ok := m.Module(component.KindExtension, typ)
+		assert.True(t, ok)
+	}
+	for typ := range factories.Connectors {
+		_, ok := m.Module(component.KindConnector, typ)
+		assert.True(t, ok)
+	}
 }
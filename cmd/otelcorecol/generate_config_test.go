@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service"
+)
+
+func generateConfig(t *testing.T, args ...string) (string, error) {
+	factories, err := components()
+	require.NoError(t, err)
+	cmd := service.NewCommand(service.CollectorSettings{BuildInfo: component.NewDefaultBuildInfo(), Factories: factories})
+	cmd.SetArgs(append([]string{"generate-config"}, args...))
+	out := bytes.NewBufferString("")
+	cmd.SetOut(out)
+	cmd.SetErr(bytes.NewBufferString(""))
+	err = cmd.Execute()
+	return out.String(), err
+}
+
+func TestGenerateConfig(t *testing.T) {
+	out, err := generateConfig(t,
+		"--receivers", "otlp",
+		"--processors", "batch,memory_limiter",
+		"--exporters", "logging,otlp",
+		"--extensions", "memory_ballast,zpages",
+	)
+	require.NoError(t, err)
+
+	// The configuration is loaded and validated before it is written.
+	assert.Contains(t, out, "verbosity: Normal")
+	assert.NotContains(t, out, "loglevel")
+	assert.NotContains(t, out, `endpoint: ""`)
+}
+
+func TestGenerateConfigSettingsToFillIn(t *testing.T) {
+	_, err := generateConfig(t, "--receivers", "otlp", "--exporters", "otlphttp")
+	assert.ErrorContains(t, err, "at least one endpoint must be specified")
+}
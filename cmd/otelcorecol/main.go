@@ -20,6 +20,7 @@ func main() {
 		Command:     "otelcorecol",
 		Description: "Local OpenTelemetry Collector binary, testing only.",
 		Version:     "0.65.0-dev",
+		Manifest:    manifest(),
 	}
 
 	if err := run(service.CollectorSettings{BuildInfo: info, Factories: factories}); err != nil {
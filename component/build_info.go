@@ -25,6 +25,47 @@ type BuildInfo struct {
 
 	// Version string.
 	Version string
+
+	// Manifest lists the Go modules providing the components compiled into the collector. It is generated
+	// by the builder, and empty for the distributions that don't set it.
+	Manifest Manifest `yaml:",omitempty"`
+}
+
+// ModuleInfo describes the Go module providing a component.
+type ModuleInfo struct {
+	// Module is the Go module path, e.g. "go.opentelemetry.io/collector/receiver/otlpreceiver".
+	Module string `yaml:",omitempty"`
+
+	// Version is the version of the Go module, e.g. "v0.65.0".
+	Version string `yaml:",omitempty"`
+}
+
+// Manifest maps the types of the components compiled into the collector, by kind, to the Go modules providing them.
+type Manifest struct {
+	Receivers  map[Type]ModuleInfo `yaml:",omitempty"`
+	Processors map[Type]ModuleInfo `yaml:",omitempty"`
+	Exporters  map[Type]ModuleInfo `yaml:",omitempty"`
+	Extensions map[Type]ModuleInfo `yaml:",omitempty"`
+	Connectors map[Type]ModuleInfo `yaml:",omitempty"`
+}
+
+// Module returns the Go module providing the component of the given kind and type, and false if it is not in the manifest.
+func (m Manifest) Module(kind Kind, componentType Type) (ModuleInfo, bool) {
+	var modules map[Type]ModuleInfo
+	switch kind {
+	case KindReceiver:
+		modules = m.Receivers
+	case KindProcessor:
+		modules = m.Processors
+	case KindExporter:
+		modules = m.Exporters
+	case KindExtension:
+		modules = m.Extensions
+	case KindConnector:
+		modules = m.Connectors
+	}
+	info, ok := modules[componentType]
+	return info, ok
 }
 
 // NewDefaultBuildInfo returns a default BuildInfo.
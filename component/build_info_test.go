@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestModule(t *testing.T) {
+	otlp := ModuleInfo{Module: "go.opentelemetry.io/collector/receiver/otlpreceiver", Version: "v0.65.0"}
+	manifest := Manifest{Receivers: map[Type]ModuleInfo{"otlp": otlp}}
+
+	mod, ok := manifest.Module(KindReceiver, "otlp")
+	assert.True(t, ok)
+	assert.Equal(t, otlp, mod)
+
+	_, ok = manifest.Module(KindExporter, "otlp")
+	assert.False(t, ok)
+
+	_, ok = Manifest{}.Module(KindReceiver, "otlp")
+	assert.False(t, ok)
+}
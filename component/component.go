@@ -49,9 +49,10 @@ type Component interface {
 	//
 	// If the component needs to perform a long-running starting operation then it is recommended
 	// that Start() returns quickly and the long-running operation is performed in background.
-	// In that case make sure that the long-running operation does not use the context passed
-	// to Start() function since that context will be cancelled soon and can abort the long-running
-	// operation. Create a new context from the context.Background() for long-running operations.
+	//
+	// The context passed to Start() is cancelled once the shutdown of the host begins, before
+	// Shutdown() is called, so that background operations can use it and stop early. It carries
+	// the HostServices of the host, see HostServicesFromContext.
 	Start(ctx context.Context, host Host) error
 
 	// Shutdown is invoked during service shutdown. After Shutdown() is called, if the component
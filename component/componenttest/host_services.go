@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package componenttest // import "go.opentelemetry.io/collector/component/componenttest"
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// NewNopHostServices returns host services for tests, with a FakeClock at the Unix epoch, a deterministic
+// random source and nop telemetry settings.
+func NewNopHostServices() component.HostServices {
+	return component.HostServices{
+		Clock:             NewFakeClock(time.Unix(0, 0)),
+		Random:            NewFakeRandom(0),
+		TelemetrySettings: NewNopTelemetrySettings(),
+	}
+}
+
+// FakeClock is a component.Clock whose time only changes when it is advanced.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeClockWaiter
+}
+
+type fakeClockWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+var _ component.Clock = (*FakeClock)(nil)
+
+// NewFakeClock returns a FakeClock at the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel receiving the time of the clock once it is advanced by at least d.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeClockWaiter{deadline: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the time of the clock forward by d, and fires the channels returned by After that are due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	waiters := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.deadline.After(c.now) {
+			waiters = append(waiters, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = waiters
+}
+
+// NewFakeRandom returns a component.Random generating the same numbers for the same seed.
+func NewFakeRandom(seed int64) component.Random {
+	return &fakeRandom{rand: rand.New(rand.NewSource(seed))} // #nosec G404 not used for security.
+}
+
+type fakeRandom struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func (r *fakeRandom) Int63() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rand.Int63()
+}
+
+func (r *fakeRandom) Float64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rand.Float64()
+}
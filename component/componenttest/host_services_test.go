@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package componenttest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestFakeClock(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	assert.Equal(t, time.Unix(0, 0), clock.Now())
+
+	assert.Equal(t, time.Unix(0, 0), <-clock.After(0))
+	after := clock.After(2 * time.Second)
+	clock.Advance(time.Second)
+	assert.Equal(t, time.Unix(1, 0), clock.Now())
+	assert.Len(t, after, 0)
+	clock.Advance(time.Second)
+	assert.Equal(t, time.Unix(2, 0), <-after)
+}
+
+func TestFakeRandom(t *testing.T) {
+	r1, r2 := NewFakeRandom(42), NewFakeRandom(42)
+	assert.Equal(t, r1.Int63(), r2.Int63())
+	assert.Equal(t, r1.Float64(), r2.Float64())
+}
+
+func TestNewNopHostServices(t *testing.T) {
+	ctx := component.ContextWithHostServices(context.Background(), NewNopHostServices())
+	services := component.HostServicesFromContext(ctx)
+	assert.Equal(t, time.Unix(0, 0), services.Clock.Now())
+	assert.NotNil(t, services.TelemetrySettings.Logger)
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package componenttest // import "go.opentelemetry.io/collector/component/componenttest"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+// NewNopConnectorCreateSettings returns a new nop settings for Create* functions of a ConnectorFactory.
+func NewNopConnectorCreateSettings() component.ConnectorCreateSettings {
+	return component.ConnectorCreateSettings{
+		TelemetrySettings: NewNopTelemetrySettings(),
+		BuildInfo:         component.NewDefaultBuildInfo(),
+	}
+}
+
+type nopConnectorConfig struct {
+	config.ConnectorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+}
+
+// NewNopConnectorFactory returns a component.ConnectorFactory that constructs nop connectors,
+// which drop all the data they consume.
+func NewNopConnectorFactory() component.ConnectorFactory {
+	return component.NewConnectorFactory(
+		"nop",
+		func() component.Config {
+			return &nopConnectorConfig{
+				ConnectorSettings: config.NewConnectorSettings(component.NewID("nop")),
+			}
+		},
+		component.WithTracesToTraces(createTracesToTracesConnector, component.StabilityLevelStable),
+		component.WithTracesToMetrics(createTracesToMetricsConnector, component.StabilityLevelStable),
+		component.WithTracesToLogs(createTracesToLogsConnector, component.StabilityLevelStable),
+		component.WithMetricsToTraces(createMetricsToTracesConnector, component.StabilityLevelStable),
+		component.WithMetricsToMetrics(createMetricsToMetricsConnector, component.StabilityLevelStable),
+		component.WithMetricsToLogs(createMetricsToLogsConnector, component.StabilityLevelStable),
+		component.WithLogsToTraces(createLogsToTracesConnector, component.StabilityLevelStable),
+		component.WithLogsToMetrics(createLogsToMetricsConnector, component.StabilityLevelStable),
+		component.WithLogsToLogs(createLogsToLogsConnector, component.StabilityLevelStable),
+	)
+}
+
+func createTracesToTracesConnector(context.Context, component.ConnectorCreateSettings, component.Config, consumer.Traces) (component.TracesConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createTracesToMetricsConnector(context.Context, component.ConnectorCreateSettings, component.Config, consumer.Metrics) (component.TracesConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createTracesToLogsConnector(context.Context, component.ConnectorCreateSettings, component.Config, consumer.Logs) (component.TracesConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createMetricsToTracesConnector(context.Context, component.ConnectorCreateSettings, component.Config, consumer.Traces) (component.MetricsConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createMetricsToMetricsConnector(context.Context, component.ConnectorCreateSettings, component.Config, consumer.Metrics) (component.MetricsConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createMetricsToLogsConnector(context.Context, component.ConnectorCreateSettings, component.Config, consumer.Logs) (component.MetricsConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createLogsToTracesConnector(context.Context, component.ConnectorCreateSettings, component.Config, consumer.Traces) (component.LogsConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createLogsToMetricsConnector(context.Context, component.ConnectorCreateSettings, component.Config, consumer.Metrics) (component.LogsConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createLogsToLogsConnector(context.Context, component.ConnectorCreateSettings, component.Config, consumer.Logs) (component.LogsConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+var nopConnectorInstance = &nopConnector{
+	Consumer: consumertest.NewNop(),
+}
+
+// nopConnector drops all the data it consumes.
+type nopConnector struct {
+	nopComponent
+	consumertest.Consumer
+}
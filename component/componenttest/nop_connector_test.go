@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package componenttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestNewNopConnectorFactory(t *testing.T) {
+	factory := NewNopConnectorFactory()
+	require.NotNil(t, factory)
+	assert.Equal(t, component.Type("nop"), factory.Type())
+	cfg := factory.CreateDefaultConfig()
+	assert.Equal(t, &nopConnectorConfig{ConnectorSettings: config.NewConnectorSettings(component.NewID("nop"))}, cfg)
+
+	tracesToMetrics, err := factory.CreateTracesToMetrics(context.Background(), NewNopConnectorCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.NoError(t, tracesToMetrics.Start(context.Background(), NewNopHost()))
+	assert.NoError(t, tracesToMetrics.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.NoError(t, tracesToMetrics.Shutdown(context.Background()))
+
+	metricsToLogs, err := factory.CreateMetricsToLogs(context.Background(), NewNopConnectorCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.NoError(t, metricsToLogs.Start(context.Background(), NewNopHost()))
+	assert.NoError(t, metricsToLogs.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	assert.NoError(t, metricsToLogs.Shutdown(context.Background()))
+
+	logsToTraces, err := factory.CreateLogsToTraces(context.Background(), NewNopConnectorCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.NoError(t, logsToTraces.Start(context.Background(), NewNopHost()))
+	assert.NoError(t, logsToTraces.ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.NoError(t, logsToTraces.Shutdown(context.Background()))
+}
@@ -26,9 +26,10 @@ import (
 // NewNopTelemetrySettings returns a new nop telemetry settings for Create* functions.
 func NewNopTelemetrySettings() component.TelemetrySettings {
 	return component.TelemetrySettings{
-		Logger:         zap.NewNop(),
-		TracerProvider: trace.NewNoopTracerProvider(),
-		MeterProvider:  metric.NewNoopMeterProvider(),
-		MetricsLevel:   configtelemetry.LevelNone,
+		Logger:                zap.NewNop(),
+		TracerProvider:        trace.NewNoopTracerProvider(),
+		MeterProvider:         metric.NewNoopMeterProvider(),
+		MetricsLevel:          configtelemetry.LevelNone,
+		ReportComponentStatus: func(*component.StatusEvent) {},
 	}
 }
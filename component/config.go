@@ -15,7 +15,10 @@
 package component // import "go.opentelemetry.io/collector/component"
 
 import (
+	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"go.uber.org/multierr"
 
@@ -32,9 +35,90 @@ type Config interface {
 	privateConfig()
 }
 
+// NewCreateDefaultConfigFunc returns a CreateDefaultConfigFunc that returns a deep copy of defaultCfg.
+// The maps, slices and pointers held by the exported fields of defaultCfg are copied, so modifying
+// a returned config never affects defaultCfg; unexported fields are copied as is.
+func NewCreateDefaultConfigFunc[CfgT any, PtrT interface {
+	*CfgT
+	Config
+}](defaultCfg CfgT) CreateDefaultConfigFunc {
+	return func() Config {
+		cfg := deepCopy(reflect.ValueOf(defaultCfg)).Interface().(CfgT)
+		return PtrT(&cfg)
+	}
+}
+
+// deepCopy returns a copy of v that shares no map, slice or pointer with v, except through unexported fields.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(deepCopy(iter.Key()), deepCopy(iter.Value()))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+// castConfig returns cfg as a *CfgT, or an error if cfg has a different type.
+func castConfig[CfgT any](cfg Config) (*CfgT, error) {
+	typedCfg, ok := interface{}(cfg).(*CfgT)
+	if !ok {
+		return nil, fmt.Errorf("invalid config type %T, expected %T", cfg, typedCfg)
+	}
+	return typedCfg, nil
+}
+
 // As interface types are only used for static typing, a common idiom to find the reflection Type
 // for an interface type Foo is to use a *Foo value.
-var configValidatorType = reflect.TypeOf((*ConfigValidator)(nil)).Elem()
+var (
+	configValidatorType = reflect.TypeOf((*ConfigValidator)(nil)).Elem()
+	configWarningsType  = reflect.TypeOf((*ConfigWarnings)(nil)).Elem()
+)
 
 // UnmarshalConfig helper function to UnmarshalConfig a Config.
 // It checks if the config implements confmap.Unmarshaler and uses that if available,
@@ -53,70 +137,166 @@ type ConfigValidator interface {
 	Validate() error
 }
 
+// ConfigWarning is a warning about a valid configuration, e.g. the use of a deprecated field or of a risky value.
+type ConfigWarning struct {
+	// Path is the path of the value in the config the warning is about, e.g. "protocols::grpc::endpoint".
+	// The path is relative to the configuration implementing ConfigWarnings, and is filled in by
+	// CollectConfigWarnings for nested configurations.
+	Path string
+
+	// Message describes the warning, and how to address it.
+	Message string
+}
+
+// ConfigWarnings defines an optional interface for configurations to implement to report warnings.
+// The service collects the warnings of all the components after validating the configuration, and
+// logs them once at startup.
+type ConfigWarnings interface {
+	// Warnings returns the warnings about the configuration. It is only called on valid configurations.
+	Warnings() []ConfigWarning
+}
+
 // ValidateConfig validates a config, by doing this:
 //   - Call Validate on the config itself if the config implements ConfigValidator.
+//   - Recursively do the same for the exported fields of structs, and for the elements
+//     of slices, arrays and maps, following pointers and interfaces.
+//
+// Errors of nested values are prefixed with their path in the config, e.g. "protocols::grpc: <error>".
 func ValidateConfig(cfg Config) error {
-	return validate(reflect.ValueOf(cfg))
+	return validate(reflect.ValueOf(cfg), "")
+}
+
+func validate(v reflect.Value, path string) error {
+	var errs error
+	walkConfig(v, path, func(v reflect.Value, path string) {
+		errs = multierr.Append(errs, callValidateIfPossible(v, path))
+	})
+	return errs
+}
+
+// CollectConfigWarnings collects the warnings of a config, the same way ValidateConfig validates it:
+//   - Call Warnings on the config itself if the config implements ConfigWarnings.
+//   - Recursively do the same for the exported fields of structs, and for the elements
+//     of slices, arrays and maps, following pointers and interfaces.
+//
+// The warnings of nested values are prefixed with their path in the config.
+func CollectConfigWarnings(cfg Config) []ConfigWarning {
+	return collectWarnings(reflect.ValueOf(cfg), "")
 }
 
-func validate(v reflect.Value) error {
-	// Validate the value itself.
+func collectWarnings(v reflect.Value, path string) []ConfigWarning {
+	var warnings []ConfigWarning
+	walkConfig(v, path, func(v reflect.Value, path string) {
+		w, ok := asInterface(v, configWarningsType)
+		if !ok {
+			return
+		}
+		for _, warning := range w.(ConfigWarnings).Warnings() {
+			warning.Path = joinPath(path, warning.Path)
+			warnings = append(warnings, warning)
+		}
+	})
+	return warnings
+}
+
+// walkConfig calls visit on v, then recursively on the exported fields of structs, and on the keys and
+// the elements of slices, arrays and maps, following pointers and interfaces.
+func walkConfig(v reflect.Value, path string, visit func(v reflect.Value, path string)) {
 	switch v.Kind() {
 	case reflect.Invalid:
-		return nil
-	case reflect.Ptr:
-		return validate(v.Elem())
+	case reflect.Ptr, reflect.Interface:
+		walkConfig(v.Elem(), path, visit)
 	case reflect.Struct:
-		var errs error
-		errs = multierr.Append(errs, callValidateIfPossible(v))
+		visit(v, path)
 		// Reflect on the pointed data and check each of its fields.
 		for i := 0; i < v.NumField(); i++ {
-			if !v.Type().Field(i).IsExported() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
 				continue
 			}
-			errs = multierr.Append(errs, validate(v.Field(i)))
+			walkConfig(v.Field(i), fieldPath(path, field), visit)
 		}
-		return errs
 	case reflect.Slice, reflect.Array:
-		var errs error
-		errs = multierr.Append(errs, callValidateIfPossible(v))
+		visit(v, path)
 		// Reflect on the pointed data and check each of its fields.
 		for i := 0; i < v.Len(); i++ {
-			errs = multierr.Append(errs, validate(v.Index(i)))
+			walkConfig(v.Index(i), joinPath(path, strconv.Itoa(i)), visit)
 		}
-		return errs
 	case reflect.Map:
-		var errs error
-		errs = multierr.Append(errs, callValidateIfPossible(v))
+		visit(v, path)
 		iter := v.MapRange()
 		for iter.Next() {
-			errs = multierr.Append(errs, validate(iter.Key()))
-			errs = multierr.Append(errs, validate(iter.Value()))
+			keyPath := joinPath(path, mapKeyName(iter.Key()))
+			walkConfig(iter.Key(), keyPath, visit)
+			walkConfig(iter.Value(), keyPath, visit)
 		}
-		return errs
 	default:
-		return callValidateIfPossible(v)
+		visit(v, path)
 	}
 }
 
-func callValidateIfPossible(v reflect.Value) error {
-	// If the value type implements ConfigValidator just call Validate
-	if v.Type().Implements(configValidatorType) {
-		return v.Interface().(ConfigValidator).Validate()
+// mapKeyName returns the name of a map key in the path, following pointers.
+func mapKeyName(key reflect.Value) string {
+	for key.Kind() == reflect.Ptr && !key.IsNil() {
+		key = key.Elem()
 	}
+	return fmt.Sprint(key.Interface())
+}
 
-	// If the pointer type implements ConfigValidator call Validate on the pointer to the current value.
-	if reflect.PtrTo(v.Type()).Implements(configValidatorType) {
+// fieldPath returns the path of a struct field, using its name in the config.
+// Squashed fields have the same path as their parent.
+func fieldPath(path string, field reflect.StructField) string {
+	name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+	if name == "" {
+		if opts == "squash" {
+			return path
+		}
+		name = strings.ToLower(field.Name)
+	}
+	return joinPath(path, name)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + confmap.KeyDelimiter + name
+}
+
+func callValidateIfPossible(v reflect.Value, path string) error {
+	err := callValidate(v)
+	if err != nil && path != "" {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return err
+}
+
+func callValidate(v reflect.Value) error {
+	if cv, ok := asInterface(v, configValidatorType); ok {
+		return cv.(ConfigValidator).Validate()
+	}
+	return nil
+}
+
+// asInterface returns the value, or a pointer to the value, implementing the given interface type.
+func asInterface(v reflect.Value, iface reflect.Type) (interface{}, bool) {
+	// If the value type implements the interface just return it.
+	if v.Type().Implements(iface) {
+		return v.Interface(), true
+	}
+
+	// If the pointer type implements the interface return a pointer to the current value.
+	if reflect.PtrTo(v.Type()).Implements(iface) {
 		// If not addressable, then create a new *V pointer and set the value to current v.
 		if !v.CanAddr() {
 			pv := reflect.New(reflect.PtrTo(v.Type()).Elem())
 			pv.Elem().Set(v)
 			v = pv.Elem()
 		}
-		return v.Addr().Interface().(ConfigValidator).Validate()
+		return v.Addr().Interface(), true
 	}
 
-	return nil
+	return nil, false
 }
 
 // Type is the component type as it is used in the config.
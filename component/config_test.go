@@ -16,6 +16,7 @@ package component
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -124,84 +125,207 @@ func TestValidateConfig(t *testing.T) {
 		{
 			name:     "child struct",
 			cfg:      configChildStruct{Child: errConfig{err: errors.New("child struct")}},
-			expected: errors.New("child struct"),
+			expected: fmt.Errorf("child: %w", errors.New("child struct")),
 		},
 		{
 			name:     "pointer child struct",
 			cfg:      &configChildStruct{Child: errConfig{err: errors.New("pointer child struct")}},
-			expected: errors.New("pointer child struct"),
+			expected: fmt.Errorf("child: %w", errors.New("pointer child struct")),
 		},
 		{
 			name:     "child struct pointer",
 			cfg:      &configChildStruct{ChildPtr: &errConfig{err: errors.New("child struct pointer")}},
-			expected: errors.New("child struct pointer"),
+			expected: fmt.Errorf("childptr: %w", errors.New("child struct pointer")),
 		},
 		{
 			name:     "child slice",
 			cfg:      configChildSlice{Child: []errConfig{{}, {err: errors.New("child slice")}}},
-			expected: errors.New("child slice"),
+			expected: fmt.Errorf("child::1: %w", errors.New("child slice")),
 		},
 		{
 			name:     "pointer child slice",
 			cfg:      &configChildSlice{Child: []errConfig{{}, {err: errors.New("pointer child slice")}}},
-			expected: errors.New("pointer child slice"),
+			expected: fmt.Errorf("child::1: %w", errors.New("pointer child slice")),
 		},
 		{
 			name:     "child slice pointer",
 			cfg:      &configChildSlice{ChildPtr: []*errConfig{{}, {err: errors.New("child slice pointer")}}},
-			expected: errors.New("child slice pointer"),
+			expected: fmt.Errorf("childptr::1: %w", errors.New("child slice pointer")),
 		},
 		{
 			name:     "child map value",
 			cfg:      configChildMapValue{Child: map[string]errConfig{"test": {err: errors.New("child map")}}},
-			expected: errors.New("child map"),
+			expected: fmt.Errorf("child::test: %w", errors.New("child map")),
 		},
 		{
 			name:     "pointer child map value",
 			cfg:      &configChildMapValue{Child: map[string]errConfig{"test": {err: errors.New("pointer child map")}}},
-			expected: errors.New("pointer child map"),
+			expected: fmt.Errorf("child::test: %w", errors.New("pointer child map")),
 		},
 		{
 			name:     "child map value pointer",
 			cfg:      &configChildMapValue{ChildPtr: map[string]*errConfig{"test": {err: errors.New("child map pointer")}}},
-			expected: errors.New("child map pointer"),
+			expected: fmt.Errorf("childptr::test: %w", errors.New("child map pointer")),
 		},
 		{
 			name:     "child map key",
 			cfg:      configChildMapKey{Child: map[errType]string{"child map key": ""}},
-			expected: errors.New("child map key"),
+			expected: fmt.Errorf("child::child map key: %w", errors.New("child map key")),
 		},
 		{
 			name:     "pointer child map key",
 			cfg:      &configChildMapKey{Child: map[errType]string{"pointer child map key": ""}},
-			expected: errors.New("pointer child map key"),
+			expected: fmt.Errorf("child::pointer child map key: %w", errors.New("pointer child map key")),
 		},
 		{
 			name:     "child map key pointer",
 			cfg:      &configChildMapKey{ChildPtr: map[*errType]string{newErrType("child map key pointer"): ""}},
-			expected: errors.New("child map key pointer"),
+			expected: fmt.Errorf("childptr::child map key pointer: %w", errors.New("child map key pointer")),
 		},
 		{
 			name:     "child type",
 			cfg:      configChildTypeDef{Child: "child type"},
-			expected: errors.New("child type"),
+			expected: fmt.Errorf("child: %w", errors.New("child type")),
 		},
 		{
 			name:     "pointer child type",
 			cfg:      &configChildTypeDef{Child: "pointer child type"},
-			expected: errors.New("pointer child type"),
+			expected: fmt.Errorf("child: %w", errors.New("pointer child type")),
 		},
 		{
 			name:     "child type pointer",
 			cfg:      &configChildTypeDef{ChildPtr: newErrType("child type pointer")},
-			expected: errors.New("child type pointer"),
+			expected: fmt.Errorf("childptr: %w", errors.New("child type pointer")),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validate(reflect.ValueOf(tt.cfg))
+			err := validate(reflect.ValueOf(tt.cfg), "")
 			assert.Equal(t, tt.expected, err)
 		})
 	}
 }
+
+type configEmbedded struct {
+	Embedded errConfig `mapstructure:"embedded"`
+}
+
+type configWithTags struct {
+	Squashed  configEmbedded               `mapstructure:",squash"`
+	Protocols map[string]*configChildSlice `mapstructure:"protocols"`
+	Endpoint  errType                      `mapstructure:"endpoint,omitempty"`
+}
+
+func TestValidateConfigPath(t *testing.T) {
+	cfg := &configWithTags{
+		Squashed: configEmbedded{Embedded: errConfig{err: errors.New("embedded")}},
+		Protocols: map[string]*configChildSlice{
+			"grpc": {Child: []errConfig{{err: errors.New("protocol")}}},
+		},
+		Endpoint: "endpoint",
+	}
+	assert.EqualError(t, validate(reflect.ValueOf(cfg), ""), "embedded: embedded; protocols::grpc::child::0: protocol; endpoint: endpoint")
+}
+
+type warnConfig struct {
+	warnings []ConfigWarning
+}
+
+func (w warnConfig) Warnings() []ConfigWarning {
+	return w.warnings
+}
+
+type configWithWarnings struct {
+	warnConfig
+	Exporter  *warnConfig           `mapstructure:"exporter"`
+	Protocols map[string]warnConfig `mapstructure:"protocols"`
+	Endpoint  string                `mapstructure:"endpoint"`
+}
+
+func TestCollectConfigWarnings(t *testing.T) {
+	cfg := &configWithWarnings{
+		warnConfig: warnConfig{warnings: []ConfigWarning{{Message: "root"}}},
+		Exporter:   &warnConfig{warnings: []ConfigWarning{{Path: "timeout", Message: "risky value"}}},
+		Protocols: map[string]warnConfig{
+			"grpc": {warnings: []ConfigWarning{{Path: "endpoint", Message: "deprecated"}}},
+		},
+	}
+	assert.Equal(t, []ConfigWarning{
+		{Message: "root"},
+		{Path: "exporter::timeout", Message: "risky value"},
+		{Path: "protocols::grpc::endpoint", Message: "deprecated"},
+	}, collectWarnings(reflect.ValueOf(cfg), ""))
+
+	assert.Empty(t, collectWarnings(reflect.ValueOf(&configWithTags{}), ""))
+}
+
+type nestedConfig struct {
+	Endpoint string
+	Headers  map[string][]string
+	Ports    []int
+	Child    *nestedConfig
+	Any      interface{}
+}
+
+func TestDeepCopy(t *testing.T) {
+	orig := nestedConfig{
+		Endpoint: "localhost:4317",
+		Headers:  map[string][]string{"key": {"value"}},
+		Ports:    []int{1, 2},
+		Child:    &nestedConfig{Ports: []int{3}},
+		Any:      map[string]string{"key": "value"},
+	}
+	cp := deepCopy(reflect.ValueOf(orig)).Interface().(nestedConfig)
+	assert.Equal(t, orig, cp)
+
+	cp.Headers["key"][0] = "changed"
+	cp.Headers["other"] = nil
+	cp.Ports[0] = 10
+	cp.Child.Ports[0] = 30
+	cp.Any.(map[string]string)["key"] = "changed"
+
+	assert.Equal(t, map[string][]string{"key": {"value"}}, orig.Headers)
+	assert.Equal(t, []int{1, 2}, orig.Ports)
+	assert.Equal(t, []int{3}, orig.Child.Ports)
+	assert.Equal(t, map[string]string{"key": "value"}, orig.Any)
+}
+
+type typedConfig struct {
+	Config
+	Headers map[string]string
+	Ports   []int
+}
+
+type otherConfig struct {
+	Config
+}
+
+func TestNewCreateDefaultConfigFunc(t *testing.T) {
+	defaultCfg := typedConfig{Headers: map[string]string{"key": "value"}, Ports: []int{1}}
+	createDefaultConfig := NewCreateDefaultConfigFunc(defaultCfg)
+
+	cfg, ok := createDefaultConfig().(*typedConfig)
+	assert.True(t, ok)
+	assert.Equal(t, &defaultCfg, cfg)
+	assert.NotSame(t, createDefaultConfig(), createDefaultConfig())
+
+	cfg.Headers["key"] = "changed"
+	cfg.Ports[0] = 2
+	assert.Equal(t, map[string]string{"key": "value"}, defaultCfg.Headers)
+	assert.Equal(t, []int{1}, defaultCfg.Ports)
+	assert.Equal(t, &defaultCfg, createDefaultConfig())
+}
+
+func TestCastConfig(t *testing.T) {
+	cfg := &typedConfig{}
+	typedCfg, err := castConfig[typedConfig](cfg)
+	assert.NoError(t, err)
+	assert.Same(t, cfg, typedCfg)
+
+	_, err = castConfig[typedConfig](&otherConfig{})
+	assert.EqualError(t, err, "invalid config type *component.otherConfig, expected *component.typedConfig")
+
+	_, err = castConfig[typedConfig](nil)
+	assert.EqualError(t, err, "invalid config type <nil>, expected *component.typedConfig")
+}
@@ -0,0 +1,392 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component // import "go.opentelemetry.io/collector/component"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// TracesConnector is a Connector that can consume traces, as the exporter of a traces pipeline.
+type TracesConnector interface {
+	Component
+	consumer.Traces
+}
+
+// MetricsConnector is a Connector that can consume metrics, as the exporter of a metrics pipeline.
+type MetricsConnector interface {
+	Component
+	consumer.Metrics
+}
+
+// LogsConnector is a Connector that can consume logs, as the exporter of a logs pipeline.
+type LogsConnector interface {
+	Component
+	consumer.Logs
+}
+
+// ConnectorCreateSettings configures Connector creators.
+type ConnectorCreateSettings struct {
+	// ID returns the ID of the component that will be created.
+	ID ID
+
+	TelemetrySettings
+
+	// BuildInfo can be used by components for informational purposes
+	BuildInfo BuildInfo
+}
+
+// ConnectorFactory is factory interface for connectors.
+//
+// A connector joins two pipelines: it is used as an exporter in the pipelines that send data to it,
+// and as a receiver in the pipelines it sends data to. The data type of these pipelines may differ,
+// so a connector is created for every pair of exporter and receiver data types.
+//
+// This interface cannot be directly implemented. Implementations must
+// use the NewConnectorFactory to implement it.
+type ConnectorFactory interface {
+	Factory
+
+	// CreateTracesToTraces creates a TracesConnector that consumes traces and sends traces to nextConsumer.
+	// If the connector does not support this combination or if the config is not valid,
+	// an error will be returned instead.
+	CreateTracesToTraces(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Traces) (TracesConnector, error)
+
+	// TracesToTracesStability gets the stability level of the TracesToTraces connector.
+	TracesToTracesStability() StabilityLevel
+
+	// CreateTracesToMetrics creates a TracesConnector that consumes traces and sends metrics to nextConsumer.
+	// If the connector does not support this combination or if the config is not valid,
+	// an error will be returned instead.
+	CreateTracesToMetrics(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Metrics) (TracesConnector, error)
+
+	// TracesToMetricsStability gets the stability level of the TracesToMetrics connector.
+	TracesToMetricsStability() StabilityLevel
+
+	// CreateTracesToLogs creates a TracesConnector that consumes traces and sends logs to nextConsumer.
+	// If the connector does not support this combination or if the config is not valid,
+	// an error will be returned instead.
+	CreateTracesToLogs(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Logs) (TracesConnector, error)
+
+	// TracesToLogsStability gets the stability level of the TracesToLogs connector.
+	TracesToLogsStability() StabilityLevel
+
+	// CreateMetricsToTraces creates a MetricsConnector that consumes metrics and sends traces to nextConsumer.
+	// If the connector does not support this combination or if the config is not valid,
+	// an error will be returned instead.
+	CreateMetricsToTraces(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Traces) (MetricsConnector, error)
+
+	// MetricsToTracesStability gets the stability level of the MetricsToTraces connector.
+	MetricsToTracesStability() StabilityLevel
+
+	// CreateMetricsToMetrics creates a MetricsConnector that consumes metrics and sends metrics to nextConsumer.
+	// If the connector does not support this combination or if the config is not valid,
+	// an error will be returned instead.
+	CreateMetricsToMetrics(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Metrics) (MetricsConnector, error)
+
+	// MetricsToMetricsStability gets the stability level of the MetricsToMetrics connector.
+	MetricsToMetricsStability() StabilityLevel
+
+	// CreateMetricsToLogs creates a MetricsConnector that consumes metrics and sends logs to nextConsumer.
+	// If the connector does not support this combination or if the config is not valid,
+	// an error will be returned instead.
+	CreateMetricsToLogs(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Logs) (MetricsConnector, error)
+
+	// MetricsToLogsStability gets the stability level of the MetricsToLogs connector.
+	MetricsToLogsStability() StabilityLevel
+
+	// CreateLogsToTraces creates a LogsConnector that consumes logs and sends traces to nextConsumer.
+	// If the connector does not support this combination or if the config is not valid,
+	// an error will be returned instead.
+	CreateLogsToTraces(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Traces) (LogsConnector, error)
+
+	// LogsToTracesStability gets the stability level of the LogsToTraces connector.
+	LogsToTracesStability() StabilityLevel
+
+	// CreateLogsToMetrics creates a LogsConnector that consumes logs and sends metrics to nextConsumer.
+	// If the connector does not support this combination or if the config is not valid,
+	// an error will be returned instead.
+	CreateLogsToMetrics(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Metrics) (LogsConnector, error)
+
+	// LogsToMetricsStability gets the stability level of the LogsToMetrics connector.
+	LogsToMetricsStability() StabilityLevel
+
+	// CreateLogsToLogs creates a LogsConnector that consumes logs and sends logs to nextConsumer.
+	// If the connector does not support this combination or if the config is not valid,
+	// an error will be returned instead.
+	CreateLogsToLogs(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Logs) (LogsConnector, error)
+
+	// LogsToLogsStability gets the stability level of the LogsToLogs connector.
+	LogsToLogsStability() StabilityLevel
+}
+
+// ConnectorFactoryOption apply changes to ConnectorOptions.
+type ConnectorFactoryOption interface {
+	// applyConnectorFactoryOption applies the option.
+	applyConnectorFactoryOption(o *connectorFactory)
+}
+
+var _ ConnectorFactoryOption = (*connectorFactoryOptionFunc)(nil)
+
+// connectorFactoryOptionFunc is a ConnectorFactoryOption created through a function.
+type connectorFactoryOptionFunc func(*connectorFactory)
+
+func (f connectorFactoryOptionFunc) applyConnectorFactoryOption(o *connectorFactory) {
+	f(o)
+}
+
+// CreateTracesToTracesFunc is the equivalent of ConnectorFactory.CreateTracesToTraces().
+type CreateTracesToTracesFunc func(context.Context, ConnectorCreateSettings, Config, consumer.Traces) (TracesConnector, error)
+
+// CreateTracesToTraces implements ConnectorFactory.CreateTracesToTraces().
+func (f CreateTracesToTracesFunc) CreateTracesToTraces(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Traces) (TracesConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateTracesToMetricsFunc is the equivalent of ConnectorFactory.CreateTracesToMetrics().
+type CreateTracesToMetricsFunc func(context.Context, ConnectorCreateSettings, Config, consumer.Metrics) (TracesConnector, error)
+
+// CreateTracesToMetrics implements ConnectorFactory.CreateTracesToMetrics().
+func (f CreateTracesToMetricsFunc) CreateTracesToMetrics(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Metrics) (TracesConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateTracesToLogsFunc is the equivalent of ConnectorFactory.CreateTracesToLogs().
+type CreateTracesToLogsFunc func(context.Context, ConnectorCreateSettings, Config, consumer.Logs) (TracesConnector, error)
+
+// CreateTracesToLogs implements ConnectorFactory.CreateTracesToLogs().
+func (f CreateTracesToLogsFunc) CreateTracesToLogs(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Logs) (TracesConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateMetricsToTracesFunc is the equivalent of ConnectorFactory.CreateMetricsToTraces().
+type CreateMetricsToTracesFunc func(context.Context, ConnectorCreateSettings, Config, consumer.Traces) (MetricsConnector, error)
+
+// CreateMetricsToTraces implements ConnectorFactory.CreateMetricsToTraces().
+func (f CreateMetricsToTracesFunc) CreateMetricsToTraces(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Traces) (MetricsConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateMetricsToMetricsFunc is the equivalent of ConnectorFactory.CreateMetricsToMetrics().
+type CreateMetricsToMetricsFunc func(context.Context, ConnectorCreateSettings, Config, consumer.Metrics) (MetricsConnector, error)
+
+// CreateMetricsToMetrics implements ConnectorFactory.CreateMetricsToMetrics().
+func (f CreateMetricsToMetricsFunc) CreateMetricsToMetrics(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Metrics) (MetricsConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateMetricsToLogsFunc is the equivalent of ConnectorFactory.CreateMetricsToLogs().
+type CreateMetricsToLogsFunc func(context.Context, ConnectorCreateSettings, Config, consumer.Logs) (MetricsConnector, error)
+
+// CreateMetricsToLogs implements ConnectorFactory.CreateMetricsToLogs().
+func (f CreateMetricsToLogsFunc) CreateMetricsToLogs(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Logs) (MetricsConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateLogsToTracesFunc is the equivalent of ConnectorFactory.CreateLogsToTraces().
+type CreateLogsToTracesFunc func(context.Context, ConnectorCreateSettings, Config, consumer.Traces) (LogsConnector, error)
+
+// CreateLogsToTraces implements ConnectorFactory.CreateLogsToTraces().
+func (f CreateLogsToTracesFunc) CreateLogsToTraces(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Traces) (LogsConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateLogsToMetricsFunc is the equivalent of ConnectorFactory.CreateLogsToMetrics().
+type CreateLogsToMetricsFunc func(context.Context, ConnectorCreateSettings, Config, consumer.Metrics) (LogsConnector, error)
+
+// CreateLogsToMetrics implements ConnectorFactory.CreateLogsToMetrics().
+func (f CreateLogsToMetricsFunc) CreateLogsToMetrics(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Metrics) (LogsConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateLogsToLogsFunc is the equivalent of ConnectorFactory.CreateLogsToLogs().
+type CreateLogsToLogsFunc func(context.Context, ConnectorCreateSettings, Config, consumer.Logs) (LogsConnector, error)
+
+// CreateLogsToLogs implements ConnectorFactory.CreateLogsToLogs().
+func (f CreateLogsToLogsFunc) CreateLogsToLogs(ctx context.Context, set ConnectorCreateSettings, cfg Config, nextConsumer consumer.Logs) (LogsConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+type connectorFactory struct {
+	baseFactory
+	CreateTracesToTracesFunc
+	tracesToTracesStabilityLevel StabilityLevel
+	CreateTracesToMetricsFunc
+	tracesToMetricsStabilityLevel StabilityLevel
+	CreateTracesToLogsFunc
+	tracesToLogsStabilityLevel StabilityLevel
+	CreateMetricsToTracesFunc
+	metricsToTracesStabilityLevel StabilityLevel
+	CreateMetricsToMetricsFunc
+	metricsToMetricsStabilityLevel StabilityLevel
+	CreateMetricsToLogsFunc
+	metricsToLogsStabilityLevel StabilityLevel
+	CreateLogsToTracesFunc
+	logsToTracesStabilityLevel StabilityLevel
+	CreateLogsToMetricsFunc
+	logsToMetricsStabilityLevel StabilityLevel
+	CreateLogsToLogsFunc
+	logsToLogsStabilityLevel StabilityLevel
+}
+
+func (c connectorFactory) TracesToTracesStability() StabilityLevel {
+	return c.tracesToTracesStabilityLevel
+}
+
+func (c connectorFactory) TracesToMetricsStability() StabilityLevel {
+	return c.tracesToMetricsStabilityLevel
+}
+
+func (c connectorFactory) TracesToLogsStability() StabilityLevel {
+	return c.tracesToLogsStabilityLevel
+}
+
+func (c connectorFactory) MetricsToTracesStability() StabilityLevel {
+	return c.metricsToTracesStabilityLevel
+}
+
+func (c connectorFactory) MetricsToMetricsStability() StabilityLevel {
+	return c.metricsToMetricsStabilityLevel
+}
+
+func (c connectorFactory) MetricsToLogsStability() StabilityLevel {
+	return c.metricsToLogsStabilityLevel
+}
+
+func (c connectorFactory) LogsToTracesStability() StabilityLevel {
+	return c.logsToTracesStabilityLevel
+}
+
+func (c connectorFactory) LogsToMetricsStability() StabilityLevel {
+	return c.logsToMetricsStabilityLevel
+}
+
+func (c connectorFactory) LogsToLogsStability() StabilityLevel {
+	return c.logsToLogsStabilityLevel
+}
+
+// WithTracesToTraces overrides the default "error not supported" implementation for CreateTracesToTraces and the default "undefined" stability level.
+func WithTracesToTraces(createTracesToTraces CreateTracesToTracesFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.tracesToTracesStabilityLevel = sl
+		o.CreateTracesToTracesFunc = createTracesToTraces
+	})
+}
+
+// WithTracesToMetrics overrides the default "error not supported" implementation for CreateTracesToMetrics and the default "undefined" stability level.
+func WithTracesToMetrics(createTracesToMetrics CreateTracesToMetricsFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.tracesToMetricsStabilityLevel = sl
+		o.CreateTracesToMetricsFunc = createTracesToMetrics
+	})
+}
+
+// WithTracesToLogs overrides the default "error not supported" implementation for CreateTracesToLogs and the default "undefined" stability level.
+func WithTracesToLogs(createTracesToLogs CreateTracesToLogsFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.tracesToLogsStabilityLevel = sl
+		o.CreateTracesToLogsFunc = createTracesToLogs
+	})
+}
+
+// WithMetricsToTraces overrides the default "error not supported" implementation for CreateMetricsToTraces and the default "undefined" stability level.
+func WithMetricsToTraces(createMetricsToTraces CreateMetricsToTracesFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.metricsToTracesStabilityLevel = sl
+		o.CreateMetricsToTracesFunc = createMetricsToTraces
+	})
+}
+
+// WithMetricsToMetrics overrides the default "error not supported" implementation for CreateMetricsToMetrics and the default "undefined" stability level.
+func WithMetricsToMetrics(createMetricsToMetrics CreateMetricsToMetricsFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.metricsToMetricsStabilityLevel = sl
+		o.CreateMetricsToMetricsFunc = createMetricsToMetrics
+	})
+}
+
+// WithMetricsToLogs overrides the default "error not supported" implementation for CreateMetricsToLogs and the default "undefined" stability level.
+func WithMetricsToLogs(createMetricsToLogs CreateMetricsToLogsFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.metricsToLogsStabilityLevel = sl
+		o.CreateMetricsToLogsFunc = createMetricsToLogs
+	})
+}
+
+// WithLogsToTraces overrides the default "error not supported" implementation for CreateLogsToTraces and the default "undefined" stability level.
+func WithLogsToTraces(createLogsToTraces CreateLogsToTracesFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.logsToTracesStabilityLevel = sl
+		o.CreateLogsToTracesFunc = createLogsToTraces
+	})
+}
+
+// WithLogsToMetrics overrides the default "error not supported" implementation for CreateLogsToMetrics and the default "undefined" stability level.
+func WithLogsToMetrics(createLogsToMetrics CreateLogsToMetricsFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.logsToMetricsStabilityLevel = sl
+		o.CreateLogsToMetricsFunc = createLogsToMetrics
+	})
+}
+
+// WithLogsToLogs overrides the default "error not supported" implementation for CreateLogsToLogs and the default "undefined" stability level.
+func WithLogsToLogs(createLogsToLogs CreateLogsToLogsFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.logsToLogsStabilityLevel = sl
+		o.CreateLogsToLogsFunc = createLogsToLogs
+	})
+}
+
+// NewConnectorFactory returns a ConnectorFactory.
+func NewConnectorFactory(cfgType Type, createDefaultConfig CreateDefaultConfigFunc, options ...ConnectorFactoryOption) ConnectorFactory {
+	f := &connectorFactory{
+		baseFactory: baseFactory{
+			cfgType:                 cfgType,
+			CreateDefaultConfigFunc: createDefaultConfig,
+		},
+	}
+	for _, opt := range options {
+		opt.applyConnectorFactoryOption(f)
+	}
+	return f
+}
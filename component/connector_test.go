@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// TODO: Move tests back to component package after config.*Settings are removed.
+
+package component_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestNewConnectorFactory(t *testing.T) {
+	const typeStr = "test"
+	defaultCfg := config.NewConnectorSettings(component.NewID(typeStr))
+	factory := component.NewConnectorFactory(
+		typeStr,
+		func() component.Config { return &defaultCfg })
+	assert.EqualValues(t, typeStr, factory.Type())
+	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+	_, err := factory.CreateTracesToTraces(context.Background(), component.ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.Error(t, err)
+	_, err = factory.CreateTracesToMetrics(context.Background(), component.ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.Error(t, err)
+	_, err = factory.CreateMetricsToLogs(context.Background(), component.ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.Error(t, err)
+	_, err = factory.CreateLogsToLogs(context.Background(), component.ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.Error(t, err)
+	assert.Equal(t, component.StabilityLevelUndefined, factory.TracesToMetricsStability())
+}
+
+func TestNewConnectorFactory_WithOptions(t *testing.T) {
+	const typeStr = "test"
+	defaultCfg := config.NewConnectorSettings(component.NewID(typeStr))
+	factory := component.NewConnectorFactory(
+		typeStr,
+		func() component.Config { return &defaultCfg },
+		component.WithTracesToTraces(createTracesToTraces, component.StabilityLevelDevelopment),
+		component.WithTracesToMetrics(createTracesToMetrics, component.StabilityLevelAlpha),
+		component.WithLogsToMetrics(createLogsToMetrics, component.StabilityLevelBeta))
+	assert.EqualValues(t, typeStr, factory.Type())
+	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+
+	assert.Equal(t, component.StabilityLevelDevelopment, factory.TracesToTracesStability())
+	_, err := factory.CreateTracesToTraces(context.Background(), component.ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.NoError(t, err)
+
+	assert.Equal(t, component.StabilityLevelAlpha, factory.TracesToMetricsStability())
+	_, err = factory.CreateTracesToMetrics(context.Background(), component.ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.NoError(t, err)
+
+	assert.Equal(t, component.StabilityLevelBeta, factory.LogsToMetricsStability())
+	_, err = factory.CreateLogsToMetrics(context.Background(), component.ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.NoError(t, err)
+
+	assert.Equal(t, component.StabilityLevelUndefined, factory.MetricsToTracesStability())
+	_, err = factory.CreateMetricsToTraces(context.Background(), component.ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.ErrorIs(t, err, component.ErrDataTypeIsNotSupported)
+}
+
+func createTracesToTraces(context.Context, component.ConnectorCreateSettings, component.Config, consumer.Traces) (component.TracesConnector, error) {
+	return nil, nil
+}
+
+func createTracesToMetrics(context.Context, component.ConnectorCreateSettings, component.Config, consumer.Metrics) (component.TracesConnector, error) {
+	return nil, nil
+}
+
+func createLogsToMetrics(context.Context, component.ConnectorCreateSettings, component.Config, consumer.Metrics) (component.LogsConnector, error) {
+	return nil, nil
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component // import "go.opentelemetry.io/collector/component"
+
+// Topic identifies the events of type T exchanged between components through the EventBus.
+// Publishers and subscribers of the same topic are expected to share the Topic variable.
+type Topic[T any] struct {
+	name string
+}
+
+// NewTopic returns a Topic with the given name. The name should be qualified by the
+// package defining the topic (e.g. "discovery/targets") to avoid clashes.
+func NewTopic[T any](name string) Topic[T] {
+	return Topic[T]{name: name}
+}
+
+// Name returns the name of the Topic.
+func (t Topic[T]) Name() string {
+	return t.name
+}
+
+// EventBus is an extra interface for Host hosted by the OpenTelemetry Collector, that allows
+// loosely-coupled components to exchange events, e.g. a discovery extension informing the
+// receivers of new targets. Components should use the Publish and Subscribe functions
+// rather than this interface, which is not typed.
+//
+// This is an experimental interface that may change or even be removed completely.
+type EventBus interface {
+	// PublishEvent delivers the event to the current subscribers of the topic. It never blocks:
+	// the event is dropped for the subscribers that did not consume the previous events yet.
+	PublishEvent(topic string, event any)
+
+	// SubscribeEvents registers fn to be called with the events published on the topic after this
+	// call, in order. The returned function removes the subscription. Events are delivered from a
+	// goroutine dedicated to the subscription until it is removed or the host shuts down.
+	SubscribeEvents(topic string, fn func(event any)) (unsubscribe func())
+}
+
+// Publish publishes the event on the topic of the EventBus of the host, see EventBus.PublishEvent.
+// It returns false if the host does not implement EventBus.
+func Publish[T any](host Host, topic Topic[T], event T) bool {
+	bus, ok := host.(EventBus)
+	if !ok {
+		return false
+	}
+	bus.PublishEvent(topic.name, event)
+	return true
+}
+
+// Subscribe subscribes fn to the events published on the topic of the EventBus of the host, see
+// EventBus.SubscribeEvents. Events of another type published with the same topic name are ignored.
+// It returns false if the host does not implement EventBus.
+func Subscribe[T any](host Host, topic Topic[T], fn func(event T)) (unsubscribe func(), ok bool) {
+	bus, ok := host.(EventBus)
+	if !ok {
+		return func() {}, false
+	}
+	return bus.SubscribeEvents(topic.name, func(event any) {
+		if ev, isT := event.(T); isT {
+			fn(ev)
+		}
+	}), true
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+type eventBusHost struct {
+	component.Host
+	subscribers map[string][]func(any)
+}
+
+func (h *eventBusHost) PublishEvent(topic string, event any) {
+	for _, fn := range h.subscribers[topic] {
+		fn(event)
+	}
+}
+
+func (h *eventBusHost) SubscribeEvents(topic string, fn func(event any)) func() {
+	h.subscribers[topic] = append(h.subscribers[topic], fn)
+	return func() {}
+}
+
+func TestPublishSubscribe(t *testing.T) {
+	topic := component.NewTopic[[]string]("discovery/targets")
+	assert.Equal(t, "discovery/targets", topic.Name())
+
+	host := &eventBusHost{Host: componenttest.NewNopHost(), subscribers: map[string][]func(any){}}
+	var received [][]string
+	_, ok := component.Subscribe(host, topic, func(targets []string) {
+		received = append(received, targets)
+	})
+	assert.True(t, ok)
+
+	assert.True(t, component.Publish(host, topic, []string{"localhost:8888"}))
+	// Events of another type published with the same topic name are ignored.
+	assert.True(t, component.Publish(host, component.NewTopic[string]("discovery/targets"), "localhost:9999"))
+	assert.Equal(t, [][]string{{"localhost:8888"}}, received)
+}
+
+func TestPublishSubscribeNotSupported(t *testing.T) {
+	topic := component.NewTopic[string]("discovery/targets")
+	host := componenttest.NewNopHost()
+
+	unsubscribe, ok := component.Subscribe(host, topic, func(string) {})
+	assert.False(t, ok)
+	unsubscribe()
+	assert.False(t, component.Publish(host, topic, "localhost:8888"))
+}
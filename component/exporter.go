@@ -44,6 +44,15 @@ type LogsExporter interface {
 	consumer.Logs
 }
 
+// ConnectionWaiter is an extra interface for Exporter hosted by the OpenTelemetry Collector
+// that is to be implemented by exporters connecting to their destination, so that the service
+// can wait for the connections to be established before it reports the pipelines as ready.
+type ConnectionWaiter interface {
+	// WaitForConnection blocks until the exporter is connected to its destination, or until ctx is done,
+	// in which case it returns the error of ctx.
+	WaitForConnection(ctx context.Context) error
+}
+
 // ExporterCreateSettings configures Exporter creators.
 type ExporterCreateSettings struct {
 	// ID returns the ID of the component that will be created.
@@ -116,6 +125,18 @@ func (f CreateTracesExporterFunc) CreateTracesExporter(ctx context.Context, set
 	return f(ctx, set, cfg)
 }
 
+// NewCreateTracesExporterFunc returns a CreateTracesExporterFunc that converts the config to *CfgT before calling createTracesExporter,
+// and returns an error if the config has a different type.
+func NewCreateTracesExporterFunc[CfgT any](createTracesExporter func(context.Context, ExporterCreateSettings, *CfgT) (TracesExporter, error)) CreateTracesExporterFunc {
+	return func(ctx context.Context, set ExporterCreateSettings, cfg Config) (TracesExporter, error) {
+		typedCfg, err := castConfig[CfgT](cfg)
+		if err != nil {
+			return nil, err
+		}
+		return createTracesExporter(ctx, set, typedCfg)
+	}
+}
+
 // CreateMetricsExporterFunc is the equivalent of ExporterFactory.CreateMetricsExporter().
 type CreateMetricsExporterFunc func(context.Context, ExporterCreateSettings, Config) (MetricsExporter, error)
 
@@ -127,6 +148,18 @@ func (f CreateMetricsExporterFunc) CreateMetricsExporter(ctx context.Context, se
 	return f(ctx, set, cfg)
 }
 
+// NewCreateMetricsExporterFunc returns a CreateMetricsExporterFunc that converts the config to *CfgT before calling createMetricsExporter,
+// and returns an error if the config has a different type.
+func NewCreateMetricsExporterFunc[CfgT any](createMetricsExporter func(context.Context, ExporterCreateSettings, *CfgT) (MetricsExporter, error)) CreateMetricsExporterFunc {
+	return func(ctx context.Context, set ExporterCreateSettings, cfg Config) (MetricsExporter, error) {
+		typedCfg, err := castConfig[CfgT](cfg)
+		if err != nil {
+			return nil, err
+		}
+		return createMetricsExporter(ctx, set, typedCfg)
+	}
+}
+
 // CreateLogsExporterFunc is the equivalent of ExporterFactory.CreateLogsExporter().
 type CreateLogsExporterFunc func(context.Context, ExporterCreateSettings, Config) (LogsExporter, error)
 
@@ -138,6 +171,18 @@ func (f CreateLogsExporterFunc) CreateLogsExporter(ctx context.Context, set Expo
 	return f(ctx, set, cfg)
 }
 
+// NewCreateLogsExporterFunc returns a CreateLogsExporterFunc that converts the config to *CfgT before calling createLogsExporter,
+// and returns an error if the config has a different type.
+func NewCreateLogsExporterFunc[CfgT any](createLogsExporter func(context.Context, ExporterCreateSettings, *CfgT) (LogsExporter, error)) CreateLogsExporterFunc {
+	return func(ctx context.Context, set ExporterCreateSettings, cfg Config) (LogsExporter, error) {
+		typedCfg, err := castConfig[CfgT](cfg)
+		if err != nil {
+			return nil, err
+		}
+		return createLogsExporter(ctx, set, typedCfg)
+	}
+}
+
 type exporterFactory struct {
 	baseFactory
 	CreateTracesExporterFunc
@@ -67,6 +67,46 @@ func TestNewExporterFactory_WithOptions(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestNewExporterFactory_WithTypedConfig(t *testing.T) {
+	const typeStr = "test"
+	defaultCfg := config.NewExporterSettings(component.NewID(typeStr))
+	factory := component.NewExporterFactory(
+		typeStr,
+		component.NewCreateDefaultConfigFunc(defaultCfg),
+		component.WithTracesExporter(component.NewCreateTracesExporterFunc(createTypedTracesExporter), component.StabilityLevelDevelopment),
+		component.WithMetricsExporter(component.NewCreateMetricsExporterFunc(createTypedMetricsExporter), component.StabilityLevelAlpha),
+		component.WithLogsExporter(component.NewCreateLogsExporterFunc(createTypedLogsExporter), component.StabilityLevelDeprecated))
+	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+	assert.NotSame(t, factory.CreateDefaultConfig(), factory.CreateDefaultConfig())
+
+	_, err := factory.CreateTracesExporter(context.Background(), component.ExporterCreateSettings{}, factory.CreateDefaultConfig())
+	assert.NoError(t, err)
+	_, err = factory.CreateMetricsExporter(context.Background(), component.ExporterCreateSettings{}, factory.CreateDefaultConfig())
+	assert.NoError(t, err)
+	_, err = factory.CreateLogsExporter(context.Background(), component.ExporterCreateSettings{}, factory.CreateDefaultConfig())
+	assert.NoError(t, err)
+
+	receiverCfg := config.NewReceiverSettings(component.NewID(typeStr))
+	_, err = factory.CreateTracesExporter(context.Background(), component.ExporterCreateSettings{}, &receiverCfg)
+	assert.EqualError(t, err, "invalid config type *config.ReceiverSettings, expected *config.ExporterSettings")
+	_, err = factory.CreateMetricsExporter(context.Background(), component.ExporterCreateSettings{}, &receiverCfg)
+	assert.EqualError(t, err, "invalid config type *config.ReceiverSettings, expected *config.ExporterSettings")
+	_, err = factory.CreateLogsExporter(context.Background(), component.ExporterCreateSettings{}, &receiverCfg)
+	assert.EqualError(t, err, "invalid config type *config.ReceiverSettings, expected *config.ExporterSettings")
+}
+
+func createTypedTracesExporter(context.Context, component.ExporterCreateSettings, *config.ExporterSettings) (component.TracesExporter, error) {
+	return nil, nil
+}
+
+func createTypedMetricsExporter(context.Context, component.ExporterCreateSettings, *config.ExporterSettings) (component.MetricsExporter, error) {
+	return nil, nil
+}
+
+func createTypedLogsExporter(context.Context, component.ExporterCreateSettings, *config.ExporterSettings) (component.LogsExporter, error) {
+	return nil, nil
+}
+
 func createTracesExporter(context.Context, component.ExporterCreateSettings, component.Config) (component.TracesExporter, error) {
 	return nil, nil
 }
@@ -16,6 +16,7 @@ package component // import "go.opentelemetry.io/collector/component"
 
 import (
 	"context"
+	"net/http"
 )
 
 // Deprecated: [v0.67.0] use Config.
@@ -46,6 +47,16 @@ type PipelineWatcher interface {
 	NotReady() error
 }
 
+// HTTPHandlerProvider is an extra interface for Extension hosted by the OpenTelemetry
+// Collector that is to be implemented by extensions exposing HTTP endpoints on the admin
+// server of the service, instead of binding their own port. The handler is served under
+// the "/<extension ID>/" path prefix, which is stripped from the requests it receives.
+// The admin server applies the TLS and auth settings configured for the service.
+type HTTPHandlerProvider interface {
+	// HTTPHandler returns the handler of the extension. It is called once the extension is started.
+	HTTPHandler() http.Handler
+}
+
 // ExtensionCreateSettings is passed to ExtensionFactory.Create* functions.
 type ExtensionCreateSettings struct {
 	// ID returns the ID of the component that will be created.
@@ -68,6 +79,18 @@ func (f CreateExtensionFunc) CreateExtension(ctx context.Context, set ExtensionC
 	return f(ctx, set, cfg)
 }
 
+// NewCreateExtensionFunc returns a CreateExtensionFunc that converts the config to *CfgT before calling createExtension,
+// and returns an error if the config has a different type.
+func NewCreateExtensionFunc[CfgT any](createExtension func(context.Context, ExtensionCreateSettings, *CfgT) (Extension, error)) CreateExtensionFunc {
+	return func(ctx context.Context, set ExtensionCreateSettings, cfg Config) (Extension, error) {
+		typedCfg, err := castConfig[CfgT](cfg)
+		if err != nil {
+			return nil, err
+		}
+		return createExtension(ctx, set, typedCfg)
+	}
+}
+
 // ExtensionFactory is a factory for extensions to the service.
 type ExtensionFactory interface {
 	Factory
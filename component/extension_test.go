@@ -51,3 +51,27 @@ func TestNewExtensionFactory(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Same(t, nopExtensionInstance, ext)
 }
+
+func TestNewExtensionFactory_WithTypedConfig(t *testing.T) {
+	const typeStr = "test"
+	defaultCfg := config.NewExtensionSettings(component.NewID(typeStr))
+	nopExtensionInstance := new(nopExtension)
+
+	factory := component.NewExtensionFactory(
+		typeStr,
+		component.NewCreateDefaultConfigFunc(defaultCfg),
+		component.NewCreateExtensionFunc(func(ctx context.Context, settings component.ExtensionCreateSettings, cfg *config.ExtensionSettings) (component.Extension, error) {
+			return nopExtensionInstance, nil
+		}),
+		component.StabilityLevelDevelopment)
+	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+	assert.NotSame(t, factory.CreateDefaultConfig(), factory.CreateDefaultConfig())
+
+	ext, err := factory.CreateExtension(context.Background(), component.ExtensionCreateSettings{}, factory.CreateDefaultConfig())
+	assert.NoError(t, err)
+	assert.Same(t, nopExtensionInstance, ext)
+
+	exporterCfg := config.NewExporterSettings(component.NewID(typeStr))
+	_, err = factory.CreateExtension(context.Background(), component.ExtensionCreateSettings{}, &exporterCfg)
+	assert.EqualError(t, err, "invalid config type *config.ExporterSettings, expected *config.ExtensionSettings")
+}
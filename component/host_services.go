@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component // import "go.opentelemetry.io/collector/component"
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Clock provides the time to the components, so that tests can control it.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After returns a channel receiving the current time once the duration elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// Random provides pseudo-random numbers to the components, so that tests can make them deterministic.
+// It is safe for concurrent use.
+type Random interface {
+	// Int63 returns a non-negative pseudo-random 63-bit integer.
+	Int63() int64
+
+	// Float64 returns a pseudo-random number in [0.0,1.0).
+	Float64() float64
+}
+
+// HostServices are the services that the host provides to the components, through the context passed to
+// Component.Start.
+type HostServices struct {
+	// Clock provides the time. By default, it is the system clock.
+	Clock Clock
+
+	// Random provides pseudo-random numbers. By default, they are generated from the math/rand default source.
+	Random Random
+
+	// TelemetrySettings are the telemetry settings of the host. They are the zero value if the host
+	// did not provide them.
+	TelemetrySettings TelemetrySettings
+}
+
+type hostServicesKey struct{}
+
+// ContextWithHostServices returns a copy of ctx carrying the host services.
+func ContextWithHostServices(ctx context.Context, services HostServices) context.Context {
+	return context.WithValue(ctx, hostServicesKey{}, services)
+}
+
+// HostServicesFromContext returns the host services carried by ctx. The services that are not provided
+// are replaced by their default.
+func HostServicesFromContext(ctx context.Context) HostServices {
+	services, _ := ctx.Value(hostServicesKey{}).(HostServices)
+	if services.Clock == nil {
+		services.Clock = systemClock{}
+	}
+	if services.Random == nil {
+		services.Random = defaultRandom{}
+	}
+	return services
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// defaultRandom uses the functions of math/rand, which are safe for concurrent use.
+type defaultRandom struct{}
+
+func (defaultRandom) Int63() int64 {
+	return rand.Int63() // #nosec G404 not used for security.
+}
+
+func (defaultRandom) Float64() float64 {
+	return rand.Float64() // #nosec G404 not used for security.
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fixedClock struct {
+	systemClock
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+func TestHostServicesFromContext(t *testing.T) {
+	services := HostServicesFromContext(context.Background())
+	assert.Equal(t, systemClock{}, services.Clock)
+	assert.Equal(t, defaultRandom{}, services.Random)
+	assert.Nil(t, services.TelemetrySettings.Logger)
+
+	r := services.Random.Float64()
+	assert.True(t, r >= 0 && r < 1)
+	assert.True(t, services.Random.Int63() >= 0)
+
+	clock := fixedClock{now: time.Unix(10, 0)}
+	logger := zap.NewNop()
+	ctx := ContextWithHostServices(context.Background(), HostServices{
+		Clock:             clock,
+		TelemetrySettings: TelemetrySettings{Logger: logger},
+	})
+	services = HostServicesFromContext(ctx)
+	assert.Equal(t, time.Unix(10, 0), services.Clock.Now())
+	assert.Equal(t, defaultRandom{}, services.Random)
+	assert.Same(t, logger, services.TelemetrySettings.Logger)
+}
@@ -120,6 +120,18 @@ func (f CreateTracesProcessorFunc) CreateTracesProcessor(
 	return f(ctx, set, cfg, nextConsumer)
 }
 
+// NewCreateTracesProcessorFunc returns a CreateTracesProcessorFunc that converts the config to *CfgT before calling createTracesProcessor,
+// and returns an error if the config has a different type.
+func NewCreateTracesProcessorFunc[CfgT any](createTracesProcessor func(context.Context, ProcessorCreateSettings, *CfgT, consumer.Traces) (TracesProcessor, error)) CreateTracesProcessorFunc {
+	return func(ctx context.Context, set ProcessorCreateSettings, cfg Config, nextConsumer consumer.Traces) (TracesProcessor, error) {
+		typedCfg, err := castConfig[CfgT](cfg)
+		if err != nil {
+			return nil, err
+		}
+		return createTracesProcessor(ctx, set, typedCfg, nextConsumer)
+	}
+}
+
 // CreateMetricsProcessorFunc is the equivalent of ProcessorFactory.CreateMetricsProcessor().
 type CreateMetricsProcessorFunc func(context.Context, ProcessorCreateSettings, Config, consumer.Metrics) (MetricsProcessor, error)
 
@@ -136,6 +148,18 @@ func (f CreateMetricsProcessorFunc) CreateMetricsProcessor(
 	return f(ctx, set, cfg, nextConsumer)
 }
 
+// NewCreateMetricsProcessorFunc returns a CreateMetricsProcessorFunc that converts the config to *CfgT before calling createMetricsProcessor,
+// and returns an error if the config has a different type.
+func NewCreateMetricsProcessorFunc[CfgT any](createMetricsProcessor func(context.Context, ProcessorCreateSettings, *CfgT, consumer.Metrics) (MetricsProcessor, error)) CreateMetricsProcessorFunc {
+	return func(ctx context.Context, set ProcessorCreateSettings, cfg Config, nextConsumer consumer.Metrics) (MetricsProcessor, error) {
+		typedCfg, err := castConfig[CfgT](cfg)
+		if err != nil {
+			return nil, err
+		}
+		return createMetricsProcessor(ctx, set, typedCfg, nextConsumer)
+	}
+}
+
 // CreateLogsProcessorFunc is the equivalent of ProcessorFactory.CreateLogsProcessor().
 type CreateLogsProcessorFunc func(context.Context, ProcessorCreateSettings, Config, consumer.Logs) (LogsProcessor, error)
 
@@ -152,6 +176,18 @@ func (f CreateLogsProcessorFunc) CreateLogsProcessor(
 	return f(ctx, set, cfg, nextConsumer)
 }
 
+// NewCreateLogsProcessorFunc returns a CreateLogsProcessorFunc that converts the config to *CfgT before calling createLogsProcessor,
+// and returns an error if the config has a different type.
+func NewCreateLogsProcessorFunc[CfgT any](createLogsProcessor func(context.Context, ProcessorCreateSettings, *CfgT, consumer.Logs) (LogsProcessor, error)) CreateLogsProcessorFunc {
+	return func(ctx context.Context, set ProcessorCreateSettings, cfg Config, nextConsumer consumer.Logs) (LogsProcessor, error) {
+		typedCfg, err := castConfig[CfgT](cfg)
+		if err != nil {
+			return nil, err
+		}
+		return createLogsProcessor(ctx, set, typedCfg, nextConsumer)
+	}
+}
+
 type processorFactory struct {
 	baseFactory
 	CreateTracesProcessorFunc
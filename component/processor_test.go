@@ -68,6 +68,46 @@ func TestNewProcessorFactory_WithOptions(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestNewProcessorFactory_WithTypedConfig(t *testing.T) {
+	const typeStr = "test"
+	defaultCfg := config.NewProcessorSettings(component.NewID(typeStr))
+	factory := component.NewProcessorFactory(
+		typeStr,
+		component.NewCreateDefaultConfigFunc(defaultCfg),
+		component.WithTracesProcessor(component.NewCreateTracesProcessorFunc(createTypedTracesProcessor), component.StabilityLevelAlpha),
+		component.WithMetricsProcessor(component.NewCreateMetricsProcessorFunc(createTypedMetricsProcessor), component.StabilityLevelBeta),
+		component.WithLogsProcessor(component.NewCreateLogsProcessorFunc(createTypedLogsProcessor), component.StabilityLevelUnmaintained))
+	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+	assert.NotSame(t, factory.CreateDefaultConfig(), factory.CreateDefaultConfig())
+
+	_, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateSettings{}, factory.CreateDefaultConfig(), nil)
+	assert.NoError(t, err)
+	_, err = factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateSettings{}, factory.CreateDefaultConfig(), nil)
+	assert.NoError(t, err)
+	_, err = factory.CreateLogsProcessor(context.Background(), component.ProcessorCreateSettings{}, factory.CreateDefaultConfig(), nil)
+	assert.NoError(t, err)
+
+	exporterCfg := config.NewExporterSettings(component.NewID(typeStr))
+	_, err = factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateSettings{}, &exporterCfg, nil)
+	assert.EqualError(t, err, "invalid config type *config.ExporterSettings, expected *config.ProcessorSettings")
+	_, err = factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateSettings{}, &exporterCfg, nil)
+	assert.EqualError(t, err, "invalid config type *config.ExporterSettings, expected *config.ProcessorSettings")
+	_, err = factory.CreateLogsProcessor(context.Background(), component.ProcessorCreateSettings{}, &exporterCfg, nil)
+	assert.EqualError(t, err, "invalid config type *config.ExporterSettings, expected *config.ProcessorSettings")
+}
+
+func createTypedTracesProcessor(context.Context, component.ProcessorCreateSettings, *config.ProcessorSettings, consumer.Traces) (component.TracesProcessor, error) {
+	return nil, nil
+}
+
+func createTypedMetricsProcessor(context.Context, component.ProcessorCreateSettings, *config.ProcessorSettings, consumer.Metrics) (component.MetricsProcessor, error) {
+	return nil, nil
+}
+
+func createTypedLogsProcessor(context.Context, component.ProcessorCreateSettings, *config.ProcessorSettings, consumer.Logs) (component.LogsProcessor, error) {
+	return nil, nil
+}
+
 func createTracesProcessor(context.Context, component.ProcessorCreateSettings, component.Config, consumer.Traces) (component.TracesProcessor, error) {
 	return nil, nil
 }
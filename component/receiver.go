@@ -96,6 +96,26 @@ type ReceiverFactory interface {
 	LogsReceiverStability() StabilityLevel
 }
 
+// ReceiverSupportsDataType returns true if the factory can create a receiver of the given data type,
+// whatever the stability level declared for it.
+func ReceiverSupportsDataType(factory ReceiverFactory, dt DataType) bool {
+	f, ok := factory.(*receiverFactory)
+	if !ok {
+		// Factories wrapping another factory are assumed to support the data type, the creation
+		// of the receiver fails otherwise.
+		return true
+	}
+	switch dt {
+	case DataTypeTraces:
+		return f.CreateTracesReceiverFunc != nil
+	case DataTypeMetrics:
+		return f.CreateMetricsReceiverFunc != nil
+	case DataTypeLogs:
+		return f.CreateLogsReceiverFunc != nil
+	}
+	return false
+}
+
 // ReceiverFactoryOption apply changes to ReceiverOptions.
 type ReceiverFactoryOption interface {
 	// applyReceiverFactoryOption applies the option.
@@ -129,6 +149,18 @@ func (f CreateTracesReceiverFunc) CreateTracesReceiver(
 	return f(ctx, set, cfg, nextConsumer)
 }
 
+// NewCreateTracesReceiverFunc returns a CreateTracesReceiverFunc that converts the config to *CfgT before calling createTracesReceiver,
+// and returns an error if the config has a different type.
+func NewCreateTracesReceiverFunc[CfgT any](createTracesReceiver func(context.Context, ReceiverCreateSettings, *CfgT, consumer.Traces) (TracesReceiver, error)) CreateTracesReceiverFunc {
+	return func(ctx context.Context, set ReceiverCreateSettings, cfg Config, nextConsumer consumer.Traces) (TracesReceiver, error) {
+		typedCfg, err := castConfig[CfgT](cfg)
+		if err != nil {
+			return nil, err
+		}
+		return createTracesReceiver(ctx, set, typedCfg, nextConsumer)
+	}
+}
+
 // CreateMetricsReceiverFunc is the equivalent of ReceiverFactory.CreateMetricsReceiver().
 type CreateMetricsReceiverFunc func(context.Context, ReceiverCreateSettings, Config, consumer.Metrics) (MetricsReceiver, error)
 
@@ -145,6 +177,18 @@ func (f CreateMetricsReceiverFunc) CreateMetricsReceiver(
 	return f(ctx, set, cfg, nextConsumer)
 }
 
+// NewCreateMetricsReceiverFunc returns a CreateMetricsReceiverFunc that converts the config to *CfgT before calling createMetricsReceiver,
+// and returns an error if the config has a different type.
+func NewCreateMetricsReceiverFunc[CfgT any](createMetricsReceiver func(context.Context, ReceiverCreateSettings, *CfgT, consumer.Metrics) (MetricsReceiver, error)) CreateMetricsReceiverFunc {
+	return func(ctx context.Context, set ReceiverCreateSettings, cfg Config, nextConsumer consumer.Metrics) (MetricsReceiver, error) {
+		typedCfg, err := castConfig[CfgT](cfg)
+		if err != nil {
+			return nil, err
+		}
+		return createMetricsReceiver(ctx, set, typedCfg, nextConsumer)
+	}
+}
+
 // CreateLogsReceiverFunc is the equivalent of ReceiverFactory.CreateLogsReceiver().
 type CreateLogsReceiverFunc func(context.Context, ReceiverCreateSettings, Config, consumer.Logs) (LogsReceiver, error)
 
@@ -161,6 +205,18 @@ func (f CreateLogsReceiverFunc) CreateLogsReceiver(
 	return f(ctx, set, cfg, nextConsumer)
 }
 
+// NewCreateLogsReceiverFunc returns a CreateLogsReceiverFunc that converts the config to *CfgT before calling createLogsReceiver,
+// and returns an error if the config has a different type.
+func NewCreateLogsReceiverFunc[CfgT any](createLogsReceiver func(context.Context, ReceiverCreateSettings, *CfgT, consumer.Logs) (LogsReceiver, error)) CreateLogsReceiverFunc {
+	return func(ctx context.Context, set ReceiverCreateSettings, cfg Config, nextConsumer consumer.Logs) (LogsReceiver, error) {
+		typedCfg, err := castConfig[CfgT](cfg)
+		if err != nil {
+			return nil, err
+		}
+		return createLogsReceiver(ctx, set, typedCfg, nextConsumer)
+	}
+}
+
 type receiverFactory struct {
 	baseFactory
 	CreateTracesReceiverFunc
@@ -68,6 +68,40 @@ func TestNewReceiverFactory_WithOptions(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestReceiverSupportsDataType(t *testing.T) {
+	const typeStr = "test"
+	defaultCfg := config.NewReceiverSettings(component.NewID(typeStr))
+	factory := component.NewReceiverFactory(
+		typeStr,
+		func() component.Config { return &defaultCfg },
+		component.WithTracesReceiver(createTracesReceiver, component.StabilityLevelUndefined))
+	assert.True(t, component.ReceiverSupportsDataType(factory, component.DataTypeTraces))
+	assert.False(t, component.ReceiverSupportsDataType(factory, component.DataTypeMetrics))
+	assert.False(t, component.ReceiverSupportsDataType(factory, component.DataTypeLogs))
+}
+
+func TestNewReceiverFactory_WithTypedConfig(t *testing.T) {
+	const typeStr = "test"
+	defaultCfg := config.NewReceiverSettings(component.NewID(typeStr))
+	factory := component.NewReceiverFactory(
+		typeStr,
+		component.NewCreateDefaultConfigFunc(defaultCfg),
+		component.WithTracesReceiver(component.NewCreateTracesReceiverFunc(createTypedTracesReceiver), component.StabilityLevelDeprecated))
+	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+	assert.NotSame(t, factory.CreateDefaultConfig(), factory.CreateDefaultConfig())
+
+	_, err := factory.CreateTracesReceiver(context.Background(), component.ReceiverCreateSettings{}, factory.CreateDefaultConfig(), nil)
+	assert.NoError(t, err)
+
+	exporterCfg := config.NewExporterSettings(component.NewID(typeStr))
+	_, err = factory.CreateTracesReceiver(context.Background(), component.ReceiverCreateSettings{}, &exporterCfg, nil)
+	assert.EqualError(t, err, "invalid config type *config.ExporterSettings, expected *config.ReceiverSettings")
+}
+
+func createTypedTracesReceiver(context.Context, component.ReceiverCreateSettings, *config.ReceiverSettings, consumer.Traces) (component.TracesReceiver, error) {
+	return nil, nil
+}
+
 func createTracesReceiver(context.Context, component.ReceiverCreateSettings, component.Config, consumer.Traces) (component.TracesReceiver, error) {
 	return nil, nil
 }
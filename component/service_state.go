@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component // import "go.opentelemetry.io/collector/component"
+
+import (
+	"time"
+)
+
+// ServiceState represents the state of the service hosting the components.
+type ServiceState int32
+
+const (
+	// ServiceStateStarting is the state of the service while its components are started,
+	// until the pipelines are ready to receive data.
+	ServiceStateStarting ServiceState = iota
+	// ServiceStateReady is the state of the service once the pipelines are ready to receive data.
+	ServiceStateReady
+	// ServiceStateDegraded is the state of a ready service while some of its components report an error.
+	ServiceStateDegraded
+	// ServiceStateStopping is the state of the service once its components are being stopped.
+	ServiceStateStopping
+)
+
+// String returns a string representation of a ServiceState.
+func (s ServiceState) String() string {
+	switch s {
+	case ServiceStateStarting:
+		return "Starting"
+	case ServiceStateReady:
+		return "Ready"
+	case ServiceStateDegraded:
+		return "Degraded"
+	case ServiceStateStopping:
+		return "Stopping"
+	}
+	return "Unknown"
+}
+
+// ServiceStateHost is an extra interface for Host hosted by the OpenTelemetry Collector,
+// that exposes the current state of the service.
+//
+// This is an experimental interface that may change or even be removed completely.
+type ServiceStateHost interface {
+	// ServiceState returns the current state of the service, and the time at which it entered it.
+	ServiceState() (ServiceState, time.Time)
+}
+
+// ServiceStateWatcher is an extra interface for Extension hosted by the OpenTelemetry
+// Collector that is to be implemented by extensions interested in the changes of the
+// state of the service, e.g. to distinguish a collector that is started from one that
+// is ready to receive data.
+type ServiceStateWatcher interface {
+	// ServiceStateChanged notifies the Extension that the service entered state at the given time.
+	// Extensions SHOULD NOT block or do expensive work in this method, since the state changes
+	// are notified synchronously.
+	ServiceStateChanged(state ServiceState, timestamp time.Time)
+}
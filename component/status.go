@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component // import "go.opentelemetry.io/collector/component"
+
+import (
+	"time"
+)
+
+// Status represents the status of a component.
+type Status int32
+
+const (
+	StatusNone Status = iota
+	StatusStarting
+	StatusOK
+	StatusRecoverableError
+	StatusPermanentError
+	StatusFatalError
+	StatusStopping
+	StatusStopped
+)
+
+// String returns a string representation of a Status.
+func (s Status) String() string {
+	switch s {
+	case StatusStarting:
+		return "StatusStarting"
+	case StatusOK:
+		return "StatusOK"
+	case StatusRecoverableError:
+		return "StatusRecoverableError"
+	case StatusPermanentError:
+		return "StatusPermanentError"
+	case StatusFatalError:
+		return "StatusFatalError"
+	case StatusStopping:
+		return "StatusStopping"
+	case StatusStopped:
+		return "StatusStopped"
+	}
+	return "StatusNone"
+}
+
+// StatusEvent contains a status and timestamp, and can contain an error.
+type StatusEvent struct {
+	status    Status
+	err       error
+	timestamp time.Time
+}
+
+// Status returns the Status (enum) associated with the StatusEvent.
+func (ev *StatusEvent) Status() Status {
+	return ev.status
+}
+
+// Err returns the error associated with the StatusEvent, nil for non-error statuses.
+func (ev *StatusEvent) Err() error {
+	return ev.err
+}
+
+// Timestamp returns the time when the StatusEvent was created.
+func (ev *StatusEvent) Timestamp() time.Time {
+	return ev.timestamp
+}
+
+// NewStatusEvent creates and returns a StatusEvent with the specified status and a timestamp of time.Now().
+// To report an error use NewRecoverableErrorEvent, NewPermanentErrorEvent or NewFatalErrorEvent.
+func NewStatusEvent(status Status) *StatusEvent {
+	return &StatusEvent{
+		status:    status,
+		timestamp: time.Now(),
+	}
+}
+
+// NewRecoverableErrorEvent creates a StatusEvent with StatusRecoverableError and the given error.
+// A recoverable error is expected to be transient, the component should report StatusOK once it recovers.
+func NewRecoverableErrorEvent(err error) *StatusEvent {
+	ev := NewStatusEvent(StatusRecoverableError)
+	ev.err = err
+	return ev
+}
+
+// NewPermanentErrorEvent creates a StatusEvent with StatusPermanentError and the given error.
+// A permanent error requires human intervention (e.g. a configuration change) to be resolved.
+func NewPermanentErrorEvent(err error) *StatusEvent {
+	ev := NewStatusEvent(StatusPermanentError)
+	ev.err = err
+	return ev
+}
+
+// NewFatalErrorEvent creates a StatusEvent with StatusFatalError and the given error.
+// A fatal error is reported to the host, as with Host.ReportFatalError, and stops the collector.
+func NewFatalErrorEvent(err error) *StatusEvent {
+	ev := NewStatusEvent(StatusFatalError)
+	ev.err = err
+	return ev
+}
+
+// StatusFunc is the function a component uses to report a change of its status.
+type StatusFunc func(*StatusEvent)
+
+// InstanceID uniquely identifies a component instance built by the service.
+//
+// Receivers and exporters are shared by all the pipelines of the same data type
+// that use them, processors are instantiated for each pipeline.
+type InstanceID struct {
+	ID   ID
+	Kind Kind
+	// PipelineIDs are the pipelines that this instance is part of.
+	PipelineIDs []ID
+}
+
+// StatusWatcher is an extra interface for Extension hosted by the OpenTelemetry
+// Collector that is to be implemented by extensions interested in changes to the
+// status of the components.
+type StatusWatcher interface {
+	// ComponentStatusChanged notifies about a change in the status of the component identified by source.
+	// Extensions SHOULD NOT block or do expensive work in this method, since it is called synchronously
+	// by the reporting component.
+	ComponentStatusChanged(source *InstanceID, event *StatusEvent)
+}
@@ -36,4 +36,9 @@ type TelemetrySettings struct {
 	// MetricsLevel controls the level of detail for metrics emitted by the collector.
 	// Experimental: *NOTE* this field is experimental and may be changed or removed.
 	MetricsLevel configtelemetry.Level
+
+	// ReportComponentStatus allows a component to report a change of its status to the service.
+	// It is never nil for components built by the service.
+	// Experimental: *NOTE* this field is experimental and may be changed or removed.
+	ReportComponentStatus StatusFunc
 }
@@ -19,18 +19,24 @@ import (
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/auth"
 )
 
 var (
 	errAuthenticatorNotFound = errors.New("authenticator not found")
 	errNotClient             = errors.New("requested authenticator is not a client authenticator")
 	errNotServer             = errors.New("requested authenticator is not a server authenticator")
+	errNotAuthorizer         = errors.New("requested authorizer is not an authorizer")
 )
 
 // Authentication defines the auth settings for the receiver.
 type Authentication struct {
 	// AuthenticatorID specifies the name of the extension to use in order to authenticate the incoming data point.
 	AuthenticatorID component.ID `mapstructure:"authenticator"`
+
+	// AuthorizerID specifies the name of the extension to use in order to authorize the authenticated requests.
+	// Optional, and only supported by gRPC servers.
+	AuthorizerID *component.ID `mapstructure:"authorizer"`
 }
 
 // GetServerAuthenticator attempts to select the appropriate ServerAuthenticator from the list of extensions,
@@ -58,3 +64,18 @@ func (a Authentication) GetClientAuthenticator(extensions map[component.ID]compo
 	}
 	return nil, fmt.Errorf("failed to resolve authenticator %q: %w", a.AuthenticatorID, errAuthenticatorNotFound)
 }
+
+// GetAuthorizer attempts to select the Authorizer from the list of extensions, based on the component id of the
+// AuthorizerID extension. It returns nil if no authorizer is configured, and an error if it is not found.
+func (a Authentication) GetAuthorizer(extensions map[component.ID]component.Component) (auth.Authorizer, error) {
+	if a.AuthorizerID == nil {
+		return nil, nil
+	}
+	if ext, found := extensions[*a.AuthorizerID]; found {
+		if authorizer, ok := ext.(auth.Authorizer); ok {
+			return authorizer, nil
+		}
+		return nil, errNotAuthorizer
+	}
+	return nil, fmt.Errorf("failed to resolve authorizer %q: %w", *a.AuthorizerID, errAuthenticatorNotFound)
+}
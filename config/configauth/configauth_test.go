@@ -20,6 +20,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/auth"
 )
 
 func TestGetServer(t *testing.T) {
@@ -122,3 +123,31 @@ func TestGetClientFails(t *testing.T) {
 	assert.ErrorIs(t, err, errAuthenticatorNotFound)
 	assert.Nil(t, authenticator)
 }
+
+func TestGetAuthorizer(t *testing.T) {
+	authorizerID := component.NewID("authz")
+	cfg := &Authentication{
+		AuthenticatorID: component.NewID("mock"),
+		AuthorizerID:    &authorizerID,
+	}
+
+	authorizer, err := cfg.GetAuthorizer(map[component.ID]component.Component{
+		authorizerID: auth.NewAuthorizer(),
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, authorizer)
+
+	authorizer, err = cfg.GetAuthorizer(map[component.ID]component.Component{
+		authorizerID: auth.NewServer(),
+	})
+	assert.ErrorIs(t, err, errNotAuthorizer)
+	assert.Nil(t, authorizer)
+
+	authorizer, err = cfg.GetAuthorizer(map[component.ID]component.Component{})
+	assert.ErrorIs(t, err, errAuthenticatorNotFound)
+	assert.Nil(t, authorizer)
+
+	authorizer, err = (&Authentication{AuthenticatorID: component.NewID("mock")}).GetAuthorizer(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, authorizer)
+}
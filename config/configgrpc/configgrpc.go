@@ -15,26 +15,33 @@
 package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
 
 import (
+	gziplib "compress/gzip"
 	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"strings"
+	"sync"
 	"time"
 
+	zstdlib "github.com/klauspost/compress/zstd"
 	"github.com/mostynb/go-grpc-compression/snappy"
 	"github.com/mostynb/go-grpc-compression/zstd"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
@@ -44,6 +51,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/config/internal"
 	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/featuregate"
 )
 
 var errMetadataNotFound = errors.New("no request metadata found")
@@ -70,6 +78,11 @@ type GRPCClientSettings struct {
 	// The compression key for supported compression types within collector.
 	Compression configcompression.CompressionType `mapstructure:"compression"`
 
+	// CompressionLevel trades compression speed for ratio. Supported for gzip (1 to 9)
+	// and zstd (1 to 22). Zero uses the default level. The compressors are shared by the
+	// whole process, so all the clients using the same compression must use the same level.
+	CompressionLevel int `mapstructure:"compression_level"`
+
 	// TLSSetting struct exposes TLS client configuration.
 	TLSSetting configtls.TLSClientSetting `mapstructure:"tls"`
 
@@ -98,6 +111,20 @@ type GRPCClientSettings struct {
 
 	// Auth configuration for outgoing RPCs.
 	Auth *configauth.Authentication `mapstructure:"auth"`
+
+	// ForwardMetadata lists the keys of the client metadata of the incoming request (see client.Info)
+	// that are sent as gRPC metadata with outgoing requests. The receiver must have include_metadata enabled.
+	// Experimental: *NOTE* this option is subject to change or removal in the future.
+	ForwardMetadata []MetadataForwardingRule `mapstructure:"forward_metadata"`
+}
+
+// MetadataForwardingRule defines a client metadata key to forward to outgoing requests.
+type MetadataForwardingRule struct {
+	// Key is the name of the client metadata key to forward, matched case-insensitively.
+	Key string `mapstructure:"key"`
+
+	// RenameTo is the name of the outgoing gRPC metadata key. Defaults to Key.
+	RenameTo string `mapstructure:"rename_to"`
 }
 
 // KeepaliveServerConfig is the configuration for keepalive.
@@ -137,8 +164,8 @@ type GRPCServerSettings struct {
 	// MaxRecvMsgSizeMiB sets the maximum size (in MiB) of messages accepted by the server.
 	MaxRecvMsgSizeMiB uint64 `mapstructure:"max_recv_msg_size_mib"`
 
-	// MaxConcurrentStreams sets the limit on the number of concurrent streams to each ServerTransport.
-	// It has effect only for streaming RPCs.
+	// MaxConcurrentStreams sets the limit on the number of concurrent streams to each ServerTransport,
+	// i.e. the number of RPCs, unary or streaming, that a client connection can have in flight at once.
 	MaxConcurrentStreams uint32 `mapstructure:"max_concurrent_streams"`
 
 	// ReadBufferSize for gRPC server. See grpc.ReadBufferSize.
@@ -152,6 +179,10 @@ type GRPCServerSettings struct {
 	// Keepalive anchor for all the settings related to keepalive.
 	Keepalive *KeepaliveServerConfig `mapstructure:"keepalive"`
 
+	// IPFilter restricts the client addresses allowed to connect to the server.
+	// It is evaluated before Auth.
+	IPFilter *confignet.IPFilterSettings `mapstructure:"ip_filter"`
+
 	// Auth for this receiver
 	Auth *configauth.Authentication `mapstructure:"auth"`
 
@@ -200,6 +231,11 @@ func (gcs *GRPCClientSettings) toDialOptions(host component.Host, settings compo
 		if err != nil {
 			return nil, err
 		}
+		if gcs.CompressionLevel != 0 {
+			if err = registerGRPCLevelCompressor(gcs.Compression, gcs.CompressionLevel); err != nil {
+				return nil, err
+			}
+		}
 		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(cp)))
 	}
 
@@ -244,7 +280,10 @@ func (gcs *GRPCClientSettings) toDialOptions(host component.Host, settings compo
 
 		perRPCCredentials, perr := grpcAuthenticator.PerRPCCredentials()
 		if perr != nil {
-			return nil, err
+			return nil, fmt.Errorf("authenticator %q cannot authenticate gRPC requests: %w", gcs.Auth.AuthenticatorID, perr)
+		}
+		if perRPCCredentials == nil {
+			return nil, fmt.Errorf("authenticator %q cannot authenticate gRPC requests: %w", gcs.Auth.AuthenticatorID, auth.ErrTransportNotSupported)
 		}
 		opts = append(opts, grpc.WithPerRPCCredentials(perRPCCredentials))
 	}
@@ -268,9 +307,47 @@ func (gcs *GRPCClientSettings) toDialOptions(host component.Host, settings compo
 	opts = append(opts, grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor(otelOpts...)))
 	opts = append(opts, grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor(otelOpts...)))
 
+	if len(gcs.ForwardMetadata) > 0 {
+		for _, rule := range gcs.ForwardMetadata {
+			if rule.Key == "" {
+				return nil, errors.New("forward_metadata key must not be empty")
+			}
+		}
+		rules := gcs.ForwardMetadata
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+				return invoker(ForwardMetadata(ctx, rules), method, req, reply, cc, callOpts...)
+			}),
+			grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+				return streamer(ForwardMetadata(ctx, rules), desc, cc, method, callOpts...)
+			}),
+		)
+	}
+
 	return opts, nil
 }
 
+// ForwardMetadata returns a context whose outgoing gRPC metadata includes the values of the client metadata
+// of ctx (see client.Info) for the keys listed in rules, renamed as configured.
+// Keys not present in the client metadata are skipped.
+func ForwardMetadata(ctx context.Context, rules []MetadataForwardingRule) context.Context {
+	info := client.FromContext(ctx)
+	var kv []string
+	for _, rule := range rules {
+		name := rule.RenameTo
+		if name == "" {
+			name = rule.Key
+		}
+		for _, v := range info.Metadata.Get(rule.Key) {
+			kv = append(kv, name, v)
+		}
+	}
+	if len(kv) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
 func validateBalancerName(balancerName string) bool {
 	for _, item := range allowedBalancerNames {
 		if item == balancerName {
@@ -357,6 +434,26 @@ func (gss *GRPCServerSettings) toServerOption(host component.Host, settings comp
 	var uInterceptors []grpc.UnaryServerInterceptor
 	var sInterceptors []grpc.StreamServerInterceptor
 
+	if gss.IPFilter != nil {
+		filter, err := internal.NewIPFilter(gss.IPFilter, settings.MeterProvider, featuregate.GetRegistry(), "grpc", gss.NetAddr.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		uInterceptors = append(uInterceptors, func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := ipFilterCheck(ctx, filter); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		})
+		sInterceptors = append(sInterceptors, func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := ipFilterCheck(ss.Context(), filter); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		})
+	}
+
 	if gss.Auth != nil {
 		authenticator, err := gss.Auth.GetServerAuthenticator(host.GetExtensions())
 		if err != nil {
@@ -369,6 +466,25 @@ func (gss *GRPCServerSettings) toServerOption(host component.Host, settings comp
 		sInterceptors = append(sInterceptors, func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 			return authStreamServerInterceptor(srv, ss, info, handler, authenticator.Authenticate)
 		})
+
+		authorizer, err := gss.Auth.GetAuthorizer(host.GetExtensions())
+		if err != nil {
+			return nil, err
+		}
+		if authorizer != nil {
+			uInterceptors = append(uInterceptors, func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				if err := authorize(ctx, info.FullMethod, authorizer); err != nil {
+					return nil, err
+				}
+				return handler(ctx, req)
+			})
+			sInterceptors = append(sInterceptors, func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				if err := authorize(ss.Context(), info.FullMethod, authorizer); err != nil {
+					return err
+				}
+				return handler(srv, ss)
+			})
+		}
 	}
 
 	otelOpts := []otelgrpc.Option{
@@ -405,6 +521,94 @@ func getGRPCCompressionName(compressionType configcompression.CompressionType) (
 	}
 }
 
+var (
+	levelCompressorsMu sync.Mutex
+	// levelCompressors holds the level of the compressors registered by registerGRPCLevelCompressor.
+	levelCompressors = map[configcompression.CompressionType]int{}
+)
+
+// registerGRPCLevelCompressor registers a compressor using the given level in place of the default one
+// of the compression type. The registered compressors are shared by the whole process, so all the clients
+// using the compression type must use the same level.
+func registerGRPCLevelCompressor(compressionType configcompression.CompressionType, level int) error {
+	levelCompressorsMu.Lock()
+	defer levelCompressorsMu.Unlock()
+
+	if registered, ok := levelCompressors[compressionType]; ok {
+		if registered != level {
+			return fmt.Errorf("compression level %d for %q conflicts with the level %d of another client", level, compressionType, registered)
+		}
+		return nil
+	}
+	cp, err := newGRPCLevelCompressor(compressionType, level)
+	if err != nil {
+		return err
+	}
+	encoding.RegisterCompressor(cp)
+	levelCompressors[compressionType] = level
+	return nil
+}
+
+// newGRPCLevelCompressor returns a compressor that compresses with the given level, and decompresses
+// with the registered compressor of the compression type.
+func newGRPCLevelCompressor(compressionType configcompression.CompressionType, level int) (encoding.Compressor, error) {
+	var pool *sync.Pool
+	switch compressionType {
+	case configcompression.Gzip:
+		if level < gziplib.BestSpeed || level > gziplib.BestCompression {
+			return nil, fmt.Errorf("invalid compression level %d for %q, must be between %d and %d", level, compressionType, gziplib.BestSpeed, gziplib.BestCompression)
+		}
+		pool = &sync.Pool{New: func() interface{} {
+			w, _ := gziplib.NewWriterLevel(nil, level)
+			return w
+		}}
+	case configcompression.Zstd:
+		if level < 1 || level > 22 {
+			return nil, fmt.Errorf("invalid compression level %d for %q, must be between 1 and 22", level, compressionType)
+		}
+		pool = &sync.Pool{New: func() interface{} {
+			w, _ := zstdlib.NewWriter(nil, zstdlib.WithEncoderLevel(zstdlib.EncoderLevelFromZstd(level)))
+			return w
+		}}
+	default:
+		return nil, fmt.Errorf("compression level is not supported for %q", compressionType)
+	}
+	name, err := getGRPCCompressionName(compressionType)
+	if err != nil {
+		return nil, err
+	}
+	return &levelCompressor{Compressor: encoding.GetCompressor(name), pool: pool}, nil
+}
+
+// resettableWriteCloser is implemented by the gzip and zstd writers.
+type resettableWriteCloser interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// levelCompressor compresses with the pooled writers, and decompresses with the embedded compressor.
+type levelCompressor struct {
+	encoding.Compressor
+	pool *sync.Pool
+}
+
+func (c *levelCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	z := c.pool.Get().(resettableWriteCloser)
+	z.Reset(w)
+	return &pooledWriteCloser{resettableWriteCloser: z, pool: c.pool}, nil
+}
+
+// pooledWriteCloser returns its writer to the pool once closed.
+type pooledWriteCloser struct {
+	resettableWriteCloser
+	pool *sync.Pool
+}
+
+func (w *pooledWriteCloser) Close() error {
+	defer w.pool.Put(w.resettableWriteCloser)
+	return w.resettableWriteCloser.Close()
+}
+
 // enhanceWithClientInformation intercepts the incoming RPC, replacing the incoming context with one that includes
 // a client.Info, potentially with the peer's address.
 func enhanceWithClientInformation(includeMetadata bool) func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
@@ -438,6 +642,18 @@ func contextWithClient(ctx context.Context, includeMetadata bool) context.Contex
 	return client.NewContext(ctx, cl)
 }
 
+// ipFilterCheck returns a PermissionDenied status if the peer address is not allowed by the filter.
+func ipFilterCheck(ctx context.Context, filter *internal.IPFilter) error {
+	var addr net.Addr
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr
+	}
+	if !filter.Allowed(ctx, addr) {
+		return status.Error(codes.PermissionDenied, "client address is not allowed")
+	}
+	return nil
+}
+
 func authUnaryServerInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler, authenticate auth.AuthenticateFunc) (interface{}, error) {
 	headers, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -446,7 +662,7 @@ func authUnaryServerInterceptor(ctx context.Context, req interface{}, _ *grpc.Un
 
 	ctx, err := authenticate(ctx, headers)
 	if err != nil {
-		return nil, err
+		return nil, authStatusError(err)
 	}
 
 	return handler(ctx, req)
@@ -461,8 +677,59 @@ func authStreamServerInterceptor(srv interface{}, stream grpc.ServerStream, _ *g
 
 	ctx, err := authenticate(ctx, headers)
 	if err != nil {
-		return err
+		return authStatusError(err)
 	}
 
 	return handler(srv, wrapServerStream(ctx, stream))
 }
+
+// authorize checks with the authorizer that the authenticated request calling the given method is allowed.
+func authorize(ctx context.Context, fullMethod string, authorizer auth.Authorizer) error {
+	err := authorizer.Authorize(ctx, auth.AuthorizationRequest{
+		Method:   fullMethod,
+		DataType: dataTypeFromMethod(fullMethod),
+		Auth:     client.FromContext(ctx).Auth,
+	})
+	if err == nil {
+		return nil
+	}
+	var authErr *auth.Error
+	if _, ok := status.FromError(err); ok || errors.As(err, &authErr) {
+		return authStatusError(err)
+	}
+	return status.Error(codes.PermissionDenied, err.Error())
+}
+
+// dataTypeFromMethod returns the type of the telemetry sent to the OTLP service of the given method,
+// or an empty type for other services.
+func dataTypeFromMethod(fullMethod string) component.DataType {
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	switch service {
+	case "opentelemetry.proto.collector.trace.v1.TraceService":
+		return component.DataTypeTraces
+	case "opentelemetry.proto.collector.metrics.v1.MetricsService":
+		return component.DataTypeMetrics
+	case "opentelemetry.proto.collector.logs.v1.LogsService":
+		return component.DataTypeLogs
+	}
+	return ""
+}
+
+// authStatusError returns the gRPC status matching the kind of the authentication error.
+// Errors that already carry a gRPC status are returned unchanged.
+func authStatusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	code := codes.Unauthenticated
+	var authErr *auth.Error
+	if errors.As(err, &authErr) {
+		switch authErr.Kind {
+		case auth.ErrorKindPermissionDenied:
+			code = codes.PermissionDenied
+		case auth.ErrorKindInternal:
+			code = codes.Internal
+		}
+	}
+	return status.Error(code, err.Error())
+}
@@ -15,9 +15,12 @@
 package configgrpc
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -29,8 +32,12 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
@@ -39,8 +46,10 @@ import (
 	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/config/internal"
 	"go.opentelemetry.io/collector/extension/auth"
 	"go.opentelemetry.io/collector/extension/auth/authtest"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 )
@@ -94,7 +103,7 @@ func TestAllGrpcClientSettings(t *testing.T) {
 			},
 			host: &mockHost{
 				ext: map[component.ID]component.Component{
-					component.NewID("testauth"): &authtest.MockClient{},
+					component.NewID("testauth"): &authtest.MockClient{ResultPerRPCCredentials: &testPerRPCCredentials{}},
 				},
 			},
 		},
@@ -122,7 +131,7 @@ func TestAllGrpcClientSettings(t *testing.T) {
 			},
 			host: &mockHost{
 				ext: map[component.ID]component.Component{
-					component.NewID("testauth"): &authtest.MockClient{},
+					component.NewID("testauth"): &authtest.MockClient{ResultPerRPCCredentials: &testPerRPCCredentials{}},
 				},
 			},
 		},
@@ -150,7 +159,7 @@ func TestAllGrpcClientSettings(t *testing.T) {
 			},
 			host: &mockHost{
 				ext: map[component.ID]component.Component{
-					component.NewID("testauth"): &authtest.MockClient{},
+					component.NewID("testauth"): &authtest.MockClient{ResultPerRPCCredentials: &testPerRPCCredentials{}},
 				},
 			},
 		},
@@ -164,6 +173,49 @@ func TestAllGrpcClientSettings(t *testing.T) {
 	}
 }
 
+type testPerRPCCredentials struct{}
+
+func (c *testPerRPCCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer token"}, nil
+}
+
+func (c *testPerRPCCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+func TestGrpcClientAuthTransportNotSupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  auth.Client
+		wantErr error
+	}{
+		{
+			name:    "no_per_rpc_credentials",
+			client:  auth.NewClient(auth.WithClientRoundTripper(func(base http.RoundTripper) (http.RoundTripper, error) { return base, nil })),
+			wantErr: auth.ErrTransportNotSupported,
+		},
+		{
+			name: "per_rpc_credentials_error",
+			client: auth.NewClient(auth.WithPerRPCCredentials(func() (credentials.PerRPCCredentials, error) {
+				return nil, auth.ErrTransportNotSupported
+			})),
+			wantErr: auth.ErrTransportNotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gcs := &GRPCClientSettings{
+				Endpoint: "localhost:1234",
+				Auth:     &configauth.Authentication{AuthenticatorID: component.NewID("testauth")},
+			}
+			host := &mockHost{ext: map[component.ID]component.Component{component.NewID("testauth"): tt.client}}
+			_, err := gcs.toDialOptions(host, componenttest.NewNopTelemetrySettings())
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.ErrorContains(t, err, `authenticator "testauth" cannot authenticate gRPC requests`)
+		})
+	}
+}
+
 func TestDefaultGrpcServerSettings(t *testing.T) {
 	gss := &GRPCServerSettings{
 		NetAddr: confignet.NetAddr{
@@ -227,6 +279,32 @@ func TestGrpcServerAuthSettings(t *testing.T) {
 	assert.NotNil(t, srv)
 }
 
+func TestGrpcServerAuthorizerSettings(t *testing.T) {
+	authorizerID := component.NewID("authz")
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint: "0.0.0.0:1234",
+		},
+		Auth: &configauth.Authentication{
+			AuthenticatorID: component.NewID("mock"),
+			AuthorizerID:    &authorizerID,
+		},
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			component.NewID("mock"): auth.NewServer(),
+			authorizerID:            auth.NewAuthorizer(),
+		},
+	}
+	srv, err := gss.ToServer(host, componenttest.NewNopTelemetrySettings())
+	assert.NoError(t, err)
+	assert.NotNil(t, srv)
+
+	delete(host.ext, authorizerID)
+	_, err = gss.ToServer(host, componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, err, `failed to resolve authorizer "authz"`)
+}
+
 func TestGRPCClientSettingsError(t *testing.T) {
 	tt, err := obsreporttest.SetupTelemetryWithID(component.NewID("component"))
 	require.NoError(t, err)
@@ -291,6 +369,13 @@ func TestGRPCClientSettingsError(t *testing.T) {
 				BalancerName:    "test",
 			},
 		},
+		{
+			err: "forward_metadata key must not be empty",
+			settings: GRPCClientSettings{
+				Endpoint:        "localhost:1234",
+				ForwardMetadata: []MetadataForwardingRule{{RenameTo: "x-tenant"}},
+			},
+		},
 		{
 			err: "failed to resolve authenticator \"doesntexist\": authenticator not found",
 			settings: GRPCClientSettings{
@@ -367,6 +452,62 @@ func TestUseSecure(t *testing.T) {
 	assert.Len(t, dialOpts, 3)
 }
 
+func TestNewGRPCLevelCompressor(t *testing.T) {
+	_, err := newGRPCLevelCompressor(configcompression.Gzip, 10)
+	assert.Error(t, err)
+	_, err = newGRPCLevelCompressor(configcompression.Zstd, 23)
+	assert.Error(t, err)
+	_, err = newGRPCLevelCompressor(configcompression.Snappy, 1)
+	assert.Error(t, err)
+
+	for _, compressionType := range []configcompression.CompressionType{configcompression.Gzip, configcompression.Zstd} {
+		cp, err := newGRPCLevelCompressor(compressionType, 3)
+		require.NoError(t, err)
+		assert.Equal(t, string(compressionType), cp.Name())
+		for i := 0; i < 2; i++ {
+			buf := &bytes.Buffer{}
+			w, err := cp.Compress(buf)
+			require.NoError(t, err)
+			_, err = w.Write([]byte("hello world"))
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			r, err := cp.Decompress(buf)
+			require.NoError(t, err)
+			out, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, "hello world", string(out))
+		}
+	}
+}
+
+func TestCompressionLevelDialOptions(t *testing.T) {
+	defaultCompressor := encoding.GetCompressor("zstd")
+	t.Cleanup(func() {
+		levelCompressorsMu.Lock()
+		defer levelCompressorsMu.Unlock()
+		delete(levelCompressors, configcompression.Zstd)
+		encoding.RegisterCompressor(defaultCompressor)
+	})
+
+	gcs := &GRPCClientSettings{
+		Compression:      configcompression.Zstd,
+		CompressionLevel: 9,
+	}
+	dialOpts, err := gcs.toDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	assert.NotEmpty(t, dialOpts)
+	assert.IsType(t, &levelCompressor{}, encoding.GetCompressor("zstd"))
+
+	// Another client can use the same level.
+	_, err = gcs.toDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	gcs.CompressionLevel = 5
+	_, err = gcs.toDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	assert.EqualError(t, err, `compression level 5 for "zstd" conflicts with the level 9 of another client`)
+}
+
 func TestGRPCServerWarning(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -463,6 +604,18 @@ func TestGRPCServerSettingsError(t *testing.T) {
 				},
 			},
 		},
+		{
+			err: `^invalid allow entry: "10.0.0.0/40" is neither a CIDR range nor an IP address`,
+			settings: GRPCServerSettings{
+				NetAddr: confignet.NetAddr{
+					Endpoint:  "127.0.0.1:1234",
+					Transport: "tcp",
+				},
+				IPFilter: &confignet.IPFilterSettings{
+					Allow: []string{"10.0.0.0/40"},
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.err, func(t *testing.T) {
@@ -897,6 +1050,122 @@ func TestClientInfoInterceptors(t *testing.T) {
 	}
 }
 
+func TestForwardMetadata(t *testing.T) {
+	rules := []MetadataForwardingRule{
+		{Key: "X-Tenant"},
+		{Key: "x-scope", RenameTo: "x-scope-orgid"},
+		{Key: "missing"},
+	}
+
+	// Without client metadata the context is returned as is.
+	ctx := context.Background()
+	assert.Equal(t, ctx, ForwardMetadata(ctx, rules))
+
+	ctx = client.NewContext(ctx, client.Info{
+		Metadata: client.NewMetadata(map[string][]string{
+			"x-tenant": {"acme"},
+			"x-scope":  {"a", "b"},
+			"x-other":  {"ignored"},
+		}),
+	})
+	ctx = metadata.AppendToOutgoingContext(ctx, "existing", "value")
+	md, ok := metadata.FromOutgoingContext(ForwardMetadata(ctx, rules))
+	require.True(t, ok)
+	assert.Equal(t, metadata.Pairs(
+		"existing", "value",
+		"x-tenant", "acme",
+		"x-scope-orgid", "a",
+		"x-scope-orgid", "b",
+	), md)
+}
+
+func TestForwardMetadataInterceptors(t *testing.T) {
+	mock := &grpcTraceServer{}
+
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+	}
+	srv, err := gss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	ptraceotlp.RegisterGRPCServer(srv, mock)
+	defer srv.Stop()
+
+	l, err := gss.ToListener()
+	require.NoError(t, err)
+	go func() {
+		_ = srv.Serve(l)
+	}()
+
+	gcs := &GRPCClientSettings{
+		Endpoint: l.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+		ForwardMetadata: []MetadataForwardingRule{{Key: "x-tenant", RenameTo: "x-scope-orgid"}},
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer grpcClientConn.Close()
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFunc()
+	ctx = client.NewContext(ctx, client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"acme"}}),
+	})
+	_, err = ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, ptraceotlp.NewExportRequest())
+	require.NoError(t, err)
+
+	md, ok := metadata.FromIncomingContext(mock.recordedContext)
+	require.True(t, ok)
+	assert.Equal(t, []string{"acme"}, md.Get("x-scope-orgid"))
+	assert.Empty(t, md.Get("x-tenant"))
+}
+
+func TestIPFilterCheck(t *testing.T) {
+	filter, err := internal.NewIPFilter(
+		&confignet.IPFilterSettings{Allow: []string{"10.0.0.0/8"}},
+		componenttest.NewNopTelemetrySettings().MeterProvider,
+		featuregate.GetRegistry(),
+		"grpc",
+		"localhost:4317",
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		expectedCode codes.Code
+	}{
+		{
+			name: "allowed peer",
+			ctx: peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4317},
+			}),
+			expectedCode: codes.OK,
+		},
+		{
+			name: "rejected peer",
+			ctx: peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 4317},
+			}),
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "no peer",
+			ctx:          context.Background(),
+			expectedCode: codes.PermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedCode, status.Code(ipFilterCheck(tt.ctx, filter)))
+		})
+	}
+}
+
 func TestDefaultUnaryInterceptorAuthSucceeded(t *testing.T) {
 	// prepare
 	handlerCalled := false
@@ -946,10 +1215,113 @@ func TestDefaultUnaryInterceptorAuthFailure(t *testing.T) {
 
 	// verify
 	assert.Nil(t, res)
-	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, expectedErr.Error(), status.Convert(err).Message())
 	assert.True(t, authCalled)
 }
 
+func TestAuthStatusError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:         "untyped",
+			err:          errors.New("not authenticated"),
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "not authenticated",
+		},
+		{
+			name:         "unauthenticated",
+			err:          auth.NewUnauthenticatedError(errors.New("invalid token"), `Bearer realm="otel"`),
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "unauthenticated: invalid token",
+		},
+		{
+			name:         "permission_denied",
+			err:          auth.NewPermissionDeniedError(errors.New("tenant not allowed")),
+			expectedCode: codes.PermissionDenied,
+			expectedMsg:  "permission denied: tenant not allowed",
+		},
+		{
+			name:         "internal",
+			err:          auth.NewInternalError(errors.New("identity provider unavailable")),
+			expectedCode: codes.Internal,
+			expectedMsg:  "internal: identity provider unavailable",
+		},
+		{
+			name:         "status",
+			err:          status.Error(codes.ResourceExhausted, "too many attempts"),
+			expectedCode: codes.ResourceExhausted,
+			expectedMsg:  "too many attempts",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authStatusError(tt.err)
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectedMsg, status.Convert(err).Message())
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	principal := &client.Principal{Tenant: "acme"}
+	ctx := client.NewContext(context.Background(), client.Info{Auth: principal})
+
+	var got auth.AuthorizationRequest
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode codes.Code
+	}{
+		{
+			name:         "allowed",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "untyped",
+			err:          errors.New("tenant may not send traces"),
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "internal",
+			err:          auth.NewInternalError(errors.New("policy store unavailable")),
+			expectedCode: codes.Internal,
+		},
+		{
+			name:         "status",
+			err:          status.Error(codes.ResourceExhausted, "quota exceeded"),
+			expectedCode: codes.ResourceExhausted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorizer := auth.NewAuthorizer(auth.WithAuthorize(func(_ context.Context, req auth.AuthorizationRequest) error {
+				got = req
+				return tt.err
+			}))
+			err := authorize(ctx, "/opentelemetry.proto.collector.trace.v1.TraceService/Export", authorizer)
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, auth.AuthorizationRequest{
+				Method:   "/opentelemetry.proto.collector.trace.v1.TraceService/Export",
+				DataType: component.DataTypeTraces,
+				Auth:     principal,
+			}, got)
+		})
+	}
+}
+
+func TestDataTypeFromMethod(t *testing.T) {
+	assert.Equal(t, component.DataTypeTraces, dataTypeFromMethod("/opentelemetry.proto.collector.trace.v1.TraceService/Export"))
+	assert.Equal(t, component.DataTypeMetrics, dataTypeFromMethod("/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"))
+	assert.Equal(t, component.DataTypeLogs, dataTypeFromMethod("/opentelemetry.proto.collector.logs.v1.LogsService/Export"))
+	assert.Equal(t, component.DataType(""), dataTypeFromMethod("/grpc.health.v1.Health/Check"))
+	assert.Equal(t, component.DataType(""), dataTypeFromMethod(""))
+}
+
 func TestDefaultUnaryInterceptorMissingMetadata(t *testing.T) {
 	// prepare
 	authFunc := func(context.Context, map[string][]string) (context.Context, error) {
@@ -1022,7 +1394,8 @@ func TestDefaultStreamInterceptorAuthFailure(t *testing.T) {
 	err := authStreamServerInterceptor(nil, streamServer, &grpc.StreamServerInfo{}, handler, authFunc)
 
 	// verify
-	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, expectedErr.Error(), status.Convert(err).Message())
 	assert.True(t, authCalled)
 }
 
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+const (
+	clientTraceScope             = "go.opentelemetry.io/collector/config/confighttp"
+	clientDNSDurationMetric      = "http_client_dns_duration"
+	clientConnectDurationMetric  = "http_client_connect_duration"
+	clientTLSDurationMetric      = "http_client_tls_handshake_duration"
+	clientConnectionsMetric      = "http_client_connections"
+	clientTraceEndpointAttribute = "endpoint"
+	clientTraceReusedAttribute   = "reused"
+)
+
+// clientTraceRoundTripper records connection level metrics (DNS lookup, connect, TLS handshake
+// and connection reuse) for every request using net/http/httptrace.
+type clientTraceRoundTripper struct {
+	rt           http.RoundTripper
+	dns          syncfloat64.Histogram
+	connect      syncfloat64.Histogram
+	tlsHandshake syncfloat64.Histogram
+	conns        syncint64.Counter
+	attrs        []attribute.KeyValue
+}
+
+func newClientTraceRoundTripper(rt http.RoundTripper, mp metric.MeterProvider, endpoint string) (*clientTraceRoundTripper, error) {
+	meter := mp.Meter(clientTraceScope)
+	dns, err := meter.SyncFloat64().Histogram(
+		clientDNSDurationMetric,
+		instrument.WithDescription("Duration of DNS lookups performed by the HTTP client."),
+		instrument.WithUnit(unit.Milliseconds),
+	)
+	if err != nil {
+		return nil, err
+	}
+	connect, err := meter.SyncFloat64().Histogram(
+		clientConnectDurationMetric,
+		instrument.WithDescription("Duration of new connections established by the HTTP client."),
+		instrument.WithUnit(unit.Milliseconds),
+	)
+	if err != nil {
+		return nil, err
+	}
+	tlsHandshake, err := meter.SyncFloat64().Histogram(
+		clientTLSDurationMetric,
+		instrument.WithDescription("Duration of TLS handshakes performed by the HTTP client."),
+		instrument.WithUnit(unit.Milliseconds),
+	)
+	if err != nil {
+		return nil, err
+	}
+	conns, err := meter.SyncInt64().Counter(
+		clientConnectionsMetric,
+		instrument.WithDescription("Number of connections obtained by the HTTP client, by whether they were reused from the idle pool."),
+		instrument.WithUnit(unit.Dimensionless),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &clientTraceRoundTripper{
+		rt:           rt,
+		dns:          dns,
+		connect:      connect,
+		tlsHandshake: tlsHandshake,
+		conns:        conns,
+		attrs:        []attribute.KeyValue{attribute.String(clientTraceEndpointAttribute, endpoint)},
+	}, nil
+}
+
+func (c *clientTraceRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	// Dials may happen concurrently (e.g. happy eyeballs), so the start times are guarded.
+	var (
+		mu           sync.Mutex
+		dnsStart     time.Time
+		tlsStart     time.Time
+		connectStart = map[string]time.Time{}
+	)
+	since := func(start time.Time) float64 {
+		return float64(time.Since(start)) / float64(time.Millisecond)
+	}
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			mu.Lock()
+			dnsStart = time.Now()
+			mu.Unlock()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			mu.Lock()
+			start := dnsStart
+			mu.Unlock()
+			if !start.IsZero() {
+				c.dns.Record(ctx, since(start), c.attrs...)
+			}
+		},
+		ConnectStart: func(network, addr string) {
+			mu.Lock()
+			connectStart[network+addr] = time.Now()
+			mu.Unlock()
+		},
+		ConnectDone: func(network, addr string, err error) {
+			mu.Lock()
+			start, ok := connectStart[network+addr]
+			delete(connectStart, network+addr)
+			mu.Unlock()
+			if ok && err == nil {
+				c.connect.Record(ctx, since(start), c.attrs...)
+			}
+		},
+		TLSHandshakeStart: func() {
+			mu.Lock()
+			tlsStart = time.Now()
+			mu.Unlock()
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			mu.Lock()
+			start := tlsStart
+			mu.Unlock()
+			if !start.IsZero() && err == nil {
+				c.tlsHandshake.Record(ctx, since(start), c.attrs...)
+			}
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			c.conns.Add(ctx, 1, append(c.attrs, attribute.Bool(clientTraceReusedAttribute, info.Reused))...)
+		},
+	}
+	return c.rt.RoundTrip(req.WithContext(httptrace.WithClientTrace(ctx, trace)))
+}
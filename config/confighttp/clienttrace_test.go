@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+)
+
+func TestClientTraceMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		level   configtelemetry.Level
+		enabled bool
+	}{
+		{name: "normal", level: configtelemetry.LevelNormal, enabled: false},
+		{name: "detailed", level: configtelemetry.LevelDetailed, enabled: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			settings := componenttest.NewNopTelemetrySettings()
+			settings.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			settings.MetricsLevel = tt.level
+
+			hcs := &HTTPClientSettings{Endpoint: server.URL}
+			client, err := hcs.ToClient(componenttest.NewNopHost(), settings)
+			require.NoError(t, err)
+
+			for i := 0; i < 2; i++ {
+				resp, err := client.Get(server.URL)
+				require.NoError(t, err)
+				_, err = io.Copy(io.Discard, resp.Body)
+				require.NoError(t, err)
+				require.NoError(t, resp.Body.Close())
+			}
+
+			rm, err := reader.Collect(context.Background())
+			require.NoError(t, err)
+			metrics := map[string]metricdata.Metrics{}
+			for _, sm := range rm.ScopeMetrics {
+				if sm.Scope.Name != clientTraceScope {
+					continue
+				}
+				for _, m := range sm.Metrics {
+					metrics[m.Name] = m
+				}
+			}
+			if !tt.enabled {
+				assert.Empty(t, metrics)
+				return
+			}
+
+			connect, ok := metrics[clientConnectDurationMetric].Data.(metricdata.Histogram)
+			require.True(t, ok)
+			require.Len(t, connect.DataPoints, 1)
+			assert.Equal(t, uint64(1), connect.DataPoints[0].Count)
+
+			conns, ok := metrics[clientConnectionsMetric].Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+			require.Len(t, conns.DataPoints, 2)
+			for _, dp := range conns.DataPoints {
+				assert.Equal(t, int64(1), dp.Value)
+				reused, found := dp.Attributes.Value(clientTraceReusedAttribute)
+				require.True(t, found)
+				assert.Equal(t, attribute.BOOL, reused.Type())
+				endpoint, found := dp.Attributes.Value(clientTraceEndpointAttribute)
+				require.True(t, found)
+				assert.Equal(t, server.URL, endpoint.AsString())
+			}
+		})
+	}
+}
@@ -20,13 +20,20 @@ import (
 	"bytes"
 	"compress/gzip"
 	"compress/zlib"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/golang/snappy"
 	"github.com/klauspost/compress/zstd"
+	"go.uber.org/multierr"
 
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/internal"
 )
 
 type compressRoundTripper struct {
@@ -35,33 +42,65 @@ type compressRoundTripper struct {
 	writer          func(*bytes.Buffer) (io.WriteCloser, error)
 }
 
-func newCompressRoundTripper(rt http.RoundTripper, compressionType configcompression.CompressionType) *compressRoundTripper {
+func newCompressRoundTripper(rt http.RoundTripper, compressionType configcompression.CompressionType, compressionLevel int) *compressRoundTripper {
 	return &compressRoundTripper{
 		RoundTripper:    rt,
 		compressionType: compressionType,
-		writer:          writerFactory(compressionType),
+		writer:          writerFactory(compressionType, compressionLevel),
+	}
+}
+
+// validateCompressionLevel checks that the compression level is supported by the compression type.
+// A zero level is always valid and selects the default level of the compression type.
+func validateCompressionLevel(compressionType configcompression.CompressionType, compressionLevel int) error {
+	if compressionLevel == 0 {
+		return nil
+	}
+	switch compressionType {
+	case configcompression.Gzip, configcompression.Zlib, configcompression.Deflate:
+		if compressionLevel < gzip.HuffmanOnly || compressionLevel > gzip.BestCompression {
+			return fmt.Errorf("invalid compression level %d for %q, must be between %d and %d", compressionLevel, compressionType, gzip.HuffmanOnly, gzip.BestCompression)
+		}
+		return nil
+	case configcompression.Zstd:
+		if compressionLevel < 1 || compressionLevel > 22 {
+			return fmt.Errorf("invalid compression level %d for %q, must be between 1 and 22", compressionLevel, compressionType)
+		}
+		return nil
+	default:
+		return fmt.Errorf("compression level is not supported for %q", compressionType)
 	}
 }
 
 // writerFactory defines writer field in CompressRoundTripper.
 // The validity of input is already checked when NewCompressRoundTripper was called in confighttp,
-func writerFactory(compressionType configcompression.CompressionType) func(*bytes.Buffer) (io.WriteCloser, error) {
+func writerFactory(compressionType configcompression.CompressionType, compressionLevel int) func(*bytes.Buffer) (io.WriteCloser, error) {
 	switch compressionType {
 	case configcompression.Gzip:
+		if compressionLevel == 0 {
+			compressionLevel = gzip.DefaultCompression
+		}
 		return func(buf *bytes.Buffer) (io.WriteCloser, error) {
-			return gzip.NewWriter(buf), nil
+			return gzip.NewWriterLevel(buf, compressionLevel)
 		}
 	case configcompression.Snappy:
 		return func(buf *bytes.Buffer) (io.WriteCloser, error) {
 			return snappy.NewBufferedWriter(buf), nil
 		}
 	case configcompression.Zstd:
+		var opts []zstd.EOption
+		if compressionLevel != 0 {
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(compressionLevel)))
+		}
 		return func(buf *bytes.Buffer) (io.WriteCloser, error) {
-			return zstd.NewWriter(buf)
+			return zstd.NewWriter(buf, opts...)
 		}
 	case configcompression.Zlib, configcompression.Deflate:
+		if compressionLevel == 0 {
+			compressionLevel = zlib.DefaultCompression
+		}
 		return func(buf *bytes.Buffer) (io.WriteCloser, error) {
-			return zlib.NewWriter(buf), nil
+			return zlib.NewWriterLevel(buf, compressionLevel)
 		}
 	}
 	return nil
@@ -117,6 +156,8 @@ type errorHandler func(w http.ResponseWriter, r *http.Request, errorMsg string,
 
 type decompressor struct {
 	errorHandler
+	maxDecompressedBodySize int64
+	rejections              *internal.RequestRejections
 }
 
 type decompressorOption func(d *decompressor)
@@ -127,10 +168,26 @@ func withErrorHandlerForDecompressor(e errorHandler) decompressorOption {
 	}
 }
 
+// withMaxDecompressedBodySize limits the size of the request bodies once decompressed, protecting the server
+// from decompression bombs. A non-positive size disables the limit.
+func withMaxDecompressedBodySize(size int64) decompressorOption {
+	return func(d *decompressor) {
+		d.maxDecompressedBodySize = size
+	}
+}
+
+// withRejectionsForDecompressor records the requests rejected by the decompressor.
+func withRejectionsForDecompressor(r *internal.RequestRejections) decompressorOption {
+	return func(d *decompressor) {
+		d.rejections = r
+	}
+}
+
 // httpContentDecompressor offloads the task of handling compressed HTTP requests
 // by identifying the compression format in the "Content-Encoding" header and re-writing
 // request body so that the handlers further in the chain can work on decompressed data.
-// It supports gzip and deflate/zlib compression.
+// It supports gzip, deflate/zlib and zstd compression. A list of encodings is decoded in the
+// reverse order it was applied, and is rejected if any of the encodings is not supported.
 func httpContentDecompressor(h http.Handler, opts ...decompressorOption) http.Handler {
 	d := &decompressor{}
 	for _, o := range opts {
@@ -146,6 +203,12 @@ func (d *decompressor) wrap(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		newBody, err := newBodyReader(r)
 		if err != nil {
+			var unsupportedErr *unsupportedEncodingError
+			if errors.As(err, &unsupportedErr) {
+				d.reject(r.Context(), internal.RejectReasonContentEncoding)
+				d.errorHandler(w, r, err.Error(), http.StatusUnsupportedMediaType)
+				return
+			}
 			d.errorHandler(w, r, err.Error(), http.StatusBadRequest)
 			return
 		}
@@ -158,27 +221,164 @@ func (d *decompressor) wrap(h http.Handler) http.Handler {
 			r.Header.Del("Content-Length")
 			r.ContentLength = -1
 			r.Body = newBody
+			if d.maxDecompressedBodySize > 0 {
+				ctx := r.Context()
+				r.Body = &maxDecompressedBodyReader{
+					ReadCloser: newBody,
+					limit:      d.maxDecompressedBodySize,
+					remaining:  d.maxDecompressedBodySize,
+					onExceeded: func() { d.reject(ctx, internal.RejectReasonDecompressedBodySize) },
+				}
+			}
 		}
 		h.ServeHTTP(w, r)
 	})
 }
 
+func (d *decompressor) reject(ctx context.Context, reason string) {
+	if d.rejections != nil {
+		d.rejections.Record(ctx, reason)
+	}
+}
+
+// maxDecompressedBodyReader fails the reads once more than limit bytes of the decompressed body are read.
+type maxDecompressedBodyReader struct {
+	io.ReadCloser
+	limit      int64
+	remaining  int64
+	onExceeded func()
+	err        error
+}
+
+func (r *maxDecompressedBodyReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	// Read one byte more than remaining to find out if the body exceeds the limit.
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.ReadCloser.Read(p)
+	if int64(n) <= r.remaining {
+		r.remaining -= int64(n)
+		return n, err
+	}
+	n = int(r.remaining)
+	r.remaining = 0
+	r.err = fmt.Errorf("request body exceeds the max_decompressed_body_size of %d bytes", r.limit)
+	r.onExceeded()
+	return n, r.err
+}
+
+// unsupportedEncodingError is returned when a list of content encodings contains an encoding
+// the decompressor cannot decode.
+type unsupportedEncodingError struct {
+	encoding string
+}
+
+func (e *unsupportedEncodingError) Error() string {
+	return fmt.Sprintf("unsupported content encoding: %q", e.encoding)
+}
+
+// contentEncodings returns the encodings listed in the "Content-Encoding" headers
+// in the order they were applied, skipping "identity".
+func contentEncodings(h http.Header) []string {
+	var encodings []string
+	for _, value := range h.Values(headerContentEncoding) {
+		for _, encoding := range strings.Split(value, ",") {
+			encoding = strings.ToLower(strings.TrimSpace(encoding))
+			if encoding == "" || encoding == "identity" {
+				continue
+			}
+			encodings = append(encodings, encoding)
+		}
+	}
+	return encodings
+}
+
+// newBodyReader returns a reader decoding the request body, or nil if the body is not encoded.
+// A single unknown encoding is left to the handlers, a list of encodings must be fully supported.
 func newBodyReader(r *http.Request) (io.ReadCloser, error) {
-	switch r.Header.Get("Content-Encoding") {
-	case "gzip":
-		gr, err := gzip.NewReader(r.Body)
+	encodings := contentEncodings(r.Header)
+	if len(encodings) == 0 {
+		return nil, nil
+	}
+	if len(encodings) == 1 && !isSupportedEncoding(encodings[0]) {
+		return nil, nil
+	}
+	for _, encoding := range encodings {
+		if !isSupportedEncoding(encoding) {
+			return nil, &unsupportedEncodingError{encoding: encoding}
+		}
+	}
+
+	body := &decodedBody{Reader: r.Body}
+	for i := len(encodings) - 1; i >= 0; i-- {
+		dr, err := newDecoder(encodings[i], body.Reader)
 		if err != nil {
+			_ = body.Close()
 			return nil, err
 		}
-		return gr, nil
-	case "deflate", "zlib":
-		zr, err := zlib.NewReader(r.Body)
+		body.Reader = dr
+		body.closers = append(body.closers, dr)
+	}
+	return body, nil
+}
+
+// decoders are keyed by the content encodings supported by the servers.
+var decoders = map[string]func(r io.Reader) (io.ReadCloser, error){
+	"gzip": func(r io.Reader) (io.ReadCloser, error) {
+		return gzip.NewReader(r)
+	},
+	"deflate": zlib.NewReader,
+	"zlib":    zlib.NewReader,
+	"zstd": func(r io.Reader) (io.ReadCloser, error) {
+		zr, err := zstd.NewReader(r,
+			// Concurrency 1 disables async decoding, avoiding goroutines that outlive the request.
+			zstd.WithDecoderConcurrency(1),
+		)
 		if err != nil {
 			return nil, err
 		}
-		return zr, nil
+		return zr.IOReadCloser(), nil
+	},
+}
+
+// SupportedContentEncodings returns the sorted content encodings that the HTTP servers decompress.
+func SupportedContentEncodings() []string {
+	encodings := make([]string, 0, len(decoders))
+	for encoding := range decoders {
+		encodings = append(encodings, encoding)
+	}
+	sort.Strings(encodings)
+	return encodings
+}
+
+func isSupportedEncoding(encoding string) bool {
+	_, ok := decoders[encoding]
+	return ok
+}
+
+func newDecoder(encoding string, r io.Reader) (io.ReadCloser, error) {
+	newReader, ok := decoders[encoding]
+	if !ok {
+		return nil, &unsupportedEncodingError{encoding: encoding}
+	}
+	return newReader(r)
+}
+
+// decodedBody reads the outermost decoder and closes all the decoders of the body.
+type decodedBody struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (b *decodedBody) Close() error {
+	var errs error
+	for i := len(b.closers) - 1; i >= 0; i-- {
+		errs = multierr.Append(errs, b.closers[i].Close())
 	}
-	return nil, nil
+	return errs
 }
 
 // defaultErrorHandler writes the error message in plain text.
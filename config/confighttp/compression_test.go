@@ -122,7 +122,7 @@ func TestHTTPClientCompression(t *testing.T) {
 
 			client := http.Client{}
 			if configcompression.IsCompressed(tt.encoding) {
-				client.Transport = newCompressRoundTripper(http.DefaultTransport, tt.encoding)
+				client.Transport = newCompressRoundTripper(http.DefaultTransport, tt.encoding, 0)
 			}
 			res, err := client.Do(req)
 			if tt.shouldError {
@@ -172,6 +172,14 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 			},
 			respCode: 200,
 		},
+		{
+			name:     "ValidZstd",
+			encoding: "zstd",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return compressZstd(testBody)
+			},
+			respCode: 200,
+		},
 		{
 			name:     "InvalidGzip",
 			encoding: "gzip",
@@ -190,6 +198,35 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 			respCode: 400,
 			respBody: "zlib: invalid header\n",
 		},
+		{
+			name:     "MultipleEncodings",
+			encoding: "zstd, gzip",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				compressed, err := compressZstd(testBody)
+				if err != nil {
+					return nil, err
+				}
+				return compressGzip(compressed.Bytes())
+			},
+			respCode: 200,
+		},
+		{
+			name:     "IdentityEncoding",
+			encoding: "gzip, identity",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return compressGzip(testBody)
+			},
+			respCode: 200,
+		},
+		{
+			name:     "UnsupportedEncodingInList",
+			encoding: "gzip, br",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return compressGzip(testBody)
+			},
+			respCode: 415,
+			respBody: "unsupported content encoding: \"br\"\n",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -235,6 +272,31 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 	}
 }
 
+func TestHTTPContentDecompressionMaxSize(t *testing.T) {
+	handler := httpContentDecompressor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}), withMaxDecompressedBodySize(int64(len("uncompressed_text"))))
+
+	serve := func(body string) *http.Response {
+		compressed, err := compressGzip([]byte(body))
+		require.NoError(t, err)
+		req := httptest.NewRequest("POST", "/", compressed)
+		req.Header.Set("Content-Encoding", "gzip")
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, req)
+		return response.Result()
+	}
+
+	assert.Equal(t, http.StatusOK, serve("uncompressed_text").StatusCode)
+	resp := serve("uncompressed_text!")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "request body exceeds the max_decompressed_body_size of 17 bytes\n", string(body))
+}
+
 func TestHTTPContentCompressionRequestWithNilBody(t *testing.T) {
 	compressedGzipBody, _ := compressGzip([]byte{})
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -249,7 +311,7 @@ func TestHTTPContentCompressionRequestWithNilBody(t *testing.T) {
 	require.NoError(t, err, "failed to create request to test handler")
 
 	client := http.Client{}
-	client.Transport = newCompressRoundTripper(http.DefaultTransport, configcompression.Gzip)
+	client.Transport = newCompressRoundTripper(http.DefaultTransport, configcompression.Gzip, 0)
 	res, err := client.Do(req)
 	require.NoError(t, err)
 
@@ -284,7 +346,7 @@ func TestHTTPContentCompressionCopyError(t *testing.T) {
 	}
 
 	client := http.Client{}
-	client.Transport = newCompressRoundTripper(http.DefaultTransport, configcompression.Gzip)
+	client.Transport = newCompressRoundTripper(http.DefaultTransport, configcompression.Gzip, 0)
 	_, err := client.Do(req)
 	require.Error(t, err)
 }
@@ -316,7 +378,7 @@ func TestHTTPContentCompressionRequestBodyCloseError(t *testing.T) {
 	}
 
 	client := http.Client{}
-	client.Transport = newCompressRoundTripper(http.DefaultTransport, configcompression.Gzip)
+	client.Transport = newCompressRoundTripper(http.DefaultTransport, configcompression.Gzip, 0)
 	_, err := client.Do(req)
 	require.Error(t, err)
 }
@@ -376,3 +438,60 @@ func compressZstd(body []byte) (*bytes.Buffer, error) {
 
 	return &buf, nil
 }
+
+func TestValidateCompressionLevel(t *testing.T) {
+	tests := []struct {
+		name        string
+		encoding    configcompression.CompressionType
+		level       int
+		shouldError bool
+	}{
+		{
+			name:     "DefaultLevel",
+			encoding: configcompression.Snappy,
+			level:    0,
+		},
+		{
+			name:     "ValidGzip",
+			encoding: configcompression.Gzip,
+			level:    1,
+		},
+		{
+			name:        "InvalidGzip",
+			encoding:    configcompression.Gzip,
+			level:       10,
+			shouldError: true,
+		},
+		{
+			name:     "ValidZstd",
+			encoding: configcompression.Zstd,
+			level:    22,
+		},
+		{
+			name:        "InvalidZstd",
+			encoding:    configcompression.Zstd,
+			level:       23,
+			shouldError: true,
+		},
+		{
+			name:        "UnsupportedSnappy",
+			encoding:    configcompression.Snappy,
+			level:       1,
+			shouldError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompressionLevel(tt.encoding, tt.level)
+			if tt.shouldError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSupportedContentEncodings(t *testing.T) {
+	assert.Equal(t, []string{"deflate", "gzip", "zlib", "zstd"}, SupportedContentEncodings())
+}
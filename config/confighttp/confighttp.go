@@ -17,8 +17,12 @@ package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
 import (
 	"crypto/tls"
 	"errors"
+	"fmt"
+	"io"
+	"math"
 	"net"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/rs/cors"
@@ -29,9 +33,12 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/config/internal"
 	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/featuregate"
 )
 
 const headerContentEncoding = "Content-Encoding"
@@ -66,6 +73,10 @@ type HTTPClientSettings struct {
 	// The compression key for supported compression types within collector.
 	Compression configcompression.CompressionType `mapstructure:"compression"`
 
+	// CompressionLevel trades compression speed for ratio. Supported for gzip, zlib and deflate
+	// (1 to 9, or -2 for Huffman only) and for zstd (1 to 22). Zero uses the default level.
+	CompressionLevel int `mapstructure:"compression_level"`
+
 	// MaxIdleConns is used to set a limit to the maximum idle HTTP connections the client can keep open.
 	// There's an already set value, and we want to override it only if an explicit value provided
 	MaxIdleConns *int `mapstructure:"max_idle_conns"`
@@ -133,9 +144,16 @@ func (hcs *HTTPClientSettings) ToClient(host component.Host, settings component.
 	}
 
 	clientTransport := (http.RoundTripper)(transport)
+	// Connection level metrics are only recorded at detailed level, they add overhead to every request.
+	if settings.MetricsLevel >= configtelemetry.LevelDetailed && settings.MeterProvider != nil {
+		clientTransport, err = newClientTraceRoundTripper(clientTransport, settings.MeterProvider, hcs.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if len(hcs.Headers) > 0 {
 		clientTransport = &headerRoundTripper{
-			transport: transport,
+			transport: clientTransport,
 			headers:   hcs.Headers,
 		}
 	}
@@ -152,7 +170,10 @@ func (hcs *HTTPClientSettings) ToClient(host component.Host, settings component.
 	// Compress the body using specified compression methods if non-empty string is provided.
 	// Supporting gzip, zlib, deflate, snappy, and zstd; none is treated as uncompressed.
 	if configcompression.IsCompressed(hcs.Compression) {
-		clientTransport = newCompressRoundTripper(clientTransport, hcs.Compression)
+		if err = validateCompressionLevel(hcs.Compression, hcs.CompressionLevel); err != nil {
+			return nil, err
+		}
+		clientTransport = newCompressRoundTripper(clientTransport, hcs.Compression, hcs.CompressionLevel)
 	}
 
 	if hcs.Auth != nil {
@@ -168,7 +189,10 @@ func (hcs *HTTPClientSettings) ToClient(host component.Host, settings component.
 
 		clientTransport, err = httpCustomAuthRoundTripper.RoundTripper(clientTransport)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("authenticator %q cannot authenticate HTTP requests: %w", hcs.Auth.AuthenticatorID, err)
+		}
+		if clientTransport == nil {
+			return nil, fmt.Errorf("authenticator %q cannot authenticate HTTP requests: %w", hcs.Auth.AuthenticatorID, auth.ErrTransportNotSupported)
 		}
 	}
 
@@ -211,12 +235,28 @@ type HTTPServerSettings struct {
 	// CORS configures the server for HTTP cross-origin resource sharing (CORS).
 	CORS *CORSSettings `mapstructure:"cors"`
 
+	// IPFilter restricts the client addresses allowed to send requests to the server.
+	// It is evaluated before Auth.
+	IPFilter *confignet.IPFilterSettings `mapstructure:"ip_filter"`
+
+	// RateLimit limits the rate of the requests accepted by the server. It is evaluated after IPFilter
+	// and before Auth.
+	RateLimit *RateLimitSettings `mapstructure:"rate_limit"`
+
 	// Auth for this receiver
 	Auth *configauth.Authentication `mapstructure:"auth"`
 
 	// MaxRequestBodySize sets the maximum request body size in bytes
 	MaxRequestBodySize int64 `mapstructure:"max_request_body_size"`
 
+	// MaxDecompressedBodySize sets the maximum size in bytes of a compressed request body once decompressed,
+	// protecting the server from decompression bombs. By default (zero) it is not limited.
+	MaxDecompressedBodySize int64 `mapstructure:"max_decompressed_body_size"`
+
+	// MaxRequestHeaderBytes sets the maximum size in bytes of the request line and headers.
+	// By default (zero) it is http.DefaultMaxHeaderBytes.
+	MaxRequestHeaderBytes int `mapstructure:"max_request_header_bytes"`
+
 	// IncludeMetadata propagates the client metadata from the incoming requests to the downstream consumers
 	// Experimental: *NOTE* this option is subject to change or removal in the future.
 	IncludeMetadata bool `mapstructure:"include_metadata"`
@@ -268,16 +308,35 @@ func (hss *HTTPServerSettings) ToServer(host component.Host, settings component.
 		o(serverOpts)
 	}
 
+	if hss.MaxDecompressedBodySize < 0 {
+		return nil, errors.New("max_decompressed_body_size must not be negative")
+	}
+	if hss.MaxRequestHeaderBytes < 0 {
+		return nil, errors.New("max_request_header_bytes must not be negative")
+	}
+
+	rejections, err := internal.NewRequestRejections(settings.MeterProvider, "http", hss.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
 	handler = httpContentDecompressor(
 		handler,
 		withErrorHandlerForDecompressor(serverOpts.errorHandler),
+		withMaxDecompressedBodySize(hss.MaxDecompressedBodySize),
+		withRejectionsForDecompressor(rejections),
 	)
 
+	handler = contentLengthInterceptor(handler, hss.MaxRequestBodySize, rejections)
+
 	if hss.MaxRequestBodySize > 0 {
 		handler = maxRequestBodySizeInterceptor(handler, hss.MaxRequestBodySize)
 	}
 
 	if hss.Auth != nil {
+		if hss.Auth.AuthorizerID != nil {
+			return nil, errors.New("auth authorizer is not supported by HTTP servers")
+		}
 		authenticator, err := hss.Auth.GetServerAuthenticator(host.GetExtensions())
 		if err != nil {
 			return nil, err
@@ -286,6 +345,27 @@ func (hss *HTTPServerSettings) ToServer(host component.Host, settings component.
 		handler = authInterceptor(handler, authenticator.Authenticate)
 	}
 
+	if hss.RateLimit != nil {
+		if err := hss.RateLimit.Validate(); err != nil {
+			return nil, err
+		}
+		limiter, err := internal.NewRateLimiter(hss.RateLimit.RequestsPerSecond, hss.RateLimit.burst(), hss.RateLimit.PerClient, settings.MeterProvider, "http", hss.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		handler = rateLimitInterceptor(handler, limiter)
+	}
+
+	if hss.IPFilter != nil {
+		filter, err := internal.NewIPFilter(hss.IPFilter, settings.MeterProvider, featuregate.GetRegistry(), "http", hss.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		handler = ipFilterInterceptor(handler, filter)
+	}
+
 	if hss.CORS != nil && len(hss.CORS.AllowedOrigins) > 0 {
 		co := cors.Options{
 			AllowedOrigins:   hss.CORS.AllowedOrigins,
@@ -317,10 +397,42 @@ func (hss *HTTPServerSettings) ToServer(host component.Host, settings component.
 	}
 
 	return &http.Server{
-		Handler: handler,
+		Handler:        handler,
+		MaxHeaderBytes: hss.MaxRequestHeaderBytes,
 	}, nil
 }
 
+// RateLimitSettings configures the rate limiting of the requests accepted by a server, with a token bucket.
+type RateLimitSettings struct {
+	// RequestsPerSecond is the sustained number of requests per second accepted by the server.
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+
+	// Burst is the number of requests that can be accepted at once, above RequestsPerSecond.
+	// By default (zero) it is RequestsPerSecond rounded up.
+	Burst int `mapstructure:"burst"`
+
+	// PerClient applies the limit to every client IP address separately, instead of to all the clients together.
+	PerClient bool `mapstructure:"per_client"`
+}
+
+// Validate checks that the rate and the burst are valid.
+func (rls *RateLimitSettings) Validate() error {
+	if rls.RequestsPerSecond <= 0 {
+		return errors.New("rate_limit requests_per_second must be positive")
+	}
+	if rls.Burst < 0 {
+		return errors.New("rate_limit burst must not be negative")
+	}
+	return nil
+}
+
+func (rls *RateLimitSettings) burst() int {
+	if rls.Burst > 0 {
+		return rls.Burst
+	}
+	return int(math.Ceil(rls.RequestsPerSecond))
+}
+
 // CORSSettings configures a receiver for HTTP cross-origin resource sharing (CORS).
 // See the underlying https://github.com/rs/cors package for details.
 type CORSSettings struct {
@@ -347,7 +459,7 @@ func authInterceptor(next http.Handler, authenticate auth.AuthenticateFunc) http
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx, err := authenticate(r.Context(), r.Header)
 		if err != nil {
-			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			writeAuthError(w, err)
 			return
 		}
 
@@ -355,9 +467,112 @@ func authInterceptor(next http.Handler, authenticate auth.AuthenticateFunc) http
 	})
 }
 
+// writeAuthError answers with the HTTP status matching the kind of the authentication error,
+// and the WWW-Authenticate challenge if the authenticator provided one.
+func writeAuthError(w http.ResponseWriter, err error) {
+	status := http.StatusUnauthorized
+	var authErr *auth.Error
+	if errors.As(err, &authErr) {
+		switch authErr.Kind {
+		case auth.ErrorKindPermissionDenied:
+			status = http.StatusForbidden
+		case auth.ErrorKindInternal:
+			status = http.StatusInternalServerError
+		}
+		if status == http.StatusUnauthorized && authErr.Challenge != "" {
+			w.Header().Set("WWW-Authenticate", authErr.Challenge)
+		}
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+func ipFilterInterceptor(next http.Handler, filter *internal.IPFilter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var addr net.Addr
+		if ip := parseIP(r.RemoteAddr); ip != nil {
+			addr = ip
+		}
+		if !filter.Allowed(r.Context(), addr) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rateLimitInterceptor answers with 429 Too Many Requests to the requests exceeding the limit, telling the
+// client when to retry in the Retry-After header.
+func rateLimitInterceptor(next http.Handler, limiter *internal.RateLimiter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := r.RemoteAddr
+		if ip := parseIP(r.RemoteAddr); ip != nil {
+			client = ip.String()
+		}
+		if allowed, wait := limiter.Allow(r.Context(), client); !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func maxRequestBodySizeInterceptor(next http.Handler, maxRecvSize int64) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		r.Body = http.MaxBytesReader(w, r.Body, maxRecvSize)
 		next.ServeHTTP(w, r)
 	})
 }
+
+// contentLengthInterceptor rejects the requests declaring a Content-Length above maxRecvSize with
+// 413 Request Entity Too Large, and fails the reads of the bodies that do not match their Content-Length.
+func contentLengthInterceptor(next http.Handler, maxRecvSize int64, rejections *internal.RequestRejections) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx := r.Context()
+		if maxRecvSize > 0 && r.ContentLength > maxRecvSize {
+			rejections.Record(ctx, internal.RejectReasonContentLength)
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = &contentLengthReader{
+			ReadCloser:    r.Body,
+			contentLength: r.ContentLength,
+			remaining:     r.ContentLength,
+			onMismatch:    func() { rejections.Record(ctx, internal.RejectReasonContentLength) },
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// contentLengthReader fails the reads once the body turns out to be shorter or longer than its Content-Length.
+type contentLengthReader struct {
+	io.ReadCloser
+	contentLength int64
+	remaining     int64
+	onMismatch    func()
+	err           error
+}
+
+func (r *contentLengthReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.ReadCloser.Read(p)
+	r.remaining -= int64(n)
+	switch {
+	case r.remaining < 0:
+		n += int(r.remaining)
+	case errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF) && r.remaining > 0:
+	default:
+		return n, err
+	}
+	r.err = fmt.Errorf("request body does not match its Content-Length of %d bytes", r.contentLength)
+	r.onMismatch()
+	return n, r.err
+}
@@ -15,6 +15,7 @@
 package confighttp
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -24,6 +25,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -36,6 +38,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/extension/auth"
 	"go.opentelemetry.io/collector/extension/auth/authtest"
@@ -318,6 +321,19 @@ func TestHTTPClientSettingWithAuthConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with_auth_configuration_has_grpc_only_extension",
+			settings: HTTPClientSettings{
+				Endpoint: "localhost:1234",
+				Auth:     &configauth.Authentication{AuthenticatorID: component.NewID("mock")},
+			},
+			shouldErr: true,
+			host: &mockHost{
+				ext: map[component.ID]component.Component{
+					component.NewID("mock"): &authtest.MockClient{},
+				},
+			},
+		},
 		{
 			name: "with_auth_configuration_has_err_extension",
 			settings: HTTPClientSettings{
@@ -350,6 +366,18 @@ func TestHTTPClientSettingWithAuthConfig(t *testing.T) {
 	}
 }
 
+func TestHTTPClientAuthTransportNotSupported(t *testing.T) {
+	hcs := HTTPClientSettings{
+		Endpoint: "localhost:1234",
+		Auth:     &configauth.Authentication{AuthenticatorID: component.NewID("testauth")},
+	}
+	// An authenticator built without a round tripper only supports gRPC.
+	host := &mockHost{ext: map[component.ID]component.Component{component.NewID("testauth"): auth.NewClient()}}
+	_, err := hcs.ToClient(host, componenttest.NewNopTelemetrySettings())
+	assert.ErrorIs(t, err, auth.ErrTransportNotSupported)
+	assert.ErrorContains(t, err, `authenticator "testauth" cannot authenticate HTTP requests`)
+}
+
 func TestHTTPServerSettingsError(t *testing.T) {
 	tests := []struct {
 		settings HTTPServerSettings
@@ -972,6 +1000,20 @@ func TestInvalidServerAuth(t *testing.T) {
 	require.Nil(t, srv)
 }
 
+func TestServerAuthorizerNotSupported(t *testing.T) {
+	authorizerID := component.NewID("authz")
+	hss := HTTPServerSettings{
+		Auth: &configauth.Authentication{
+			AuthenticatorID: component.NewID("mock"),
+			AuthorizerID:    &authorizerID,
+		},
+	}
+
+	srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.NewServeMux())
+	require.EqualError(t, err, "auth authorizer is not supported by HTTP servers")
+	require.Nil(t, srv)
+}
+
 func TestFailedServerAuth(t *testing.T) {
 	// prepare
 	hss := HTTPServerSettings{
@@ -1002,6 +1044,175 @@ func TestFailedServerAuth(t *testing.T) {
 	assert.Equal(t, response.Result().Status, fmt.Sprintf("%v %s", http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized)))
 }
 
+func TestFailedServerAuthErrorKinds(t *testing.T) {
+	tests := []struct {
+		name              string
+		err               error
+		expectedStatus    int
+		expectedChallenge string
+	}{
+		{
+			name:              "unauthenticated",
+			err:               auth.NewUnauthenticatedError(errors.New("invalid token"), `Bearer realm="otel"`),
+			expectedStatus:    http.StatusUnauthorized,
+			expectedChallenge: `Bearer realm="otel"`,
+		},
+		{
+			name:           "permission_denied",
+			err:            auth.NewPermissionDeniedError(errors.New("tenant not allowed")),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "internal",
+			err:            fmt.Errorf("wrapped: %w", auth.NewInternalError(errors.New("identity provider unavailable"))),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hss := HTTPServerSettings{
+				Endpoint: "localhost:0",
+				Auth: &configauth.Authentication{
+					AuthenticatorID: component.NewID("mock"),
+				},
+			}
+			host := &mockHost{
+				ext: map[component.ID]component.Component{
+					component.NewID("mock"): auth.NewServer(
+						auth.WithAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+							return ctx, tt.err
+						}),
+					),
+				},
+			}
+
+			srv, err := hss.ToServer(host, componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			require.NoError(t, err)
+
+			response := httptest.NewRecorder()
+			srv.Handler.ServeHTTP(response, httptest.NewRequest("GET", "/", nil))
+
+			assert.Equal(t, tt.expectedStatus, response.Result().StatusCode)
+			assert.Equal(t, tt.expectedChallenge, response.Result().Header.Get("WWW-Authenticate"))
+		})
+	}
+}
+
+func TestServerIPFilter(t *testing.T) {
+	authCalled := false
+	hss := HTTPServerSettings{
+		Endpoint: "localhost:0",
+		IPFilter: &confignet.IPFilterSettings{
+			Allow: []string{"10.0.0.0/8"},
+			Deny:  []string{"10.1.0.0/16"},
+		},
+		Auth: &configauth.Authentication{
+			AuthenticatorID: component.NewID("mock"),
+		},
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			component.NewID("mock"): auth.NewServer(
+				auth.WithAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+					authCalled = true
+					return ctx, nil
+				}),
+			),
+		},
+	}
+
+	srv, err := hss.ToServer(host, componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	require.NoError(t, err)
+
+	tests := []struct {
+		remoteAddr     string
+		expectedStatus int
+	}{
+		{remoteAddr: "10.0.0.1:4318", expectedStatus: http.StatusOK},
+		{remoteAddr: "10.1.0.1:4318", expectedStatus: http.StatusForbidden},
+		{remoteAddr: "192.0.2.1:4318", expectedStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.remoteAddr, func(t *testing.T) {
+			authCalled = false
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			response := httptest.NewRecorder()
+			srv.Handler.ServeHTTP(response, req)
+
+			assert.Equal(t, tt.expectedStatus, response.Result().StatusCode)
+			assert.Equal(t, tt.expectedStatus == http.StatusOK, authCalled)
+		})
+	}
+}
+
+func TestInvalidServerIPFilter(t *testing.T) {
+	hss := HTTPServerSettings{
+		IPFilter: &confignet.IPFilterSettings{Deny: []string{"not-an-ip"}},
+	}
+
+	srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.NewServeMux())
+	require.Error(t, err)
+	require.Nil(t, srv)
+}
+
+func TestServerRateLimit(t *testing.T) {
+	authCalls := 0
+	hss := HTTPServerSettings{
+		Endpoint: "localhost:0",
+		RateLimit: &RateLimitSettings{
+			RequestsPerSecond: 0.5,
+			PerClient:         true,
+		},
+		Auth: &configauth.Authentication{
+			AuthenticatorID: component.NewID("mock"),
+		},
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			component.NewID("mock"): auth.NewServer(
+				auth.WithAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+					authCalls++
+					return ctx, nil
+				}),
+			),
+		},
+	}
+
+	srv, err := hss.ToServer(host, componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	require.NoError(t, err)
+
+	serve := func(remoteAddr string) *http.Response {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = remoteAddr
+		response := httptest.NewRecorder()
+		srv.Handler.ServeHTTP(response, req)
+		return response.Result()
+	}
+
+	assert.Equal(t, http.StatusOK, serve("10.0.0.1:4318").StatusCode)
+	resp := serve("10.0.0.1:4319")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	// The limit applies to every client address separately.
+	assert.Equal(t, http.StatusOK, serve("10.0.0.2:4318").StatusCode)
+	// The rejected requests are not authenticated.
+	assert.Equal(t, 2, authCalls)
+}
+
+func TestInvalidServerRateLimit(t *testing.T) {
+	hss := HTTPServerSettings{
+		RateLimit: &RateLimitSettings{RequestsPerSecond: 0},
+	}
+
+	srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.NewServeMux())
+	require.EqualError(t, err, "rate_limit requests_per_second must be positive")
+	require.Nil(t, srv)
+
+	hss.RateLimit = &RateLimitSettings{RequestsPerSecond: 1, Burst: -1}
+	assert.EqualError(t, hss.RateLimit.Validate(), "rate_limit burst must not be negative")
+}
+
 type mockHost struct {
 	component.Host
 	ext map[component.ID]component.Component
@@ -1116,3 +1327,63 @@ func BenchmarkHttpRequest(b *testing.B) {
 		})
 	}
 }
+
+func TestServerRequestGuards(t *testing.T) {
+	hss := HTTPServerSettings{
+		Endpoint:                "localhost:0",
+		MaxRequestBodySize:      64,
+		MaxDecompressedBodySize: 16,
+		MaxRequestHeaderBytes:   4096,
+	}
+
+	srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, 4096, srv.MaxHeaderBytes)
+
+	serve := func(req *http.Request) *http.Response {
+		response := httptest.NewRecorder()
+		srv.Handler.ServeHTTP(response, req)
+		return response.Result()
+	}
+
+	assert.Equal(t, http.StatusOK, serve(httptest.NewRequest("POST", "/", strings.NewReader("payload"))).StatusCode)
+
+	// The declared Content-Length exceeds max_request_body_size.
+	req := httptest.NewRequest("POST", "/", strings.NewReader("payload"))
+	req.ContentLength = 128
+	assert.Equal(t, http.StatusRequestEntityTooLarge, serve(req).StatusCode)
+
+	// The body is shorter than its Content-Length.
+	req = httptest.NewRequest("POST", "/", strings.NewReader("payload"))
+	req.ContentLength = 10
+	assert.Equal(t, http.StatusBadRequest, serve(req).StatusCode)
+
+	// The body is longer than its Content-Length.
+	req = httptest.NewRequest("POST", "/", strings.NewReader("payload"))
+	req.ContentLength = 3
+	assert.Equal(t, http.StatusBadRequest, serve(req).StatusCode)
+
+	// The decompressed body exceeds max_decompressed_body_size.
+	compressed, err := compressGzip(bytes.Repeat([]byte("a"), 1024))
+	require.NoError(t, err)
+	require.Less(t, compressed.Len(), 64)
+	req = httptest.NewRequest("POST", "/", compressed)
+	req.Header.Set("Content-Encoding", "gzip")
+	assert.Equal(t, http.StatusBadRequest, serve(req).StatusCode)
+}
+
+func TestInvalidServerRequestGuards(t *testing.T) {
+	hss := HTTPServerSettings{MaxDecompressedBodySize: -1}
+	srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.NewServeMux())
+	require.EqualError(t, err, "max_decompressed_body_size must not be negative")
+	require.Nil(t, srv)
+
+	hss = HTTPServerSettings{MaxRequestHeaderBytes: -1}
+	srv, err = hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.NewServeMux())
+	require.EqualError(t, err, "max_request_header_bytes must not be negative")
+	require.Nil(t, srv)
+}
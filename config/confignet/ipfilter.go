@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"fmt"
+	"net"
+)
+
+// IPFilterSettings configures which client addresses a server accepts.
+// Entries are CIDR ranges (e.g. "10.0.0.0/8") or single IP addresses.
+type IPFilterSettings struct {
+	// Allow lists the client addresses that are accepted. When empty, every address not
+	// matched by Deny is accepted.
+	Allow []string `mapstructure:"allow"`
+
+	// Deny lists the client addresses that are rejected. Deny takes precedence over Allow.
+	Deny []string `mapstructure:"deny"`
+}
+
+// Validate checks that all the entries are valid CIDR ranges or IP addresses.
+func (s *IPFilterSettings) Validate() error {
+	_, err := s.ToIPFilter()
+	return err
+}
+
+// ToIPFilter parses the settings into an IPFilter.
+func (s *IPFilterSettings) ToIPFilter() (*IPFilter, error) {
+	allow, err := parseCIDRs(s.Allow)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allow entry: %w", err)
+	}
+	deny, err := parseCIDRs(s.Deny)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deny entry: %w", err)
+	}
+	return &IPFilter{allow: allow, deny: deny}, nil
+}
+
+// IPFilter decides whether a client address is accepted based on IPFilterSettings.
+type IPFilter struct {
+	allow []*net.IPNet
+	deny  []*net.IPNet
+}
+
+// Allowed returns true if connections from the given IP are accepted.
+func (f *IPFilter) Allowed(ip net.IP) bool {
+	if ip == nil {
+		// Only addresses without an IP, like unix sockets, get here; they cannot be matched.
+		return len(f.allow) == 0
+	}
+	if containsIP(f.deny, ip) {
+		return false
+	}
+	return len(f.allow) == 0 || containsIP(f.allow, ip)
+}
+
+// AllowedAddr returns true if connections from the given address are accepted.
+// Addresses other than *net.TCPAddr, *net.UDPAddr and *net.IPAddr carry no IP.
+func (f *IPFilter) AllowedAddr(addr net.Addr) bool {
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		return f.Allowed(a.IP)
+	case *net.UDPAddr:
+		return f.Allowed(a.IP)
+	case *net.IPAddr:
+		return f.Allowed(a.IP)
+	}
+	return f.Allowed(nil)
+}
+
+func parseCIDRs(entries []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("%q is neither a CIDR range nor an IP address", entry)
+			}
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			ipNet = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confignet
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPFilterSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		settings    IPFilterSettings
+		expectedErr string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "valid",
+			settings: IPFilterSettings{
+				Allow: []string{"10.0.0.0/8", "192.168.1.10", "2001:db8::/32"},
+				Deny:  []string{"10.1.0.0/16", "::1"},
+			},
+		},
+		{
+			name:        "invalid allow",
+			settings:    IPFilterSettings{Allow: []string{"10.0.0.0/33"}},
+			expectedErr: `invalid allow entry: "10.0.0.0/33" is neither a CIDR range nor an IP address`,
+		},
+		{
+			name:        "invalid deny",
+			settings:    IPFilterSettings{Deny: []string{"localhost"}},
+			expectedErr: `invalid deny entry: "localhost" is neither a CIDR range nor an IP address`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestIPFilterAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings IPFilterSettings
+		allowed  []string
+		rejected []string
+	}{
+		{
+			name:    "no lists",
+			allowed: []string{"10.0.0.1", "::1"},
+		},
+		{
+			name:     "allow only",
+			settings: IPFilterSettings{Allow: []string{"10.0.0.0/8", "192.168.1.10"}},
+			allowed:  []string{"10.0.0.1", "10.255.255.255", "192.168.1.10"},
+			rejected: []string{"11.0.0.1", "192.168.1.11", "::1"},
+		},
+		{
+			name:     "deny only",
+			settings: IPFilterSettings{Deny: []string{"10.0.0.0/8", "::1"}},
+			allowed:  []string{"11.0.0.1", "2001:db8::1"},
+			rejected: []string{"10.0.0.1", "::1"},
+		},
+		{
+			name: "deny takes precedence",
+			settings: IPFilterSettings{
+				Allow: []string{"10.0.0.0/8"},
+				Deny:  []string{"10.1.0.0/16"},
+			},
+			allowed:  []string{"10.0.0.1", "10.2.0.1"},
+			rejected: []string{"10.1.0.1", "11.0.0.1"},
+		},
+		{
+			name:     "ipv4 mapped ipv6",
+			settings: IPFilterSettings{Allow: []string{"10.0.0.0/8"}},
+			allowed:  []string{"::ffff:10.0.0.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := tt.settings.ToIPFilter()
+			require.NoError(t, err)
+			for _, ip := range tt.allowed {
+				assert.True(t, filter.Allowed(net.ParseIP(ip)), ip)
+			}
+			for _, ip := range tt.rejected {
+				assert.False(t, filter.Allowed(net.ParseIP(ip)), ip)
+			}
+		})
+	}
+}
+
+func TestIPFilterAllowedAddr(t *testing.T) {
+	filter, err := (&IPFilterSettings{Allow: []string{"10.0.0.0/8"}}).ToIPFilter()
+	require.NoError(t, err)
+
+	assert.True(t, filter.AllowedAddr(&net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4317}))
+	assert.False(t, filter.AllowedAddr(&net.TCPAddr{IP: net.ParseIP("11.0.0.1"), Port: 4317}))
+	assert.True(t, filter.AllowedAddr(&net.UDPAddr{IP: net.ParseIP("10.0.0.1")}))
+	assert.True(t, filter.AllowedAddr(&net.IPAddr{IP: net.ParseIP("10.0.0.1")}))
+	assert.False(t, filter.AllowedAddr(&net.UnixAddr{Name: "/tmp/otel.sock", Net: "unix"}))
+
+	filter, err = (&IPFilterSettings{Deny: []string{"10.0.0.0/8"}}).ToIPFilter()
+	require.NoError(t, err)
+	assert.True(t, filter.AllowedAddr(&net.UnixAddr{Name: "/tmp/otel.sock", Net: "unix"}))
+}
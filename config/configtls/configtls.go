@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"sync"
 	"time"
 )
 
@@ -41,12 +40,22 @@ type TLSSetting struct {
 	// (optional)
 	CAFile string `mapstructure:"ca_file"`
 
+	// TrustProviders is a list of trust providers registered with RegisterTrustProvider,
+	// whose certificates are trusted in addition to the CA cert, or to the system root CA
+	// if no CA cert is set. (optional)
+	TrustProviders []string `mapstructure:"trust_providers"`
+
 	// Path to the TLS cert to use for TLS required connections. (optional)
 	CertFile string `mapstructure:"cert_file"`
 
 	// Path to the TLS key to use for TLS required connections. (optional)
 	KeyFile string `mapstructure:"key_file"`
 
+	// KeyRef references the TLS key to use instead of KeyFile, as "<scheme>:<reference>", where
+	// scheme is the name of a key provider registered with RegisterKeyProvider, e.g. a PKCS#11
+	// module or the OS keychain. (optional)
+	KeyRef string `mapstructure:"key_ref"`
+
 	// MinVersion sets the minimum TLS version that is acceptable.
 	// If not set, TLS 1.2 will be used. (optional)
 	MinVersion string `mapstructure:"min_version"`
@@ -58,6 +67,11 @@ type TLSSetting struct {
 	// ReloadInterval specifies the duration after which the certificate will be reloaded
 	// If not set, it will never be reloaded (optional)
 	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+
+	// WatchFiles enables watching the cert, key and CA files, which are then reloaded on the next
+	// handshake after they change, in addition to every ReloadInterval if set. The files are checked
+	// at most once per second. (optional)
+	WatchFiles bool `mapstructure:"watch_files"`
 }
 
 // TLSClientSetting contains TLS configurations that are specific to client
@@ -99,64 +113,29 @@ type TLSServerSetting struct {
 	ClientCAFile string `mapstructure:"client_ca_file"`
 }
 
-// certReloader is a wrapper object for certificate reloading
-// Its GetCertificate method will either return the current certificate or reload from disk
-// if the last reload happened more than ReloadInterval ago
-type certReloader struct {
-	// Path to the TLS cert
-	CertFile string
-	// Path to the TLS key
-	KeyFile string
-	// ReloadInterval specifies the duration after which the certificate will be reloaded
-	// If not set, it will never be reloaded (optional)
-	ReloadInterval time.Duration
-	nextReload     time.Time
-	cert           *tls.Certificate
-	lock           sync.RWMutex
+// reloadEnabled returns whether the TLS files are reloaded after the TLS config is loaded.
+func (c TLSSetting) reloadEnabled() bool {
+	return c.ReloadInterval != 0 || c.WatchFiles
 }
 
-func newCertReloader(certFile, keyFile string, reloadInterval time.Duration) (*certReloader, error) {
-	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
-	if err != nil {
-		return nil, err
+// watchedFiles returns the files to watch for changes, or nil if the files are not watched.
+func (c TLSSetting) watchedFiles(files ...string) []string {
+	if !c.WatchFiles {
+		return nil
 	}
-	return &certReloader{
-		CertFile:       certFile,
-		KeyFile:        keyFile,
-		ReloadInterval: reloadInterval,
-		nextReload:     time.Now().Add(reloadInterval),
-		cert:           &cert,
-	}, nil
-}
-
-func (r *certReloader) GetCertificate() (*tls.Certificate, error) {
-	now := time.Now()
-	// Read locking here before we do the time comparison
-	// If a reload is in progress this will block and we will skip reloading in the current
-	// call once we can continue
-	r.lock.RLock()
-	if r.ReloadInterval != 0 && r.nextReload.Before(now) {
-		// Need to release the read lock, otherwise we deadlock
-		r.lock.RUnlock()
-		r.lock.Lock()
-		defer r.lock.Unlock()
-		cert, err := tls.LoadX509KeyPair(r.CertFile, r.KeyFile)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load TLS cert and key: %w", err)
+	var watched []string
+	for _, file := range files {
+		if file != "" {
+			watched = append(watched, file)
 		}
-		r.cert = &cert
-		r.nextReload = now.Add(r.ReloadInterval)
-		return r.cert, nil
 	}
-	defer r.lock.RUnlock()
-	return r.cert, nil
+	return watched
 }
 
-// LoadTLSConfig loads TLS certificates and returns a tls.Config.
-// This will set the RootCAs and Certificates of a tls.Config.
-func (c TLSSetting) loadTLSConfig() (*tls.Config, error) {
-	// There is no need to load the System Certs for RootCAs because
-	// if the value is nil, it will default to checking against th System Certs.
+// loadCertPool loads the CA cert and the certificates of the trust providers.
+// There is no need to load the System Certs for RootCAs because
+// if the value is nil, it will default to checking against th System Certs.
+func (c TLSSetting) loadCertPool() (*x509.CertPool, error) {
 	var err error
 	var certPool *x509.CertPool
 	if len(c.CAFile) != 0 {
@@ -167,20 +146,62 @@ func (c TLSSetting) loadTLSConfig() (*tls.Config, error) {
 		}
 	}
 
-	if (c.CertFile == "" && c.KeyFile != "") || (c.CertFile != "" && c.KeyFile == "") {
+	if len(c.TrustProviders) != 0 {
+		if certPool == nil {
+			if certPool, err = x509.SystemCertPool(); err != nil {
+				return nil, fmt.Errorf("failed to load system CertPool: %w", err)
+			}
+		}
+		if err = loadTrustProviders(certPool, c.TrustProviders); err != nil {
+			return nil, err
+		}
+	}
+	return certPool, nil
+}
+
+// LoadTLSConfig loads TLS certificates and returns a tls.Config.
+// This will set the RootCAs and Certificates of a tls.Config.
+func (c TLSSetting) loadTLSConfig() (*tls.Config, error) {
+	certPool, err := c.loadCertPool()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.KeyFile != "" && c.KeyRef != "" {
+		return nil, errors.New("for auth via TLS, key_file and key_ref cannot be both supplied")
+	}
+	hasKey := c.KeyFile != "" || c.KeyRef != ""
+	if (c.CertFile == "" && hasKey) || (c.CertFile != "" && !hasKey) {
 		return nil, errors.New("for auth via TLS, either both certificate and key must be supplied, or neither")
 	}
 
 	var getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)
 	var getClientCertificate func(*tls.CertificateRequestInfo) (*tls.Certificate, error)
-	if c.CertFile != "" && c.KeyFile != "" {
-		var certReloader *certReloader
-		certReloader, err = newCertReloader(c.CertFile, c.KeyFile, c.ReloadInterval)
+	if c.CertFile != "" && hasKey {
+		load := func() (*tls.Certificate, error) {
+			cert, loadErr := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+			return &cert, loadErr
+		}
+		if c.KeyRef != "" {
+			load = func() (*tls.Certificate, error) {
+				cert, loadErr := loadX509KeyRef(c.CertFile, c.KeyRef)
+				return &cert, loadErr
+			}
+		}
+		var certReloader *reloader[*tls.Certificate]
+		certReloader, err = newReloader(reloadTypeCert, load, c.ReloadInterval, c.watchedFiles(c.CertFile, c.KeyFile))
 		if err != nil {
 			return nil, fmt.Errorf("failed to load TLS cert and key: %w", err)
 		}
-		getCertificate = func(chi *tls.ClientHelloInfo) (*tls.Certificate, error) { return certReloader.GetCertificate() }
-		getClientCertificate = func(cri *tls.CertificateRequestInfo) (*tls.Certificate, error) { return certReloader.GetCertificate() }
+		getCert := func() (*tls.Certificate, error) {
+			cert, reloadErr := certReloader.get()
+			if reloadErr != nil {
+				return nil, fmt.Errorf("failed to load TLS cert and key: %w", reloadErr)
+			}
+			return cert, nil
+		}
+		getCertificate = func(chi *tls.ClientHelloInfo) (*tls.Certificate, error) { return getCert() }
+		getClientCertificate = func(cri *tls.CertificateRequestInfo) (*tls.Certificate, error) { return getCert() }
 	}
 
 	minTLS, err := convertVersion(c.MinVersion, defaultMinTLSVersion)
@@ -226,6 +247,15 @@ func (c TLSClientSetting) LoadTLSConfig() (*tls.Config, error) {
 	}
 	tlsCfg.ServerName = c.ServerName
 	tlsCfg.InsecureSkipVerify = c.InsecureSkipVerify
+	if c.CAFile != "" && c.reloadEnabled() && !c.InsecureSkipVerify {
+		caReloader, err := newReloader(reloadTypeCA, c.loadCertPool, c.ReloadInterval, c.watchedFiles(c.CAFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		// The RootCAs cannot be reloaded, so the server certificate is verified against the reloaded CA certs instead.
+		tlsCfg.InsecureSkipVerify = true // #nosec G402
+		tlsCfg.VerifyConnection = verifyConnectionWithRootCAs(caReloader)
+	}
 	return tlsCfg, nil
 }
 
@@ -236,12 +266,26 @@ func (c TLSServerSetting) LoadTLSConfig() (*tls.Config, error) {
 		return nil, fmt.Errorf("failed to load TLS config: %w", err)
 	}
 	if c.ClientCAFile != "" {
-		certPool, err := c.loadCert(c.ClientCAFile)
+		clientCAReloader, err := newReloader(reloadTypeClientCA, func() (*x509.CertPool, error) { return c.loadCert(c.ClientCAFile) },
+			c.ReloadInterval, c.watchedFiles(c.ClientCAFile))
 		if err != nil {
 			return nil, fmt.Errorf("failed to load TLS config: failed to load client CA CertPool: %w", err)
 		}
-		tlsCfg.ClientCAs = certPool
+		tlsCfg.ClientCAs = clientCAReloader.value
 		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+		if c.reloadEnabled() {
+			// The ClientCAs cannot be reloaded, so every connection gets a copy of the config with the reloaded client CA certs.
+			baseCfg := tlsCfg.Clone()
+			tlsCfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+				clientCAs, err := clientCAReloader.get()
+				if err != nil {
+					return nil, fmt.Errorf("failed to load client CA CertPool: %w", err)
+				}
+				cfg := baseCfg.Clone()
+				cfg.ClientCAs = clientCAs
+				return cfg, nil
+			}
+		}
 	}
 	return tlsCfg, nil
 }
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls // import "go.opentelemetry.io/collector/config/configtls"
+
+import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// KeyProviderFunc returns a signer for a private key that never touches the disk, e.g. a key held
+// by a PKCS#11 module or by the OS keychain. It receives the key reference without its scheme.
+type KeyProviderFunc func(ref string) (crypto.Signer, error)
+
+var (
+	keyProvidersMu sync.RWMutex
+	keyProviders   = map[string]KeyProviderFunc{}
+)
+
+// RegisterKeyProvider registers a KeyProviderFunc for the given scheme, so keys can be referenced
+// from the `key_ref` setting as "<scheme>:<reference>". It is meant to be called from an init function,
+// and returns an error if a provider for the same scheme is already registered.
+func RegisterKeyProvider(scheme string, provider KeyProviderFunc) error {
+	keyProvidersMu.Lock()
+	defer keyProvidersMu.Unlock()
+	if _, ok := keyProviders[scheme]; ok {
+		return fmt.Errorf("key provider %q is already registered", scheme)
+	}
+	keyProviders[scheme] = provider
+	return nil
+}
+
+// loadKeyRef returns the signer of the key referenced by keyRef from the key provider of its scheme.
+func loadKeyRef(keyRef string) (crypto.Signer, error) {
+	scheme, ref, ok := strings.Cut(keyRef, ":")
+	if !ok || scheme == "" {
+		return nil, fmt.Errorf("invalid key reference %q: missing scheme", keyRef)
+	}
+	keyProvidersMu.RLock()
+	provider, ok := keyProviders[scheme]
+	keyProvidersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown key provider %q", scheme)
+	}
+	signer, err := provider(ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key from key provider %q: %w", scheme, err)
+	}
+	return signer, nil
+}
+
+// loadX509KeyRef is the equivalent of tls.LoadX509KeyPair for a key loaded from a key provider.
+func loadX509KeyRef(certFile, keyRef string) (tls.Certificate, error) {
+	certPEM, err := os.ReadFile(filepath.Clean(certFile))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	var cert tls.Certificate
+	for block, rest := pem.Decode(certPEM); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type == "CERTIFICATE" {
+			cert.Certificate = append(cert.Certificate, block.Bytes)
+		}
+	}
+	if len(cert.Certificate) == 0 {
+		return tls.Certificate{}, fmt.Errorf("failed to find any PEM certificate in %s", certFile)
+	}
+	if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+		return tls.Certificate{}, err
+	}
+
+	signer, err := loadKeyRef(keyRef)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	pub, ok := cert.Leaf.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pub.Equal(signer.Public()) {
+		return tls.Certificate{}, errors.New("private key does not match public key")
+	}
+	cert.PrivateKey = signer
+	return cert, nil
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls
+
+import (
+	"crypto"
+	"crypto/tls"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyProviders(t *testing.T) {
+	require.NoError(t, RegisterKeyProvider("testkeys", func(ref string) (crypto.Signer, error) {
+		cert, err := tls.LoadX509KeyPair(filepath.Join("testdata", ref+".crt"), filepath.Join("testdata", ref+".key"))
+		if err != nil {
+			return nil, err
+		}
+		return cert.PrivateKey.(crypto.Signer), nil
+	}))
+	require.NoError(t, RegisterKeyProvider("failing", func(string) (crypto.Signer, error) {
+		return nil, errors.New("token not found")
+	}))
+	assert.EqualError(t, RegisterKeyProvider("testkeys", nil), `key provider "testkeys" is already registered`)
+
+	tests := []struct {
+		name        string
+		keyFile     string
+		keyRef      string
+		errorString string
+	}{
+		{
+			name:   "key from provider",
+			keyRef: "testkeys:server-1",
+		},
+		{
+			name:        "key not matching the cert",
+			keyRef:      "testkeys:server-2",
+			errorString: "private key does not match public key",
+		},
+		{
+			name:        "missing scheme",
+			keyRef:      "server-1",
+			errorString: `invalid key reference "server-1": missing scheme`,
+		},
+		{
+			name:        "unknown provider",
+			keyRef:      "pkcs11:object=server-1",
+			errorString: `unknown key provider "pkcs11"`,
+		},
+		{
+			name:        "failing provider",
+			keyRef:      "failing:server-1",
+			errorString: `failed to load key from key provider "failing": token not found`,
+		},
+		{
+			name:        "both key file and key ref",
+			keyFile:     filepath.Join("testdata", "server-1.key"),
+			keyRef:      "testkeys:server-1",
+			errorString: "key_file and key_ref cannot be both supplied",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tlsSetting := TLSServerSetting{
+				TLSSetting: TLSSetting{
+					CertFile: filepath.Join("testdata", "server-1.crt"),
+					KeyFile:  test.keyFile,
+					KeyRef:   test.keyRef,
+				},
+			}
+			tlsCfg, err := tlsSetting.LoadTLSConfig()
+			if test.errorString != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.errorString)
+				return
+			}
+			require.NoError(t, err)
+			cert, err := tlsCfg.GetCertificate(&tls.ClientHelloInfo{})
+			require.NoError(t, err)
+			assert.Equal(t, loadTestCert(t, "server-1.crt"), cert.Leaf)
+		})
+	}
+}
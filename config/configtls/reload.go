@@ -0,0 +1,238 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls // import "go.opentelemetry.io/collector/config/configtls"
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+const (
+	reloadTypeCert     = "cert"
+	reloadTypeCA       = "ca"
+	reloadTypeClientCA = "client_ca"
+
+	reloadResultSuccess = "success"
+	reloadResultFailure = "failure"
+)
+
+var (
+	reloadTypeTagKey   = tag.MustNewKey("type")
+	reloadResultTagKey = tag.MustNewKey("result")
+	statReloads        = stats.Int64("configtls/reloads", "Number of times the TLS files were reloaded", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views related to the reloading of the TLS files.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        statReloads.Name(),
+			Measure:     statReloads,
+			Description: statReloads.Description(),
+			TagKeys:     []tag.Key{reloadTypeTagKey, reloadResultTagKey},
+			Aggregation: view.Sum(),
+		},
+	}
+}
+
+func recordReload(reloadType string, err error) {
+	result := reloadResultSuccess
+	if err != nil {
+		result = reloadResultFailure
+	}
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Upsert(reloadTypeTagKey, reloadType), tag.Upsert(reloadResultTagKey, result)},
+		statReloads.M(1))
+}
+
+// watchPollInterval is the minimum duration between two checks of the watched files.
+var watchPollInterval = time.Second
+
+// reloader holds a value loaded from files, such as a certificate, and reloads it on access
+// when the reload interval elapsed since the last reload, or when the watched files changed.
+type reloader[T any] struct {
+	reloadType     string
+	load           func() (T, error)
+	reloadInterval time.Duration
+	nextReload     time.Time
+	// watcher is nil when the files are not watched.
+	watcher *fileWatcher
+	value   T
+	lock    sync.RWMutex
+}
+
+// newReloader loads the value, and watches the given files for changes if not empty.
+func newReloader[T any](reloadType string, load func() (T, error), reloadInterval time.Duration, watchFiles []string) (*reloader[T], error) {
+	var watcher *fileWatcher
+	if len(watchFiles) != 0 {
+		watcher = newFileWatcher(watchFiles)
+	}
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+	return &reloader[T]{
+		reloadType:     reloadType,
+		load:           load,
+		reloadInterval: reloadInterval,
+		nextReload:     time.Now().Add(reloadInterval),
+		watcher:        watcher,
+		value:          value,
+	}, nil
+}
+
+func (r *reloader[T]) needsReload(now time.Time) bool {
+	return (r.reloadInterval != 0 && r.nextReload.Before(now)) || (r.watcher != nil && r.watcher.changed(now))
+}
+
+// get returns the current value, reloading it first if needed.
+func (r *reloader[T]) get() (T, error) {
+	now := time.Now()
+	// Read locking here before we do the time comparison
+	// If a reload is in progress this will block and we will skip reloading in the current
+	// call once we can continue
+	r.lock.RLock()
+	if !r.needsReload(now) {
+		defer r.lock.RUnlock()
+		return r.value, nil
+	}
+	// Need to release the read lock, otherwise we deadlock
+	r.lock.RUnlock()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.needsReload(now) {
+		// Reloaded by a concurrent call.
+		return r.value, nil
+	}
+	// Stat before loading, so that a change happening during the load triggers another reload.
+	var states []fileState
+	if r.watcher != nil {
+		states = r.watcher.stat()
+	}
+	value, err := r.load()
+	recordReload(r.reloadType, err)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	if r.watcher != nil {
+		r.watcher.reset(states)
+	}
+	r.value = value
+	r.nextReload = now.Add(r.reloadInterval)
+	return r.value, nil
+}
+
+// fileState is the state of a watched file, compared to detect its changes.
+type fileState struct {
+	// info is nil if the file could not be stat'ed.
+	info os.FileInfo
+}
+
+func (s fileState) equal(other fileState) bool {
+	if s.info == nil || other.info == nil {
+		// A file that could not be stat'ed is unchanged until it can be.
+		return s.info == nil && other.info == nil
+	}
+	return os.SameFile(s.info, other.info) && s.info.ModTime().Equal(other.info.ModTime()) && s.info.Size() == other.info.Size()
+}
+
+// fileWatcher detects the changes of files by comparing their state with the state at the last reload. The files
+// are checked with os.Stat when the value is accessed, at most once per watchPollInterval, so nothing keeps running
+// once the TLS config is no longer used. os.Stat follows symlinks, so the files replaced by a rename or a symlink
+// swap, as done by Kubernetes for the mounted secrets, are detected too.
+type fileWatcher struct {
+	files     []string
+	states    []fileState
+	nextCheck time.Time
+	pending   bool
+	lock      sync.Mutex
+}
+
+func newFileWatcher(files []string) *fileWatcher {
+	w := &fileWatcher{files: files}
+	w.reset(w.stat())
+	return w
+}
+
+func (w *fileWatcher) stat() []fileState {
+	states := make([]fileState, len(w.files))
+	for i, file := range w.files {
+		if info, err := os.Stat(file); err == nil {
+			states[i].info = info
+		}
+	}
+	return states
+}
+
+// changed returns whether one of the files changed since the last reset.
+func (w *fileWatcher) changed(now time.Time) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.pending || now.Before(w.nextCheck) {
+		return w.pending
+	}
+	w.nextCheck = now.Add(watchPollInterval)
+	for i, state := range w.stat() {
+		if !state.equal(w.states[i]) {
+			w.pending = true
+			break
+		}
+	}
+	return w.pending
+}
+
+// reset records the given states as the states of the last reload.
+func (w *fileWatcher) reset(states []fileState) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.states = states
+	w.pending = false
+	w.nextCheck = time.Now().Add(watchPollInterval)
+}
+
+// verifyConnectionWithRootCAs verifies the certificate chain of the server against the reloaded CA certs,
+// replacing the verification done by crypto/tls against the RootCAs, which cannot be reloaded.
+func verifyConnectionWithRootCAs(caReloader *reloader[*x509.CertPool]) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return fmt.Errorf("tls: server did not provide a certificate")
+		}
+		roots, err := caReloader.get()
+		if err != nil {
+			return err
+		}
+		opts := x509.VerifyOptions{
+			Roots:         roots,
+			DNSName:       cs.ServerName,
+			Intermediates: x509.NewCertPool(),
+		}
+		for _, cert := range cs.PeerCertificates[1:] {
+			opts.Intermediates.AddCert(cert)
+		}
+		_, err = cs.PeerCertificates[0].Verify(opts)
+		return err
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+)
+
+func copyTestFile(t *testing.T, dst string, name string) {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	// Write to a temporary file renamed over dst, as done when rotating certificates.
+	tmp := dst + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, data, 0600))
+	require.NoError(t, os.Rename(tmp, dst))
+}
+
+func TestWatchFilesCertReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	copyTestFile(t, certFile, "server-1.crt")
+	copyTestFile(t, keyFile, "server-1.key")
+
+	tlsSetting := TLSServerSetting{
+		TLSSetting: TLSSetting{
+			CertFile:   certFile,
+			KeyFile:    keyFile,
+			WatchFiles: true,
+		},
+	}
+	cfg, err := tlsSetting.LoadTLSConfig()
+	require.NoError(t, err)
+
+	dnsName := func() string {
+		cert, err := cfg.GetCertificate(&tls.ClientHelloInfo{})
+		require.NoError(t, err)
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		require.NoError(t, err)
+		return leaf.DNSNames[0]
+	}
+	assert.Equal(t, "example1", dnsName())
+
+	copyTestFile(t, certFile, "server-2.crt")
+	copyTestFile(t, keyFile, "server-2.key")
+	assert.Eventually(t, func() bool { return dnsName() == "example2" }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWatchFilesNoGoroutineLeak(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	caFile := filepath.Join(dir, "ca.pem")
+	copyTestFile(t, certFile, "server-1.crt")
+	copyTestFile(t, keyFile, "server-1.key")
+	copyTestFile(t, caFile, "server-1.crt")
+
+	load := func() {
+		serverSetting := TLSServerSetting{
+			TLSSetting: TLSSetting{
+				CertFile:   certFile,
+				KeyFile:    keyFile,
+				WatchFiles: true,
+			},
+			ClientCAFile: caFile,
+		}
+		_, err := serverSetting.LoadTLSConfig()
+		require.NoError(t, err)
+		clientSetting := TLSClientSetting{
+			TLSSetting: TLSSetting{
+				CAFile:     caFile,
+				WatchFiles: true,
+			},
+		}
+		_, err = clientSetting.LoadTLSConfig()
+		require.NoError(t, err)
+	}
+	load()
+	goroutines := runtime.NumGoroutine()
+	for i := 0; i < 50; i++ {
+		load()
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), goroutines)
+}
+
+func TestClientCAReload(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	// The test certificates are self-signed, so they are their own CA.
+	copyTestFile(t, caFile, "server-1.crt")
+
+	tlsSetting := TLSClientSetting{
+		TLSSetting: TLSSetting{
+			CAFile:     caFile,
+			WatchFiles: true,
+		},
+	}
+	cfg, err := tlsSetting.LoadTLSConfig()
+	require.NoError(t, err)
+	require.NotNil(t, cfg.VerifyConnection)
+	assert.True(t, cfg.InsecureSkipVerify)
+
+	server1 := tls.ConnectionState{ServerName: "example1", PeerCertificates: []*x509.Certificate{loadTestCert(t, "server-1.crt")}}
+	server2 := tls.ConnectionState{ServerName: "example2", PeerCertificates: []*x509.Certificate{loadTestCert(t, "server-2.crt")}}
+	assert.NoError(t, cfg.VerifyConnection(server1))
+	assert.Error(t, cfg.VerifyConnection(server2))
+	assert.Error(t, cfg.VerifyConnection(tls.ConnectionState{ServerName: "other", PeerCertificates: server1.PeerCertificates}))
+
+	copyTestFile(t, caFile, "server-2.crt")
+	assert.Eventually(t, func() bool { return cfg.VerifyConnection(server2) == nil }, 5*time.Second, 10*time.Millisecond)
+	assert.Error(t, cfg.VerifyConnection(server1))
+}
+
+func TestClientCAWithoutReload(t *testing.T) {
+	tlsSetting := TLSClientSetting{
+		TLSSetting: TLSSetting{
+			CAFile: filepath.Join("testdata", "ca-1.crt"),
+		},
+	}
+	cfg, err := tlsSetting.LoadTLSConfig()
+	require.NoError(t, err)
+	assert.Nil(t, cfg.VerifyConnection)
+	assert.False(t, cfg.InsecureSkipVerify)
+	assert.NotNil(t, cfg.RootCAs)
+}
+
+func TestServerClientCAReload(t *testing.T) {
+	clientCAFile := filepath.Join(t.TempDir(), "client-ca.pem")
+	copyTestFile(t, clientCAFile, "client-1.crt")
+
+	tlsSetting := TLSServerSetting{
+		TLSSetting: TLSSetting{
+			ReloadInterval: 10 * time.Millisecond,
+		},
+		ClientCAFile: clientCAFile,
+	}
+	cfg, err := tlsSetting.LoadTLSConfig()
+	require.NoError(t, err)
+	require.NotNil(t, cfg.GetConfigForClient)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, cfg.ClientAuth)
+
+	verifies := func(name string) bool {
+		clientCfg, err := cfg.GetConfigForClient(&tls.ClientHelloInfo{})
+		require.NoError(t, err)
+		assert.Equal(t, tls.RequireAndVerifyClientCert, clientCfg.ClientAuth)
+		_, err = loadTestCert(t, name).Verify(x509.VerifyOptions{Roots: clientCfg.ClientCAs, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+		return err == nil
+	}
+	assert.True(t, verifies("client-1.crt"))
+	assert.False(t, verifies("client-2.crt"))
+
+	copyTestFile(t, clientCAFile, "client-2.crt")
+	assert.Eventually(t, func() bool { return verifies("client-2.crt") }, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, verifies("client-1.crt"))
+}
+
+func TestReloadMetrics(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	fail := false
+	r, err := newReloader("test", func() (int, error) {
+		if fail {
+			return 0, errors.New("invalid file")
+		}
+		return 1, nil
+	}, time.Nanosecond, nil)
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond)
+	_, err = r.get()
+	require.NoError(t, err)
+	fail = true
+	time.Sleep(time.Millisecond)
+	_, err = r.get()
+	require.Error(t, err)
+
+	rows, err := view.RetrieveData(statReloads.Name())
+	require.NoError(t, err)
+	counts := map[string]float64{}
+	for _, row := range rows {
+		var typ, result string
+		for _, tg := range row.Tags {
+			switch tg.Key {
+			case reloadTypeTagKey:
+				typ = tg.Value
+			case reloadResultTagKey:
+				result = tg.Value
+			}
+		}
+		if typ == "test" {
+			counts[result] = row.Data.(*view.SumData).Value
+		}
+	}
+	assert.Equal(t, map[string]float64{reloadResultSuccess: 1, reloadResultFailure: 1}, counts)
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls // import "go.opentelemetry.io/collector/config/configtls"
+
+import (
+	"crypto/x509"
+	"fmt"
+	"sync"
+)
+
+// TrustProviderFunc returns trust anchors from a source other than a CA file,
+// e.g. a cloud provider certificate bundle.
+type TrustProviderFunc func() ([]*x509.Certificate, error)
+
+var (
+	trustProvidersMu sync.RWMutex
+	trustProviders   = map[string]TrustProviderFunc{}
+)
+
+// RegisterTrustProvider registers a TrustProviderFunc under the given name, so it can be
+// referenced from the `trust_providers` setting. It is meant to be called from an init function,
+// and returns an error if a provider with the same name is already registered.
+func RegisterTrustProvider(name string, provider TrustProviderFunc) error {
+	trustProvidersMu.Lock()
+	defer trustProvidersMu.Unlock()
+	if _, ok := trustProviders[name]; ok {
+		return fmt.Errorf("trust provider %q is already registered", name)
+	}
+	trustProviders[name] = provider
+	return nil
+}
+
+// loadTrustProviders adds the certificates returned by the named trust providers to certPool.
+func loadTrustProviders(certPool *x509.CertPool, names []string) error {
+	trustProvidersMu.RLock()
+	defer trustProvidersMu.RUnlock()
+	for _, name := range names {
+		provider, ok := trustProviders[name]
+		if !ok {
+			return fmt.Errorf("unknown trust provider %q", name)
+		}
+		certs, err := provider()
+		if err != nil {
+			return fmt.Errorf("failed to load trust provider %q: %w", name, err)
+		}
+		for _, cert := range certs {
+			certPool.AddCert(cert)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestCert(t *testing.T, name string) *x509.Certificate {
+	certPEM, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	block, _ := pem.Decode(certPEM)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestTrustProviders(t *testing.T) {
+	caCert := loadTestCert(t, "ca-2.crt")
+	require.NoError(t, RegisterTrustProvider("test_bundle", func() ([]*x509.Certificate, error) {
+		return []*x509.Certificate{caCert}, nil
+	}))
+	require.NoError(t, RegisterTrustProvider("test_failing", func() ([]*x509.Certificate, error) {
+		return nil, errors.New("bundle unavailable")
+	}))
+	t.Cleanup(func() {
+		trustProvidersMu.Lock()
+		defer trustProvidersMu.Unlock()
+		delete(trustProviders, "test_bundle")
+		delete(trustProviders, "test_failing")
+	})
+
+	assert.Error(t, RegisterTrustProvider("test_bundle", nil))
+
+	tlsCfg, err := TLSSetting{
+		CAFile:         filepath.Join("testdata", "ca-1.crt"),
+		TrustProviders: []string{"test_bundle"},
+	}.loadTLSConfig()
+	require.NoError(t, err)
+	_, err = caCert.Verify(x509.VerifyOptions{Roots: tlsCfg.RootCAs})
+	assert.NoError(t, err)
+
+	_, err = TLSSetting{TrustProviders: []string{"test_bundle"}}.loadTLSConfig()
+	assert.NoError(t, err)
+
+	_, err = TLSSetting{TrustProviders: []string{"unknown"}}.loadTLSConfig()
+	assert.EqualError(t, err, `unknown trust provider "unknown"`)
+
+	_, err = TLSSetting{TrustProviders: []string{"test_failing"}}.loadTLSConfig()
+	assert.EqualError(t, err, `failed to load trust provider "test_failing": bundle unavailable`)
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/config/internal"
+
+import (
+	"context"
+	"net"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+const ipFilterScope = "go.opentelemetry.io/collector/config/ipfilter"
+
+// IPFilter checks client addresses against confignet.IPFilterSettings and records the rejections.
+type IPFilter struct {
+	filter  *confignet.IPFilter
+	useOtel bool
+
+	mutators []tag.Mutator
+
+	rejected syncint64.Counter
+	attrs    []attribute.KeyValue
+}
+
+// NewIPFilter creates an IPFilter for the server listening on the given transport and endpoint. The rejections
+// are recorded with the meter provider if the telemetry.useOtelForInternalMetrics feature gate is enabled,
+// otherwise with OpenCensus.
+func NewIPFilter(settings *confignet.IPFilterSettings, mp metric.MeterProvider, registry *featuregate.Registry, transport, endpoint string) (*IPFilter, error) {
+	filter, err := settings.ToIPFilter()
+	if err != nil {
+		return nil, err
+	}
+	f := &IPFilter{
+		filter:   filter,
+		useOtel:  registry.IsEnabled(obsreportconfig.UseOtelForInternalMetricsfeatureGateID),
+		mutators: serverMutators(transport, endpoint),
+		attrs: []attribute.KeyValue{
+			attribute.String(obsmetrics.TransportKey, transport),
+			attribute.String(obsmetrics.EndpointKey, endpoint),
+		},
+	}
+	if f.useOtel {
+		f.rejected, err = mp.Meter(ipFilterScope).SyncInt64().Counter(
+			obsmetrics.ServerIPFilterRejected.Name(),
+			instrument.WithDescription(obsmetrics.ServerIPFilterRejected.Description()),
+			instrument.WithUnit(unit.Dimensionless),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// Allowed returns true if the client address is accepted, otherwise it records the rejection.
+func (f *IPFilter) Allowed(ctx context.Context, addr net.Addr) bool {
+	if f.filter.AllowedAddr(addr) {
+		return true
+	}
+	if f.useOtel {
+		f.rejected.Add(ctx, 1, f.attrs...)
+	} else {
+		_ = stats.RecordWithTags(ctx, f.mutators, obsmetrics.ServerIPFilterRejected.M(1))
+	}
+	return false
+}
+
+// serverMutators returns the tags of the metrics of the server listening on the given transport and endpoint.
+func serverMutators(transport, endpoint string) []tag.Mutator {
+	return []tag.Mutator{
+		tag.Upsert(obsmetrics.TagKeyTransport, transport, tag.WithTTL(tag.TTLNoPropagation)),
+		tag.Upsert(obsmetrics.TagKeyEndpoint, endpoint, tag.WithTTL(tag.TTLNoPropagation)),
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/config/internal"
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+// registerViews registers the views of the internal metrics for the duration of the test.
+func registerViews(t *testing.T) {
+	views := obsreportconfig.Configure(configtelemetry.LevelNormal).Views
+	require.NoError(t, view.Register(views...))
+	t.Cleanup(func() { view.Unregister(views...) })
+}
+
+// serverMetricValue returns the value of the given server metric for the transport and endpoint.
+func serverMetricValue(t *testing.T, name, transport, endpoint string) float64 {
+	rows, err := view.RetrieveData(name)
+	require.NoError(t, err)
+	for _, row := range rows {
+		var rowTransport, rowEndpoint string
+		for _, tg := range row.Tags {
+			switch tg.Key {
+			case obsmetrics.TagKeyTransport:
+				rowTransport = tg.Value
+			case obsmetrics.TagKeyEndpoint:
+				rowEndpoint = tg.Value
+			}
+		}
+		if rowTransport == transport && rowEndpoint == endpoint {
+			return row.Data.(*view.SumData).Value
+		}
+	}
+	return 0
+}
+
+func TestIPFilter(t *testing.T) {
+	allowed := func(t *testing.T, filter *IPFilter) {
+		ctx := context.Background()
+		assert.True(t, filter.Allowed(ctx, &net.TCPAddr{IP: net.ParseIP("127.0.0.1")}))
+		assert.False(t, filter.Allowed(ctx, &net.TCPAddr{IP: net.ParseIP("10.0.0.1")}))
+		assert.False(t, filter.Allowed(ctx, &net.TCPAddr{IP: net.ParseIP("10.0.0.2")}))
+	}
+
+	t.Run("WithOC", func(t *testing.T) {
+		registerViews(t)
+		mp := sdkmetric.NewMeterProvider()
+
+		_, err := NewIPFilter(&confignet.IPFilterSettings{Allow: []string{"invalid"}}, mp, featuregate.NewRegistry(), "grpc", "localhost:4317")
+		assert.Error(t, err)
+
+		filter, err := NewIPFilter(&confignet.IPFilterSettings{Allow: []string{"127.0.0.0/8"}}, mp, featuregate.NewRegistry(), "grpc", "localhost:4317")
+		require.NoError(t, err)
+		allowed(t, filter)
+
+		assert.Equal(t, float64(2), serverMetricValue(t, obsmetrics.ServerIPFilterRejected.Name(), "grpc", "localhost:4317"))
+	})
+
+	t.Run("WithOTel", func(t *testing.T) {
+		registry := featuregate.NewRegistry()
+		obsreportconfig.RegisterInternalMetricFeatureGate(registry)
+		require.NoError(t, registry.Apply(map[string]bool{obsreportconfig.UseOtelForInternalMetricsfeatureGateID: true}))
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+		filter, err := NewIPFilter(&confignet.IPFilterSettings{Allow: []string{"127.0.0.0/8"}}, mp, registry, "grpc", "localhost:4317")
+		require.NoError(t, err)
+		allowed(t, filter)
+
+		rm, err := reader.Collect(context.Background())
+		require.NoError(t, err)
+		require.Len(t, rm.ScopeMetrics, 1)
+		require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+		m := rm.ScopeMetrics[0].Metrics[0]
+		assert.Equal(t, obsmetrics.ServerIPFilterRejected.Name(), m.Name)
+		sum, ok := m.Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		require.Len(t, sum.DataPoints, 1)
+		assert.Equal(t, int64(2), sum.DataPoints[0].Value)
+		assert.Equal(t, attribute.NewSet(
+			attribute.String(obsmetrics.TransportKey, "grpc"),
+			attribute.String(obsmetrics.EndpointKey, "localhost:4317"),
+		), sum.DataPoints[0].Attributes)
+	})
+}
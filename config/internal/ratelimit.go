@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/config/internal"
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+const (
+	rateLimitScope               = "go.opentelemetry.io/collector/config/ratelimit"
+	rateLimitRejectedMetricName  = "server_rate_limited"
+	rateLimitTransportAttribute  = "transport"
+	rateLimitEndpointAttribute   = "endpoint"
+	rateLimitRejectedDescription = "Number of requests rejected because they exceed the server rate_limit."
+
+	// rateLimitSweepInterval is how often the buckets of the clients are swept.
+	rateLimitSweepInterval = time.Minute
+)
+
+// RateLimiter is a token bucket rate limiter, shared by all the clients or kept per client, which records
+// the rejected requests.
+type RateLimiter struct {
+	rate      float64
+	burst     float64
+	perClient bool
+	rejected  syncint64.Counter
+	attrs     []attribute.KeyValue
+	now       func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a RateLimiter allowing rate requests per second with bursts of up to burst requests,
+// for the server listening on the given transport and endpoint. If perClient is set, every client gets its
+// own budget.
+func NewRateLimiter(rate float64, burst int, perClient bool, mp metric.MeterProvider, transport, endpoint string) (*RateLimiter, error) {
+	rejected, err := mp.Meter(rateLimitScope).SyncInt64().Counter(
+		rateLimitRejectedMetricName,
+		instrument.WithDescription(rateLimitRejectedDescription),
+		instrument.WithUnit(unit.Dimensionless),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &RateLimiter{
+		rate:      rate,
+		burst:     float64(burst),
+		perClient: perClient,
+		rejected:  rejected,
+		attrs: []attribute.KeyValue{
+			attribute.String(rateLimitTransportAttribute, transport),
+			attribute.String(rateLimitEndpointAttribute, endpoint),
+		},
+		now:     time.Now,
+		buckets: make(map[string]*tokenBucket),
+	}, nil
+}
+
+// Allow returns true if a request of the given client is within the limit. Otherwise it records the rejection,
+// and returns how long the client should wait before retrying.
+func (l *RateLimiter) Allow(ctx context.Context, client string) (bool, time.Duration) {
+	if !l.perClient {
+		client = ""
+	}
+	now := l.now()
+
+	l.mu.Lock()
+	l.sweep(now)
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		l.mu.Unlock()
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	l.rejected.Add(ctx, 1, l.attrs...)
+	return false, wait
+}
+
+// sweep forgets the clients whose budget is full again, so that the buckets of past clients do not accumulate.
+func (l *RateLimiter) sweep(now time.Time) {
+	if !l.perClient || now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for client, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, client)
+		}
+	}
+}
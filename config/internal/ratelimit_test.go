@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/config/internal"
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestRateLimiter(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	limiter, err := NewRateLimiter(2, 2, false, mp, "http", "localhost:4318")
+	require.NoError(t, err)
+	now := time.Unix(0, 0)
+	limiter.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	allowed, _ := limiter.Allow(ctx, "10.0.0.1")
+	assert.True(t, allowed)
+	allowed, _ = limiter.Allow(ctx, "10.0.0.2")
+	assert.True(t, allowed)
+
+	// The budget is shared by all the clients.
+	allowed, wait := limiter.Allow(ctx, "10.0.0.3")
+	assert.False(t, allowed)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	now = now.Add(500 * time.Millisecond)
+	allowed, _ = limiter.Allow(ctx, "10.0.0.3")
+	assert.True(t, allowed)
+
+	rm, err := reader.Collect(ctx)
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	m := rm.ScopeMetrics[0].Metrics[0]
+	assert.Equal(t, rateLimitRejectedMetricName, m.Name)
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(1), sum.DataPoints[0].Value)
+	assert.Equal(t, attribute.NewSet(
+		attribute.String(rateLimitTransportAttribute, "http"),
+		attribute.String(rateLimitEndpointAttribute, "localhost:4318"),
+	), sum.DataPoints[0].Attributes)
+}
+
+func TestRateLimiterPerClient(t *testing.T) {
+	limiter, err := NewRateLimiter(1, 1, true, sdkmetric.NewMeterProvider(), "http", "localhost:4318")
+	require.NoError(t, err)
+	now := time.Unix(0, 0)
+	limiter.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	allowed, _ := limiter.Allow(ctx, "10.0.0.1")
+	assert.True(t, allowed)
+	allowed, _ = limiter.Allow(ctx, "10.0.0.1")
+	assert.False(t, allowed)
+
+	// Every client has its own budget.
+	allowed, _ = limiter.Allow(ctx, "10.0.0.2")
+	assert.True(t, allowed)
+	assert.Len(t, limiter.buckets, 2)
+
+	// The buckets of the clients with a full budget are swept.
+	now = now.Add(rateLimitSweepInterval)
+	allowed, _ = limiter.Allow(ctx, "10.0.0.3")
+	assert.True(t, allowed)
+	assert.Len(t, limiter.buckets, 1)
+}
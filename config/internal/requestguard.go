@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/config/internal"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+const (
+	requestGuardScope               = "go.opentelemetry.io/collector/config/requestguard"
+	requestGuardRejectedMetricName  = "server_request_rejected"
+	requestGuardTransportAttribute  = "transport"
+	requestGuardEndpointAttribute   = "endpoint"
+	requestGuardReasonAttribute     = "reason"
+	requestGuardRejectedDescription = "Number of requests rejected because they are malformed or exceed a limit of the server."
+)
+
+const (
+	// RejectReasonContentLength is the reason of the requests whose body does not match their Content-Length,
+	// or whose Content-Length exceeds the maximum body size of the server.
+	RejectReasonContentLength = "content_length"
+	// RejectReasonContentEncoding is the reason of the requests listing a content encoding that is not supported.
+	RejectReasonContentEncoding = "content_encoding"
+	// RejectReasonDecompressedBodySize is the reason of the requests whose body exceeds the maximum size
+	// of the server once decompressed.
+	RejectReasonDecompressedBodySize = "decompressed_body_size"
+)
+
+// RequestRejections records the requests rejected by the guards of a server, by reason.
+type RequestRejections struct {
+	rejected syncint64.Counter
+	attrs    []attribute.KeyValue
+}
+
+// NewRequestRejections creates a RequestRejections for the server listening on the given transport and endpoint.
+func NewRequestRejections(mp metric.MeterProvider, transport, endpoint string) (*RequestRejections, error) {
+	rejected, err := mp.Meter(requestGuardScope).SyncInt64().Counter(
+		requestGuardRejectedMetricName,
+		instrument.WithDescription(requestGuardRejectedDescription),
+		instrument.WithUnit(unit.Dimensionless),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &RequestRejections{
+		rejected: rejected,
+		attrs: []attribute.KeyValue{
+			attribute.String(requestGuardTransportAttribute, transport),
+			attribute.String(requestGuardEndpointAttribute, endpoint),
+		},
+	}, nil
+}
+
+// Record records the rejection of a request for the given reason.
+func (r *RequestRejections) Record(ctx context.Context, reason string) {
+	attrs := make([]attribute.KeyValue, 0, len(r.attrs)+1)
+	attrs = append(attrs, r.attrs...)
+	attrs = append(attrs, attribute.String(requestGuardReasonAttribute, reason))
+	r.rejected.Add(ctx, 1, attrs...)
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/config/internal"
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestRequestRejections(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	rejections, err := NewRequestRejections(mp, "http", "localhost:4318")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	rejections.Record(ctx, RejectReasonContentLength)
+	rejections.Record(ctx, RejectReasonDecompressedBodySize)
+	rejections.Record(ctx, RejectReasonDecompressedBodySize)
+
+	rm, err := reader.Collect(ctx)
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	m := rm.ScopeMetrics[0].Metrics[0]
+	assert.Equal(t, requestGuardRejectedMetricName, m.Name)
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 2)
+	got := map[string]int64{}
+	for _, dp := range sum.DataPoints {
+		transport, _ := dp.Attributes.Value(requestGuardTransportAttribute)
+		assert.Equal(t, "http", transport.AsString())
+		endpoint, _ := dp.Attributes.Value(requestGuardEndpointAttribute)
+		assert.Equal(t, "localhost:4318", endpoint.AsString())
+		reason, _ := dp.Attributes.Value(requestGuardReasonAttribute)
+		got[reason.AsString()] = dp.Value
+	}
+	assert.Equal(t, map[string]int64{
+		RejectReasonContentLength:        1,
+		RejectReasonDecompressedBodySize: 2,
+	}, got)
+}
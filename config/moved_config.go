@@ -24,6 +24,26 @@ type Pipeline struct {
 	Receivers  []component.ID `mapstructure:"receivers"`
 	Processors []component.ID `mapstructure:"processors"`
 	Exporters  []component.ID `mapstructure:"exporters"`
+
+	// FailurePolicy configures which failures of the exporters fail the pipeline.
+	FailurePolicy PipelineFailurePolicy `mapstructure:"failure_policy"`
+}
+
+const (
+	// PipelineFailureModeAll fails the pipeline when any exporter fails, this is the default.
+	PipelineFailureModeAll = "all"
+	// PipelineFailureModeAny fails the pipeline only when no exporter accepts the data.
+	PipelineFailureModeAny = "any"
+)
+
+// PipelineFailurePolicy defines which failures of the exporters of a pipeline are returned to the receivers,
+// so that a single failing exporter does not fail the whole pipeline.
+type PipelineFailurePolicy struct {
+	// Mode is either PipelineFailureModeAll (the default) or PipelineFailureModeAny.
+	Mode string `mapstructure:"mode"`
+
+	// IgnoreErrorsFor lists the exporters of the pipeline whose failures never fail the pipeline.
+	IgnoreErrorsFor []component.ID `mapstructure:"ignore_errors_for"`
 }
 
 // Deprecated: [v0.52.0] will be removed soon.
@@ -127,6 +127,38 @@ func (l *Conf) Merge(in *Conf) error {
 	return l.k.Merge(in.k)
 }
 
+// MergeOverlay merges the input given configuration into the existing config as an overlay:
+// values override the existing ones as with Merge, except the null values which remove the key
+// from the existing config instead of setting it to null.
+func (l *Conf) MergeOverlay(in *Conf) error {
+	merged := l.ToStringMap()
+	applyOverlay(merged, in.ToStringMap())
+	l.k = NewFromStringMap(merged).k
+	return nil
+}
+
+func applyOverlay(dst map[string]interface{}, overlay map[string]interface{}) {
+	for key, val := range overlay {
+		if val == nil {
+			delete(dst, key)
+			continue
+		}
+		overlayMap, isOverlayMap := val.(map[string]interface{})
+		dstMap, isDstMap := dst[key].(map[string]interface{})
+		if isOverlayMap && isDstMap {
+			applyOverlay(dstMap, overlayMap)
+			continue
+		}
+		if isOverlayMap {
+			// Tombstones have nothing to remove in a new map.
+			dstMap = make(map[string]interface{}, len(overlayMap))
+			applyOverlay(dstMap, overlayMap)
+			val = dstMap
+		}
+		dst[key] = val
+	}
+}
+
 // Sub returns new Conf instance representing a sub-config of this instance.
 // It returns an error is the sub-config is not a map[string]interface{} (use Get()), and an empty Map if none exists.
 func (l *Conf) Sub(key string) (*Conf, error) {
@@ -421,3 +421,57 @@ func TestUnmarshalerErr(t *testing.T) {
 	assert.EqualError(t, cfgMap.Unmarshal(tc), expectErr)
 	assert.Empty(t, tc.Err.Foo)
 }
+
+func TestMergeOverlay(t *testing.T) {
+	conf := NewFromStringMap(map[string]interface{}{
+		"receivers": map[string]interface{}{
+			"otlp":       map[string]interface{}{"endpoint": "0.0.0.0:4317"},
+			"prometheus": map[string]interface{}{"interval": "10s"},
+		},
+		"exporters": map[string]interface{}{
+			"logging": nil,
+		},
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces": map[string]interface{}{
+					"receivers": []interface{}{"otlp", "prometheus"},
+				},
+			},
+		},
+	})
+	overlay := NewFromStringMap(map[string]interface{}{
+		"receivers": map[string]interface{}{
+			"otlp":       map[string]interface{}{"endpoint": "localhost:4317"},
+			"prometheus": nil,
+		},
+		"exporters": map[string]interface{}{
+			"otlp": map[string]interface{}{"endpoint": "backend:4317", "headers": nil},
+		},
+		"extensions": nil,
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces": map[string]interface{}{
+					"receivers": []interface{}{"otlp"},
+				},
+			},
+		},
+	})
+
+	require.NoError(t, conf.MergeOverlay(overlay))
+	assert.Equal(t, map[string]interface{}{
+		"receivers": map[string]interface{}{
+			"otlp": map[string]interface{}{"endpoint": "localhost:4317"},
+		},
+		"exporters": map[string]interface{}{
+			"logging": nil,
+			"otlp":    map[string]interface{}{"endpoint": "backend:4317"},
+		},
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces": map[string]interface{}{
+					"receivers": []interface{}{"otlp"},
+				},
+			},
+		},
+	}, conf.ToStringMap())
+}
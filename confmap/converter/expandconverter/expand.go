@@ -16,14 +16,40 @@ package expandconverter // import "go.opentelemetry.io/collector/confmap/convert
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"regexp"
+	"strings"
 
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/featuregate"
 )
 
+// strictReferencesFeatureGateID is the feature gate that controls whether the references that cannot be
+// environment variables fail the conversion. Disabled, they are replaced by an empty string as before.
+const strictReferencesFeatureGateID = "confmap.expandconverter.strictReferences"
+
+func init() {
+	featuregate.GetRegistry().MustRegisterID(
+		strictReferencesFeatureGateID,
+		featuregate.StageBeta,
+		featuregate.WithRegisterDescription("controls whether the references that cannot be environment variables, e.g. \"$1\" or \"${VAR:-default}\", fail the configuration instead of being replaced by an empty string"),
+	)
+}
+
+// literalPrefix marks the string values that are not expanded, e.g. "literal:^(.*)$1".
+// The prefix is removed from the value.
+const literalPrefix = "literal:"
+
+// envNameRegexp matches the names of environment variables.
+var envNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 type converter struct{}
 
 // New returns a confmap.Converter, that expands all environment variables for a given confmap.Conf.
+// String values prefixed with "literal:" are not expanded, and references that cannot be environment
+// variables, e.g. "$1" or "${VAR:-default}", fail the conversion instead of being silently replaced, unless the
+// "confmap.expandconverter.strictReferences" feature gate is disabled.
 //
 // Notice: This API is experimental.
 func New() confmap.Converter {
@@ -33,34 +59,61 @@ func New() confmap.Converter {
 func (converter) Convert(_ context.Context, conf *confmap.Conf) error {
 	out := make(map[string]interface{})
 	for _, k := range conf.AllKeys() {
-		out[k] = expandStringValues(conf.Get(k))
+		v, err := expandStringValues(conf.Get(k))
+		if err != nil {
+			return fmt.Errorf("failed to expand %q: %w", k, err)
+		}
+		out[k] = v
 	}
 	return conf.Merge(confmap.NewFromStringMap(out))
 }
 
-func expandStringValues(value interface{}) interface{} {
+func expandStringValues(value interface{}) (interface{}, error) {
 	switch v := value.(type) {
 	case string:
+		if strings.HasPrefix(v, literalPrefix) {
+			return strings.TrimPrefix(v, literalPrefix), nil
+		}
 		return expandEnv(v)
 	case []interface{}:
 		nslice := make([]interface{}, 0, len(v))
 		for _, vint := range v {
-			nslice = append(nslice, expandStringValues(vint))
+			nv, err := expandStringValues(vint)
+			if err != nil {
+				return nil, err
+			}
+			nslice = append(nslice, nv)
 		}
-		return nslice
+		return nslice, nil
 	case map[string]interface{}:
 		nmap := map[string]interface{}{}
 		for mk, mv := range v {
-			nmap[mk] = expandStringValues(mv)
+			nv, err := expandStringValues(mv)
+			if err != nil {
+				return nil, err
+			}
+			nmap[mk] = nv
 		}
-		return nmap
+		return nmap, nil
 	default:
-		return v
+		return v, nil
 	}
 }
 
-func expandEnv(s string) string {
-	return os.Expand(s, func(str string) string {
+func expandEnv(s string) (string, error) {
+	if !featuregate.GetRegistry().IsEnabled(strictReferencesFeatureGateID) {
+		return os.Expand(s, func(str string) string {
+			if str == "$" {
+				return "$"
+			}
+			return os.Getenv(str)
+		}), nil
+	}
+	if err := checkBraces(s); err != nil {
+		return "", err
+	}
+	var invalid string
+	expanded := os.Expand(s, func(str string) string {
 		// This allows escaping environment variable substitution via $$, e.g.
 		// - $FOO will be substituted with env var FOO
 		// - $$FOO will be replaced with $FOO
@@ -68,6 +121,47 @@ func expandEnv(s string) string {
 		if str == "$" {
 			return "$"
 		}
+		if !envNameRegexp.MatchString(str) && invalid == "" {
+			invalid = str
+		}
 		return os.Getenv(str)
 	})
+	switch {
+	case invalid == "":
+		return expanded, nil
+	case len(invalid) == 1:
+		// Shell special variables, e.g. "$1".
+		return "", invalidReferenceError("$" + invalid)
+	default:
+		return "", invalidReferenceError("${" + invalid + "}")
+	}
+}
+
+// checkBraces returns an error if a "${" is not closed, or encloses an empty name, in which case
+// os.Expand silently drops the characters.
+func checkBraces(s string) error {
+	for i := 0; i < len(s)-1; i++ {
+		if s[i] != '$' {
+			continue
+		}
+		switch s[i+1] {
+		case '$':
+			// Escaped "$".
+			i++
+		case '{':
+			end := strings.IndexByte(s[i+2:], '}')
+			if end < 0 {
+				return invalidReferenceError(s[i:])
+			}
+			if end == 0 {
+				return invalidReferenceError("${}")
+			}
+			i += end + 2
+		}
+	}
+	return nil
+}
+
+func invalidReferenceError(ref string) error {
+	return fmt.Errorf("%q is not a valid environment variable reference, escape the \"$\" as \"$$\" or prefix the value with %q to not expand it", ref, literalPrefix)
 }
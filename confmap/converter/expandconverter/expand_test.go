@@ -24,6 +24,7 @@ import (
 
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/featuregate"
 )
 
 func TestNewExpandConverter(t *testing.T) {
@@ -170,3 +171,73 @@ func TestNewExpandConverterHostPort(t *testing.T) {
 		})
 	}
 }
+
+func TestNewExpandConverterLiteral(t *testing.T) {
+	t.Setenv("HOST", "127.0.0.1")
+
+	conf := confmap.NewFromStringMap(map[string]any{
+		"regex":      "literal:^(?P<host>.*)$1",
+		"annotation": "literal:{{ $labels.instance }} is ${HOST}",
+		"list":       []any{"literal:$HOST", "$HOST"},
+		"expanded":   "$HOST",
+		"prefix":     "$$literal:$HOST",
+	})
+	require.NoError(t, New().Convert(context.Background(), conf))
+	assert.Equal(t, map[string]any{
+		"regex":      "^(?P<host>.*)$1",
+		"annotation": "{{ $labels.instance }} is ${HOST}",
+		"list":       []any{"$HOST", "127.0.0.1"},
+		"expanded":   "127.0.0.1",
+		"prefix":     "$literal:127.0.0.1",
+	}, conf.ToStringMap())
+}
+
+func TestNewExpandConverterInvalidReference(t *testing.T) {
+	var testCases = []struct {
+		value    any
+		expected string
+	}{
+		{
+			value:    "s/(.*)/$1/",
+			expected: `failed to expand "test": "$1" is not a valid environment variable reference, escape the "$" as "$$" or prefix the value with "literal:" to not expand it`,
+		},
+		{
+			value:    "${HOST:-localhost}:4317",
+			expected: `failed to expand "test": "${HOST:-localhost}" is not a valid environment variable reference, escape the "$" as "$$" or prefix the value with "literal:" to not expand it`,
+		},
+		{
+			value:    []any{"ok", "${HOST"},
+			expected: `failed to expand "test": "${HOST" is not a valid environment variable reference, escape the "$" as "$$" or prefix the value with "literal:" to not expand it`,
+		},
+		{
+			value:    map[string]any{"nested": "a${}b"},
+			expected: `failed to expand "test::nested": "${}" is not a valid environment variable reference, escape the "$" as "$$" or prefix the value with "literal:" to not expand it`,
+		},
+	}
+	for _, tt := range testCases {
+		conf := confmap.NewFromStringMap(map[string]any{"test": tt.value})
+		assert.EqualError(t, New().Convert(context.Background(), conf), tt.expected)
+	}
+}
+
+func TestNewExpandConverterInvalidReferenceNotStrict(t *testing.T) {
+	require.NoError(t, featuregate.GetRegistry().Apply(map[string]bool{strictReferencesFeatureGateID: false}))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GetRegistry().Apply(map[string]bool{strictReferencesFeatureGateID: true}))
+	})
+	t.Setenv("HOST", "127.0.0.1")
+
+	conf := confmap.NewFromStringMap(map[string]any{
+		"replacement": "s/(.*)/$1/",
+		"default":     "${HOST:-localhost}:4317",
+		"expanded":    "$HOST",
+		"literal":     "literal:$1",
+	})
+	require.NoError(t, New().Convert(context.Background(), conf))
+	assert.Equal(t, map[string]any{
+		"replacement": "s/(.*)//",
+		"default":     ":4317",
+		"expanded":    "127.0.0.1",
+		"literal":     "$1",
+	}, conf.ToStringMap())
+}
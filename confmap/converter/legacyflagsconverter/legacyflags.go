@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package legacyflagsconverter // import "go.opentelemetry.io/collector/confmap/converter/legacyflagsconverter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const memoryBallastExtension = "memory_ballast"
+
+// Flags holds the values of the legacy command-line flags. Flags with a zero value are not converted.
+type Flags struct {
+	// MemBallastSizeMiB is the value of the --mem-ballast-size-mib flag, converted to the
+	// size_mib of the memory_ballast extension, which is added to the service extensions.
+	MemBallastSizeMiB uint64
+
+	// MetricsAddr is the value of the --metrics-addr flag, converted to the address of the
+	// service telemetry metrics.
+	MetricsAddr string
+}
+
+type converter struct {
+	flags Flags
+	warn  func(msg string)
+}
+
+// New returns a confmap.Converter, that sets the config keys equivalent to the given legacy flags.
+// The values of the flags have a higher precedence than the config, and warn is called with a
+// deprecation message for every flag that is set.
+//
+// Notice: This API is experimental.
+func New(flags Flags, warn func(msg string)) confmap.Converter {
+	return converter{flags: flags, warn: warn}
+}
+
+func (c converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	if c.flags.MemBallastSizeMiB != 0 {
+		c.warn("The --mem-ballast-size-mib flag is deprecated, set `size_mib` of the `memory_ballast` extension in the config instead.")
+		err := conf.Merge(confmap.NewFromStringMap(map[string]interface{}{
+			"extensions": map[string]interface{}{
+				memoryBallastExtension: map[string]interface{}{
+					"size_mib": c.flags.MemBallastSizeMiB,
+				},
+			},
+			"service": map[string]interface{}{
+				"extensions": appendExtension(conf.Get("service::extensions"), memoryBallastExtension),
+			},
+		}))
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.flags.MetricsAddr != "" {
+		c.warn("The --metrics-addr flag is deprecated, set `service::telemetry::metrics::address` in the config instead.")
+		err := conf.Merge(confmap.NewFromStringMap(map[string]interface{}{
+			"service": map[string]interface{}{
+				"telemetry": map[string]interface{}{
+					"metrics": map[string]interface{}{
+						"address": c.flags.MetricsAddr,
+					},
+				},
+			},
+		}))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendExtension returns the list of extensions with the given extension added, if not already present.
+func appendExtension(extensions interface{}, extension string) []interface{} {
+	list, _ := extensions.([]interface{})
+	for _, ext := range list {
+		if ext == extension {
+			return list
+		}
+	}
+	return append(list, extension)
+}
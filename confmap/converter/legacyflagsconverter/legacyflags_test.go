@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package legacyflagsconverter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestConvert(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		flags    Flags
+		conf     map[string]interface{}
+		expected map[string]interface{}
+		warnings int
+	}{
+		{
+			name: "no_flags",
+			conf: map[string]interface{}{
+				"service": map[string]interface{}{"extensions": []interface{}{"zpages"}},
+			},
+			expected: map[string]interface{}{
+				"service": map[string]interface{}{"extensions": []interface{}{"zpages"}},
+			},
+		},
+		{
+			name:  "mem_ballast",
+			flags: Flags{MemBallastSizeMiB: 64},
+			conf: map[string]interface{}{
+				"extensions": map[string]interface{}{"zpages": nil},
+				"service":    map[string]interface{}{"extensions": []interface{}{"zpages"}},
+			},
+			expected: map[string]interface{}{
+				"extensions": map[string]interface{}{
+					"zpages":         nil,
+					"memory_ballast": map[string]interface{}{"size_mib": uint64(64)},
+				},
+				"service": map[string]interface{}{"extensions": []interface{}{"zpages", "memory_ballast"}},
+			},
+			warnings: 1,
+		},
+		{
+			name:  "mem_ballast_already_enabled",
+			flags: Flags{MemBallastSizeMiB: 64},
+			conf: map[string]interface{}{
+				"extensions": map[string]interface{}{"memory_ballast": map[string]interface{}{"size_mib": 32}},
+				"service":    map[string]interface{}{"extensions": []interface{}{"memory_ballast"}},
+			},
+			expected: map[string]interface{}{
+				"extensions": map[string]interface{}{"memory_ballast": map[string]interface{}{"size_mib": uint64(64)}},
+				"service":    map[string]interface{}{"extensions": []interface{}{"memory_ballast"}},
+			},
+			warnings: 1,
+		},
+		{
+			name:  "metrics_addr",
+			flags: Flags{MetricsAddr: "localhost:9999"},
+			conf: map[string]interface{}{
+				"service": map[string]interface{}{
+					"telemetry": map[string]interface{}{
+						"metrics": map[string]interface{}{"level": "detailed", "address": ":8888"},
+					},
+				},
+			},
+			expected: map[string]interface{}{
+				"service": map[string]interface{}{
+					"telemetry": map[string]interface{}{
+						"metrics": map[string]interface{}{"level": "detailed", "address": "localhost:9999"},
+					},
+				},
+			},
+			warnings: 1,
+		},
+		{
+			name:  "all_flags",
+			flags: Flags{MemBallastSizeMiB: 64, MetricsAddr: "localhost:9999"},
+			conf:  map[string]interface{}{},
+			expected: map[string]interface{}{
+				"extensions": map[string]interface{}{"memory_ballast": map[string]interface{}{"size_mib": uint64(64)}},
+				"service": map[string]interface{}{
+					"extensions": []interface{}{"memory_ballast"},
+					"telemetry": map[string]interface{}{
+						"metrics": map[string]interface{}{"address": "localhost:9999"},
+					},
+				},
+			},
+			warnings: 2,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var warnings []string
+			conf := confmap.NewFromStringMap(tt.conf)
+			require.NoError(t, New(tt.flags, func(msg string) { warnings = append(warnings, msg) }).Convert(context.Background(), conf))
+			assert.Equal(t, tt.expected, conf.ToStringMap())
+			assert.Len(t, warnings, tt.warnings)
+		})
+	}
+}
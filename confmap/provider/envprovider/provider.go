@@ -24,14 +24,18 @@ import (
 	"go.opentelemetry.io/collector/confmap/provider/internal"
 )
 
-const schemeName = "env"
+const (
+	schemeName       = "env"
+	defaultSeparator = ":-"
+)
 
 type provider struct{}
 
 // New returns a new confmap.Provider that reads the configuration from the given environment variable.
 //
 // This Provider supports "env" scheme, and can be called with a selector:
-// `env:NAME_OF_ENVIRONMENT_VARIABLE`
+// `env:NAME_OF_ENVIRONMENT_VARIABLE`, or with a default value used when the environment
+// variable is not set or empty: `env:NAME_OF_ENVIRONMENT_VARIABLE:-default`
 func New() confmap.Provider {
 	return &provider{}
 }
@@ -41,7 +45,12 @@ func (emp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFu
 		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
 	}
 
-	return internal.NewRetrievedFromYAML([]byte(os.Getenv(uri[len(schemeName)+1:])))
+	name, defaultValue, _ := strings.Cut(uri[len(schemeName)+1:], defaultSeparator)
+	val := os.Getenv(name)
+	if val == "" {
+		val = defaultValue
+	}
+	return internal.NewRetrievedFromYAML([]byte(val))
 }
 
 func (*provider) Scheme() string {
@@ -79,3 +79,23 @@ func TestEnv(t *testing.T) {
 
 	assert.NoError(t, env.Shutdown(context.Background()))
 }
+
+func TestEnvWithDefault(t *testing.T) {
+	const envName = "default-value"
+	env := New()
+
+	ret, err := env.Retrieve(context.Background(), envSchemePrefix+envName+":-localhost:4317", nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:4317", raw)
+
+	t.Setenv(envName, "otelcol:4317")
+	ret, err = env.Retrieve(context.Background(), envSchemePrefix+envName+":-localhost:4317", nil)
+	require.NoError(t, err)
+	raw, err = ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "otelcol:4317", raw)
+
+	assert.NoError(t, env.Shutdown(context.Background()))
+}
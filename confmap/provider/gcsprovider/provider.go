@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsprovider // import "go.opentelemetry.io/collector/confmap/provider/gcsprovider"
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/internal/configurablehttpprovider"
+)
+
+const (
+	schemeName      = "gs"
+	defaultEndpoint = "https://storage.googleapis.com"
+	readOnlyScope   = "https://www.googleapis.com/auth/devstorage.read_only"
+)
+
+type settings struct {
+	endpoint     string
+	pollInterval time.Duration
+}
+
+// Option configures the provider.
+type Option func(*settings)
+
+// WithEndpoint sends the requests to another endpoint implementing the Cloud Storage JSON API, instead of
+// "https://storage.googleapis.com".
+func WithEndpoint(endpoint string) Option {
+	return func(set *settings) {
+		set.endpoint = strings.TrimSuffix(endpoint, "/")
+	}
+}
+
+// WithPollInterval downloads the configuration again every interval, and notifies the watcher
+// once it changed so that the collector reloads it. The ETag of the object is sent back in the
+// If-None-Match header, so that an unchanged configuration is not downloaded again.
+func WithPollInterval(interval time.Duration) Option {
+	return func(set *settings) {
+		set.pollInterval = interval
+	}
+}
+
+// New returns a new confmap.Provider that downloads the configuration from a Google Cloud Storage object.
+//
+// This Provider supports "gs" scheme, and can be called with a "uri" that follows:
+//
+//	gs-uri = "gs://" bucket "/" object
+//
+// The requests are authenticated with an access token of the Application Default Credentials: the
+// GOOGLE_APPLICATION_CREDENTIALS file, the gcloud credentials, or the service account of the instance
+// from the metadata server of Compute Engine, GKE (with Workload Identity) or Cloud Run.
+//
+// Examples:
+// `gs://my-bucket/collector/config.yaml` - (unix, windows)
+func New(opts ...Option) confmap.Provider {
+	set := settings{endpoint: defaultEndpoint}
+	for _, opt := range opts {
+		opt(&set)
+	}
+	tokens := &tokenSource{}
+	return configurablehttpprovider.New(schemeName, configurablehttpprovider.Settings{
+		PollInterval: set.pollInterval,
+		URL:          set.objectURL,
+		Authorize: func(req *http.Request) error {
+			token, err := tokens.token()
+			if err != nil {
+				return err
+			}
+			token.SetAuthHeader(req)
+			return nil
+		},
+	})
+}
+
+// tokenSource gets the access tokens of the Application Default Credentials, found on first use.
+type tokenSource struct {
+	mu  sync.Mutex
+	src oauth2.TokenSource
+}
+
+func (s *tokenSource) token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.src == nil {
+		// The token source outlives the request, it must not depend on its context.
+		creds, err := google.FindDefaultCredentials(context.Background(), readOnlyScope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the Google Cloud credentials: %w", err)
+		}
+		s.src = creds.TokenSource
+	}
+	token, err := s.src.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a Google Cloud access token: %w", err)
+	}
+	return token, nil
+}
+
+// objectURL returns the URL downloading the object designated by the uri with the Cloud Storage JSON API.
+func (set *settings) objectURL(uri string) (string, error) {
+	bucket, object, ok := strings.Cut(strings.TrimPrefix(uri, schemeName+"://"), "/")
+	if !strings.HasPrefix(uri, schemeName+"://") || !ok || bucket == "" || object == "" {
+		return "", fmt.Errorf("invalid uri %q, expected %s://bucket/object", uri, schemeName)
+	}
+	return set.endpoint + "/storage/v1/b/" + url.PathEscape(bucket) + "/o/" + url.PathEscape(object) + "?alt=media", nil
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsprovider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestObjectURL(t *testing.T) {
+	set := &settings{endpoint: defaultEndpoint}
+	url, err := set.objectURL("gs://my-bucket/dir/config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "https://storage.googleapis.com/storage/v1/b/my-bucket/o/dir%2Fconfig.yaml?alt=media", url)
+
+	for _, uri := range []string{"gs://my-bucket", "gs://my-bucket/", "gs:///config.yaml", "gs:my-bucket/config.yaml"} {
+		_, err = set.objectURL(uri)
+		assert.Error(t, err, uri)
+	}
+}
+
+const metadataPath = "/computeMetadata/v1/"
+
+// setMetadataHost makes the Application Default Credentials come from the metadata server at host.
+func setMetadataHost(t *testing.T, host string) {
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("CLOUDSDK_CONFIG", t.TempDir())
+	t.Setenv("GCE_METADATA_HOST", host)
+}
+
+// newServer returns a server implementing both the metadata server and the Cloud Storage JSON API,
+// serving the config returned by config with its ETag.
+func newServer(t *testing.T, config func() string) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, metadataPath) {
+			assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+			if r.URL.Path == metadataPath+"instance/service-accounts/default/token" {
+				_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3600,"token_type":"Bearer"}`))
+				return
+			}
+			_, _ = w.Write([]byte("my-project"))
+			return
+		}
+		assert.Equal(t, "/storage/v1/b/my-bucket/o/dir%2Fconfig.yaml", r.URL.EscapedPath())
+		assert.Equal(t, "media", r.URL.Query().Get("alt"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		cfg := config()
+		etag := `"` + cfg + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(cfg))
+	}))
+	t.Cleanup(ts.Close)
+	setMetadataHost(t, strings.TrimPrefix(ts.URL, "http://"))
+	return ts
+}
+
+func TestRetrieve(t *testing.T) {
+	ts := newServer(t, func() string { return "key: value" })
+
+	p := New(WithEndpoint(ts.URL + "/"))
+	ret, err := p.Retrieve(context.Background(), "gs://my-bucket/dir/config.yaml", nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"key": "value"}, raw)
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestPollInterval(t *testing.T) {
+	changed := make(chan struct{})
+	ts := newServer(t, func() string {
+		select {
+		case <-changed:
+			return "key: other"
+		default:
+			return "key: value"
+		}
+	})
+
+	events := make(chan *confmap.ChangeEvent, 1)
+	p := New(WithEndpoint(ts.URL), WithPollInterval(10*time.Millisecond))
+	ret, err := p.Retrieve(context.Background(), "gs://my-bucket/dir/config.yaml", func(event *confmap.ChangeEvent) { events <- event })
+	require.NoError(t, err)
+
+	close(changed)
+	select {
+	case event := <-events:
+		assert.NoError(t, event.Error)
+	case <-time.After(time.Second):
+		t.Fatal("the change of the configuration was not notified")
+	}
+	assert.NoError(t, ret.Close(context.Background()))
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestRetrieveWithoutMetadataServer(t *testing.T) {
+	setMetadataHost(t, "localhost:0")
+
+	_, err := New(WithEndpoint("http://localhost:0")).Retrieve(context.Background(), "gs://my-bucket/config.yaml", nil)
+	assert.ErrorContains(t, err, "failed to get a Google Cloud access token")
+}
+
+func TestScheme(t *testing.T) {
+	p := New()
+	assert.Equal(t, "gs", p.Scheme())
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestValidateProviderScheme(t *testing.T) {
+	assert.NoError(t, confmaptest.ValidateProviderScheme(New()))
+}
@@ -15,22 +15,42 @@
 package httpprovider // import "go.opentelemetry.io/collector/confmap/provider/httpprovider"
 
 import (
-	"context"
-	"fmt"
-	"io"
-	"net/http"
-	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/confmap"
-	"go.opentelemetry.io/collector/confmap/provider/internal"
+	"go.opentelemetry.io/collector/confmap/provider/internal/configurablehttpprovider"
 )
 
 const (
 	schemeName = "http"
 )
 
-type provider struct {
-	client http.Client
+// Option configures the provider.
+type Option func(*configurablehttpprovider.Settings)
+
+// WithBearerToken sends the token in the Authorization header of the requests.
+func WithBearerToken(token string) Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.BearerToken = token
+	}
+}
+
+// WithBasicAuth authenticates the requests with HTTP basic authentication.
+// It is ignored if WithBearerToken is also used.
+func WithBasicAuth(username, password string) Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.Username = username
+		set.Password = password
+	}
+}
+
+// WithPollInterval downloads the configuration again every interval, and notifies the watcher
+// once it changed so that the collector reloads it. If the server answers with an ETag, it is sent
+// back in the If-None-Match header, so that an unchanged configuration is not downloaded again.
+func WithPollInterval(interval time.Duration) Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.PollInterval = interval
+	}
 }
 
 // New returns a new confmap.Provider that reads the configuration from a file.
@@ -41,40 +61,10 @@ type provider struct {
 //
 // Examples:
 // `http://localhost:3333/getConfig` - (unix, windows)
-func New() confmap.Provider {
-	return &provider{client: http.Client{}}
-}
-
-func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
-	if !strings.HasPrefix(uri, schemeName+":") {
-		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+func New(opts ...Option) confmap.Provider {
+	set := configurablehttpprovider.Settings{}
+	for _, opt := range opts {
+		opt(&set)
 	}
-
-	// send a HTTP GET request
-	resp, err := fmp.client.Get(uri)
-	if err != nil {
-		return nil, fmt.Errorf("unable to download the file via HTTP GET for uri %q, with err: %w ", uri, err)
-	}
-	defer resp.Body.Close()
-
-	// check the HTTP status code
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("404: resource didn't exist, fail to read the response body from uri %q", uri)
-	}
-
-	// read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("fail to read the response body from uri %q, with err: %w ", uri, err)
-	}
-
-	return internal.NewRetrievedFromYAML(body)
-}
-
-func (*provider) Scheme() string {
-	return schemeName
-}
-
-func (*provider) Shutdown(context.Context) error {
-	return nil
+	return configurablehttpprovider.New(schemeName, set)
 }
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpsprovider // import "go.opentelemetry.io/collector/confmap/provider/httpsprovider"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/internal/configurablehttpprovider"
+)
+
+const (
+	schemeName = "https"
+)
+
+// Option configures the provider.
+type Option func(*configurablehttpprovider.Settings)
+
+// WithBearerToken sends the token in the Authorization header of the requests.
+func WithBearerToken(token string) Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.BearerToken = token
+	}
+}
+
+// WithBasicAuth authenticates the requests with HTTP basic authentication.
+// It is ignored if WithBearerToken is also used.
+func WithBasicAuth(username, password string) Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.Username = username
+		set.Password = password
+	}
+}
+
+// WithPollInterval downloads the configuration again every interval, and notifies the watcher
+// once it changed so that the collector reloads it. If the server answers with an ETag, it is sent
+// back in the If-None-Match header, so that an unchanged configuration is not downloaded again.
+func WithPollInterval(interval time.Duration) Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.PollInterval = interval
+	}
+}
+
+// WithCAFile verifies the server certificate with the PEM encoded CA certificates of the file,
+// instead of the system certificates.
+func WithCAFile(file string) Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.CAFile = file
+	}
+}
+
+// WithClientCertificate presents the PEM encoded certificate and key of the files to the servers
+// requiring mutual TLS.
+func WithClientCertificate(certFile, keyFile string) Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.CertFile = certFile
+		set.KeyFile = keyFile
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of the server certificate.
+// It should only be used for testing.
+func WithInsecureSkipVerify() Option {
+	return func(set *configurablehttpprovider.Settings) {
+		set.InsecureSkipVerify = true
+	}
+}
+
+// New returns a new confmap.Provider that downloads the configuration with HTTPS.
+//
+// This Provider supports "https" scheme, and can be called with a "uri" that follows:
+//
+// One example for https-uri be like: https://localhost:3333/getConfig
+//
+// Examples:
+// `https://localhost:3333/getConfig` - (unix, windows)
+func New(opts ...Option) confmap.Provider {
+	set := configurablehttpprovider.Settings{}
+	for _, opt := range opts {
+		opt(&set)
+	}
+	return configurablehttpprovider.New(schemeName, set)
+}
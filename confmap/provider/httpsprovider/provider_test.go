@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpsprovider
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func newConfigServer(t *testing.T) *httptest.Server {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, err := os.ReadFile(filepath.Join("testdata", "otel-config.yaml"))
+		if !assert.NoError(t, err) {
+			w.WriteHeader(404)
+			return
+		}
+		_, err = w.Write(f)
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestCAFile(t *testing.T) {
+	ts := newConfigServer(t)
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}), 0600))
+
+	fp := New(WithCAFile(caFile))
+	_, err := fp.Retrieve(context.Background(), ts.URL, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestInsecureSkipVerify(t *testing.T) {
+	ts := newConfigServer(t)
+
+	fp := New(WithInsecureSkipVerify())
+	_, err := fp.Retrieve(context.Background(), ts.URL, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestUnknownAuthority(t *testing.T) {
+	ts := newConfigServer(t)
+
+	fp := New()
+	_, err := fp.Retrieve(context.Background(), ts.URL, nil)
+	assert.ErrorContains(t, err, "certificate")
+	assert.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestInvalidTLSFiles(t *testing.T) {
+	ts := newConfigServer(t)
+	invalid := filepath.Join(t.TempDir(), "invalid.pem")
+	require.NoError(t, os.WriteFile(invalid, []byte("invalid"), 0600))
+
+	tests := []struct {
+		name        string
+		opt         Option
+		expectedErr string
+	}{
+		{
+			name:        "missing CA file",
+			opt:         WithCAFile(filepath.Join("testdata", "missing.crt")),
+			expectedErr: "failed to read the CA file",
+		},
+		{
+			name:        "invalid CA file",
+			opt:         WithCAFile(invalid),
+			expectedErr: "failed to parse the CA file",
+		},
+		{
+			name:        "missing client key",
+			opt:         WithClientCertificate(invalid, ""),
+			expectedErr: "both the client certificate and key files must be set",
+		},
+		{
+			name:        "invalid client certificate",
+			opt:         WithClientCertificate(invalid, invalid),
+			expectedErr: "failed to load the client certificate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.opt).Retrieve(context.Background(), ts.URL, nil)
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestUnsupportedScheme(t *testing.T) {
+	fp := New()
+	_, err := fp.Retrieve(context.Background(), "http://...", nil)
+	assert.Error(t, err)
+	assert.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestScheme(t *testing.T) {
+	fp := New()
+	assert.Equal(t, "https", fp.Scheme())
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestValidateProviderScheme(t *testing.T) {
+	assert.NoError(t, confmaptest.ValidateProviderScheme(New()))
+}
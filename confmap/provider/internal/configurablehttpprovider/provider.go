@@ -0,0 +1,257 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configurablehttpprovider // import "go.opentelemetry.io/collector/confmap/provider/internal/configurablehttpprovider"
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/internal"
+)
+
+// Settings configures the HTTP client of a provider, and how it watches the configuration.
+type Settings struct {
+	// CAFile is the path of the PEM encoded CA certificates used to verify the server certificate,
+	// instead of the system certificates.
+	CAFile string
+	// CertFile and KeyFile are the paths of the PEM encoded client certificate and key,
+	// presented to servers requiring mutual TLS.
+	CertFile string
+	KeyFile  string
+	// InsecureSkipVerify disables the verification of the server certificate.
+	InsecureSkipVerify bool
+
+	// BearerToken is sent in the Authorization header of the requests.
+	BearerToken string
+	// Username and Password are sent with HTTP basic authentication, unless BearerToken is set.
+	Username string
+	Password string
+
+	// PollInterval is how often the configuration is downloaded again to detect its changes.
+	// By default (zero) the configuration is not watched.
+	PollInterval time.Duration
+
+	// URL returns the URL of the configuration designated by the uri. By default, it is the uri itself.
+	URL func(uri string) (string, error)
+	// Authorize authenticates the requests before they are sent, e.g. by signing them.
+	Authorize func(req *http.Request) error
+}
+
+type provider struct {
+	scheme string
+	set    Settings
+}
+
+// New returns a new confmap.Provider that downloads the configuration from the URIs of the given scheme.
+// The URIs of schemes other than "http" and "https" must be mapped to URLs with Settings.URL.
+func New(scheme string, set Settings) confmap.Provider {
+	return &provider{scheme: scheme, set: set}
+}
+
+func (p *provider) Retrieve(ctx context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, p.scheme+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, p.scheme)
+	}
+
+	client, err := p.newClient()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the HTTP client for uri %q, with err: %w", uri, err)
+	}
+
+	body, etag, err := p.download(ctx, client, uri, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if watcher == nil || p.set.PollInterval <= 0 {
+		return internal.NewRetrievedFromYAML(body)
+	}
+	w := &poller{
+		provider: p,
+		client:   client,
+		uri:      uri,
+		body:     body,
+		etag:     etag,
+		watcher:  watcher,
+		done:     make(chan struct{}),
+	}
+	ret, err := internal.NewRetrievedFromYAML(body, confmap.WithRetrievedClose(w.close))
+	if err != nil {
+		return nil, err
+	}
+	w.wg.Add(1)
+	go w.poll()
+	return ret, nil
+}
+
+func (p *provider) Scheme() string {
+	return p.scheme
+}
+
+func (*provider) Shutdown(context.Context) error {
+	return nil
+}
+
+func (p *provider) newClient() (*http.Client, error) {
+	if p.scheme != "https" {
+		return &http.Client{}, nil
+	}
+	tlsCfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: p.set.InsecureSkipVerify, // #nosec G402 explicitly requested by the user.
+	}
+	if p.set.CAFile != "" {
+		pem, err := os.ReadFile(p.set.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA file %q: %w", p.set.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse the CA file %q", p.set.CAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+	if p.set.CertFile != "" || p.set.KeyFile != "" {
+		if p.set.CertFile == "" || p.set.KeyFile == "" {
+			return nil, errors.New("both the client certificate and key files must be set")
+		}
+		cert, err := tls.LoadX509KeyPair(p.set.CertFile, p.set.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+	return &http.Client{Transport: transport}, nil
+}
+
+// download sends a HTTP GET request for the uri, and returns the body and the ETag of the response.
+// If etag is set and the configuration did not change, the returned body is nil.
+func (p *provider) download(ctx context.Context, client *http.Client, uri string, etag string) ([]byte, string, error) {
+	url := uri
+	if p.set.URL != nil {
+		var err error
+		if url, err = p.set.URL(uri); err != nil {
+			return nil, "", err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to create the HTTP GET request for uri %q, with err: %w", uri, err)
+	}
+	switch {
+	case p.set.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+p.set.BearerToken)
+	case p.set.Username != "" || p.set.Password != "":
+		req.SetBasicAuth(p.set.Username, p.set.Password)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if p.set.Authorize != nil {
+		if err = p.set.Authorize(req); err != nil {
+			return nil, "", fmt.Errorf("unable to authorize the request for uri %q, with err: %w", uri, err)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to download the file via HTTP GET for uri %q, with err: %w ", uri, err)
+	}
+	defer resp.Body.Close()
+
+	if etag != "" && resp.StatusCode == http.StatusNotModified {
+		return nil, etag, nil
+	}
+	// check the HTTP status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status %q, fail to read the response body from uri %q", resp.Status, uri)
+	}
+
+	// read the response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("fail to read the response body from uri %q, with err: %w ", uri, err)
+	}
+	return body, resp.Header.Get("ETag"), nil
+}
+
+// poller downloads the configuration every PollInterval, and notifies the watcher once it changes.
+type poller struct {
+	*provider
+	client  *http.Client
+	uri     string
+	body    []byte
+	etag    string
+	watcher confmap.WatcherFunc
+
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func (w *poller) poll() {
+	defer w.wg.Done()
+	ticker := time.NewTicker(w.set.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+		}
+		if w.changed() {
+			w.watcher(&confmap.ChangeEvent{})
+			return
+		}
+	}
+}
+
+// changed downloads the configuration again, and returns whether it changed. The download errors are ignored,
+// so that a server temporarily unavailable does not stop the collector.
+func (w *poller) changed() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), w.set.PollInterval)
+	defer cancel()
+	go func() {
+		select {
+		case <-w.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	body, _, err := w.download(ctx, w.client, w.uri, w.etag)
+	if err != nil || body == nil {
+		return false
+	}
+	return !bytes.Equal(body, w.body)
+}
+
+func (w *poller) close(context.Context) error {
+	w.closeOnce.Do(func() { close(w.done) })
+	w.wg.Wait()
+	return nil
+}
@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configurablehttpprovider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestUnsupportedScheme(t *testing.T) {
+	p := New("http", Settings{})
+	_, err := p.Retrieve(context.Background(), "https://...", nil)
+	assert.EqualError(t, err, `"https://..." uri is not supported by "http" provider`)
+	assert.Equal(t, "http", p.Scheme())
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestAuthorization(t *testing.T) {
+	tests := []struct {
+		name     string
+		set      Settings
+		expected string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:     "bearer token",
+			set:      Settings{BearerToken: "token", Username: "user"},
+			expected: "Bearer token",
+		},
+		{
+			name:     "basic auth",
+			set:      Settings{Username: "user", Password: "pass"},
+			expected: "Basic dXNlcjpwYXNz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.expected, r.Header.Get("Authorization"))
+				_, _ = w.Write([]byte("key: value"))
+			}))
+			defer ts.Close()
+
+			ret, err := New("http", tt.set).Retrieve(context.Background(), ts.URL, nil)
+			require.NoError(t, err)
+			raw, err := ret.AsRaw()
+			require.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"key": "value"}, raw)
+		})
+	}
+}
+
+func TestURLAndAuthorize(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/bucket/config.yaml", r.URL.Path)
+		assert.Equal(t, "signed", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte("key: value"))
+	}))
+	defer ts.Close()
+
+	p := New("s3", Settings{
+		URL: func(uri string) (string, error) {
+			return ts.URL + "/" + strings.TrimPrefix(uri, "s3://"), nil
+		},
+		Authorize: func(req *http.Request) error {
+			req.Header.Set("Authorization", "signed")
+			return nil
+		},
+	})
+	_, err := p.Retrieve(context.Background(), "s3://bucket/config.yaml", nil)
+	assert.NoError(t, err)
+
+	p = New("s3", Settings{
+		URL: func(uri string) (string, error) {
+			return "", errors.New("invalid uri")
+		},
+	})
+	_, err = p.Retrieve(context.Background(), "s3://bucket", nil)
+	assert.EqualError(t, err, "invalid uri")
+
+	p = New("s3", Settings{
+		URL: func(uri string) (string, error) {
+			return ts.URL, nil
+		},
+		Authorize: func(req *http.Request) error {
+			return errors.New("no credentials")
+		},
+	})
+	_, err = p.Retrieve(context.Background(), "s3://bucket/config.yaml", nil)
+	assert.EqualError(t, err, `unable to authorize the request for uri "s3://bucket/config.yaml", with err: no credentials`)
+}
+
+func TestUnexpectedStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	_, err := New("http", Settings{}).Retrieve(context.Background(), ts.URL, nil)
+	assert.ErrorContains(t, err, `unexpected status "401 Unauthorized"`)
+}
+
+// configServer serves a configuration, with an ETag if etag is set.
+type configServer struct {
+	mu       sync.Mutex
+	config   string
+	etag     bool
+	requests int
+}
+
+func (s *configServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	if s.etag {
+		etag := `"` + s.config + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+	}
+	_, _ = w.Write([]byte(s.config))
+}
+
+func (s *configServer) set(config string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = config
+}
+
+func (s *configServer) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func TestPollInterval(t *testing.T) {
+	for _, etag := range []bool{false, true} {
+		srv := &configServer{config: "key: value", etag: etag}
+		ts := httptest.NewServer(srv)
+		defer ts.Close()
+
+		events := make(chan *confmap.ChangeEvent, 1)
+		p := New("http", Settings{PollInterval: 10 * time.Millisecond})
+		ret, err := p.Retrieve(context.Background(), ts.URL, func(event *confmap.ChangeEvent) { events <- event })
+		require.NoError(t, err)
+
+		// The unchanged configuration does not notify the watcher.
+		assert.Eventually(t, func() bool { return srv.count() > 3 }, time.Second, 10*time.Millisecond)
+		assert.Empty(t, events)
+
+		srv.set("key: other")
+		select {
+		case event := <-events:
+			assert.NoError(t, event.Error)
+		case <-time.After(time.Second):
+			t.Fatal("the change of the configuration was not notified")
+		}
+		require.NoError(t, ret.Close(context.Background()))
+		require.NoError(t, p.Shutdown(context.Background()))
+	}
+}
+
+func TestPollIntervalClose(t *testing.T) {
+	srv := &configServer{config: "key: value"}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	ret, err := New("http", Settings{PollInterval: 10 * time.Millisecond}).Retrieve(context.Background(), ts.URL, func(*confmap.ChangeEvent) {
+		t.Error("the watcher must not be notified")
+	})
+	require.NoError(t, err)
+	require.NoError(t, ret.Close(context.Background()))
+
+	// No more requests are sent once the configuration is closed.
+	count := srv.count()
+	srv.set("key: other")
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, count, srv.count())
+}
+
+func TestPollIntervalWithoutWatcher(t *testing.T) {
+	srv := &configServer{config: "key: value"}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	ret, err := New("http", Settings{PollInterval: 10 * time.Millisecond}).Retrieve(context.Background(), ts.URL, nil)
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 1, srv.count())
+	require.NoError(t, ret.Close(context.Background()))
+}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3provider // import "go.opentelemetry.io/collector/confmap/provider/s3provider"
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/internal/configurablehttpprovider"
+)
+
+const (
+	schemeName    = "s3"
+	defaultRegion = "us-east-1"
+)
+
+// emptyPayloadHash is the SHA-256 of the empty body of the GET requests, sent in the X-Amz-Content-Sha256 header.
+var emptyPayloadHash = func() string {
+	sum := sha256.Sum256(nil)
+	return hex.EncodeToString(sum[:])
+}()
+
+type settings struct {
+	region       string
+	endpoint     string
+	pollInterval time.Duration
+}
+
+// Option configures the provider.
+type Option func(*settings)
+
+// WithRegion sets the AWS region of the buckets. By default, it is the region of the AWS SDK default
+// configuration, e.g. the AWS_REGION environment variable, or "us-east-1".
+func WithRegion(region string) Option {
+	return func(set *settings) {
+		set.region = region
+	}
+}
+
+// WithEndpoint sends the requests to an S3 compatible endpoint, e.g. "https://minio.example.com:9000",
+// with path-style URLs, instead of the AWS endpoint of the region.
+func WithEndpoint(endpoint string) Option {
+	return func(set *settings) {
+		set.endpoint = strings.TrimSuffix(endpoint, "/")
+	}
+}
+
+// WithPollInterval downloads the configuration again every interval, and notifies the watcher
+// once it changed so that the collector reloads it. The ETag of the object is sent back in the
+// If-None-Match header, so that an unchanged configuration is not downloaded again.
+func WithPollInterval(interval time.Duration) Option {
+	return func(set *settings) {
+		set.pollInterval = interval
+	}
+}
+
+// New returns a new confmap.Provider that downloads the configuration from an AWS S3 object.
+//
+// This Provider supports "s3" scheme, and can be called with a "uri" that follows:
+//
+//	s3-uri = "s3://" bucket "/" key
+//
+// The requests are signed with AWS Signature Version 4, with the credentials and the region of the
+// AWS SDK default configuration: environment variables, shared configuration files, web identity,
+// ECS container credentials or EC2 instance metadata.
+//
+// Examples:
+// `s3://my-bucket/collector/config.yaml` - (unix, windows)
+func New(opts ...Option) confmap.Provider {
+	set := settings{}
+	for _, opt := range opts {
+		opt(&set)
+	}
+	c := &client{set: set, signer: v4.NewSigner(func(o *v4.SignerOptions) {
+		// S3 expects the path of the request to be escaped once.
+		o.DisableURIPathEscaping = true
+	})}
+	return configurablehttpprovider.New(schemeName, configurablehttpprovider.Settings{
+		PollInterval: set.pollInterval,
+		URL:          c.objectURL,
+		Authorize:    c.authorize,
+	})
+}
+
+// client locates and signs the requests with the AWS SDK default configuration, loaded on first use.
+type client struct {
+	set    settings
+	signer *v4.Signer
+
+	mu     sync.Mutex
+	awsCfg *aws.Config
+}
+
+func (c *client) config(ctx context.Context) (aws.Config, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.awsCfg != nil {
+		return *c.awsCfg, nil
+	}
+	awsCfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("failed to load the AWS configuration: %w", err)
+	}
+	if c.set.region != "" {
+		awsCfg.Region = c.set.region
+	}
+	if awsCfg.Region == "" {
+		awsCfg.Region = defaultRegion
+	}
+	c.awsCfg = &awsCfg
+	return awsCfg, nil
+}
+
+func (c *client) authorize(req *http.Request) error {
+	awsCfg, err := c.config(req.Context())
+	if err != nil {
+		return err
+	}
+	creds, err := awsCfg.Credentials.Retrieve(req.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get the AWS credentials: %w", err)
+	}
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+	return c.signer.SignHTTP(req.Context(), creds, req, emptyPayloadHash, "s3", awsCfg.Region, time.Now())
+}
+
+// objectURL returns the URL of the object designated by the uri.
+func (c *client) objectURL(uri string) (string, error) {
+	bucket, key, ok := strings.Cut(strings.TrimPrefix(uri, schemeName+"://"), "/")
+	if !strings.HasPrefix(uri, schemeName+"://") || !ok || bucket == "" || key == "" {
+		return "", fmt.Errorf("invalid uri %q, expected %s://bucket/key", uri, schemeName)
+	}
+	if c.set.endpoint != "" {
+		return c.set.endpoint + "/" + uriEncode(bucket, true) + "/" + uriEncode(key, false), nil
+	}
+	awsCfg, err := c.config(context.Background())
+	if err != nil {
+		return "", err
+	}
+	return "https://" + bucket + ".s3." + awsCfg.Region + ".amazonaws.com/" + uriEncode(key, false), nil
+}
+
+// uriEncode escapes s as expected by S3, keeping the slashes unless encodeSlash is set.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && !encodeSlash) {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+// setEnv isolates the AWS SDK default configuration from the environment of the test.
+func setEnv(t *testing.T, env map[string]string) {
+	for _, name := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "AWS_REGION", "AWS_DEFAULT_REGION", "AWS_PROFILE",
+		"AWS_CONTAINER_CREDENTIALS_RELATIVE_URI", "AWS_CONTAINER_CREDENTIALS_FULL_URI", "AWS_WEB_IDENTITY_TOKEN_FILE", "AWS_ROLE_ARN"} {
+		t.Setenv(name, env[name])
+	}
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+}
+
+func TestObjectURL(t *testing.T) {
+	setEnv(t, map[string]string{"AWS_REGION": "eu-west-1"})
+	c := &client{}
+	url, err := c.objectURL("s3://my-bucket/dir/config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "https://my-bucket.s3.eu-west-1.amazonaws.com/dir/config.yaml", url)
+
+	c = &client{set: settings{region: "eu-central-1"}}
+	url, err = c.objectURL("s3://my-bucket/dir/config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "https://my-bucket.s3.eu-central-1.amazonaws.com/dir/config.yaml", url)
+
+	c = &client{set: settings{endpoint: "http://localhost:9000"}}
+	url, err = c.objectURL("s3://my-bucket/dir/config file.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:9000/my-bucket/dir/config%20file.yaml", url)
+
+	for _, uri := range []string{"s3://my-bucket", "s3://my-bucket/", "s3:///config.yaml", "s3:my-bucket/config.yaml"} {
+		_, err = c.objectURL(uri)
+		assert.Error(t, err, uri)
+	}
+}
+
+func TestObjectURLDefaultRegion(t *testing.T) {
+	setEnv(t, nil)
+	url, err := (&client{}).objectURL("s3://my-bucket/config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "https://my-bucket.s3.us-east-1.amazonaws.com/config.yaml", url)
+}
+
+func TestRetrieve(t *testing.T) {
+	setEnv(t, map[string]string{"AWS_ACCESS_KEY_ID": "id", "AWS_SECRET_ACCESS_KEY": "secret"})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/my-bucket/config.yaml", r.URL.Path)
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=id/"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/s3/aws4_request")
+		assert.Equal(t, emptyPayloadHash, r.Header.Get("X-Amz-Content-Sha256"))
+		_, _ = w.Write([]byte("key: value"))
+	}))
+	defer ts.Close()
+
+	p := New(WithEndpoint(ts.URL+"/"), WithRegion("eu-west-1"), WithPollInterval(time.Minute))
+	ret, err := p.Retrieve(context.Background(), "s3://my-bucket/config.yaml", nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"key": "value"}, raw)
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestRetrieveWithoutCredentials(t *testing.T) {
+	setEnv(t, map[string]string{"AWS_CONTAINER_CREDENTIALS_FULL_URI": "http://localhost:0/credentials"})
+
+	p := New(WithEndpoint("http://localhost:0"))
+	_, err := p.Retrieve(context.Background(), "s3://my-bucket/config.yaml", nil)
+	assert.ErrorContains(t, err, "failed to get the AWS credentials")
+}
+
+func TestScheme(t *testing.T) {
+	p := New()
+	assert.Equal(t, "s3", p.Scheme())
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestValidateProviderScheme(t *testing.T) {
+	assert.NoError(t, confmaptest.ValidateProviderScheme(New()))
+}
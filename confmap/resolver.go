@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"regexp"
 	"strings"
 
@@ -41,6 +42,13 @@ var (
 
 const expandEnabled = "confmap.expandEnabled"
 
+const (
+	envScheme = "env"
+	// envDefaultSeparator separates the name of the environment variable from its default value
+	// in an "env" URI, e.g. "env:VAR:-default", following the shell syntax.
+	envDefaultSeparator = ":-"
+)
+
 func init() {
 	// TODO: Remove this if by v0.64.0 no complains from distros.
 	featuregate.GetRegistry().MustRegisterID(
@@ -52,9 +60,13 @@ func init() {
 
 // Resolver resolves a configuration as a Conf.
 type Resolver struct {
-	uris       []location
-	providers  map[string]Provider
-	converters []Converter
+	uris         []location
+	overlayURIs  []location
+	overrideURIs []location
+	providers    map[string]Provider
+	converters   []Converter
+
+	strictEnvSubstitution bool
 
 	closers []CloseFunc
 	watcher chan error
@@ -63,22 +75,39 @@ type Resolver struct {
 // ResolverSettings are the settings to configure the behavior of the Resolver.
 type ResolverSettings struct {
 	// URIs locations from where the Conf is retrieved, and merged in the given order.
-	// It is required to have at least one location.
+	// It is required to have at least one location, here, in OverlayURIs or in OverrideURIs.
 	URIs []string
 
+	// OverlayURIs locations from where overlays are retrieved, and merged in the given order after the URIs.
+	// Unlike in the URIs, a null value in an overlay removes the key from the configuration instead of
+	// setting it to null, see Conf.MergeOverlay. Optional.
+	OverlayURIs []string
+
+	// OverrideURIs locations from where the Conf is retrieved, and merged in the given order after the
+	// overlays, so that they take precedence over all the other locations. They are merged as the URIs,
+	// a null value sets the key to null. Optional.
+	OverrideURIs []string
+
 	// Providers is a map of pairs <scheme, Provider>.
 	// It is required to have at least one Provider.
 	Providers map[string]Provider
 
 	// MapConverters is a slice of Converter.
 	Converters []Converter
+
+	// StrictEnvSubstitution makes the resolution fail when an "env" URI, e.g. "${env:VAR}", references
+	// an environment variable that is not set and has no default value, e.g. "${env:VAR:-default}".
+	// Optional, by default a missing environment variable resolves to an empty value.
+	StrictEnvSubstitution bool
 }
 
 // NewResolver returns a new Resolver that resolves configuration from multiple URIs.
 //
 // To resolve a configuration the following steps will happen:
 //  1. Retrieves individual configurations from all given "URIs", and merge them in the retrieve order.
-//  2. Once the Conf is merged, apply the converters in the given order.
+//  2. Retrieves the overlays from all given "OverlayURIs", and merge them in the retrieve order.
+//  3. Retrieves individual configurations from all given "OverrideURIs", and merge them in the retrieve order.
+//  4. Once the Conf is merged, apply the converters in the given order.
 //
 // After the configuration was resolved the `Resolver` can be used as a single point to watch for updates in
 // the configuration data retrieved via the config providers used to process the "initial" configuration and to generate
@@ -94,7 +123,7 @@ type ResolverSettings struct {
 // `uri` must follow the "<scheme>:<opaque_data>" format. This format is compatible with the URI definition
 // (see https://datatracker.ietf.org/doc/html/rfc3986). An empty "<scheme>" defaults to "file" schema.
 func NewResolver(set ResolverSettings) (*Resolver, error) {
-	if len(set.URIs) == 0 {
+	if len(set.URIs) == 0 && len(set.OverlayURIs) == 0 && len(set.OverrideURIs) == 0 {
 		return nil, errors.New("invalid map resolver config: no URIs")
 	}
 
@@ -103,23 +132,17 @@ func NewResolver(set ResolverSettings) (*Resolver, error) {
 	}
 
 	// Safe copy, ensures the slices and maps cannot be changed from the caller.
-	uris := make([]location, len(set.URIs))
-	for i, uri := range set.URIs {
-		// For backwards compatibility:
-		// - empty url scheme means "file".
-		// - "^[A-z]:" also means "file"
-		if driverLetterRegexp.MatchString(uri) || !strings.Contains(uri, ":") {
-			uris[i] = location{scheme: "file", opaqueValue: uri}
-			continue
-		}
-		lURI, err := newLocation(uri)
-		if err != nil {
-			return nil, err
-		}
-		if _, ok := set.Providers[lURI.scheme]; !ok {
-			return nil, fmt.Errorf("unsupported scheme on URI %q", uri)
-		}
-		uris[i] = lURI
+	uris, err := toLocations(set.URIs, set.Providers)
+	if err != nil {
+		return nil, err
+	}
+	overlayURIs, err := toLocations(set.OverlayURIs, set.Providers)
+	if err != nil {
+		return nil, err
+	}
+	overrideURIs, err := toLocations(set.OverrideURIs, set.Providers)
+	if err != nil {
+		return nil, err
 	}
 	providersCopy := make(map[string]Provider, len(set.Providers))
 	for k, v := range set.Providers {
@@ -129,10 +152,14 @@ func NewResolver(set ResolverSettings) (*Resolver, error) {
 	copy(convertersCopy, set.Converters)
 
 	return &Resolver{
-		uris:       uris,
-		providers:  providersCopy,
-		converters: convertersCopy,
-		watcher:    make(chan error, 1),
+		uris:         uris,
+		overlayURIs:  overlayURIs,
+		overrideURIs: overrideURIs,
+		providers:    providersCopy,
+		converters:   convertersCopy,
+
+		strictEnvSubstitution: set.StrictEnvSubstitution,
+		watcher:               make(chan error, 1),
 	}, nil
 }
 
@@ -147,19 +174,15 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 
 	// Retrieves individual configurations from all URIs in the given order, and merge them in retMap.
 	retMap := New()
-	for _, uri := range mr.uris {
-		ret, err := mr.retrieveValue(ctx, uri)
-		if err != nil {
-			return nil, fmt.Errorf("cannot retrieve the configuration: %w", err)
-		}
-		mr.closers = append(mr.closers, ret.Close)
-		retCfgMap, err := ret.AsConf()
-		if err != nil {
-			return nil, err
-		}
-		if err = retMap.Merge(retCfgMap); err != nil {
-			return nil, err
-		}
+	if err := mr.retrieveAndMerge(ctx, mr.uris, retMap.Merge); err != nil {
+		return nil, err
+	}
+	// Then the overlays, which can remove keys.
+	if err := mr.retrieveAndMerge(ctx, mr.overlayURIs, retMap.MergeOverlay); err != nil {
+		return nil, err
+	}
+	if err := mr.retrieveAndMerge(ctx, mr.overrideURIs, retMap.Merge); err != nil {
+		return nil, err
 	}
 
 	if featuregate.GetRegistry().IsEnabled(expandEnabled) {
@@ -183,6 +206,24 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 	return retMap, nil
 }
 
+func (mr *Resolver) retrieveAndMerge(ctx context.Context, uris []location, merge func(*Conf) error) error {
+	for _, uri := range uris {
+		ret, err := mr.retrieveValue(ctx, uri)
+		if err != nil {
+			return fmt.Errorf("cannot retrieve the configuration: %w", err)
+		}
+		mr.closers = append(mr.closers, ret.Close)
+		retCfgMap, err := ret.AsConf()
+		if err != nil {
+			return err
+		}
+		if err = merge(retCfgMap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Watch blocks until any configuration change was detected or an unrecoverable error
 // happened during monitoring the configuration changes.
 //
@@ -296,6 +337,28 @@ func (c location) asString() string {
 	return c.scheme + ":" + c.opaqueValue
 }
 
+func toLocations(uris []string, providers map[string]Provider) ([]location, error) {
+	locations := make([]location, len(uris))
+	for i, uri := range uris {
+		// For backwards compatibility:
+		// - empty url scheme means "file".
+		// - "^[A-z]:" also means "file"
+		if driverLetterRegexp.MatchString(uri) || !strings.Contains(uri, ":") {
+			locations[i] = location{scheme: "file", opaqueValue: uri}
+			continue
+		}
+		lURI, err := newLocation(uri)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := providers[lURI.scheme]; !ok {
+			return nil, fmt.Errorf("unsupported scheme on URI %q", uri)
+		}
+		locations[i] = lURI
+	}
+	return locations, nil
+}
+
 func newLocation(uri string) (location, error) {
 	submatches := locationRegexp.FindStringSubmatch(uri)
 	if len(submatches) != 3 {
@@ -309,5 +372,23 @@ func (mr *Resolver) retrieveValue(ctx context.Context, uri location) (*Retrieved
 	if !ok {
 		return nil, fmt.Errorf("scheme %q is not supported for uri %q", uri.scheme, uri.asString())
 	}
+	if mr.strictEnvSubstitution && uri.scheme == envScheme {
+		if err := checkEnvVarSet(uri.opaqueValue); err != nil {
+			return nil, err
+		}
+	}
 	return p.Retrieve(ctx, uri.asString(), mr.onChange)
 }
+
+// checkEnvVarSet returns an error if the environment variable referenced by an "env" URI
+// is not set and the URI has no default value, as in "VAR:-default".
+func checkEnvVarSet(opaqueValue string) error {
+	name, _, hasDefault := strings.Cut(opaqueValue, envDefaultSeparator)
+	if hasDefault {
+		return nil
+	}
+	if _, ok := os.LookupEnv(name); !ok {
+		return fmt.Errorf("environment variable %q is not set and has no default value", name)
+	}
+	return nil
+}
@@ -300,6 +300,55 @@ func TestResolverNewLinesInOpaqueValue(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestResolverOverlays(t *testing.T) {
+	confs := map[string]map[string]interface{}{
+		"base": {
+			"receivers": map[string]interface{}{"otlp": nil, "prometheus": map[string]interface{}{"interval": "10s"}},
+			"exporters": map[string]interface{}{"logging": nil},
+		},
+		"env": {
+			"receivers": map[string]interface{}{"prometheus": map[string]interface{}{"interval": "30s"}},
+		},
+		"overlay1": {
+			"receivers": map[string]interface{}{"prometheus": nil},
+			"exporters": map[string]interface{}{"otlp": map[string]interface{}{"endpoint": "backend:4317"}},
+		},
+		"overlay2": {
+			"exporters": map[string]interface{}{"logging": nil},
+		},
+		"override": {
+			"exporters": map[string]interface{}{"otlp": map[string]interface{}{"endpoint": "other:4317", "tls": nil}},
+		},
+	}
+	provider := newFakeProvider("mock", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+		return NewRetrieved(confs[uri[len("mock:"):]])
+	})
+
+	resolver, err := NewResolver(ResolverSettings{
+		URIs:         []string{"mock:base", "mock:env"},
+		OverlayURIs:  []string{"mock:overlay1", "mock:overlay2"},
+		OverrideURIs: []string{"mock:override"},
+		Providers:    makeMapProvidersMap(provider),
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"receivers": map[string]interface{}{"otlp": nil},
+		// The overrides are merged after the overlays, a null value is kept.
+		"exporters": map[string]interface{}{"otlp": map[string]interface{}{"endpoint": "other:4317", "tls": nil}},
+	}, conf.ToStringMap())
+}
+
+func TestResolverOverlayInvalidScheme(t *testing.T) {
+	_, err := NewResolver(ResolverSettings{
+		URIs:        []string{"mock:base"},
+		OverlayURIs: []string{"s3:overlay"},
+		Providers:   makeMapProvidersMap(&mockProvider{}),
+	})
+	assert.EqualError(t, err, `unsupported scheme on URI "s3:overlay"`)
+}
+
 func TestResolverNoLocations(t *testing.T) {
 	_, err := NewResolver(ResolverSettings{
 		URIs:       []string{},
@@ -507,6 +556,55 @@ func TestResolverExpandInvalidOpaqueValue(t *testing.T) {
 	assert.EqualError(t, err, `the uri "test:$VALUE" contains unsupported characters ('$')`)
 }
 
+func TestResolverStrictEnvSubstitution(t *testing.T) {
+	const envName = "CONFMAP_STRICT_TEST_VAR"
+	tests := []struct {
+		name        string
+		value       string
+		setEnv      bool
+		expectedErr string
+	}{
+		{
+			name:   "set",
+			value:  "${env:" + envName + "}",
+			setEnv: true,
+		},
+		{
+			name:  "missing with default",
+			value: "${env:" + envName + ":-default}",
+		},
+		{
+			name:        "missing",
+			value:       "${env:" + envName + "}",
+			expectedErr: `environment variable "CONFMAP_STRICT_TEST_VAR" is not set and has no default value`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setEnv {
+				t.Setenv(envName, "value")
+			}
+			provider := newFakeProvider("input", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(map[string]interface{}{"test": tt.value})
+			})
+			envProvider := newFakeProvider("env", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved("value")
+			})
+
+			resolver, err := NewResolver(ResolverSettings{URIs: []string{"input:"}, Providers: makeMapProvidersMap(provider, envProvider), StrictEnvSubstitution: true})
+			require.NoError(t, err)
+
+			cfgMap, err := resolver.Resolve(context.Background())
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"test": "value"}, cfgMap.ToStringMap())
+		})
+	}
+}
+
 func makeMapProvidersMap(providers ...Provider) map[string]Provider {
 	ret := make(map[string]Provider, len(providers))
 	for _, provider := range providers {
@@ -12,81 +12,48 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+// Package connector contains the types to implement a connector, a component that is used as an exporter
+// in the pipelines that send data to it and as a receiver in the pipelines it sends data to.
 package connector // import "go.opentelemetry.io/collector/connector"
 
 import (
 	"go.opentelemetry.io/collector/component"
 )
 
-// Connector sends telemetry data from one pipeline to another. A connector
-// is both an exporter and receiver, working together to connect pipelines.
-// The purpose is to allow for differentiated processing of telemetry data.
-//
-// Connectors can be used to replicate or route data, merge data streams,
-// derive signals from other signals, etc.
+// Connector sends the data of one or more pipelines to one or more other pipelines.
 type Connector interface {
 	component.Component
 }
 
-// CreateSettings configures Connector creators.
-type CreateSettings struct {
-	TelemetrySettings component.TelemetrySettings
-
-	// BuildInfo can be used by components for informational purposes
-	BuildInfo component.BuildInfo
-}
-
-// Factory is factory interface for connectors.
-//
-// This interface cannot be directly implemented. Implementations must
-// use the NewFactory to implement it.
-type Factory interface {
-	component.Factory
-}
-
-// FactoryOption applies changes to Factory.
-type FactoryOption interface {
-	// apply applies the option.
-	apply(o *factory)
-}
-
-var _ FactoryOption = (*factoryOptionFunc)(nil)
+// Traces is a Connector that consumes traces.
+type Traces = component.TracesConnector
 
-// factoryOptionFunc is an FactoryOption created through a function.
-type factoryOptionFunc func(*factory)
+// Metrics is a Connector that consumes metrics.
+type Metrics = component.MetricsConnector
 
-func (f factoryOptionFunc) apply(o *factory) {
-	f(o)
-}
+// Logs is a Connector that consumes logs.
+type Logs = component.LogsConnector
 
-// factory implements Factory.
-type factory struct {
-	component.Factory
-	cfgType component.Type
-	component.CreateDefaultConfigFunc
-}
-
-var _ Factory = (*factory)(nil)
+// CreateSettings configures Connector creators.
+type CreateSettings = component.ConnectorCreateSettings
 
-// Type returns the type of component.
-func (f *factory) Type() component.Type {
-	return f.cfgType
-}
+// Factory is factory interface for connectors.
+type Factory = component.ConnectorFactory
 
-// CreateDefaultConfig creates the default configuration for the Component.
-// TODO: Remove this when we remove the private func from component.Factory and add it to every specialized Factory.
-func (f *factory) CreateDefaultConfig() component.Config {
-	return f.CreateDefaultConfigFunc()
-}
+// FactoryOption apply changes to the Factory created by NewFactory.
+type FactoryOption = component.ConnectorFactoryOption
 
 // NewFactory returns a Factory.
-func NewFactory(cfgType component.Type, createDefaultConfig component.CreateDefaultConfigFunc, options ...FactoryOption) Factory {
-	f := &factory{
-		cfgType:                 cfgType,
-		CreateDefaultConfigFunc: createDefaultConfig,
-	}
-	for _, opt := range options {
-		opt.apply(f)
-	}
-	return f
-}
+var NewFactory = component.NewConnectorFactory
+
+var (
+	WithTracesToTraces   = component.WithTracesToTraces
+	WithTracesToMetrics  = component.WithTracesToMetrics
+	WithTracesToLogs     = component.WithTracesToLogs
+	WithMetricsToTraces  = component.WithMetricsToTraces
+	WithMetricsToMetrics = component.WithMetricsToMetrics
+	WithMetricsToLogs    = component.WithMetricsToLogs
+	WithLogsToTraces     = component.WithLogsToTraces
+	WithLogsToMetrics    = component.WithLogsToMetrics
+	WithLogsToLogs       = component.WithLogsToLogs
+)
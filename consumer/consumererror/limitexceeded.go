@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import "errors"
+
+// limitExceeded is an error returned when the data is refused because a limit was exceeded,
+// e.g. the memory usage or the capacity of a queue. The data may be accepted once the load decreases.
+type limitExceeded struct {
+	err error
+}
+
+// NewLimitExceeded wraps an error to indicate that the data was refused because a limit was exceeded.
+// Senders should retry later, after backing off.
+func NewLimitExceeded(err error) error {
+	return limitExceeded{err: err}
+}
+
+func (l limitExceeded) Error() string {
+	return "Limit exceeded: " + l.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (l limitExceeded) Unwrap() error {
+	return l.err
+}
+
+// IsLimitExceeded checks if an error was wrapped with the NewLimitExceeded function.
+func IsLimitExceeded(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.As(err, &limitExceeded{})
+}
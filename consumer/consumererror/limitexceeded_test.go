@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsLimitExceeded(t *testing.T) {
+	var err error
+	assert.False(t, IsLimitExceeded(err))
+
+	err = errors.New("testError")
+	assert.False(t, IsLimitExceeded(err))
+	assert.False(t, IsLimitExceeded(NewPermanent(err)))
+
+	err = NewLimitExceeded(err)
+	assert.True(t, IsLimitExceeded(err))
+	assert.False(t, IsPermanent(err))
+	assert.Equal(t, "Limit exceeded: testError", err.Error())
+
+	err = fmt.Errorf("%w", err)
+	assert.True(t, IsLimitExceeded(err))
+}
+
+func TestLimitExceeded_Unwrap(t *testing.T) {
+	err := errors.New("queue is full")
+	assert.ErrorIs(t, NewLimitExceeded(err), err)
+}
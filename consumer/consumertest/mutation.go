@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumertest // import "go.opentelemetry.io/collector/consumer/consumertest"
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	tracesMarshaler  = &ptrace.ProtoMarshaler{}
+	metricsMarshaler = &pmetric.ProtoMarshaler{}
+	logsMarshaler    = &plog.ProtoMarshaler{}
+)
+
+// MutationDetector is a Consumer that records a fingerprint of every payload it receives before
+// forwarding it to the next consumer. Verify reports the payloads that were modified afterwards,
+// which catches components that keep and mutate the data they passed downstream, possibly concurrently
+// with the consumers that read it.
+//
+// The next consumer must not mutate the data, since its changes would be reported as well.
+type MutationDetector struct {
+	nonMutatingConsumer
+	next interface{}
+
+	mu       sync.Mutex
+	payloads []fingerprinted
+}
+
+type fingerprinted struct {
+	signal      string
+	fingerprint [sha256.Size]byte
+	compute     func() [sha256.Size]byte
+}
+
+// NewMutationDetector returns a MutationDetector forwarding the data to next, which must implement
+// consumer.Traces, consumer.Metrics or consumer.Logs for each signal sent to the detector, e.g. a
+// TracesSink. If next is nil, the data is dropped after its fingerprint is recorded.
+func NewMutationDetector(next interface{}) *MutationDetector {
+	return &MutationDetector{next: next}
+}
+
+func (md *MutationDetector) unexported() {}
+
+// ConsumeTraces records the fingerprint of td and forwards it to the next consumer.
+func (md *MutationDetector) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	md.record("traces", func() [sha256.Size]byte {
+		buf, _ := tracesMarshaler.MarshalTraces(td)
+		return sha256.Sum256(buf)
+	})
+	if md.next == nil {
+		return nil
+	}
+	next, ok := md.next.(consumer.Traces)
+	if !ok {
+		return errors.New("the next consumer does not accept traces")
+	}
+	return next.ConsumeTraces(ctx, td)
+}
+
+// ConsumeMetrics records the fingerprint of metrics and forwards them to the next consumer.
+func (md *MutationDetector) ConsumeMetrics(ctx context.Context, metrics pmetric.Metrics) error {
+	md.record("metrics", func() [sha256.Size]byte {
+		buf, _ := metricsMarshaler.MarshalMetrics(metrics)
+		return sha256.Sum256(buf)
+	})
+	if md.next == nil {
+		return nil
+	}
+	next, ok := md.next.(consumer.Metrics)
+	if !ok {
+		return errors.New("the next consumer does not accept metrics")
+	}
+	return next.ConsumeMetrics(ctx, metrics)
+}
+
+// ConsumeLogs records the fingerprint of ld and forwards it to the next consumer.
+func (md *MutationDetector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	md.record("logs", func() [sha256.Size]byte {
+		buf, _ := logsMarshaler.MarshalLogs(ld)
+		return sha256.Sum256(buf)
+	})
+	if md.next == nil {
+		return nil
+	}
+	next, ok := md.next.(consumer.Logs)
+	if !ok {
+		return errors.New("the next consumer does not accept logs")
+	}
+	return next.ConsumeLogs(ctx, ld)
+}
+
+func (md *MutationDetector) record(signal string, compute func() [sha256.Size]byte) {
+	fp := compute()
+	md.mu.Lock()
+	defer md.mu.Unlock()
+	md.payloads = append(md.payloads, fingerprinted{signal: signal, fingerprint: fp, compute: compute})
+}
+
+// Verify returns an error listing the payloads, numbered in the order they were received since the
+// last Reset, that were modified after being consumed.
+func (md *MutationDetector) Verify() error {
+	md.mu.Lock()
+	defer md.mu.Unlock()
+
+	var mutated []string
+	for i, p := range md.payloads {
+		if p.compute() != p.fingerprint {
+			mutated = append(mutated, fmt.Sprintf("%s payload #%d", p.signal, i))
+		}
+	}
+	if len(mutated) == 0 {
+		return nil
+	}
+	return fmt.Errorf("data was modified after being consumed: %s", strings.Join(mutated, ", "))
+}
+
+// Reset forgets all the payloads received so far.
+func (md *MutationDetector) Reset() {
+	md.mu.Lock()
+	defer md.mu.Unlock()
+	md.payloads = nil
+}
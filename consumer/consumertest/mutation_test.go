@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumertest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestMutationDetector(t *testing.T) {
+	sink := new(TracesSink)
+	md := NewMutationDetector(sink)
+	require.NotNil(t, md)
+	assert.NotPanics(t, md.unexported)
+	assert.False(t, md.Capabilities().MutatesData)
+
+	td1 := ptrace.NewTraces()
+	td1.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	td2 := ptrace.NewTraces()
+	td2.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+
+	require.NoError(t, md.ConsumeTraces(context.Background(), td1))
+	require.NoError(t, md.ConsumeTraces(context.Background(), td2))
+	assert.Len(t, sink.AllTraces(), 2)
+	assert.NoError(t, md.Verify())
+
+	// Mutate the second payload after it was consumed.
+	td2.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("renamed")
+	assert.EqualError(t, md.Verify(), "data was modified after being consumed: traces payload #1")
+
+	md.Reset()
+	assert.NoError(t, md.Verify())
+}
+
+func TestMutationDetectorAllSignals(t *testing.T) {
+	md := NewMutationDetector(nil)
+
+	metrics := pmetric.NewMetrics()
+	metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log")
+
+	require.NoError(t, md.ConsumeMetrics(context.Background(), metrics))
+	require.NoError(t, md.ConsumeLogs(context.Background(), logs))
+	assert.NoError(t, md.Verify())
+
+	metrics.ResourceMetrics().At(0).Resource().Attributes().PutStr("key", "value")
+	logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().RemoveIf(func(plog.LogRecord) bool { return true })
+	assert.EqualError(t, md.Verify(), "data was modified after being consumed: metrics payload #0, logs payload #1")
+}
+
+func TestMutationDetectorUnsupportedNext(t *testing.T) {
+	md := NewMutationDetector(new(TracesSink))
+	assert.EqualError(t, md.ConsumeMetrics(context.Background(), pmetric.NewMetrics()), "the next consumer does not accept metrics")
+	assert.EqualError(t, md.ConsumeLogs(context.Background(), plog.NewLogs()), "the next consumer does not accept logs")
+
+	md = NewMutationDetector(new(LogsSink))
+	assert.EqualError(t, md.ConsumeTraces(context.Background(), ptrace.NewTraces()), "the next consumer does not accept traces")
+}
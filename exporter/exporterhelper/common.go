@@ -46,6 +46,7 @@ type requestSender interface {
 type baseRequest struct {
 	ctx                        context.Context
 	processingFinishedCallback func()
+	enqueuedAt                 time.Time
 }
 
 func (req *baseRequest) Context() context.Context {
@@ -56,6 +57,14 @@ func (req *baseRequest) SetContext(ctx context.Context) {
 	req.ctx = ctx
 }
 
+func (req *baseRequest) EnqueuedAt() time.Time {
+	return req.enqueuedAt
+}
+
+func (req *baseRequest) SetEnqueuedAt(enqueuedAt time.Time) {
+	req.enqueuedAt = enqueuedAt
+}
+
 func (req *baseRequest) SetOnProcessingFinished(callback func()) {
 	req.processingFinishedCallback = callback
 }
@@ -70,7 +79,9 @@ func (req *baseRequest) OnProcessingFinished() {
 type baseSettings struct {
 	component.StartFunc
 	component.ShutdownFunc
-	consumerOptions []consumer.Option
+	consumerOptions   []consumer.Option
+	waitForConnection func(context.Context) error
+	recordBytes       bool
 	TimeoutSettings
 	QueueSettings
 	RetrySettings
@@ -146,17 +157,37 @@ func WithCapabilities(capabilities consumer.Capabilities) Option {
 	}
 }
 
+// WithConnectionWaiter sets the function that waits for the exporter to be connected to its destination,
+// see component.ConnectionWaiter. By default the exporter is considered connected once started.
+func WithConnectionWaiter(waitForConnection func(context.Context) error) Option {
+	return func(o *baseSettings) {
+		o.waitForConnection = waitForConnection
+	}
+}
+
+// WithRequestBytes records the size in bytes of the sent requests, in their OTLP protobuf encoding, in the
+// exporter/sent_bytes and exporter/send_failed_bytes metrics. It is meant for the exporters sending OTLP protobuf,
+// whose uncompressed requests have that size. The size is not recorded by default.
+func WithRequestBytes() Option {
+	return func(o *baseSettings) {
+		o.recordBytes = true
+	}
+}
+
+var _ component.ConnectionWaiter = (*baseExporter)(nil)
+
 // baseExporter contains common fields between different exporter types.
 type baseExporter struct {
 	component.StartFunc
 	component.ShutdownFunc
-	obsrep   *obsExporter
-	sender   requestSender
-	qrSender *queuedRetrySender
+	obsrep            *obsExporter
+	sender            requestSender
+	qrSender          *queuedRetrySender
+	waitForConnection func(context.Context) error
 }
 
 func newBaseExporter(set component.ExporterCreateSettings, bs *baseSettings, signal component.DataType, reqUnmarshaler internal.RequestUnmarshaler) (*baseExporter, error) {
-	be := &baseExporter{}
+	be := &baseExporter{waitForConnection: bs.waitForConnection}
 
 	var err error
 	be.obsrep, err = newObsExporter(obsreport.ExporterSettings{ExporterID: set.ID, ExporterCreateSettings: set}, globalInstruments)
@@ -184,6 +215,14 @@ func newBaseExporter(set component.ExporterCreateSettings, bs *baseSettings, sig
 	return be, nil
 }
 
+// WaitForConnection implements component.ConnectionWaiter.
+func (be *baseExporter) WaitForConnection(ctx context.Context) error {
+	if be.waitForConnection == nil {
+		return nil
+	}
+	return be.waitForConnection(ctx)
+}
+
 // wrapConsumerSender wraps the consumer sender (the sender that uses retries and timeout) with the given wrapper.
 // This can be used to wrap with observability (create spans, record metrics) the consumer sender.
 func (be *baseExporter) wrapConsumerSender(f func(consumer requestSender) requestSender) {
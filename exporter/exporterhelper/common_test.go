@@ -66,6 +66,22 @@ func TestBaseExporterWithOptions(t *testing.T) {
 	require.Equal(t, want, be.Shutdown(context.Background()))
 }
 
+func TestBaseExporterWaitForConnection(t *testing.T) {
+	be, err := newBaseExporter(defaultSettings, fromOptions(), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	require.NoError(t, be.WaitForConnection(context.Background()))
+
+	want := errors.New("not connected")
+	be, err = newBaseExporter(
+		defaultSettings,
+		fromOptions(WithConnectionWaiter(func(context.Context) error { return want })),
+		"",
+		nopRequestUnmarshaler(),
+	)
+	require.NoError(t, err)
+	require.Equal(t, want, be.WaitForConnection(context.Background()))
+}
+
 func checkStatus(t *testing.T, sd sdktrace.ReadOnlySpan, err error) {
 	if err != nil {
 		require.Equal(t, codes.Error, sd.Status().Code, "SpanData %v", sd)
@@ -0,0 +1,455 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"go.uber.org/atomic"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+const (
+	// recordHeaderSize is the size of the checksum and the length preceding each record.
+	recordHeaderSize = 8
+	// minCompactionSize is the size below which the file is never compacted.
+	minCompactionSize = 1 << 20
+
+	opSet    byte = 1
+	opDelete byte = 2
+)
+
+var (
+	errClientClosed = errors.New("file storage client is closed")
+
+	unsafeFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+)
+
+var _ storage.Client = (*FileStorageClient)(nil)
+
+// storageFile is the file of a FileStorageClient, an *os.File opened for appending.
+type storageFile interface {
+	io.ReaderAt
+	io.Writer
+	Truncate(size int64) error
+	Stat() (os.FileInfo, error)
+	Sync() error
+	Close() error
+}
+
+// FileStorageClient is a storage.Client keeping its data in a single append-only file, so that the persistent
+// queue can be used without a storage extension. Every update is appended to the file as a record, and
+// the file is compacted, by rewriting only the live records, once most of its content is obsolete.
+// Only the location of the live values is kept in memory, the values are read from the file when requested.
+//
+// The records are not synced to the disk when they are written, so the data survives the restarts of
+// the collector but may be lost if the host crashes. Truncated or corrupted records at the end of the
+// file, e.g. after a crash, are discarded when the file is opened.
+type FileStorageClient struct {
+	logger *zap.Logger
+	path   string
+
+	mu       sync.Mutex
+	file     storageFile
+	index    map[string]valueLocation
+	fileSize int64
+	liveSize int64
+
+	size               *atomic.Int64
+	compactions        *atomic.Int64
+	compactionFailures *atomic.Int64
+}
+
+// valueLocation is the location of a live value in the file.
+type valueLocation struct {
+	// offset is the offset of the value in the file.
+	offset int64
+	// length is the length of the value.
+	length int64
+	// recordSize is the size of the whole record holding the value.
+	recordSize int64
+}
+
+// NewFileStorageClient opens, or creates, the file of the queue with the given name in the given directory.
+func NewFileStorageClient(directory string, name string, logger *zap.Logger) (*FileStorageClient, error) {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the storage directory: %w", err)
+	}
+	fsc := &FileStorageClient{
+		logger:             logger,
+		path:               filepath.Join(directory, unsafeFileNameChars.ReplaceAllString(name, "_")),
+		index:              map[string]valueLocation{},
+		size:               atomic.NewInt64(0),
+		compactions:        atomic.NewInt64(0),
+		compactionFailures: atomic.NewInt64(0),
+	}
+	file, err := os.OpenFile(fsc.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the storage file: %w", err)
+	}
+	fsc.file = file
+	if err = fsc.load(); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	fsc.maybeCompact()
+	return fsc, nil
+}
+
+// load replays the records of the file, and truncates it after the last valid record.
+func (fsc *FileStorageClient) load() error {
+	reader := bufio.NewReader(io.NewSectionReader(fsc.file, 0, 1<<62))
+	var body []byte
+	offset := int64(0)
+	for {
+		var header [recordHeaderSize]byte
+		if _, err := io.ReadFull(reader, header[:]); err != nil {
+			if err == io.EOF {
+				break
+			}
+			if err != io.ErrUnexpectedEOF {
+				return fmt.Errorf("failed to read the storage file: %w", err)
+			}
+			return fsc.discardFrom(offset)
+		}
+		checksum := binary.LittleEndian.Uint32(header[:])
+		bodyLen := int(binary.LittleEndian.Uint32(header[4:]))
+		if bodyLen < 1 {
+			return fsc.discardFrom(offset)
+		}
+		if cap(body) < bodyLen {
+			body = make([]byte, bodyLen)
+		}
+		body = body[:bodyLen]
+		if _, err := io.ReadFull(reader, body); err != nil {
+			if err != io.EOF && err != io.ErrUnexpectedEOF {
+				return fmt.Errorf("failed to read the storage file: %w", err)
+			}
+			return fsc.discardFrom(offset)
+		}
+		op, key, valueOffset, ok := decodeRecordBody(checksum, body)
+		if !ok {
+			return fsc.discardFrom(offset)
+		}
+		recordSize := int64(recordHeaderSize + bodyLen)
+		fsc.apply(op, key, valueLocation{
+			offset:     offset + recordHeaderSize + int64(valueOffset),
+			length:     int64(bodyLen - valueOffset),
+			recordSize: recordSize,
+		})
+		offset += recordSize
+	}
+	fsc.fileSize = offset
+	fsc.size.Store(fsc.fileSize)
+	return nil
+}
+
+// discardFrom truncates the file at the given offset, where its corrupted end starts.
+func (fsc *FileStorageClient) discardFrom(offset int64) error {
+	info, err := fsc.file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to read the storage file: %w", err)
+	}
+	fsc.logger.Warn("Discarding the corrupted end of the storage file",
+		zap.String("path", fsc.path), zap.Int64("discarded_bytes", info.Size()-offset))
+	if err = fsc.file.Truncate(offset); err != nil {
+		return fmt.Errorf("failed to truncate the storage file: %w", err)
+	}
+	fsc.fileSize = offset
+	fsc.size.Store(fsc.fileSize)
+	return nil
+}
+
+// Get implements storage.Client.
+func (fsc *FileStorageClient) Get(_ context.Context, key string) ([]byte, error) {
+	fsc.mu.Lock()
+	defer fsc.mu.Unlock()
+	if fsc.file == nil {
+		return nil, errClientClosed
+	}
+	return fsc.read(key)
+}
+
+// Set implements storage.Client.
+func (fsc *FileStorageClient) Set(ctx context.Context, key string, value []byte) error {
+	return fsc.Batch(ctx, storage.SetOperation(key, value))
+}
+
+// Delete implements storage.Client.
+func (fsc *FileStorageClient) Delete(ctx context.Context, key string) error {
+	return fsc.Batch(ctx, storage.DeleteOperation(key))
+}
+
+// Batch implements storage.Client. The updates of the batch are written to the file at once. A failure to
+// compact the file afterwards does not fail the batch, it is logged and reported by CompactionFailures.
+func (fsc *FileStorageClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	fsc.mu.Lock()
+	defer fsc.mu.Unlock()
+	if fsc.file == nil {
+		return errClientClosed
+	}
+
+	var buf []byte
+	// The updates are only applied to the index once written, but are visible to the Get operations of the batch.
+	updates := map[string]*valueLocation{}
+	var order []string
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			loc, ok := updates[op.Key]
+			switch {
+			case !ok:
+				val, err := fsc.read(op.Key)
+				if err != nil {
+					return err
+				}
+				op.Value = val
+			case loc == nil:
+				op.Value = nil
+			default:
+				start := loc.offset - fsc.fileSize
+				op.Value = append([]byte(nil), buf[start:start+loc.length]...)
+			}
+		case storage.Set:
+			start := len(buf)
+			buf = appendRecord(buf, opSet, op.Key, op.Value)
+			recordSize := int64(len(buf) - start)
+			if _, ok := updates[op.Key]; !ok {
+				order = append(order, op.Key)
+			}
+			updates[op.Key] = &valueLocation{
+				offset:     fsc.fileSize + int64(len(buf)-len(op.Value)),
+				length:     int64(len(op.Value)),
+				recordSize: recordSize,
+			}
+		case storage.Delete:
+			buf = appendRecord(buf, opDelete, op.Key, nil)
+			if _, ok := updates[op.Key]; !ok {
+				order = append(order, op.Key)
+			}
+			updates[op.Key] = nil
+		}
+	}
+	if len(buf) == 0 {
+		return nil
+	}
+
+	if _, err := fsc.file.Write(buf); err != nil {
+		return fsc.discardPartialWrite(fmt.Errorf("failed to write to the storage file: %w", err))
+	}
+	for _, key := range order {
+		if loc := updates[key]; loc != nil {
+			fsc.apply(opSet, key, *loc)
+		} else {
+			fsc.apply(opDelete, key, valueLocation{})
+		}
+	}
+	fsc.fileSize += int64(len(buf))
+	fsc.size.Store(fsc.fileSize)
+	fsc.maybeCompact()
+	return nil
+}
+
+// discardPartialWrite truncates the file back to the end of its last record after a failed write, so that the
+// next records are written where the index expects them, and that the records written after a torn one are
+// not discarded when the file is opened again. If the file cannot be truncated, the client is closed.
+func (fsc *FileStorageClient) discardPartialWrite(writeErr error) error {
+	truncErr := fsc.file.Truncate(fsc.fileSize)
+	if truncErr == nil {
+		return writeErr
+	}
+	fsc.logger.Error("Failed to discard a partial write, closing the storage file",
+		zap.String("path", fsc.path), zap.Error(truncErr))
+	_ = fsc.file.Close()
+	fsc.file = nil
+	return multierr.Append(writeErr, fmt.Errorf("failed to truncate the storage file: %w", truncErr))
+}
+
+// Close implements storage.Client.
+func (fsc *FileStorageClient) Close(context.Context) error {
+	fsc.mu.Lock()
+	defer fsc.mu.Unlock()
+	if fsc.file == nil {
+		return nil
+	}
+	err := fsc.file.Sync()
+	if closeErr := fsc.file.Close(); err == nil {
+		err = closeErr
+	}
+	fsc.file = nil
+	return err
+}
+
+// Size returns the current size of the file, in bytes.
+func (fsc *FileStorageClient) Size() int64 {
+	return fsc.size.Load()
+}
+
+// Compactions returns the number of times the file was compacted since it was opened.
+func (fsc *FileStorageClient) Compactions() int64 {
+	return fsc.compactions.Load()
+}
+
+// CompactionFailures returns the number of times the compaction of the file failed since it was opened.
+func (fsc *FileStorageClient) CompactionFailures() int64 {
+	return fsc.compactionFailures.Load()
+}
+
+// read reads the live value of the given key from the file.
+func (fsc *FileStorageClient) read(key string) ([]byte, error) {
+	loc, ok := fsc.index[key]
+	if !ok {
+		return nil, nil
+	}
+	value := make([]byte, loc.length)
+	if _, err := fsc.file.ReadAt(value, loc.offset); err != nil {
+		return nil, fmt.Errorf("failed to read from the storage file: %w", err)
+	}
+	return value, nil
+}
+
+func (fsc *FileStorageClient) apply(op byte, key string, loc valueLocation) {
+	if old, ok := fsc.index[key]; ok {
+		fsc.liveSize -= old.recordSize
+		delete(fsc.index, key)
+	}
+	if op == opSet {
+		fsc.index[key] = loc
+		fsc.liveSize += loc.recordSize
+	}
+}
+
+// maybeCompact compacts the file once the live records take less than half of it. A failure is logged, and
+// the file is left as is.
+func (fsc *FileStorageClient) maybeCompact() {
+	if fsc.fileSize < minCompactionSize || fsc.fileSize < 2*fsc.liveSize {
+		return
+	}
+	if err := fsc.compact(); err != nil {
+		fsc.compactionFailures.Inc()
+		fsc.logger.Error("Failed to compact the storage file", zap.String("path", fsc.path), zap.Error(err))
+	}
+}
+
+// compact rewrites the file with only the live records, copied one at a time from the current file.
+func (fsc *FileStorageClient) compact() error {
+	tmpPath := fsc.path + ".compact"
+	index, size, err := fsc.writeLiveRecords(tmpPath)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err = os.Rename(tmpPath, fsc.path); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	file, err := os.OpenFile(fsc.path, os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		// The current file descriptor still refers to the previous content, which remains readable.
+		return fmt.Errorf("failed to reopen the storage file: %w", err)
+	}
+	_ = fsc.file.Close()
+	fsc.file = file
+	fsc.index = index
+	fsc.fileSize = size
+	fsc.liveSize = size
+	fsc.size.Store(fsc.fileSize)
+	fsc.compactions.Inc()
+	return nil
+}
+
+// writeLiveRecords writes the live records to a new, synced, file at the given path, and returns their index.
+func (fsc *FileStorageClient) writeLiveRecords(path string) (map[string]valueLocation, int64, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	index := make(map[string]valueLocation, len(fsc.index))
+	var value, record []byte
+	offset := int64(0)
+	for key, loc := range fsc.index {
+		if int64(cap(value)) < loc.length {
+			value = make([]byte, loc.length)
+		}
+		value = value[:loc.length]
+		if _, err = fsc.file.ReadAt(value, loc.offset); err != nil {
+			return nil, 0, err
+		}
+		record = appendRecord(record[:0], opSet, key, value)
+		if _, err = writer.Write(record); err != nil {
+			return nil, 0, err
+		}
+		index[key] = valueLocation{
+			offset:     offset + int64(len(record)-len(value)),
+			length:     loc.length,
+			recordSize: int64(len(record)),
+		}
+		offset += int64(len(record))
+	}
+	if err = writer.Flush(); err != nil {
+		return nil, 0, err
+	}
+	if err = file.Sync(); err != nil {
+		return nil, 0, err
+	}
+	return index, offset, file.Close()
+}
+
+// appendRecord appends the record of the given operation to buf. A record is the CRC-32 checksum and the length
+// of its body, followed by the body: the operation, the length of the key, the key and the value.
+func appendRecord(buf []byte, op byte, key string, value []byte) []byte {
+	start := len(buf)
+	buf = append(buf, make([]byte, recordHeaderSize)...)
+	buf = append(buf, op)
+	var lenBuf [binary.MaxVarintLen64]byte
+	buf = append(buf, lenBuf[:binary.PutUvarint(lenBuf[:], uint64(len(key)))]...)
+	buf = append(buf, key...)
+	buf = append(buf, value...)
+	body := buf[start+recordHeaderSize:]
+	binary.LittleEndian.PutUint32(buf[start:], crc32.ChecksumIEEE(body))
+	binary.LittleEndian.PutUint32(buf[start+4:], uint32(len(body)))
+	return buf
+}
+
+// decodeRecordBody decodes the body of a record, and returns the offset of its value in the body. It returns
+// false if the body is corrupted.
+func decodeRecordBody(checksum uint32, body []byte) (op byte, key string, valueOffset int, ok bool) {
+	if len(body) < 1 || crc32.ChecksumIEEE(body) != checksum {
+		return 0, "", 0, false
+	}
+	op = body[0]
+	keyLen, k := binary.Uvarint(body[1:])
+	if k <= 0 || uint64(len(body)-1-k) < keyLen || (op != opSet && op != opDelete) {
+		return 0, "", 0, false
+	}
+	valueOffset = 1 + k + int(keyLen)
+	return op, string(body[1+k : valueOffset]), valueOffset, true
+}
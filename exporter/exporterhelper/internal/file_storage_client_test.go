@@ -0,0 +1,264 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func TestFileStorageClient(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewFileStorageClient(t.TempDir(), "otlp/1-traces", zap.NewNop())
+	require.NoError(t, err)
+
+	val, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	val, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), val)
+
+	getOp := storage.GetOperation("key")
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("other", []byte("other")), storage.DeleteOperation("key"), getOp))
+	assert.Nil(t, getOp.Value)
+	val, err = client.Get(ctx, "other")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("other"), val)
+
+	require.NoError(t, client.Delete(ctx, "other"))
+	val, err = client.Get(ctx, "other")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+
+	require.NoError(t, client.Close(ctx))
+	_, err = client.Get(ctx, "key")
+	assert.ErrorIs(t, err, errClientClosed)
+	assert.ErrorIs(t, client.Set(ctx, "key", []byte("value")), errClientClosed)
+	assert.NoError(t, client.Close(ctx))
+}
+
+func TestFileStorageClientReopen(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	client, err := NewFileStorageClient(dir, "queue", zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "deleted", []byte("value")))
+	require.NoError(t, client.Set(ctx, "overwritten", []byte("old")))
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("overwritten", []byte("new")), storage.DeleteOperation("deleted")))
+	require.NoError(t, client.Set(ctx, "kept", []byte("value")))
+	require.NoError(t, client.Close(ctx))
+
+	client, err = NewFileStorageClient(dir, "queue", zap.NewNop())
+	require.NoError(t, err)
+	defer func() { require.NoError(t, client.Close(ctx)) }()
+
+	for key, expected := range map[string][]byte{"deleted": nil, "overwritten": []byte("new"), "kept": []byte("value")} {
+		val, err := client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, val, key)
+	}
+}
+
+func TestFileStorageClientDiscardsCorruptedEnd(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	client, err := NewFileStorageClient(dir, "queue", zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	size := client.Size()
+	require.NoError(t, client.Close(ctx))
+
+	// Simulate a record partially written before a crash.
+	file, err := os.OpenFile(filepath.Join(dir, "queue"), os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = file.Write(appendRecord(nil, opSet, "other", []byte("value"))[:10])
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	client, err = NewFileStorageClient(dir, "queue", zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, size, client.Size())
+	val, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), val)
+
+	// The new records are readable after the discarded ones.
+	require.NoError(t, client.Set(ctx, "other", []byte("value")))
+	require.NoError(t, client.Close(ctx))
+	client, err = NewFileStorageClient(dir, "queue", zap.NewNop())
+	require.NoError(t, err)
+	val, err = client.Get(ctx, "other")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), val)
+	require.NoError(t, client.Close(ctx))
+}
+
+// shortWriteFile writes only half of the next buffer written to the file, and fails.
+type shortWriteFile struct {
+	storageFile
+	failWrite    bool
+	truncateErr  error
+	truncateSize int64
+}
+
+func (f *shortWriteFile) Write(buf []byte) (int, error) {
+	if !f.failWrite {
+		return f.storageFile.Write(buf)
+	}
+	f.failWrite = false
+	n, err := f.storageFile.Write(buf[:len(buf)/2])
+	if err != nil {
+		return n, err
+	}
+	return n, errors.New("no space left on device")
+}
+
+func (f *shortWriteFile) Truncate(size int64) error {
+	f.truncateSize = size
+	if f.truncateErr != nil {
+		return f.truncateErr
+	}
+	return f.storageFile.Truncate(size)
+}
+
+func TestFileStorageClientPartialWrite(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	client, err := NewFileStorageClient(dir, "queue", zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	size := client.Size()
+
+	file := &shortWriteFile{storageFile: client.file, failWrite: true}
+	client.file = file
+	require.Error(t, client.Set(ctx, "lost", []byte("lost value")))
+	assert.Equal(t, size, file.truncateSize)
+	assert.Equal(t, size, client.Size())
+
+	// The torn record was discarded, the records written afterwards are where the index expects them.
+	require.NoError(t, client.Set(ctx, "other", []byte("other value")))
+	for key, expected := range map[string][]byte{"key": []byte("value"), "lost": nil, "other": []byte("other value")} {
+		val, err := client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, val, key)
+	}
+	require.NoError(t, client.Close(ctx))
+
+	// No record is discarded when the file is opened again.
+	client, err = NewFileStorageClient(dir, "queue", zap.NewNop())
+	require.NoError(t, err)
+	for key, expected := range map[string][]byte{"key": []byte("value"), "lost": nil, "other": []byte("other value")} {
+		val, err := client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, val, key)
+	}
+
+	// The client is closed if the torn record cannot be discarded.
+	client.file = &shortWriteFile{storageFile: client.file, failWrite: true, truncateErr: errors.New("read-only file system")}
+	err = client.Set(ctx, "lost", []byte("lost value"))
+	assert.ErrorContains(t, err, "no space left on device")
+	assert.ErrorContains(t, err, "read-only file system")
+	assert.ErrorIs(t, client.Set(ctx, "other", []byte("value")), errClientClosed)
+	assert.NoError(t, client.Close(ctx))
+}
+
+func TestFileStorageClientCompaction(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	client, err := NewFileStorageClient(dir, "queue", zap.NewNop())
+	require.NoError(t, err)
+
+	value := make([]byte, 64*1024)
+	for i := 0; i < 2*minCompactionSize/len(value); i++ {
+		require.NoError(t, client.Set(ctx, "key", value))
+	}
+	assert.Greater(t, client.Compactions(), int64(0))
+	assert.Less(t, client.Size(), int64(minCompactionSize))
+	require.NoError(t, client.Close(ctx))
+
+	info, err := os.Stat(filepath.Join(dir, "queue"))
+	require.NoError(t, err)
+	assert.Equal(t, client.Size(), info.Size())
+
+	client, err = NewFileStorageClient(dir, "queue", zap.NewNop())
+	require.NoError(t, err)
+	val, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, value, val)
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestFileStorageClientCompactionFailure(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	client, err := NewFileStorageClient(dir, "queue", zap.NewNop())
+	require.NoError(t, err)
+
+	// The compacted file cannot be created in place of a non-empty directory.
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "queue.compact", "dir"), 0700))
+	value := make([]byte, 64*1024)
+	for i := 0; i < 2*minCompactionSize/len(value); i++ {
+		require.NoError(t, client.Set(ctx, "key", value))
+	}
+	assert.Equal(t, int64(0), client.Compactions())
+	assert.Greater(t, client.CompactionFailures(), int64(0))
+
+	getOp := storage.GetOperation("key")
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("key", []byte("value")), getOp))
+	assert.Equal(t, []byte("value"), getOp.Value)
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestFileStorageClientPersistentQueue(t *testing.T) {
+	dir := t.TempDir()
+	client, err := NewFileStorageClient(dir, "exporter-traces", zap.NewNop())
+	require.NoError(t, err)
+	ps := createTestPersistentStorage(client)
+
+	req := newFakeTracesRequest(newTraces(5, 10))
+	require.NoError(t, ps.put(req))
+	require.NoError(t, ps.put(req))
+	ps.stop()
+
+	client, err = NewFileStorageClient(dir, "exporter-traces", zap.NewNop())
+	require.NoError(t, err)
+	ps = createTestPersistentStorage(client)
+	assert.Equal(t, uint64(2), ps.size())
+
+	got := <-ps.get()
+	assert.Equal(t, req.td, got.(*fakeTracesRequest).td)
+	ps.stop()
+}
+
+func TestFileStorageClientFileName(t *testing.T) {
+	dir := t.TempDir()
+	client, err := NewFileStorageClient(dir, "otlp/a b-traces", zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, client.Close(context.Background()))
+	_, err = os.Stat(filepath.Join(dir, "otlp_a_b-traces"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"sync"
+)
+
+type partition struct {
+	key   string
+	items []Request
+}
+
+// PartitionedMemoryQueue is a bounded in-memory queue whose items are split into partitions by a key.
+// Every partition has its own capacity, and consumers take items from the partitions in a round-robin
+// fashion, so that a partition receiving a lot of data cannot starve the others.
+type PartitionedMemoryQueue struct {
+	mu         sync.Mutex
+	cond       *sync.Cond
+	stopWG     sync.WaitGroup
+	stopped    bool
+	partitions map[string]*partition
+	// ring holds the non-empty partitions in the order they are consumed.
+	ring              []*partition
+	next              int
+	size              int
+	capacity          int
+	partitionCapacity int
+	keyFunc           func(Request) string
+	onPartitionFull   func(key string)
+}
+
+// NewPartitionedMemoryQueue constructs a new queue holding at most capacity items overall and partitionCapacity
+// items per partition. The partition of an item is given by keyFunc, and onPartitionFull, if not nil, is called
+// with the key of the partition when an item is rejected because its partition is full.
+func NewPartitionedMemoryQueue(capacity, partitionCapacity int, keyFunc func(Request) string, onPartitionFull func(key string)) *PartitionedMemoryQueue {
+	q := &PartitionedMemoryQueue{
+		partitions:        map[string]*partition{},
+		capacity:          capacity,
+		partitionCapacity: partitionCapacity,
+		keyFunc:           keyFunc,
+		onPartitionFull:   onPartitionFull,
+	}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// StartConsumers starts a given number of goroutines consuming items from the queue
+// and passing them into the consumer callback.
+func (q *PartitionedMemoryQueue) StartConsumers(numWorkers int, callback func(item Request)) {
+	for i := 0; i < numWorkers; i++ {
+		q.stopWG.Add(1)
+		go func() {
+			defer q.stopWG.Done()
+			for {
+				item, ok := q.take()
+				if !ok {
+					return
+				}
+				callback(item)
+			}
+		}()
+	}
+}
+
+// take blocks until an item is available, it returns false once the queue is stopped and drained.
+func (q *PartitionedMemoryQueue) take() (Request, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.ring) == 0 {
+		if q.stopped {
+			return nil, false
+		}
+		q.cond.Wait()
+	}
+
+	if q.next >= len(q.ring) {
+		q.next = 0
+	}
+	p := q.ring[q.next]
+	item := p.items[0]
+	p.items[0] = nil
+	p.items = p.items[1:]
+	q.size--
+	if len(p.items) == 0 {
+		// Forget the empty partition, the next one takes its place in the ring.
+		delete(q.partitions, p.key)
+		q.ring = append(q.ring[:q.next], q.ring[q.next+1:]...)
+	} else {
+		q.next++
+	}
+	return item, true
+}
+
+// Produce is used by the producer to submit new item to the queue. Returns false if the item wasn't added
+// to the queue because the queue or the partition of the item is full.
+func (q *PartitionedMemoryQueue) Produce(item Request) bool {
+	key := q.keyFunc(item)
+
+	q.mu.Lock()
+	if q.stopped || q.size >= q.capacity {
+		q.mu.Unlock()
+		return false
+	}
+	p, ok := q.partitions[key]
+	if ok && len(p.items) >= q.partitionCapacity || q.partitionCapacity <= 0 {
+		q.mu.Unlock()
+		if q.onPartitionFull != nil {
+			q.onPartitionFull(key)
+		}
+		return false
+	}
+	if !ok {
+		p = &partition{key: key}
+		q.partitions[key] = p
+		q.ring = append(q.ring, p)
+	}
+	p.items = append(p.items, item)
+	q.size++
+	q.mu.Unlock()
+
+	q.cond.Signal()
+	return true
+}
+
+// Stop stops all consumers once the remaining items are consumed. It blocks until all consumers have stopped.
+func (q *PartitionedMemoryQueue) Stop() {
+	q.mu.Lock()
+	q.stopped = true // disable producer
+	q.mu.Unlock()
+	q.cond.Broadcast()
+	q.stopWG.Wait()
+}
+
+// Size returns the current number of items in the queue.
+func (q *PartitionedMemoryQueue) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// Partitions returns the current number of non-empty partitions.
+func (q *PartitionedMemoryQueue) Partitions() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.ring)
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func partitionByFirstLetter(item Request) string {
+	return item.(stringRequest).str[:1]
+}
+
+func TestPartitionedMemoryQueueLimits(t *testing.T) {
+	var full []string
+	q := NewPartitionedMemoryQueue(4, 2, partitionByFirstLetter, func(key string) {
+		full = append(full, key)
+	})
+
+	assert.True(t, q.Produce(newStringRequest("a1")))
+	assert.True(t, q.Produce(newStringRequest("a2")))
+	assert.False(t, q.Produce(newStringRequest("a3")))
+	assert.True(t, q.Produce(newStringRequest("b1")))
+	assert.True(t, q.Produce(newStringRequest("c1")))
+	// The overall capacity is reached even though the partition has room.
+	assert.False(t, q.Produce(newStringRequest("d1")))
+
+	assert.Equal(t, []string{"a"}, full)
+	assert.Equal(t, 4, q.Size())
+	assert.Equal(t, 3, q.Partitions())
+}
+
+func TestPartitionedMemoryQueueRoundRobin(t *testing.T) {
+	q := NewPartitionedMemoryQueue(10, 10, partitionByFirstLetter, nil)
+	for _, str := range []string{"a1", "a2", "a3", "b1", "c1", "c2"} {
+		assert.True(t, q.Produce(newStringRequest(str)))
+	}
+
+	var mu sync.Mutex
+	var consumed []string
+	q.StartConsumers(1, func(item Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		consumed = append(consumed, item.(stringRequest).str)
+	})
+	q.Stop()
+
+	assert.Equal(t, []string{"a1", "b1", "c1", "a2", "c2", "a3"}, consumed)
+	assert.Equal(t, 0, q.Size())
+	assert.Equal(t, 0, q.Partitions())
+	assert.False(t, q.Produce(newStringRequest("a4")))
+}
+
+func TestPartitionedMemoryQueueZeroPartitionSize(t *testing.T) {
+	q := NewPartitionedMemoryQueue(10, 0, partitionByFirstLetter, nil)
+	assert.False(t, q.Produce(newStringRequest("a1")))
+	assert.Equal(t, 0, q.Partitions())
+}
@@ -80,6 +80,7 @@ const (
 	readIndexKey                = "ri"
 	writeIndexKey               = "wi"
 	currentlyDispatchedItemsKey = "di"
+	enqueuedAtKeySuffix         = "_ea"
 )
 
 var (
@@ -128,11 +129,15 @@ func initPersistentContiguousStorage(ctx context.Context, pcs *persistentContigu
 	batch, err := newBatch(pcs).get(readIndexKey, writeIndexKey).execute(ctx)
 
 	if err == nil {
-		readIndex, err = batch.getItemIndexResult(readIndexKey)
+		writeIndex, err = batch.getItemIndexResult(writeIndexKey)
 	}
 
 	if err == nil {
-		writeIndex, err = batch.getItemIndexResult(writeIndexKey)
+		readIndex, err = batch.getItemIndexResult(readIndexKey)
+		// The read index is only stored once an item is dispatched, the items written before are still in the queue.
+		if errors.Is(err, errValueNotSet) {
+			readIndex, err = 0, nil
+		}
 	}
 
 	if err != nil {
@@ -230,7 +235,11 @@ func (pcs *persistentContiguousStorage) put(req Request) error {
 	pcs.itemsCount.Store(uint64(pcs.writeIndex - pcs.readIndex))
 
 	ctx := context.Background()
-	_, err := newBatch(pcs).setItemIndex(writeIndexKey, pcs.writeIndex).setRequest(itemKey, req).execute(ctx)
+	batch := newBatch(pcs).setItemIndex(writeIndexKey, pcs.writeIndex).setRequest(itemKey, req)
+	if enqueuedAt := req.EnqueuedAt(); !enqueuedAt.IsZero() {
+		batch.setTime(enqueuedAtKey(itemKey), enqueuedAt)
+	}
+	_, err := batch.execute(ctx)
 
 	// Inform the loop that there's some data to process
 	pcs.putChan <- struct{}{}
@@ -253,9 +262,10 @@ func (pcs *persistentContiguousStorage) getNextItem(ctx context.Context) (Reques
 		pcs.itemDispatchingStart(ctx, index)
 
 		var req Request
-		batch, err := newBatch(pcs).get(pcs.itemKey(index)).execute(ctx)
+		itemKey := pcs.itemKey(index)
+		batch, err := newBatch(pcs).get(itemKey, enqueuedAtKey(itemKey)).execute(ctx)
 		if err == nil {
-			req, err = batch.getRequestResult(pcs.itemKey(index))
+			req, err = batch.getRequestResult(itemKey)
 		}
 
 		if err != nil || req == nil {
@@ -265,6 +275,8 @@ func (pcs *persistentContiguousStorage) getNextItem(ctx context.Context) (Reques
 			return nil, false
 		}
 
+		restoreEnqueuedAt(batch, itemKey, req)
+
 		// If all went well so far, cleanup will be handled by callback
 		req.SetOnProcessingFinished(func() {
 			pcs.mu.Lock()
@@ -310,8 +322,8 @@ func (pcs *persistentContiguousStorage) retrieveNotDispatchedReqs(ctx context.Co
 	cleanupBatch := newBatch(pcs)
 	for i, it := range dispatchedItems {
 		keys[i] = pcs.itemKey(it)
-		retrieveBatch.get(keys[i])
-		cleanupBatch.delete(keys[i])
+		retrieveBatch.get(keys[i], enqueuedAtKey(keys[i]))
+		cleanupBatch.delete(keys[i], enqueuedAtKey(keys[i]))
 	}
 
 	_, retrieveErr := retrieveBatch.execute(ctx)
@@ -340,6 +352,7 @@ func (pcs *persistentContiguousStorage) retrieveNotDispatchedReqs(ctx context.Co
 				pcs.logger.Debug("Item value could not be retrieved",
 					zap.String(zapQueueNameKey, pcs.queueName), zap.String(zapKey, key), zap.Error(err))
 			} else {
+				restoreEnqueuedAt(retrieveBatch, key, req)
 				reqs[i] = req
 			}
 		}
@@ -372,7 +385,7 @@ func (pcs *persistentContiguousStorage) itemDispatchingFinish(ctx context.Contex
 
 	_, err := newBatch(pcs).
 		setItemIndexArray(currentlyDispatchedItemsKey, pcs.currentlyDispatchedItems).
-		delete(pcs.itemKey(index)).
+		delete(pcs.itemKey(index), enqueuedAtKey(pcs.itemKey(index))).
 		execute(ctx)
 	if err != nil {
 		pcs.logger.Debug("Failed updating currently dispatched items",
@@ -394,3 +407,16 @@ func (pcs *persistentContiguousStorage) updateReadIndex(ctx context.Context) {
 func (pcs *persistentContiguousStorage) itemKey(index itemIndex) string {
 	return strconv.FormatUint(uint64(index), 10)
 }
+
+// enqueuedAtKey returns the key storing the time the item stored at itemKey was first enqueued.
+func enqueuedAtKey(itemKey string) string {
+	return itemKey + enqueuedAtKeySuffix
+}
+
+// restoreEnqueuedAt sets the time the request was first enqueued, if it was stored.
+// Items stored by previous versions do not have it.
+func restoreEnqueuedAt(batch *batchStruct, itemKey string, req Request) {
+	if enqueuedAt, err := batch.getTimeResult(enqueuedAtKey(itemKey)); err == nil {
+		req.SetEnqueuedAt(enqueuedAt)
+	}
+}
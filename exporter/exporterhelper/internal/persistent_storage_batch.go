@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/binary"
 	"errors"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -116,6 +117,20 @@ func (bof *batchStruct) getRequestResult(key string) (Request, error) {
 	return reqIf.(Request), nil
 }
 
+// getTimeResult returns the result of a Get operation as a time
+// If the value cannot be retrieved, it returns an error
+func (bof *batchStruct) getTimeResult(key string) (time.Time, error) {
+	timeIf, err := bof.getResult(key, bytesToTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if timeIf == nil {
+		return time.Time{}, errValueNotSet
+	}
+
+	return timeIf.(time.Time), nil
+}
+
 // getItemIndexResult returns the result of a Get operation as an itemIndex
 // If the value cannot be retrieved, it returns an error
 func (bof *batchStruct) getItemIndexResult(key string) (itemIndex, error) {
@@ -161,6 +176,11 @@ func (bof *batchStruct) setItemIndexArray(key string, value []itemIndex) *batchS
 	return bof.set(key, value, itemIndexArrayToBytes)
 }
 
+// setTime adds Set operation over a given time to the batch
+func (bof *batchStruct) setTime(key string, value time.Time) *batchStruct {
+	return bof.set(key, value, timeToBytes)
+}
+
 func itemIndexToBytes(val interface{}) ([]byte, error) {
 	var buf bytes.Buffer
 	err := binary.Write(&buf, binary.LittleEndian, val)
@@ -217,6 +237,19 @@ func bytesToItemIndexArray(b []byte) (interface{}, error) {
 	return val, err
 }
 
+func timeToBytes(val interface{}) ([]byte, error) {
+	return itemIndexToBytes(val.(time.Time).UnixNano())
+}
+
+func bytesToTime(b []byte) (interface{}, error) {
+	var val int64
+	err := binary.Read(bytes.NewReader(b), binary.LittleEndian, &val)
+	if err != nil {
+		return nil, err
+	}
+	return time.Unix(0, val), nil
+}
+
 func requestToBytes(req interface{}) ([]byte, error) {
 	return req.(Request).Marshal()
 }
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
@@ -56,6 +57,7 @@ func createTestPersistentStorage(client storage.Client) *persistentContiguousSto
 type fakeTracesRequest struct {
 	td                         ptrace.Traces
 	processingFinishedCallback func()
+	enqueuedAt                 time.Time
 	Request
 }
 
@@ -80,6 +82,14 @@ func (fd *fakeTracesRequest) SetOnProcessingFinished(callback func()) {
 	fd.processingFinishedCallback = callback
 }
 
+func (fd *fakeTracesRequest) EnqueuedAt() time.Time {
+	return fd.enqueuedAt
+}
+
+func (fd *fakeTracesRequest) SetEnqueuedAt(enqueuedAt time.Time) {
+	fd.enqueuedAt = enqueuedAt
+}
+
 func newFakeTracesRequestUnmarshalerFunc() RequestUnmarshaler {
 	return func(bytes []byte) (Request, error) {
 		unmarshaler := ptrace.ProtoUnmarshaler{}
@@ -320,6 +330,38 @@ func TestPersistentStorage_RepeatPutCloseReadClose(t *testing.T) {
 	require.NoError(t, ext.Shutdown(context.Background()))
 }
 
+func TestPersistentStorage_InitWithoutReadIndex(t *testing.T) {
+	client := newMockStorageClient()
+	wi, err := itemIndexToBytes(itemIndex(2))
+	require.NoError(t, err)
+	require.NoError(t, client.Set(context.Background(), writeIndexKey, wi))
+
+	// The queue was written to, but no item was dispatched before the restart.
+	pcs := &persistentContiguousStorage{logger: zap.NewNop(), client: client, itemsCount: atomic.NewUint64(0)}
+	initPersistentContiguousStorage(context.Background(), pcs)
+	require.Equal(t, itemIndex(0), pcs.readIndex)
+	require.Equal(t, itemIndex(2), pcs.writeIndex)
+	require.Equal(t, uint64(2), pcs.size())
+}
+
+func TestPersistentStorage_EnqueuedAtPreserved(t *testing.T) {
+	path := t.TempDir()
+
+	ext := createStorageExtension(path)
+	client := createTestClient(ext)
+	ps := createTestPersistentStorage(client)
+
+	enqueuedAt := time.Unix(0, time.Now().UnixNano())
+	req := newFakeTracesRequest(newTraces(1, 1))
+	req.SetEnqueuedAt(enqueuedAt)
+	require.NoError(t, ps.put(req))
+
+	readReq := getItemFromChannel(t, ps)
+	require.True(t, enqueuedAt.Equal(readReq.EnqueuedAt()))
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
 func TestPersistentStorage_EmptyRequest(t *testing.T) {
 	path := t.TempDir()
 
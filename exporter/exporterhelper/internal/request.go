@@ -14,7 +14,10 @@
 
 package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Request defines capabilities required for persistent storage of a request
 type Request interface {
@@ -41,6 +44,12 @@ type Request interface {
 
 	// SetOnProcessingFinished allows to set an optional callback function to do the cleanup (e.g. remove the item from persistent queue)
 	SetOnProcessingFinished(callback func())
+
+	// EnqueuedAt returns the time the request was first added to the sending queue, or the zero time if it was not.
+	EnqueuedAt() time.Time
+
+	// SetEnqueuedAt sets the time the request was first added to the sending queue.
+	SetEnqueuedAt(time.Time)
 }
 
 // RequestUnmarshaler defines a function which takes a byte slice and unmarshals it into a relevant request
@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
 
@@ -55,7 +56,9 @@ func newLogsRequestUnmarshalerFunc(pusher consumer.ConsumeLogsFunc) internal.Req
 func (req *logsRequest) OnError(err error) internal.Request {
 	var logError consumererror.Logs
 	if errors.As(err, &logError) {
-		return newLogsRequest(req.ctx, logError.GetLogs(), req.pusher)
+		partial := newLogsRequest(req.ctx, logError.GetLogs(), req.pusher)
+		partial.SetEnqueuedAt(req.enqueuedAt)
+		return partial
 	}
 	return req
 }
@@ -72,6 +75,16 @@ func (req *logsRequest) Count() int {
 	return req.ld.LogRecordCount()
 }
 
+func (req *logsRequest) resourceAttribute(key string) (pcommon.Value, bool) {
+	rs := req.ld.ResourceLogs()
+	for i := 0; i < rs.Len(); i++ {
+		if v, ok := rs.At(i).Resource().Attributes().Get(key); ok {
+			return v, true
+		}
+	}
+	return pcommon.Value{}, false
+}
+
 type logsExporter struct {
 	*baseExporter
 	consumer.Logs
@@ -104,8 +117,9 @@ func NewLogsExporter(
 	}
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &logsExporterWithObservability{
-			obsrep:     be.obsrep,
-			nextSender: nextSender,
+			obsrep:      be.obsrep,
+			nextSender:  nextSender,
+			recordBytes: bs.recordBytes,
 		}
 	})
 
@@ -125,13 +139,19 @@ func NewLogsExporter(
 }
 
 type logsExporterWithObservability struct {
-	obsrep     *obsExporter
-	nextSender requestSender
+	obsrep      *obsExporter
+	nextSender  requestSender
+	recordBytes bool
 }
 
 func (lewo *logsExporterWithObservability) send(req internal.Request) error {
 	req.SetContext(lewo.obsrep.StartLogsOp(req.Context()))
+	setPayloadAge(req)
 	err := lewo.nextSender.send(req)
+	if lewo.recordBytes {
+		lewo.obsrep.EndLogsOpWithBytes(req.Context(), req.Count(), int64(logsMarshaler.LogsSize(req.(*logsRequest).ld)), err)
+		return err
+	}
 	lewo.obsrep.EndLogsOp(req.Context(), req.Count(), err)
 	return err
 }
@@ -118,6 +118,29 @@ func TestLogsExporter_WithRecordLogs(t *testing.T) {
 	checkRecordedMetricsForLogsExporter(t, tt, le, nil)
 }
 
+func TestLogsExporter_WithRequestBytes(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetryWithID(fakeLogsExporterName)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	want := errors.New("my_error")
+	failed := false
+	exp, err := NewLogsExporter(context.Background(), tt.ToExporterCreateSettings(), &fakeLogsExporterConfig, func(context.Context, plog.Logs) error {
+		if failed {
+			return want
+		}
+		return nil
+	}, WithRequestBytes())
+	require.NoError(t, err)
+
+	ld := testdata.GenerateLogs(2)
+	size := int64(logsMarshaler.LogsSize(ld))
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+	failed = true
+	require.Equal(t, want, exp.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, obsreporttest.CheckExporterBytes(tt, fakeLogsExporterName, size, size))
+}
+
 func TestLogsExporter_WithRecordLogs_ReturnError(t *testing.T) {
 	want := errors.New("my_error")
 	tt, err := obsreporttest.SetupTelemetryWithID(fakeLogsExporterName)
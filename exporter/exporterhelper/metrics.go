@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
@@ -55,7 +56,9 @@ func newMetricsRequestUnmarshalerFunc(pusher consumer.ConsumeMetricsFunc) intern
 func (req *metricsRequest) OnError(err error) internal.Request {
 	var metricsError consumererror.Metrics
 	if errors.As(err, &metricsError) {
-		return newMetricsRequest(req.ctx, metricsError.GetMetrics(), req.pusher)
+		partial := newMetricsRequest(req.ctx, metricsError.GetMetrics(), req.pusher)
+		partial.SetEnqueuedAt(req.enqueuedAt)
+		return partial
 	}
 	return req
 }
@@ -73,6 +76,16 @@ func (req *metricsRequest) Count() int {
 	return req.md.DataPointCount()
 }
 
+func (req *metricsRequest) resourceAttribute(key string) (pcommon.Value, bool) {
+	rs := req.md.ResourceMetrics()
+	for i := 0; i < rs.Len(); i++ {
+		if v, ok := rs.At(i).Resource().Attributes().Get(key); ok {
+			return v, true
+		}
+	}
+	return pcommon.Value{}, false
+}
+
 type metricsExporter struct {
 	*baseExporter
 	consumer.Metrics
@@ -105,8 +118,9 @@ func NewMetricsExporter(
 	}
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &metricsSenderWithObservability{
-			obsrep:     be.obsrep,
-			nextSender: nextSender,
+			obsrep:      be.obsrep,
+			nextSender:  nextSender,
+			recordBytes: bs.recordBytes,
 		}
 	})
 
@@ -126,13 +140,19 @@ func NewMetricsExporter(
 }
 
 type metricsSenderWithObservability struct {
-	obsrep     *obsExporter
-	nextSender requestSender
+	obsrep      *obsExporter
+	nextSender  requestSender
+	recordBytes bool
 }
 
 func (mewo *metricsSenderWithObservability) send(req internal.Request) error {
 	req.SetContext(mewo.obsrep.StartMetricsOp(req.Context()))
+	setPayloadAge(req)
 	err := mewo.nextSender.send(req)
+	if mewo.recordBytes {
+		mewo.obsrep.EndMetricsOpWithBytes(req.Context(), req.Count(), int64(metricsMarshaler.MetricsSize(req.(*metricsRequest).md)), err)
+		return err
+	}
 	mewo.obsrep.EndMetricsOp(req.Context(), req.Count(), err)
 	return err
 }
@@ -117,6 +117,29 @@ func TestMetricsExporter_WithRecordMetrics(t *testing.T) {
 	checkRecordedMetricsForMetricsExporter(t, tt, me, nil)
 }
 
+func TestMetricsExporter_WithRequestBytes(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetryWithID(fakeMetricsExporterName)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	want := errors.New("my_error")
+	failed := false
+	exp, err := NewMetricsExporter(context.Background(), tt.ToExporterCreateSettings(), &fakeMetricsExporterConfig, func(context.Context, pmetric.Metrics) error {
+		if failed {
+			return want
+		}
+		return nil
+	}, WithRequestBytes())
+	require.NoError(t, err)
+
+	md := testdata.GenerateMetrics(2)
+	size := int64(metricsMarshaler.MetricsSize(md))
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+	failed = true
+	require.Equal(t, want, exp.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, obsreporttest.CheckExporterBytes(tt, fakeMetricsExporterName, size, size))
+}
+
 func TestMetricsExporter_WithRecordMetrics_ReturnError(t *testing.T) {
 	want := errors.New("my_error")
 	tt, err := obsreporttest.SetupTelemetryWithID(fakeMetricsExporterName)
@@ -16,11 +16,13 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 
 import (
 	"context"
+	"time"
 
 	"go.opencensus.io/metric"
 	"go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/metric/metricproducer"
 
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/obsreport"
 )
@@ -29,6 +31,9 @@ import (
 //       into existing `obsreport` package once its functionally is not exposed
 //       as public API. For now this part is kept private.
 
+// queueDataTypeKey is the metric label holding the data type of the queue.
+const queueDataTypeKey = "data_type"
+
 var (
 	globalInstruments = newInstruments(metric.NewRegistry())
 )
@@ -41,6 +46,12 @@ type instruments struct {
 	registry                    *metric.Registry
 	queueSize                   *metric.Int64DerivedGauge
 	queueCapacity               *metric.Int64DerivedGauge
+	queueStorageSize            *metric.Int64DerivedGauge
+	queueCompactions            *metric.Int64DerivedCumulative
+	queueCompactionFailures     *metric.Int64DerivedCumulative
+	queuePartitions             *metric.Int64DerivedGauge
+	queuePartitionRejected      *metric.Int64Cumulative
+	queueExpiredItems           *metric.Int64Cumulative
 	failedToEnqueueTraceSpans   *metric.Int64Cumulative
 	failedToEnqueueMetricPoints *metric.Int64Cumulative
 	failedToEnqueueLogRecords   *metric.Int64Cumulative
@@ -62,6 +73,42 @@ func newInstruments(registry *metric.Registry) *instruments {
 		metric.WithLabelKeys(obsmetrics.ExporterKey),
 		metric.WithUnit(metricdata.UnitDimensionless))
 
+	insts.queueStorageSize, _ = registry.AddInt64DerivedGauge(
+		obsmetrics.ExporterKey+"/queue_storage_size",
+		metric.WithDescription("Current size of the file of the persistent queue kept in the storage directory"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, queueDataTypeKey),
+		metric.WithUnit(metricdata.UnitBytes))
+
+	insts.queueCompactions, _ = registry.AddInt64DerivedCumulative(
+		obsmetrics.ExporterKey+"/queue_compactions",
+		metric.WithDescription("Number of compactions of the file of the persistent queue kept in the storage directory"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, queueDataTypeKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.queueCompactionFailures, _ = registry.AddInt64DerivedCumulative(
+		obsmetrics.ExporterKey+"/queue_compaction_failures",
+		metric.WithDescription("Number of failed compactions of the file of the persistent queue kept in the storage directory"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, queueDataTypeKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.queuePartitions, _ = registry.AddInt64DerivedGauge(
+		obsmetrics.ExporterKey+"/queue_partitions",
+		metric.WithDescription("Current number of non-empty partitions of the retry queue"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.queuePartitionRejected, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/queue_partition_rejected",
+		metric.WithDescription("Number of batches rejected because their partition of the retry queue is full"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, queuePartitionKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.queueExpiredItems, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/queue_expired_items",
+		metric.WithDescription("Number of spans, metric points or log records dropped because they exceeded max_item_age in the retry queue"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, queueDataTypeKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
 	insts.failedToEnqueueTraceSpans, _ = registry.AddInt64Cumulative(
 		obsmetrics.ExporterKey+"/enqueue_failed_spans",
 		metric.WithDescription("Number of spans failed to be added to the sending queue."),
@@ -125,3 +172,11 @@ func (eor *obsExporter) recordMetricsEnqueueFailure(_ context.Context, numMetric
 func (eor *obsExporter) recordLogsEnqueueFailure(_ context.Context, numLogRecords int64) {
 	eor.failedToEnqueueLogRecordsEntry.Inc(numLogRecords)
 }
+
+// setPayloadAge sets the time since the request was added to the sending queue as the payload age of the
+// export operation. Requests that were not queued have no payload age.
+func setPayloadAge(req internal.Request) {
+	if enqueuedAt := req.EnqueuedAt(); !enqueuedAt.IsZero() {
+		obsreport.SetPayloadAge(req.Context(), time.Since(enqueuedAt))
+	}
+}
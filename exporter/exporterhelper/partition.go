@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	// queuePartitionKey is the metric label holding the partition of the sending queue.
+	queuePartitionKey = "partition"
+	// partitionMetadataPrefix marks the QueueSettings.PartitionBy keys read from the client.Info metadata.
+	partitionMetadataPrefix = "metadata."
+	// partitionKeySeparator separates the values of the QueueSettings.PartitionBy keys in a partition key.
+	partitionKeySeparator = ","
+)
+
+// resourceAttributeGetter is implemented by the requests able to look up their resource attributes.
+type resourceAttributeGetter interface {
+	// resourceAttribute returns the value of the attribute in the first resource having it.
+	resourceAttribute(key string) (pcommon.Value, bool)
+}
+
+// newPartitionKeyFunc returns a function computing the partition of a request from the values of the keys,
+// see QueueSettings.PartitionBy. Missing values are empty.
+func newPartitionKeyFunc(keys []string) func(internal.Request) string {
+	return func(req internal.Request) string {
+		values := make([]string, len(keys))
+		for i, key := range keys {
+			if metadataKey := strings.TrimPrefix(key, partitionMetadataPrefix); metadataKey != key {
+				values[i] = strings.Join(client.FromContext(req.Context()).Metadata.Get(metadataKey), partitionKeySeparator)
+				continue
+			}
+			if getter, ok := req.(resourceAttributeGetter); ok {
+				if v, found := getter.resourceAttribute(key); found {
+					values[i] = v.AsString()
+				}
+			}
+		}
+		return strings.Join(values, partitionKeySeparator)
+	}
+}
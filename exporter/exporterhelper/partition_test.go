@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestPartitionKeyFunc(t *testing.T) {
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"X-Tenant": {"acme"}}),
+	})
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("k8s.namespace.name", "traces-ns")
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("k8s.namespace.name", "metrics-ns")
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("k8s.namespace.name", "logs-ns")
+
+	keyFunc := newPartitionKeyFunc([]string{"k8s.namespace.name", "metadata.x-tenant"})
+	assert.Equal(t, "traces-ns,acme", keyFunc(newTracesRequest(ctx, td, nil)))
+	assert.Equal(t, "metrics-ns,acme", keyFunc(newMetricsRequest(ctx, md, nil)))
+	assert.Equal(t, "logs-ns,acme", keyFunc(newLogsRequest(ctx, ld, nil)))
+	// Missing values are empty.
+	assert.Equal(t, ",", keyFunc(newTracesRequest(context.Background(), ptrace.NewTraces(), nil)))
+	assert.Equal(t, ",acme", keyFunc(newErrorRequest(ctx)))
+}
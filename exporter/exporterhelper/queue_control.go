@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// flushPollInterval is the interval at which Flush checks if the sending queue is drained.
+const flushPollInterval = 10 * time.Millisecond
+
+var (
+	errExporterNotFound        = errors.New("exporter not found")
+	errNotQueueController      = errors.New("exporter does not support queue control")
+	errNotBackpressureReporter = errors.New("exporter does not report backpressure")
+)
+
+var (
+	_ QueueController      = (*baseExporter)(nil)
+	_ QueueController      = (*queuedRetrySender)(nil)
+	_ BackpressureReporter = (*baseExporter)(nil)
+	_ BackpressureReporter = (*queuedRetrySender)(nil)
+)
+
+// QueueController is implemented by the exporters created with this package. It allows extensions to observe
+// and drain the sending queue of an exporter, e.g. from a pre-stop hook before the termination of the process.
+type QueueController interface {
+	// QueueSize returns the number of requests waiting in the sending queue or being sent.
+	// It is always zero if the sending queue is disabled.
+	QueueSize() int
+
+	// Flush retries immediately the requests waiting for the next retry, and blocks until the sending queue
+	// is empty or the context is done. The requests that fail again are retried after the usual backoff.
+	Flush(ctx context.Context) error
+}
+
+// GetQueueController returns the QueueController of the exporter with the given ID, for the given data type.
+func GetQueueController(host component.Host, dataType component.DataType, id component.ID) (QueueController, error) {
+	exp, ok := host.GetExporters()[dataType][id]
+	if !ok {
+		return nil, fmt.Errorf("failed to control the queue of %q for %s: %w", id, dataType, errExporterNotFound)
+	}
+	qc, ok := exp.(QueueController)
+	if !ok {
+		return nil, fmt.Errorf("failed to control the queue of %q for %s: %w", id, dataType, errNotQueueController)
+	}
+	return qc, nil
+}
+
+// BackpressureReporter is implemented by the exporters created with this package. It allows receivers to
+// ask their clients to slow down when the sending queue of an exporter is nearly full, so that the backpressure
+// propagates across tiers of collectors before the queue starts dropping data.
+type BackpressureReporter interface {
+	// UnderBackpressure returns true when the sending queue holds more requests than the backpressure watermark.
+	// It is always false if the sending queue or the watermark is disabled. It must be safe for concurrent use.
+	UnderBackpressure() bool
+}
+
+// GetBackpressureReporter returns the BackpressureReporter of the exporter with the given ID, for the given data type.
+func GetBackpressureReporter(host component.Host, dataType component.DataType, id component.ID) (BackpressureReporter, error) {
+	exp, ok := host.GetExporters()[dataType][id]
+	if !ok {
+		return nil, fmt.Errorf("failed to watch the queue of %q for %s: %w", id, dataType, errExporterNotFound)
+	}
+	br, ok := exp.(BackpressureReporter)
+	if !ok {
+		return nil, fmt.Errorf("failed to watch the queue of %q for %s: %w", id, dataType, errNotBackpressureReporter)
+	}
+	return br, nil
+}
+
+// QueueSize implements QueueController.
+func (be *baseExporter) QueueSize() int {
+	return be.qrSender.QueueSize()
+}
+
+// Flush implements QueueController.
+func (be *baseExporter) Flush(ctx context.Context) error {
+	return be.qrSender.Flush(ctx)
+}
+
+// UnderBackpressure implements BackpressureReporter.
+func (be *baseExporter) UnderBackpressure() bool {
+	return be.qrSender.UnderBackpressure()
+}
+
+// QueueSize implements QueueController.
+func (qrs *queuedRetrySender) QueueSize() int {
+	// The persistent queue is only created on start.
+	if !qrs.cfg.Enabled || qrs.queue == nil {
+		return 0
+	}
+	return int(qrs.pending.Load())
+}
+
+// UnderBackpressure implements BackpressureReporter.
+func (qrs *queuedRetrySender) UnderBackpressure() bool {
+	// The persistent queue is only created on start.
+	if !qrs.cfg.Enabled || qrs.cfg.BackpressureWatermark == 0 || qrs.queue == nil {
+		return false
+	}
+	return float64(qrs.queue.Size()) > qrs.cfg.BackpressureWatermark*float64(qrs.cfg.QueueSize)
+}
+
+// Flush implements QueueController.
+func (qrs *queuedRetrySender) Flush(ctx context.Context) error {
+	qrs.flush.begin()
+	defer qrs.flush.end()
+
+	ticker := time.NewTicker(flushPollInterval)
+	defer ticker.Stop()
+	for qrs.QueueSize() > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("sending queue not drained, %d requests left: %w", qrs.QueueSize(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// flushSignal wakes up the requests waiting for the next retry when a flush begins. A request failing
+// again while the flush is in progress is retried immediately once more, if it was not already sent
+// for that flush, so that a request being sent when the flush begins is not left waiting for its backoff.
+type flushSignal struct {
+	mu sync.Mutex
+	ch chan struct{}
+	// gen is incremented by every flush.
+	gen uint64
+	// active is the number of flushes in progress.
+	active int
+}
+
+func newFlushSignal() *flushSignal {
+	return &flushSignal{ch: make(chan struct{})}
+}
+
+// closedCh is returned by wait to retry a request immediately.
+var closedCh = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+// generation returns the generation of the last flush.
+func (fs *flushSignal) generation() uint64 {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.gen
+}
+
+// wait returns a channel closed when a flush begins. The channel is already closed if a flush newer than
+// the given generation is in progress.
+func (fs *flushSignal) wait(gen uint64) <-chan struct{} {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fs.active > 0 && fs.gen > gen {
+		return closedCh
+	}
+	return fs.ch
+}
+
+func (fs *flushSignal) begin() {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.gen++
+	fs.active++
+	close(fs.ch)
+	fs.ch = make(chan struct{})
+}
+
+func (fs *flushSignal) end() {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.active--
+}
@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+type exportersHost struct {
+	component.Host
+	exporters map[component.DataType]map[component.ID]component.Component
+}
+
+func (h *exportersHost) GetExporters() map[component.DataType]map[component.ID]component.Component {
+	return h.exporters
+}
+
+func TestQueuedRetry_FlushRetriesImmediately(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Minute
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	mockR := newMockRequest(context.Background(), 2, errors.New("transient error"))
+	require.NoError(t, be.sender.send(mockR))
+	mockR.checkNumRequests(t, 1)
+	assert.Equal(t, 1, be.QueueSize())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, be.Flush(ctx))
+	mockR.checkNumRequests(t, 2)
+	assert.Equal(t, 0, be.QueueSize())
+}
+
+func TestQueuedRetry_FlushTimeout(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Minute
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, be.sender.send(newErrorRequest(context.Background())))
+	require.NoError(t, be.sender.send(newErrorRequest(context.Background())))
+	assert.Eventually(t, func() bool { return be.QueueSize() == 2 }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = be.Flush(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 2, be.QueueSize())
+}
+
+func TestQueuedRetry_QueueSizeDisabledQueue(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.Enabled = false
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	assert.Equal(t, 0, be.QueueSize())
+	assert.NoError(t, be.Flush(context.Background()))
+}
+
+func TestQueuedRetry_UnderBackpressure(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.QueueSize = 4
+	qCfg.BackpressureWatermark = 0.5
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Minute
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The only consumer waits for the next retry of the first request, the next ones stay in the queue.
+	require.NoError(t, be.sender.send(newErrorRequest(context.Background())))
+	assert.Eventually(t, func() bool { return be.QueueSize() == 1 }, time.Second, time.Millisecond)
+	require.NoError(t, be.sender.send(newErrorRequest(context.Background())))
+	require.NoError(t, be.sender.send(newErrorRequest(context.Background())))
+	assert.False(t, be.UnderBackpressure())
+
+	require.NoError(t, be.sender.send(newErrorRequest(context.Background())))
+	assert.True(t, be.UnderBackpressure())
+}
+
+func TestQueuedRetry_UnderBackpressureDisabled(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.QueueSize = 1
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Minute
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, be.sender.send(newErrorRequest(context.Background())))
+	assert.Eventually(t, func() bool { return be.QueueSize() == 1 }, time.Second, time.Millisecond)
+	require.NoError(t, be.sender.send(newErrorRequest(context.Background())))
+	assert.False(t, be.UnderBackpressure())
+}
+
+func TestGetQueueController(t *testing.T) {
+	te, err := NewTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), &fakeTracesExporterConfig, newTraceDataPusher(nil))
+	require.NoError(t, err)
+	otherID := component.NewIDWithName("other", "1")
+	nop, err := componenttest.NewNopExporterFactory().CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), nil)
+	require.NoError(t, err)
+	host := &exportersHost{
+		exporters: map[component.DataType]map[component.ID]component.Component{
+			component.DataTypeTraces: {
+				fakeTracesExporterName: te,
+				otherID:                nop,
+			},
+		},
+	}
+
+	qc, err := GetQueueController(host, component.DataTypeTraces, fakeTracesExporterName)
+	require.NoError(t, err)
+	assert.Equal(t, 0, qc.QueueSize())
+
+	_, err = GetQueueController(host, component.DataTypeMetrics, fakeTracesExporterName)
+	assert.ErrorIs(t, err, errExporterNotFound)
+
+	_, err = GetQueueController(host, component.DataTypeTraces, otherID)
+	assert.ErrorIs(t, err, errNotQueueController)
+}
+
+func TestGetBackpressureReporter(t *testing.T) {
+	te, err := NewTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), &fakeTracesExporterConfig, newTraceDataPusher(nil))
+	require.NoError(t, err)
+	otherID := component.NewIDWithName("other", "1")
+	nop, err := componenttest.NewNopExporterFactory().CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), nil)
+	require.NoError(t, err)
+	host := &exportersHost{
+		exporters: map[component.DataType]map[component.ID]component.Component{
+			component.DataTypeTraces: {
+				fakeTracesExporterName: te,
+				otherID:                nop,
+			},
+		},
+	}
+
+	br, err := GetBackpressureReporter(host, component.DataTypeTraces, fakeTracesExporterName)
+	require.NoError(t, err)
+	assert.False(t, br.UnderBackpressure())
+
+	_, err = GetBackpressureReporter(host, component.DataTypeMetrics, fakeTracesExporterName)
+	assert.ErrorIs(t, err, errExporterNotFound)
+
+	_, err = GetBackpressureReporter(host, component.DataTypeTraces, otherID)
+	assert.ErrorIs(t, err, errNotBackpressureReporter)
+}
+
+func TestFlushSignal(t *testing.T) {
+	fs := newFlushSignal()
+	gen := fs.generation()
+	waitCh := fs.wait(gen)
+	assertClosed := func(ch <-chan struct{}, closed bool) {
+		select {
+		case <-ch:
+			assert.True(t, closed, "channel is closed")
+		default:
+			assert.False(t, closed, "channel is open")
+		}
+	}
+	assertClosed(waitCh, false)
+
+	fs.begin()
+	// The requests waiting for their backoff are woken up.
+	assertClosed(waitCh, true)
+	// A request sent before the flush began, and failing during the flush, is retried immediately once more.
+	assertClosed(fs.wait(gen), true)
+	assertClosed(fs.wait(fs.generation()), false)
+
+	fs.end()
+	// Once the flush is over, the requests wait for their backoff.
+	assertClosed(fs.wait(gen), false)
+}
@@ -24,6 +24,7 @@ import (
 	"go.opencensus.io/metric/metricdata"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
@@ -51,6 +52,25 @@ type QueueSettings struct {
 	// StorageID if not empty, enables the persistent storage and uses the component specified
 	// as a storage extension for the persistent queue
 	StorageID *component.ID `mapstructure:"storage"`
+	// StorageDirectory if not empty, enables the persistent storage and keeps the persistent queue in a file
+	// in this directory, without requiring a storage extension. It cannot be used along with StorageID.
+	StorageDirectory string `mapstructure:"storage_directory"`
+	// MaxItemAge is the maximum amount of time since a batch was first added to the queue, including
+	// the time spent waiting in the queue and all retries. Once this value is reached, the data is discarded.
+	// Zero means no limit.
+	MaxItemAge time.Duration `mapstructure:"max_item_age"`
+	// BackpressureWatermark is the fraction of QueueSize above which the exporter reports backpressure,
+	// see BackpressureReporter. Receivers configured to watch the exporter ask their clients to slow down.
+	// Zero disables the backpressure reporting.
+	BackpressureWatermark float64 `mapstructure:"backpressure_watermark"`
+	// PartitionBy if not empty, splits the queue into partitions by the values of these keys, so that a tenant
+	// sending a lot of data cannot starve the others. Keys are resource attribute names, or the `metadata.`
+	// prefix followed by the name of a request metadata key from client.Info (e.g. a tenant header).
+	// It cannot be used along with the persistent queue.
+	PartitionBy []string `mapstructure:"partition_by"`
+	// PartitionQueueSize is the maximum number of batches allowed in each partition of the queue,
+	// see PartitionBy. Zero means QueueSize.
+	PartitionQueueSize int `mapstructure:"partition_queue_size"`
 }
 
 // NewDefaultQueueSettings returns the default settings for QueueSettings.
@@ -66,6 +86,16 @@ func NewDefaultQueueSettings() QueueSettings {
 	}
 }
 
+// SendingQueueBudget returns the number of batches the queue can hold and the number of its consumers, zero
+// if the queue is disabled. The service uses it, through the exporter configs embedding QueueSettings,
+// to estimate the resources held by the pipelines.
+func (qCfg *QueueSettings) SendingQueueBudget() (queueSize int, numConsumers int) {
+	if !qCfg.Enabled {
+		return 0, 0
+	}
+	return qCfg.QueueSize, qCfg.NumConsumers
+}
+
 // Validate checks if the QueueSettings configuration is valid
 func (qCfg *QueueSettings) Validate() error {
 	if !qCfg.Enabled {
@@ -76,21 +106,49 @@ func (qCfg *QueueSettings) Validate() error {
 		return errors.New("queue size must be positive")
 	}
 
+	if qCfg.StorageID != nil && qCfg.StorageDirectory != "" {
+		return errors.New("storage and storage_directory cannot be used together")
+	}
+
+	if qCfg.MaxItemAge < 0 {
+		return errors.New("max item age must not be negative")
+	}
+
+	if qCfg.BackpressureWatermark < 0 || qCfg.BackpressureWatermark > 1 {
+		return errors.New("backpressure watermark must be between 0 and 1")
+	}
+
+	if len(qCfg.PartitionBy) > 0 && (qCfg.StorageID != nil || qCfg.StorageDirectory != "") {
+		return errors.New("partition_by cannot be used with the persistent queue")
+	}
+
+	if qCfg.PartitionQueueSize < 0 || qCfg.PartitionQueueSize > qCfg.QueueSize {
+		return errors.New("partition queue size must be between 0 and the queue size")
+	}
+
 	return nil
 }
 
 type queuedRetrySender struct {
-	fullName           string
-	id                 component.ID
-	signal             component.DataType
-	cfg                QueueSettings
-	consumerSender     requestSender
-	queue              internal.ProducerConsumerQueue
+	fullName       string
+	id             component.ID
+	signal         component.DataType
+	cfg            QueueSettings
+	consumerSender requestSender
+	queue          internal.ProducerConsumerQueue
+	// partitionedQueue is the queue when it is split by QueueSettings.PartitionBy.
+	partitionedQueue   *internal.PartitionedMemoryQueue
 	retryStopCh        chan struct{}
 	traceAttribute     attribute.KeyValue
 	logger             *zap.Logger
 	requeuingEnabled   bool
 	requestUnmarshaler internal.RequestUnmarshaler
+	// pending counts the requests accepted by the queue and not yet processed. It is incremented before
+	// the request is produced, so that it never misses a request taken by a consumer.
+	pending *atomic.Int64
+	flush   *flushSignal
+	// fileStorage is the storage of the persistent queue if it is kept in the storage directory.
+	fileStorage *internal.FileStorageClient
 }
 
 func newQueuedRetrySender(id component.ID, signal component.DataType, qCfg QueueSettings, rCfg RetrySettings, reqUnmarshaler internal.RequestUnmarshaler, nextSender requestSender, logger *zap.Logger) *queuedRetrySender {
@@ -107,19 +165,31 @@ func newQueuedRetrySender(id component.ID, signal component.DataType, qCfg Queue
 		traceAttribute:     traceAttr,
 		logger:             sampledLogger,
 		requestUnmarshaler: reqUnmarshaler,
+		pending:            atomic.NewInt64(0),
+		flush:              newFlushSignal(),
 	}
 
 	qrs.consumerSender = &retrySender{
 		traceAttribute: traceAttr,
 		cfg:            rCfg,
+		maxItemAge:     qCfg.MaxItemAge,
 		nextSender:     nextSender,
 		stopCh:         retryStopCh,
+		flush:          qrs.flush,
 		logger:         sampledLogger,
 		// Following three functions actually depend on queuedRetrySender
 		onTemporaryFailure: qrs.onTemporaryFailure,
 	}
 
-	if qCfg.StorageID == nil {
+	switch {
+	case len(qCfg.PartitionBy) > 0:
+		partitionSize := qCfg.PartitionQueueSize
+		if partitionSize == 0 {
+			partitionSize = qCfg.QueueSize
+		}
+		qrs.partitionedQueue = internal.NewPartitionedMemoryQueue(qCfg.QueueSize, partitionSize, newPartitionKeyFunc(qCfg.PartitionBy), qrs.onPartitionFull)
+		qrs.queue = qrs.partitionedQueue
+	case qCfg.StorageID == nil && qCfg.StorageDirectory == "":
 		qrs.queue = internal.NewBoundedMemoryQueue(qrs.cfg.QueueSize)
 	}
 	// The Persistent Queue is initialized separately as it needs extra information about the component
@@ -153,15 +223,24 @@ func toStorageClient(ctx context.Context, storageID component.ID, host component
 
 // initializePersistentQueue uses extra information for initialization available from component.Host
 func (qrs *queuedRetrySender) initializePersistentQueue(ctx context.Context, host component.Host) error {
-	if qrs.cfg.StorageID == nil {
+	var storageClient storage.Client
+	switch {
+	case qrs.cfg.StorageDirectory != "":
+		fileStorage, err := internal.NewFileStorageClient(qrs.cfg.StorageDirectory, qrs.fullName+"-"+string(qrs.signal), qrs.logger)
+		if err != nil {
+			return err
+		}
+		qrs.fileStorage = fileStorage
+		storageClient = fileStorage
+	case qrs.cfg.StorageID != nil:
+		var err error
+		if storageClient, err = toStorageClient(ctx, *qrs.cfg.StorageID, host, qrs.id, qrs.signal); err != nil {
+			return err
+		}
+	default:
 		return nil
 	}
 
-	storageClient, err := toStorageClient(ctx, *qrs.cfg.StorageID, host, qrs.id, qrs.signal)
-	if err != nil {
-		return err
-	}
-
 	qrs.queue = internal.NewPersistentQueue(ctx, qrs.fullName, qrs.signal, qrs.cfg.QueueSize, qrs.logger, storageClient, qrs.requestUnmarshaler)
 
 	// TODO: this can be further exposed as a config param rather than relying on a type of queue
@@ -180,7 +259,7 @@ func (qrs *queuedRetrySender) onTemporaryFailure(logger *zap.Logger, req interna
 		return err
 	}
 
-	if qrs.queue.Produce(req) {
+	if qrs.produce(req) {
 		logger.Error(
 			"Exporting failed. Putting back to the end of the queue.",
 			zap.Error(err),
@@ -195,16 +274,72 @@ func (qrs *queuedRetrySender) onTemporaryFailure(logger *zap.Logger, req interna
 	return err
 }
 
+// onPartitionFull records a request rejected because its partition of the queue is full.
+func (qrs *queuedRetrySender) onPartitionFull(key string) {
+	qrs.logger.Error(
+		"Dropping data because the partition of the sending_queue is full. Try increasing partition_queue_size.",
+		zap.String("partition", key),
+	)
+	if entry, err := globalInstruments.queuePartitionRejected.GetEntry(metricdata.NewLabelValue(qrs.fullName), metricdata.NewLabelValue(key)); err == nil {
+		entry.Inc(1)
+	}
+}
+
+// onItemExpired records a request dropped because it exceeded max_item_age in the queue.
+func (qrs *queuedRetrySender) onItemExpired(item internal.Request) {
+	qrs.logger.Error(
+		"Dropping data because it exceeded max_item_age in the sending queue.",
+		zap.Int("dropped_items", item.Count()),
+	)
+	if entry, err := globalInstruments.queueExpiredItems.GetEntry(metricdata.NewLabelValue(qrs.fullName), metricdata.NewLabelValue(string(qrs.signal))); err == nil {
+		entry.Inc(int64(item.Count()))
+	}
+}
+
+// produce adds the request to the queue, counting it as pending until it is consumed.
+func (qrs *queuedRetrySender) produce(req internal.Request) bool {
+	qrs.pending.Inc()
+	if !qrs.queue.Produce(req) {
+		qrs.pending.Dec()
+		return false
+	}
+	return true
+}
+
+// consume sends a request taken from the queue.
+func (qrs *queuedRetrySender) consume(item internal.Request) {
+	defer qrs.pending.Dec()
+
+	if item.EnqueuedAt().IsZero() {
+		// Requests persisted by an older version do not record when they were enqueued.
+		item.SetEnqueuedAt(time.Now())
+	}
+	if qrs.cfg.MaxItemAge > 0 && time.Since(item.EnqueuedAt()) > qrs.cfg.MaxItemAge {
+		qrs.onItemExpired(item)
+		item.OnProcessingFinished()
+		return
+	}
+	err := qrs.consumerSender.send(item)
+	if qrs.requeuingEnabled && errors.As(err, &shutdownErr{}) {
+		// Keep the request in the persistent queue, it is dispatched again on the next start.
+		qrs.logger.Info(
+			"Exporting interrupted by shutdown. Keeping data in the persistent queue.",
+			zap.Int("items", item.Count()),
+		)
+		return
+	}
+	item.OnProcessingFinished()
+}
+
 // start is invoked during service startup.
 func (qrs *queuedRetrySender) start(ctx context.Context, host component.Host) error {
 	if err := qrs.initializePersistentQueue(ctx, host); err != nil {
 		return err
 	}
 
-	qrs.queue.StartConsumers(qrs.cfg.NumConsumers, func(item internal.Request) {
-		_ = qrs.consumerSender.send(item)
-		item.OnProcessingFinished()
-	})
+	// The persistent queue may hold requests from the previous run.
+	qrs.pending.Store(int64(qrs.queue.Size()))
+	qrs.queue.StartConsumers(qrs.cfg.NumConsumers, qrs.consume)
 
 	// Start reporting queue length metric
 	if qrs.cfg.Enabled {
@@ -222,6 +357,33 @@ func (qrs *queuedRetrySender) start(ctx context.Context, host component.Host) er
 		}
 	}
 
+	// Start reporting the number of partitions of the queue
+	if qrs.partitionedQueue != nil {
+		err := globalInstruments.queuePartitions.UpsertEntry(func() int64 {
+			return int64(qrs.partitionedQueue.Partitions())
+		}, metricdata.NewLabelValue(qrs.fullName))
+		if err != nil {
+			return fmt.Errorf("failed to create queue partitions metric: %w", err)
+		}
+	}
+
+	// Start reporting the storage metrics of the queue kept in the storage directory
+	if qrs.fileStorage != nil {
+		labels := []metricdata.LabelValue{metricdata.NewLabelValue(qrs.fullName), metricdata.NewLabelValue(string(qrs.signal))}
+		err := globalInstruments.queueStorageSize.UpsertEntry(qrs.fileStorage.Size, labels...)
+		if err != nil {
+			return fmt.Errorf("failed to create queue storage size metric: %w", err)
+		}
+		err = globalInstruments.queueCompactions.UpsertEntry(qrs.fileStorage.Compactions, labels...)
+		if err != nil {
+			return fmt.Errorf("failed to create queue compactions metric: %w", err)
+		}
+		err = globalInstruments.queueCompactionFailures.UpsertEntry(qrs.fileStorage.CompactionFailures, labels...)
+		if err != nil {
+			return fmt.Errorf("failed to create queue compaction failures metric: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -255,7 +417,8 @@ type RetrySettings struct {
 	// consecutive retries will always be `MaxInterval`.
 	MaxInterval time.Duration `mapstructure:"max_interval"`
 	// MaxElapsedTime is the maximum amount of time (including retries) spent trying to send a request/batch.
-	// Once this value is reached, the data is discarded.
+	// It is measured from the first attempt and does not include the time spent waiting in the sending queue,
+	// see QueueSettings.MaxItemAge to limit that. Once this value is reached, the data is discarded.
 	MaxElapsedTime time.Duration `mapstructure:"max_elapsed_time"`
 }
 
@@ -291,11 +454,14 @@ func createSampledLogger(logger *zap.Logger) *zap.Logger {
 // send implements the requestSender interface
 func (qrs *queuedRetrySender) send(req internal.Request) error {
 	if !qrs.cfg.Enabled {
+		// Synchronous mode: the caller is blocked while the request is sent and retried, and gets the error back,
+		// so that backpressure reaches it. Retries stop before the deadline of the context of the caller.
 		err := qrs.consumerSender.send(req)
 		if err != nil {
 			qrs.logger.Error(
-				"Exporting failed. Dropping data. Try enabling sending_queue to survive temporary failures.",
-				zap.Int("dropped_items", req.Count()),
+				"Exporting failed. Returning the error to the caller. Try enabling sending_queue to survive temporary failures.",
+				zap.Error(err),
+				zap.Int("rejected_items", req.Count()),
 			)
 		}
 		return err
@@ -304,9 +470,10 @@ func (qrs *queuedRetrySender) send(req internal.Request) error {
 	// Prevent cancellation and deadline to propagate to the context stored in the queue.
 	// The grpc/http based receivers will cancel the request context after this function returns.
 	req.SetContext(noCancellationContext{Context: req.Context()})
+	req.SetEnqueuedAt(time.Now())
 
 	span := trace.SpanFromContext(req.Context())
-	if !qrs.queue.Produce(req) {
+	if !qrs.produce(req) {
 		qrs.logger.Error(
 			"Dropping data because sending_queue is full. Try increasing queue_size.",
 			zap.Int("dropped_items", req.Count()),
@@ -333,7 +500,23 @@ func (t throttleRetry) Unwrap() error {
 	return t.err
 }
 
-// NewThrottleRetry creates a new throttle retry error.
+// shutdownErr is returned when retrying a request is interrupted by shutdown.
+type shutdownErr struct {
+	err error
+}
+
+func (s shutdownErr) Error() string {
+	return "interrupted due to shutdown " + s.err.Error()
+}
+
+func (s shutdownErr) Unwrap() error {
+	return s.err
+}
+
+// NewThrottleRetry creates a new throttle retry error, asking to retry the request after the given delay, e.g.
+// as requested by the server with a gRPC RetryInfo or an HTTP Retry-After header. The retry waits for the longer
+// of the delay and the exponential backoff, so that a short delay does not retry back-to-back against an
+// overloaded server. A zero delay keeps the exponential backoff.
 func NewThrottleRetry(err error, delay time.Duration) error {
 	return throttleRetry{
 		err:   err,
@@ -346,8 +529,10 @@ type onRequestHandlingFinishedFunc func(*zap.Logger, internal.Request, error) er
 type retrySender struct {
 	traceAttribute     attribute.KeyValue
 	cfg                RetrySettings
+	maxItemAge         time.Duration
 	nextSender         requestSender
 	stopCh             chan struct{}
+	flush              *flushSignal
 	logger             *zap.Logger
 	onTemporaryFailure onRequestHandlingFinishedFunc
 }
@@ -379,6 +564,8 @@ func (rs *retrySender) send(req internal.Request) error {
 	expBackoff.Reset()
 	span := trace.SpanFromContext(req.Context())
 	retryNum := int64(0)
+	// flushGen is the generation of the last flush the request was sent for, see flushSignal.
+	flushGen := rs.flush.generation()
 	for {
 		span.AddEvent(
 			"Sending request.",
@@ -410,10 +597,33 @@ func (rs *retrySender) send(req internal.Request) error {
 			return rs.onTemporaryFailure(rs.logger, req, err)
 		}
 
+		// Wait at least for the delay asked by the server, as long as it is within max_elapsed_time.
 		throttleErr := throttleRetry{}
-		isThrottle := errors.As(err, &throttleErr)
-		if isThrottle {
+		if errors.As(err, &throttleErr) && throttleErr.delay > 0 {
 			backoffDelay = max(backoffDelay, throttleErr.delay)
+			if rs.cfg.MaxElapsedTime > 0 && expBackoff.GetElapsedTime()+backoffDelay > rs.cfg.MaxElapsedTime {
+				err = fmt.Errorf("max elapsed time expires before the throttling delay %w", err)
+				return rs.onTemporaryFailure(rs.logger, req, err)
+			}
+		}
+
+		if rs.maxItemAge > 0 && !req.EnqueuedAt().IsZero() && time.Since(req.EnqueuedAt())+backoffDelay > rs.maxItemAge {
+			rs.logger.Error(
+				"Exporting failed. The data exceeds max_item_age before the next retry. Dropping data.",
+				zap.Error(err),
+				zap.Int("dropped_items", req.Count()),
+			)
+			return fmt.Errorf("max item age expired %w", err)
+		}
+
+		// Give up right away instead of waiting for a retry that cannot happen before the deadline of the request.
+		if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < backoffDelay {
+			rs.logger.Error(
+				"Exporting failed. The request deadline expires before the next retry.",
+				zap.Error(err),
+				zap.Int("dropped_items", req.Count()),
+			)
+			return fmt.Errorf("request deadline expires before the next retry %w", err)
 		}
 
 		backoffDelayStr := backoffDelay.String()
@@ -435,7 +645,9 @@ func (rs *retrySender) send(req internal.Request) error {
 		case <-req.Context().Done():
 			return fmt.Errorf("Request is cancelled or timed out %w", err)
 		case <-rs.stopCh:
-			return fmt.Errorf("interrupted due to shutdown %w", err)
+			return shutdownErr{err: err}
+		case <-rs.flush.wait(flushGen):
+			flushGen = rs.flush.generation()
 		case <-time.After(backoffDelay):
 		}
 	}
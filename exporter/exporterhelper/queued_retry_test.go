@@ -30,6 +30,7 @@ import (
 	"go.opencensus.io/tag"
 	"go.uber.org/atomic"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumererror"
@@ -157,6 +158,33 @@ func TestQueuedRetry_StopWhileWaiting(t *testing.T) {
 	// require.Zero(t, be.qrSender.queue.OtlpProtoSize())
 }
 
+func TestQueuedRetry_StopWhileWaitingKeepsPersistedRequest(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := NewDefaultRetrySettings()
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	be.qrSender.requeuingEnabled = true
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	processingFinished := atomic.NewBool(false)
+	mockR := newMockRequest(context.Background(), 2, errors.New("transient error"))
+	mockR.SetOnProcessingFinished(func() { processingFinished.Store(true) })
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		require.NoError(t, be.sender.send(mockR))
+	})
+	mockR.checkNumRequests(t, 1)
+
+	assert.NoError(t, be.Shutdown(context.Background()))
+	ocs.awaitAsyncProcessing()
+
+	// The request must not be removed from the persistent queue, so it is sent again after restart.
+	assert.False(t, processingFinished.Load())
+}
+
 func TestQueuedRetry_DoNotPreserveCancellation(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	qCfg.NumConsumers = 1
@@ -225,6 +253,55 @@ func TestQueuedRetry_MaxElapsedTime(t *testing.T) {
 	require.Zero(t, be.qrSender.queue.Size())
 }
 
+func TestQueuedRetry_MaxItemAge(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.MaxItemAge = 50 * time.Millisecond
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Second
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	mockR := newMockRequest(context.Background(), 2, errors.New("transient error"))
+	start := time.Now()
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		require.NoError(t, be.sender.send(mockR))
+	})
+	ocs.awaitAsyncProcessing()
+
+	// The next retry would happen after max_item_age, so the data is dropped without waiting for it.
+	assert.Less(t, time.Since(start), time.Second)
+	mockR.checkNumRequests(t, 1)
+	ocs.checkSendItemsCount(t, 0)
+	ocs.checkDroppedItemsCount(t, 2)
+	require.Zero(t, be.qrSender.queue.Size())
+}
+
+func TestQueuedRetry_MaxItemAgeExpiredInQueue(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.MaxItemAge = time.Minute
+	rCfg := NewDefaultRetrySettings()
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+
+	finished := false
+	mockR := newMockRequest(context.Background(), 2, nil)
+	mockR.SetOnProcessingFinished(func() { finished = true })
+	mockR.SetEnqueuedAt(time.Now().Add(-2 * time.Minute))
+	be.qrSender.consume(mockR)
+
+	mockR.checkNumRequests(t, 0)
+	assert.True(t, finished)
+	checkValueForGlobalManager(t, append(defaultExporterTags, tag.Tag{Key: tag.MustNewKey(queueDataTypeKey), Value: ""}), int64(2), "exporter/queue_expired_items")
+}
+
 type wrappedError struct {
 	error
 }
@@ -265,6 +342,68 @@ func TestQueuedRetry_ThrottleError(t *testing.T) {
 	require.Zero(t, be.qrSender.queue.Size())
 }
 
+func TestQueuedRetry_ThrottleErrorShorterThanBackoff(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = 200 * time.Millisecond
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The server asks to retry much sooner than the exponential backoff, which is kept.
+	retry := NewThrottleRetry(errors.New("throttle error"), time.Millisecond)
+	mockR := newMockRequest(context.Background(), 2, wrappedError{retry})
+	start := time.Now()
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		require.NoError(t, be.sender.send(mockR))
+	})
+	ocs.awaitAsyncProcessing()
+
+	// The randomized backoff is at least half of the initial interval.
+	assert.GreaterOrEqual(t, time.Since(start), rCfg.InitialInterval/2)
+	mockR.checkNumRequests(t, 2)
+	ocs.checkSendItemsCount(t, 2)
+	ocs.checkDroppedItemsCount(t, 0)
+	require.Zero(t, be.qrSender.queue.Size())
+}
+
+func TestQueuedRetry_ThrottleErrorBeyondMaxElapsedTime(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.MaxElapsedTime = 100 * time.Millisecond
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The data is dropped right away instead of waiting for a retry after max_elapsed_time.
+	retry := NewThrottleRetry(errors.New("throttle error"), time.Hour)
+	mockR := newMockRequest(context.Background(), 2, retry)
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		require.NoError(t, be.sender.send(mockR))
+	})
+	ocs.awaitAsyncProcessing()
+
+	mockR.checkNumRequests(t, 1)
+	ocs.checkSendItemsCount(t, 0)
+	ocs.checkDroppedItemsCount(t, 2)
+	require.Zero(t, be.qrSender.queue.Size())
+}
+
 func TestQueuedRetry_RetryOnError(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	qCfg.NumConsumers = 1
@@ -294,6 +433,60 @@ func TestQueuedRetry_RetryOnError(t *testing.T) {
 	require.Zero(t, be.qrSender.queue.Size())
 }
 
+func TestQueuedRetry_SyncRetryOnError(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.Enabled = false
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = 0
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	mockR := newMockRequest(context.Background(), 2, errors.New("transient error"))
+	ocs.run(func() {
+		// This is synchronous, the caller is blocked until the retry succeeds.
+		require.NoError(t, be.sender.send(mockR))
+	})
+	mockR.checkNumRequests(t, 2)
+	ocs.checkSendItemsCount(t, 2)
+	ocs.checkDroppedItemsCount(t, 0)
+}
+
+func TestQueuedRetry_SyncDeadlineBeforeRetry(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.Enabled = false
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Minute
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	mockR := newMockRequest(ctx, 2, errors.New("transient error"))
+	start := time.Now()
+	ocs.run(func() {
+		// The next retry would happen after the deadline, the error is returned right away.
+		err := be.sender.send(mockR)
+		assert.Error(t, err)
+		assert.False(t, consumererror.IsPermanent(err))
+	})
+	assert.Less(t, time.Since(start), 5*time.Second)
+	mockR.checkNumRequests(t, 1)
+	ocs.checkSendItemsCount(t, 0)
+	ocs.checkDroppedItemsCount(t, 2)
+}
+
 func TestQueuedRetry_DropOnFull(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	qCfg.QueueSize = 0
@@ -383,6 +576,18 @@ func TestNoCancellationContext(t *testing.T) {
 	assert.True(t, d.IsZero())
 }
 
+func TestQueueSettings_SendingQueueBudget(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	queueSize, numConsumers := qCfg.SendingQueueBudget()
+	assert.Equal(t, 5000, queueSize)
+	assert.Equal(t, 10, numConsumers)
+
+	qCfg.Enabled = false
+	queueSize, numConsumers = qCfg.SendingQueueBudget()
+	assert.Zero(t, queueSize)
+	assert.Zero(t, numConsumers)
+}
+
 func TestQueueSettings_Validate(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	assert.NoError(t, qCfg.Validate())
@@ -390,11 +595,63 @@ func TestQueueSettings_Validate(t *testing.T) {
 	qCfg.QueueSize = 0
 	assert.EqualError(t, qCfg.Validate(), "queue size must be positive")
 
+	qCfg.QueueSize = 1
+	qCfg.MaxItemAge = -time.Second
+	assert.EqualError(t, qCfg.Validate(), "max item age must not be negative")
+
+	qCfg.MaxItemAge = 0
+	qCfg.BackpressureWatermark = 1.5
+	assert.EqualError(t, qCfg.Validate(), "backpressure watermark must be between 0 and 1")
+
+	qCfg.BackpressureWatermark = 0
+	storageID := component.NewID("file_storage")
+	qCfg.StorageID = &storageID
+	qCfg.StorageDirectory = t.TempDir()
+	assert.EqualError(t, qCfg.Validate(), "storage and storage_directory cannot be used together")
+
+	qCfg.StorageID = nil
+	qCfg.PartitionBy = []string{"k8s.namespace.name"}
+	assert.EqualError(t, qCfg.Validate(), "partition_by cannot be used with the persistent queue")
+
+	qCfg.StorageDirectory = ""
+	qCfg.PartitionQueueSize = 2
+	assert.EqualError(t, qCfg.Validate(), "partition queue size must be between 0 and the queue size")
+
 	// Confirm Validate doesn't return error with invalid config when feature is disabled
 	qCfg.Enabled = false
 	assert.NoError(t, qCfg.Validate())
 }
 
+func TestQueuedRetry_PartitionedQueue(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to make every request go straight to the queue
+	qCfg.QueueSize = 10
+	qCfg.PartitionQueueSize = 2
+	qCfg.PartitionBy = []string{"metadata.tenant"}
+	rCfg := NewDefaultRetrySettings()
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	tenantCtx := func(tenant string) context.Context {
+		return client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"tenant": {tenant}}),
+		})
+	}
+	require.NoError(t, be.sender.send(newErrorRequest(tenantCtx("noisy"))))
+	require.NoError(t, be.sender.send(newErrorRequest(tenantCtx("noisy"))))
+	assert.ErrorIs(t, be.sender.send(newErrorRequest(tenantCtx("noisy"))), errSendingQueueIsFull)
+	// Other tenants are not affected by the full partition.
+	require.NoError(t, be.sender.send(newErrorRequest(tenantCtx("quiet"))))
+
+	checkValueForGlobalManager(t, defaultExporterTags, int64(3), "exporter/queue_size")
+	checkValueForGlobalManager(t, defaultExporterTags, int64(2), "exporter/queue_partitions")
+	checkValueForGlobalManager(t, append(defaultExporterTags, tag.Tag{Key: tag.MustNewKey(queuePartitionKey), Value: "noisy"}), int64(1), "exporter/queue_partition_rejected")
+}
+
 func TestGetRetrySettings(t *testing.T) {
 	getStorageClientError := errors.New("unable to create storage client")
 	testCases := []struct {
@@ -580,6 +837,34 @@ func TestQueuedRetryPersistenceEnabledStorageError(t *testing.T) {
 	require.Error(t, be.Start(context.Background(), host), "could not get storage client")
 }
 
+func TestQueuedRetryPersistenceStorageDirectory(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to keep the requests in the queue
+	qCfg.StorageDirectory = t.TempDir()
+	rCfg := NewDefaultRetrySettings()
+	be, err := newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), component.DataTypeTraces, nopRequestUnmarshaler())
+	require.NoError(t, err)
+
+	// no storage extension is needed
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, be.sender.send(newErrorRequest(context.Background())))
+	}
+	assert.Equal(t, 3, be.QueueSize())
+	storageTags := append(defaultExporterTags, tag.Tag{Key: tag.MustNewKey(queueDataTypeKey), Value: "traces"})
+	checkValueForGlobalManager(t, storageTags, be.qrSender.fileStorage.Size(), "exporter/queue_storage_size")
+	checkValueForGlobalManager(t, storageTags, int64(0), "exporter/queue_compactions")
+	checkValueForGlobalManager(t, storageTags, int64(0), "exporter/queue_compaction_failures")
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	// the requests are still queued after a restart
+	be, err = newBaseExporter(defaultSettings, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), component.DataTypeTraces, nopRequestUnmarshaler())
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, 3, be.QueueSize())
+	require.NoError(t, be.Shutdown(context.Background()))
+}
+
 type mockErrorRequest struct {
 	baseRequest
 }
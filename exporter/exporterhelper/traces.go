@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -60,7 +61,9 @@ func (req *tracesRequest) Marshal() ([]byte, error) {
 func (req *tracesRequest) OnError(err error) internal.Request {
 	var traceError consumererror.Traces
 	if errors.As(err, &traceError) {
-		return newTracesRequest(req.ctx, traceError.GetTraces(), req.pusher)
+		partial := newTracesRequest(req.ctx, traceError.GetTraces(), req.pusher)
+		partial.SetEnqueuedAt(req.enqueuedAt)
+		return partial
 	}
 	return req
 }
@@ -73,6 +76,16 @@ func (req *tracesRequest) Count() int {
 	return req.td.SpanCount()
 }
 
+func (req *tracesRequest) resourceAttribute(key string) (pcommon.Value, bool) {
+	rs := req.td.ResourceSpans()
+	for i := 0; i < rs.Len(); i++ {
+		if v, ok := rs.At(i).Resource().Attributes().Get(key); ok {
+			return v, true
+		}
+	}
+	return pcommon.Value{}, false
+}
+
 type traceExporter struct {
 	*baseExporter
 	consumer.Traces
@@ -105,8 +118,9 @@ func NewTracesExporter(
 	}
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &tracesExporterWithObservability{
-			obsrep:     be.obsrep,
-			nextSender: nextSender,
+			obsrep:      be.obsrep,
+			nextSender:  nextSender,
+			recordBytes: bs.recordBytes,
 		}
 	})
 
@@ -126,14 +140,20 @@ func NewTracesExporter(
 }
 
 type tracesExporterWithObservability struct {
-	obsrep     *obsExporter
-	nextSender requestSender
+	obsrep      *obsExporter
+	nextSender  requestSender
+	recordBytes bool
 }
 
 func (tewo *tracesExporterWithObservability) send(req internal.Request) error {
 	req.SetContext(tewo.obsrep.StartTracesOp(req.Context()))
+	setPayloadAge(req)
 	// Forward the data to the next consumer (this pusher is the next).
 	err := tewo.nextSender.send(req)
+	if tewo.recordBytes {
+		tewo.obsrep.EndTracesOpWithBytes(req.Context(), req.Count(), int64(tracesMarshaler.TracesSize(req.(*tracesRequest).td)), err)
+		return err
+	}
 	tewo.obsrep.EndTracesOp(req.Context(), req.Count(), err)
 	return err
 }
@@ -115,6 +115,29 @@ func TestTracesExporter_WithRecordMetrics(t *testing.T) {
 	checkRecordedMetricsForTracesExporter(t, tt, te, nil)
 }
 
+func TestTracesExporter_WithRequestBytes(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetryWithID(fakeTracesExporterName)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	want := errors.New("my_error")
+	failed := false
+	exp, err := NewTracesExporter(context.Background(), tt.ToExporterCreateSettings(), &fakeTracesExporterConfig, func(context.Context, ptrace.Traces) error {
+		if failed {
+			return want
+		}
+		return nil
+	}, WithRequestBytes())
+	require.NoError(t, err)
+
+	td := testdata.GenerateTraces(2)
+	size := int64(tracesMarshaler.TracesSize(td))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	failed = true
+	require.Equal(t, want, exp.ConsumeTraces(context.Background(), td))
+	require.NoError(t, obsreporttest.CheckExporterBytes(tt, fakeTracesExporterName, size, size))
+}
+
 func TestTracesExporter_WithRecordMetrics_ReturnError(t *testing.T) {
 	want := errors.New("my_error")
 	tt, err := obsreporttest.SetupTelemetryWithID(fakeTracesExporterName)
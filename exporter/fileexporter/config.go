@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "go.opentelemetry.io/collector/exporter/fileexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	// formatJSON writes one OTLP JSON encoded request per line.
+	formatJSON = "json"
+	// formatProto writes OTLP protobuf encoded requests, each prefixed by its length.
+	formatProto = "proto"
+
+	compressionGzip = "gzip"
+)
+
+// Config defines configuration for file exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Path of the file to write to.
+	Path string `mapstructure:"path"`
+
+	// Format of the exported data, either "json" (the default) or "proto".
+	Format string `mapstructure:"format"`
+
+	// Compression of the written file. Only "gzip" is supported, no compression is applied by default.
+	Compression string `mapstructure:"compression"`
+
+	// FlushInterval is the interval at which the buffered data is flushed to the file.
+	// If zero, the data is flushed after every export.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+
+	// Rotation configures the rotation of the file, if nil the file is never rotated.
+	Rotation *Rotation `mapstructure:"rotation"`
+}
+
+// Rotation defines when the file is rotated and how many rotated files are kept.
+type Rotation struct {
+	// MaxMegabytes is the maximum size in megabytes of the data written to a file, before compression.
+	// If zero, the file is not rotated based on its size.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// MaxInterval is the maximum duration a file is written to. If zero, the file is not rotated based on time.
+	MaxInterval time.Duration `mapstructure:"max_interval"`
+
+	// MaxBackups is the maximum number of rotated files to keep. If zero, all rotated files are kept.
+	MaxBackups int `mapstructure:"max_backups"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be non-empty")
+	}
+	if cfg.Format != formatJSON && cfg.Format != formatProto {
+		return fmt.Errorf("format %q is not supported, must be one of %q or %q", cfg.Format, formatJSON, formatProto)
+	}
+	if cfg.Compression != "" && cfg.Compression != compressionGzip {
+		return fmt.Errorf("compression %q is not supported, must be %q", cfg.Compression, compressionGzip)
+	}
+	if cfg.FlushInterval < 0 {
+		return errors.New("flush_interval must not be negative")
+	}
+	if cfg.Rotation != nil {
+		if cfg.Rotation.MaxMegabytes < 0 {
+			return errors.New("rotation max_megabytes must not be negative")
+		}
+		if cfg.Rotation.MaxInterval < 0 {
+			return errors.New("rotation max_interval must not be negative")
+		}
+		if cfg.Rotation.MaxBackups < 0 {
+			return errors.New("rotation max_backups must not be negative")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+			Path:             "./traces.pb.gz",
+			Format:           formatProto,
+			Compression:      compressionGzip,
+			FlushInterval:    5 * time.Second,
+			Rotation: &Rotation{
+				MaxMegabytes: 10,
+				MaxInterval:  time.Hour,
+				MaxBackups:   3,
+			},
+		}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr string
+	}{
+		{
+			name:        "no_path",
+			modify:      func(cfg *Config) { cfg.Path = "" },
+			expectedErr: "path must be non-empty",
+		},
+		{
+			name:        "invalid_format",
+			modify:      func(cfg *Config) { cfg.Format = "yaml" },
+			expectedErr: `format "yaml" is not supported, must be one of "json" or "proto"`,
+		},
+		{
+			name:        "invalid_compression",
+			modify:      func(cfg *Config) { cfg.Compression = "zstd" },
+			expectedErr: `compression "zstd" is not supported, must be "gzip"`,
+		},
+		{
+			name:        "negative_flush_interval",
+			modify:      func(cfg *Config) { cfg.FlushInterval = -time.Second },
+			expectedErr: "flush_interval must not be negative",
+		},
+		{
+			name:        "negative_max_megabytes",
+			modify:      func(cfg *Config) { cfg.Rotation = &Rotation{MaxMegabytes: -1} },
+			expectedErr: "rotation max_megabytes must not be negative",
+		},
+		{
+			name:        "negative_max_interval",
+			modify:      func(cfg *Config) { cfg.Rotation = &Rotation{MaxInterval: -time.Second} },
+			expectedErr: "rotation max_interval must not be negative",
+		},
+		{
+			name:        "negative_max_backups",
+			modify:      func(cfg *Config) { cfg.Rotation = &Rotation{MaxBackups: -1} },
+			expectedErr: "rotation max_backups must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Path = "file.json"
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "go.opentelemetry.io/collector/exporter/fileexporter"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/sharedcomponent"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr              = "file"
+	defaultFlushInterval = time.Second
+)
+
+// NewFactory creates a factory for File exporter.
+func NewFactory() component.ExporterFactory {
+	return component.NewExporterFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithTracesExporter(component.NewCreateTracesExporterFunc(createTracesExporter), component.StabilityLevelDevelopment),
+		component.WithMetricsExporter(component.NewCreateMetricsExporterFunc(createMetricsExporter), component.StabilityLevelDevelopment),
+		component.WithLogsExporter(component.NewCreateLogsExporterFunc(createLogsExporter), component.StabilityLevelDevelopment),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+		Format:           formatJSON,
+		FlushInterval:    defaultFlushInterval,
+	}
+}
+
+func createTracesExporter(ctx context.Context, set component.ExporterCreateSettings, cfg *Config) (component.TracesExporter, error) {
+	fe := getOrCreateFileExporter(cfg, set)
+	return exporterhelper.NewTracesExporter(ctx, set, cfg,
+		fe.Unwrap().(*fileExporter).consumeTraces,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		// Disable Timeout/RetryOnFailure and SendingQueue
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(exporterhelper.RetrySettings{Enabled: false}),
+		exporterhelper.WithQueue(exporterhelper.QueueSettings{Enabled: false}),
+		exporterhelper.WithStart(fe.Start),
+		exporterhelper.WithShutdown(fe.Shutdown),
+	)
+}
+
+func createMetricsExporter(ctx context.Context, set component.ExporterCreateSettings, cfg *Config) (component.MetricsExporter, error) {
+	fe := getOrCreateFileExporter(cfg, set)
+	return exporterhelper.NewMetricsExporter(ctx, set, cfg,
+		fe.Unwrap().(*fileExporter).consumeMetrics,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		// Disable Timeout/RetryOnFailure and SendingQueue
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(exporterhelper.RetrySettings{Enabled: false}),
+		exporterhelper.WithQueue(exporterhelper.QueueSettings{Enabled: false}),
+		exporterhelper.WithStart(fe.Start),
+		exporterhelper.WithShutdown(fe.Shutdown),
+	)
+}
+
+func createLogsExporter(ctx context.Context, set component.ExporterCreateSettings, cfg *Config) (component.LogsExporter, error) {
+	fe := getOrCreateFileExporter(cfg, set)
+	return exporterhelper.NewLogsExporter(ctx, set, cfg,
+		fe.Unwrap().(*fileExporter).consumeLogs,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		// Disable Timeout/RetryOnFailure and SendingQueue
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(exporterhelper.RetrySettings{Enabled: false}),
+		exporterhelper.WithQueue(exporterhelper.QueueSettings{Enabled: false}),
+		exporterhelper.WithStart(fe.Start),
+		exporterhelper.WithShutdown(fe.Shutdown),
+	)
+}
+
+// getOrCreateFileExporter returns the exporter shared by all the signals configured with cfg,
+// so that a single file is written even if the exporter is used in pipelines of several data types.
+func getOrCreateFileExporter(cfg *Config, set component.ExporterCreateSettings) *sharedcomponent.SharedComponent {
+	return exporters.GetOrAdd(cfg, func() component.Component {
+		return newFileExporter(cfg, set.Logger)
+	})
+}
+
+// This is the map of already created file exporters for particular configurations.
+// We maintain this map because the Factory is asked trace, metric and log exporters separately
+// when it gets CreateTracesExporter(), CreateMetricsExporter() and CreateLogsExporter() but
+// they must not create separate objects writing to the same file.
+var exporters = sharedcomponent.NewSharedComponents()
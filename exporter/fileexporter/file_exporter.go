@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "go.opentelemetry.io/collector/exporter/fileexporter"
+
+import (
+	"context"
+	"encoding/binary"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// The data type of a protobuf record is identified by the first byte of its frame.
+const (
+	recordTypeTraces  byte = 1
+	recordTypeMetrics byte = 2
+	recordTypeLogs    byte = 3
+)
+
+// fileExporter writes the exported data to a file, it is shared by the exporters of all signals
+// created with the same configuration.
+type fileExporter struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	tracesMarshaler  ptrace.Marshaler
+	metricsMarshaler pmetric.Marshaler
+	logsMarshaler    plog.Marshaler
+
+	writer *fileWriter
+}
+
+func newFileExporter(cfg *Config, logger *zap.Logger) *fileExporter {
+	fe := &fileExporter{
+		cfg:    cfg,
+		logger: logger,
+	}
+	if cfg.Format == formatProto {
+		fe.tracesMarshaler = &ptrace.ProtoMarshaler{}
+		fe.metricsMarshaler = &pmetric.ProtoMarshaler{}
+		fe.logsMarshaler = &plog.ProtoMarshaler{}
+	} else {
+		fe.tracesMarshaler = &ptrace.JSONMarshaler{}
+		fe.metricsMarshaler = &pmetric.JSONMarshaler{}
+		fe.logsMarshaler = &plog.JSONMarshaler{}
+	}
+	return fe
+}
+
+// Start opens the file and starts the periodic flush.
+func (fe *fileExporter) Start(context.Context, component.Host) error {
+	w, err := newFileWriter(fe.cfg, fe.logger)
+	if err != nil {
+		return err
+	}
+	fe.writer = w
+	return nil
+}
+
+// Shutdown flushes the buffered data and closes the file.
+func (fe *fileExporter) Shutdown(context.Context) error {
+	if fe.writer == nil {
+		return nil
+	}
+	return fe.writer.close()
+}
+
+func (fe *fileExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	buf, err := fe.tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+	return fe.writer.write(fe.frame(recordTypeTraces, buf))
+}
+
+func (fe *fileExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	buf, err := fe.metricsMarshaler.MarshalMetrics(md)
+	if err != nil {
+		return err
+	}
+	return fe.writer.write(fe.frame(recordTypeMetrics, buf))
+}
+
+func (fe *fileExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	buf, err := fe.logsMarshaler.MarshalLogs(ld)
+	if err != nil {
+		return err
+	}
+	return fe.writer.write(fe.frame(recordTypeLogs, buf))
+}
+
+// frame delimits a marshaled request: JSON requests are written one per line, protobuf
+// requests are prefixed by their record type as a single byte and their length as a 4 bytes
+// big-endian unsigned integer.
+func (fe *fileExporter) frame(recordType byte, buf []byte) []byte {
+	if fe.cfg.Format == formatProto {
+		framed := make([]byte, 5, 5+len(buf))
+		framed[0] = recordType
+		binary.BigEndian.PutUint32(framed[1:], uint32(len(buf)))
+		return append(framed, buf...)
+	}
+	return append(buf, '\n')
+}
@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestFileExporter(t *testing.T, cfg *Config) *fileExporter {
+	fe := newFileExporter(cfg, zap.NewNop())
+	require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	return fe
+}
+
+func newTestConfig(t *testing.T, name string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = filepath.Join(t.TempDir(), name)
+	cfg.FlushInterval = 0
+	return cfg
+}
+
+func TestFileExporterJSON(t *testing.T) {
+	cfg := newTestConfig(t, "data.json")
+	fe := newTestFileExporter(t, cfg)
+
+	td := testdata.GenerateTraces(2)
+	md := testdata.GenerateMetrics(2)
+	ld := testdata.GenerateLogs(2)
+	require.NoError(t, fe.consumeTraces(context.Background(), td))
+	require.NoError(t, fe.consumeMetrics(context.Background(), md))
+	require.NoError(t, fe.consumeLogs(context.Background(), ld))
+	require.NoError(t, fe.Shutdown(context.Background()))
+
+	f, err := os.Open(cfg.Path)
+	require.NoError(t, err)
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+
+	require.True(t, scanner.Scan())
+	expected, err := (&ptrace.JSONMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	assert.Equal(t, expected, scanner.Bytes())
+
+	require.True(t, scanner.Scan())
+	expected, err = (&pmetric.JSONMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	assert.Equal(t, expected, scanner.Bytes())
+
+	require.True(t, scanner.Scan())
+	expected, err = (&plog.JSONMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, expected, scanner.Bytes())
+
+	assert.False(t, scanner.Scan())
+}
+
+func TestFileExporterProtoGzip(t *testing.T) {
+	cfg := newTestConfig(t, "data.pb.gz")
+	cfg.Format = formatProto
+	cfg.Compression = compressionGzip
+	fe := newTestFileExporter(t, cfg)
+
+	td := testdata.GenerateTraces(2)
+	require.NoError(t, fe.consumeTraces(context.Background(), td))
+	require.NoError(t, fe.consumeTraces(context.Background(), td))
+	require.NoError(t, fe.Shutdown(context.Background()))
+
+	f, err := os.Open(cfg.Path)
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	expected, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		recordType, record := readFrame(t, r)
+		assert.Equal(t, recordTypeTraces, recordType)
+		assert.Equal(t, expected, record)
+	}
+	_, err = r.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func readFrame(t *testing.T, r io.Reader) (byte, []byte) {
+	var header struct {
+		RecordType byte
+		Size       uint32
+	}
+	require.NoError(t, binary.Read(r, binary.BigEndian, &header))
+	buf := make([]byte, header.Size)
+	_, err := io.ReadFull(r, buf)
+	require.NoError(t, err)
+	return header.RecordType, buf
+}
+
+func TestFileExporterProtoRecordTypes(t *testing.T) {
+	cfg := newTestConfig(t, "data.pb")
+	cfg.Format = formatProto
+	fe := newTestFileExporter(t, cfg)
+
+	require.NoError(t, fe.consumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, fe.consumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	require.NoError(t, fe.consumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	require.NoError(t, fe.Shutdown(context.Background()))
+
+	f, err := os.Open(cfg.Path)
+	require.NoError(t, err)
+	defer f.Close()
+	for _, expected := range []byte{recordTypeTraces, recordTypeMetrics, recordTypeLogs} {
+		recordType, _ := readFrame(t, f)
+		assert.Equal(t, expected, recordType)
+	}
+}
+
+func TestFileExporterAppends(t *testing.T) {
+	cfg := newTestConfig(t, "data.json")
+	td := testdata.GenerateTraces(1)
+	for i := 0; i < 2; i++ {
+		fe := newTestFileExporter(t, cfg)
+		require.NoError(t, fe.consumeTraces(context.Background(), td))
+		require.NoError(t, fe.Shutdown(context.Background()))
+	}
+
+	data, err := os.ReadFile(cfg.Path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, bytes.Count(data, []byte{'\n'}))
+}
+
+func TestFileExporterFlushInterval(t *testing.T) {
+	cfg := newTestConfig(t, "data.json")
+	cfg.FlushInterval = 10 * time.Millisecond
+	fe := newTestFileExporter(t, cfg)
+	t.Cleanup(func() { require.NoError(t, fe.Shutdown(context.Background())) })
+
+	require.NoError(t, fe.consumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Eventually(t, func() bool {
+		fi, err := os.Stat(cfg.Path)
+		return err == nil && fi.Size() > 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestFileWriterRotateSize(t *testing.T) {
+	cfg := newTestConfig(t, "data.json")
+	cfg.Rotation = &Rotation{MaxMegabytes: 1, MaxBackups: 2}
+	w, err := newFileWriter(cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	now := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	w.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	chunk := bytes.Repeat([]byte{'a'}, 600*1024)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, w.write(chunk))
+	}
+	require.NoError(t, w.close())
+
+	// Every chunk is written to its own file, and only the 2 latest rotated files are kept.
+	// The clock advances on every rename and every open of the file.
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(cfg.Path), "*"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		cfg.Path,
+		filepath.Join(filepath.Dir(cfg.Path), "data-2022-11-01T00-00-05.000.json"),
+		filepath.Join(filepath.Dir(cfg.Path), "data-2022-11-01T00-00-07.000.json"),
+	}, files)
+	for _, file := range files {
+		fi, err := os.Stat(file)
+		require.NoError(t, err)
+		assert.EqualValues(t, len(chunk), fi.Size())
+	}
+}
+
+func TestFileWriterRotateSameTime(t *testing.T) {
+	cfg := newTestConfig(t, "data.json")
+	cfg.Rotation = &Rotation{MaxMegabytes: 1, MaxBackups: 2}
+	w, err := newFileWriter(cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	// All the files are rotated within the same millisecond.
+	now := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	w.now = func() time.Time { return now }
+
+	chunk := bytes.Repeat([]byte{'a'}, 600*1024)
+	for i := 0; i < 4; i++ {
+		require.NoError(t, w.write(append(chunk, byte('0'+i))))
+	}
+	require.NoError(t, w.close())
+
+	// No rotated file is overwritten, and the 2 latest ones are kept.
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(cfg.Path), "*"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		cfg.Path,
+		filepath.Join(filepath.Dir(cfg.Path), "data-2022-11-01T00-00-00.000-1.json"),
+		filepath.Join(filepath.Dir(cfg.Path), "data-2022-11-01T00-00-00.000-2.json"),
+	}, files)
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(cfg.Path), "data-2022-11-01T00-00-00.000-2.json"))
+	require.NoError(t, err)
+	assert.Equal(t, byte('2'), data[len(data)-1])
+}
+
+func TestFileWriterRotateInterval(t *testing.T) {
+	cfg := newTestConfig(t, "data.json")
+	cfg.Rotation = &Rotation{MaxInterval: time.Minute}
+	w, err := newFileWriter(cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	now := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	w.now = func() time.Time { return now }
+	w.openedAt = now
+
+	require.NoError(t, w.write([]byte("first\n")))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, w.write([]byte("second\n")))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, w.write([]byte("third\n")))
+	require.NoError(t, w.close())
+
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(cfg.Path), "data-2022-11-01T00-01-00.000.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond\n", string(data))
+	data, err = os.ReadFile(cfg.Path)
+	require.NoError(t, err)
+	assert.Equal(t, "third\n", string(data))
+}
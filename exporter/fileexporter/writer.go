@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "go.opentelemetry.io/collector/exporter/fileexporter"
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// backupTimeFormat is the format of the timestamp appended to the name of the rotated files,
+// it sorts lexicographically in chronological order. Files rotated within the same millisecond
+// are told apart by a sequence number appended to the timestamp, e.g. "-1".
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// fileWriter writes to a file, buffering the writes and rotating the file as configured.
+type fileWriter struct {
+	path          string
+	compress      bool
+	rotation      *Rotation
+	flushInterval time.Duration
+	logger        *zap.Logger
+	now           func() time.Time
+
+	mu       sync.Mutex
+	file     *os.File
+	buf      *bufio.Writer
+	gz       *gzip.Writer
+	size     int64
+	openedAt time.Time
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newFileWriter(cfg *Config, logger *zap.Logger) (*fileWriter, error) {
+	w := &fileWriter{
+		path:          cfg.Path,
+		compress:      cfg.Compression == compressionGzip,
+		rotation:      cfg.Rotation,
+		flushInterval: cfg.FlushInterval,
+		logger:        logger,
+		now:           time.Now,
+		stopCh:        make(chan struct{}),
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	if w.flushInterval > 0 {
+		w.wg.Add(1)
+		go w.flushLoop()
+	}
+	return w, nil
+}
+
+// open opens the file for appending, creating it if needed.
+func (w *fileWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open file %q: %w", w.path, err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return multierr.Append(fmt.Errorf("failed to stat file %q: %w", w.path, err), f.Close())
+	}
+
+	w.file = f
+	w.buf = bufio.NewWriter(f)
+	w.gz = nil
+	if w.compress {
+		// Appending to an existing file adds a new gzip member, which readers concatenate.
+		w.gz = gzip.NewWriter(w.buf)
+	}
+	w.size = fi.Size()
+	w.openedAt = w.now()
+	return nil
+}
+
+func (w *fileWriter) writer() io.Writer {
+	if w.gz != nil {
+		return w.gz
+	}
+	return w.buf
+}
+
+// write writes b to the file, rotating it first if needed.
+func (w *fileWriter) write(b []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.shouldRotate(len(b)) {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+	if _, err := w.writer().Write(b); err != nil {
+		return err
+	}
+	w.size += int64(len(b))
+	if w.flushInterval == 0 {
+		return w.flush()
+	}
+	return nil
+}
+
+// shouldRotate returns true if writing n more bytes requires to rotate the file first.
+func (w *fileWriter) shouldRotate(n int) bool {
+	if w.rotation == nil || w.size == 0 {
+		return false
+	}
+	if w.rotation.MaxMegabytes > 0 && w.size+int64(n) > int64(w.rotation.MaxMegabytes)*1024*1024 {
+		return true
+	}
+	return w.rotation.MaxInterval > 0 && w.now().Sub(w.openedAt) >= w.rotation.MaxInterval
+}
+
+// rotate closes the current file, renames it with the current time as suffix and opens a new file.
+func (w *fileWriter) rotate() error {
+	if err := w.closeFile(); err != nil {
+		return err
+	}
+	ext := filepath.Ext(w.path)
+	prefix := strings.TrimSuffix(w.path, ext) + "-"
+	backup, err := backupName(prefix, w.now().UTC().Format(backupTimeFormat), ext)
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(w.path, backup); err != nil {
+		return fmt.Errorf("failed to rotate file %q: %w", w.path, err)
+	}
+	if err := w.open(); err != nil {
+		return err
+	}
+	w.removeOldBackups(prefix, ext)
+	return nil
+}
+
+// backupName returns the name of a rotated file that does not exist yet, appending a sequence
+// number to the timestamp if files were already rotated at the same time.
+func backupName(prefix, timestamp, ext string) (string, error) {
+	name := prefix + timestamp + ext
+	for seq := 1; ; seq++ {
+		_, err := os.Lstat(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			return name, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to check rotated file %q: %w", name, err)
+		}
+		name = prefix + timestamp + "-" + strconv.Itoa(seq) + ext
+	}
+}
+
+// backup is a rotated file, ordered by its timestamp then its sequence number.
+type backup struct {
+	name      string
+	timestamp time.Time
+	seq       int
+}
+
+// parseBackup returns the rotated file with the given name, or false if the name is not the
+// name of a rotated file.
+func parseBackup(name, prefix, ext string) (backup, bool) {
+	suffix := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+	seq := 0
+	if len(suffix) > len(backupTimeFormat) {
+		var err error
+		if suffix[len(backupTimeFormat)] != '-' {
+			return backup{}, false
+		}
+		if seq, err = strconv.Atoi(suffix[len(backupTimeFormat)+1:]); err != nil || seq < 1 {
+			return backup{}, false
+		}
+		suffix = suffix[:len(backupTimeFormat)]
+	}
+	timestamp, err := time.Parse(backupTimeFormat, suffix)
+	if err != nil {
+		return backup{}, false
+	}
+	return backup{name: name, timestamp: timestamp, seq: seq}, true
+}
+
+// removeOldBackups removes the oldest rotated files, keeping at most MaxBackups of them.
+func (w *fileWriter) removeOldBackups(prefix, ext string) {
+	if w.rotation.MaxBackups == 0 {
+		return
+	}
+	backups, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		w.logger.Warn("Failed to list rotated files", zap.Error(err))
+		return
+	}
+	var rotated []backup
+	for _, name := range backups {
+		if b, ok := parseBackup(name, prefix, ext); ok {
+			rotated = append(rotated, b)
+		}
+	}
+	if len(rotated) <= w.rotation.MaxBackups {
+		return
+	}
+	sort.Slice(rotated, func(i, j int) bool {
+		if !rotated[i].timestamp.Equal(rotated[j].timestamp) {
+			return rotated[i].timestamp.Before(rotated[j].timestamp)
+		}
+		return rotated[i].seq < rotated[j].seq
+	})
+	for _, b := range rotated[:len(rotated)-w.rotation.MaxBackups] {
+		if err = os.Remove(b.name); err != nil {
+			w.logger.Warn("Failed to remove rotated file", zap.String("file", b.name), zap.Error(err))
+		}
+	}
+}
+
+// flush writes the buffered data to the file.
+func (w *fileWriter) flush() error {
+	if w.gz != nil {
+		if err := w.gz.Flush(); err != nil {
+			return err
+		}
+	}
+	return w.buf.Flush()
+}
+
+func (w *fileWriter) flushLoop() {
+	defer w.wg.Done()
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.mu.Lock()
+			if err := w.flush(); err != nil {
+				w.logger.Warn("Failed to flush file", zap.String("file", w.path), zap.Error(err))
+			}
+			w.mu.Unlock()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// closeFile flushes the buffered data and closes the current file.
+func (w *fileWriter) closeFile() error {
+	var err error
+	if w.gz != nil {
+		err = w.gz.Close()
+	}
+	err = multierr.Append(err, w.buf.Flush())
+	return multierr.Append(err, w.file.Close())
+}
+
+// close stops the periodic flush and closes the file.
+func (w *fileWriter) close() error {
+	close(w.stopCh)
+	w.wg.Wait()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closeFile()
+}
@@ -43,9 +43,9 @@ func NewFactory() component.ExporterFactory {
 	return component.NewExporterFactory(
 		typeStr,
 		createDefaultConfig,
-		component.WithTracesExporter(createTracesExporter, component.StabilityLevelDevelopment),
-		component.WithMetricsExporter(createMetricsExporter, component.StabilityLevelDevelopment),
-		component.WithLogsExporter(createLogsExporter, component.StabilityLevelDevelopment),
+		component.WithTracesExporter(component.NewCreateTracesExporterFunc(createTracesExporter), component.StabilityLevelDevelopment),
+		component.WithMetricsExporter(component.NewCreateMetricsExporterFunc(createMetricsExporter), component.StabilityLevelDevelopment),
+		component.WithLogsExporter(component.NewCreateLogsExporterFunc(createLogsExporter), component.StabilityLevelDevelopment),
 	)
 }
 
@@ -59,8 +59,7 @@ func createDefaultConfig() component.Config {
 	}
 }
 
-func createTracesExporter(ctx context.Context, set component.ExporterCreateSettings, config component.Config) (component.TracesExporter, error) {
-	cfg := config.(*Config)
+func createTracesExporter(ctx context.Context, set component.ExporterCreateSettings, cfg *Config) (component.TracesExporter, error) {
 	exporterLogger := createLogger(cfg, set.TelemetrySettings.Logger)
 	s := newLoggingExporter(exporterLogger, cfg.Verbosity)
 	return exporterhelper.NewTracesExporter(ctx, set, cfg,
@@ -74,8 +73,7 @@ func createTracesExporter(ctx context.Context, set component.ExporterCreateSetti
 	)
 }
 
-func createMetricsExporter(ctx context.Context, set component.ExporterCreateSettings, config component.Config) (component.MetricsExporter, error) {
-	cfg := config.(*Config)
+func createMetricsExporter(ctx context.Context, set component.ExporterCreateSettings, cfg *Config) (component.MetricsExporter, error) {
 	exporterLogger := createLogger(cfg, set.TelemetrySettings.Logger)
 	s := newLoggingExporter(exporterLogger, cfg.Verbosity)
 	return exporterhelper.NewMetricsExporter(ctx, set, cfg,
@@ -89,8 +87,7 @@ func createMetricsExporter(ctx context.Context, set component.ExporterCreateSett
 	)
 }
 
-func createLogsExporter(ctx context.Context, set component.ExporterCreateSettings, config component.Config) (component.LogsExporter, error) {
-	cfg := config.(*Config)
+func createLogsExporter(ctx context.Context, set component.ExporterCreateSettings, cfg *Config) (component.LogsExporter, error) {
 	exporterLogger := createLogger(cfg, set.TelemetrySettings.Logger)
 	s := newLoggingExporter(exporterLogger, cfg.Verbosity)
 	return exporterhelper.NewLogsExporter(ctx, set, cfg,
@@ -31,6 +31,13 @@ type Config struct {
 	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
 
 	configgrpc.GRPCClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// TracesEndpoint overrides Endpoint for the traces.
+	TracesEndpoint string `mapstructure:"traces_endpoint"`
+	// MetricsEndpoint overrides Endpoint for the metrics.
+	MetricsEndpoint string `mapstructure:"metrics_endpoint"`
+	// LogsEndpoint overrides Endpoint for the logs.
+	LogsEndpoint string `mapstructure:"logs_endpoint"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -40,6 +47,26 @@ func (cfg *Config) Validate() error {
 	if err := cfg.QueueSettings.Validate(); err != nil {
 		return fmt.Errorf("queue settings has invalid configuration: %w", err)
 	}
+	if _, _, err := parseHeaders(cfg.GRPCClientSettings.Headers); err != nil {
+		return fmt.Errorf("invalid headers: %w", err)
+	}
 
 	return nil
 }
+
+// endpoint returns the endpoint to which the data of the given type is sent.
+func (cfg *Config) endpoint(dataType component.DataType) string {
+	var endpoint string
+	switch dataType {
+	case component.DataTypeTraces:
+		endpoint = cfg.TracesEndpoint
+	case component.DataTypeMetrics:
+		endpoint = cfg.MetricsEndpoint
+	case component.DataTypeLogs:
+		endpoint = cfg.LogsEndpoint
+	}
+	if endpoint == "" {
+		return cfg.Endpoint
+	}
+	return endpoint
+}
@@ -87,3 +87,23 @@ func TestUnmarshalConfig(t *testing.T) {
 			},
 		}, cfg)
 }
+
+func TestValidateHeaderTemplates(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Headers = map[string]string{"X-Scope-OrgID": "${metadata:tenant}"}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Headers = map[string]string{"X-Scope-OrgID": "${tenant}"}
+	assert.EqualError(t, cfg.Validate(), `invalid headers: header "X-Scope-OrgID": placeholder "${tenant}" must have the format ${<source>:<name>}`)
+}
+
+func TestConfigEndpoint(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = "otelcol:4317"
+	cfg.TracesEndpoint = "traces:4317"
+	cfg.LogsEndpoint = "logs:4317"
+
+	assert.Equal(t, "traces:4317", cfg.endpoint(component.DataTypeTraces))
+	assert.Equal(t, "otelcol:4317", cfg.endpoint(component.DataTypeMetrics))
+	assert.Equal(t, "logs:4317", cfg.endpoint(component.DataTypeLogs))
+}
@@ -35,9 +35,9 @@ func NewFactory() component.ExporterFactory {
 	return component.NewExporterFactory(
 		typeStr,
 		createDefaultConfig,
-		component.WithTracesExporter(createTracesExporter, component.StabilityLevelStable),
-		component.WithMetricsExporter(createMetricsExporter, component.StabilityLevelStable),
-		component.WithLogsExporter(createLogsExporter, component.StabilityLevelBeta),
+		component.WithTracesExporter(component.NewCreateTracesExporterFunc(createTracesExporter), component.StabilityLevelStable),
+		component.WithMetricsExporter(component.NewCreateMetricsExporterFunc(createMetricsExporter), component.StabilityLevelStable),
+		component.WithLogsExporter(component.NewCreateLogsExporterFunc(createLogsExporter), component.StabilityLevelBeta),
 	)
 }
 
@@ -60,34 +60,34 @@ func createDefaultConfig() component.Config {
 func createTracesExporter(
 	ctx context.Context,
 	set component.ExporterCreateSettings,
-	cfg component.Config,
+	oCfg *Config,
 ) (component.TracesExporter, error) {
-	oce, err := newExporter(cfg, set)
+	oce, err := newExporter(oCfg, set, component.DataTypeTraces)
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
-	return exporterhelper.NewTracesExporter(ctx, set, cfg,
+	return exporterhelper.NewTracesExporter(ctx, set, oCfg,
 		oce.pushTraces,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
 		exporterhelper.WithQueue(oCfg.QueueSettings),
 		exporterhelper.WithStart(oce.start),
-		exporterhelper.WithShutdown(oce.shutdown))
+		exporterhelper.WithShutdown(oce.shutdown),
+		exporterhelper.WithConnectionWaiter(oce.waitForConnection),
+		exporterhelper.WithRequestBytes())
 }
 
 func createMetricsExporter(
 	ctx context.Context,
 	set component.ExporterCreateSettings,
-	cfg component.Config,
+	oCfg *Config,
 ) (component.MetricsExporter, error) {
-	oce, err := newExporter(cfg, set)
+	oce, err := newExporter(oCfg, set, component.DataTypeMetrics)
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
-	return exporterhelper.NewMetricsExporter(ctx, set, cfg,
+	return exporterhelper.NewMetricsExporter(ctx, set, oCfg,
 		oce.pushMetrics,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
@@ -95,20 +95,21 @@ func createMetricsExporter(
 		exporterhelper.WithQueue(oCfg.QueueSettings),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
+		exporterhelper.WithConnectionWaiter(oce.waitForConnection),
+		exporterhelper.WithRequestBytes(),
 	)
 }
 
 func createLogsExporter(
 	ctx context.Context,
 	set component.ExporterCreateSettings,
-	cfg component.Config,
+	oCfg *Config,
 ) (component.LogsExporter, error) {
-	oce, err := newExporter(cfg, set)
+	oce, err := newExporter(oCfg, set, component.DataTypeLogs)
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
-	return exporterhelper.NewLogsExporter(ctx, set, cfg,
+	return exporterhelper.NewLogsExporter(ctx, set, oCfg,
 		oce.pushLogs,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
@@ -116,5 +117,7 @@ func createLogsExporter(
 		exporterhelper.WithQueue(oCfg.QueueSettings),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
+		exporterhelper.WithConnectionWaiter(oce.waitForConnection),
+		exporterhelper.WithRequestBytes(),
 	)
 }
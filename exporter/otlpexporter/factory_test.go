@@ -75,6 +75,22 @@ func TestCreateTracesExporter(t *testing.T) {
 			},
 			mustFailOnCreate: true,
 		},
+		{
+			name: "TracesEndpoint",
+			config: Config{
+				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				TracesEndpoint:   endpoint,
+			},
+		},
+		{
+			name: "OtherSignalEndpoint",
+			config: Config{
+				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				MetricsEndpoint:  endpoint,
+				LogsEndpoint:     endpoint,
+			},
+			mustFailOnCreate: true,
+		},
 		{
 			name: "UseSecure",
 			config: Config{
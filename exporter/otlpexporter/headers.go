@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexporter"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// headerSourceMetadata renders the value of a metadata key of the incoming request.
+	headerSourceMetadata = "metadata"
+	// headerSourceAuth renders the value of an attribute of the authentication data of the incoming request.
+	headerSourceAuth = "auth"
+	// headerSourceResource renders the value of a resource attribute of the exported data.
+	headerSourceResource = "resource"
+)
+
+// headerTemplate is a header whose value is rendered for every request from placeholders such as
+// "${metadata:tenant}", "${auth:subject}" or "${resource:service.namespace}".
+type headerTemplate struct {
+	key   string
+	parts []templatePart
+}
+
+// templatePart is either a literal text, when source is empty, or a placeholder.
+type templatePart struct {
+	literal string
+	source  string
+	name    string
+}
+
+// parseHeaders splits the configured headers into the static ones and the templated ones.
+func parseHeaders(headers map[string]string) (metadata.MD, []headerTemplate, error) {
+	static := map[string]string{}
+	var templates []headerTemplate
+	for key, value := range headers {
+		if !strings.Contains(value, "${") {
+			static[key] = value
+			continue
+		}
+		parts, err := parseTemplate(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("header %q: %w", key, err)
+		}
+		templates = append(templates, headerTemplate{key: key, parts: parts})
+	}
+	return metadata.New(static), templates, nil
+}
+
+func parseTemplate(value string) ([]templatePart, error) {
+	var parts []templatePart
+	for value != "" {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			parts = append(parts, templatePart{literal: value})
+			break
+		}
+		if start > 0 {
+			parts = append(parts, templatePart{literal: value[:start]})
+		}
+		end := strings.Index(value[start:], "}")
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated placeholder in %q", value)
+		}
+		source, name, found := strings.Cut(value[start+2:start+end], ":")
+		if !found || name == "" {
+			return nil, fmt.Errorf("placeholder %q must have the format ${<source>:<name>}", value[start:start+end+1])
+		}
+		switch source {
+		case headerSourceMetadata, headerSourceAuth, headerSourceResource:
+		default:
+			return nil, fmt.Errorf("placeholder %q has unknown source %q, must be one of %q, %q or %q",
+				value[start:start+end+1], source, headerSourceMetadata, headerSourceAuth, headerSourceResource)
+		}
+		parts = append(parts, templatePart{source: source, name: name})
+		value = value[start+end+1:]
+	}
+	return parts, nil
+}
+
+// render returns the value of the header for a request, or false if none of its placeholders has a value.
+// The resourceAttribute function returns the value of a resource attribute of the exported data.
+func (ht headerTemplate) render(ctx context.Context, resourceAttribute func(string) string) (string, bool) {
+	info := client.FromContext(ctx)
+	var sb strings.Builder
+	rendered := false
+	for _, part := range ht.parts {
+		var value string
+		switch part.source {
+		case "":
+			sb.WriteString(part.literal)
+			continue
+		case headerSourceMetadata:
+			value = strings.Join(info.Metadata.Get(part.name), ",")
+		case headerSourceAuth:
+			if info.Auth != nil {
+				if attr := info.Auth.GetAttribute(part.name); attr != nil {
+					value = fmt.Sprint(attr)
+				}
+			}
+		case headerSourceResource:
+			value = resourceAttribute(part.name)
+		}
+		if value != "" {
+			rendered = true
+		}
+		sb.WriteString(value)
+	}
+	return sb.String(), rendered
+}
+
+// tracesResourceAttribute returns a function looking up a resource attribute in the first resource of td that has it.
+func tracesResourceAttribute(td ptrace.Traces) func(string) string {
+	return func(name string) string {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			if v, ok := rss.At(i).Resource().Attributes().Get(name); ok {
+				return v.AsString()
+			}
+		}
+		return ""
+	}
+}
+
+// metricsResourceAttribute returns a function looking up a resource attribute in the first resource of md that has it.
+func metricsResourceAttribute(md pmetric.Metrics) func(string) string {
+	return func(name string) string {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			if v, ok := rms.At(i).Resource().Attributes().Get(name); ok {
+				return v.AsString()
+			}
+		}
+		return ""
+	}
+}
+
+// logsResourceAttribute returns a function looking up a resource attribute in the first resource of ld that has it.
+func logsResourceAttribute(ld plog.Logs) func(string) string {
+	return func(name string) string {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			if v, ok := rls.At(i).Resource().Attributes().Get(name); ok {
+				return v.AsString()
+			}
+		}
+		return ""
+	}
+}
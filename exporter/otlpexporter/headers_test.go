@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type fakeAuthData map[string]interface{}
+
+func (a fakeAuthData) GetAttribute(name string) interface{} {
+	return a[name]
+}
+
+func (a fakeAuthData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestParseHeaders(t *testing.T) {
+	md, templates, err := parseHeaders(map[string]string{
+		"static":        "value",
+		"X-Scope-OrgID": "${metadata:tenant}",
+		"x-route":       "prefix-${auth:subject}-${resource:service.namespace}",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"value"}, md.Get("static"))
+	assert.Len(t, templates, 2)
+}
+
+func TestParseHeadersInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		err   string
+	}{
+		{
+			name:  "unterminated",
+			value: "${metadata:tenant",
+			err:   `header "h": unterminated placeholder in "${metadata:tenant"`,
+		},
+		{
+			name:  "no source",
+			value: "${tenant}",
+			err:   `header "h": placeholder "${tenant}" must have the format ${<source>:<name>}`,
+		},
+		{
+			name:  "unknown source",
+			value: "${env:TENANT}",
+			err:   `header "h": placeholder "${env:TENANT}" has unknown source "env", must be one of "metadata", "auth" or "resource"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseHeaders(map[string]string{"h": tt.value})
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestHeaderTemplateRender(t *testing.T) {
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"tenant": {"acme"}}),
+		Auth:     fakeAuthData{"subject": "user1"},
+	})
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("service.namespace", "shop")
+	resourceAttribute := tracesResourceAttribute(td)
+
+	tests := []struct {
+		name     string
+		value    string
+		ctx      context.Context
+		expected string
+		rendered bool
+	}{
+		{
+			name:     "metadata",
+			value:    "${metadata:tenant}",
+			ctx:      ctx,
+			expected: "acme",
+			rendered: true,
+		},
+		{
+			name:     "all sources",
+			value:    "${metadata:tenant}/${auth:subject}/${resource:service.namespace}",
+			ctx:      ctx,
+			expected: "acme/user1/shop",
+			rendered: true,
+		},
+		{
+			name:     "missing values",
+			value:    "tenant-${metadata:tenant}-${auth:subject}",
+			ctx:      context.Background(),
+			expected: "tenant--",
+			rendered: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := parseTemplate(tt.value)
+			require.NoError(t, err)
+			value, rendered := headerTemplate{key: "h", parts: parts}.render(tt.ctx, resourceAttribute)
+			assert.Equal(t, tt.expected, value)
+			assert.Equal(t, tt.rendered, rendered)
+		})
+	}
+}
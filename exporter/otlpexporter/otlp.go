@@ -24,6 +24,7 @@ import (
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
@@ -41,6 +42,8 @@ import (
 type exporter struct {
 	// Input configuration.
 	config *Config
+	// endpoint to which the data is sent, Endpoint or the override of the data type of the exporter.
+	endpoint string
 
 	// gRPC clients and connection.
 	traceExporter  ptraceotlp.GRPCClient
@@ -48,6 +51,7 @@ type exporter struct {
 	logExporter    plogotlp.GRPCClient
 	clientConn     *grpc.ClientConn
 	metadata       metadata.MD
+	headers        []headerTemplate
 	callOptions    []grpc.CallOption
 
 	settings component.TelemetrySettings
@@ -58,29 +62,32 @@ type exporter struct {
 
 // Crete new exporter and start it. The exporter will begin connecting but
 // this function may return before the connection is established.
-func newExporter(cfg component.Config, set component.ExporterCreateSettings) (*exporter, error) {
-	oCfg := cfg.(*Config)
-
-	if oCfg.Endpoint == "" {
+func newExporter(oCfg *Config, set component.ExporterCreateSettings, dataType component.DataType) (*exporter, error) {
+	endpoint := oCfg.endpoint(dataType)
+	if endpoint == "" {
 		return nil, errors.New("OTLP exporter config requires an Endpoint")
 	}
 
 	userAgent := fmt.Sprintf("%s/%s (%s/%s)",
 		set.BuildInfo.Description, set.BuildInfo.Version, runtime.GOOS, runtime.GOARCH)
 
-	return &exporter{config: oCfg, settings: set.TelemetrySettings, userAgent: userAgent}, nil
+	return &exporter{config: oCfg, endpoint: endpoint, settings: set.TelemetrySettings, userAgent: userAgent}, nil
 }
 
 // start actually creates the gRPC connection. The client construction is deferred till this point as this
 // is the only place we get hold of Extensions which are required to construct auth round tripper.
 func (e *exporter) start(ctx context.Context, host component.Host) (err error) {
-	if e.clientConn, err = e.config.GRPCClientSettings.ToClientConn(ctx, host, e.settings, grpc.WithUserAgent(e.userAgent)); err != nil {
+	clientSettings := e.config.GRPCClientSettings
+	clientSettings.Endpoint = e.endpoint
+	if e.clientConn, err = clientSettings.ToClientConn(ctx, host, e.settings, grpc.WithUserAgent(e.userAgent)); err != nil {
 		return err
 	}
 	e.traceExporter = ptraceotlp.NewGRPCClient(e.clientConn)
 	e.metricExporter = pmetricotlp.NewGRPCClient(e.clientConn)
 	e.logExporter = plogotlp.NewGRPCClient(e.clientConn)
-	e.metadata = metadata.New(e.config.GRPCClientSettings.Headers)
+	if e.metadata, e.headers, err = parseHeaders(e.config.GRPCClientSettings.Headers); err != nil {
+		return err
+	}
 	e.callOptions = []grpc.CallOption{
 		grpc.WaitForReady(e.config.GRPCClientSettings.WaitForReady),
 	}
@@ -95,27 +102,53 @@ func (e *exporter) shutdown(context.Context) error {
 	return nil
 }
 
+// waitForConnection blocks until the gRPC connection is ready, or until ctx is done.
+func (e *exporter) waitForConnection(ctx context.Context) error {
+	e.clientConn.Connect()
+	for {
+		state := e.clientConn.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+		if !e.clientConn.WaitForStateChange(ctx, state) {
+			return ctx.Err()
+		}
+	}
+}
+
 func (e *exporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 	req := ptraceotlp.NewExportRequestFromTraces(td)
-	_, err := e.traceExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	_, err := e.traceExporter.Export(e.enhanceContext(ctx, tracesResourceAttribute(td)), req, e.callOptions...)
 	return processError(err)
 }
 
 func (e *exporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
 	req := pmetricotlp.NewExportRequestFromMetrics(md)
-	_, err := e.metricExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	_, err := e.metricExporter.Export(e.enhanceContext(ctx, metricsResourceAttribute(md)), req, e.callOptions...)
 	return processError(err)
 }
 
 func (e *exporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 	req := plogotlp.NewExportRequestFromLogs(ld)
-	_, err := e.logExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	_, err := e.logExporter.Export(e.enhanceContext(ctx, logsResourceAttribute(ld)), req, e.callOptions...)
 	return processError(err)
 }
 
-func (e *exporter) enhanceContext(ctx context.Context) context.Context {
-	if e.metadata.Len() > 0 {
-		return metadata.NewOutgoingContext(ctx, e.metadata)
+// enhanceContext adds the configured headers to the outgoing context. The templated headers are rendered
+// from ctx and from the resource attributes returned by resourceAttribute, using the first resource that
+// has the attribute.
+func (e *exporter) enhanceContext(ctx context.Context, resourceAttribute func(string) string) context.Context {
+	md := e.metadata
+	if len(e.headers) > 0 {
+		md = e.metadata.Copy()
+		for _, h := range e.headers {
+			if value, ok := h.render(ctx, resourceAttribute); ok {
+				md.Set(h.key, value)
+			}
+		}
+	}
+	if md.Len() > 0 {
+		return metadata.NewOutgoingContext(ctx, md)
 	}
 	return ctx
 }
@@ -34,11 +34,14 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -276,6 +279,93 @@ func TestSendTraces(t *testing.T) {
 	require.Contains(t, md.Get("User-Agent")[0], "Collector/1.2.3test")
 }
 
+func TestSendTracesRecordsBytes(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	rcv, _ := otlpTracesReceiverOnGRPCServer(ln, false)
+	defer rcv.srv.GracefulStop()
+
+	id := component.NewID(typeStr)
+	tt, err := obsreporttest.SetupTelemetryWithID(id)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	cfg.QueueSettings.Enabled = false
+	exp, err := factory.CreateTracesExporter(context.Background(), tt.ToExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	td := testdata.GenerateTraces(2)
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	body, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+	require.NoError(t, err)
+	require.NoError(t, obsreporttest.CheckExporterBytes(tt, id, int64(len(body)), 0))
+}
+
+func TestSendTracesWithHeaderTemplates(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err, "Failed to find an available address to run the gRPC server: %v", err)
+	rcv, _ := otlpTracesReceiverOnGRPCServer(ln, false)
+	defer rcv.srv.GracefulStop()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+		Headers: map[string]string{
+			"header":        "header-value",
+			"X-Scope-OrgID": "${metadata:tenant}",
+			"x-namespace":   "${resource:service.namespace}",
+		},
+	}
+	exp, err := factory.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, exp)
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+	assert.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"tenant": {"acme"}}),
+	})
+	td := testdata.GenerateTraces(2)
+	td.ResourceSpans().At(0).Resource().Attributes().PutStr("service.namespace", "shop")
+	assert.NoError(t, exp.ConsumeTraces(ctx, td))
+	assert.Eventually(t, func() bool {
+		return rcv.requestCount.Load() > 0
+	}, 10*time.Second, 5*time.Millisecond)
+
+	md := rcv.getMetadata()
+	assert.Equal(t, []string{"header-value"}, md.Get("header"))
+	assert.Equal(t, []string{"acme"}, md.Get("X-Scope-OrgID"))
+	assert.Equal(t, []string{"shop"}, md.Get("x-namespace"))
+
+	// Headers without any value for the request are not sent.
+	assert.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.Eventually(t, func() bool {
+		return rcv.requestCount.Load() > 1
+	}, 10*time.Second, 5*time.Millisecond)
+	md = rcv.getMetadata()
+	assert.Equal(t, []string{"header-value"}, md.Get("header"))
+	assert.Empty(t, md.Get("X-Scope-OrgID"))
+	assert.Empty(t, md.Get("x-namespace"))
+}
+
 func TestSendTracesWhenEndpointHasHttpScheme(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -422,6 +512,76 @@ func TestSendMetrics(t *testing.T) {
 	require.Contains(t, mdata.Get("User-Agent")[0], "Collector/1.2.3test")
 }
 
+func TestSendMetricsToMetricsEndpoint(t *testing.T) {
+	// Start an OTLP-compatible receiver.
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err, "Failed to find an available address to run the gRPC server: %v", err)
+	rcv := otlpMetricsReceiverOnGRPCServer(ln)
+	// Also closes the connection.
+	defer rcv.srv.GracefulStop()
+
+	// Start an OTLP exporter pointing to the receiver only for the metrics.
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: testutil.GetAvailableLocalAddress(t),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	cfg.MetricsEndpoint = ln.Addr().String()
+	cfg.RetrySettings.Enabled = false
+	cfg.QueueSettings.Enabled = false
+	exp, err := factory.CreateMetricsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, exp)
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	assert.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := testdata.GenerateMetrics(2)
+	assert.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+
+	assert.EqualValues(t, 1, rcv.requestCount.Load())
+	assert.EqualValues(t, md, rcv.getLastRequest())
+}
+
+func TestWaitForConnection(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err, "Failed to find an available address to run the gRPC server: %v", err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	exp, err := factory.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	waiter, ok := exp.(component.ConnectionWaiter)
+	require.True(t, ok)
+
+	// The server is not started yet.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, waiter.WaitForConnection(ctx), context.DeadlineExceeded)
+
+	rcv, _ := otlpTracesReceiverOnGRPCServer(ln, false)
+	defer rcv.srv.GracefulStop()
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	assert.NoError(t, waiter.WaitForConnection(ctx))
+}
+
 func TestSendTraceDataServerDownAndUp(t *testing.T) {
 	// Find the addr, but don't start the server.
 	ln, err := net.Listen("tcp", "localhost:")
@@ -38,9 +38,9 @@ func NewFactory() component.ExporterFactory {
 	return component.NewExporterFactory(
 		typeStr,
 		createDefaultConfig,
-		component.WithTracesExporter(createTracesExporter, component.StabilityLevelStable),
-		component.WithMetricsExporter(createMetricsExporter, component.StabilityLevelStable),
-		component.WithLogsExporter(createLogsExporter, component.StabilityLevelBeta),
+		component.WithTracesExporter(component.NewCreateTracesExporterFunc(createTracesExporter), component.StabilityLevelStable),
+		component.WithMetricsExporter(component.NewCreateMetricsExporterFunc(createMetricsExporter), component.StabilityLevelStable),
+		component.WithLogsExporter(component.NewCreateLogsExporterFunc(createLogsExporter), component.StabilityLevelBeta),
 	)
 }
 
@@ -79,77 +79,77 @@ func composeSignalURL(oCfg *Config, signalOverrideURL string, signalName string)
 func createTracesExporter(
 	ctx context.Context,
 	set component.ExporterCreateSettings,
-	cfg component.Config,
+	oCfg *Config,
 ) (component.TracesExporter, error) {
-	oce, err := newExporter(cfg, set)
+	oce, err := newExporter(oCfg, set)
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
 
 	oce.tracesURL, err = composeSignalURL(oCfg, oCfg.TracesEndpoint, "traces")
 	if err != nil {
 		return nil, err
 	}
 
-	return exporterhelper.NewTracesExporter(ctx, set, cfg,
+	return exporterhelper.NewTracesExporter(ctx, set, oCfg,
 		oce.pushTraces,
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
-		exporterhelper.WithQueue(oCfg.QueueSettings))
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithRequestBytes())
 }
 
 func createMetricsExporter(
 	ctx context.Context,
 	set component.ExporterCreateSettings,
-	cfg component.Config,
+	oCfg *Config,
 ) (component.MetricsExporter, error) {
-	oce, err := newExporter(cfg, set)
+	oce, err := newExporter(oCfg, set)
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
 
 	oce.metricsURL, err = composeSignalURL(oCfg, oCfg.MetricsEndpoint, "metrics")
 	if err != nil {
 		return nil, err
 	}
 
-	return exporterhelper.NewMetricsExporter(ctx, set, cfg,
+	return exporterhelper.NewMetricsExporter(ctx, set, oCfg,
 		oce.pushMetrics,
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
-		exporterhelper.WithQueue(oCfg.QueueSettings))
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithRequestBytes())
 }
 
 func createLogsExporter(
 	ctx context.Context,
 	set component.ExporterCreateSettings,
-	cfg component.Config,
+	oCfg *Config,
 ) (component.LogsExporter, error) {
-	oce, err := newExporter(cfg, set)
+	oce, err := newExporter(oCfg, set)
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
 
 	oce.logsURL, err = composeSignalURL(oCfg, oCfg.LogsEndpoint, "logs")
 	if err != nil {
 		return nil, err
 	}
 
-	return exporterhelper.NewLogsExporter(ctx, set, cfg,
+	return exporterhelper.NewLogsExporter(ctx, set, oCfg,
 		oce.pushLogs,
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
-		exporterhelper.WithQueue(oCfg.QueueSettings))
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithRequestBytes())
 }
@@ -60,9 +60,7 @@ const (
 )
 
 // Create new exporter.
-func newExporter(cfg component.Config, set component.ExporterCreateSettings) (*exporter, error) {
-	oCfg := cfg.(*Config)
-
+func newExporter(oCfg *Config, set component.ExporterCreateSettings) (*exporter, error) {
 	if oCfg.Endpoint != "" {
 		_, err := url.Parse(oCfg.Endpoint)
 		if err != nil {
@@ -164,16 +162,11 @@ func (e *exporter) export(ctx context.Context, url string, request []byte) error
 	// Check if the server is overwhelmed.
 	// See spec https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#throttling-1
 	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
-		// Fallback to 0 if the Retry-After header is not present. This will trigger the
+		// Fallback to 0 if the Retry-After header is not present or invalid. This will trigger the
 		// default backoff policy by our caller (retry handler).
-		retryAfter := 0
-		if val := resp.Header.Get(headerRetryAfter); val != "" {
-			if seconds, err2 := strconv.Atoi(val); err2 == nil {
-				retryAfter = seconds
-			}
-		}
-		// Indicate to our caller to pause for the specified number of seconds.
-		return exporterhelper.NewThrottleRetry(formattedErr, time.Duration(retryAfter)*time.Second)
+		retryAfter := parseRetryAfter(resp.Header.Get(headerRetryAfter), time.Now())
+		// Indicate to our caller to pause for the specified duration.
+		return exporterhelper.NewThrottleRetry(formattedErr, retryAfter)
 	}
 
 	if isPermanentClientFailure(resp.StatusCode) {
@@ -185,6 +178,25 @@ func (e *exporter) export(ctx context.Context, url string, request []byte) error
 	return formattedErr
 }
 
+// parseRetryAfter returns the delay of a Retry-After header value, either a number of seconds or an HTTP date,
+// see https://www.rfc-editor.org/rfc/rfc9110#field.retry-after. It returns 0 when the value is invalid or the
+// date is in the past.
+func parseRetryAfter(val string, now time.Time) time.Duration {
+	if val == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(val); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(val); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
 // Does the 'code' indicate a permanent error
 func isPermanentClientFailure(code int) bool {
 	switch code {
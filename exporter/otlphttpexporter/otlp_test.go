@@ -45,6 +45,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -138,6 +139,31 @@ func TestTraceRoundTrip(t *testing.T) {
 	}
 }
 
+func TestTraceRecordsBytes(t *testing.T) {
+	received := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		received <- body
+	}))
+	defer srv.Close()
+
+	id := component.NewID(typeStr)
+	tt, err := obsreporttest.SetupTelemetryWithID(id)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	factory := NewFactory()
+	cfg := createExporterConfig(srv.URL, factory.CreateDefaultConfig())
+	cfg.Compression = "none"
+	exp, err := factory.CreateTracesExporter(context.Background(), tt.ToExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	startAndCleanup(t, exp)
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, obsreporttest.CheckExporterBytes(tt, id, int64(len(<-received)), 0))
+}
+
 func TestMetricsError(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 
@@ -516,6 +542,26 @@ func TestErrorResponses(t *testing.T) {
 	}
 }
 
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{value: "", expected: 0},
+		{value: "30", expected: 30 * time.Second},
+		{value: "-1", expected: 0},
+		{value: "soon", expected: 0},
+		{value: "Tue, 01 Nov 2022 10:01:30 GMT", expected: 90 * time.Second},
+		{value: "Tue, 01 Nov 2022 09:59:00 GMT", expected: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseRetryAfter(tt.value, now))
+		})
+	}
+}
+
 func TestUserAgent(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	set := componenttest.NewNopExporterCreateSettings()
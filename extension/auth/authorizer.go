@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth // import "go.opentelemetry.io/collector/extension/auth"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+)
+
+// Authorizer is an Extension deciding whether the requests authenticated by a Server are allowed, so that
+// fine-grained policies, e.g. "tenant X may send logs but not traces", are enforced in one place for all the
+// receivers. Authorizers are referenced by their names from the Authentication configuration.
+type Authorizer interface {
+	component.Extension
+
+	// Authorize returns nil when the request is allowed. It is called after the successful authentication of the
+	// request, with the context returned by Server.Authenticate. Refused requests get the returned error, handled
+	// as if it was of the ErrorKindPermissionDenied kind unless it is an *Error of another kind.
+	Authorize(ctx context.Context, req AuthorizationRequest) error
+}
+
+// AuthorizationRequest describes the request to authorize.
+type AuthorizationRequest struct {
+	// Method is the full name of the called method, e.g. "/opentelemetry.proto.collector.trace.v1.TraceService/Export"
+	// for gRPC requests.
+	Method string
+
+	// DataType is the type of the telemetry sent with the request, empty when it is not known.
+	DataType component.DataType
+
+	// Auth is the authentication data set by the Server in the client.Info of the context, nil if there is none.
+	Auth client.AuthData
+}
+
+// AuthorizeFunc defines the signature for the function responsible for authorizing requests.
+// See Authorizer.Authorize.
+type AuthorizeFunc func(ctx context.Context, req AuthorizationRequest) error
+
+var _ Authorizer = (*defaultAuthorizer)(nil)
+
+// AuthorizerOption represents the possible options for NewAuthorizer.
+type AuthorizerOption func(*defaultAuthorizer)
+
+type defaultAuthorizer struct {
+	AuthorizeFunc
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+// WithAuthorize specifies which function to use to authorize the requests.
+// The default allows all the requests.
+func WithAuthorize(authorizeFunc AuthorizeFunc) AuthorizerOption {
+	return func(o *defaultAuthorizer) {
+		o.AuthorizeFunc = authorizeFunc
+	}
+}
+
+// WithAuthorizerStart overrides the default `Start` function for a component.Component.
+// The default always returns nil.
+func WithAuthorizerStart(startFunc component.StartFunc) AuthorizerOption {
+	return func(o *defaultAuthorizer) {
+		o.StartFunc = startFunc
+	}
+}
+
+// WithAuthorizerShutdown overrides the default `Shutdown` function for a component.Component.
+// The default always returns nil.
+func WithAuthorizerShutdown(shutdownFunc component.ShutdownFunc) AuthorizerOption {
+	return func(o *defaultAuthorizer) {
+		o.ShutdownFunc = shutdownFunc
+	}
+}
+
+// NewAuthorizer returns an Authorizer configured with the provided options.
+func NewAuthorizer(options ...AuthorizerOption) Authorizer {
+	a := &defaultAuthorizer{
+		AuthorizeFunc: func(ctx context.Context, req AuthorizationRequest) error { return nil },
+		StartFunc:     func(ctx context.Context, host component.Host) error { return nil },
+		ShutdownFunc:  func(ctx context.Context) error { return nil },
+	}
+
+	for _, op := range options {
+		op(a)
+	}
+
+	return a
+}
+
+// Authorize authorizes the request.
+func (a *defaultAuthorizer) Authorize(ctx context.Context, req AuthorizationRequest) error {
+	return a.AuthorizeFunc(ctx, req)
+}
+
+// Start the component.
+func (a *defaultAuthorizer) Start(ctx context.Context, host component.Host) error {
+	return a.StartFunc(ctx, host)
+}
+
+// Shutdown stops the component.
+func (a *defaultAuthorizer) Shutdown(ctx context.Context) error {
+	return a.ShutdownFunc(ctx)
+}
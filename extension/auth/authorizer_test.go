@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestAuthorizerDefaultValues(t *testing.T) {
+	a := NewAuthorizer()
+	assert.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, a.Authorize(context.Background(), AuthorizationRequest{Method: "/svc/Method"}))
+	assert.NoError(t, a.Shutdown(context.Background()))
+}
+
+func TestWithAuthorize(t *testing.T) {
+	a := NewAuthorizer(WithAuthorize(func(ctx context.Context, req AuthorizationRequest) error {
+		if req.Auth == nil || req.Auth.GetAttribute(client.AuthAttributeTenant) != "acme" || req.DataType != component.DataTypeLogs {
+			return errors.New("not allowed")
+		}
+		return nil
+	}))
+
+	acme := &client.Principal{Tenant: "acme"}
+	assert.NoError(t, a.Authorize(context.Background(), AuthorizationRequest{DataType: component.DataTypeLogs, Auth: acme}))
+	assert.EqualError(t, a.Authorize(context.Background(), AuthorizationRequest{DataType: component.DataTypeTraces, Auth: acme}), "not allowed")
+	assert.EqualError(t, a.Authorize(context.Background(), AuthorizationRequest{DataType: component.DataTypeLogs}), "not allowed")
+}
+
+func TestWithAuthorizerStartAndShutdown(t *testing.T) {
+	started, stopped := false, false
+	a := NewAuthorizer(
+		WithAuthorizerStart(func(context.Context, component.Host) error {
+			started = true
+			return nil
+		}),
+		WithAuthorizerShutdown(func(context.Context) error {
+			stopped = true
+			return nil
+		}),
+	)
+	assert.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, a.Shutdown(context.Background()))
+	assert.True(t, started)
+	assert.True(t, stopped)
+}
@@ -52,7 +52,8 @@ func WithClientShutdown(shutdownFunc component.ShutdownFunc) ClientOption {
 }
 
 // WithClientRoundTripper provides a `RoundTripper` function for this client authenticator.
-// The default round tripper is no-op.
+// There's no default, a client without a round tripper cannot authenticate HTTP requests
+// and returns ErrTransportNotSupported.
 func WithClientRoundTripper(roundTripperFunc func(base http.RoundTripper) (http.RoundTripper, error)) ClientOption {
 	return func(o *defaultClient) {
 		o.roundTripperFunc = roundTripperFunc
@@ -72,7 +73,7 @@ func NewClient(options ...ClientOption) Client {
 	bc := &defaultClient{
 		StartFunc:             func(ctx context.Context, host component.Host) error { return nil },
 		ShutdownFunc:          func(ctx context.Context) error { return nil },
-		roundTripperFunc:      func(base http.RoundTripper) (http.RoundTripper, error) { return base, nil },
+		roundTripperFunc:      func(base http.RoundTripper) (http.RoundTripper, error) { return nil, ErrTransportNotSupported },
 		perRPCCredentialsFunc: func() (credentials.PerRPCCredentials, error) { return nil, nil },
 	}
 
@@ -37,9 +37,9 @@ func TestClientDefaultValues(t *testing.T) {
 	})
 
 	t.Run("roundtripper", func(t *testing.T) {
-		ctx, err := e.RoundTripper(http.DefaultTransport)
-		assert.NotNil(t, ctx)
-		assert.NoError(t, err)
+		rt, err := e.RoundTripper(http.DefaultTransport)
+		assert.Nil(t, rt)
+		assert.ErrorIs(t, err, ErrTransportNotSupported)
 	})
 
 	t.Run("per-rpc-credentials", func(t *testing.T) {
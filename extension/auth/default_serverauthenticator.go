@@ -38,6 +38,12 @@ func WithAuthenticate(authenticateFunc AuthenticateFunc) Option {
 	}
 }
 
+// WithAuthenticatePrincipal specifies which function to use to perform the authentication, the returned
+// principal being stored as the Auth of the client.Info of the context. See AuthenticatePrincipalFunc.
+func WithAuthenticatePrincipal(authenticateFunc AuthenticatePrincipalFunc) Option {
+	return WithAuthenticate(authenticateFunc.ToAuthenticateFunc())
+}
+
 // WithStart overrides the default `Start` function for a component.Component.
 // The default always returns nil.
 func WithStart(startFunc component.StartFunc) Option {
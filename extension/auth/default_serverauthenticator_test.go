@@ -16,10 +16,14 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 )
@@ -64,6 +68,44 @@ func TestWithAuthenticateFunc(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestWithAuthenticatePrincipal(t *testing.T) {
+	e := NewServer(
+		WithAuthenticatePrincipal(func(ctx context.Context, headers map[string][]string) (*client.Principal, error) {
+			if len(headers["authorization"]) == 0 {
+				return nil, errors.New("missing authorization header")
+			}
+			return &client.Principal{Subject: "jdoe", Groups: []string{"devs"}, Tenant: "acme"}, nil
+		}),
+	)
+
+	addr := &net.IPAddr{IP: net.IPv4(1, 2, 3, 4)}
+	ctx := client.NewContext(context.Background(), client.Info{Addr: addr})
+	ctx, err := e.Authenticate(ctx, map[string][]string{"authorization": {"Bearer token"}})
+	require.NoError(t, err)
+
+	info := client.FromContext(ctx)
+	assert.Equal(t, addr, info.Addr)
+	require.NotNil(t, info.Auth)
+	assert.Equal(t, "jdoe", info.Auth.GetAttribute(client.AuthAttributeSubject))
+	assert.Equal(t, []string{"devs"}, info.Auth.GetAttribute(client.AuthAttributeGroups))
+	assert.Equal(t, "acme", info.Auth.GetAttribute(client.AuthAttributeTenant))
+
+	_, err = e.Authenticate(context.Background(), map[string][]string{})
+	assert.EqualError(t, err, "missing authorization header")
+}
+
+func TestWithAuthenticatePrincipalNil(t *testing.T) {
+	e := NewServer(
+		WithAuthenticatePrincipal(func(ctx context.Context, headers map[string][]string) (*client.Principal, error) {
+			return nil, nil
+		}),
+	)
+
+	ctx, err := e.Authenticate(context.Background(), map[string][]string{})
+	require.NoError(t, err)
+	assert.Nil(t, client.FromContext(ctx).Auth)
+}
+
 func TestWithStart(t *testing.T) {
 	called := false
 	e := NewServer(WithStart(func(c context.Context, h component.Host) error {
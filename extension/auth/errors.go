@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth // import "go.opentelemetry.io/collector/extension/auth"
+
+import "fmt"
+
+// ErrorKind classifies the authentication failures, so that the receivers can answer with the matching
+// HTTP status code or gRPC status.
+type ErrorKind int
+
+const (
+	// ErrorKindUnauthenticated means that the request does not have valid authentication data.
+	// It is mapped to the HTTP status 401 and the gRPC code Unauthenticated.
+	ErrorKindUnauthenticated ErrorKind = iota
+	// ErrorKindPermissionDenied means that the request is authenticated, but not allowed.
+	// It is mapped to the HTTP status 403 and the gRPC code PermissionDenied.
+	ErrorKindPermissionDenied
+	// ErrorKindInternal means that the authentication data could not be verified, e.g. the identity provider is unavailable.
+	// It is mapped to the HTTP status 500 and the gRPC code Internal.
+	ErrorKindInternal
+)
+
+// String returns the string representation of the ErrorKind.
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindUnauthenticated:
+		return "unauthenticated"
+	case ErrorKindPermissionDenied:
+		return "permission denied"
+	case ErrorKindInternal:
+		return "internal"
+	}
+	return fmt.Sprintf("ErrorKind(%d)", int(k))
+}
+
+// Error is an error returned by a Server authenticator. Errors of other types returned by
+// Server.Authenticate are handled as if they were of the ErrorKindUnauthenticated kind.
+type Error struct {
+	// Kind classifies the failure.
+	Kind ErrorKind
+
+	// Challenge is the optional value of the WWW-Authenticate header sent back to HTTP clients
+	// with an ErrorKindUnauthenticated error, e.g. `Bearer realm="otel"`.
+	Challenge string
+
+	err error
+}
+
+// NewUnauthenticatedError returns an Error of the ErrorKindUnauthenticated kind, with the given WWW-Authenticate challenge.
+func NewUnauthenticatedError(err error, challenge string) *Error {
+	return &Error{Kind: ErrorKindUnauthenticated, Challenge: challenge, err: err}
+}
+
+// NewPermissionDeniedError returns an Error of the ErrorKindPermissionDenied kind.
+func NewPermissionDeniedError(err error) *Error {
+	return &Error{Kind: ErrorKindPermissionDenied, err: err}
+}
+
+// NewInternalError returns an Error of the ErrorKindInternal kind.
+func NewInternalError(err error) *Error {
+	return &Error{Kind: ErrorKindInternal, err: err}
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e.err == nil {
+		return e.Kind.String()
+	}
+	return e.Kind.String() + ": " + e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *Error) Unwrap() error {
+	return e.err
+}
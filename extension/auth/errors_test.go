@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	cause := errors.New("invalid token")
+
+	tests := []struct {
+		err          *Error
+		expectedKind ErrorKind
+		expectedMsg  string
+	}{
+		{
+			err:          NewUnauthenticatedError(cause, `Bearer realm="otel"`),
+			expectedKind: ErrorKindUnauthenticated,
+			expectedMsg:  "unauthenticated: invalid token",
+		},
+		{
+			err:          NewPermissionDeniedError(cause),
+			expectedKind: ErrorKindPermissionDenied,
+			expectedMsg:  "permission denied: invalid token",
+		},
+		{
+			err:          NewInternalError(cause),
+			expectedKind: ErrorKindInternal,
+			expectedMsg:  "internal: invalid token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expectedKind.String(), func(t *testing.T) {
+			assert.Equal(t, tt.expectedKind, tt.err.Kind)
+			assert.EqualError(t, tt.err, tt.expectedMsg)
+			assert.ErrorIs(t, tt.err, cause)
+
+			var authErr *Error
+			assert.True(t, errors.As(fmt.Errorf("wrapped: %w", tt.err), &authErr))
+			assert.Equal(t, tt.err, authErr)
+		})
+	}
+
+	assert.Equal(t, `Bearer realm="otel"`, NewUnauthenticatedError(cause, `Bearer realm="otel"`).Challenge)
+	assert.Equal(t, "ErrorKind(42)", ErrorKind(42).String())
+
+	// The wrapped error is optional.
+	assert.EqualError(t, NewPermissionDeniedError(nil), "permission denied")
+	assert.EqualError(t, &Error{Kind: ErrorKindInternal}, "internal")
+	assert.NoError(t, errors.Unwrap(NewInternalError(nil)))
+}
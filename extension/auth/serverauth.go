@@ -17,6 +17,7 @@ package auth // import "go.opentelemetry.io/collector/extension/auth"
 import (
 	"context"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 )
 
@@ -35,10 +36,33 @@ type Server interface {
 	// The resulting context should contain the authentication data, such as the principal/username, group membership (if available), and the raw
 	// authentication data (if possible). This will allow other components in the pipeline to make decisions based on that data, such as routing based
 	// on tenancy as determined by the group membership, or passing through the authentication data to the next collector/backend.
-	// The context keys to be used are not defined yet.
+	// This data is stored as the Auth of the client.Info of the resulting context, ideally as a *client.Principal
+	// so that components can rely on its well-known attributes, see WithAuthenticatePrincipal.
 	Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error)
 }
 
 // AuthenticateFunc defines the signature for the function responsible for performing the authentication based on the given headers map.
 // See Server.Authenticate.
 type AuthenticateFunc func(ctx context.Context, headers map[string][]string) (context.Context, error)
+
+// AuthenticatePrincipalFunc defines the signature for the function authenticating the given headers map and
+// returning the authenticated principal. See WithAuthenticatePrincipal.
+type AuthenticatePrincipalFunc func(ctx context.Context, headers map[string][]string) (*client.Principal, error)
+
+// ToAuthenticateFunc returns an AuthenticateFunc storing the principal as the Auth of the client.Info of the
+// context, so that it is available to the processors and exporters with client.FromContext. The context is left
+// unchanged when no principal is returned, rather than holding a nil *client.Principal as its Auth.
+func (f AuthenticatePrincipalFunc) ToAuthenticateFunc() AuthenticateFunc {
+	return func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+		principal, err := f(ctx, headers)
+		if err != nil {
+			return ctx, err
+		}
+		if principal == nil {
+			return ctx, nil
+		}
+		info := client.FromContext(ctx)
+		info.Auth = principal
+		return client.NewContext(ctx, info), nil
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth // import "go.opentelemetry.io/collector/extension/auth"
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Token is a credential obtained by a client authenticator, for example an OAuth2 access token.
+type Token struct {
+	// Value is the credential sent with the requests.
+	Value string
+
+	// Expiry is the time after which the token is no longer valid. A zero Expiry means that the token never expires.
+	Expiry time.Time
+}
+
+// TokenFetchFunc fetches a new Token. The context passed to it is never cancelled,
+// so the function must apply its own timeout.
+type TokenFetchFunc func(ctx context.Context) (Token, error)
+
+// TokenCacheOption apply changes to a TokenCache.
+type TokenCacheOption func(*TokenCache)
+
+// WithRefreshWindow sets how long before its expiry a token is renewed. The default is one minute.
+// The window is capped to half of the token lifetime, so that short-lived tokens are not renewed on every use.
+func WithRefreshWindow(window time.Duration) TokenCacheOption {
+	return func(c *TokenCache) {
+		c.refreshWindow = window
+	}
+}
+
+// WithRefreshJitter renews the tokens earlier by a random fraction, in the [0, jitter) range, of the refresh window.
+// It spreads the renewal of tokens fetched at the same time, for example by many collectors started together.
+// The default is 0.1.
+func WithRefreshJitter(jitter float64) TokenCacheOption {
+	return func(c *TokenCache) {
+		c.jitter = jitter
+	}
+}
+
+// WithRenewalBackoff sets how long to wait before fetching a token again after a failed fetch. The delay starts
+// at initial and doubles after every consecutive failure, up to max. While waiting, the cached token is returned
+// if it has not expired, the error of the last fetch otherwise. The defaults are one second and one minute.
+func WithRenewalBackoff(initial, max time.Duration) TokenCacheOption {
+	return func(c *TokenCache) {
+		c.initialBackoff = initial
+		c.maxBackoff = max
+	}
+}
+
+// TokenCache caches a Token and renews it shortly before it expires. Concurrent callers share a single
+// fetch of the token, so that client authenticators do not have to deal with refresh races.
+type TokenCache struct {
+	fetch          TokenFetchFunc
+	refreshWindow  time.Duration
+	jitter         float64
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	now            func() time.Time
+
+	// mu protects everything below.
+	mu       sync.Mutex
+	token    Token
+	valid    bool
+	renewAt  time.Time
+	inflight *tokenFetch
+	// failures is the number of consecutive failed fetches, no fetch is attempted before retryAt.
+	failures int
+	retryAt  time.Time
+	lastErr  error
+}
+
+type tokenFetch struct {
+	done  chan struct{}
+	token Token
+	err   error
+}
+
+// NewTokenCache returns a TokenCache that fetches the tokens with the given function.
+func NewTokenCache(fetch TokenFetchFunc, opts ...TokenCacheOption) *TokenCache {
+	c := &TokenCache{
+		fetch:          fetch,
+		refreshWindow:  time.Minute,
+		jitter:         0.1,
+		initialBackoff: time.Second,
+		maxBackoff:     time.Minute,
+		now:            time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Token returns the cached token, fetching a new one if there is none or if it is about to expire.
+// If renewing a token that has not expired yet fails, the cached token is returned.
+// After a failed fetch, no new fetch is attempted until the renewal backoff elapsed.
+func (c *TokenCache) Token(ctx context.Context) (Token, error) {
+	c.mu.Lock()
+	now := c.now()
+	if c.valid && now.Before(c.renewAt) {
+		token := c.token
+		c.mu.Unlock()
+		return token, nil
+	}
+	if !c.valid && c.lastErr != nil && now.Before(c.retryAt) {
+		err := c.lastErr
+		c.mu.Unlock()
+		return Token{}, err
+	}
+
+	f := c.inflight
+	if f == nil {
+		f = &tokenFetch{done: make(chan struct{})}
+		c.inflight = f
+		go c.doFetch(f)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.token, f.err
+	case <-ctx.Done():
+		return Token{}, ctx.Err()
+	}
+}
+
+// Invalidate drops the cached token, so that the next call to Token fetches a new one.
+// It should be called when the server rejects the token before its expiry.
+func (c *TokenCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.valid = false
+}
+
+func (c *TokenCache) doFetch(f *tokenFetch) {
+	token, err := c.fetch(context.Background())
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	switch {
+	case err == nil:
+		c.token = token
+		c.valid = true
+		c.renewAt = c.renewalTime(now, token)
+		c.failures = 0
+		c.lastErr = nil
+		f.token = token
+	case c.valid && (c.token.Expiry.IsZero() || now.Before(c.token.Expiry)):
+		// Keep using the current token until it expires, and retry the renewal after the backoff.
+		c.retryAt = now.Add(c.nextBackoff())
+		c.renewAt = c.retryAt
+		if !c.token.Expiry.IsZero() && c.token.Expiry.Before(c.renewAt) {
+			c.renewAt = c.token.Expiry
+		}
+		f.token = c.token
+	default:
+		c.valid = false
+		c.retryAt = now.Add(c.nextBackoff())
+		c.lastErr = err
+		f.err = err
+	}
+	c.inflight = nil
+	close(f.done)
+}
+
+// nextBackoff records a failed fetch and returns how long to wait before the next one.
+func (c *TokenCache) nextBackoff() time.Duration {
+	backoff := c.initialBackoff
+	for i := 0; i < c.failures && backoff < c.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > c.maxBackoff {
+		backoff = c.maxBackoff
+	}
+	c.failures++
+	return backoff
+}
+
+// renewalTime returns when the token, fetched at now, must be renewed.
+func (c *TokenCache) renewalTime(now time.Time, token Token) time.Time {
+	if token.Expiry.IsZero() {
+		// Never renew a token that does not expire, unless it is invalidated.
+		return time.Unix(1<<62, 0)
+	}
+
+	window := c.refreshWindow
+	if lifetime := token.Expiry.Sub(now); window > lifetime/2 {
+		window = lifetime / 2
+	}
+	if c.jitter > 0 {
+		window += time.Duration(rand.Float64() * c.jitter * float64(window)) //nolint:gosec
+	}
+	return token.Expiry.Add(-window)
+}
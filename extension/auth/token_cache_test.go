@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestTokenCache(clock *fakeClock, fetch TokenFetchFunc) *TokenCache {
+	c := NewTokenCache(fetch, WithRefreshWindow(time.Minute), WithRefreshJitter(0))
+	c.now = clock.Now
+	return c
+}
+
+func TestTokenCacheReusesToken(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	fetches := atomic.NewInt64(0)
+	c := newTestTokenCache(clock, func(context.Context) (Token, error) {
+		n := fetches.Inc()
+		return Token{Value: fmt.Sprintf("token%d", n), Expiry: clock.Now().Add(10 * time.Minute)}, nil
+	})
+
+	token, err := c.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token1", token.Value)
+
+	// Still outside the refresh window.
+	clock.Advance(8 * time.Minute)
+	token, err = c.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token1", token.Value)
+	assert.EqualValues(t, 1, fetches.Load())
+
+	// Within the refresh window, the token is renewed before it expires.
+	clock.Advance(90 * time.Second)
+	token, err = c.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token2", token.Value)
+	assert.EqualValues(t, 2, fetches.Load())
+}
+
+func TestTokenCacheNoExpiry(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	fetches := atomic.NewInt64(0)
+	c := newTestTokenCache(clock, func(context.Context) (Token, error) {
+		fetches.Inc()
+		return Token{Value: "token"}, nil
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := c.Token(context.Background())
+		require.NoError(t, err)
+		clock.Advance(24 * time.Hour)
+	}
+	assert.EqualValues(t, 1, fetches.Load())
+
+	c.Invalidate()
+	_, err := c.Token(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, fetches.Load())
+}
+
+func TestTokenCacheShortLivedToken(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	fetches := atomic.NewInt64(0)
+	c := newTestTokenCache(clock, func(context.Context) (Token, error) {
+		fetches.Inc()
+		return Token{Value: "token", Expiry: clock.Now().Add(30 * time.Second)}, nil
+	})
+
+	_, err := c.Token(context.Background())
+	require.NoError(t, err)
+
+	// The refresh window is capped to half of the token lifetime.
+	clock.Advance(10 * time.Second)
+	_, err = c.Token(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, fetches.Load())
+
+	clock.Advance(10 * time.Second)
+	_, err = c.Token(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, fetches.Load())
+}
+
+func TestTokenCacheSingleFlight(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	fetches := atomic.NewInt64(0)
+	release := make(chan struct{})
+	c := newTestTokenCache(clock, func(context.Context) (Token, error) {
+		fetches.Inc()
+		<-release
+		return Token{Value: "token", Expiry: clock.Now().Add(time.Hour)}, nil
+	})
+
+	const callers = 10
+	wg := sync.WaitGroup{}
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			token, err := c.Token(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "token", token.Value)
+		}()
+	}
+	assert.Eventually(t, func() bool { return fetches.Load() == 1 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.EqualValues(t, 1, fetches.Load())
+}
+
+func TestTokenCacheFetchError(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	fail := atomic.NewBool(false)
+	c := newTestTokenCache(clock, func(context.Context) (Token, error) {
+		if fail.Load() {
+			return Token{}, errors.New("fetch failed")
+		}
+		return Token{Value: "token", Expiry: clock.Now().Add(10 * time.Minute)}, nil
+	})
+
+	_, err := c.Token(context.Background())
+	require.NoError(t, err)
+
+	// Renewing fails, but the cached token has not expired yet.
+	fail.Store(true)
+	clock.Advance(9*time.Minute + 30*time.Second)
+	token, err := c.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token", token.Value)
+
+	// Once the cached token expired, the error is returned.
+	clock.Advance(time.Minute)
+	_, err = c.Token(context.Background())
+	assert.EqualError(t, err, "fetch failed")
+}
+
+func TestTokenCacheRenewalBackoff(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	fetches := atomic.NewInt64(0)
+	fail := atomic.NewBool(false)
+	c := newTestTokenCache(clock, func(context.Context) (Token, error) {
+		fetches.Inc()
+		if fail.Load() {
+			return Token{}, errors.New("fetch failed")
+		}
+		return Token{Value: "token", Expiry: clock.Now().Add(10 * time.Minute)}, nil
+	})
+
+	_, err := c.Token(context.Background())
+	require.NoError(t, err)
+
+	// The failed renewal is not retried before the backoff elapsed, the cached token is used meanwhile.
+	fail.Store(true)
+	clock.Advance(9*time.Minute + 30*time.Second)
+	for i := 0; i < 3; i++ {
+		token, err := c.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token", token.Value)
+	}
+	assert.EqualValues(t, 2, fetches.Load())
+
+	clock.Advance(time.Second)
+	_, err = c.Token(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, fetches.Load())
+
+	// The backoff doubles after every failure.
+	clock.Advance(time.Second)
+	_, err = c.Token(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, fetches.Load())
+	clock.Advance(time.Second)
+	_, err = c.Token(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, fetches.Load())
+
+	// Once the token expired, the last error is returned until the backoff elapsed.
+	clock.Advance(time.Minute)
+	_, err = c.Token(context.Background())
+	assert.EqualError(t, err, "fetch failed")
+	assert.EqualValues(t, 5, fetches.Load())
+	_, err = c.Token(context.Background())
+	assert.EqualError(t, err, "fetch failed")
+	assert.EqualValues(t, 5, fetches.Load())
+
+	// A successful fetch resets the backoff.
+	fail.Store(false)
+	clock.Advance(time.Minute)
+	token, err := c.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token", token.Value)
+	assert.EqualValues(t, 6, fetches.Load())
+	assert.Equal(t, 0, c.failures)
+}
+
+func TestTokenCacheContextCancelled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	c := NewTokenCache(func(context.Context) (Token, error) {
+		<-release
+		return Token{Value: "token"}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.Token(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth // import "go.opentelemetry.io/collector/extension/auth"
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// ErrTransportNotSupported is returned by a Client that cannot authenticate requests for a transport,
+// e.g. by the RoundTripper function of an authenticator supporting only gRPC.
+var ErrTransportNotSupported = errors.New("transport not supported by the authenticator")
+
+// WithTokenCache authenticates both the HTTP and the gRPC requests with the tokens of the given TokenCache,
+// sent as the value of the "Authorization" header prefixed by the scheme (e.g. "Bearer").
+// The HTTP round tripper and the gRPC credentials share the cache, so that a token is fetched once
+// for both transports. A token refused by an HTTP server is invalidated.
+//
+// The gRPC credentials require transport security, tokens are never sent on an insecure connection.
+func WithTokenCache(cache *TokenCache, scheme string) ClientOption {
+	return func(o *defaultClient) {
+		o.roundTripperFunc = func(base http.RoundTripper) (http.RoundTripper, error) {
+			return &tokenRoundTripper{base: base, cache: cache, scheme: scheme}, nil
+		}
+		o.perRPCCredentialsFunc = func() (credentials.PerRPCCredentials, error) {
+			return &tokenPerRPCCredentials{cache: cache, scheme: scheme}, nil
+		}
+	}
+}
+
+func authorizationValue(ctx context.Context, cache *TokenCache, scheme string) (string, error) {
+	token, err := cache.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	if scheme == "" {
+		return token.Value, nil
+	}
+	return scheme + " " + token.Value, nil
+}
+
+type tokenRoundTripper struct {
+	base   http.RoundTripper
+	cache  *TokenCache
+	scheme string
+}
+
+func (rt *tokenRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	value, err := authorizationValue(req.Context(), rt.cache, rt.scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	// The RoundTripper must not modify the request, see http.RoundTripper.
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", value)
+	resp, err := rt.base.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		rt.cache.Invalidate()
+	}
+	return resp, err
+}
+
+type tokenPerRPCCredentials struct {
+	cache  *TokenCache
+	scheme string
+}
+
+var _ credentials.PerRPCCredentials = (*tokenPerRPCCredentials)(nil)
+
+func (c *tokenPerRPCCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	value, err := authorizationValue(ctx, c.cache, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": value}, nil
+}
+
+func (c *tokenPerRPCCredentials) RequireTransportSecurity() bool {
+	return true
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestWithTokenCacheSharedToken(t *testing.T) {
+	fetches := atomic.NewInt32(0)
+	cache := NewTokenCache(func(context.Context) (Token, error) {
+		fetches.Inc()
+		return Token{Value: "secret", Expiry: time.Now().Add(time.Hour)}, nil
+	})
+	client := NewClient(WithTokenCache(cache, "Bearer"))
+
+	var gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	rt, err := client.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, "Bearer secret", gotHeader)
+	assert.Empty(t, req.Header.Get("Authorization"), "the original request must not be modified")
+
+	creds, err := client.PerRPCCredentials()
+	require.NoError(t, err)
+	assert.True(t, creds.RequireTransportSecurity())
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer secret"}, md)
+
+	assert.Equal(t, int32(1), fetches.Load())
+}
+
+func TestWithTokenCacheInvalidatesRefusedToken(t *testing.T) {
+	fetches := atomic.NewInt32(0)
+	cache := NewTokenCache(func(context.Context) (Token, error) {
+		fetches.Inc()
+		return Token{Value: "secret"}, nil
+	})
+	client := NewClient(WithTokenCache(cache, ""))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	rt, err := client.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := rt.RoundTrip(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	}
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+func TestWithTokenCacheFetchError(t *testing.T) {
+	fetchErr := errors.New("identity provider unavailable")
+	cache := NewTokenCache(func(context.Context) (Token, error) {
+		return Token{}, fetchErr
+	})
+	client := NewClient(WithTokenCache(cache, "Bearer"))
+
+	rt, err := client.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req) //nolint:bodyclose
+	assert.ErrorIs(t, err, fetchErr)
+
+	creds, err := client.PerRPCCredentials()
+	require.NoError(t, err)
+	_, err = creds.GetRequestMetadata(context.Background())
+	assert.ErrorIs(t, err, fetchErr)
+}
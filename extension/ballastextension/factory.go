@@ -32,7 +32,7 @@ var memHandler = iruntime.TotalMemory
 
 // NewFactory creates a factory for FluentBit extension.
 func NewFactory() component.ExtensionFactory {
-	return component.NewExtensionFactory(typeStr, createDefaultConfig, createExtension, component.StabilityLevelBeta)
+	return component.NewExtensionFactory(typeStr, createDefaultConfig, component.NewCreateExtensionFunc(createExtension), component.StabilityLevelBeta)
 }
 
 func createDefaultConfig() component.Config {
@@ -41,6 +41,6 @@ func createDefaultConfig() component.Config {
 	}
 }
 
-func createExtension(_ context.Context, set component.ExtensionCreateSettings, cfg component.Config) (component.Extension, error) {
-	return newMemoryBallast(cfg.(*Config), set.Logger, memHandler), nil
+func createExtension(_ context.Context, set component.ExtensionCreateSettings, cfg *Config) (component.Extension, error) {
+	return newMemoryBallast(cfg, set.Logger, memHandler), nil
 }
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package memorylimiter defines the interface of the extensions limiting the memory usage of the
+// collector, that receivers consult to refuse data at the network edge.
+package memorylimiter // import "go.opentelemetry.io/collector/extension/experimental/memorylimiter"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Extension is the interface that memory limiter extensions must implement.
+type Extension interface {
+	component.Extension
+
+	// MustRefuse returns true while the memory usage is above the limit. Receivers should then
+	// refuse new requests before reading them, with a retryable error (429/RESOURCE_EXHAUSTED).
+	MustRefuse() bool
+}
+
+// GetExtension returns the memory limiter Extension with the given ID of the host.
+func GetExtension(host component.Host, id component.ID) (Extension, error) {
+	ext, found := host.GetExtensions()[id]
+	if !found {
+		return nil, fmt.Errorf("memory limiter extension %q not found", id)
+	}
+	limiter, ok := ext.(Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a memory limiter extension", id)
+	}
+	return limiter, nil
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorylimiter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+type limiterExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (limiterExtension) MustRefuse() bool {
+	return true
+}
+
+type otherExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestGetExtension(t *testing.T) {
+	host := extensionsHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			component.NewID("memory_limiter"): limiterExtension{},
+			component.NewID("other"):          otherExtension{},
+		},
+	}
+
+	limiter, err := GetExtension(host, component.NewID("memory_limiter"))
+	require.NoError(t, err)
+	assert.True(t, limiter.MustRefuse())
+
+	_, err = GetExtension(host, component.NewID("other"))
+	assert.EqualError(t, err, `extension "other" is not a memory limiter extension`)
+
+	_, err = GetExtension(host, component.NewID("missing"))
+	assert.EqualError(t, err, `memory limiter extension "missing" not found`)
+}
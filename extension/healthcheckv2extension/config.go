@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckv2extension // import "go.opentelemetry.io/collector/extension/healthcheckv2extension"
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+// Config has the configuration for the healthcheckv2 extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// TCPAddr is the address and port in which the health endpoint will be listening to.
+	// Use localhost:<port> to make it available only locally, or ":<port>" to
+	// make it available on all network interfaces.
+	TCPAddr confignet.TCPAddr `mapstructure:",squash"`
+
+	// Path is the path of the health endpoint.
+	Path string `mapstructure:"path"`
+
+	// RecoveryDuration is how long a component can report recoverable errors before
+	// it makes its pipelines unhealthy.
+	RecoveryDuration time.Duration `mapstructure:"recovery_duration"`
+
+	// IncludePermanentErrors makes the pipelines of a component that reported a permanent
+	// error unhealthy.
+	IncludePermanentErrors bool `mapstructure:"include_permanent_errors"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.TCPAddr.Endpoint == "" {
+		return errors.New("\"endpoint\" is required when using the \"healthcheckv2\" extension")
+	}
+	if !strings.HasPrefix(cfg.Path, "/") {
+		return errors.New("\"path\" must start with \"/\"")
+	}
+	if cfg.RecoveryDuration < 0 {
+		return errors.New("\"recovery_duration\" must be non-negative")
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckv2extension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: config.NewExtensionSettings(component.NewID(typeStr)),
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "localhost:13134",
+			},
+			Path:                   "/status",
+			RecoveryDuration:       time.Minute,
+			IncludePermanentErrors: false,
+		}, cfg)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr string
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name:        "no endpoint",
+			modify:      func(cfg *Config) { cfg.TCPAddr.Endpoint = "" },
+			expectedErr: "\"endpoint\" is required when using the \"healthcheckv2\" extension",
+		},
+		{
+			name:        "relative path",
+			modify:      func(cfg *Config) { cfg.Path = "health" },
+			expectedErr: "\"path\" must start with \"/\"",
+		},
+		{
+			name:        "negative recovery duration",
+			modify:      func(cfg *Config) { cfg.RecoveryDuration = -time.Second },
+			expectedErr: "\"recovery_duration\" must be non-negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckv2extension // import "go.opentelemetry.io/collector/extension/healthcheckv2extension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+const (
+	// The value of extension "type" in configuration.
+	typeStr = "healthcheckv2"
+
+	defaultEndpoint = "localhost:13133"
+	defaultPath     = "/health"
+)
+
+// NewFactory creates a factory for the healthcheckv2 extension.
+func NewFactory() component.ExtensionFactory {
+	return component.NewExtensionFactory(typeStr, createDefaultConfig, component.NewCreateExtensionFunc(createExtension), component.StabilityLevelDevelopment)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ExtensionSettings: config.NewExtensionSettings(component.NewID(typeStr)),
+		TCPAddr: confignet.TCPAddr{
+			Endpoint: defaultEndpoint,
+		},
+		Path:                   defaultPath,
+		RecoveryDuration:       5 * time.Minute,
+		IncludePermanentErrors: true,
+	}
+}
+
+func createExtension(_ context.Context, set component.ExtensionCreateSettings, cfg *Config) (component.Extension, error) {
+	return newHealthCheck(cfg, set.TelemetrySettings), nil
+}
@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckv2extension // import "go.opentelemetry.io/collector/extension/healthcheckv2extension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// pipelineQueryParam selects the pipeline to report the health of, e.g. "?pipeline=traces/1".
+const pipelineQueryParam = "pipeline"
+
+var (
+	_ component.PipelineWatcher = (*healthCheck)(nil)
+	_ component.StatusWatcher   = (*healthCheck)(nil)
+)
+
+// componentStatus is the last status reported by a component instance.
+type componentStatus struct {
+	event *component.StatusEvent
+	// since is the time when the component entered its current status,
+	// repeated reports of the same status do not change it.
+	since time.Time
+}
+
+type healthCheck struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	server    http.Server
+	stopCh    chan struct{}
+	now       func() time.Time
+
+	mu       sync.Mutex
+	ready    bool
+	statuses map[*component.InstanceID]*componentStatus
+}
+
+// componentHealth is the JSON representation of the health of a component.
+type componentHealth struct {
+	Healthy bool      `json:"healthy"`
+	Status  string    `json:"status"`
+	Error   string    `json:"error,omitempty"`
+	Since   time.Time `json:"since"`
+}
+
+// pipelineHealth is the JSON representation of the health of a pipeline.
+type pipelineHealth struct {
+	Healthy    bool                        `json:"healthy"`
+	Components map[string]*componentHealth `json:"components"`
+}
+
+// collectorHealth is the JSON representation of the overall health of the collector.
+type collectorHealth struct {
+	Healthy   bool                       `json:"healthy"`
+	Ready     bool                       `json:"ready"`
+	Pipelines map[string]*pipelineHealth `json:"pipelines"`
+}
+
+func newHealthCheck(config *Config, telemetry component.TelemetrySettings) *healthCheck {
+	return &healthCheck{
+		config:    config,
+		telemetry: telemetry,
+		now:       time.Now,
+		statuses:  map[*component.InstanceID]*componentStatus{},
+	}
+}
+
+func (hc *healthCheck) Start(_ context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(hc.config.Path, hc.handleHealth)
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := hc.config.TCPAddr.Listen()
+	if err != nil {
+		return err
+	}
+
+	hc.telemetry.Logger.Info("Starting healthcheckv2 extension", zap.Any("config", hc.config))
+	hc.server = http.Server{Handler: mux}
+	hc.stopCh = make(chan struct{})
+	go func() {
+		defer close(hc.stopCh)
+
+		if errHTTP := hc.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+
+	return nil
+}
+
+func (hc *healthCheck) Shutdown(context.Context) error {
+	err := hc.server.Close()
+	if hc.stopCh != nil {
+		<-hc.stopCh
+	}
+	return err
+}
+
+// Ready implements component.PipelineWatcher.
+func (hc *healthCheck) Ready() error {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.ready = true
+	return nil
+}
+
+// NotReady implements component.PipelineWatcher.
+func (hc *healthCheck) NotReady() error {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.ready = false
+	return nil
+}
+
+// ComponentStatusChanged implements component.StatusWatcher.
+func (hc *healthCheck) ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	if prev, ok := hc.statuses[source]; ok && prev.event.Status() == event.Status() {
+		prev.event = event
+		return
+	}
+	hc.statuses[source] = &componentStatus{event: event, since: event.Timestamp()}
+}
+
+func (hc *healthCheck) handleHealth(w http.ResponseWriter, r *http.Request) {
+	hc.mu.Lock()
+	pipelines := hc.pipelinesHealth()
+	ready := hc.ready
+	hc.mu.Unlock()
+
+	var (
+		body    interface{}
+		healthy bool
+	)
+	if pipelineID := r.URL.Query().Get(pipelineQueryParam); pipelineID != "" {
+		ph, ok := pipelines[pipelineID]
+		if !ok {
+			http.Error(w, fmt.Sprintf("pipeline %q not found", pipelineID), http.StatusNotFound)
+			return
+		}
+		body, healthy = ph, ph.Healthy
+	} else {
+		ch := &collectorHealth{Healthy: ready, Ready: ready, Pipelines: pipelines}
+		for _, ph := range pipelines {
+			ch.Healthy = ch.Healthy && ph.Healthy
+		}
+		body, healthy = ch, ch.Healthy
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		hc.telemetry.Logger.Warn("Failed to write health response", zap.Error(err))
+	}
+}
+
+// pipelinesHealth returns the health of every pipeline with at least one component that reported its status.
+// Must be called with hc.mu held.
+func (hc *healthCheck) pipelinesHealth() map[string]*pipelineHealth {
+	now := hc.now()
+	pipelines := map[string]*pipelineHealth{}
+	for instanceID, cs := range hc.statuses {
+		health := &componentHealth{
+			Healthy: hc.isHealthy(cs, now),
+			Status:  cs.event.Status().String(),
+			Since:   cs.since,
+		}
+		if err := cs.event.Err(); err != nil {
+			health.Error = err.Error()
+		}
+		for _, pipelineID := range instanceID.PipelineIDs {
+			ph, ok := pipelines[pipelineID.String()]
+			if !ok {
+				ph = &pipelineHealth{Healthy: true, Components: map[string]*componentHealth{}}
+				pipelines[pipelineID.String()] = ph
+			}
+			ph.Components[kindString(instanceID.Kind)+":"+instanceID.ID.String()] = health
+			ph.Healthy = ph.Healthy && health.Healthy
+		}
+	}
+	return pipelines
+}
+
+// isHealthy applies the configured policy to the status of a component.
+func (hc *healthCheck) isHealthy(cs *componentStatus, now time.Time) bool {
+	switch cs.event.Status() {
+	case component.StatusOK:
+		return true
+	case component.StatusRecoverableError:
+		return now.Sub(cs.since) < hc.config.RecoveryDuration
+	case component.StatusPermanentError:
+		return !hc.config.IncludePermanentErrors
+	}
+	return false
+}
+
+func kindString(kind component.Kind) string {
+	switch kind {
+	case component.KindReceiver:
+		return "receiver"
+	case component.KindProcessor:
+		return "processor"
+	case component.KindExporter:
+		return "exporter"
+	case component.KindExtension:
+		return "extension"
+	case component.KindConnector:
+		return "connector"
+	}
+	return ""
+}
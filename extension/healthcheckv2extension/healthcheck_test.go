@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckv2extension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+func TestHealthCheckPipelines(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TCPAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.RecoveryDuration = time.Minute
+
+	hc := newHealthCheck(cfg, componenttest.NewNopTelemetrySettings())
+	now := time.Now()
+	// hc.now is only called with hc.mu held.
+	hc.now = func() time.Time { return now }
+	advance := func(d time.Duration) {
+		hc.mu.Lock()
+		defer hc.mu.Unlock()
+		now = now.Add(d)
+	}
+	require.NoError(t, hc.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, hc.Shutdown(context.Background())) })
+
+	traces := component.NewID("traces")
+	metrics := component.NewID("metrics")
+	recv := &component.InstanceID{ID: component.NewID("otlp"), Kind: component.KindReceiver, PipelineIDs: []component.ID{traces, metrics}}
+	tracesExp := &component.InstanceID{ID: component.NewID("otlp"), Kind: component.KindExporter, PipelineIDs: []component.ID{traces}}
+	metricsExp := &component.InstanceID{ID: component.NewID("logging"), Kind: component.KindExporter, PipelineIDs: []component.ID{metrics}}
+	for _, id := range []*component.InstanceID{recv, tracesExp, metricsExp} {
+		hc.ComponentStatusChanged(id, component.NewStatusEvent(component.StatusOK))
+	}
+
+	url := "http://" + cfg.TCPAddr.Endpoint + cfg.Path
+
+	// Not healthy until the pipelines are ready.
+	assertHealth(t, url, http.StatusServiceUnavailable)
+	require.NoError(t, hc.Ready())
+	body := assertHealth(t, url, http.StatusOK)
+	assert.Equal(t, true, body["ready"])
+
+	// A recoverable error only makes the pipeline unhealthy after the recovery duration.
+	hc.ComponentStatusChanged(tracesExp, component.NewRecoverableErrorEvent(errors.New("connection refused")))
+	advance(30 * time.Second)
+	hc.ComponentStatusChanged(tracesExp, component.NewRecoverableErrorEvent(errors.New("connection refused")))
+	assertHealth(t, url, http.StatusOK)
+	advance(time.Minute)
+	body = assertHealth(t, url, http.StatusServiceUnavailable)
+	pipelines := body["pipelines"].(map[string]interface{})
+	assert.Equal(t, false, pipelines["traces"].(map[string]interface{})["healthy"])
+	assert.Equal(t, true, pipelines["metrics"].(map[string]interface{})["healthy"])
+	exp := pipelines["traces"].(map[string]interface{})["components"].(map[string]interface{})["exporter:otlp"].(map[string]interface{})
+	assert.Equal(t, "StatusRecoverableError", exp["status"])
+	assert.Equal(t, "connection refused", exp["error"])
+
+	assertHealth(t, url+"?pipeline=traces", http.StatusServiceUnavailable)
+	assertHealth(t, url+"?pipeline=metrics", http.StatusOK)
+	assertHealth(t, url+"?pipeline=logs", http.StatusNotFound)
+
+	// Recovering makes the pipeline healthy again.
+	hc.ComponentStatusChanged(tracesExp, component.NewStatusEvent(component.StatusOK))
+	assertHealth(t, url, http.StatusOK)
+
+	// Permanent errors make the pipeline unhealthy right away.
+	hc.ComponentStatusChanged(metricsExp, component.NewPermanentErrorEvent(errors.New("invalid config")))
+	assertHealth(t, url+"?pipeline=metrics", http.StatusServiceUnavailable)
+	assertHealth(t, url+"?pipeline=traces", http.StatusOK)
+
+	require.NoError(t, hc.NotReady())
+	assertHealth(t, url+"?pipeline=traces", http.StatusOK)
+	assertHealth(t, url, http.StatusServiceUnavailable)
+}
+
+func TestHealthCheckExcludePermanentErrors(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.IncludePermanentErrors = false
+	hc := newHealthCheck(cfg, componenttest.NewNopTelemetrySettings())
+
+	exp := &component.InstanceID{ID: component.NewID("otlp"), Kind: component.KindExporter, PipelineIDs: []component.ID{component.NewID("traces")}}
+	hc.ComponentStatusChanged(exp, component.NewPermanentErrorEvent(errors.New("invalid config")))
+	assert.True(t, hc.pipelinesHealth()["traces"].Healthy)
+
+	hc.ComponentStatusChanged(exp, component.NewStatusEvent(component.StatusStopped))
+	assert.False(t, hc.pipelinesHealth()["traces"].Healthy)
+}
+
+func TestHealthCheckStartFailsOnUsedPort(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TCPAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	hc := newHealthCheck(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, hc.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, hc.Shutdown(context.Background())) })
+
+	other := newHealthCheck(cfg, componenttest.NewNopTelemetrySettings())
+	assert.Error(t, other.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func assertHealth(t *testing.T, url string, expectedCode int) map[string]interface{} {
+	resp, err := http.Get(url) //nolint:gosec
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, expectedCode, resp.StatusCode)
+
+	body := map[string]interface{}{}
+	if resp.StatusCode != http.StatusNotFound {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	}
+	return body
+}
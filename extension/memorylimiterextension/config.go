@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorylimiterextension // import "go.opentelemetry.io/collector/extension/memorylimiterextension"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config has the configuration for the memory limiter extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// CheckInterval is the time between measurements of memory usage.
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+
+	// MemoryLimitMiB is the maximum amount of memory, in MiB, targeted to be
+	// allocated by the process.
+	MemoryLimitMiB uint32 `mapstructure:"limit_mib"`
+
+	// MemorySpikeLimitMiB is the maximum, in MiB, spike expected between the
+	// measurements of memory usage. Defaults to 20% of MemoryLimitMiB.
+	MemorySpikeLimitMiB uint32 `mapstructure:"spike_limit_mib"`
+
+	// MemoryLimitPercentage is the maximum amount of memory, in %, targeted to be
+	// allocated by the process. The fixed memory settings MemoryLimitMiB has a higher precedence.
+	MemoryLimitPercentage uint32 `mapstructure:"limit_percentage"`
+
+	// MemorySpikePercentage is the maximum, in percents against the total memory,
+	// spike expected between the measurements of memory usage.
+	MemorySpikePercentage uint32 `mapstructure:"spike_limit_percentage"`
+}
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.CheckInterval <= 0 {
+		return errors.New("check_interval must be greater than zero")
+	}
+	if cfg.MemoryLimitMiB == 0 && cfg.MemoryLimitPercentage == 0 {
+		return errors.New("limit_mib or limit_percentage must be greater than zero")
+	}
+	if cfg.MemoryLimitMiB != 0 && cfg.MemorySpikeLimitMiB >= cfg.MemoryLimitMiB {
+		return errors.New("spike_limit_mib must be smaller than limit_mib")
+	}
+	if cfg.MemoryLimitMiB == 0 && (cfg.MemoryLimitPercentage > 100 || cfg.MemorySpikePercentage == 0 || cfg.MemorySpikePercentage >= cfg.MemoryLimitPercentage) {
+		return errors.New("limit_percentage must not be greater than 100, and spike_limit_percentage must be greater than zero and smaller than limit_percentage")
+	}
+	return nil
+}
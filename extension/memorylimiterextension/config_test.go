@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorylimiterextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings:   config.NewExtensionSettings(component.NewID(typeStr)),
+			CheckInterval:       2 * time.Second,
+			MemoryLimitMiB:      4000,
+			MemorySpikeLimitMiB: 500,
+		}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  string
+	}{
+		{
+			name: "NoCheckInterval",
+			cfg:  &Config{MemoryLimitMiB: 100},
+			err:  "check_interval must be greater than zero",
+		},
+		{
+			name: "NoLimit",
+			cfg:  &Config{CheckInterval: time.Second},
+			err:  "limit_mib or limit_percentage must be greater than zero",
+		},
+		{
+			name: "SpikeAboveLimit",
+			cfg:  &Config{CheckInterval: time.Second, MemoryLimitMiB: 100, MemorySpikeLimitMiB: 100},
+			err:  "spike_limit_mib must be smaller than limit_mib",
+		},
+		{
+			name: "PercentageOutOfRange",
+			cfg:  &Config{CheckInterval: time.Second, MemoryLimitPercentage: 150, MemorySpikePercentage: 10},
+			err:  "limit_percentage must not be greater than 100, and spike_limit_percentage must be greater than zero and smaller than limit_percentage",
+		},
+		{
+			name: "Percentage",
+			cfg:  &Config{CheckInterval: time.Second, MemoryLimitPercentage: 80, MemorySpikePercentage: 20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
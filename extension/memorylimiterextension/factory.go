@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorylimiterextension // import "go.opentelemetry.io/collector/extension/memorylimiterextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	// The value of extension "type" in configuration.
+	typeStr = "memory_limiter"
+)
+
+// NewFactory creates a factory for the memory limiter extension.
+func NewFactory() component.ExtensionFactory {
+	return component.NewExtensionFactory(typeStr, createDefaultConfig, component.NewCreateExtensionFunc(createExtension), component.StabilityLevelAlpha)
+}
+
+// createDefaultConfig creates the default configuration for the extension. Notice
+// that the default configuration is expected to fail, the limit must be set.
+func createDefaultConfig() component.Config {
+	return &Config{
+		ExtensionSettings: config.NewExtensionSettings(component.NewID(typeStr)),
+		CheckInterval:     time.Second,
+	}
+}
+
+func createExtension(_ context.Context, set component.ExtensionCreateSettings, cfg *Config) (component.Extension, error) {
+	return newMemoryLimiter(cfg, set.Logger)
+}
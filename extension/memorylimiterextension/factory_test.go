@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorylimiterextension
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.Equal(t, &Config{
+		ExtensionSettings: config.NewExtensionSettings(component.NewID(typeStr)),
+		CheckInterval:     time.Second,
+	}, cfg)
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+	// The limit must be configured.
+	assert.Error(t, component.ValidateConfig(cfg))
+}
+
+func TestFactory_CreateExtension(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MemoryLimitMiB = 1024
+	ext, err := NewFactory().CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, ext.Shutdown(context.Background()))
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorylimiterextension // import "go.opentelemetry.io/collector/extension/memorylimiterextension"
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/extension/experimental/memorylimiter"
+	internalmemorylimiter "go.opentelemetry.io/collector/internal/memorylimiter"
+)
+
+var _ memorylimiter.Extension = (*memoryLimiter)(nil)
+
+// memoryLimiter periodically checks the memory usage, and reports through MustRefuse when it is above
+// the soft limit, so that the receivers refuse new requests. It forces a GC above the hard limit.
+type memoryLimiter struct {
+	*internalmemorylimiter.MemoryLimiter
+}
+
+func newMemoryLimiter(cfg *Config, logger *zap.Logger) (*memoryLimiter, error) {
+	ml, err := internalmemorylimiter.New(internalmemorylimiter.Settings{
+		CheckInterval:         cfg.CheckInterval,
+		MemoryLimitMiB:        cfg.MemoryLimitMiB,
+		MemorySpikeLimitMiB:   cfg.MemorySpikeLimitMiB,
+		MemoryLimitPercentage: cfg.MemoryLimitPercentage,
+		MemorySpikePercentage: cfg.MemorySpikePercentage,
+	}, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &memoryLimiter{MemoryLimiter: ml}, nil
+}
+
+// Shutdown stops checking the memory usage, the extension can be shut down without being started.
+func (ml *memoryLimiter) Shutdown(ctx context.Context) error {
+	if err := ml.MemoryLimiter.Shutdown(ctx); !errors.Is(err, internalmemorylimiter.ErrShutdownNotStarted) {
+		return err
+	}
+	return nil
+}
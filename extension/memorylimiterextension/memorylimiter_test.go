@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorylimiterextension
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+type ballastExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	size uint64
+}
+
+func (b ballastExtension) GetBallastSize() uint64 {
+	return b.size
+}
+
+type ballastHost struct {
+	component.Host
+	ballast ballastExtension
+}
+
+func (h ballastHost) GetExtensions() map[component.ID]component.Component {
+	return map[component.ID]component.Component{component.NewID("memory_ballast"): h.ballast}
+}
+
+func TestMemoryLimiterMustRefuse(t *testing.T) {
+	ml, err := newMemoryLimiter(&Config{CheckInterval: time.Hour, MemoryLimitMiB: 1 << 20}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ml.Start(context.Background(), ballastHost{Host: componenttest.NewNopHost(), ballast: ballastExtension{size: 50 << 20}}))
+	t.Cleanup(func() { assert.NoError(t, ml.Shutdown(context.Background())) })
+
+	// The memory usage is far below the limit.
+	ml.CheckMemLimits()
+	assert.False(t, ml.MustRefuse())
+}
+
+func TestMemoryLimiterShutdownWithoutStart(t *testing.T) {
+	ml, err := newMemoryLimiter(&Config{CheckInterval: time.Second, MemoryLimitMiB: 100}, zap.NewNop())
+	require.NoError(t, err)
+	assert.NoError(t, ml.Shutdown(context.Background()))
+}
@@ -31,7 +31,7 @@ const (
 
 // NewFactory creates a factory for Z-Pages extension.
 func NewFactory() component.ExtensionFactory {
-	return component.NewExtensionFactory(typeStr, createDefaultConfig, createExtension, component.StabilityLevelBeta)
+	return component.NewExtensionFactory(typeStr, createDefaultConfig, component.NewCreateExtensionFunc(createExtension), component.StabilityLevelBeta)
 }
 
 func createDefaultConfig() component.Config {
@@ -44,6 +44,6 @@ func createDefaultConfig() component.Config {
 }
 
 // createExtension creates the extension based on this config.
-func createExtension(_ context.Context, set component.ExtensionCreateSettings, cfg component.Config) (component.Extension, error) {
-	return newServer(cfg.(*Config), set.TelemetrySettings), nil
+func createExtension(_ context.Context, set component.ExtensionCreateSettings, cfg *Config) (component.Extension, error) {
+	return newServer(cfg, set.TelemetrySettings), nil
 }
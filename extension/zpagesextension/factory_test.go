@@ -39,7 +39,7 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 		cfg)
 
 	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
-	ext, err := createExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	ext, err := createExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg.(*Config))
 	require.NoError(t, err)
 	require.NotNil(t, ext)
 }
@@ -40,6 +40,7 @@
 package cgroups // import "go.opentelemetry.io/collector/internal/cgroups"
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -62,9 +63,22 @@ const (
 
 	_cgroupMemoryLimitBytes = "memory.limit_in_bytes"
 
+	// _cgroupCPUCFSQuotaUsParam is the file name for the CGroup CFS quota
+	// parameter.
+	_cgroupCPUCFSQuotaUsParam = "cpu.cfs_quota_us"
+	// _cgroupCPUCFSPeriodUsParam is the file name for the CGroup CFS period
+	// parameter.
+	_cgroupCPUCFSPeriodUsParam = "cpu.cfs_period_us"
+
 	// _cgroupv2MemoryMax is the file name for the CGroup-V2 Memory max
 	// parameter.
 	_cgroupv2MemoryMax = "memory.max"
+	// _cgroupv2CPUMax is the file name for the CGroup-V2 CPU max and period
+	// parameter.
+	_cgroupv2CPUMax = "cpu.max"
+	// _cgroupv2DefaultPeriodUs is the CFS period used by CGroup-V2 when
+	// `cpu.max` only defines the quota.
+	_cgroupv2DefaultPeriodUs = 100 * 1000
 	// _cgroupFSType is the Linux CGroup-V2 file system type used in
 	// `/proc/$PID/mountinfo`.
 	_cgroupv2FSType = "cgroup2"
@@ -138,6 +152,28 @@ func (cg CGroups) MemoryQuota() (int64, bool, error) {
 	return memLimitBytes, true, nil
 }
 
+// CPUQuota returns the CPU quota applied with the CPU cgroup controller.
+// It is a result of `cpu.cfs_quota_us / cpu.cfs_period_us`. If the value of
+// `cpu.cfs_quota_us` was not set (-1), the method returns `(-1, false, nil)`.
+func (cg CGroups) CPUQuota() (float64, bool, error) {
+	cpuCGroup, exists := cg[_cgroupSubsysCPU]
+	if !exists {
+		return -1, false, nil
+	}
+
+	cfsQuotaUs, err := cpuCGroup.readInt(_cgroupCPUCFSQuotaUsParam)
+	if defined := cfsQuotaUs > 0; err != nil || !defined {
+		return -1, defined, err
+	}
+
+	cfsPeriodUs, err := cpuCGroup.readInt(_cgroupCPUCFSPeriodUsParam)
+	if defined := cfsPeriodUs > 0; err != nil || !defined {
+		return -1, defined, err
+	}
+
+	return float64(cfsQuotaUs) / float64(cfsPeriodUs), true, nil
+}
+
 // IsCGroupV2 returns true if the system supports and uses cgroup2.
 // It gets the required information for deciding from mountinfo file.
 func IsCGroupV2() (bool, error) {
@@ -190,3 +226,51 @@ func memoryQuotaV2(cgroupv2MountPoint, cgroupv2MemoryMax string) (int64, bool, e
 	}
 	return -1, false, io.ErrUnexpectedEOF
 }
+
+// CPUQuotaV2 returns the CPU quota applied with the cgroupv2 CPU controller.
+// It is a result of cgroupv2 `cpu.max` (quota divided by period). If the quota
+// in `cpu.max` was not set (max), the method returns `(-1, false, nil)`.
+func CPUQuotaV2() (float64, bool, error) {
+	return cpuQuotaV2(_cgroupv2MountPoint, _cgroupv2CPUMax)
+}
+
+func cpuQuotaV2(cgroupv2MountPoint, cgroupv2CPUMax string) (float64, bool, error) {
+	cpuMaxParams, err := os.Open(filepath.Clean(filepath.Join(cgroupv2MountPoint, cgroupv2CPUMax)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return -1, false, nil
+		}
+		return -1, false, err
+	}
+	defer cpuMaxParams.Close()
+
+	scanner := bufio.NewScanner(cpuMaxParams)
+	if scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || len(fields) > 2 {
+			return -1, false, fmt.Errorf("invalid format for %s: %q", cgroupv2CPUMax, scanner.Text())
+		}
+		if fields[0] == "max" {
+			return -1, false, nil
+		}
+		max, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return -1, false, err
+		}
+		period := _cgroupv2DefaultPeriodUs
+		if len(fields) == 2 {
+			period, err = strconv.Atoi(fields[1])
+			if err != nil {
+				return -1, false, err
+			}
+			if period <= 0 {
+				return -1, false, fmt.Errorf("invalid period in %s: %d", cgroupv2CPUMax, period)
+			}
+		}
+		return float64(max) / float64(period), true, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return -1, false, err
+	}
+	return -1, false, io.ErrUnexpectedEOF
+}
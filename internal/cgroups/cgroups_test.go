@@ -143,6 +143,56 @@ func TestCGroupsMemoryQuota(t *testing.T) {
 	}
 }
 
+func TestCGroupsCPUQuota(t *testing.T) {
+	testTable := []struct {
+		name            string
+		expectedQuota   float64
+		expectedDefined bool
+		shouldHaveError bool
+	}{
+		{
+			name:            "cpu",
+			expectedQuota:   6.0,
+			expectedDefined: true,
+			shouldHaveError: false,
+		},
+		{
+			name:            "undefined",
+			expectedQuota:   -1.0,
+			expectedDefined: false,
+			shouldHaveError: false,
+		},
+		{
+			name:            "undefined-period",
+			expectedQuota:   -1.0,
+			expectedDefined: false,
+			shouldHaveError: true,
+		},
+	}
+
+	cgroups := make(CGroups)
+
+	quota, defined, err := cgroups.CPUQuota()
+	assert.Equal(t, -1.0, quota, "nonexistent")
+	assert.False(t, defined, "nonexistent")
+	assert.NoError(t, err, "nonexistent")
+
+	for _, tt := range testTable {
+		cgroupPath := filepath.Join(testDataCGroupsPath, tt.name)
+		cgroups[_cgroupSubsysCPU] = NewCGroup(cgroupPath)
+
+		quota, defined, err := cgroups.CPUQuota()
+		assert.Equal(t, tt.expectedQuota, quota, tt.name)
+		assert.Equal(t, tt.expectedDefined, defined, tt.name)
+
+		if tt.shouldHaveError {
+			assert.Error(t, err, tt.name)
+		} else {
+			assert.NoError(t, err, tt.name)
+		}
+	}
+}
+
 func TestCGroupsIsCGroupV2(t *testing.T) {
 	testTable := []struct {
 		name            string
@@ -237,3 +287,62 @@ func TestCGroupsMemoryQuotaV2(t *testing.T) {
 		}
 	}
 }
+
+func TestCGroupsCPUQuotaV2(t *testing.T) {
+	testTable := []struct {
+		name            string
+		expectedQuota   float64
+		expectedDefined bool
+		shouldHaveError bool
+	}{
+		{
+			name:            "cpu",
+			expectedQuota:   2.5,
+			expectedDefined: true,
+			shouldHaveError: false,
+		},
+		{
+			name:            "cpu-no-period",
+			expectedQuota:   1.5,
+			expectedDefined: true,
+			shouldHaveError: false,
+		},
+		{
+			name:            "cpu-undefined",
+			expectedQuota:   -1.0,
+			expectedDefined: false,
+			shouldHaveError: false,
+		},
+		{
+			name:            "cpu-invalid",
+			expectedQuota:   -1.0,
+			expectedDefined: false,
+			shouldHaveError: true,
+		},
+		{
+			name:            "cpu-empty",
+			expectedQuota:   -1.0,
+			expectedDefined: false,
+			shouldHaveError: true,
+		},
+	}
+
+	quota, defined, err := cpuQuotaV2("nonexistent", "nonexistent")
+	assert.Equal(t, -1.0, quota, "nonexistent")
+	assert.Equal(t, false, defined, "nonexistent")
+	assert.NoError(t, err, "nonexistent")
+
+	cgroupBasePath := filepath.Join(testDataCGroupsPath, "v2")
+	for _, tt := range testTable {
+		cgroupPath := filepath.Join(cgroupBasePath, tt.name)
+		quota, defined, err := cpuQuotaV2(cgroupPath, "cpu.max")
+		assert.Equal(t, tt.expectedQuota, quota, tt.name)
+		assert.Equal(t, tt.expectedDefined, defined, tt.name)
+
+		if tt.shouldHaveError {
+			assert.Error(t, err, tt.name)
+		} else {
+			assert.NoError(t, err, tt.name)
+		}
+	}
+}
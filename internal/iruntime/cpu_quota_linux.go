@@ -0,0 +1,39 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package iruntime // import "go.opentelemetry.io/collector/internal/iruntime"
+
+import "go.opentelemetry.io/collector/internal/cgroups"
+
+// CPUQuota returns the number of CPUs the process is allowed to use and whether a quota is defined.
+// This implementation is meant for linux and uses cgroups to determine the CPU quota.
+func CPUQuota() (float64, bool, error) {
+	isV2, err := cgroups.IsCGroupV2()
+	if err != nil {
+		return 0, false, err
+	}
+
+	if isV2 {
+		return cgroups.CPUQuotaV2()
+	}
+
+	cgv1, err := cgroups.NewCGroupsForCurrentProcess()
+	if err != nil {
+		return 0, false, err
+	}
+	return cgv1.CPUQuota()
+}
@@ -0,0 +1,24 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+// +build !linux
+
+package iruntime // import "go.opentelemetry.io/collector/internal/iruntime"
+
+// CPUQuota returns the number of CPUs the process is allowed to use and whether a quota is defined.
+// CPU quotas are only detected on linux, for other platforms no quota is ever defined.
+func CPUQuota() (float64, bool, error) {
+	return -1, false, nil
+}
@@ -0,0 +1,301 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package memorylimiter checks the memory usage of the collector periodically, and reports when it is above
+// the soft limit. It is shared by the memory limiter processor, which drops data, and the memory limiter
+// extension, which makes the receivers refuse data.
+package memorylimiter // import "go.opentelemetry.io/collector/internal/memorylimiter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/iruntime"
+)
+
+const mibBytes = 1024 * 1024
+
+// Minimum interval between forced GC when in soft limited mode. We don't want to
+// do GCs too frequently since it is a CPU-heavy operation.
+const minGCIntervalWhenSoftLimited = 10 * time.Second
+
+var (
+	// ErrCheckIntervalOutOfRange is returned by New when the check interval is not positive.
+	ErrCheckIntervalOutOfRange = errors.New("checkInterval must be greater than zero")
+
+	// ErrLimitOutOfRange is returned by New when neither a fixed nor a percentage limit is set.
+	ErrLimitOutOfRange = errors.New("memAllocLimit or memoryLimitPercentage must be greater than zero")
+
+	// ErrMemSpikeLimitOutOfRange is returned by New when the spike limit is not smaller than the limit.
+	ErrMemSpikeLimitOutOfRange = errors.New("memSpikeLimit must be smaller than memAllocLimit")
+
+	// ErrPercentageLimitOutOfRange is returned by New when the percentages are out of range.
+	ErrPercentageLimitOutOfRange = errors.New(
+		"memoryLimitPercentage and memorySpikePercentage must be greater than zero and less than or equal to hundred")
+
+	// ErrShutdownNotStarted is returned by Shutdown when the MemoryLimiter is not started.
+	ErrShutdownNotStarted = errors.New("no existing monitoring routine is running")
+)
+
+// make it overridable by tests
+var getMemoryFn = iruntime.TotalMemory
+
+// Settings are the limits of a MemoryLimiter, see the Config of the memory limiter processor and extension.
+type Settings struct {
+	CheckInterval         time.Duration
+	MemoryLimitMiB        uint32
+	MemorySpikeLimitMiB   uint32
+	MemoryLimitPercentage uint32
+	MemorySpikePercentage uint32
+}
+
+// MemoryLimiter checks the memory usage every check interval once started, and reports through MustRefuse
+// when it is above the soft limit. It forces a GC above the hard limit, and when the soft limit is exceeded.
+// It can be started by several components, it stops checking once all of them are shut down.
+type MemoryLimiter struct {
+	usageChecker memUsageChecker
+
+	checkInterval time.Duration
+	ballastSize   uint64
+
+	// mustRefuse is used atomically to indicate when data should be refused.
+	mustRefuse *atomic.Bool
+
+	lastGCDone time.Time
+
+	// The function to read the mem values is set as a reference to help with
+	// testing different values.
+	readMemStatsFn func(m *runtime.MemStats)
+
+	// Fields used for logging.
+	logger                 *zap.Logger
+	configMismatchedLogged bool
+
+	refCounterLock sync.Mutex
+	refCounter     int
+	stopCh         chan struct{}
+	wg             sync.WaitGroup
+}
+
+// New returns a new MemoryLimiter, checking the memory usage against the given limits once started.
+func New(set Settings, logger *zap.Logger) (*MemoryLimiter, error) {
+	if set.CheckInterval <= 0 {
+		return nil, ErrCheckIntervalOutOfRange
+	}
+	if set.MemoryLimitMiB == 0 && set.MemoryLimitPercentage == 0 {
+		return nil, ErrLimitOutOfRange
+	}
+
+	usageChecker, err := getMemUsageChecker(set, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Memory limiter configured",
+		zap.Uint64("limit_mib", usageChecker.memAllocLimit/mibBytes),
+		zap.Uint64("spike_limit_mib", usageChecker.memSpikeLimit/mibBytes),
+		zap.Duration("check_interval", set.CheckInterval))
+
+	return &MemoryLimiter{
+		usageChecker:   *usageChecker,
+		checkInterval:  set.CheckInterval,
+		mustRefuse:     atomic.NewBool(false),
+		readMemStatsFn: runtime.ReadMemStats,
+		logger:         logger,
+	}, nil
+}
+
+func getMemUsageChecker(set Settings, logger *zap.Logger) (*memUsageChecker, error) {
+	memAllocLimit := uint64(set.MemoryLimitMiB) * mibBytes
+	memSpikeLimit := uint64(set.MemorySpikeLimitMiB) * mibBytes
+	if set.MemoryLimitMiB != 0 {
+		return newFixedMemUsageChecker(memAllocLimit, memSpikeLimit)
+	}
+	totalMemory, err := getMemoryFn()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total memory, use fixed memory settings (limit_mib): %w", err)
+	}
+	logger.Info("Using percentage memory limiter",
+		zap.Uint64("total_memory_mib", totalMemory/mibBytes),
+		zap.Uint32("limit_percentage", set.MemoryLimitPercentage),
+		zap.Uint32("spike_limit_percentage", set.MemorySpikePercentage))
+	return newPercentageMemUsageChecker(totalMemory, uint64(set.MemoryLimitPercentage), uint64(set.MemorySpikePercentage))
+}
+
+// Start starts checking the memory usage, unless it is already started by another component. The size of
+// the ballast of the host's memory ballast extension, if any, is not counted in the memory usage.
+func (ml *MemoryLimiter) Start(_ context.Context, host component.Host) error {
+	ml.refCounterLock.Lock()
+	defer ml.refCounterLock.Unlock()
+
+	ml.refCounter++
+	if ml.refCounter > 1 {
+		return nil
+	}
+	for _, extension := range host.GetExtensions() {
+		if ext, ok := extension.(interface{ GetBallastSize() uint64 }); ok {
+			ml.ballastSize = ext.GetBallastSize()
+			break
+		}
+	}
+
+	ml.stopCh = make(chan struct{})
+	ml.wg.Add(1)
+	go func() {
+		defer ml.wg.Done()
+		ticker := time.NewTicker(ml.checkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ml.CheckMemLimits()
+			case <-ml.stopCh:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops checking the memory usage once all the components that started the MemoryLimiter are shut
+// down. It returns ErrShutdownNotStarted if the MemoryLimiter is not started.
+func (ml *MemoryLimiter) Shutdown(context.Context) error {
+	ml.refCounterLock.Lock()
+	defer ml.refCounterLock.Unlock()
+
+	if ml.refCounter == 0 {
+		return ErrShutdownNotStarted
+	}
+	ml.refCounter--
+	if ml.refCounter == 0 {
+		close(ml.stopCh)
+		ml.wg.Wait()
+	}
+	return nil
+}
+
+// MustRefuse returns true while the memory usage is above the soft limit.
+func (ml *MemoryLimiter) MustRefuse() bool {
+	return ml.mustRefuse.Load()
+}
+
+func (ml *MemoryLimiter) readMemStats() *runtime.MemStats {
+	ms := &runtime.MemStats{}
+	ml.readMemStatsFn(ms)
+	// If proper configured ms.Alloc should be at least ml.ballastSize but since
+	// a misconfiguration is possible check for that here.
+	if ms.Alloc >= ml.ballastSize {
+		ms.Alloc -= ml.ballastSize
+	} else if !ml.configMismatchedLogged {
+		// This indicates misconfiguration. Log it once.
+		ml.configMismatchedLogged = true
+		ml.logger.Warn(`"size_mib" in ballast extension is likely incorrectly configured.`)
+	}
+
+	return ms
+}
+
+func memstatToZapField(ms *runtime.MemStats) zap.Field {
+	return zap.Uint64("cur_mem_mib", ms.Alloc/mibBytes)
+}
+
+func (ml *MemoryLimiter) doGCandReadMemStats() *runtime.MemStats {
+	runtime.GC()
+	ml.lastGCDone = time.Now()
+	ms := ml.readMemStats()
+	ml.logger.Info("Memory usage after GC.", memstatToZapField(ms))
+	return ms
+}
+
+// CheckMemLimits checks the memory usage once, the started MemoryLimiter calls it every check interval.
+func (ml *MemoryLimiter) CheckMemLimits() {
+	ms := ml.readMemStats()
+
+	ml.logger.Debug("Currently used memory.", memstatToZapField(ms))
+
+	if ml.usageChecker.aboveHardLimit(ms) {
+		ml.logger.Warn("Memory usage is above hard limit. Forcing a GC.", memstatToZapField(ms))
+		ms = ml.doGCandReadMemStats()
+	}
+
+	// Remember current refusing state.
+	wasRefusing := ml.mustRefuse.Load()
+
+	// Check if the memory usage is above the soft limit.
+	mustRefuse := ml.usageChecker.aboveSoftLimit(ms)
+
+	if wasRefusing && !mustRefuse {
+		// Was previously refusing but enough memory is available now, no need to limit.
+		ml.logger.Info("Memory usage back within limits. Resuming normal operation.", memstatToZapField(ms))
+	}
+
+	if !wasRefusing && mustRefuse {
+		// We are above soft limit, do a GC if it wasn't done recently and see if
+		// it brings memory usage below the soft limit.
+		if time.Since(ml.lastGCDone) > minGCIntervalWhenSoftLimited {
+			ml.logger.Info("Memory usage is above soft limit. Forcing a GC.", memstatToZapField(ms))
+			ms = ml.doGCandReadMemStats()
+			// Check the limit again to see if GC helped.
+			mustRefuse = ml.usageChecker.aboveSoftLimit(ms)
+		}
+
+		if mustRefuse {
+			ml.logger.Warn("Memory usage is above soft limit. Refusing data.", memstatToZapField(ms))
+		}
+	}
+
+	ml.mustRefuse.Store(mustRefuse)
+}
+
+type memUsageChecker struct {
+	memAllocLimit uint64
+	memSpikeLimit uint64
+}
+
+func (d memUsageChecker) aboveSoftLimit(ms *runtime.MemStats) bool {
+	return ms.Alloc >= d.memAllocLimit-d.memSpikeLimit
+}
+
+func (d memUsageChecker) aboveHardLimit(ms *runtime.MemStats) bool {
+	return ms.Alloc >= d.memAllocLimit
+}
+
+func newFixedMemUsageChecker(memAllocLimit, memSpikeLimit uint64) (*memUsageChecker, error) {
+	if memSpikeLimit >= memAllocLimit {
+		return nil, ErrMemSpikeLimitOutOfRange
+	}
+	if memSpikeLimit == 0 {
+		// If spike limit is unspecified use 20% of mem limit.
+		memSpikeLimit = memAllocLimit / 5
+	}
+	return &memUsageChecker{
+		memAllocLimit: memAllocLimit,
+		memSpikeLimit: memSpikeLimit,
+	}, nil
+}
+
+func newPercentageMemUsageChecker(totalMemory uint64, percentageLimit, percentageSpike uint64) (*memUsageChecker, error) {
+	if percentageLimit > 100 || percentageLimit <= 0 || percentageSpike > 100 || percentageSpike <= 0 {
+		return nil, ErrPercentageLimitOutOfRange
+	}
+	return newFixedMemUsageChecker(percentageLimit*totalMemory/100, percentageSpike*totalMemory/100)
+}
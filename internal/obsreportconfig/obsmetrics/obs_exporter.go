@@ -23,6 +23,11 @@ const (
 	// ExporterKey used to identify exporters in metrics and traces.
 	ExporterKey = "exporter"
 
+	// ShardKey used to identify the destination shard or endpoint that a request was sent to.
+	ShardKey = "shard"
+	// ResponseClassKey used to identify the class of the response received for a request.
+	ResponseClassKey = "response_class"
+
 	// SentSpansKey used to track spans sent by exporters.
 	SentSpansKey = "sent_spans"
 	// FailedToSendSpansKey used to track spans that failed to be sent by exporters.
@@ -37,10 +42,32 @@ const (
 	SentLogRecordsKey = "sent_log_records"
 	// FailedToSendLogRecordsKey used to track logs that failed to be sent by exporters.
 	FailedToSendLogRecordsKey = "send_failed_log_records"
+
+	// SentBytesKey used to track the bytes of the requests sent by exporters.
+	SentBytesKey = "sent_bytes"
+	// FailedToSendBytesKey used to track the bytes of the requests that failed to be sent by exporters.
+	FailedToSendBytesKey = "send_failed_bytes"
+
+	// SendDurationKey used to track the duration of the requests sent by exporters.
+	SendDurationKey = "send_duration"
+	// PayloadAgeKey used to identify the age of the oldest item of the requests sent by exporters.
+	PayloadAgeKey = "payload_age"
+
+	// DroppedByPolicySpansKey used to track spans dropped by the failure policy of a pipeline after exporters failed.
+	DroppedByPolicySpansKey = "dropped_by_policy_spans"
+	// DroppedByPolicyMetricPointsKey used to track metric points dropped by the failure policy of a pipeline after
+	// exporters failed.
+	DroppedByPolicyMetricPointsKey = "dropped_by_policy_metric_points"
+	// DroppedByPolicyLogRecordsKey used to track log records dropped by the failure policy of a pipeline after
+	// exporters failed.
+	DroppedByPolicyLogRecordsKey = "dropped_by_policy_log_records"
 )
 
 var (
-	TagKeyExporter, _ = tag.NewKey(ExporterKey)
+	TagKeyExporter, _      = tag.NewKey(ExporterKey)
+	TagKeyShard, _         = tag.NewKey(ShardKey)
+	TagKeyResponseClass, _ = tag.NewKey(ResponseClassKey)
+	TagKeyPayloadAge, _    = tag.NewKey(PayloadAgeKey)
 
 	ExporterPrefix                 = ExporterKey + NameSep
 	ExportTraceDataOperationSuffix = NameSep + "traces"
@@ -76,4 +103,28 @@ var (
 		ExporterPrefix+FailedToSendLogRecordsKey,
 		"Number of log records in failed attempts to send to destination.",
 		stats.UnitDimensionless)
+	ExporterSentBytes = stats.Int64(
+		ExporterPrefix+SentBytesKey,
+		"Size in bytes of the requests successfully sent to destination.",
+		stats.UnitBytes)
+	ExporterFailedToSendBytes = stats.Int64(
+		ExporterPrefix+FailedToSendBytesKey,
+		"Size in bytes of the requests in failed attempts to send to destination.",
+		stats.UnitBytes)
+	ExporterSendDuration = stats.Int64(
+		ExporterPrefix+SendDurationKey,
+		"Duration of the attempts to send requests to destination.",
+		stats.UnitMilliseconds)
+	ExporterDroppedByPolicySpans = stats.Int64(
+		ExporterPrefix+DroppedByPolicySpansKey,
+		"Number of spans the exporter failed to send whose error was dropped by the failure policy of the pipeline.",
+		stats.UnitDimensionless)
+	ExporterDroppedByPolicyMetricPoints = stats.Int64(
+		ExporterPrefix+DroppedByPolicyMetricPointsKey,
+		"Number of metric points the exporter failed to send whose error was dropped by the failure policy of the pipeline.",
+		stats.UnitDimensionless)
+	ExporterDroppedByPolicyLogRecords = stats.Int64(
+		ExporterPrefix+DroppedByPolicyLogRecordsKey,
+		"Number of log records the exporter failed to send whose error was dropped by the failure policy of the pipeline.",
+		stats.UnitDimensionless)
 )
@@ -43,6 +43,12 @@ const (
 	// RefusedLogRecordsKey used to identify log records refused (ie.: not ingested) by the
 	// Collector.
 	RefusedLogRecordsKey = "refused_log_records"
+
+	// AcceptedBytesKey used to identify the bytes of the requests accepted by the Collector.
+	AcceptedBytesKey = "accepted_bytes"
+	// RefusedBytesKey used to identify the bytes of the requests refused (ie.: not ingested) by the
+	// Collector.
+	RefusedBytesKey = "refused_bytes"
 )
 
 var (
@@ -83,4 +89,12 @@ var (
 		ReceiverPrefix+RefusedLogRecordsKey,
 		"Number of log records that could not be pushed into the pipeline.",
 		stats.UnitDimensionless)
+	ReceiverAcceptedBytes = stats.Int64(
+		ReceiverPrefix+AcceptedBytesKey,
+		"Size in bytes of the requests successfully pushed into the pipeline.",
+		stats.UnitBytes)
+	ReceiverRefusedBytes = stats.Int64(
+		ReceiverPrefix+RefusedBytesKey,
+		"Size in bytes of the requests that could not be pushed into the pipeline.",
+		stats.UnitBytes)
 )
@@ -29,6 +29,21 @@ const (
 	// ErroredMetricPointsKey used to identify metric points errored (i.e.
 	// unable to be scraped) by the Collector.
 	ErroredMetricPointsKey = "errored_metric_points"
+	// ScrapeDurationKey used to identify the duration of the scrape operations.
+	ScrapeDurationKey = "scrape_duration"
+	// ScrapeErrorsKey used to identify the scrape operations that failed.
+	ScrapeErrorsKey = "scrape_errors"
+	// ScrapeErrorTypeKey used to identify the type of error of a failed scrape operation.
+	ScrapeErrorTypeKey = "error_type"
+)
+
+const (
+	// ScrapeErrorTypePartial is the error type of scrape operations that failed to scrape some metric points.
+	ScrapeErrorTypePartial = "partial"
+	// ScrapeErrorTypeTimeout is the error type of scrape operations that did not complete before their deadline.
+	ScrapeErrorTypeTimeout = "timeout"
+	// ScrapeErrorTypeFailed is the error type of scrape operations that failed to scrape any metric point.
+	ScrapeErrorTypeFailed = "failed"
 )
 
 const (
@@ -37,7 +52,8 @@ const (
 )
 
 var (
-	TagKeyScraper, _ = tag.NewKey(ScraperKey)
+	TagKeyScraper, _         = tag.NewKey(ScraperKey)
+	TagKeyScrapeErrorType, _ = tag.NewKey(ScrapeErrorTypeKey)
 
 	ScraperScrapedMetricPoints = stats.Int64(
 		ScraperPrefix+ScrapedMetricPointsKey,
@@ -47,4 +63,12 @@ var (
 		ScraperPrefix+ErroredMetricPointsKey,
 		"Number of metric points that were unable to be scraped.",
 		stats.UnitDimensionless)
+	ScraperScrapeDuration = stats.Int64(
+		ScraperPrefix+ScrapeDurationKey,
+		"Duration of the scrape operations.",
+		stats.UnitMilliseconds)
+	ScraperScrapeErrors = stats.Int64(
+		ScraperPrefix+ScrapeErrorsKey,
+		"Number of scrape operations that failed, by type of error.",
+		stats.UnitDimensionless)
 )
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsmetrics // import "go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+)
+
+const (
+	// ServerKey used to identify the metrics of the gRPC and HTTP servers.
+	ServerKey = "server"
+	// EndpointKey used to identify the endpoint a server listens on.
+	EndpointKey = "endpoint"
+
+	// IPFilterRejectedKey used to identify the requests rejected by the ip_filter of a server.
+	IPFilterRejectedKey = "ip_filter_rejected"
+)
+
+var (
+	TagKeyEndpoint, _ = tag.NewKey(EndpointKey)
+
+	ServerPrefix = ServerKey + NameSep
+
+	// Server metrics, tagged with the transport and the endpoint of the server.
+	ServerIPFilterRejected = stats.Int64(
+		ServerPrefix+IPFilterRejectedKey,
+		"Number of requests rejected because the client address is not allowed by the server ip_filter.",
+		stats.UnitDimensionless)
+)
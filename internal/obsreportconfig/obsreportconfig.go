@@ -81,8 +81,10 @@ func allViews() []*view.View {
 		obsmetrics.ExporterFailedToSendMetricPoints,
 		obsmetrics.ExporterSentLogRecords,
 		obsmetrics.ExporterFailedToSendLogRecords,
+		obsmetrics.ExporterSentBytes,
+		obsmetrics.ExporterFailedToSendBytes,
 	}
-	tagKeys = []tag.Key{obsmetrics.TagKeyExporter}
+	tagKeys = []tag.Key{obsmetrics.TagKeyExporter, obsmetrics.TagKeyShard, obsmetrics.TagKeyResponseClass}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
 
 	errorNumberView := &view.View{
@@ -93,6 +95,21 @@ func allViews() []*view.View {
 	}
 	views = append(views, errorNumberView)
 
+	measures = []*stats.Int64Measure{
+		obsmetrics.ExporterDroppedByPolicySpans,
+		obsmetrics.ExporterDroppedByPolicyMetricPoints,
+		obsmetrics.ExporterDroppedByPolicyLogRecords,
+	}
+	views = append(views, genViews(measures, []tag.Key{obsmetrics.TagKeyExporter}, view.Sum())...)
+
+	views = append(views, &view.View{
+		Name:        obsmetrics.ExporterSendDuration.Name(),
+		Description: obsmetrics.ExporterSendDuration.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyExporter, obsmetrics.TagKeyShard, obsmetrics.TagKeyResponseClass, obsmetrics.TagKeyPayloadAge},
+		Measure:     obsmetrics.ExporterSendDuration,
+		Aggregation: view.Distribution(5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000),
+	})
+
 	// Processor views.
 	measures = []*stats.Int64Measure{
 		obsmetrics.ProcessorAcceptedSpans,
@@ -108,9 +125,20 @@ func allViews() []*view.View {
 	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
 
+	// Server views.
+	views = append(views, serverViews()...)
+
 	return views
 }
 
+func serverViews() []*view.View {
+	measures := []*stats.Int64Measure{
+		obsmetrics.ServerIPFilterRejected,
+	}
+	tagKeys := []tag.Key{obsmetrics.TagKeyTransport, obsmetrics.TagKeyEndpoint}
+	return genViews(measures, tagKeys, view.Sum())
+}
+
 func receiverViews() []*view.View {
 	if featuregate.GetRegistry().IsEnabled(UseOtelForInternalMetricsfeatureGateID) {
 		return nil
@@ -123,6 +151,8 @@ func receiverViews() []*view.View {
 		obsmetrics.ReceiverRefusedMetricPoints,
 		obsmetrics.ReceiverAcceptedLogRecords,
 		obsmetrics.ReceiverRefusedLogRecords,
+		obsmetrics.ReceiverAcceptedBytes,
+		obsmetrics.ReceiverRefusedBytes,
 	}
 	tagKeys := []tag.Key{
 		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport,
@@ -142,7 +172,22 @@ func scraperViews() []*view.View {
 	}
 	tagKeys := []tag.Key{obsmetrics.TagKeyReceiver, obsmetrics.TagKeyScraper}
 
-	return genViews(measures, tagKeys, view.Sum())
+	views := genViews(measures, tagKeys, view.Sum())
+	views = append(views, &view.View{
+		Name:        obsmetrics.ScraperScrapeDuration.Name(),
+		Description: obsmetrics.ScraperScrapeDuration.Description(),
+		TagKeys:     tagKeys,
+		Measure:     obsmetrics.ScraperScrapeDuration,
+		Aggregation: view.Distribution(5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000),
+	})
+	views = append(views, &view.View{
+		Name:        obsmetrics.ScraperScrapeErrors.Name(),
+		Description: obsmetrics.ScraperScrapeErrors.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyReceiver, obsmetrics.TagKeyScraper, obsmetrics.TagKeyScrapeErrorType},
+		Measure:     obsmetrics.ScraperScrapeErrors,
+		Aggregation: view.Sum(),
+	})
+	return views
 }
 
 func genViews(
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opencensus.io/stats/view"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
@@ -52,7 +53,17 @@ func TestConfigure(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			gotViews := Configure(tt.level)
-			assert.Equal(t, tt.wantViews, gotViews.Views)
+			require.Len(t, gotViews.Views, len(tt.wantViews))
+			for i, want := range tt.wantViews {
+				got := gotViews.Views[i]
+				assert.Equal(t, want.Name, got.Name)
+				assert.Equal(t, want.Description, got.Description)
+				assert.Equal(t, want.TagKeys, got.TagKeys)
+				assert.Equal(t, want.Measure, got.Measure)
+				// The aggregations hold functions, which are never equal.
+				assert.Equal(t, want.Aggregation.Type, got.Aggregation.Type)
+				assert.Equal(t, want.Aggregation.Buckets, got.Aggregation.Buckets)
+			}
 		})
 	}
 }
@@ -16,6 +16,8 @@ package obsreport // import "go.opentelemetry.io/collector/obsreport"
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
@@ -40,6 +42,117 @@ const (
 	exporterScope = scopeName + nameSep + exporterName
 )
 
+// SendAttributeKey is an attribute that an exporter can set on the send metrics of an export operation.
+type SendAttributeKey string
+
+const (
+	// SendAttributeShard identifies the destination shard or endpoint that the data was sent to.
+	SendAttributeShard SendAttributeKey = obsmetrics.ShardKey
+	// SendAttributeResponseClass identifies the class of the response received from the destination,
+	// for example "2xx" or "throttled". It should have a small and bounded set of values.
+	SendAttributeResponseClass SendAttributeKey = obsmetrics.ResponseClassKey
+)
+
+type sendAttributesKey struct{}
+
+// sendAttributes holds the attributes set by the exporter during an export operation.
+type sendAttributes struct {
+	start time.Time
+
+	mu            sync.Mutex
+	shard         string
+	responseClass string
+	payloadAge    time.Duration
+	hasPayloadAge bool
+}
+
+// SetSendAttribute sets an attribute on the sent and failed to send metrics of the export operation
+// that ctx belongs to. The context must descend from the one returned by StartTracesOp, StartMetricsOp
+// or StartLogsOp, otherwise the call has no effect. Unknown keys are ignored.
+func SetSendAttribute(ctx context.Context, key SendAttributeKey, value string) {
+	attrs, ok := ctx.Value(sendAttributesKey{}).(*sendAttributes)
+	if !ok {
+		return
+	}
+	attrs.mu.Lock()
+	defer attrs.mu.Unlock()
+	switch key {
+	case SendAttributeShard:
+		attrs.shard = value
+	case SendAttributeResponseClass:
+		attrs.responseClass = value
+	}
+}
+
+// SetPayloadAge sets the age of the oldest item of the request sent by the export operation that ctx belongs to,
+// e.g. the time since the request was added to the sending queue. The send duration is recorded with the class
+// of the age, see PayloadAgeClass, so that the queueing latency of the collector can be told apart from the
+// latency of the destination. The span of the operation has the age in milliseconds.
+// The context must descend from the one returned by StartTracesOp, StartMetricsOp or StartLogsOp, otherwise
+// the call has no effect.
+func SetPayloadAge(ctx context.Context, age time.Duration) {
+	attrs, ok := ctx.Value(sendAttributesKey{}).(*sendAttributes)
+	if !ok {
+		return
+	}
+	attrs.mu.Lock()
+	defer attrs.mu.Unlock()
+	attrs.payloadAge = age
+	attrs.hasPayloadAge = true
+}
+
+// PayloadAgeClass returns the class of a payload age that the send duration is recorded with. The classes are
+// bounded: "<1s", "<10s", "<1m", "<10m" and ">=10m".
+func PayloadAgeClass(age time.Duration) string {
+	switch {
+	case age < time.Second:
+		return "<1s"
+	case age < 10*time.Second:
+		return "<10s"
+	case age < time.Minute:
+		return "<1m"
+	case age < 10*time.Minute:
+		return "<10m"
+	default:
+		return ">=10m"
+	}
+}
+
+// getPayloadAge returns the payload age, if set.
+func (sa *sendAttributes) getPayloadAge() (time.Duration, bool) {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+	return sa.payloadAge, sa.hasPayloadAge
+}
+
+// mutators returns the OpenCensus tag mutators for the attributes that are set.
+func (sa *sendAttributes) mutators() []tag.Mutator {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+	var mutators []tag.Mutator
+	if sa.shard != "" {
+		mutators = append(mutators, tag.Upsert(obsmetrics.TagKeyShard, sa.shard, tag.WithTTL(tag.TTLNoPropagation)))
+	}
+	if sa.responseClass != "" {
+		mutators = append(mutators, tag.Upsert(obsmetrics.TagKeyResponseClass, sa.responseClass, tag.WithTTL(tag.TTLNoPropagation)))
+	}
+	return mutators
+}
+
+// attributes returns the OpenTelemetry attributes for the attributes that are set.
+func (sa *sendAttributes) attributes() []attribute.KeyValue {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+	var attrs []attribute.KeyValue
+	if sa.shard != "" {
+		attrs = append(attrs, attribute.String(obsmetrics.ShardKey, sa.shard))
+	}
+	if sa.responseClass != "" {
+		attrs = append(attrs, attribute.String(obsmetrics.ResponseClassKey, sa.responseClass))
+	}
+	return attrs
+}
+
 // Exporter is a helper to add observability to a component.Exporter.
 type Exporter struct {
 	level          configtelemetry.Level
@@ -56,6 +169,9 @@ type Exporter struct {
 	failedToSendMetricPoints syncint64.Counter
 	sentLogRecords           syncint64.Counter
 	failedToSendLogRecords   syncint64.Counter
+	sentBytes                syncint64.Counter
+	failedToSendBytes        syncint64.Counter
+	sendDuration             syncint64.Histogram
 }
 
 // ExporterSettings are settings for creating an Exporter.
@@ -134,6 +250,24 @@ func (exp *Exporter) createOtelMetrics(cfg ExporterSettings) error {
 		instrument.WithUnit(unit.Dimensionless))
 	errors = multierr.Append(errors, err)
 
+	exp.sentBytes, err = meter.SyncInt64().Counter(
+		obsmetrics.ExporterPrefix+obsmetrics.SentBytesKey,
+		instrument.WithDescription("Size in bytes of the requests successfully sent to destination."),
+		instrument.WithUnit(unit.Bytes))
+	errors = multierr.Append(errors, err)
+
+	exp.failedToSendBytes, err = meter.SyncInt64().Counter(
+		obsmetrics.ExporterPrefix+obsmetrics.FailedToSendBytesKey,
+		instrument.WithDescription("Size in bytes of the requests in failed attempts to send to destination."),
+		instrument.WithUnit(unit.Bytes))
+	errors = multierr.Append(errors, err)
+
+	exp.sendDuration, err = meter.SyncInt64().Histogram(
+		obsmetrics.ExporterPrefix+obsmetrics.SendDurationKey,
+		instrument.WithDescription("Duration of the attempts to send requests to destination."),
+		instrument.WithUnit(unit.Milliseconds))
+	errors = multierr.Append(errors, err)
+
 	return errors
 }
 
@@ -148,9 +282,17 @@ func (exp *Exporter) StartTracesOp(ctx context.Context) context.Context {
 func (exp *Exporter) EndTracesOp(ctx context.Context, numSpans int, err error) {
 	numSent, numFailedToSend := toNumItems(numSpans, err)
 	exp.recordMetrics(ctx, component.DataTypeTraces, numSent, numFailedToSend)
+	exp.recordSendDuration(ctx)
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentSpansKey, obsmetrics.FailedToSendSpansKey)
 }
 
+// EndTracesOpWithBytes completes the export operation that was started with StartTracesOp,
+// also recording the size in bytes of the sent request.
+func (exp *Exporter) EndTracesOpWithBytes(ctx context.Context, numSpans int, numBytes int64, err error) {
+	exp.recordBytes(ctx, numBytes, err)
+	exp.EndTracesOp(ctx, numSpans, err)
+}
+
 // StartMetricsOp is called at the start of an Export operation.
 // The returned context should be used in other calls to the Exporter functions
 // dealing with the same export operation.
@@ -163,9 +305,17 @@ func (exp *Exporter) StartMetricsOp(ctx context.Context) context.Context {
 func (exp *Exporter) EndMetricsOp(ctx context.Context, numMetricPoints int, err error) {
 	numSent, numFailedToSend := toNumItems(numMetricPoints, err)
 	exp.recordMetrics(ctx, component.DataTypeMetrics, numSent, numFailedToSend)
+	exp.recordSendDuration(ctx)
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentMetricPointsKey, obsmetrics.FailedToSendMetricPointsKey)
 }
 
+// EndMetricsOpWithBytes completes the export operation that was started with StartMetricsOp,
+// also recording the size in bytes of the sent request.
+func (exp *Exporter) EndMetricsOpWithBytes(ctx context.Context, numMetricPoints int, numBytes int64, err error) {
+	exp.recordBytes(ctx, numBytes, err)
+	exp.EndMetricsOp(ctx, numMetricPoints, err)
+}
+
 // StartLogsOp is called at the start of an Export operation.
 // The returned context should be used in other calls to the Exporter functions
 // dealing with the same export operation.
@@ -177,15 +327,23 @@ func (exp *Exporter) StartLogsOp(ctx context.Context) context.Context {
 func (exp *Exporter) EndLogsOp(ctx context.Context, numLogRecords int, err error) {
 	numSent, numFailedToSend := toNumItems(numLogRecords, err)
 	exp.recordMetrics(ctx, component.DataTypeLogs, numSent, numFailedToSend)
+	exp.recordSendDuration(ctx)
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentLogRecordsKey, obsmetrics.FailedToSendLogRecordsKey)
 }
 
+// EndLogsOpWithBytes completes the export operation that was started with StartLogsOp,
+// also recording the size in bytes of the sent request.
+func (exp *Exporter) EndLogsOpWithBytes(ctx context.Context, numLogRecords int, numBytes int64, err error) {
+	exp.recordBytes(ctx, numBytes, err)
+	exp.EndLogsOp(ctx, numLogRecords, err)
+}
+
 // startOp creates the span used to trace the operation. Returning
 // the updated context and the created span.
 func (exp *Exporter) startOp(ctx context.Context, operationSuffix string) context.Context {
 	spanName := exp.spanNamePrefix + operationSuffix
 	ctx, _ = exp.tracer.Start(ctx, spanName)
-	return ctx
+	return context.WithValue(ctx, sendAttributesKey{}, &sendAttributes{start: time.Now()})
 }
 
 func (exp *Exporter) recordMetrics(ctx context.Context, dataType component.DataType, numSent, numFailed int64) {
@@ -213,8 +371,9 @@ func (exp *Exporter) recordWithOtel(ctx context.Context, dataType component.Data
 		failedMeasure = exp.failedToSendLogRecords
 	}
 
-	sentMeasure.Add(ctx, sent, exp.otelAttrs...)
-	failedMeasure.Add(ctx, failed, exp.otelAttrs...)
+	attrs := exp.attributes(ctx)
+	sentMeasure.Add(ctx, sent, attrs...)
+	failedMeasure.Add(ctx, failed, attrs...)
 }
 
 func (exp *Exporter) recordWithOC(ctx context.Context, dataType component.DataType, sent int64, failed int64) {
@@ -233,11 +392,83 @@ func (exp *Exporter) recordWithOC(ctx context.Context, dataType component.DataTy
 
 	_ = stats.RecordWithTags(
 		ctx,
-		exp.mutators,
+		exp.tagMutators(ctx),
 		sentMeasure.M(sent),
 		failedMeasure.M(failed))
 }
 
+// recordBytes records the size in bytes of the request sent in an export operation.
+func (exp *Exporter) recordBytes(ctx context.Context, numBytes int64, err error) {
+	if exp.level == configtelemetry.LevelNone {
+		return
+	}
+	sent, failed := numBytes, int64(0)
+	if err != nil {
+		sent, failed = 0, numBytes
+	}
+	if exp.useOtelForMetrics {
+		attrs := exp.attributes(ctx)
+		exp.sentBytes.Add(ctx, sent, attrs...)
+		exp.failedToSendBytes.Add(ctx, failed, attrs...)
+		return
+	}
+	_ = stats.RecordWithTags(
+		ctx,
+		exp.tagMutators(ctx),
+		obsmetrics.ExporterSentBytes.M(sent),
+		obsmetrics.ExporterFailedToSendBytes.M(failed))
+}
+
+// recordSendDuration records the duration of the export operation that ctx belongs to, with the class of
+// the payload age if it is set.
+func (exp *Exporter) recordSendDuration(ctx context.Context) {
+	if exp.level == configtelemetry.LevelNone {
+		return
+	}
+	sa, ok := ctx.Value(sendAttributesKey{}).(*sendAttributes)
+	if !ok {
+		return
+	}
+	duration := time.Since(sa.start).Milliseconds()
+	age, hasAge := sa.getPayloadAge()
+	if exp.useOtelForMetrics {
+		attrs := exp.attributes(ctx)
+		if hasAge {
+			attrs = append(append(make([]attribute.KeyValue, 0, len(attrs)+1), attrs...), attribute.String(obsmetrics.PayloadAgeKey, PayloadAgeClass(age)))
+		}
+		exp.sendDuration.Record(ctx, duration, attrs...)
+		return
+	}
+	mutators := exp.tagMutators(ctx)
+	if hasAge {
+		mutators = append(append(make([]tag.Mutator, 0, len(mutators)+1), mutators...),
+			tag.Upsert(obsmetrics.TagKeyPayloadAge, PayloadAgeClass(age), tag.WithTTL(tag.TTLNoPropagation)))
+	}
+	_ = stats.RecordWithTags(ctx, mutators, obsmetrics.ExporterSendDuration.M(duration))
+}
+
+// attributes returns the OpenTelemetry attributes of the export operation that ctx belongs to.
+func (exp *Exporter) attributes(ctx context.Context) []attribute.KeyValue {
+	attrs := exp.otelAttrs
+	if sa, ok := ctx.Value(sendAttributesKey{}).(*sendAttributes); ok {
+		if extra := sa.attributes(); len(extra) > 0 {
+			attrs = append(append(make([]attribute.KeyValue, 0, len(attrs)+len(extra)), attrs...), extra...)
+		}
+	}
+	return attrs
+}
+
+// tagMutators returns the OpenCensus tag mutators of the export operation that ctx belongs to.
+func (exp *Exporter) tagMutators(ctx context.Context) []tag.Mutator {
+	mutators := exp.mutators
+	if sa, ok := ctx.Value(sendAttributesKey{}).(*sendAttributes); ok {
+		if extra := sa.mutators(); len(extra) > 0 {
+			mutators = append(append(make([]tag.Mutator, 0, len(mutators)+len(extra)), mutators...), extra...)
+		}
+	}
+	return mutators
+}
+
 func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sentItemsKey, failedToSendItemsKey string) {
 	span := trace.SpanFromContext(ctx)
 	// End the span according to errors.
@@ -246,6 +477,11 @@ func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sen
 			attribute.Int64(sentItemsKey, numSent),
 			attribute.Int64(failedToSendItemsKey, numFailedToSend),
 		)
+		if sa, ok := ctx.Value(sendAttributesKey{}).(*sendAttributes); ok {
+			if age, hasAge := sa.getPayloadAge(); hasAge {
+				span.SetAttributes(attribute.Int64(obsmetrics.PayloadAgeKey+"_ms", age.Milliseconds()))
+			}
+		}
 		recordError(span, err)
 	}
 	span.End()
@@ -61,6 +61,8 @@ type Receiver struct {
 	refusedMetricPointsCounter  syncint64.Counter
 	acceptedLogRecordsCounter   syncint64.Counter
 	refusedLogRecordsCounter    syncint64.Counter
+	acceptedBytesCounter        syncint64.Counter
+	refusedBytesCounter         syncint64.Counter
 }
 
 // ReceiverSettings are settings for creating an Receiver.
@@ -158,6 +160,20 @@ func (rec *Receiver) createOtelMetrics() error {
 	)
 	errors = multierr.Append(errors, err)
 
+	rec.acceptedBytesCounter, err = rec.meter.SyncInt64().Counter(
+		obsmetrics.ReceiverPrefix+obsmetrics.AcceptedBytesKey,
+		instrument.WithDescription("Size in bytes of the requests successfully pushed into the pipeline."),
+		instrument.WithUnit(unit.Bytes),
+	)
+	errors = multierr.Append(errors, err)
+
+	rec.refusedBytesCounter, err = rec.meter.SyncInt64().Counter(
+		obsmetrics.ReceiverPrefix+obsmetrics.RefusedBytesKey,
+		instrument.WithDescription("Size in bytes of the requests that could not be pushed into the pipeline."),
+		instrument.WithUnit(unit.Bytes),
+	)
+	errors = multierr.Append(errors, err)
+
 	return errors
 }
 
@@ -179,6 +195,19 @@ func (rec *Receiver) EndTracesOp(
 	rec.endOp(receiverCtx, format, numReceivedSpans, err, component.DataTypeTraces)
 }
 
+// EndTracesOpWithBytes completes the receive operation that was started with
+// StartTracesOp, also recording the size in bytes of the received request.
+func (rec *Receiver) EndTracesOpWithBytes(
+	receiverCtx context.Context,
+	format string,
+	numReceivedSpans int,
+	numReceivedBytes int64,
+	err error,
+) {
+	rec.recordBytes(receiverCtx, numReceivedBytes, err)
+	rec.endOp(receiverCtx, format, numReceivedSpans, err, component.DataTypeTraces)
+}
+
 // StartLogsOp is called when a request is received from a client.
 // The returned context should be used in other calls to the obsreport functions
 // dealing with the same receive operation.
@@ -197,6 +226,19 @@ func (rec *Receiver) EndLogsOp(
 	rec.endOp(receiverCtx, format, numReceivedLogRecords, err, component.DataTypeLogs)
 }
 
+// EndLogsOpWithBytes completes the receive operation that was started with
+// StartLogsOp, also recording the size in bytes of the received request.
+func (rec *Receiver) EndLogsOpWithBytes(
+	receiverCtx context.Context,
+	format string,
+	numReceivedLogRecords int,
+	numReceivedBytes int64,
+	err error,
+) {
+	rec.recordBytes(receiverCtx, numReceivedBytes, err)
+	rec.endOp(receiverCtx, format, numReceivedLogRecords, err, component.DataTypeLogs)
+}
+
 // StartMetricsOp is called when a request is received from a client.
 // The returned context should be used in other calls to the obsreport functions
 // dealing with the same receive operation.
@@ -215,6 +257,19 @@ func (rec *Receiver) EndMetricsOp(
 	rec.endOp(receiverCtx, format, numReceivedPoints, err, component.DataTypeMetrics)
 }
 
+// EndMetricsOpWithBytes completes the receive operation that was started with
+// StartMetricsOp, also recording the size in bytes of the received request.
+func (rec *Receiver) EndMetricsOpWithBytes(
+	receiverCtx context.Context,
+	format string,
+	numReceivedPoints int,
+	numReceivedBytes int64,
+	err error,
+) {
+	rec.recordBytes(receiverCtx, numReceivedBytes, err)
+	rec.endOp(receiverCtx, format, numReceivedPoints, err, component.DataTypeMetrics)
+}
+
 // startOp creates the span used to trace the operation. Returning
 // the updated context with the created span.
 func (rec *Receiver) startOp(receiverCtx context.Context, operationSuffix string) context.Context {
@@ -331,3 +386,26 @@ func (rec *Receiver) recordWithOC(receiverCtx context.Context, dataType componen
 		acceptedMeasure.M(int64(numAccepted)),
 		refusedMeasure.M(int64(numRefused)))
 }
+
+// recordBytes records the size in bytes of the request received in an operation.
+func (rec *Receiver) recordBytes(receiverCtx context.Context, numReceivedBytes int64, err error) {
+	if rec.level == configtelemetry.LevelNone {
+		return
+	}
+	numAccepted := numReceivedBytes
+	numRefused := int64(0)
+	if err != nil {
+		numAccepted = 0
+		numRefused = numReceivedBytes
+	}
+
+	if rec.useOtelForMetrics {
+		rec.acceptedBytesCounter.Add(receiverCtx, numAccepted, rec.otelAttrs...)
+		rec.refusedBytesCounter.Add(receiverCtx, numRefused, rec.otelAttrs...)
+		return
+	}
+	stats.Record(
+		receiverCtx,
+		obsmetrics.ReceiverAcceptedBytes.M(numAccepted),
+		obsmetrics.ReceiverRefusedBytes.M(numRefused))
+}
@@ -17,6 +17,7 @@ package obsreport // import "go.opentelemetry.io/collector/obsreport"
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
@@ -55,8 +56,13 @@ type Scraper struct {
 	otelAttrs            []attribute.KeyValue
 	scrapedMetricsPoints syncint64.Counter
 	erroredMetricsPoints syncint64.Counter
+	scrapeDuration       syncint64.Histogram
+	scrapeErrors         syncint64.Counter
 }
 
+// scrapeStartKey is the context key of the time at which a scrape operation started.
+type scrapeStartKey struct{}
+
 // ScraperSettings are settings for creating a Scraper.
 type ScraperSettings struct {
 	ReceiverID             component.ID
@@ -116,6 +122,20 @@ func (s *Scraper) createOtelMetrics(cfg ScraperSettings) error {
 	)
 	errors = multierr.Append(errors, err)
 
+	s.scrapeDuration, err = meter.SyncInt64().Histogram(
+		obsmetrics.ScraperPrefix+obsmetrics.ScrapeDurationKey,
+		instrument.WithDescription("Duration of the scrape operations."),
+		instrument.WithUnit(unit.Milliseconds),
+	)
+	errors = multierr.Append(errors, err)
+
+	s.scrapeErrors, err = meter.SyncInt64().Counter(
+		obsmetrics.ScraperPrefix+obsmetrics.ScrapeErrorsKey,
+		instrument.WithDescription("Number of scrape operations that failed, by type of error."),
+		instrument.WithUnit(unit.Dimensionless),
+	)
+	errors = multierr.Append(errors, err)
+
 	return errors
 }
 
@@ -124,6 +144,7 @@ func (s *Scraper) createOtelMetrics(cfg ScraperSettings) error {
 // dealing with the same scrape operation.
 func (s *Scraper) StartMetricsOp(ctx context.Context) context.Context {
 	ctx, _ = tag.New(ctx, s.mutators...)
+	ctx = context.WithValue(ctx, scrapeStartKey{}, time.Now())
 
 	spanName := obsmetrics.ScraperPrefix + s.receiverID.String() + obsmetrics.NameSep + s.scraper.String() + obsmetrics.ScraperMetricsOperationSuffix
 	ctx, _ = s.tracer.Start(ctx, spanName)
@@ -152,6 +173,12 @@ func (s *Scraper) EndMetricsOp(
 
 	if s.level != configtelemetry.LevelNone {
 		s.recordMetrics(scraperCtx, numScrapedMetrics, numErroredMetrics)
+		if start, ok := scraperCtx.Value(scrapeStartKey{}).(time.Time); ok {
+			s.recordDuration(scraperCtx, time.Since(start))
+		}
+		if err != nil {
+			s.recordError(scraperCtx, scrapeErrorType(err))
+		}
 	}
 
 	// end span according to errors
@@ -161,6 +188,9 @@ func (s *Scraper) EndMetricsOp(
 			attribute.Int64(obsmetrics.ScrapedMetricPointsKey, int64(numScrapedMetrics)),
 			attribute.Int64(obsmetrics.ErroredMetricPointsKey, int64(numErroredMetrics)),
 		)
+		if err != nil {
+			span.SetAttributes(attribute.String(obsmetrics.ScrapeErrorTypeKey, scrapeErrorType(err)))
+		}
 		recordError(span, err)
 	}
 
@@ -178,3 +208,38 @@ func (s *Scraper) recordMetrics(scraperCtx context.Context, numScrapedMetrics, n
 			obsmetrics.ScraperErroredMetricPoints.M(int64(numErroredMetrics)))
 	}
 }
+
+func (s *Scraper) recordDuration(scraperCtx context.Context, duration time.Duration) {
+	if s.useOtelForMetrics {
+		s.scrapeDuration.Record(scraperCtx, duration.Milliseconds(), s.otelAttrs...)
+	} else { // OC for metrics
+		stats.Record(scraperCtx, obsmetrics.ScraperScrapeDuration.M(duration.Milliseconds()))
+	}
+}
+
+func (s *Scraper) recordError(scraperCtx context.Context, errorType string) {
+	if s.useOtelForMetrics {
+		attrs := append([]attribute.KeyValue{attribute.String(obsmetrics.ScrapeErrorTypeKey, errorType)}, s.otelAttrs...)
+		s.scrapeErrors.Add(scraperCtx, 1, attrs...)
+	} else { // OC for metrics
+		_ = stats.RecordWithTags(
+			scraperCtx,
+			[]tag.Mutator{tag.Upsert(obsmetrics.TagKeyScrapeErrorType, errorType, tag.WithTTL(tag.TTLNoPropagation))},
+			obsmetrics.ScraperScrapeErrors.M(1))
+	}
+}
+
+// scrapeErrorType classifies the error of a failed scrape operation: partial failures are reported
+// before timeouts, so that the scrapers returning the metric points scraped before their deadline
+// are not reported as timed out.
+func scrapeErrorType(err error) string {
+	var partialErr scrapererror.PartialScrapeError
+	switch {
+	case errors.As(err, &partialErr):
+		return obsmetrics.ScrapeErrorTypePartial
+	case errors.Is(err, context.DeadlineExceeded):
+		return obsmetrics.ScrapeErrorTypeTimeout
+	default:
+		return obsmetrics.ScrapeErrorTypeFailed
+	}
+}
@@ -17,7 +17,9 @@ package obsreport
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -259,6 +261,7 @@ func testScrapeMetricsDataOp(t *testing.T, tt obsreporttest.TestTelemetry, regis
 			erroredMetricPoints += params[i].items
 			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedMetricPointsKey, Value: attribute.Int64Value(0)})
 			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
+			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapeErrorTypeKey, Value: attribute.StringValue(obsmetrics.ScrapeErrorTypeFailed)})
 			assert.Equal(t, codes.Error, span.Status().Code)
 			assert.Equal(t, params[i].err.Error(), span.Status().Description)
 
@@ -267,6 +270,7 @@ func testScrapeMetricsDataOp(t *testing.T, tt obsreporttest.TestTelemetry, regis
 			erroredMetricPoints++
 			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
 			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(1)})
+			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapeErrorTypeKey, Value: attribute.StringValue(obsmetrics.ScrapeErrorTypePartial)})
 			assert.Equal(t, codes.Error, span.Status().Code)
 			assert.Equal(t, params[i].err.Error(), span.Status().Description)
 		default:
@@ -275,6 +279,42 @@ func testScrapeMetricsDataOp(t *testing.T, tt obsreporttest.TestTelemetry, regis
 	}
 
 	require.NoError(t, obsreporttest.CheckScraperMetrics(tt, receiver, scraper, int64(scrapedMetricPoints), int64(erroredMetricPoints)))
+	require.NoError(t, obsreporttest.CheckScraperErrors(tt, receiver, scraper, obsmetrics.ScrapeErrorTypePartial, 1))
+	require.NoError(t, obsreporttest.CheckScraperErrors(tt, receiver, scraper, obsmetrics.ScrapeErrorTypeFailed, 1))
+}
+
+func TestScrapeErrorType(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		errorType string
+	}{
+		{
+			name:      "failed",
+			err:       errFake,
+			errorType: obsmetrics.ScrapeErrorTypeFailed,
+		},
+		{
+			name:      "partial",
+			err:       partialErrFake,
+			errorType: obsmetrics.ScrapeErrorTypePartial,
+		},
+		{
+			name:      "timeout",
+			err:       fmt.Errorf("scrape failed: %w", context.DeadlineExceeded),
+			errorType: obsmetrics.ScrapeErrorTypeTimeout,
+		},
+		{
+			name:      "partial timeout",
+			err:       scrapererror.NewPartialScrapeError(context.DeadlineExceeded, 2),
+			errorType: obsmetrics.ScrapeErrorTypePartial,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.errorType, scrapeErrorType(tt.err))
+		})
+	}
 }
 
 func TestExportTraceDataOp(t *testing.T) {
@@ -326,6 +366,91 @@ func TestExportTraceDataOp(t *testing.T) {
 	})
 }
 
+func TestExportTraceDataOpWithSendAttributes(t *testing.T) {
+	testTelemetry(t, exporter, func(t *testing.T, tt obsreporttest.TestTelemetry, registry *featuregate.Registry) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporter,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, registry)
+		require.NoError(t, err)
+
+		ctx := obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 22, nil)
+
+		ctx = obsrep.StartTracesOp(context.Background())
+		SetSendAttribute(ctx, SendAttributeShard, "shard-1")
+		SetSendAttribute(ctx, SendAttributeResponseClass, "5xx")
+		obsrep.EndTracesOp(ctx, 14, errFake)
+
+		// The operation with send attributes is recorded in its own series.
+		require.NoError(t, obsreporttest.CheckExporterTraces(tt, exporter, 22, 0))
+	})
+}
+
+func TestSetSendAttribute(t *testing.T) {
+	// Outside of an export operation the attributes are ignored.
+	SetSendAttribute(context.Background(), SendAttributeShard, "shard-1")
+
+	sa := &sendAttributes{}
+	ctx := context.WithValue(context.Background(), sendAttributesKey{}, sa)
+	assert.Empty(t, sa.attributes())
+	assert.Empty(t, sa.mutators())
+
+	SetSendAttribute(ctx, SendAttributeShard, "shard-1")
+	SetSendAttribute(ctx, SendAttributeKey("unknown"), "value")
+	assert.Equal(t, []attribute.KeyValue{attribute.String(obsmetrics.ShardKey, "shard-1")}, sa.attributes())
+	assert.Len(t, sa.mutators(), 1)
+
+	SetSendAttribute(ctx, SendAttributeResponseClass, "2xx")
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String(obsmetrics.ShardKey, "shard-1"),
+		attribute.String(obsmetrics.ResponseClassKey, "2xx"),
+	}, sa.attributes())
+	assert.Len(t, sa.mutators(), 2)
+}
+
+func TestExportTraceDataOpWithPayloadAge(t *testing.T) {
+	testTelemetry(t, exporter, func(t *testing.T, tt obsreporttest.TestTelemetry, registry *featuregate.Registry) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporter,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, registry)
+		require.NoError(t, err)
+
+		ctx := obsrep.StartTracesOp(context.Background())
+		SetPayloadAge(ctx, 1500*time.Millisecond)
+		obsrep.EndTracesOp(ctx, 22, nil)
+
+		// Without a payload age, the operation is still recorded.
+		ctx = obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 10, nil)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 2)
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.PayloadAgeKey + "_ms", Value: attribute.Int64Value(1500)})
+		for _, kv := range spans[1].Attributes() {
+			assert.NotEqual(t, attribute.Key(obsmetrics.PayloadAgeKey+"_ms"), kv.Key)
+		}
+
+		require.NoError(t, obsreporttest.CheckMetricExists(tt, "exporter_send_duration"))
+		require.NoError(t, obsreporttest.CheckExporterTraces(tt, exporter, 32, 0))
+	})
+}
+
+func TestPayloadAgeClass(t *testing.T) {
+	assert.Equal(t, "<1s", PayloadAgeClass(0))
+	assert.Equal(t, "<1s", PayloadAgeClass(999*time.Millisecond))
+	assert.Equal(t, "<10s", PayloadAgeClass(time.Second))
+	assert.Equal(t, "<1m", PayloadAgeClass(30*time.Second))
+	assert.Equal(t, "<10m", PayloadAgeClass(time.Minute))
+	assert.Equal(t, ">=10m", PayloadAgeClass(time.Hour))
+}
+
+func TestSetPayloadAgeWithoutOperation(t *testing.T) {
+	// The call has no effect on a context without an export operation.
+	SetPayloadAge(context.Background(), time.Second)
+}
+
 func TestExportMetricsOp(t *testing.T) {
 	testTelemetry(t, exporter, func(t *testing.T, tt obsreporttest.TestTelemetry, registry *featuregate.Registry) {
 		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
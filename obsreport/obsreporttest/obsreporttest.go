@@ -16,6 +16,7 @@ package obsreporttest // import "go.opentelemetry.io/collector/obsreport/obsrepo
 
 import (
 	"context"
+	"fmt"
 
 	ocprom "contrib.go.opencensus.io/exporter/prometheus"
 	"github.com/prometheus/client_golang/prometheus"
@@ -46,6 +47,7 @@ var (
 	transportTag, _ = tag.NewKey("transport")
 	exporterTag, _  = tag.NewKey("exporter")
 	processorTag, _ = tag.NewKey("processor")
+	errorTypeTag, _ = tag.NewKey("error_type")
 )
 
 type TestTelemetry struct {
@@ -104,6 +106,13 @@ func SetupTelemetry() (TestTelemetry, error) {
 // The caller must pass the ID of the component that intends to test, so the CreateSettings and Check methods will use.
 // The caller should defer a call to Shutdown the returned TestTelemetry.
 func SetupTelemetryWithID(id component.ID) (TestTelemetry, error) {
+	return SetupTelemetryWithLevel(id, configtelemetry.LevelNormal)
+}
+
+// SetupTelemetryWithLevel is like SetupTelemetryWithID, but configures the telemetry at the given level.
+// Use it together with CheckMetricExists and CheckMetricNotExists to verify that metrics are correctly
+// gated by TelemetrySettings.MetricsLevel.
+func SetupTelemetryWithLevel(id component.ID, level configtelemetry.Level) (TestTelemetry, error) {
 	sr := new(tracetest.SpanRecorder)
 	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
 
@@ -113,8 +122,8 @@ func SetupTelemetryWithID(id component.ID) (TestTelemetry, error) {
 		SpanRecorder:      sr,
 	}
 	settings.TelemetrySettings.TracerProvider = tp
-	settings.TelemetrySettings.MetricsLevel = configtelemetry.LevelNormal
-	obsMetrics := obsreportconfig.Configure(configtelemetry.LevelNormal)
+	settings.TelemetrySettings.MetricsLevel = level
+	obsMetrics := obsreportconfig.Configure(level)
 	settings.views = obsMetrics.Views
 	err := view.Register(settings.views...)
 	if err != nil {
@@ -163,6 +172,12 @@ func CheckExporterLogs(tts TestTelemetry, exporter component.ID, sentLogRecords,
 	return tts.otelPrometheusChecker.checkExporterLogs(exporter, sentLogRecords, sendFailedLogRecords)
 }
 
+// CheckExporterBytes checks that for the current exported values for exporter bytes metrics match given values.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckExporterBytes(tts TestTelemetry, exporter component.ID, sentBytes, sendFailedBytes int64) error {
+	return tts.otelPrometheusChecker.checkExporterBytes(exporter, sentBytes, sendFailedBytes)
+}
+
 // CheckProcessorTraces checks that for the current exported values for trace exporter metrics match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func CheckProcessorTraces(tts TestTelemetry, processor component.ID, acceptedSpans, refusedSpans, droppedSpans int64) error {
@@ -199,8 +214,46 @@ func CheckReceiverMetrics(tts TestTelemetry, receiver component.ID, protocol str
 	return tts.otelPrometheusChecker.checkReceiverMetrics(receiver, protocol, acceptedMetricPoints, droppedMetricPoints)
 }
 
+// CheckReceiverBytes checks that for the current exported values for receiver bytes metrics match given values.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckReceiverBytes(tts TestTelemetry, receiver component.ID, protocol string, acceptedBytes, refusedBytes int64) error {
+	return tts.otelPrometheusChecker.checkReceiverBytes(receiver, protocol, acceptedBytes, refusedBytes)
+}
+
 // CheckScraperMetrics checks that for the current exported values for metrics scraper metrics match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func CheckScraperMetrics(tts TestTelemetry, receiver component.ID, scraper component.ID, scrapedMetricPoints, erroredMetricPoints int64) error {
 	return tts.otelPrometheusChecker.checkScraperMetrics(receiver, scraper, scrapedMetricPoints, erroredMetricPoints)
 }
+
+// CheckScraperErrors checks that for the current exported values for the scrape errors of the given type match given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckScraperErrors(tts TestTelemetry, receiver component.ID, scraper component.ID, errorType string, scrapeErrors int64) error {
+	return tts.otelPrometheusChecker.checkScraperErrors(receiver, scraper, errorType, scrapeErrors)
+}
+
+// CheckMetricExists checks that a metric with the given name was recorded.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckMetricExists(tts TestTelemetry, metric string) error {
+	exists, err := tts.otelPrometheusChecker.metricExists(metric)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("metric '%s' not found", metric)
+	}
+	return nil
+}
+
+// CheckMetricNotExists checks that no metric with the given name was recorded.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckMetricNotExists(tts TestTelemetry, metric string) error {
+	exists, err := tts.otelPrometheusChecker.metricExists(metric)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("metric '%s' found, but it should not be recorded at level %s", metric, tts.MetricsLevel)
+	}
+	return nil
+}
@@ -16,12 +16,14 @@ package obsreporttest_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 )
@@ -36,6 +38,8 @@ var (
 	receiver  = component.NewID("fakeReicever")
 	processor = component.NewID("fakeProcessor")
 	exporter  = component.NewID("fakeExporter")
+
+	errFake = errors.New("fake error")
 )
 
 func TestCheckScraperMetricsViews(t *testing.T) {
@@ -80,6 +84,68 @@ func TestCheckReceiverTracesViews(t *testing.T) {
 	assert.Error(t, obsreporttest.CheckReceiverTraces(tt, receiver, transport, 0, 7))
 }
 
+func TestCheckReceiverBytesViews(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetryWithID(receiver)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	rec, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             receiver,
+		Transport:              transport,
+		ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+	})
+	require.NoError(t, err)
+	ctx := rec.StartTracesOp(context.Background())
+	require.NotNil(t, ctx)
+	rec.EndTracesOpWithBytes(ctx, format, 7, 1024, nil)
+	ctx = rec.StartLogsOp(context.Background())
+	require.NotNil(t, ctx)
+	rec.EndLogsOpWithBytes(ctx, format, 3, 512, errFake)
+
+	assert.NoError(t, obsreporttest.CheckReceiverTraces(tt, receiver, transport, 7, 0))
+	assert.NoError(t, obsreporttest.CheckReceiverBytes(tt, receiver, transport, 1024, 512))
+	assert.Error(t, obsreporttest.CheckReceiverBytes(tt, receiver, transport, 1024, 0))
+	assert.Error(t, obsreporttest.CheckReceiverBytes(tt, receiver, transport, 0, 512))
+}
+
+func TestCheckMetricExistsAtLevel(t *testing.T) {
+	tests := []struct {
+		level  configtelemetry.Level
+		exists bool
+	}{
+		{level: configtelemetry.LevelNone, exists: false},
+		{level: configtelemetry.LevelBasic, exists: true},
+		{level: configtelemetry.LevelNormal, exists: true},
+		{level: configtelemetry.LevelDetailed, exists: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			tel, err := obsreporttest.SetupTelemetryWithLevel(receiver, tt.level)
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+			assert.Equal(t, tt.level, tel.MetricsLevel)
+
+			rec, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+				ReceiverID:             receiver,
+				Transport:              transport,
+				ReceiverCreateSettings: tel.ToReceiverCreateSettings(),
+			})
+			require.NoError(t, err)
+			ctx := rec.StartTracesOp(context.Background())
+			rec.EndTracesOp(ctx, format, 7, nil)
+
+			if tt.exists {
+				assert.NoError(t, obsreporttest.CheckMetricExists(tel, "receiver_accepted_spans"))
+				assert.Error(t, obsreporttest.CheckMetricNotExists(tel, "receiver_accepted_spans"))
+			} else {
+				assert.Error(t, obsreporttest.CheckMetricExists(tel, "receiver_accepted_spans"))
+				assert.NoError(t, obsreporttest.CheckMetricNotExists(tel, "receiver_accepted_spans"))
+			}
+			assert.NoError(t, obsreporttest.CheckMetricNotExists(tel, "exporter_sent_spans"))
+		})
+	}
+}
+
 func TestCheckReceiverMetricsViews(t *testing.T) {
 	tt, err := obsreporttest.SetupTelemetryWithID(receiver)
 	require.NoError(t, err)
@@ -256,3 +322,26 @@ func TestCheckExporterLogsViews(t *testing.T) {
 	assert.Error(t, obsreporttest.CheckExporterLogs(tt, exporter, 0, 0))
 	assert.Error(t, obsreporttest.CheckExporterLogs(tt, exporter, 0, 7))
 }
+
+func TestCheckExporterBytesViews(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetryWithID(exporter)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	obsrep, err := obsreport.NewExporter(obsreport.ExporterSettings{
+		ExporterID:             exporter,
+		ExporterCreateSettings: tt.ToExporterCreateSettings(),
+	})
+	require.NoError(t, err)
+	ctx := obsrep.StartMetricsOp(context.Background())
+	require.NotNil(t, ctx)
+	obsrep.EndMetricsOpWithBytes(ctx, 7, 2048, nil)
+	ctx = obsrep.StartLogsOp(context.Background())
+	require.NotNil(t, ctx)
+	obsrep.EndLogsOpWithBytes(ctx, 3, 256, errFake)
+
+	assert.NoError(t, obsreporttest.CheckExporterMetrics(tt, exporter, 7, 0))
+	assert.NoError(t, obsreporttest.CheckExporterBytes(tt, exporter, 2048, 256))
+	assert.Error(t, obsreporttest.CheckExporterBytes(tt, exporter, 2048, 0))
+	assert.Error(t, obsreporttest.CheckExporterBytes(tt, exporter, 0, 256))
+}
@@ -41,6 +41,11 @@ func (pc *prometheusChecker) checkScraperMetrics(receiver component.ID, scraper
 		pc.checkCounter("scraper_errored_metric_points", erroredMetricPoints, scraperAttrs))
 }
 
+func (pc *prometheusChecker) checkScraperErrors(receiver component.ID, scraper component.ID, errorType string, scrapeErrors int64) error {
+	scraperAttrs := append(attributesForScraperMetrics(receiver, scraper), attribute.String(errorTypeTag.Name(), errorType))
+	return pc.checkCounter("scraper_scrape_errors", scrapeErrors, scraperAttrs)
+}
+
 func (pc *prometheusChecker) checkReceiverTraces(receiver component.ID, protocol string, acceptedSpans, droppedSpans int64) error {
 	receiverAttrs := attributesForReceiverMetrics(receiver, protocol)
 	return multierr.Combine(
@@ -62,6 +67,13 @@ func (pc *prometheusChecker) checkReceiverMetrics(receiver component.ID, protoco
 		pc.checkCounter("receiver_refused_metric_points", droppedMetricPoints, receiverAttrs))
 }
 
+func (pc *prometheusChecker) checkReceiverBytes(receiver component.ID, protocol string, acceptedBytes, refusedBytes int64) error {
+	receiverAttrs := attributesForReceiverMetrics(receiver, protocol)
+	return multierr.Combine(
+		pc.checkCounter("receiver_accepted_bytes", acceptedBytes, receiverAttrs),
+		pc.checkCounter("receiver_refused_bytes", refusedBytes, receiverAttrs))
+}
+
 func (pc *prometheusChecker) checkProcessorTraces(processor component.ID, acceptedSpans, refusedSpans, droppedSpans int64) error {
 	processorAttrs := attributesForProcessorMetrics(processor)
 	return multierr.Combine(
@@ -107,6 +119,13 @@ func (pc *prometheusChecker) checkExporterMetrics(exporter component.ID, sentMet
 		pc.checkCounter("exporter_send_failed_metric_points", sendFailedMetricPoints, exporterAttrs))
 }
 
+func (pc *prometheusChecker) checkExporterBytes(exporter component.ID, sentBytes, sendFailedBytes int64) error {
+	exporterAttrs := attributesForExporterMetrics(exporter)
+	return multierr.Combine(
+		pc.checkCounter("exporter_sent_bytes", sentBytes, exporterAttrs),
+		pc.checkCounter("exporter_send_failed_bytes", sendFailedBytes, exporterAttrs))
+}
+
 func (pc *prometheusChecker) checkCounter(expectedMetric string, value int64, attrs []attribute.KeyValue) error {
 	// Forces a flush for the opencensus view data.
 	_, _ = view.RetrieveData(expectedMetric)
@@ -124,6 +143,23 @@ func (pc *prometheusChecker) checkCounter(expectedMetric string, value int64, at
 	return nil
 }
 
+// metricExists returns whether a metric with the given name is exported.
+func (pc *prometheusChecker) metricExists(expectedName string) (bool, error) {
+	// Forces a flush for the opencensus view data.
+	_, _ = view.RetrieveData(expectedName)
+
+	parsed, err := fetchPrometheusMetrics(pc.promHandler)
+	if err != nil {
+		return false, err
+	}
+	_, ok := parsed[expectedName]
+	if !ok {
+		// OTel Go adds `_total` suffix for all monotonic sum.
+		_, ok = parsed[expectedName+"_total"]
+	}
+	return ok, nil
+}
+
 // getMetric returns the metric time series that matches the given name, type and set of attributes
 // it fetches data from the prometheus endpoint and parse them, ideally OTel Go should provide a MeterRecorder of some kind.
 func (pc *prometheusChecker) getMetric(expectedName string, expectedType io_prometheus_client.MetricType, expectedAttrs []attribute.KeyValue) (*io_prometheus_client.Metric, error) {
@@ -132,31 +168,51 @@ func (pc *prometheusChecker) getMetric(expectedName string, expectedType io_prom
 		return nil, err
 	}
 
-	metricFamily, ok := parsed[expectedName]
-	if !ok {
-		// OTel Go adds `_total` suffix for all monotonic sum.
-		metricFamily, ok = parsed[expectedName+"_total"]
+	metricFamilies := []*io_prometheus_client.MetricFamily{}
+	if metricFamily, ok := parsed[expectedName]; ok {
+		metricFamilies = append(metricFamilies, metricFamily)
+	}
+	// OTel Go adds `_total` suffix for all monotonic sum, once per data point of the sum,
+	// so the time series of a counter with several attribute sets are spread over several names.
+	for name := expectedName + "_total"; ; name += "_total" {
+		metricFamily, ok := parsed[name]
 		if !ok {
-			return nil, fmt.Errorf("metric '%s' not found", expectedName)
+			break
 		}
+		metricFamilies = append(metricFamilies, metricFamily)
+	}
+	if len(metricFamilies) == 0 {
+		return nil, fmt.Errorf("metric '%s' not found", expectedName)
 	}
 
-	if metricFamily.Type.String() != expectedType.String() {
-		return nil, fmt.Errorf("metric '%v' has type '%s' instead of '%s'", expectedName, metricFamily.Type.String(), expectedType.String())
+	// Prometheus treats labels with an empty value as absent.
+	var nonEmptyAttrs []attribute.KeyValue
+	for _, attr := range expectedAttrs {
+		if attr.Value.Emit() != "" {
+			nonEmptyAttrs = append(nonEmptyAttrs, attr)
+		}
 	}
+	expectedSet := attribute.NewSet(nonEmptyAttrs...)
 
-	expectedSet := attribute.NewSet(expectedAttrs...)
+	for _, metricFamily := range metricFamilies {
+		if metricFamily.Type.String() != expectedType.String() {
+			return nil, fmt.Errorf("metric '%v' has type '%s' instead of '%s'", expectedName, metricFamily.Type.String(), expectedType.String())
+		}
 
-	for _, metric := range metricFamily.Metric {
-		var attrs []attribute.KeyValue
+		for _, metric := range metricFamily.Metric {
+			var attrs []attribute.KeyValue
 
-		for _, label := range metric.Label {
-			attrs = append(attrs, attribute.String(label.GetName(), label.GetValue()))
-		}
-		set := attribute.NewSet(attrs...)
+			for _, label := range metric.Label {
+				if label.GetValue() == "" {
+					continue
+				}
+				attrs = append(attrs, attribute.String(label.GetName(), label.GetValue()))
+			}
+			set := attribute.NewSet(attrs...)
 
-		if expectedSet.Equals(&set) {
-			return metric, nil
+			if expectedSet.Equals(&set) {
+				return metric, nil
+			}
 		}
 	}
 
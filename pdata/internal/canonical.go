@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"bytes"
+	"sort"
+
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	otlpresource "go.opentelemetry.io/collector/pdata/internal/data/protogen/resource/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+)
+
+// The canonical form of a payload sorts, in place, all the attributes and key/value lists by key, and all
+// the repeated messages (resources, scopes, spans, events, links, metrics, data points, exemplars and log
+// records) by their encoding. The order of array values, histogram buckets and summary quantiles is kept
+// since it is meaningful.
+
+// CanonicalizeTraces puts the traces in their canonical form.
+func CanonicalizeTraces(td *otlptrace.TracesData) {
+	for _, rs := range td.ResourceSpans {
+		canonicalizeResource(&rs.Resource)
+		for _, ss := range rs.ScopeSpans {
+			canonicalizeScope(&ss.Scope)
+			for _, span := range ss.Spans {
+				canonicalizeAttributes(span.Attributes)
+				for _, event := range span.Events {
+					canonicalizeAttributes(event.Attributes)
+				}
+				sortMessages(span.Events)
+				for _, link := range span.Links {
+					canonicalizeAttributes(link.Attributes)
+				}
+				sortMessages(span.Links)
+			}
+			sortMessages(ss.Spans)
+		}
+		sortMessages(rs.ScopeSpans)
+	}
+	sortMessages(td.ResourceSpans)
+}
+
+// CanonicalizeMetrics puts the metrics in their canonical form.
+func CanonicalizeMetrics(md *otlpmetrics.MetricsData) {
+	for _, rm := range md.ResourceMetrics {
+		canonicalizeResource(&rm.Resource)
+		for _, sm := range rm.ScopeMetrics {
+			canonicalizeScope(&sm.Scope)
+			for _, m := range sm.Metrics {
+				canonicalizeMetric(m)
+			}
+			sortMessages(sm.Metrics)
+		}
+		sortMessages(rm.ScopeMetrics)
+	}
+	sortMessages(md.ResourceMetrics)
+}
+
+// CanonicalizeLogs puts the logs in their canonical form.
+func CanonicalizeLogs(ld *otlplogs.LogsData) {
+	for _, rl := range ld.ResourceLogs {
+		canonicalizeResource(&rl.Resource)
+		for _, sl := range rl.ScopeLogs {
+			canonicalizeScope(&sl.Scope)
+			for _, lr := range sl.LogRecords {
+				canonicalizeValue(&lr.Body)
+				canonicalizeAttributes(lr.Attributes)
+			}
+			sortMessages(sl.LogRecords)
+		}
+		sortMessages(rl.ScopeLogs)
+	}
+	sortMessages(ld.ResourceLogs)
+}
+
+func canonicalizeMetric(m *otlpmetrics.Metric) {
+	switch data := m.Data.(type) {
+	case *otlpmetrics.Metric_Gauge:
+		canonicalizeNumberDataPoints(data.Gauge.DataPoints)
+	case *otlpmetrics.Metric_Sum:
+		canonicalizeNumberDataPoints(data.Sum.DataPoints)
+	case *otlpmetrics.Metric_Histogram:
+		for _, dp := range data.Histogram.DataPoints {
+			canonicalizeAttributes(dp.Attributes)
+			canonicalizeExemplars(dp.Exemplars)
+		}
+		sortMessages(data.Histogram.DataPoints)
+	case *otlpmetrics.Metric_ExponentialHistogram:
+		for _, dp := range data.ExponentialHistogram.DataPoints {
+			canonicalizeAttributes(dp.Attributes)
+			canonicalizeExemplars(dp.Exemplars)
+		}
+		sortMessages(data.ExponentialHistogram.DataPoints)
+	case *otlpmetrics.Metric_Summary:
+		for _, dp := range data.Summary.DataPoints {
+			canonicalizeAttributes(dp.Attributes)
+		}
+		sortMessages(data.Summary.DataPoints)
+	}
+}
+
+func canonicalizeNumberDataPoints(dps []*otlpmetrics.NumberDataPoint) {
+	for _, dp := range dps {
+		canonicalizeAttributes(dp.Attributes)
+		canonicalizeExemplars(dp.Exemplars)
+	}
+	sortMessages(dps)
+}
+
+func canonicalizeExemplars(exemplars []otlpmetrics.Exemplar) {
+	ptrs := make([]*otlpmetrics.Exemplar, len(exemplars))
+	for i := range exemplars {
+		canonicalizeAttributes(exemplars[i].FilteredAttributes)
+		ptrs[i] = &exemplars[i]
+	}
+	keys := messageKeys(ptrs)
+	sort.Sort(&messageSorter{keys: keys, swap: func(i, j int) {
+		exemplars[i], exemplars[j] = exemplars[j], exemplars[i]
+	}})
+}
+
+func canonicalizeResource(res *otlpresource.Resource) {
+	canonicalizeAttributes(res.Attributes)
+}
+
+func canonicalizeScope(scope *otlpcommon.InstrumentationScope) {
+	canonicalizeAttributes(scope.Attributes)
+}
+
+// canonicalizeAttributes sorts the attributes by key, the order of attributes with the same key is kept.
+func canonicalizeAttributes(kvs []otlpcommon.KeyValue) {
+	for i := range kvs {
+		canonicalizeValue(&kvs[i].Value)
+	}
+	sort.SliceStable(kvs, func(i, j int) bool {
+		return kvs[i].Key < kvs[j].Key
+	})
+}
+
+func canonicalizeValue(v *otlpcommon.AnyValue) {
+	switch val := v.Value.(type) {
+	case *otlpcommon.AnyValue_KvlistValue:
+		if val.KvlistValue != nil {
+			canonicalizeAttributes(val.KvlistValue.Values)
+		}
+	case *otlpcommon.AnyValue_ArrayValue:
+		if val.ArrayValue != nil {
+			for i := range val.ArrayValue.Values {
+				canonicalizeValue(&val.ArrayValue.Values[i])
+			}
+		}
+	}
+}
+
+type protoMessage interface {
+	Marshal() ([]byte, error)
+}
+
+// sortMessages sorts the messages by their encoding. The messages must already be canonical.
+func sortMessages[T protoMessage](msgs []T) {
+	keys := messageKeys(msgs)
+	sort.Sort(&messageSorter{keys: keys, swap: func(i, j int) {
+		msgs[i], msgs[j] = msgs[j], msgs[i]
+	}})
+}
+
+func messageKeys[T protoMessage](msgs []T) [][]byte {
+	keys := make([][]byte, len(msgs))
+	for i, m := range msgs {
+		// An invalid message cannot be marshaled later anyway, so its position does not matter.
+		keys[i], _ = m.Marshal()
+	}
+	return keys
+}
+
+// messageSorter sorts a slice along with the encoding of its elements.
+type messageSorter struct {
+	keys [][]byte
+	swap func(i, j int)
+}
+
+func (s *messageSorter) Len() int {
+	return len(s.keys)
+}
+
+func (s *messageSorter) Less(i, j int) bool {
+	return bytes.Compare(s.keys[i], s.keys[j]) < 0
+}
+
+func (s *messageSorter) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.swap(i, j)
+}
@@ -95,6 +95,23 @@ func Test${structName}_RemoveIf(t *testing.T) {
 		return pos%3 == 0
 	})
 	assert.Equal(t, 5, filtered.Len())
+}
+
+func Test${structName}_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	New${structName}().ForEach(func(int, ${elementName}) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := ${structName}(internal.GenerateTest${structName}())
+	count := 0
+	es.ForEach(func(i int, el ${elementName}) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
 }`
 
 const slicePtrTemplate = `// ${structName} logically represents a slice of ${elementName}.
@@ -139,24 +156,37 @@ func (es ${structName}) At(ix int) ${elementName} {
 	return new${elementName}((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es ${structName}) ForEach(f func(int, ${elementName})) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, new${elementName}(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ${structName}) CopyTo(dest ${structName}) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			new${elementName}((*es.getOrig())[i]).CopyTo(new${elementName}((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*${originName}, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]${originName}, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]${originName}, srcLen)
-	wrappers := make([]*${originName}, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		new${elementName}((*es.getOrig())[i]).CopyTo(new${elementName}(wrappers[i]))
+		new${elementName}((*es.getOrig())[i]).CopyTo(new${elementName}((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -340,14 +370,30 @@ func (es ${structName}) At(ix int) ${elementName} {
 	return new${elementName}(&(*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es ${structName}) ForEach(f func(int, ${elementName})) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, new${elementName}(&orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ${structName}) CopyTo(dest ${structName}) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+		// Elements past the previous length may still share state with removed elements.
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = ${originName}{}
+		}
 	} else {
-		(*dest.getOrig()) = make([]${originName}, srcLen)
+		origs := make([]${originName}, srcLen)
+		copy(origs, *dest.getOrig())
+		*dest.getOrig() = origs
 	}
 
 	for i := range *es.getOrig() {
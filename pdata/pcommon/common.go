@@ -357,8 +357,7 @@ func (v Value) CopyTo(dest Value) {
 			bv = &otlpcommon.AnyValue_BytesValue{}
 			destOrig.Value = bv
 		}
-		bv.BytesValue = make([]byte, len(ov.BytesValue))
-		copy(bv.BytesValue, ov.BytesValue)
+		bv.BytesValue = append(bv.BytesValue[:0], ov.BytesValue...)
 	default:
 		// Primitive immutable type, no need for deep copy.
 		destOrig.Value = ov
@@ -758,27 +757,49 @@ func (m Map) Range(f func(k string, v Value) bool) {
 // CopyTo copies all elements from the current map overriding the destination.
 func (m Map) CopyTo(dest Map) {
 	newLen := len(*m.getOrig())
-	oldCap := cap(*dest.getOrig())
-	if newLen <= oldCap {
+	oldLen := len(*dest.getOrig())
+	if newLen <= cap(*dest.getOrig()) {
 		// New slice fits in existing slice, no need to reallocate.
-		*dest.getOrig() = (*dest.getOrig())[:newLen:oldCap]
-		for i := range *m.getOrig() {
-			akv := &(*m.getOrig())[i]
-			destAkv := &(*dest.getOrig())[i]
-			destAkv.Key = akv.Key
-			newValue(&akv.Value).CopyTo(newValue(&destAkv.Value))
+		*dest.getOrig() = (*dest.getOrig())[:newLen]
+		// Entries past the previous length may still share values with removed entries.
+		for i := oldLen; i < newLen; i++ {
+			(*dest.getOrig())[i] = otlpcommon.KeyValue{}
 		}
-		return
+	} else {
+		// New slice is bigger than exist slice. Allocate new space, keeping the existing
+		// entries so that their values can be reused.
+		origs := make([]otlpcommon.KeyValue, newLen)
+		copy(origs, *dest.getOrig())
+		*dest.getOrig() = origs
 	}
 
-	// New slice is bigger than exist slice. Allocate new space.
-	origs := make([]otlpcommon.KeyValue, len(*m.getOrig()))
 	for i := range *m.getOrig() {
 		akv := &(*m.getOrig())[i]
-		origs[i].Key = akv.Key
-		newValue(&akv.Value).CopyTo(newValue(&origs[i].Value))
+		destAkv := &(*dest.getOrig())[i]
+		destAkv.Key = akv.Key
+		newValue(&akv.Value).CopyTo(newValue(&destAkv.Value))
+	}
+}
+
+// MoveAndAppendTo moves all entries from the current map and appends them to the dest.
+// Entries with a key that already exists in the dest override the existing value.
+// The current map will be cleared.
+func (m Map) MoveAndAppendTo(dest Map) {
+	if len(*dest.getOrig()) == 0 {
+		// We can simply move the entire vector and avoid any allocations.
+		*dest.getOrig() = *m.getOrig()
+		*m.getOrig() = nil
+		return
 	}
-	*dest.getOrig() = origs
+	for i := range *m.getOrig() {
+		akv := &(*m.getOrig())[i]
+		if av, existing := dest.Get(akv.Key); existing {
+			*av.getOrig() = akv.Value
+			continue
+		}
+		*dest.getOrig() = append(*dest.getOrig(), *akv)
+	}
+	*m.getOrig() = nil
 }
 
 // AsRaw converts an OTLP Map to a standard go map
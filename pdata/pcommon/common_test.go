@@ -635,6 +635,64 @@ func TestMap_CopyTo(t *testing.T) {
 	assert.EqualValues(t, Map(internal.GenerateTestMap()), dest)
 }
 
+func TestMap_CopyToReuseRemovedEntries(t *testing.T) {
+	dest := NewMap()
+	dest.PutEmptyMap("k1").PutStr("inner", "v1")
+	dest.PutEmptyMap("k2").PutStr("inner", "v2")
+	dest.Remove("k1")
+
+	src := NewMap()
+	src.PutStr("a", "b")
+	src.PutEmptyMap("c").PutStr("inner", "overwritten")
+	src.CopyTo(dest)
+	assert.Equal(t, src.AsRaw(), dest.AsRaw())
+
+	// Modifying the copy must not affect the source.
+	dest.PutStr("a", "changed")
+	val, ok := src.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "b", val.Str())
+}
+
+func TestMap_CopyToBytesReuse(t *testing.T) {
+	src := NewMap()
+	src.PutEmptyBytes("k").FromRaw([]byte{1, 2})
+	dest := NewMap()
+	dest.PutEmptyBytes("k").FromRaw([]byte{5, 6, 7})
+	src.CopyTo(dest)
+	assert.Equal(t, src.AsRaw(), dest.AsRaw())
+
+	val, _ := dest.Get("k")
+	val.Bytes().SetAt(0, 9)
+	srcVal, _ := src.Get("k")
+	assert.Equal(t, []byte{1, 2}, srcVal.Bytes().AsRaw())
+}
+
+func TestMap_MoveAndAppendTo(t *testing.T) {
+	// Test MoveAndAppendTo to empty
+	expectedMap := Map(internal.GenerateTestMap())
+	dest := NewMap()
+	src := Map(internal.GenerateTestMap())
+	src.MoveAndAppendTo(dest)
+	assert.EqualValues(t, expectedMap, dest)
+	assert.EqualValues(t, 0, src.Len())
+
+	// Test MoveAndAppendTo empty map
+	NewMap().MoveAndAppendTo(dest)
+	assert.EqualValues(t, expectedMap, dest)
+
+	// Test MoveAndAppendTo with new and existing keys
+	dest = NewMap()
+	dest.PutStr("k1", "v1")
+	dest.PutStr("k2", "v2")
+	src = NewMap()
+	src.PutStr("k2", "new")
+	src.PutInt("k3", 3)
+	src.MoveAndAppendTo(dest)
+	assert.Equal(t, map[string]any{"k1": "v1", "k2": "new", "k3": int64(3)}, dest.AsRaw())
+	assert.EqualValues(t, 0, src.Len())
+}
+
 func TestMap_EnsureCapacity_Zero(t *testing.T) {
 	am := NewMap()
 	am.EnsureCapacity(0)
@@ -141,14 +141,30 @@ func (es Slice) At(ix int) Value {
 	return newValue(&(*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es Slice) ForEach(f func(int, Value)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newValue(&orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es Slice) CopyTo(dest Slice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+		// Elements past the previous length may still share state with removed elements.
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = otlpcommon.AnyValue{}
+		}
 	} else {
-		(*dest.getOrig()) = make([]otlpcommon.AnyValue, srcLen)
+		origs := make([]otlpcommon.AnyValue, srcLen)
+		copy(origs, *dest.getOrig())
+		*dest.getOrig() = origs
 	}
 
 	for i := range *es.getOrig() {
@@ -172,3 +172,20 @@ func TestSlice_RemoveIf(t *testing.T) {
 	})
 	assert.Equal(t, 5, filtered.Len())
 }
+
+func TestSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewSlice().ForEach(func(int, Value) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := Slice(internal.GenerateTestSlice())
+	count := 0
+	es.ForEach(func(i int, el Value) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// StringPredicate is a compiled condition on a string, either equal to a value or matching a regular
+// expression. It is safe for concurrent use, and evaluating it does not allocate.
+// The zero value matches the empty string only.
+type StringPredicate struct {
+	value  string
+	regexp *regexp.Regexp
+}
+
+// NewStringEqualsPredicate returns a StringPredicate matching the strings equal to value.
+func NewStringEqualsPredicate(value string) StringPredicate {
+	return StringPredicate{value: value}
+}
+
+// NewStringMatchesPredicate returns a StringPredicate matching the strings matched by the regular
+// expression pattern, in the RE2 syntax. The pattern is not anchored, use ^ and $ to match whole strings.
+func NewStringMatchesPredicate(pattern string) (StringPredicate, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return StringPredicate{}, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return StringPredicate{regexp: re}, nil
+}
+
+// Eval returns whether s matches the predicate.
+func (p StringPredicate) Eval(s string) bool {
+	if p.regexp != nil {
+		return p.regexp.MatchString(s)
+	}
+	return s == p.value
+}
+
+// AttributePredicate is a compiled condition on the value of an attribute of a Map. A Map without the
+// attribute never matches. It is safe for concurrent use, and evaluating it does not allocate.
+type AttributePredicate struct {
+	key   string
+	value StringPredicate
+
+	// Typed forms of the value of equality predicates, compiled once to compare the attributes that
+	// are not strings without formatting them.
+	isEquals bool
+	intOK    bool
+	intVal   int64
+	doubleOK bool
+	double   float64
+	boolOK   bool
+	boolVal  bool
+}
+
+// NewAttributeEqualsPredicate returns an AttributePredicate matching the maps in which the attribute key is
+// a string equal to value, or an int, a double or a bool equal to value parsed as such.
+func NewAttributeEqualsPredicate(key string, value string) AttributePredicate {
+	p := AttributePredicate{key: key, value: NewStringEqualsPredicate(value), isEquals: true}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		p.intOK, p.intVal = true, i
+	}
+	if d, err := strconv.ParseFloat(value, 64); err == nil {
+		p.doubleOK, p.double = true, d
+	}
+	if b, err := strconv.ParseBool(value); err == nil {
+		p.boolOK, p.boolVal = true, b
+	}
+	return p
+}
+
+// NewAttributeMatchesPredicate returns an AttributePredicate matching the maps in which the attribute key
+// is a string matched by the regular expression pattern, see NewStringMatchesPredicate.
+// Attributes of other types never match.
+func NewAttributeMatchesPredicate(key string, pattern string) (AttributePredicate, error) {
+	value, err := NewStringMatchesPredicate(pattern)
+	if err != nil {
+		return AttributePredicate{}, fmt.Errorf("attribute %q: %w", key, err)
+	}
+	return AttributePredicate{key: key, value: value}, nil
+}
+
+// Eval returns whether the attributes m match the predicate.
+func (p AttributePredicate) Eval(m Map) bool {
+	v, ok := m.Get(p.key)
+	if !ok {
+		return false
+	}
+	switch v.Type() {
+	case ValueTypeStr:
+		return p.value.Eval(v.Str())
+	case ValueTypeInt:
+		return p.isEquals && p.intOK && v.Int() == p.intVal
+	case ValueTypeDouble:
+		return p.isEquals && p.doubleOK && v.Double() == p.double
+	case ValueTypeBool:
+		return p.isEquals && p.boolOK && v.Bool() == p.boolVal
+	}
+	return false
+}
+
+// EvalAttributePredicates returns whether the attributes m match all the predicates.
+func EvalAttributePredicates(m Map, predicates []AttributePredicate) bool {
+	for i := range predicates {
+		if !predicates[i].Eval(m) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringPredicate(t *testing.T) {
+	p := NewStringEqualsPredicate("foo")
+	assert.True(t, p.Eval("foo"))
+	assert.False(t, p.Eval("foobar"))
+
+	p, err := NewStringMatchesPredicate("^foo")
+	require.NoError(t, err)
+	assert.True(t, p.Eval("foobar"))
+	assert.False(t, p.Eval("barfoo"))
+
+	_, err = NewStringMatchesPredicate("(")
+	assert.ErrorContains(t, err, `invalid pattern "("`)
+}
+
+func TestAttributeEqualsPredicate(t *testing.T) {
+	m := NewMap()
+	m.PutStr("str", "200")
+	m.PutInt("int", 200)
+	m.PutDouble("double", 200)
+	m.PutBool("bool", true)
+	m.PutEmptySlice("slice")
+
+	tests := []struct {
+		key   string
+		value string
+		want  bool
+	}{
+		{key: "str", value: "200", want: true},
+		{key: "str", value: "201", want: false},
+		{key: "int", value: "200", want: true},
+		{key: "int", value: "201", want: false},
+		{key: "int", value: "abc", want: false},
+		{key: "double", value: "200", want: true},
+		{key: "double", value: "200.5", want: false},
+		{key: "bool", value: "true", want: true},
+		{key: "bool", value: "false", want: false},
+		{key: "slice", value: "[]", want: false},
+		{key: "missing", value: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewAttributeEqualsPredicate(tt.key, tt.value).Eval(m))
+		})
+	}
+}
+
+func TestAttributeMatchesPredicate(t *testing.T) {
+	m := NewMap()
+	m.PutStr("http.route", "/api/users")
+	m.PutInt("http.status_code", 200)
+
+	p, err := NewAttributeMatchesPredicate("http.route", "^/api/")
+	require.NoError(t, err)
+	assert.True(t, p.Eval(m))
+
+	p, err = NewAttributeMatchesPredicate("http.status_code", "^2")
+	require.NoError(t, err)
+	assert.False(t, p.Eval(m))
+
+	_, err = NewAttributeMatchesPredicate("http.route", "(")
+	assert.ErrorContains(t, err, `attribute "http.route": invalid pattern "("`)
+}
+
+func TestEvalAttributePredicates(t *testing.T) {
+	m := NewMap()
+	m.PutStr("service.name", "frontend")
+	m.PutStr("deployment.environment", "prod")
+
+	assert.True(t, EvalAttributePredicates(m, nil))
+	assert.True(t, EvalAttributePredicates(m, []AttributePredicate{
+		NewAttributeEqualsPredicate("service.name", "frontend"),
+		NewAttributeEqualsPredicate("deployment.environment", "prod"),
+	}))
+	assert.False(t, EvalAttributePredicates(m, []AttributePredicate{
+		NewAttributeEqualsPredicate("service.name", "frontend"),
+		NewAttributeEqualsPredicate("deployment.environment", "dev"),
+	}))
+}
+
+func TestAttributePredicateDoesNotAllocate(t *testing.T) {
+	m := NewMap()
+	m.PutStr("http.route", "/api/users")
+	m.PutInt("http.status_code", 200)
+	predicates := []AttributePredicate{
+		NewAttributeEqualsPredicate("http.status_code", "200"),
+	}
+	p, err := NewAttributeMatchesPredicate("http.route", "^/api/")
+	require.NoError(t, err)
+	predicates = append(predicates, p)
+
+	assert.Equal(t, float64(0), testing.AllocsPerRun(100, func() {
+		EvalAttributePredicates(m, predicates)
+	}))
+}
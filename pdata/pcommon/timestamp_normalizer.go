@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
+
+import (
+	"time"
+)
+
+// TimestampNormalizer adjusts the timestamps of records relative to a reference time, usually the time the
+// data was received, so that backends rejecting out-of-range timestamps accept them. The zero value does
+// not adjust anything.
+type TimestampNormalizer struct {
+	// MaxFuture is how far after the reference time a timestamp can be. Later timestamps are clamped to
+	// the reference time plus MaxFuture. Zero disables the check.
+	MaxFuture time.Duration
+
+	// MaxAge is how far before the reference time a timestamp can be. Earlier timestamps are clamped to
+	// the reference time minus MaxAge. Zero disables the check.
+	MaxAge time.Duration
+
+	// ReplaceZero replaces zero timestamps with the reference time.
+	ReplaceZero bool
+}
+
+// TimestampAdjustments counts the timestamps adjusted by a TimestampNormalizer.
+type TimestampAdjustments struct {
+	// Zero is the number of zero timestamps replaced with the reference time.
+	Zero int
+	// Future is the number of timestamps clamped because they were too far in the future.
+	Future int
+	// Past is the number of timestamps clamped because they were too far in the past.
+	Past int
+}
+
+// Total returns the number of adjusted timestamps.
+func (a TimestampAdjustments) Total() int {
+	return a.Zero + a.Future + a.Past
+}
+
+// Add adds the adjustments counted in other to a.
+func (a *TimestampAdjustments) Add(other TimestampAdjustments) {
+	a.Zero += other.Zero
+	a.Future += other.Future
+	a.Past += other.Past
+}
+
+// Normalize returns ts adjusted relative to the reference time now, and counts the adjustment in adj.
+// Zero timestamps are left untouched unless ReplaceZero is set, since they usually mean that the
+// timestamp is unknown.
+func (tn TimestampNormalizer) Normalize(ts Timestamp, now Timestamp, adj *TimestampAdjustments) Timestamp {
+	if ts == 0 {
+		if !tn.ReplaceZero {
+			return ts
+		}
+		adj.Zero++
+		return now
+	}
+	if tn.MaxFuture > 0 {
+		if limit := now + Timestamp(tn.MaxFuture); ts > limit {
+			adj.Future++
+			return limit
+		}
+	}
+	if tn.MaxAge > 0 {
+		limit := Timestamp(0)
+		if Timestamp(tn.MaxAge) < now {
+			limit = now - Timestamp(tn.MaxAge)
+		}
+		if ts < limit {
+			adj.Past++
+			return limit
+		}
+	}
+	return ts
+}
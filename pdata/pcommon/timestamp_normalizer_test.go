@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pcommon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestampNormalizer(t *testing.T) {
+	now := NewTimestampFromTime(time.Unix(100000, 0))
+	tn := TimestampNormalizer{MaxFuture: time.Minute, MaxAge: time.Hour, ReplaceZero: true}
+
+	var adj TimestampAdjustments
+	assert.Equal(t, now, tn.Normalize(0, now, &adj))
+	assert.Equal(t, now+Timestamp(time.Second), tn.Normalize(now+Timestamp(time.Second), now, &adj))
+	assert.Equal(t, now+Timestamp(time.Minute), tn.Normalize(now+Timestamp(time.Hour), now, &adj))
+	assert.Equal(t, now-Timestamp(time.Hour), tn.Normalize(now-Timestamp(2*time.Hour), now, &adj))
+	assert.Equal(t, TimestampAdjustments{Zero: 1, Future: 1, Past: 1}, adj)
+	assert.Equal(t, 3, adj.Total())
+
+	adj.Add(TimestampAdjustments{Zero: 1, Future: 2, Past: 3})
+	assert.Equal(t, TimestampAdjustments{Zero: 2, Future: 3, Past: 4}, adj)
+}
+
+func TestTimestampNormalizerDisabled(t *testing.T) {
+	now := NewTimestampFromTime(time.Unix(1000, 0))
+	var tn TimestampNormalizer
+
+	var adj TimestampAdjustments
+	assert.Equal(t, Timestamp(0), tn.Normalize(0, now, &adj))
+	assert.Equal(t, now+Timestamp(time.Hour), tn.Normalize(now+Timestamp(time.Hour), now, &adj))
+	assert.Equal(t, Timestamp(1), tn.Normalize(1, now, &adj))
+	assert.Zero(t, adj.Total())
+}
+
+func TestTimestampNormalizerMaxAgeBeforeEpoch(t *testing.T) {
+	now := NewTimestampFromTime(time.Unix(10, 0))
+	tn := TimestampNormalizer{MaxAge: time.Hour}
+
+	var adj TimestampAdjustments
+	assert.Equal(t, Timestamp(1), tn.Normalize(1, now, &adj))
+	assert.Zero(t, adj.Total())
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCanonicalTestLogs(reversed bool) Logs {
+	ld := NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	bodies := []string{"first", "second"}
+	if reversed {
+		bodies = []string{"second", "first"}
+	}
+	for _, b := range bodies {
+		lr := lrs.AppendEmpty()
+		body := lr.Body().SetEmptyMap()
+		if reversed {
+			body.PutStr("message", b)
+			body.PutStr("level", "info")
+		} else {
+			body.PutStr("level", "info")
+			body.PutStr("message", b)
+		}
+	}
+	return ld
+}
+
+func TestCanonicalMarshaler(t *testing.T) {
+	marshaler := NewCanonicalMarshaler(&JSONMarshaler{})
+
+	canonical1, err := marshaler.MarshalLogs(newCanonicalTestLogs(false))
+	require.NoError(t, err)
+	canonical2, err := marshaler.MarshalLogs(newCanonicalTestLogs(true))
+	require.NoError(t, err)
+	assert.Equal(t, string(canonical1), string(canonical2))
+}
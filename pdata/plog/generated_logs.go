@@ -69,24 +69,37 @@ func (es ResourceLogsSlice) At(ix int) ResourceLogs {
 	return newResourceLogs((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es ResourceLogsSlice) ForEach(f func(int, ResourceLogs)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newResourceLogs(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ResourceLogsSlice) CopyTo(dest ResourceLogsSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newResourceLogs((*es.getOrig())[i]).CopyTo(newResourceLogs((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlplogs.ResourceLogs, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlplogs.ResourceLogs, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlplogs.ResourceLogs, srcLen)
-	wrappers := make([]*otlplogs.ResourceLogs, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newResourceLogs((*es.getOrig())[i]).CopyTo(newResourceLogs(wrappers[i]))
+		newResourceLogs((*es.getOrig())[i]).CopyTo(newResourceLogs((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -269,24 +282,37 @@ func (es ScopeLogsSlice) At(ix int) ScopeLogs {
 	return newScopeLogs((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es ScopeLogsSlice) ForEach(f func(int, ScopeLogs)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newScopeLogs(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ScopeLogsSlice) CopyTo(dest ScopeLogsSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newScopeLogs((*es.getOrig())[i]).CopyTo(newScopeLogs((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlplogs.ScopeLogs, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlplogs.ScopeLogs, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlplogs.ScopeLogs, srcLen)
-	wrappers := make([]*otlplogs.ScopeLogs, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newScopeLogs((*es.getOrig())[i]).CopyTo(newScopeLogs(wrappers[i]))
+		newScopeLogs((*es.getOrig())[i]).CopyTo(newScopeLogs((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -469,24 +495,37 @@ func (es LogRecordSlice) At(ix int) LogRecord {
 	return newLogRecord((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es LogRecordSlice) ForEach(f func(int, LogRecord)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newLogRecord(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es LogRecordSlice) CopyTo(dest LogRecordSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newLogRecord((*es.getOrig())[i]).CopyTo(newLogRecord((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlplogs.LogRecord, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlplogs.LogRecord, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlplogs.LogRecord, srcLen)
-	wrappers := make([]*otlplogs.LogRecord, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newLogRecord((*es.getOrig())[i]).CopyTo(newLogRecord(wrappers[i]))
+		newLogRecord((*es.getOrig())[i]).CopyTo(newLogRecord((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
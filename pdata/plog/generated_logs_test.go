@@ -138,6 +138,23 @@ func TestResourceLogsSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestResourceLogsSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewResourceLogsSlice().ForEach(func(int, ResourceLogs) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := ResourceLogsSlice(internal.GenerateTestResourceLogsSlice())
+	count := 0
+	es.ForEach(func(i int, el ResourceLogs) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestResourceLogs_MoveTo(t *testing.T) {
 	ms := ResourceLogs(internal.GenerateTestResourceLogs())
 	dest := NewResourceLogs()
@@ -286,6 +303,23 @@ func TestScopeLogsSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestScopeLogsSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewScopeLogsSlice().ForEach(func(int, ScopeLogs) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := ScopeLogsSlice(internal.GenerateTestScopeLogsSlice())
+	count := 0
+	es.ForEach(func(i int, el ScopeLogs) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestScopeLogs_MoveTo(t *testing.T) {
 	ms := ScopeLogs(internal.GenerateTestScopeLogs())
 	dest := NewScopeLogs()
@@ -434,6 +468,23 @@ func TestLogRecordSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestLogRecordSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewLogRecordSlice().ForEach(func(int, LogRecord) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := LogRecordSlice(internal.GenerateTestLogRecordSlice())
+	count := 0
+	es.ForEach(func(i int, el LogRecord) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestLogRecord_MoveTo(t *testing.T) {
 	ms := LogRecord(internal.GenerateTestLogRecord())
 	dest := NewLogRecord()
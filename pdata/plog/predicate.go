@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// LogRecordPredicate is a set of conditions on log records, all of which must hold for a log record to
+// match, for example to implement include and exclude settings. The zero value matches all log records.
+// It is safe for concurrent use, and evaluating it does not allocate.
+type LogRecordPredicate struct {
+	// ResourceAttributes are conditions on the attributes of the resource of the log record.
+	ResourceAttributes []pcommon.AttributePredicate
+
+	// Attributes are conditions on the attributes of the log record.
+	Attributes []pcommon.AttributePredicate
+
+	// MinSeverity is the lowest severity number of the log record, inclusive.
+	// SeverityNumberUnspecified does not set a lower bound.
+	MinSeverity SeverityNumber
+
+	// MaxSeverity is the highest severity number of the log record, inclusive.
+	// SeverityNumberUnspecified does not set an upper bound.
+	MaxSeverity SeverityNumber
+}
+
+// Eval returns whether the log record lr, of the resource res, matches the predicate.
+func (p *LogRecordPredicate) Eval(res pcommon.Resource, lr LogRecord) bool {
+	if p.MinSeverity != SeverityNumberUnspecified && lr.SeverityNumber() < p.MinSeverity {
+		return false
+	}
+	if p.MaxSeverity != SeverityNumberUnspecified && lr.SeverityNumber() > p.MaxSeverity {
+		return false
+	}
+	return pcommon.EvalAttributePredicates(lr.Attributes(), p.Attributes) &&
+		pcommon.EvalAttributePredicates(res.Attributes(), p.ResourceAttributes)
+}
+
+// RemoveMatching removes the log records matched by p from ld, then the scopes and resources left
+// without log records. It returns the number of removed log records.
+func (p *LogRecordPredicate) RemoveMatching(ld Logs) int {
+	return p.remove(ld, true)
+}
+
+// KeepMatching removes the log records not matched by p from ld, then the scopes and resources left
+// without log records. It returns the number of removed log records.
+func (p *LogRecordPredicate) KeepMatching(ld Logs) int {
+	return p.remove(ld, false)
+}
+
+func (p *LogRecordPredicate) remove(ld Logs, match bool) int {
+	removed := 0
+	ld.ResourceLogs().RemoveIf(func(rl ResourceLogs) bool {
+		res := rl.Resource()
+		rl.ScopeLogs().RemoveIf(func(sl ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr LogRecord) bool {
+				if p.Eval(res, lr) == match {
+					removed++
+					return true
+				}
+				return false
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	return removed
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func newPredicateTestLogs() Logs {
+	ld := NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "frontend")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty().SetSeverityNumber(SeverityNumberDebug)
+	lr := lrs.AppendEmpty()
+	lr.SetSeverityNumber(SeverityNumberWarn)
+	lr.Attributes().PutStr("component", "db")
+	lrs.AppendEmpty().SetSeverityNumber(SeverityNumberError)
+
+	rl = ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "backend")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().SetSeverityNumber(SeverityNumberInfo)
+	return ld
+}
+
+func TestLogRecordPredicateEval(t *testing.T) {
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("service.name", "frontend")
+	lr := NewLogRecord()
+	lr.SetSeverityNumber(SeverityNumberWarn)
+	lr.Attributes().PutStr("component", "db")
+
+	tests := []struct {
+		name      string
+		predicate LogRecordPredicate
+		want      bool
+	}{
+		{
+			name: "empty",
+			want: true,
+		},
+		{
+			name:      "severity in range",
+			predicate: LogRecordPredicate{MinSeverity: SeverityNumberWarn, MaxSeverity: SeverityNumberWarn4},
+			want:      true,
+		},
+		{
+			name:      "severity below",
+			predicate: LogRecordPredicate{MinSeverity: SeverityNumberError},
+			want:      false,
+		},
+		{
+			name:      "severity above",
+			predicate: LogRecordPredicate{MaxSeverity: SeverityNumberInfo4},
+			want:      false,
+		},
+		{
+			name:      "attribute",
+			predicate: LogRecordPredicate{Attributes: []pcommon.AttributePredicate{pcommon.NewAttributeEqualsPredicate("component", "db")}},
+			want:      true,
+		},
+		{
+			name:      "resource attribute",
+			predicate: LogRecordPredicate{ResourceAttributes: []pcommon.AttributePredicate{pcommon.NewAttributeEqualsPredicate("service.name", "backend")}},
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.predicate.Eval(res, lr))
+		})
+	}
+
+	p := LogRecordPredicate{
+		ResourceAttributes: []pcommon.AttributePredicate{pcommon.NewAttributeEqualsPredicate("service.name", "frontend")},
+		Attributes:         []pcommon.AttributePredicate{pcommon.NewAttributeEqualsPredicate("component", "db")},
+		MinSeverity:        SeverityNumberWarn,
+	}
+	assert.Equal(t, float64(0), testing.AllocsPerRun(100, func() {
+		p.Eval(res, lr)
+	}))
+}
+
+func TestLogRecordPredicateRemoveMatching(t *testing.T) {
+	p := LogRecordPredicate{MinSeverity: SeverityNumberWarn}
+
+	ld := newPredicateTestLogs()
+	assert.Equal(t, 2, p.RemoveMatching(ld))
+	assert.Equal(t, 2, ld.LogRecordCount())
+	assert.Equal(t, 2, ld.ResourceLogs().Len())
+
+	ld = newPredicateTestLogs()
+	assert.Equal(t, 2, p.KeepMatching(ld))
+	assert.Equal(t, 2, ld.LogRecordCount())
+	// The resource left without log records is removed.
+	assert.Equal(t, 1, ld.ResourceLogs().Len())
+}
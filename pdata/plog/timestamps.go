@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// NormalizeTimestamps adjusts the timestamps and the observed timestamps of the log records with the given
+// normalizer relative to now, usually the time the logs were received.
+// It returns the number of adjusted timestamps.
+func (ms Logs) NormalizeTimestamps(tn pcommon.TimestampNormalizer, now pcommon.Timestamp) pcommon.TimestampAdjustments {
+	var adj pcommon.TimestampAdjustments
+	rls := ms.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				lr.SetTimestamp(tn.Normalize(lr.Timestamp(), now, &adj))
+				lr.SetObservedTimestamp(tn.Normalize(lr.ObservedTimestamp(), now, &adj))
+			}
+		}
+	}
+	return adj
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestLogsNormalizeTimestamps(t *testing.T) {
+	now := pcommon.NewTimestampFromTime(time.Unix(10000, 0))
+	ld := NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(now - pcommon.Timestamp(2*time.Hour))
+
+	adj := ld.NormalizeTimestamps(pcommon.TimestampNormalizer{MaxAge: time.Hour, ReplaceZero: true}, now)
+	assert.Equal(t, pcommon.TimestampAdjustments{Zero: 1, Past: 1}, adj)
+	assert.Equal(t, now-pcommon.Timestamp(time.Hour), lr.Timestamp())
+	assert.Equal(t, now, lr.ObservedTimestamp())
+}
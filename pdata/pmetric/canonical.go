@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"go.opentelemetry.io/collector/pdata/internal"
+)
+
+// NewCanonicalMarshaler returns a Marshaler producing byte-identical output for logically identical Metrics,
+// e.g. to hash, deduplicate or snapshot payloads. Before being marshaled by the given Marshaler, a copy
+// of the metrics is put in canonical form: attributes are sorted by key, and repeated messages are sorted
+// by their encoding. The order of array values, histogram buckets and summary quantiles is preserved.
+func NewCanonicalMarshaler(marshaler Marshaler) Marshaler {
+	return &canonicalMarshaler{delegate: marshaler}
+}
+
+type canonicalMarshaler struct {
+	delegate Marshaler
+}
+
+func (m *canonicalMarshaler) MarshalMetrics(md Metrics) ([]byte, error) {
+	canonical := NewMetrics()
+	md.CopyTo(canonical)
+	pb := internal.MetricsToProto(internal.Metrics(canonical))
+	internal.CanonicalizeMetrics(&pb)
+	return m.delegate.MarshalMetrics(canonical)
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCanonicalTestMetrics(reversed bool) Metrics {
+	md := NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("gauge")
+	dps := gauge.SetEmptyGauge().DataPoints()
+	values := []int64{1, 2}
+	if reversed {
+		values = []int64{2, 1}
+	}
+	for _, v := range values {
+		dp := dps.AppendEmpty()
+		dp.SetIntValue(v)
+		if reversed {
+			dp.Attributes().PutStr("b", "b")
+			dp.Attributes().PutStr("a", "a")
+		} else {
+			dp.Attributes().PutStr("a", "a")
+			dp.Attributes().PutStr("b", "b")
+		}
+		for _, e := range values {
+			dp.Exemplars().AppendEmpty().SetIntValue(e)
+		}
+	}
+	hist := sm.Metrics().AppendEmpty()
+	hist.SetName("histogram")
+	dp := hist.SetEmptyHistogram().DataPoints().AppendEmpty()
+	dp.ExplicitBounds().FromRaw([]float64{10, 5})
+	dp.BucketCounts().FromRaw([]uint64{3, 2, 1})
+	if reversed {
+		sm.Metrics().Sort(func(a, b Metric) bool { return a.Name() > b.Name() })
+	}
+	return md
+}
+
+func TestCanonicalMarshaler(t *testing.T) {
+	marshaler := NewCanonicalMarshaler(&ProtoMarshaler{})
+
+	canonical1, err := marshaler.MarshalMetrics(newCanonicalTestMetrics(false))
+	require.NoError(t, err)
+	canonical2, err := marshaler.MarshalMetrics(newCanonicalTestMetrics(true))
+	require.NoError(t, err)
+	assert.Equal(t, canonical1, canonical2)
+
+	got, err := (&ProtoUnmarshaler{}).UnmarshalMetrics(canonical1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, got.DataPointCount())
+	metrics := got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Type() == MetricTypeHistogram {
+			// Buckets keep their order.
+			assert.Equal(t, []float64{10, 5}, metrics.At(i).Histogram().DataPoints().At(0).ExplicitBounds().AsRaw())
+			assert.Equal(t, []uint64{3, 2, 1}, metrics.At(i).Histogram().DataPoints().At(0).BucketCounts().AsRaw())
+		}
+	}
+}
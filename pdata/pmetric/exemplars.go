@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// AppendFromSpan appends an exemplar recorded at the given timestamp while the given span was active,
+// and returns it so that the value and the filtered attributes can be set.
+//
+// The IDs of the span active in a context.Context can be obtained with the OpenTelemetry API:
+//
+//	sc := trace.SpanContextFromContext(ctx)
+//	es.AppendFromSpan(ts, pcommon.TraceID(sc.TraceID()), pcommon.SpanID(sc.SpanID()))
+func (es ExemplarSlice) AppendFromSpan(timestamp pcommon.Timestamp, traceID pcommon.TraceID, spanID pcommon.SpanID) Exemplar {
+	e := es.AppendEmpty()
+	e.SetTimestamp(timestamp)
+	e.SetTraceID(traceID)
+	e.SetSpanID(spanID)
+	return e
+}
+
+// TrimToMaxCount removes the oldest exemplars until there are at most maxCount left.
+// The order of the remaining exemplars is preserved. If maxCount is not positive, all exemplars are removed.
+func (es ExemplarSlice) TrimToMaxCount(maxCount int) {
+	if es.Len() <= maxCount {
+		return
+	}
+	if maxCount <= 0 {
+		es.RemoveIf(func(Exemplar) bool { return true })
+		return
+	}
+
+	// Sort the indexes from the newest to the oldest exemplar, and keep the first maxCount of them.
+	idx := make([]int, es.Len())
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return es.At(idx[i]).Timestamp() > es.At(idx[j]).Timestamp()
+	})
+	keep := make([]bool, es.Len())
+	for _, i := range idx[:maxCount] {
+		keep[i] = true
+	}
+
+	i := 0
+	es.RemoveIf(func(Exemplar) bool {
+		remove := !keep[i]
+		i++
+		return remove
+	})
+}
+
+// RemoveOutsideTimeWindow removes the exemplars with a timestamp before start or after end.
+// A zero end means that the window is not bounded at the end.
+func (es ExemplarSlice) RemoveOutsideTimeWindow(start, end pcommon.Timestamp) {
+	es.RemoveIf(func(e Exemplar) bool {
+		return e.Timestamp() < start || (end != 0 && e.Timestamp() > end)
+	})
+}
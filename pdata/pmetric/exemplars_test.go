@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func newExemplarsWithTimestamps(timestamps ...pcommon.Timestamp) ExemplarSlice {
+	es := NewExemplarSlice()
+	for i, ts := range timestamps {
+		e := es.AppendEmpty()
+		e.SetTimestamp(ts)
+		e.SetIntValue(int64(i))
+	}
+	return es
+}
+
+func exemplarValues(es ExemplarSlice) []int64 {
+	values := make([]int64, 0, es.Len())
+	for i := 0; i < es.Len(); i++ {
+		values = append(values, es.At(i).IntValue())
+	}
+	return values
+}
+
+func TestExemplarSliceAppendFromSpan(t *testing.T) {
+	es := NewExemplarSlice()
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
+	spanID := pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+	e := es.AppendFromSpan(pcommon.Timestamp(123), traceID, spanID)
+	e.SetDoubleValue(1.5)
+
+	assert.Equal(t, 1, es.Len())
+	assert.Equal(t, pcommon.Timestamp(123), es.At(0).Timestamp())
+	assert.Equal(t, traceID, es.At(0).TraceID())
+	assert.Equal(t, spanID, es.At(0).SpanID())
+	assert.Equal(t, 1.5, es.At(0).DoubleValue())
+}
+
+func TestExemplarSliceTrimToMaxCount(t *testing.T) {
+	tests := []struct {
+		name       string
+		timestamps []pcommon.Timestamp
+		maxCount   int
+		expected   []int64
+	}{
+		{
+			name:       "under_limit",
+			timestamps: []pcommon.Timestamp{1, 2},
+			maxCount:   3,
+			expected:   []int64{0, 1},
+		},
+		{
+			name:       "keeps_newest",
+			timestamps: []pcommon.Timestamp{1, 2, 3, 4},
+			maxCount:   2,
+			expected:   []int64{2, 3},
+		},
+		{
+			name:       "unordered",
+			timestamps: []pcommon.Timestamp{4, 1, 3, 2},
+			maxCount:   2,
+			expected:   []int64{0, 2},
+		},
+		{
+			name:       "same_timestamp_keeps_first",
+			timestamps: []pcommon.Timestamp{5, 5, 5},
+			maxCount:   2,
+			expected:   []int64{0, 1},
+		},
+		{
+			name:       "zero",
+			timestamps: []pcommon.Timestamp{1, 2},
+			maxCount:   0,
+			expected:   []int64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := newExemplarsWithTimestamps(tt.timestamps...)
+			es.TrimToMaxCount(tt.maxCount)
+			assert.Equal(t, tt.expected, exemplarValues(es))
+		})
+	}
+}
+
+func TestExemplarSliceRemoveOutsideTimeWindow(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end pcommon.Timestamp
+		expected   []int64
+	}{
+		{
+			name:     "bounded",
+			start:    2,
+			end:      4,
+			expected: []int64{1, 2, 3},
+		},
+		{
+			name:     "unbounded_end",
+			start:    3,
+			expected: []int64{2, 3, 4},
+		},
+		{
+			name:     "empty_window",
+			start:    10,
+			end:      20,
+			expected: []int64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := newExemplarsWithTimestamps(1, 2, 3, 4, 5)
+			es.RemoveOutsideTimeWindow(tt.start, tt.end)
+			assert.Equal(t, tt.expected, exemplarValues(es))
+		})
+	}
+}
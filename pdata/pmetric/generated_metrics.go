@@ -69,24 +69,37 @@ func (es ResourceMetricsSlice) At(ix int) ResourceMetrics {
 	return newResourceMetrics((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es ResourceMetricsSlice) ForEach(f func(int, ResourceMetrics)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newResourceMetrics(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ResourceMetricsSlice) CopyTo(dest ResourceMetricsSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newResourceMetrics((*es.getOrig())[i]).CopyTo(newResourceMetrics((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlpmetrics.ResourceMetrics, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlpmetrics.ResourceMetrics, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlpmetrics.ResourceMetrics, srcLen)
-	wrappers := make([]*otlpmetrics.ResourceMetrics, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newResourceMetrics((*es.getOrig())[i]).CopyTo(newResourceMetrics(wrappers[i]))
+		newResourceMetrics((*es.getOrig())[i]).CopyTo(newResourceMetrics((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -269,24 +282,37 @@ func (es ScopeMetricsSlice) At(ix int) ScopeMetrics {
 	return newScopeMetrics((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es ScopeMetricsSlice) ForEach(f func(int, ScopeMetrics)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newScopeMetrics(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ScopeMetricsSlice) CopyTo(dest ScopeMetricsSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newScopeMetrics((*es.getOrig())[i]).CopyTo(newScopeMetrics((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlpmetrics.ScopeMetrics, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlpmetrics.ScopeMetrics, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlpmetrics.ScopeMetrics, srcLen)
-	wrappers := make([]*otlpmetrics.ScopeMetrics, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newScopeMetrics((*es.getOrig())[i]).CopyTo(newScopeMetrics(wrappers[i]))
+		newScopeMetrics((*es.getOrig())[i]).CopyTo(newScopeMetrics((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -469,24 +495,37 @@ func (es MetricSlice) At(ix int) Metric {
 	return newMetric((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es MetricSlice) ForEach(f func(int, Metric)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newMetric(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es MetricSlice) CopyTo(dest MetricSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newMetric((*es.getOrig())[i]).CopyTo(newMetric((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlpmetrics.Metric, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlpmetrics.Metric, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlpmetrics.Metric, srcLen)
-	wrappers := make([]*otlpmetrics.Metric, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newMetric((*es.getOrig())[i]).CopyTo(newMetric(wrappers[i]))
+		newMetric((*es.getOrig())[i]).CopyTo(newMetric((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -1096,24 +1135,37 @@ func (es NumberDataPointSlice) At(ix int) NumberDataPoint {
 	return newNumberDataPoint((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es NumberDataPointSlice) ForEach(f func(int, NumberDataPoint)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newNumberDataPoint(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es NumberDataPointSlice) CopyTo(dest NumberDataPointSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newNumberDataPoint((*es.getOrig())[i]).CopyTo(newNumberDataPoint((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlpmetrics.NumberDataPoint, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlpmetrics.NumberDataPoint, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlpmetrics.NumberDataPoint, srcLen)
-	wrappers := make([]*otlpmetrics.NumberDataPoint, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newNumberDataPoint((*es.getOrig())[i]).CopyTo(newNumberDataPoint(wrappers[i]))
+		newNumberDataPoint((*es.getOrig())[i]).CopyTo(newNumberDataPoint((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -1361,24 +1413,37 @@ func (es HistogramDataPointSlice) At(ix int) HistogramDataPoint {
 	return newHistogramDataPoint((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es HistogramDataPointSlice) ForEach(f func(int, HistogramDataPoint)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newHistogramDataPoint(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es HistogramDataPointSlice) CopyTo(dest HistogramDataPointSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newHistogramDataPoint((*es.getOrig())[i]).CopyTo(newHistogramDataPoint((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlpmetrics.HistogramDataPoint, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlpmetrics.HistogramDataPoint, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlpmetrics.HistogramDataPoint, srcLen)
-	wrappers := make([]*otlpmetrics.HistogramDataPoint, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newHistogramDataPoint((*es.getOrig())[i]).CopyTo(newHistogramDataPoint(wrappers[i]))
+		newHistogramDataPoint((*es.getOrig())[i]).CopyTo(newHistogramDataPoint((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -1681,24 +1746,37 @@ func (es ExponentialHistogramDataPointSlice) At(ix int) ExponentialHistogramData
 	return newExponentialHistogramDataPoint((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es ExponentialHistogramDataPointSlice) ForEach(f func(int, ExponentialHistogramDataPoint)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newExponentialHistogramDataPoint(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ExponentialHistogramDataPointSlice) CopyTo(dest ExponentialHistogramDataPointSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newExponentialHistogramDataPoint((*es.getOrig())[i]).CopyTo(newExponentialHistogramDataPoint((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlpmetrics.ExponentialHistogramDataPoint, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlpmetrics.ExponentialHistogramDataPoint, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlpmetrics.ExponentialHistogramDataPoint, srcLen)
-	wrappers := make([]*otlpmetrics.ExponentialHistogramDataPoint, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newExponentialHistogramDataPoint((*es.getOrig())[i]).CopyTo(newExponentialHistogramDataPoint(wrappers[i]))
+		newExponentialHistogramDataPoint((*es.getOrig())[i]).CopyTo(newExponentialHistogramDataPoint((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -2080,24 +2158,37 @@ func (es SummaryDataPointSlice) At(ix int) SummaryDataPoint {
 	return newSummaryDataPoint((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es SummaryDataPointSlice) ForEach(f func(int, SummaryDataPoint)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newSummaryDataPoint(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es SummaryDataPointSlice) CopyTo(dest SummaryDataPointSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newSummaryDataPoint((*es.getOrig())[i]).CopyTo(newSummaryDataPoint((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlpmetrics.SummaryDataPoint, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlpmetrics.SummaryDataPoint, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlpmetrics.SummaryDataPoint, srcLen)
-	wrappers := make([]*otlpmetrics.SummaryDataPoint, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newSummaryDataPoint((*es.getOrig())[i]).CopyTo(newSummaryDataPoint(wrappers[i]))
+		newSummaryDataPoint((*es.getOrig())[i]).CopyTo(newSummaryDataPoint((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -2324,24 +2415,37 @@ func (es SummaryDataPointValueAtQuantileSlice) At(ix int) SummaryDataPointValueA
 	return newSummaryDataPointValueAtQuantile((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es SummaryDataPointValueAtQuantileSlice) ForEach(f func(int, SummaryDataPointValueAtQuantile)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newSummaryDataPointValueAtQuantile(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es SummaryDataPointValueAtQuantileSlice) CopyTo(dest SummaryDataPointValueAtQuantileSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newSummaryDataPointValueAtQuantile((*es.getOrig())[i]).CopyTo(newSummaryDataPointValueAtQuantile((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlpmetrics.SummaryDataPoint_ValueAtQuantile, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlpmetrics.SummaryDataPoint_ValueAtQuantile, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlpmetrics.SummaryDataPoint_ValueAtQuantile, srcLen)
-	wrappers := make([]*otlpmetrics.SummaryDataPoint_ValueAtQuantile, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newSummaryDataPointValueAtQuantile((*es.getOrig())[i]).CopyTo(newSummaryDataPointValueAtQuantile(wrappers[i]))
+		newSummaryDataPointValueAtQuantile((*es.getOrig())[i]).CopyTo(newSummaryDataPointValueAtQuantile((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -2523,14 +2627,30 @@ func (es ExemplarSlice) At(ix int) Exemplar {
 	return newExemplar(&(*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es ExemplarSlice) ForEach(f func(int, Exemplar)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newExemplar(&orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ExemplarSlice) CopyTo(dest ExemplarSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+		// Elements past the previous length may still share state with removed elements.
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = otlpmetrics.Exemplar{}
+		}
 	} else {
-		(*dest.getOrig()) = make([]otlpmetrics.Exemplar, srcLen)
+		origs := make([]otlpmetrics.Exemplar, srcLen)
+		copy(origs, *dest.getOrig())
+		*dest.getOrig() = origs
 	}
 
 	for i := range *es.getOrig() {
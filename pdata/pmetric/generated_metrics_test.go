@@ -138,6 +138,23 @@ func TestResourceMetricsSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestResourceMetricsSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewResourceMetricsSlice().ForEach(func(int, ResourceMetrics) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := ResourceMetricsSlice(internal.GenerateTestResourceMetricsSlice())
+	count := 0
+	es.ForEach(func(i int, el ResourceMetrics) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestResourceMetrics_MoveTo(t *testing.T) {
 	ms := ResourceMetrics(internal.GenerateTestResourceMetrics())
 	dest := NewResourceMetrics()
@@ -286,6 +303,23 @@ func TestScopeMetricsSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestScopeMetricsSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewScopeMetricsSlice().ForEach(func(int, ScopeMetrics) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := ScopeMetricsSlice(internal.GenerateTestScopeMetricsSlice())
+	count := 0
+	es.ForEach(func(i int, el ScopeMetrics) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestScopeMetrics_MoveTo(t *testing.T) {
 	ms := ScopeMetrics(internal.GenerateTestScopeMetrics())
 	dest := NewScopeMetrics()
@@ -434,6 +468,23 @@ func TestMetricSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestMetricSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewMetricSlice().ForEach(func(int, Metric) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := MetricSlice(internal.GenerateTestMetricSlice())
+	count := 0
+	es.ForEach(func(i int, el Metric) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestMetric_MoveTo(t *testing.T) {
 	ms := Metric(internal.GenerateTestMetric())
 	dest := NewMetric()
@@ -819,6 +870,23 @@ func TestNumberDataPointSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestNumberDataPointSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewNumberDataPointSlice().ForEach(func(int, NumberDataPoint) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := NumberDataPointSlice(internal.GenerateTestNumberDataPointSlice())
+	count := 0
+	es.ForEach(func(i int, el NumberDataPoint) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestNumberDataPoint_MoveTo(t *testing.T) {
 	ms := NumberDataPoint(internal.GenerateTestNumberDataPoint())
 	dest := NewNumberDataPoint()
@@ -1006,6 +1074,23 @@ func TestHistogramDataPointSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestHistogramDataPointSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewHistogramDataPointSlice().ForEach(func(int, HistogramDataPoint) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := HistogramDataPointSlice(internal.GenerateTestHistogramDataPointSlice())
+	count := 0
+	es.ForEach(func(i int, el HistogramDataPoint) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestHistogramDataPoint_MoveTo(t *testing.T) {
 	ms := HistogramDataPoint(internal.GenerateTestHistogramDataPoint())
 	dest := NewHistogramDataPoint()
@@ -1223,6 +1308,23 @@ func TestExponentialHistogramDataPointSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestExponentialHistogramDataPointSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewExponentialHistogramDataPointSlice().ForEach(func(int, ExponentialHistogramDataPoint) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := ExponentialHistogramDataPointSlice(internal.GenerateTestExponentialHistogramDataPointSlice())
+	count := 0
+	es.ForEach(func(i int, el ExponentialHistogramDataPoint) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestExponentialHistogramDataPoint_MoveTo(t *testing.T) {
 	ms := ExponentialHistogramDataPoint(internal.GenerateTestExponentialHistogramDataPoint())
 	dest := NewExponentialHistogramDataPoint()
@@ -1484,6 +1586,23 @@ func TestSummaryDataPointSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestSummaryDataPointSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewSummaryDataPointSlice().ForEach(func(int, SummaryDataPoint) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := SummaryDataPointSlice(internal.GenerateTestSummaryDataPointSlice())
+	count := 0
+	es.ForEach(func(i int, el SummaryDataPoint) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestSummaryDataPoint_MoveTo(t *testing.T) {
 	ms := SummaryDataPoint(internal.GenerateTestSummaryDataPoint())
 	dest := NewSummaryDataPoint()
@@ -1664,6 +1783,23 @@ func TestSummaryDataPointValueAtQuantileSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestSummaryDataPointValueAtQuantileSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewSummaryDataPointValueAtQuantileSlice().ForEach(func(int, SummaryDataPointValueAtQuantile) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := SummaryDataPointValueAtQuantileSlice(internal.GenerateTestSummaryDataPointValueAtQuantileSlice())
+	count := 0
+	es.ForEach(func(i int, el SummaryDataPointValueAtQuantile) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestSummaryDataPointValueAtQuantile_MoveTo(t *testing.T) {
 	ms := SummaryDataPointValueAtQuantile(internal.GenerateTestSummaryDataPointValueAtQuantile())
 	dest := NewSummaryDataPointValueAtQuantile()
@@ -1797,6 +1933,23 @@ func TestExemplarSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestExemplarSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewExemplarSlice().ForEach(func(int, Exemplar) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := ExemplarSlice(internal.GenerateTestExemplarSlice())
+	count := 0
+	es.ForEach(func(i int, el Exemplar) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestExemplar_MoveTo(t *testing.T) {
 	ms := Exemplar(internal.GenerateTestExemplar())
 	dest := NewExemplar()
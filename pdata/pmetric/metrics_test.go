@@ -983,3 +983,29 @@ func generateMetricsEmptyDataPoints() Metrics {
 		},
 	})
 }
+
+func TestResourceMetricsSliceCopyToReuse(t *testing.T) {
+	src := NewResourceMetricsSlice()
+	src.AppendEmpty().Resource().Attributes().PutStr("k", "v1")
+	src.AppendEmpty().Resource().Attributes().PutStr("k", "v2")
+
+	// Copy into a destination with spare capacity and nil pointers past its length.
+	dest := NewResourceMetricsSlice()
+	dest.EnsureCapacity(5)
+	src.CopyTo(dest)
+	assert.Equal(t, src, dest)
+
+	// Copy into a destination where removed elements are still referenced past its length.
+	dest = NewResourceMetricsSlice()
+	dest.AppendEmpty().Resource().Attributes().PutStr("k", "removed")
+	kept := dest.AppendEmpty()
+	kept.Resource().Attributes().PutStr("k", "kept")
+	dest.RemoveIf(func(rm ResourceMetrics) bool {
+		val, _ := rm.Resource().Attributes().Get("k")
+		return val.Str() == "removed"
+	})
+	src.CopyTo(dest)
+	assert.Equal(t, src, dest)
+	dest.At(1).Resource().Attributes().PutStr("k", "changed")
+	assert.Equal(t, "v1", dest.At(0).Resource().Attributes().AsRaw()["k"])
+}
@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pmetricutil provides utilities to manipulate pmetric.Metrics.
+package pmetricutil // import "go.opentelemetry.io/collector/pdata/pmetric/pmetricutil"
+
+import (
+	"reflect"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// MergeMetrics moves all the metrics from src into dst, leaving src empty.
+//
+// ResourceMetrics with identical resources (attributes, dropped attributes count and schema URL) are
+// merged into a single entry, and so are the ScopeMetrics with identical scopes (name, version,
+// attributes, dropped attributes count and schema URL) within a resource. This applies to the
+// entries that were already duplicated in dst as well. Metrics themselves are appended, never merged.
+func MergeMetrics(dst, src pmetric.Metrics) {
+	dstRMs := dst.ResourceMetrics()
+
+	var resources []*resourceEntry
+	duplicates := make([]bool, dstRMs.Len())
+	for i := 0; i < dstRMs.Len(); i++ {
+		rm := dstRMs.At(i)
+		if re := findResource(resources, rm); re != nil {
+			re.mergeScopes(rm.ScopeMetrics())
+			duplicates[i] = true
+			continue
+		}
+		resources = append(resources, newResourceEntry(rm))
+	}
+	idx := 0
+	dstRMs.RemoveIf(func(pmetric.ResourceMetrics) bool {
+		dup := duplicates[idx]
+		idx++
+		return dup
+	})
+
+	srcRMs := src.ResourceMetrics()
+	for i := 0; i < srcRMs.Len(); i++ {
+		rm := srcRMs.At(i)
+		if re := findResource(resources, rm); re != nil {
+			re.mergeScopes(rm.ScopeMetrics())
+			continue
+		}
+		newRM := dstRMs.AppendEmpty()
+		rm.MoveTo(newRM)
+		resources = append(resources, newResourceEntry(newRM))
+	}
+	srcRMs.RemoveIf(func(pmetric.ResourceMetrics) bool { return true })
+}
+
+// resourceEntry holds the identity of a ResourceMetrics in the destination, and the identity of its scopes.
+type resourceEntry struct {
+	attrs     map[string]any
+	dropped   uint32
+	schemaURL string
+	rm        pmetric.ResourceMetrics
+	scopes    []*scopeEntry
+}
+
+func newResourceEntry(rm pmetric.ResourceMetrics) *resourceEntry {
+	re := &resourceEntry{
+		attrs:     rm.Resource().Attributes().AsRaw(),
+		dropped:   rm.Resource().DroppedAttributesCount(),
+		schemaURL: rm.SchemaUrl(),
+		rm:        rm,
+	}
+	// Deduplicate the scopes that are already in the destination.
+	sms := rm.ScopeMetrics()
+	duplicates := make([]bool, sms.Len())
+	for i := 0; i < sms.Len(); i++ {
+		sm := sms.At(i)
+		if se := findScope(re.scopes, sm); se != nil {
+			sm.Metrics().MoveAndAppendTo(se.sm.Metrics())
+			duplicates[i] = true
+			continue
+		}
+		re.scopes = append(re.scopes, newScopeEntry(sm))
+	}
+	idx := 0
+	sms.RemoveIf(func(pmetric.ScopeMetrics) bool {
+		dup := duplicates[idx]
+		idx++
+		return dup
+	})
+	return re
+}
+
+func (re *resourceEntry) matches(rm pmetric.ResourceMetrics) bool {
+	return re.schemaURL == rm.SchemaUrl() &&
+		re.dropped == rm.Resource().DroppedAttributesCount() &&
+		mapEqual(re.attrs, rm.Resource().Attributes())
+}
+
+// mergeScopes moves all the ScopeMetrics from sms into the entry, leaving sms empty.
+func (re *resourceEntry) mergeScopes(sms pmetric.ScopeMetricsSlice) {
+	dstSMs := re.rm.ScopeMetrics()
+	for i := 0; i < sms.Len(); i++ {
+		sm := sms.At(i)
+		if se := findScope(re.scopes, sm); se != nil {
+			sm.Metrics().MoveAndAppendTo(se.sm.Metrics())
+			continue
+		}
+		newSM := dstSMs.AppendEmpty()
+		sm.MoveTo(newSM)
+		re.scopes = append(re.scopes, newScopeEntry(newSM))
+	}
+	sms.RemoveIf(func(pmetric.ScopeMetrics) bool { return true })
+}
+
+// scopeEntry holds the identity of a ScopeMetrics in the destination.
+type scopeEntry struct {
+	name      string
+	version   string
+	attrs     map[string]any
+	dropped   uint32
+	schemaURL string
+	sm        pmetric.ScopeMetrics
+}
+
+func newScopeEntry(sm pmetric.ScopeMetrics) *scopeEntry {
+	return &scopeEntry{
+		name:      sm.Scope().Name(),
+		version:   sm.Scope().Version(),
+		attrs:     sm.Scope().Attributes().AsRaw(),
+		dropped:   sm.Scope().DroppedAttributesCount(),
+		schemaURL: sm.SchemaUrl(),
+		sm:        sm,
+	}
+}
+
+func (se *scopeEntry) matches(sm pmetric.ScopeMetrics) bool {
+	return se.name == sm.Scope().Name() &&
+		se.version == sm.Scope().Version() &&
+		se.schemaURL == sm.SchemaUrl() &&
+		se.dropped == sm.Scope().DroppedAttributesCount() &&
+		mapEqual(se.attrs, sm.Scope().Attributes())
+}
+
+func findResource(resources []*resourceEntry, rm pmetric.ResourceMetrics) *resourceEntry {
+	for _, re := range resources {
+		if re.matches(rm) {
+			return re
+		}
+	}
+	return nil
+}
+
+func findScope(scopes []*scopeEntry, sm pmetric.ScopeMetrics) *scopeEntry {
+	for _, se := range scopes {
+		if se.matches(sm) {
+			return se
+		}
+	}
+	return nil
+}
+
+func mapEqual(raw map[string]any, m pcommon.Map) bool {
+	if len(raw) != m.Len() {
+		return false
+	}
+	return reflect.DeepEqual(raw, m.AsRaw())
+}
@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetricutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func appendMetric(md pmetric.Metrics, service, scope, metric string) {
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", service)
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scope)
+	sm.Metrics().AppendEmpty().SetName(metric)
+}
+
+func metricNames(sm pmetric.ScopeMetrics) []string {
+	var names []string
+	for i := 0; i < sm.Metrics().Len(); i++ {
+		names = append(names, sm.Metrics().At(i).Name())
+	}
+	return names
+}
+
+func TestMergeMetrics(t *testing.T) {
+	dst := pmetric.NewMetrics()
+	appendMetric(dst, "svc-a", "scope-1", "m1")
+	appendMetric(dst, "svc-b", "scope-1", "m2")
+
+	src := pmetric.NewMetrics()
+	appendMetric(src, "svc-a", "scope-1", "m3")
+	appendMetric(src, "svc-a", "scope-2", "m4")
+	appendMetric(src, "svc-c", "scope-1", "m5")
+	appendMetric(src, "svc-c", "scope-1", "m6")
+
+	MergeMetrics(dst, src)
+
+	assert.Equal(t, 0, src.ResourceMetrics().Len())
+	assert.Equal(t, 6, dst.MetricCount())
+	rms := dst.ResourceMetrics()
+	require.Equal(t, 3, rms.Len())
+
+	svcA := rms.At(0)
+	assert.Equal(t, map[string]any{"service.name": "svc-a"}, svcA.Resource().Attributes().AsRaw())
+	require.Equal(t, 2, svcA.ScopeMetrics().Len())
+	assert.Equal(t, "scope-1", svcA.ScopeMetrics().At(0).Scope().Name())
+	assert.Equal(t, []string{"m1", "m3"}, metricNames(svcA.ScopeMetrics().At(0)))
+	assert.Equal(t, "scope-2", svcA.ScopeMetrics().At(1).Scope().Name())
+	assert.Equal(t, []string{"m4"}, metricNames(svcA.ScopeMetrics().At(1)))
+
+	svcB := rms.At(1)
+	require.Equal(t, 1, svcB.ScopeMetrics().Len())
+	assert.Equal(t, []string{"m2"}, metricNames(svcB.ScopeMetrics().At(0)))
+
+	svcC := rms.At(2)
+	assert.Equal(t, map[string]any{"service.name": "svc-c"}, svcC.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, svcC.ScopeMetrics().Len())
+	assert.Equal(t, []string{"m5", "m6"}, metricNames(svcC.ScopeMetrics().At(0)))
+}
+
+func TestMergeMetricsDeduplicatesDestination(t *testing.T) {
+	dst := pmetric.NewMetrics()
+	appendMetric(dst, "svc-a", "scope-1", "m1")
+	appendMetric(dst, "svc-b", "scope-1", "m2")
+	appendMetric(dst, "svc-a", "scope-1", "m3")
+	dupScope := dst.ResourceMetrics().At(1).ScopeMetrics().AppendEmpty()
+	dupScope.Scope().SetName("scope-1")
+	dupScope.Metrics().AppendEmpty().SetName("m4")
+
+	MergeMetrics(dst, pmetric.NewMetrics())
+
+	rms := dst.ResourceMetrics()
+	require.Equal(t, 2, rms.Len())
+	require.Equal(t, 1, rms.At(0).ScopeMetrics().Len())
+	assert.Equal(t, []string{"m1", "m3"}, metricNames(rms.At(0).ScopeMetrics().At(0)))
+	require.Equal(t, 1, rms.At(1).ScopeMetrics().Len())
+	assert.Equal(t, []string{"m2", "m4"}, metricNames(rms.At(1).ScopeMetrics().At(0)))
+}
+
+func TestMergeMetricsIdentity(t *testing.T) {
+	tests := []struct {
+		name           string
+		modify         func(rm pmetric.ResourceMetrics)
+		expectedRMs    int
+		expectedScopes int
+	}{
+		{
+			name:           "identical",
+			modify:         func(rm pmetric.ResourceMetrics) {},
+			expectedRMs:    1,
+			expectedScopes: 1,
+		},
+		{
+			name: "resource schema url",
+			modify: func(rm pmetric.ResourceMetrics) {
+				rm.SetSchemaUrl("https://opentelemetry.io/schemas/1.13.0")
+			},
+			expectedRMs:    2,
+			expectedScopes: 1,
+		},
+		{
+			name: "resource attributes",
+			modify: func(rm pmetric.ResourceMetrics) {
+				rm.Resource().Attributes().PutStr("host.name", "host-1")
+			},
+			expectedRMs:    2,
+			expectedScopes: 1,
+		},
+		{
+			name: "resource dropped attributes count",
+			modify: func(rm pmetric.ResourceMetrics) {
+				rm.Resource().SetDroppedAttributesCount(1)
+			},
+			expectedRMs:    2,
+			expectedScopes: 1,
+		},
+		{
+			name: "scope version",
+			modify: func(rm pmetric.ResourceMetrics) {
+				rm.ScopeMetrics().At(0).Scope().SetVersion("v1")
+			},
+			expectedRMs:    1,
+			expectedScopes: 2,
+		},
+		{
+			name: "scope attributes",
+			modify: func(rm pmetric.ResourceMetrics) {
+				rm.ScopeMetrics().At(0).Scope().Attributes().PutBool("enabled", true)
+			},
+			expectedRMs:    1,
+			expectedScopes: 2,
+		},
+		{
+			name: "scope schema url",
+			modify: func(rm pmetric.ResourceMetrics) {
+				rm.ScopeMetrics().At(0).SetSchemaUrl("https://opentelemetry.io/schemas/1.13.0")
+			},
+			expectedRMs:    1,
+			expectedScopes: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := pmetric.NewMetrics()
+			appendMetric(dst, "svc-a", "scope-1", "m1")
+			src := pmetric.NewMetrics()
+			appendMetric(src, "svc-a", "scope-1", "m2")
+			tt.modify(src.ResourceMetrics().At(0))
+
+			MergeMetrics(dst, src)
+
+			assert.Equal(t, 2, dst.MetricCount())
+			assert.Equal(t, tt.expectedRMs, dst.ResourceMetrics().Len())
+			assert.Equal(t, tt.expectedScopes, dst.ResourceMetrics().At(0).ScopeMetrics().Len())
+		})
+	}
+}
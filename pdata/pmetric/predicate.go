@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// MetricPredicate is a set of conditions on metrics, all of which must hold for a metric to match, for
+// example to implement include and exclude settings. The zero value matches all metrics.
+// It is safe for concurrent use, and evaluating it does not allocate.
+type MetricPredicate struct {
+	// ResourceAttributes are conditions on the attributes of the resource of the metric.
+	ResourceAttributes []pcommon.AttributePredicate
+
+	// Names are conditions on the name of the metric, any of which matches. Empty matches all names.
+	Names []pcommon.StringPredicate
+
+	// Types are the types of the metric, any of which matches. Empty matches all types.
+	Types []MetricType
+}
+
+// Eval returns whether the metric, of the resource res, matches the predicate.
+func (p *MetricPredicate) Eval(res pcommon.Resource, metric Metric) bool {
+	if len(p.Types) > 0 && !containsType(p.Types, metric.Type()) {
+		return false
+	}
+	if len(p.Names) > 0 && !evalAnyName(p.Names, metric.Name()) {
+		return false
+	}
+	return pcommon.EvalAttributePredicates(res.Attributes(), p.ResourceAttributes)
+}
+
+// RemoveMatching removes the metrics matched by p from md, then the scopes and resources left without
+// metrics. It returns the number of removed metrics.
+func (p *MetricPredicate) RemoveMatching(md Metrics) int {
+	return p.remove(md, true)
+}
+
+// KeepMatching removes the metrics not matched by p from md, then the scopes and resources left without
+// metrics. It returns the number of removed metrics.
+func (p *MetricPredicate) KeepMatching(md Metrics) int {
+	return p.remove(md, false)
+}
+
+func (p *MetricPredicate) remove(md Metrics, match bool) int {
+	removed := 0
+	md.ResourceMetrics().RemoveIf(func(rm ResourceMetrics) bool {
+		res := rm.Resource()
+		rm.ScopeMetrics().RemoveIf(func(sm ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(metric Metric) bool {
+				if p.Eval(res, metric) == match {
+					removed++
+					return true
+				}
+				return false
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return removed
+}
+
+func containsType(types []MetricType, typ MetricType) bool {
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+func evalAnyName(names []pcommon.StringPredicate, name string) bool {
+	for i := range names {
+		if names[i].Eval(name) {
+			return true
+		}
+	}
+	return false
+}
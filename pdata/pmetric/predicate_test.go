@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func newPredicateTestMetrics() Metrics {
+	md := NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "frontend")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	m := ms.AppendEmpty()
+	m.SetName("http.server.duration")
+	m.SetEmptyHistogram()
+	m = ms.AppendEmpty()
+	m.SetName("process.cpu.time")
+	m.SetEmptySum()
+
+	rm = md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "backend")
+	m = rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("http.client.duration")
+	m.SetEmptyHistogram()
+	return md
+}
+
+func TestMetricPredicateEval(t *testing.T) {
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("service.name", "frontend")
+	metric := NewMetric()
+	metric.SetName("http.server.duration")
+	metric.SetEmptyHistogram()
+
+	httpPrefix, err := pcommon.NewStringMatchesPredicate(`^http\.`)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		predicate MetricPredicate
+		want      bool
+	}{
+		{
+			name: "empty",
+			want: true,
+		},
+		{
+			name:      "name",
+			predicate: MetricPredicate{Names: []pcommon.StringPredicate{pcommon.NewStringEqualsPredicate("other"), httpPrefix}},
+			want:      true,
+		},
+		{
+			name:      "other name",
+			predicate: MetricPredicate{Names: []pcommon.StringPredicate{pcommon.NewStringEqualsPredicate("other")}},
+			want:      false,
+		},
+		{
+			name:      "type",
+			predicate: MetricPredicate{Types: []MetricType{MetricTypeHistogram}},
+			want:      true,
+		},
+		{
+			name:      "other type",
+			predicate: MetricPredicate{Types: []MetricType{MetricTypeSum, MetricTypeGauge}},
+			want:      false,
+		},
+		{
+			name:      "resource attribute",
+			predicate: MetricPredicate{ResourceAttributes: []pcommon.AttributePredicate{pcommon.NewAttributeEqualsPredicate("service.name", "backend")}},
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.predicate.Eval(res, metric))
+		})
+	}
+
+	p := MetricPredicate{
+		ResourceAttributes: []pcommon.AttributePredicate{pcommon.NewAttributeEqualsPredicate("service.name", "frontend")},
+		Names:              []pcommon.StringPredicate{httpPrefix},
+		Types:              []MetricType{MetricTypeHistogram},
+	}
+	assert.Equal(t, float64(0), testing.AllocsPerRun(100, func() {
+		p.Eval(res, metric)
+	}))
+}
+
+func TestMetricPredicateRemoveMatching(t *testing.T) {
+	p := MetricPredicate{Types: []MetricType{MetricTypeHistogram}}
+
+	md := newPredicateTestMetrics()
+	assert.Equal(t, 2, p.RemoveMatching(md))
+	assert.Equal(t, 1, md.MetricCount())
+	// The resource left without metrics is removed.
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+
+	md = newPredicateTestMetrics()
+	assert.Equal(t, 1, p.KeepMatching(md))
+	assert.Equal(t, 2, md.MetricCount())
+	assert.Equal(t, 2, md.ResourceMetrics().Len())
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// dataPoint is implemented by the data points of all the metric types.
+type dataPoint interface {
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+}
+
+// NormalizeTimestamps adjusts the timestamps of the data points and of their exemplars with the given normalizer
+// relative to now, usually the time the metrics were received. Start timestamps and exemplar timestamps are
+// never replaced when zero, since that means they are unknown, but they are clamped like the other timestamps.
+// The start timestamps of the cumulative points are left untouched, since moving them would look like a reset
+// of the series downstream. The start timestamps of the other points are then clamped to be no later than their
+// timestamp. It returns the number of adjusted timestamps.
+func (ms Metrics) NormalizeTimestamps(tn pcommon.TimestampNormalizer, now pcommon.Timestamp) pcommon.TimestampAdjustments {
+	var adj pcommon.TimestampAdjustments
+	optional := tn
+	optional.ReplaceZero = false
+	normalize := func(dp dataPoint, cumulative bool) {
+		ts := tn.Normalize(dp.Timestamp(), now, &adj)
+		dp.SetTimestamp(ts)
+		if cumulative {
+			return
+		}
+		start := optional.Normalize(dp.StartTimestamp(), now, &adj)
+		if ts != 0 && start > ts {
+			start = ts
+		}
+		dp.SetStartTimestamp(start)
+	}
+	normalizeExemplars := func(es ExemplarSlice) {
+		for i := 0; i < es.Len(); i++ {
+			e := es.At(i)
+			e.SetTimestamp(optional.Normalize(e.Timestamp(), now, &adj))
+		}
+	}
+
+	rms := ms.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				m := metrics.At(k)
+				switch m.Type() {
+				case MetricTypeGauge:
+					dps := m.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						normalize(dps.At(l), false)
+						normalizeExemplars(dps.At(l).Exemplars())
+					}
+				case MetricTypeSum:
+					dps := m.Sum().DataPoints()
+					cumulative := m.Sum().AggregationTemporality() == AggregationTemporalityCumulative
+					for l := 0; l < dps.Len(); l++ {
+						normalize(dps.At(l), cumulative)
+						normalizeExemplars(dps.At(l).Exemplars())
+					}
+				case MetricTypeHistogram:
+					dps := m.Histogram().DataPoints()
+					cumulative := m.Histogram().AggregationTemporality() == AggregationTemporalityCumulative
+					for l := 0; l < dps.Len(); l++ {
+						normalize(dps.At(l), cumulative)
+						normalizeExemplars(dps.At(l).Exemplars())
+					}
+				case MetricTypeExponentialHistogram:
+					dps := m.ExponentialHistogram().DataPoints()
+					cumulative := m.ExponentialHistogram().AggregationTemporality() == AggregationTemporalityCumulative
+					for l := 0; l < dps.Len(); l++ {
+						normalize(dps.At(l), cumulative)
+						normalizeExemplars(dps.At(l).Exemplars())
+					}
+				case MetricTypeSummary:
+					// The summaries are cumulative.
+					dps := m.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						normalize(dps.At(l), true)
+					}
+				}
+			}
+		}
+	}
+	return adj
+}
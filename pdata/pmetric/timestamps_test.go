@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestMetricsNormalizeTimestamps(t *testing.T) {
+	now := pcommon.NewTimestampFromTime(time.Unix(1000, 0))
+	future := now + pcommon.Timestamp(time.Hour)
+	md := NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	gauge := metrics.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	gauge.SetTimestamp(future)
+	gauge.Exemplars().AppendEmpty().SetTimestamp(future)
+	sum := metrics.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty()
+	sum.SetStartTimestamp(future)
+	sum.SetTimestamp(future)
+	histogram := metrics.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty()
+	histogram.Exemplars().AppendEmpty()
+	metrics.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty().SetTimestamp(now)
+	metrics.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty().SetTimestamp(future)
+
+	adj := md.NormalizeTimestamps(pcommon.TimestampNormalizer{MaxFuture: time.Minute, ReplaceZero: true}, now)
+	// Zero start timestamps and exemplar timestamps are left untouched.
+	assert.Equal(t, pcommon.TimestampAdjustments{Zero: 1, Future: 5}, adj)
+	limit := now + pcommon.Timestamp(time.Minute)
+	assert.Equal(t, limit, gauge.Timestamp())
+	assert.Equal(t, pcommon.Timestamp(0), gauge.StartTimestamp())
+	assert.Equal(t, limit, gauge.Exemplars().At(0).Timestamp())
+	assert.Equal(t, limit, sum.StartTimestamp())
+	assert.Equal(t, limit, sum.Timestamp())
+	assert.Equal(t, now, histogram.Timestamp())
+	assert.Equal(t, pcommon.Timestamp(0), histogram.Exemplars().At(0).Timestamp())
+}
+
+func TestMetricsNormalizeTimestampsStart(t *testing.T) {
+	now := pcommon.NewTimestampFromTime(time.Unix(100000, 0))
+	old := now - pcommon.Timestamp(2*time.Hour)
+	md := NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	cumulative := metrics.AppendEmpty().SetEmptySum()
+	cumulative.SetAggregationTemporality(AggregationTemporalityCumulative)
+	cumulativeDp := cumulative.DataPoints().AppendEmpty()
+	cumulativeDp.SetStartTimestamp(old)
+	cumulativeDp.SetTimestamp(now)
+	summaryDp := metrics.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty()
+	summaryDp.SetStartTimestamp(old)
+	summaryDp.SetTimestamp(now)
+	delta := metrics.AppendEmpty().SetEmptyHistogram()
+	delta.SetAggregationTemporality(AggregationTemporalityDelta)
+	deltaDp := delta.DataPoints().AppendEmpty()
+	deltaDp.SetStartTimestamp(now)
+	gaugeDp := metrics.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	gaugeDp.SetStartTimestamp(now)
+	gaugeDp.SetTimestamp(old)
+
+	adj := md.NormalizeTimestamps(pcommon.TimestampNormalizer{MaxAge: time.Hour, ReplaceZero: true}, now)
+	assert.Equal(t, pcommon.TimestampAdjustments{Zero: 1, Past: 1}, adj)
+	// The start timestamps of the cumulative points are kept, so that downstream does not see a reset.
+	assert.Equal(t, old, cumulativeDp.StartTimestamp())
+	assert.Equal(t, old, summaryDp.StartTimestamp())
+	// The start timestamps of the other points are not later than their timestamp.
+	assert.Equal(t, now, deltaDp.Timestamp())
+	assert.Equal(t, now, deltaDp.StartTimestamp())
+	limit := now - pcommon.Timestamp(time.Hour)
+	assert.Equal(t, limit, gaugeDp.Timestamp())
+	assert.Equal(t, limit, gaugeDp.StartTimestamp())
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pschema provides utilities to work with the schema URLs of the telemetry,
+// and to translate the telemetry between the versions of a schema.
+package pschema // import "go.opentelemetry.io/collector/pdata/pschema"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// ParseSchemaURL splits a schema URL, e.g. "https://opentelemetry.io/schemas/1.13.0", into the schema family,
+// "https://opentelemetry.io/schemas", and the version of the schema, "1.13.0".
+func ParseSchemaURL(schemaURL string) (family string, version string, err error) {
+	u, err := url.Parse(schemaURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid schema URL %q: %w", schemaURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", "", fmt.Errorf("invalid schema URL %q: scheme must be http or https", schemaURL)
+	}
+	i := strings.LastIndexByte(schemaURL, '/')
+	family, version = schemaURL[:i], schemaURL[i+1:]
+	if version == "" || len(family) <= len(u.Scheme+"://") {
+		return "", "", fmt.Errorf("invalid schema URL %q: %w", schemaURL, errNoVersion)
+	}
+	return family, version, nil
+}
+
+var errNoVersion = errors.New("the last path segment must be the schema version")
+
+// TracesSchemaURLs returns the distinct schema URLs set at the resource and scope levels of the traces,
+// in the order they are found. Empty schema URLs are ignored.
+func TracesSchemaURLs(td ptrace.Traces) []string {
+	var urls schemaURLSet
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		urls.add(rs.SchemaUrl())
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			urls.add(rs.ScopeSpans().At(j).SchemaUrl())
+		}
+	}
+	return urls
+}
+
+// SetTracesSchemaURL sets the schema URL of all the resources and scopes of the traces.
+func SetTracesSchemaURL(td ptrace.Traces, schemaURL string) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		rs.SetSchemaUrl(schemaURL)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			rs.ScopeSpans().At(j).SetSchemaUrl(schemaURL)
+		}
+	}
+}
+
+// MetricsSchemaURLs returns the distinct schema URLs set at the resource and scope levels of the metrics,
+// in the order they are found. Empty schema URLs are ignored.
+func MetricsSchemaURLs(md pmetric.Metrics) []string {
+	var urls schemaURLSet
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		urls.add(rm.SchemaUrl())
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			urls.add(rm.ScopeMetrics().At(j).SchemaUrl())
+		}
+	}
+	return urls
+}
+
+// SetMetricsSchemaURL sets the schema URL of all the resources and scopes of the metrics.
+func SetMetricsSchemaURL(md pmetric.Metrics, schemaURL string) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		rm.SetSchemaUrl(schemaURL)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			rm.ScopeMetrics().At(j).SetSchemaUrl(schemaURL)
+		}
+	}
+}
+
+// LogsSchemaURLs returns the distinct schema URLs set at the resource and scope levels of the logs,
+// in the order they are found. Empty schema URLs are ignored.
+func LogsSchemaURLs(ld plog.Logs) []string {
+	var urls schemaURLSet
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		urls.add(rl.SchemaUrl())
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			urls.add(rl.ScopeLogs().At(j).SchemaUrl())
+		}
+	}
+	return urls
+}
+
+// SetLogsSchemaURL sets the schema URL of all the resources and scopes of the logs.
+func SetLogsSchemaURL(ld plog.Logs, schemaURL string) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		rl.SetSchemaUrl(schemaURL)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			rl.ScopeLogs().At(j).SetSchemaUrl(schemaURL)
+		}
+	}
+}
+
+// schemaURLSet is a set of schema URLs keeping the insertion order. The number of distinct schema URLs
+// in a batch of telemetry is expected to be small, so a slice is used.
+type schemaURLSet []string
+
+func (s *schemaURLSet) add(schemaURL string) {
+	if schemaURL == "" {
+		return
+	}
+	for _, u := range *s {
+		if u == schemaURL {
+			return
+		}
+	}
+	*s = append(*s, schemaURL)
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestParseSchemaURL(t *testing.T) {
+	tests := []struct {
+		schemaURL string
+		family    string
+		version   string
+		wantErr   bool
+	}{
+		{schemaURL: "https://opentelemetry.io/schemas/1.13.0", family: "https://opentelemetry.io/schemas", version: "1.13.0"},
+		{schemaURL: "http://example.com/1.0.0", family: "http://example.com", version: "1.0.0"},
+		{schemaURL: "", wantErr: true},
+		{schemaURL: "opentelemetry.io/schemas/1.13.0", wantErr: true},
+		{schemaURL: "https://opentelemetry.io/schemas/", wantErr: true},
+		{schemaURL: "https://1.13.0", wantErr: true},
+		{schemaURL: "https://example.com/%zz/1.0.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schemaURL, func(t *testing.T) {
+			family, version, err := ParseSchemaURL(tt.schemaURL)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.family, family)
+			assert.Equal(t, tt.version, version)
+		})
+	}
+}
+
+func TestTracesSchemaURL(t *testing.T) {
+	td := ptrace.NewTraces()
+	assert.Empty(t, TracesSchemaURLs(td))
+
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.9.0")
+	rs.ScopeSpans().AppendEmpty().SetSchemaUrl("https://opentelemetry.io/schemas/1.12.0")
+	rs.ScopeSpans().AppendEmpty()
+	td.ResourceSpans().AppendEmpty().SetSchemaUrl("https://opentelemetry.io/schemas/1.9.0")
+	assert.Equal(t, []string{"https://opentelemetry.io/schemas/1.9.0", "https://opentelemetry.io/schemas/1.12.0"}, TracesSchemaURLs(td))
+
+	SetTracesSchemaURL(td, "https://opentelemetry.io/schemas/1.13.0")
+	assert.Equal(t, []string{"https://opentelemetry.io/schemas/1.13.0"}, TracesSchemaURLs(td))
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.13.0", rs.ScopeSpans().At(1).SchemaUrl())
+}
+
+func TestMetricsSchemaURL(t *testing.T) {
+	md := pmetric.NewMetrics()
+	assert.Empty(t, MetricsSchemaURLs(md))
+
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.SetSchemaUrl("https://opentelemetry.io/schemas/1.9.0")
+	rm.ScopeMetrics().AppendEmpty().SetSchemaUrl("https://opentelemetry.io/schemas/1.12.0")
+	rm.ScopeMetrics().AppendEmpty()
+	assert.Equal(t, []string{"https://opentelemetry.io/schemas/1.9.0", "https://opentelemetry.io/schemas/1.12.0"}, MetricsSchemaURLs(md))
+
+	SetMetricsSchemaURL(md, "https://opentelemetry.io/schemas/1.13.0")
+	assert.Equal(t, []string{"https://opentelemetry.io/schemas/1.13.0"}, MetricsSchemaURLs(md))
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.13.0", rm.ScopeMetrics().At(1).SchemaUrl())
+}
+
+func TestLogsSchemaURL(t *testing.T) {
+	ld := plog.NewLogs()
+	assert.Empty(t, LogsSchemaURLs(ld))
+
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.9.0")
+	rl.ScopeLogs().AppendEmpty().SetSchemaUrl("https://opentelemetry.io/schemas/1.12.0")
+	rl.ScopeLogs().AppendEmpty()
+	assert.Equal(t, []string{"https://opentelemetry.io/schemas/1.9.0", "https://opentelemetry.io/schemas/1.12.0"}, LogsSchemaURLs(ld))
+
+	SetLogsSchemaURL(ld, "https://opentelemetry.io/schemas/1.13.0")
+	assert.Equal(t, []string{"https://opentelemetry.io/schemas/1.13.0"}, LogsSchemaURLs(ld))
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.13.0", rl.ScopeLogs().At(1).SchemaUrl())
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pschema // import "go.opentelemetry.io/collector/pdata/pschema"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Translator translates telemetry from one schema URL to another, e.g. renames the attributes
+// changed between two versions of the semantic conventions. Each function translates the data
+// of one level in place; the schema URLs are updated by the caller.
+type Translator interface {
+	// TranslateResource translates the attributes of a resource.
+	TranslateResource(res pcommon.Resource) error
+
+	// TranslateScopeSpans translates the scope and the spans of a ScopeSpans.
+	TranslateScopeSpans(ss ptrace.ScopeSpans) error
+
+	// TranslateScopeMetrics translates the scope and the metrics of a ScopeMetrics.
+	TranslateScopeMetrics(sm pmetric.ScopeMetrics) error
+
+	// TranslateScopeLogs translates the scope and the log records of a ScopeLogs.
+	TranslateScopeLogs(sl plog.ScopeLogs) error
+}
+
+// TranslatorProvider is the hook used to obtain the Translator between two schema URLs,
+// e.g. by fetching and parsing the schema files. Implementations must be safe for concurrent use.
+type TranslatorProvider interface {
+	// Translator returns the Translator from the schema URL `from` to the schema URL `to`,
+	// or an error if the translation is not supported.
+	Translator(ctx context.Context, from, to string) (Translator, error)
+}
+
+// TranslateTraces translates in place the resources and scopes of the traces to the target schema URL,
+// and sets their schema URL. Data without schema URL, or already at the target schema URL, is left unchanged.
+func TranslateTraces(ctx context.Context, provider TranslatorProvider, td ptrace.Traces, target string) error {
+	tc := translatorCache{provider: provider, target: target}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if err := tc.translate(ctx, rs.SchemaUrl(), func(t Translator) error { return t.TranslateResource(rs.Resource()) }); err != nil {
+			return err
+		}
+		rs.SetSchemaUrl(tc.schemaURL(rs.SchemaUrl()))
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			if err := tc.translate(ctx, ss.SchemaUrl(), func(t Translator) error { return t.TranslateScopeSpans(ss) }); err != nil {
+				return err
+			}
+			ss.SetSchemaUrl(tc.schemaURL(ss.SchemaUrl()))
+		}
+	}
+	return nil
+}
+
+// TranslateMetrics translates in place the resources and scopes of the metrics to the target schema URL,
+// and sets their schema URL. Data without schema URL, or already at the target schema URL, is left unchanged.
+func TranslateMetrics(ctx context.Context, provider TranslatorProvider, md pmetric.Metrics, target string) error {
+	tc := translatorCache{provider: provider, target: target}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if err := tc.translate(ctx, rm.SchemaUrl(), func(t Translator) error { return t.TranslateResource(rm.Resource()) }); err != nil {
+			return err
+		}
+		rm.SetSchemaUrl(tc.schemaURL(rm.SchemaUrl()))
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			if err := tc.translate(ctx, sm.SchemaUrl(), func(t Translator) error { return t.TranslateScopeMetrics(sm) }); err != nil {
+				return err
+			}
+			sm.SetSchemaUrl(tc.schemaURL(sm.SchemaUrl()))
+		}
+	}
+	return nil
+}
+
+// TranslateLogs translates in place the resources and scopes of the logs to the target schema URL,
+// and sets their schema URL. Data without schema URL, or already at the target schema URL, is left unchanged.
+func TranslateLogs(ctx context.Context, provider TranslatorProvider, ld plog.Logs, target string) error {
+	tc := translatorCache{provider: provider, target: target}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if err := tc.translate(ctx, rl.SchemaUrl(), func(t Translator) error { return t.TranslateResource(rl.Resource()) }); err != nil {
+			return err
+		}
+		rl.SetSchemaUrl(tc.schemaURL(rl.SchemaUrl()))
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			if err := tc.translate(ctx, sl.SchemaUrl(), func(t Translator) error { return t.TranslateScopeLogs(sl) }); err != nil {
+				return err
+			}
+			sl.SetSchemaUrl(tc.schemaURL(sl.SchemaUrl()))
+		}
+	}
+	return nil
+}
+
+// translatorCache caches the translators obtained during the translation of one batch of telemetry.
+type translatorCache struct {
+	provider    TranslatorProvider
+	target      string
+	translators map[string]Translator
+}
+
+// translate calls fn with the translator from the given schema URL, unless no translation is needed.
+func (tc *translatorCache) translate(ctx context.Context, from string, fn func(Translator) error) error {
+	if from == "" || from == tc.target {
+		return nil
+	}
+	t, ok := tc.translators[from]
+	if !ok {
+		var err error
+		if t, err = tc.provider.Translator(ctx, from, tc.target); err != nil {
+			return fmt.Errorf("failed to get the translator from %q to %q: %w", from, tc.target, err)
+		}
+		if tc.translators == nil {
+			tc.translators = make(map[string]Translator)
+		}
+		tc.translators[from] = t
+	}
+	if err := fn(t); err != nil {
+		return fmt.Errorf("failed to translate from %q to %q: %w", from, tc.target, err)
+	}
+	return nil
+}
+
+// schemaURL returns the schema URL of the data after the translation.
+func (tc *translatorCache) schemaURL(from string) string {
+	if from == "" {
+		return ""
+	}
+	return tc.target
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pschema
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	schemaV1 = "https://example.com/schemas/1.0.0"
+	schemaV2 = "https://example.com/schemas/2.0.0"
+)
+
+// renameTranslator renames the attribute "old" to "new" at every level.
+type renameTranslator struct{}
+
+func rename(attrs pcommon.Map) {
+	if v, ok := attrs.Get("old"); ok {
+		v.CopyTo(attrs.PutEmpty("new"))
+		attrs.Remove("old")
+	}
+}
+
+func (renameTranslator) TranslateResource(res pcommon.Resource) error {
+	rename(res.Attributes())
+	return nil
+}
+
+func (renameTranslator) TranslateScopeSpans(ss ptrace.ScopeSpans) error {
+	for i := 0; i < ss.Spans().Len(); i++ {
+		rename(ss.Spans().At(i).Attributes())
+	}
+	return nil
+}
+
+func (renameTranslator) TranslateScopeMetrics(sm pmetric.ScopeMetrics) error {
+	rename(sm.Scope().Attributes())
+	return nil
+}
+
+func (renameTranslator) TranslateScopeLogs(sl plog.ScopeLogs) error {
+	for i := 0; i < sl.LogRecords().Len(); i++ {
+		rename(sl.LogRecords().At(i).Attributes())
+	}
+	return nil
+}
+
+type testProvider struct {
+	calls int
+	err   error
+}
+
+func (p *testProvider) Translator(_ context.Context, from, to string) (Translator, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	if from != schemaV1 || to != schemaV2 {
+		return nil, errors.New("unsupported translation")
+	}
+	return renameTranslator{}, nil
+}
+
+func TestTranslateTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	for i := 0; i < 2; i++ {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl(schemaV1)
+		rs.Resource().Attributes().PutStr("old", "resource")
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.SetSchemaUrl(schemaV1)
+		ss.Spans().AppendEmpty().Attributes().PutStr("old", "span")
+	}
+	noSchema := td.ResourceSpans().AppendEmpty()
+	noSchema.Resource().Attributes().PutStr("old", "unknown")
+
+	provider := &testProvider{}
+	require.NoError(t, TranslateTraces(context.Background(), provider, td, schemaV2))
+	assert.Equal(t, 1, provider.calls)
+
+	for i := 0; i < 2; i++ {
+		rs := td.ResourceSpans().At(i)
+		assert.Equal(t, schemaV2, rs.SchemaUrl())
+		assert.Equal(t, map[string]any{"new": "resource"}, rs.Resource().Attributes().AsRaw())
+		assert.Equal(t, schemaV2, rs.ScopeSpans().At(0).SchemaUrl())
+		assert.Equal(t, map[string]any{"new": "span"}, rs.ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+	}
+	assert.Equal(t, "", noSchema.SchemaUrl())
+	assert.Equal(t, map[string]any{"old": "unknown"}, noSchema.Resource().Attributes().AsRaw())
+
+	// Translating data already at the target schema URL is a no-op.
+	require.NoError(t, TranslateTraces(context.Background(), provider, td, schemaV2))
+	assert.Equal(t, 1, provider.calls)
+}
+
+func TestTranslateMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.SetSchemaUrl(schemaV1)
+	rm.Resource().Attributes().PutStr("old", "resource")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.SetSchemaUrl(schemaV1)
+	sm.Scope().Attributes().PutStr("old", "scope")
+
+	require.NoError(t, TranslateMetrics(context.Background(), &testProvider{}, md, schemaV2))
+	assert.Equal(t, []string{schemaV2}, MetricsSchemaURLs(md))
+	assert.Equal(t, map[string]any{"new": "resource"}, rm.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"new": "scope"}, sm.Scope().Attributes().AsRaw())
+}
+
+func TestTranslateLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl(schemaV1)
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.SetSchemaUrl(schemaV1)
+	sl.LogRecords().AppendEmpty().Attributes().PutStr("old", "log")
+
+	require.NoError(t, TranslateLogs(context.Background(), &testProvider{}, ld, schemaV2))
+	assert.Equal(t, []string{schemaV2}, LogsSchemaURLs(ld))
+	assert.Equal(t, map[string]any{"new": "log"}, sl.LogRecords().At(0).Attributes().AsRaw())
+}
+
+func TestTranslateUnsupported(t *testing.T) {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().SetSchemaUrl(schemaV2)
+
+	err := TranslateTraces(context.Background(), &testProvider{}, td, schemaV1)
+	assert.EqualError(t, err, `failed to get the translator from "https://example.com/schemas/2.0.0" to "https://example.com/schemas/1.0.0": unsupported translation`)
+	assert.Equal(t, schemaV2, td.ResourceSpans().At(0).SchemaUrl())
+
+	providerErr := errors.New("schema file not found")
+	err = TranslateTraces(context.Background(), &testProvider{err: providerErr}, td, schemaV1)
+	assert.ErrorIs(t, err, providerErr)
+}
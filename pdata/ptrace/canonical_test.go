@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCanonicalTestTraces(reversed bool) Traces {
+	td := NewTraces()
+	services := []string{"svc-a", "svc-b"}
+	spans := []string{"span-1", "span-2"}
+	if reversed {
+		services = []string{"svc-b", "svc-a"}
+		spans = []string{"span-2", "span-1"}
+	}
+	for _, service := range services {
+		rs := td.ResourceSpans().AppendEmpty()
+		if reversed {
+			rs.Resource().Attributes().PutStr("service.name", service)
+			rs.Resource().Attributes().PutStr("host.name", "host")
+		} else {
+			rs.Resource().Attributes().PutStr("host.name", "host")
+			rs.Resource().Attributes().PutStr("service.name", service)
+		}
+		ss := rs.ScopeSpans().AppendEmpty()
+		for _, name := range spans {
+			span := ss.Spans().AppendEmpty()
+			span.SetName(name)
+			nested := span.Attributes().PutEmptyMap("nested")
+			if reversed {
+				nested.PutInt("b", 2)
+				nested.PutInt("a", 1)
+			} else {
+				nested.PutInt("a", 1)
+				nested.PutInt("b", 2)
+			}
+			arr := span.Attributes().PutEmptySlice("array")
+			arr.AppendEmpty().SetStr("z")
+			arr.AppendEmpty().SetStr("y")
+			span.Events().AppendEmpty().SetName("event-" + name)
+		}
+	}
+	return td
+}
+
+func TestCanonicalMarshaler(t *testing.T) {
+	for _, delegate := range []Marshaler{&ProtoMarshaler{}, &JSONMarshaler{}} {
+		marshaler := NewCanonicalMarshaler(delegate)
+
+		td := newCanonicalTestTraces(false)
+		reversed := newCanonicalTestTraces(true)
+
+		plain1, err := delegate.MarshalTraces(td)
+		require.NoError(t, err)
+		plain2, err := delegate.MarshalTraces(reversed)
+		require.NoError(t, err)
+		assert.NotEqual(t, plain1, plain2)
+
+		canonical1, err := marshaler.MarshalTraces(td)
+		require.NoError(t, err)
+		canonical2, err := marshaler.MarshalTraces(reversed)
+		require.NoError(t, err)
+		assert.Equal(t, canonical1, canonical2)
+
+		// The input is not modified.
+		assert.Equal(t, newCanonicalTestTraces(true), reversed)
+	}
+}
+
+func TestCanonicalMarshalerKeepsArrayOrder(t *testing.T) {
+	marshaler := NewCanonicalMarshaler(&ProtoMarshaler{})
+	td := newCanonicalTestTraces(false)
+
+	buf, err := marshaler.MarshalTraces(td)
+	require.NoError(t, err)
+	got, err := (&ProtoUnmarshaler{}).UnmarshalTraces(buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, got.SpanCount())
+	arr, ok := got.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get("array")
+	require.True(t, ok)
+	assert.Equal(t, []any{"z", "y"}, arr.Slice().AsRaw())
+	assert.Equal(t, map[string]any{"host.name": "host", "service.name": "svc-a"},
+		got.ResourceSpans().At(0).Resource().Attributes().AsRaw())
+}
@@ -69,24 +69,37 @@ func (es ResourceSpansSlice) At(ix int) ResourceSpans {
 	return newResourceSpans((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es ResourceSpansSlice) ForEach(f func(int, ResourceSpans)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newResourceSpans(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ResourceSpansSlice) CopyTo(dest ResourceSpansSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newResourceSpans((*es.getOrig())[i]).CopyTo(newResourceSpans((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlptrace.ResourceSpans, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlptrace.ResourceSpans, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlptrace.ResourceSpans, srcLen)
-	wrappers := make([]*otlptrace.ResourceSpans, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newResourceSpans((*es.getOrig())[i]).CopyTo(newResourceSpans(wrappers[i]))
+		newResourceSpans((*es.getOrig())[i]).CopyTo(newResourceSpans((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -269,24 +282,37 @@ func (es ScopeSpansSlice) At(ix int) ScopeSpans {
 	return newScopeSpans((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es ScopeSpansSlice) ForEach(f func(int, ScopeSpans)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newScopeSpans(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ScopeSpansSlice) CopyTo(dest ScopeSpansSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newScopeSpans((*es.getOrig())[i]).CopyTo(newScopeSpans((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlptrace.ScopeSpans, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlptrace.ScopeSpans, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlptrace.ScopeSpans, srcLen)
-	wrappers := make([]*otlptrace.ScopeSpans, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newScopeSpans((*es.getOrig())[i]).CopyTo(newScopeSpans(wrappers[i]))
+		newScopeSpans((*es.getOrig())[i]).CopyTo(newScopeSpans((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -469,24 +495,37 @@ func (es SpanSlice) At(ix int) Span {
 	return newSpan((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es SpanSlice) ForEach(f func(int, Span)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newSpan(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es SpanSlice) CopyTo(dest SpanSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newSpan((*es.getOrig())[i]).CopyTo(newSpan((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlptrace.Span, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlptrace.Span, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlptrace.Span, srcLen)
-	wrappers := make([]*otlptrace.Span, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newSpan((*es.getOrig())[i]).CopyTo(newSpan(wrappers[i]))
+		newSpan((*es.getOrig())[i]).CopyTo(newSpan((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -787,24 +826,37 @@ func (es SpanEventSlice) At(ix int) SpanEvent {
 	return newSpanEvent((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es SpanEventSlice) ForEach(f func(int, SpanEvent)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newSpanEvent(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es SpanEventSlice) CopyTo(dest SpanEventSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newSpanEvent((*es.getOrig())[i]).CopyTo(newSpanEvent((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlptrace.Span_Event, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlptrace.Span_Event, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlptrace.Span_Event, srcLen)
-	wrappers := make([]*otlptrace.Span_Event, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newSpanEvent((*es.getOrig())[i]).CopyTo(newSpanEvent(wrappers[i]))
+		newSpanEvent((*es.getOrig())[i]).CopyTo(newSpanEvent((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -1004,24 +1056,37 @@ func (es SpanLinkSlice) At(ix int) SpanLink {
 	return newSpanLink((*es.getOrig())[ix])
 }
 
+// ForEach calls f sequentially for each element present in the slice, together with its index.
+// It is equivalent to calling f(i, es.At(i)) for each index, but avoids the overhead of At.
+// f must not add or remove elements from the slice.
+func (es SpanLinkSlice) ForEach(f func(int, SpanLink)) {
+	orig := *es.getOrig()
+	for i := range orig {
+		f(i, newSpanLink(orig[i]))
+	}
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es SpanLinkSlice) CopyTo(dest SpanLinkSlice) {
 	srcLen := es.Len()
-	destCap := cap(*dest.getOrig())
-	if srcLen <= destCap {
-		(*dest.getOrig()) = (*dest.getOrig())[:srcLen:destCap]
-		for i := range *es.getOrig() {
-			newSpanLink((*es.getOrig())[i]).CopyTo(newSpanLink((*dest.getOrig())[i]))
+	destLen := dest.Len()
+	if srcLen <= cap(*dest.getOrig()) {
+		(*dest.getOrig()) = (*dest.getOrig())[:srcLen]
+	} else {
+		wrappers := make([]*otlptrace.Span_Link, srcLen)
+		copy(wrappers, *dest.getOrig())
+		*dest.getOrig() = wrappers
+	}
+	// Pointers past the previous length are either nil or shared with removed elements.
+	if destLen < srcLen {
+		origs := make([]otlptrace.Span_Link, srcLen-destLen)
+		for i := destLen; i < srcLen; i++ {
+			(*dest.getOrig())[i] = &origs[i-destLen]
 		}
-		return
 	}
-	origs := make([]otlptrace.Span_Link, srcLen)
-	wrappers := make([]*otlptrace.Span_Link, srcLen)
 	for i := range *es.getOrig() {
-		wrappers[i] = &origs[i]
-		newSpanLink((*es.getOrig())[i]).CopyTo(newSpanLink(wrappers[i]))
+		newSpanLink((*es.getOrig())[i]).CopyTo(newSpanLink((*dest.getOrig())[i]))
 	}
-	*dest.getOrig() = wrappers
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
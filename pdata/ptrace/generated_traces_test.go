@@ -138,6 +138,23 @@ func TestResourceSpansSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestResourceSpansSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewResourceSpansSlice().ForEach(func(int, ResourceSpans) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := ResourceSpansSlice(internal.GenerateTestResourceSpansSlice())
+	count := 0
+	es.ForEach(func(i int, el ResourceSpans) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestResourceSpans_MoveTo(t *testing.T) {
 	ms := ResourceSpans(internal.GenerateTestResourceSpans())
 	dest := NewResourceSpans()
@@ -286,6 +303,23 @@ func TestScopeSpansSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestScopeSpansSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewScopeSpansSlice().ForEach(func(int, ScopeSpans) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := ScopeSpansSlice(internal.GenerateTestScopeSpansSlice())
+	count := 0
+	es.ForEach(func(i int, el ScopeSpans) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestScopeSpans_MoveTo(t *testing.T) {
 	ms := ScopeSpans(internal.GenerateTestScopeSpans())
 	dest := NewScopeSpans()
@@ -434,6 +468,23 @@ func TestSpanSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestSpanSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewSpanSlice().ForEach(func(int, Span) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := SpanSlice(internal.GenerateTestSpanSlice())
+	count := 0
+	es.ForEach(func(i int, el Span) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestSpan_MoveTo(t *testing.T) {
 	ms := Span(internal.GenerateTestSpan())
 	dest := NewSpan()
@@ -671,6 +722,23 @@ func TestSpanEventSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestSpanEventSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewSpanEventSlice().ForEach(func(int, SpanEvent) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := SpanEventSlice(internal.GenerateTestSpanEventSlice())
+	count := 0
+	es.ForEach(func(i int, el SpanEvent) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestSpanEvent_MoveTo(t *testing.T) {
 	ms := SpanEvent(internal.GenerateTestSpanEvent())
 	dest := NewSpanEvent()
@@ -828,6 +896,23 @@ func TestSpanLinkSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestSpanLinkSlice_ForEach(t *testing.T) {
+	// Test ForEach on empty slice
+	NewSpanLinkSlice().ForEach(func(int, SpanLink) {
+		t.Fail()
+	})
+
+	// Test ForEach
+	es := SpanLinkSlice(internal.GenerateTestSpanLinkSlice())
+	count := 0
+	es.ForEach(func(i int, el SpanLink) {
+		assert.Equal(t, count, i)
+		assert.Equal(t, es.At(i), el)
+		count++
+	})
+	assert.Equal(t, es.Len(), count)
+}
+
 func TestSpanLink_MoveTo(t *testing.T) {
 	ms := SpanLink(internal.GenerateTestSpanLink())
 	dest := NewSpanLink()
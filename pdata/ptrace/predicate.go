@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// SpanPredicate is a set of conditions on spans, all of which must hold for a span to match, for example
+// to implement include and exclude settings. The zero value matches all spans.
+// It is safe for concurrent use, and evaluating it does not allocate.
+type SpanPredicate struct {
+	// ResourceAttributes are conditions on the attributes of the resource of the span.
+	ResourceAttributes []pcommon.AttributePredicate
+
+	// Attributes are conditions on the attributes of the span.
+	Attributes []pcommon.AttributePredicate
+
+	// Kinds are the kinds of the span, any of which matches. Empty matches all kinds.
+	Kinds []SpanKind
+
+	// StatusCodes are the codes of the status of the span, any of which matches. Empty matches all codes.
+	StatusCodes []StatusCode
+}
+
+// Eval returns whether the span, of the resource res, matches the predicate.
+func (p *SpanPredicate) Eval(res pcommon.Resource, span Span) bool {
+	if len(p.Kinds) > 0 && !containsKind(p.Kinds, span.Kind()) {
+		return false
+	}
+	if len(p.StatusCodes) > 0 && !containsStatusCode(p.StatusCodes, span.Status().Code()) {
+		return false
+	}
+	return pcommon.EvalAttributePredicates(span.Attributes(), p.Attributes) &&
+		pcommon.EvalAttributePredicates(res.Attributes(), p.ResourceAttributes)
+}
+
+// RemoveMatching removes the spans matched by p from td, then the scopes and resources left without
+// spans. It returns the number of removed spans.
+func (p *SpanPredicate) RemoveMatching(td Traces) int {
+	return p.remove(td, true)
+}
+
+// KeepMatching removes the spans not matched by p from td, then the scopes and resources left without
+// spans. It returns the number of removed spans.
+func (p *SpanPredicate) KeepMatching(td Traces) int {
+	return p.remove(td, false)
+}
+
+func (p *SpanPredicate) remove(td Traces, match bool) int {
+	removed := 0
+	td.ResourceSpans().RemoveIf(func(rs ResourceSpans) bool {
+		res := rs.Resource()
+		rs.ScopeSpans().RemoveIf(func(ss ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span Span) bool {
+				if p.Eval(res, span) == match {
+					removed++
+					return true
+				}
+				return false
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	return removed
+}
+
+func containsKind(kinds []SpanKind, kind SpanKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func containsStatusCode(codes []StatusCode, code StatusCode) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func newPredicateTestTraces() Traces {
+	td := NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "frontend")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetKind(SpanKindServer)
+	span := spans.AppendEmpty()
+	span.SetKind(SpanKindClient)
+	span.Status().SetCode(StatusCodeError)
+
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "backend")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetKind(SpanKindInternal)
+	return td
+}
+
+func TestSpanPredicateEval(t *testing.T) {
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("service.name", "frontend")
+	span := NewSpan()
+	span.SetKind(SpanKindServer)
+	span.Status().SetCode(StatusCodeError)
+	span.Attributes().PutStr("http.route", "/api/users")
+
+	route, err := pcommon.NewAttributeMatchesPredicate("http.route", "^/api/")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		predicate SpanPredicate
+		want      bool
+	}{
+		{
+			name: "empty",
+			want: true,
+		},
+		{
+			name:      "kind",
+			predicate: SpanPredicate{Kinds: []SpanKind{SpanKindClient, SpanKindServer}},
+			want:      true,
+		},
+		{
+			name:      "other kind",
+			predicate: SpanPredicate{Kinds: []SpanKind{SpanKindClient}},
+			want:      false,
+		},
+		{
+			name:      "status code",
+			predicate: SpanPredicate{StatusCodes: []StatusCode{StatusCodeError}},
+			want:      true,
+		},
+		{
+			name:      "other status code",
+			predicate: SpanPredicate{StatusCodes: []StatusCode{StatusCodeOk, StatusCodeUnset}},
+			want:      false,
+		},
+		{
+			name:      "attribute",
+			predicate: SpanPredicate{Attributes: []pcommon.AttributePredicate{route}},
+			want:      true,
+		},
+		{
+			name:      "resource attribute",
+			predicate: SpanPredicate{ResourceAttributes: []pcommon.AttributePredicate{pcommon.NewAttributeEqualsPredicate("service.name", "backend")}},
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.predicate.Eval(res, span))
+		})
+	}
+
+	p := SpanPredicate{
+		ResourceAttributes: []pcommon.AttributePredicate{pcommon.NewAttributeEqualsPredicate("service.name", "frontend")},
+		Attributes:         []pcommon.AttributePredicate{route},
+		Kinds:              []SpanKind{SpanKindServer},
+		StatusCodes:        []StatusCode{StatusCodeError},
+	}
+	assert.Equal(t, float64(0), testing.AllocsPerRun(100, func() {
+		p.Eval(res, span)
+	}))
+}
+
+func TestSpanPredicateRemoveMatching(t *testing.T) {
+	p := SpanPredicate{Kinds: []SpanKind{SpanKindServer, SpanKindInternal}}
+
+	td := newPredicateTestTraces()
+	assert.Equal(t, 2, p.RemoveMatching(td))
+	assert.Equal(t, 1, td.SpanCount())
+	// The resource left without spans is removed.
+	assert.Equal(t, 1, td.ResourceSpans().Len())
+
+	td = newPredicateTestTraces()
+	assert.Equal(t, 1, p.KeepMatching(td))
+	assert.Equal(t, 2, td.SpanCount())
+	assert.Equal(t, 2, td.ResourceSpans().Len())
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// NormalizeTimestamps adjusts the start and end timestamps of the spans, and the timestamps of their events,
+// with the given normalizer relative to now, usually the time the traces were received. The start timestamps
+// are then clamped to be no later than the end timestamps. It returns the number of adjusted timestamps.
+func (ms Traces) NormalizeTimestamps(tn pcommon.TimestampNormalizer, now pcommon.Timestamp) pcommon.TimestampAdjustments {
+	var adj pcommon.TimestampAdjustments
+	rss := ms.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				start := tn.Normalize(span.StartTimestamp(), now, &adj)
+				end := tn.Normalize(span.EndTimestamp(), now, &adj)
+				if end != 0 && start > end {
+					start = end
+				}
+				span.SetStartTimestamp(start)
+				span.SetEndTimestamp(end)
+				events := span.Events()
+				for l := 0; l < events.Len(); l++ {
+					event := events.At(l)
+					event.SetTimestamp(tn.Normalize(event.Timestamp(), now, &adj))
+				}
+			}
+		}
+	}
+	return adj
+}
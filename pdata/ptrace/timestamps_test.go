@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptrace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestTracesNormalizeTimestamps(t *testing.T) {
+	now := pcommon.NewTimestampFromTime(time.Unix(1000, 0))
+	td := NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetStartTimestamp(now - pcommon.Timestamp(time.Second))
+	span.SetEndTimestamp(now + pcommon.Timestamp(time.Hour))
+	span.Events().AppendEmpty()
+
+	adj := td.NormalizeTimestamps(pcommon.TimestampNormalizer{MaxFuture: time.Minute, ReplaceZero: true}, now)
+	assert.Equal(t, pcommon.TimestampAdjustments{Zero: 1, Future: 1}, adj)
+	assert.Equal(t, now-pcommon.Timestamp(time.Second), span.StartTimestamp())
+	assert.Equal(t, now+pcommon.Timestamp(time.Minute), span.EndTimestamp())
+	assert.Equal(t, now, span.Events().At(0).Timestamp())
+}
+
+func TestTracesNormalizeTimestampsStartAfterEnd(t *testing.T) {
+	now := pcommon.NewTimestampFromTime(time.Unix(1000, 0))
+	td := NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetEndTimestamp(now - pcommon.Timestamp(time.Second))
+
+	adj := td.NormalizeTimestamps(pcommon.TimestampNormalizer{ReplaceZero: true}, now)
+	assert.Equal(t, pcommon.TimestampAdjustments{Zero: 1}, adj)
+	assert.Equal(t, now-pcommon.Timestamp(time.Second), span.StartTimestamp())
+	assert.Equal(t, now-pcommon.Timestamp(time.Second), span.EndTimestamp())
+}
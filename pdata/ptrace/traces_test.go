@@ -151,3 +151,44 @@ func TestStatusCodeString(t *testing.T) {
 	assert.EqualValues(t, "Error", StatusCodeError.String())
 	assert.EqualValues(t, "", StatusCode(100).String())
 }
+
+func BenchmarkTracesIterateAt(b *testing.B) {
+	td := generateBenchmarkTraces(128)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		count := 0
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			sss := rss.At(i).ScopeSpans()
+			for j := 0; j < sss.Len(); j++ {
+				spans := sss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					if spans.At(k).Kind() == SpanKindServer {
+						count++
+					}
+				}
+			}
+		}
+		assert.Equal(b, 0, count)
+	}
+}
+
+func BenchmarkTracesIterateForEach(b *testing.B) {
+	td := generateBenchmarkTraces(128)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		count := 0
+		td.ResourceSpans().ForEach(func(_ int, rs ResourceSpans) {
+			rs.ScopeSpans().ForEach(func(_ int, ss ScopeSpans) {
+				ss.Spans().ForEach(func(_ int, span Span) {
+					if span.Kind() == SpanKindServer {
+						count++
+					}
+				})
+			})
+		})
+		assert.Equal(b, 0, count)
+	}
+}
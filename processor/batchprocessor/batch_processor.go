@@ -17,6 +17,7 @@ package batchprocessor // import "go.opentelemetry.io/collector/processor/batchp
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"runtime"
 	"sync"
 	"time"
@@ -38,18 +39,31 @@ import (
 //
 // Batches are sent out with any of the following conditions:
 // - batch size reaches cfg.SendBatchSize
-// - cfg.Timeout is elapsed since the timestamp when the previous batch was sent out.
+// - estimated encoded size of the batch reaches cfg.SendBatchSizeBytes, or would exceed it with the new data
+// - cfg.Timeout, plus a random jitter up to cfg.TimeoutJitter, is elapsed since the timestamp when the previous batch was sent out.
+//
+// Batches are exported one at a time by the batching goroutine, or in the background up to
+// cfg.MaxInFlightBatches at a time. The items accepted and not exported yet are limited to cfg.MaxPendingItems.
 type batchProcessor struct {
-	logger           *zap.Logger
-	exportCtx        context.Context
-	timer            *time.Timer
-	timeout          time.Duration
-	sendBatchSize    int
-	sendBatchMaxSize int
+	logger             *zap.Logger
+	exportCtx          context.Context
+	timer              *time.Timer
+	timeout            time.Duration
+	timeoutJitter      time.Duration
+	rand               *rand.Rand
+	sendBatchSize      int
+	sendBatchMaxSize   int
+	sendBatchSizeBytes int
 
 	newItem chan interface{}
 	batch   batch
 
+	// inFlight holds a token for every batch being exported in the background, nil if the batches are
+	// exported by the batching goroutine.
+	inFlight chan struct{}
+	exports  sync.WaitGroup
+	pending  *pendingLimiter
+
 	shutdownC  chan struct{}
 	goroutines sync.WaitGroup
 
@@ -57,14 +71,23 @@ type batchProcessor struct {
 }
 
 type batch interface {
-	// export the current batch
-	export(ctx context.Context, sendBatchMaxSize int, returnBytes bool) (sentBatchSize int, sentBatchBytes int, err error)
+	// split removes up to sendBatchMaxSize items from the current batch and returns the request to export them
+	split(sendBatchMaxSize int, returnBytes bool) (req interface{}, sentBatchSize int, sentBatchBytes int)
+
+	// export a request returned by split
+	export(ctx context.Context, req interface{}) error
 
 	// itemCount returns the size of the current batch
 	itemCount() int
 
-	// add item to the current batch
-	add(item interface{})
+	// byteSize returns the estimated encoded size in bytes of the current batch
+	byteSize() int
+
+	// itemByteSize returns the estimated encoded size in bytes of the item
+	itemByteSize(item interface{}) int
+
+	// add item to the current batch, with its estimated encoded size in bytes if it is counted
+	add(item interface{}, bytes int)
 }
 
 var _ consumer.Traces = (*batchProcessor)(nil)
@@ -77,17 +100,27 @@ func newBatchProcessor(set component.ProcessorCreateSettings, cfg *Config, batch
 		return nil, fmt.Errorf("error to create batch processor telemetry %w", err)
 	}
 
+	var inFlight chan struct{}
+	if cfg.MaxInFlightBatches > 0 {
+		inFlight = make(chan struct{}, cfg.MaxInFlightBatches)
+	}
+
 	return &batchProcessor{
 		logger:    set.Logger,
 		exportCtx: bpt.exportCtx,
 		telemetry: bpt,
 
-		sendBatchSize:    int(cfg.SendBatchSize),
-		sendBatchMaxSize: int(cfg.SendBatchMaxSize),
-		timeout:          cfg.Timeout,
-		newItem:          make(chan interface{}, runtime.NumCPU()),
-		batch:            batch,
-		shutdownC:        make(chan struct{}, 1),
+		sendBatchSize:      int(cfg.SendBatchSize),
+		sendBatchMaxSize:   int(cfg.SendBatchMaxSize),
+		sendBatchSizeBytes: int(cfg.SendBatchSizeBytes),
+		timeout:            cfg.Timeout,
+		timeoutJitter:      cfg.TimeoutJitter,
+		rand:               rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec
+		newItem:            make(chan interface{}, runtime.NumCPU()),
+		batch:              batch,
+		shutdownC:          make(chan struct{}, 1),
+		inFlight:           inFlight,
+		pending:            newPendingLimiter(int(cfg.MaxPendingItems)),
 	}, nil
 }
 
@@ -108,12 +141,14 @@ func (bp *batchProcessor) Shutdown(context.Context) error {
 
 	// Wait until all goroutines are done.
 	bp.goroutines.Wait()
+	// Wait until all the batches sent in the background are exported.
+	bp.exports.Wait()
 	return nil
 }
 
 func (bp *batchProcessor) startProcessingCycle() {
 	defer bp.goroutines.Done()
-	bp.timer = time.NewTimer(bp.timeout)
+	bp.timer = time.NewTimer(bp.nextTimeout())
 	for {
 		select {
 		case <-bp.shutdownC:
@@ -148,9 +183,20 @@ func (bp *batchProcessor) startProcessingCycle() {
 }
 
 func (bp *batchProcessor) processItem(item interface{}) {
-	bp.batch.add(item)
 	sent := false
-	for bp.batch.itemCount() >= bp.sendBatchSize {
+	bytes := 0
+	if bp.sendBatchSizeBytes > 0 {
+		// Send the current batch first if the item would make it exceed the size in bytes.
+		bytes = bp.batch.itemByteSize(item)
+		for bp.batch.itemCount() > 0 && bp.batch.byteSize()+bytes > bp.sendBatchSizeBytes {
+			sent = true
+			bp.sendItems(triggerBatchSize)
+		}
+	}
+
+	bp.batch.add(item, bytes)
+	for bp.batch.itemCount() >= bp.sendBatchSize ||
+		(bp.sendBatchSizeBytes > 0 && bp.batch.itemCount() > 0 && bp.batch.byteSize() >= bp.sendBatchSizeBytes) {
 		sent = true
 		bp.sendItems(triggerBatchSize)
 	}
@@ -168,11 +214,39 @@ func (bp *batchProcessor) stopTimer() {
 }
 
 func (bp *batchProcessor) resetTimer() {
-	bp.timer.Reset(bp.timeout)
+	bp.timer.Reset(bp.nextTimeout())
+}
+
+// nextTimeout returns the timeout with a random jitter added, if configured.
+func (bp *batchProcessor) nextTimeout() time.Duration {
+	if bp.timeoutJitter <= 0 {
+		return bp.timeout
+	}
+	return bp.timeout + time.Duration(bp.rand.Int63n(int64(bp.timeoutJitter)))
 }
 
 func (bp *batchProcessor) sendItems(trigger trigger) {
-	sent, bytes, err := bp.batch.export(bp.exportCtx, bp.sendBatchMaxSize, bp.telemetry.detailed)
+	req, sent, bytes := bp.batch.split(bp.sendBatchMaxSize, bp.telemetry.detailed)
+	if bp.inFlight == nil {
+		bp.export(trigger, req, sent, bytes)
+		return
+	}
+
+	// Wait for a slot, which stops the batching and applies backpressure while all the slots are used.
+	bp.inFlight <- struct{}{}
+	bp.exports.Add(1)
+	go func() {
+		defer func() {
+			<-bp.inFlight
+			bp.exports.Done()
+		}()
+		bp.export(trigger, req, sent, bytes)
+	}()
+}
+
+func (bp *batchProcessor) export(trigger trigger, req interface{}, sent int, bytes int) {
+	err := bp.batch.export(bp.exportCtx, req)
+	bp.pending.release(sent)
 	if err != nil {
 		bp.logger.Warn("Sender failed", zap.Error(err))
 	} else {
@@ -181,43 +255,59 @@ func (bp *batchProcessor) sendItems(trigger trigger) {
 }
 
 // ConsumeTraces implements TracesProcessor
-func (bp *batchProcessor) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
+func (bp *batchProcessor) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if bp.pending != nil {
+		if err := bp.pending.acquire(ctx, td.SpanCount()); err != nil {
+			return err
+		}
+	}
 	bp.newItem <- td
 	return nil
 }
 
 // ConsumeMetrics implements MetricsProcessor
-func (bp *batchProcessor) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
+func (bp *batchProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if bp.pending != nil {
+		if err := bp.pending.acquire(ctx, md.DataPointCount()); err != nil {
+			return err
+		}
+	}
 	// First thing is convert into a different internal format
 	bp.newItem <- md
 	return nil
 }
 
 // ConsumeLogs implements LogsProcessor
-func (bp *batchProcessor) ConsumeLogs(_ context.Context, ld plog.Logs) error {
+func (bp *batchProcessor) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if bp.pending != nil {
+		if err := bp.pending.acquire(ctx, ld.LogRecordCount()); err != nil {
+			return err
+		}
+	}
 	bp.newItem <- ld
 	return nil
 }
 
 // newBatchTracesProcessor creates a new batch processor that batches traces by size or with timeout
 func newBatchTracesProcessor(set component.ProcessorCreateSettings, next consumer.Traces, cfg *Config, registry *featuregate.Registry) (*batchProcessor, error) {
-	return newBatchProcessor(set, cfg, newBatchTraces(next), registry)
+	return newBatchProcessor(set, cfg.forSignal(cfg.Traces), newBatchTraces(next), registry)
 }
 
 // newBatchMetricsProcessor creates a new batch processor that batches metrics by size or with timeout
 func newBatchMetricsProcessor(set component.ProcessorCreateSettings, next consumer.Metrics, cfg *Config, registry *featuregate.Registry) (*batchProcessor, error) {
-	return newBatchProcessor(set, cfg, newBatchMetrics(next), registry)
+	return newBatchProcessor(set, cfg.forSignal(cfg.Metrics), newBatchMetrics(next), registry)
 }
 
 // newBatchLogsProcessor creates a new batch processor that batches logs by size or with timeout
 func newBatchLogsProcessor(set component.ProcessorCreateSettings, next consumer.Logs, cfg *Config, registry *featuregate.Registry) (*batchProcessor, error) {
-	return newBatchProcessor(set, cfg, newBatchLogs(next), registry)
+	return newBatchProcessor(set, cfg.forSignal(cfg.Logs), newBatchLogs(next), registry)
 }
 
 type batchTraces struct {
 	nextConsumer consumer.Traces
 	traceData    ptrace.Traces
 	spanCount    int
+	bytes        int
 	sizer        ptrace.Sizer
 }
 
@@ -226,7 +316,7 @@ func newBatchTraces(nextConsumer consumer.Traces) *batchTraces {
 }
 
 // add updates current batchTraces by adding new TraceData object
-func (bt *batchTraces) add(item interface{}) {
+func (bt *batchTraces) add(item interface{}, bytes int) {
 	td := item.(ptrace.Traces)
 	newSpanCount := td.SpanCount()
 	if newSpanCount == 0 {
@@ -234,10 +324,11 @@ func (bt *batchTraces) add(item interface{}) {
 	}
 
 	bt.spanCount += newSpanCount
+	bt.bytes += bytes
 	td.ResourceSpans().MoveAndAppendTo(bt.traceData.ResourceSpans())
 }
 
-func (bt *batchTraces) export(ctx context.Context, sendBatchMaxSize int, returnBytes bool) (int, int, error) {
+func (bt *batchTraces) split(sendBatchMaxSize int, returnBytes bool) (interface{}, int, int) {
 	var req ptrace.Traces
 	var sent int
 	var bytes int
@@ -245,26 +336,45 @@ func (bt *batchTraces) export(ctx context.Context, sendBatchMaxSize int, returnB
 		req = splitTraces(sendBatchMaxSize, bt.traceData)
 		bt.spanCount -= sendBatchMaxSize
 		sent = sendBatchMaxSize
+		if bt.bytes > 0 {
+			bytes = bt.sizer.TracesSize(req)
+			bt.bytes = bt.sizer.TracesSize(bt.traceData)
+		}
 	} else {
 		req = bt.traceData
 		sent = bt.spanCount
+		bytes = bt.bytes
 		bt.traceData = ptrace.NewTraces()
 		bt.spanCount = 0
+		bt.bytes = 0
 	}
-	if returnBytes {
+	if returnBytes && bytes == 0 {
 		bytes = bt.sizer.TracesSize(req)
 	}
-	return sent, bytes, bt.nextConsumer.ConsumeTraces(ctx, req)
+	return req, sent, bytes
+}
+
+func (bt *batchTraces) export(ctx context.Context, req interface{}) error {
+	return bt.nextConsumer.ConsumeTraces(ctx, req.(ptrace.Traces))
 }
 
 func (bt *batchTraces) itemCount() int {
 	return bt.spanCount
 }
 
+func (bt *batchTraces) byteSize() int {
+	return bt.bytes
+}
+
+func (bt *batchTraces) itemByteSize(item interface{}) int {
+	return bt.sizer.TracesSize(item.(ptrace.Traces))
+}
+
 type batchMetrics struct {
 	nextConsumer   consumer.Metrics
 	metricData     pmetric.Metrics
 	dataPointCount int
+	bytes          int
 	sizer          pmetric.Sizer
 }
 
@@ -272,7 +382,7 @@ func newBatchMetrics(nextConsumer consumer.Metrics) *batchMetrics {
 	return &batchMetrics{nextConsumer: nextConsumer, metricData: pmetric.NewMetrics(), sizer: &pmetric.ProtoMarshaler{}}
 }
 
-func (bm *batchMetrics) export(ctx context.Context, sendBatchMaxSize int, returnBytes bool) (int, int, error) {
+func (bm *batchMetrics) split(sendBatchMaxSize int, returnBytes bool) (interface{}, int, int) {
 	var req pmetric.Metrics
 	var sent int
 	var bytes int
@@ -280,23 +390,41 @@ func (bm *batchMetrics) export(ctx context.Context, sendBatchMaxSize int, return
 		req = splitMetrics(sendBatchMaxSize, bm.metricData)
 		bm.dataPointCount -= sendBatchMaxSize
 		sent = sendBatchMaxSize
+		if bm.bytes > 0 {
+			bytes = bm.sizer.MetricsSize(req)
+			bm.bytes = bm.sizer.MetricsSize(bm.metricData)
+		}
 	} else {
 		req = bm.metricData
 		sent = bm.dataPointCount
+		bytes = bm.bytes
 		bm.metricData = pmetric.NewMetrics()
 		bm.dataPointCount = 0
+		bm.bytes = 0
 	}
-	if returnBytes {
+	if returnBytes && bytes == 0 {
 		bytes = bm.sizer.MetricsSize(req)
 	}
-	return sent, bytes, bm.nextConsumer.ConsumeMetrics(ctx, req)
+	return req, sent, bytes
+}
+
+func (bm *batchMetrics) export(ctx context.Context, req interface{}) error {
+	return bm.nextConsumer.ConsumeMetrics(ctx, req.(pmetric.Metrics))
 }
 
 func (bm *batchMetrics) itemCount() int {
 	return bm.dataPointCount
 }
 
-func (bm *batchMetrics) add(item interface{}) {
+func (bm *batchMetrics) byteSize() int {
+	return bm.bytes
+}
+
+func (bm *batchMetrics) itemByteSize(item interface{}) int {
+	return bm.sizer.MetricsSize(item.(pmetric.Metrics))
+}
+
+func (bm *batchMetrics) add(item interface{}, bytes int) {
 	md := item.(pmetric.Metrics)
 
 	newDataPointCount := md.DataPointCount()
@@ -304,6 +432,7 @@ func (bm *batchMetrics) add(item interface{}) {
 		return
 	}
 	bm.dataPointCount += newDataPointCount
+	bm.bytes += bytes
 	md.ResourceMetrics().MoveAndAppendTo(bm.metricData.ResourceMetrics())
 }
 
@@ -311,6 +440,7 @@ type batchLogs struct {
 	nextConsumer consumer.Logs
 	logData      plog.Logs
 	logCount     int
+	bytes        int
 	sizer        plog.Sizer
 }
 
@@ -318,7 +448,7 @@ func newBatchLogs(nextConsumer consumer.Logs) *batchLogs {
 	return &batchLogs{nextConsumer: nextConsumer, logData: plog.NewLogs(), sizer: &plog.ProtoMarshaler{}}
 }
 
-func (bl *batchLogs) export(ctx context.Context, sendBatchMaxSize int, returnBytes bool) (int, int, error) {
+func (bl *batchLogs) split(sendBatchMaxSize int, returnBytes bool) (interface{}, int, int) {
 	var req plog.Logs
 	var sent int
 	var bytes int
@@ -326,23 +456,41 @@ func (bl *batchLogs) export(ctx context.Context, sendBatchMaxSize int, returnByt
 		req = splitLogs(sendBatchMaxSize, bl.logData)
 		bl.logCount -= sendBatchMaxSize
 		sent = sendBatchMaxSize
+		if bl.bytes > 0 {
+			bytes = bl.sizer.LogsSize(req)
+			bl.bytes = bl.sizer.LogsSize(bl.logData)
+		}
 	} else {
 		req = bl.logData
 		sent = bl.logCount
+		bytes = bl.bytes
 		bl.logData = plog.NewLogs()
 		bl.logCount = 0
+		bl.bytes = 0
 	}
-	if returnBytes {
+	if returnBytes && bytes == 0 {
 		bytes = bl.sizer.LogsSize(req)
 	}
-	return sent, bytes, bl.nextConsumer.ConsumeLogs(ctx, req)
+	return req, sent, bytes
+}
+
+func (bl *batchLogs) export(ctx context.Context, req interface{}) error {
+	return bl.nextConsumer.ConsumeLogs(ctx, req.(plog.Logs))
 }
 
 func (bl *batchLogs) itemCount() int {
 	return bl.logCount
 }
 
-func (bl *batchLogs) add(item interface{}) {
+func (bl *batchLogs) byteSize() int {
+	return bl.bytes
+}
+
+func (bl *batchLogs) itemByteSize(item interface{}) int {
+	return bl.sizer.LogsSize(item.(plog.Logs))
+}
+
+func (bl *batchLogs) add(item interface{}, bytes int) {
 	ld := item.(plog.Logs)
 
 	newLogsCount := ld.LogRecordCount()
@@ -350,5 +498,6 @@ func (bl *batchLogs) add(item interface{}) {
 		return
 	}
 	bl.logCount += newLogsCount
+	bl.bytes += bytes
 	ld.ResourceLogs().MoveAndAppendTo(bl.logData.ResourceLogs())
 }
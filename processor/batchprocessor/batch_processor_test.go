@@ -227,6 +227,142 @@ func testBatchProcessorSentBySizeWithMaxSize(t *testing.T, tel testTelemetry, re
 	})
 }
 
+func TestBatchProcessorSentBySizeBytes(t *testing.T) {
+	sizer := &ptrace.ProtoMarshaler{}
+	sink := new(consumertest.TracesSink)
+	requestBytes := sizer.TracesSize(testdata.GenerateTraces(10))
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 1_000_000
+	cfg.Timeout = time.Hour
+	// Three requests fit in a batch, the fourth one would exceed the size in bytes.
+	cfg.SendBatchSizeBytes = uint32(3*requestBytes + requestBytes/2)
+	batcher, err := newBatchTracesProcessor(componenttest.NewNopProcessorCreateSettings(), sink, cfg, featuregate.GetRegistry())
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	for requestNum := 0; requestNum < 9; requestNum++ {
+		require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(10)))
+	}
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	require.Len(t, sink.AllTraces(), 3)
+	for _, td := range sink.AllTraces() {
+		assert.Equal(t, 30, td.SpanCount())
+		assert.LessOrEqual(t, sizer.TracesSize(td), int(cfg.SendBatchSizeBytes))
+	}
+}
+
+func TestBatchProcessorSentBySizeBytesLargeRequest(t *testing.T) {
+	sizer := &plog.ProtoMarshaler{}
+	sink := new(consumertest.LogsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 1000
+	cfg.Timeout = time.Hour
+	// A single request larger than the size in bytes is sent on its own.
+	cfg.SendBatchSizeBytes = uint32(sizer.LogsSize(testdata.GenerateLogs(5)))
+	batcher, err := newBatchLogsProcessor(componenttest.NewNopProcessorCreateSettings(), sink, cfg, featuregate.GetRegistry())
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, batcher.ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	require.NoError(t, batcher.ConsumeLogs(context.Background(), testdata.GenerateLogs(20)))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	require.Len(t, sink.AllLogs(), 2)
+	assert.Equal(t, 2, sink.AllLogs()[0].LogRecordCount())
+	assert.Equal(t, 20, sink.AllLogs()[1].LogRecordCount())
+}
+
+// blockingTracesSink blocks the exports until unblock is closed, and tracks how many are in flight.
+type blockingTracesSink struct {
+	consumertest.TracesSink
+	unblock chan struct{}
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (s *blockingTracesSink) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	s.mu.Lock()
+	s.inFlight++
+	if s.inFlight > s.maxInFlight {
+		s.maxInFlight = s.inFlight
+	}
+	s.mu.Unlock()
+
+	<-s.unblock
+
+	s.mu.Lock()
+	s.inFlight--
+	s.mu.Unlock()
+	return s.TracesSink.ConsumeTraces(ctx, td)
+}
+
+func (s *blockingTracesSink) currentInFlight() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inFlight
+}
+
+func TestBatchProcessorMaxInFlightBatches(t *testing.T) {
+	sink := &blockingTracesSink{unblock: make(chan struct{})}
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	cfg.Timeout = time.Hour
+	cfg.MaxInFlightBatches = 2
+	batcher, err := newBatchTracesProcessor(componenttest.NewNopProcessorCreateSettings(), sink, cfg, featuregate.GetRegistry())
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	requestCount := 5
+	consumed := make(chan struct{})
+	go func() {
+		defer close(consumed)
+		for requestNum := 0; requestNum < requestCount; requestNum++ {
+			assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(10)))
+		}
+	}()
+
+	assert.Eventually(t, func() bool {
+		return sink.currentInFlight() == 2
+	}, time.Second, 5*time.Millisecond)
+	// The next batches wait for the exports in flight.
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 2, sink.currentInFlight())
+
+	close(sink.unblock)
+	<-consumed
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	assert.Equal(t, requestCount*10, sink.SpanCount())
+	assert.Equal(t, 2, sink.maxInFlight)
+}
+
+func TestBatchProcessorMaxPendingItems(t *testing.T) {
+	sink := &blockingTracesSink{unblock: make(chan struct{})}
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	cfg.Timeout = time.Hour
+	cfg.MaxPendingItems = 10
+	batcher, err := newBatchTracesProcessor(componenttest.NewNopProcessorCreateSettings(), sink, cfg, featuregate.GetRegistry())
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The batch is sent and its export blocks, the items stay pending.
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(10)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, batcher.ConsumeTraces(ctx, testdata.GenerateTraces(1)), context.DeadlineExceeded)
+
+	close(sink.unblock)
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	assert.Equal(t, 11, sink.SpanCount())
+}
+
 func TestBatchProcessorSentByTimeout(t *testing.T) {
 	sink := new(consumertest.TracesSink)
 	cfg := createDefaultConfig().(*Config)
@@ -278,6 +414,26 @@ func TestBatchProcessorSentByTimeout(t *testing.T) {
 	}
 }
 
+func TestBatchProcessorTimeoutJitter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = 100 * time.Millisecond
+	cfg.TimeoutJitter = 50 * time.Millisecond
+
+	batcher, err := newBatchTracesProcessor(componenttest.NewNopProcessorCreateSettings(), new(consumertest.TracesSink), cfg, featuregate.GetRegistry())
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		timeout := batcher.nextTimeout()
+		require.GreaterOrEqual(t, timeout, cfg.Timeout)
+		require.Less(t, timeout, cfg.Timeout+cfg.TimeoutJitter)
+	}
+
+	cfg.TimeoutJitter = 0
+	batcher, err = newBatchTracesProcessor(componenttest.NewNopProcessorCreateSettings(), new(consumertest.TracesSink), cfg, featuregate.GetRegistry())
+	require.NoError(t, err)
+	require.Equal(t, cfg.Timeout, batcher.nextTimeout())
+}
+
 func TestBatchProcessorTraceSendWhenClosing(t *testing.T) {
 	cfg := Config{
 		ProcessorSettings: config.NewProcessorSettings(component.NewID(typeStr)),
@@ -425,10 +581,10 @@ func TestBatchMetrics_UnevenBatchMaxSize(t *testing.T) {
 	batchMetrics := newBatchMetrics(sink)
 	md := testdata.GenerateMetrics(metricsCount)
 
-	batchMetrics.add(md)
+	batchMetrics.add(md, 0)
 	require.Equal(t, dataPointsPerMetric*metricsCount, batchMetrics.dataPointCount)
-	sent, _, sendErr := batchMetrics.export(ctx, sendBatchMaxSize, false)
-	require.NoError(t, sendErr)
+	req, sent, _ := batchMetrics.split(sendBatchMaxSize, false)
+	require.NoError(t, batchMetrics.export(ctx, req))
 	require.Equal(t, sendBatchMaxSize, sent)
 	remainingDataPointCount := metricsCount*dataPointsPerMetric - sendBatchMaxSize
 	require.Equal(t, remainingDataPointCount, batchMetrics.dataPointCount)
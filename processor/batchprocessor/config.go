@@ -16,6 +16,7 @@ package batchprocessor // import "go.opentelemetry.io/collector/processor/batchp
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -29,6 +30,10 @@ type Config struct {
 	// Timeout sets the time after which a batch will be sent regardless of size.
 	Timeout time.Duration `mapstructure:"timeout"`
 
+	// TimeoutJitter adds a random duration between 0 and TimeoutJitter to every timeout,
+	// so that many batch processors started at the same time do not send their batches in lockstep.
+	TimeoutJitter time.Duration `mapstructure:"timeout_jitter"`
+
 	// SendBatchSize is the size of a batch which after hit, will trigger it to be sent.
 	SendBatchSize uint32 `mapstructure:"send_batch_size"`
 
@@ -36,6 +41,86 @@ type Config struct {
 	// Larger batches are split into smaller units.
 	// Default value is 0, that means no maximum size.
 	SendBatchMaxSize uint32 `mapstructure:"send_batch_max_size"`
+
+	// SendBatchSizeBytes is the estimated size in bytes of the OTLP encoded batch which after hit,
+	// will trigger it to be sent. The batch is also sent before adding data that would make it exceed
+	// this size, so that the batches stay below the maximum message size of the destination.
+	// Default value is 0, that means no size in bytes is enforced.
+	SendBatchSizeBytes uint32 `mapstructure:"send_batch_size_bytes"`
+
+	// MaxInFlightBatches is the maximum number of batches exported concurrently. When this many batches
+	// are being exported, the next batch waits for one of them to complete, and so does the data
+	// received meanwhile once the queue of the processor is full, which applies backpressure upstream.
+	// Default value is 0, that means batches are exported one at a time by the batching goroutine.
+	MaxInFlightBatches uint32 `mapstructure:"max_in_flight_batches"`
+
+	// MaxPendingItems is the maximum number of spans, metric data points or log records accepted by the
+	// processor and not exported yet. Data that would exceed it waits for pending data to be exported,
+	// and is refused if the context of the caller is done first. A single request larger than this
+	// limit is accepted when nothing is pending. It must be greater than or equal to SendBatchSize.
+	// Default value is 0, that means no limit.
+	MaxPendingItems uint32 `mapstructure:"max_pending_items"`
+
+	// Traces overrides the batching settings for traces.
+	Traces SignalConfig `mapstructure:"traces"`
+
+	// Metrics overrides the batching settings for metrics.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Logs overrides the batching settings for logs.
+	Logs SignalConfig `mapstructure:"logs"`
+}
+
+// SignalConfig overrides the batching settings for a single signal, so that one batch processor
+// shared by several pipelines can batch every signal differently. Zero values use the top-level settings.
+type SignalConfig struct {
+	// Timeout overrides Config.Timeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// SendBatchSize overrides Config.SendBatchSize.
+	SendBatchSize uint32 `mapstructure:"send_batch_size"`
+
+	// SendBatchMaxSize overrides Config.SendBatchMaxSize.
+	SendBatchMaxSize uint32 `mapstructure:"send_batch_max_size"`
+
+	// SendBatchSizeBytes overrides Config.SendBatchSizeBytes.
+	SendBatchSizeBytes uint32 `mapstructure:"send_batch_size_bytes"`
+}
+
+// MaxBatchSize returns the largest number of items of the batches of the given data type, zero if it is not
+// limited. The service uses it to estimate the resources held by the pipelines.
+func (cfg *Config) MaxBatchSize(dt component.DataType) uint64 {
+	sigCfg := cfg
+	switch dt {
+	case component.DataTypeTraces:
+		sigCfg = cfg.forSignal(cfg.Traces)
+	case component.DataTypeMetrics:
+		sigCfg = cfg.forSignal(cfg.Metrics)
+	case component.DataTypeLogs:
+		sigCfg = cfg.forSignal(cfg.Logs)
+	}
+	if sigCfg.SendBatchMaxSize > 0 {
+		return uint64(sigCfg.SendBatchMaxSize)
+	}
+	return uint64(sigCfg.SendBatchSize)
+}
+
+// forSignal returns the settings with the overrides of the given signal applied.
+func (cfg *Config) forSignal(sc SignalConfig) *Config {
+	out := *cfg
+	if sc.Timeout != 0 {
+		out.Timeout = sc.Timeout
+	}
+	if sc.SendBatchSize != 0 {
+		out.SendBatchSize = sc.SendBatchSize
+	}
+	if sc.SendBatchMaxSize != 0 {
+		out.SendBatchMaxSize = sc.SendBatchMaxSize
+	}
+	if sc.SendBatchSizeBytes != 0 {
+		out.SendBatchSizeBytes = sc.SendBatchSizeBytes
+	}
+	return &out
 }
 
 var _ component.Config = (*Config)(nil)
@@ -45,5 +130,28 @@ func (cfg *Config) Validate() error {
 	if cfg.SendBatchMaxSize > 0 && cfg.SendBatchMaxSize < cfg.SendBatchSize {
 		return errors.New("send_batch_max_size must be greater or equal to send_batch_size")
 	}
+	if cfg.TimeoutJitter < 0 {
+		return errors.New("timeout_jitter must not be negative")
+	}
+	signals := []struct {
+		name string
+		sc   SignalConfig
+	}{
+		{name: "traces", sc: cfg.Traces},
+		{name: "metrics", sc: cfg.Metrics},
+		{name: "logs", sc: cfg.Logs},
+	}
+	for _, s := range signals {
+		if s.sc.Timeout < 0 {
+			return fmt.Errorf("%s: timeout must not be negative", s.name)
+		}
+		sigCfg := cfg.forSignal(s.sc)
+		if sigCfg.SendBatchMaxSize > 0 && sigCfg.SendBatchMaxSize < sigCfg.SendBatchSize {
+			return fmt.Errorf("%s: send_batch_max_size must be greater or equal to send_batch_size", s.name)
+		}
+		if sigCfg.MaxPendingItems > 0 && sigCfg.MaxPendingItems < sigCfg.SendBatchSize {
+			return fmt.Errorf("%s: max_pending_items must be greater or equal to send_batch_size", s.name)
+		}
+	}
 	return nil
 }
@@ -41,15 +41,101 @@ func TestUnmarshalConfig(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
 	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings:  config.NewProcessorSettings(component.NewID(typeStr)),
+			SendBatchSize:      uint32(10000),
+			SendBatchMaxSize:   uint32(11000),
+			SendBatchSizeBytes: uint32(4000000),
+			Timeout:            time.Second * 10,
+			TimeoutJitter:      time.Second,
+			MaxInFlightBatches: 4,
+			MaxPendingItems:    50000,
+		}, cfg)
+}
+
+func TestUnmarshalConfigSignals(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_signals.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.NoError(t, component.ValidateConfig(cfg))
 	assert.Equal(t,
 		&Config{
 			ProcessorSettings: config.NewProcessorSettings(component.NewID(typeStr)),
 			SendBatchSize:     uint32(10000),
-			SendBatchMaxSize:  uint32(11000),
 			Timeout:           time.Second * 10,
+			Traces: SignalConfig{
+				SendBatchSize:    uint32(2000),
+				SendBatchMaxSize: uint32(4000),
+			},
+			Metrics: SignalConfig{
+				Timeout: time.Second * 30,
+			},
 		}, cfg)
 }
 
+func TestConfigForSignal(t *testing.T) {
+	cfg := &Config{
+		SendBatchSize: 100,
+		Timeout:       time.Second,
+		TimeoutJitter: time.Millisecond,
+		Traces:        SignalConfig{SendBatchSize: 10, SendBatchMaxSize: 20, SendBatchSizeBytes: 1024},
+	}
+
+	tracesCfg := cfg.forSignal(cfg.Traces)
+	assert.Equal(t, uint32(10), tracesCfg.SendBatchSize)
+	assert.Equal(t, uint32(20), tracesCfg.SendBatchMaxSize)
+	assert.Equal(t, uint32(1024), tracesCfg.SendBatchSizeBytes)
+	assert.Equal(t, time.Second, tracesCfg.Timeout)
+	assert.Equal(t, time.Millisecond, tracesCfg.TimeoutJitter)
+
+	logsCfg := cfg.forSignal(cfg.Logs)
+	assert.Equal(t, uint32(100), logsCfg.SendBatchSize)
+	assert.Equal(t, uint32(0), logsCfg.SendBatchMaxSize)
+	assert.Equal(t, uint32(0), logsCfg.SendBatchSizeBytes)
+	assert.Equal(t, time.Second, logsCfg.Timeout)
+}
+
+func TestConfigMaxBatchSize(t *testing.T) {
+	cfg := &Config{
+		SendBatchSize: 100,
+		Traces:        SignalConfig{SendBatchSize: 10, SendBatchMaxSize: 20},
+		Metrics:       SignalConfig{SendBatchSize: 50},
+	}
+	assert.Equal(t, uint64(20), cfg.MaxBatchSize(component.DataTypeTraces))
+	assert.Equal(t, uint64(50), cfg.MaxBatchSize(component.DataTypeMetrics))
+	assert.Equal(t, uint64(100), cfg.MaxBatchSize(component.DataTypeLogs))
+}
+
+func TestValidateConfig_InvalidSignalBatchSize(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(component.NewIDWithName(typeStr, "2")),
+		SendBatchSize:     100,
+		Metrics:           SignalConfig{SendBatchMaxSize: 10},
+	}
+	assert.EqualError(t, cfg.Validate(), "metrics: send_batch_max_size must be greater or equal to send_batch_size")
+}
+
+func TestValidateConfig_InvalidMaxPendingItems(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(component.NewIDWithName(typeStr, "2")),
+		SendBatchSize:     100,
+		MaxPendingItems:   1000,
+		Traces:            SignalConfig{SendBatchSize: 2000},
+	}
+	assert.EqualError(t, cfg.Validate(), "traces: max_pending_items must be greater or equal to send_batch_size")
+}
+
+func TestValidateConfig_InvalidSignalTimeout(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(component.NewIDWithName(typeStr, "2")),
+		Logs:              SignalConfig{Timeout: -time.Second},
+	}
+	assert.EqualError(t, cfg.Validate(), "logs: timeout must not be negative")
+}
+
 func TestValidateConfig_DefaultBatchMaxSize(t *testing.T) {
 	cfg := &Config{
 		ProcessorSettings: config.NewProcessorSettings(component.NewIDWithName(typeStr, "2")),
@@ -69,6 +155,15 @@ func TestValidateConfig_ValidBatchSizes(t *testing.T) {
 
 }
 
+func TestValidateConfig_InvalidTimeoutJitter(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(component.NewIDWithName(typeStr, "2")),
+		SendBatchSize:     100,
+		TimeoutJitter:     -time.Second,
+	}
+	assert.Error(t, cfg.Validate())
+}
+
 func TestValidateConfig_InvalidBatchSize(t *testing.T) {
 	cfg := &Config{
 		ProcessorSettings: config.NewProcessorSettings(component.NewIDWithName(typeStr, "2")),
@@ -37,9 +37,9 @@ func NewFactory() component.ProcessorFactory {
 	return component.NewProcessorFactory(
 		typeStr,
 		createDefaultConfig,
-		component.WithTracesProcessor(createTracesProcessor, component.StabilityLevelStable),
-		component.WithMetricsProcessor(createMetricsProcessor, component.StabilityLevelStable),
-		component.WithLogsProcessor(createLogsProcessor, component.StabilityLevelStable))
+		component.WithTracesProcessor(component.NewCreateTracesProcessorFunc(createTracesProcessor), component.StabilityLevelStable),
+		component.WithMetricsProcessor(component.NewCreateMetricsProcessorFunc(createMetricsProcessor), component.StabilityLevelStable),
+		component.WithLogsProcessor(component.NewCreateLogsProcessorFunc(createLogsProcessor), component.StabilityLevelStable))
 }
 
 func createDefaultConfig() component.Config {
@@ -53,26 +53,26 @@ func createDefaultConfig() component.Config {
 func createTracesProcessor(
 	_ context.Context,
 	set component.ProcessorCreateSettings,
-	cfg component.Config,
+	cfg *Config,
 	nextConsumer consumer.Traces,
 ) (component.TracesProcessor, error) {
-	return newBatchTracesProcessor(set, nextConsumer, cfg.(*Config), featuregate.GetRegistry())
+	return newBatchTracesProcessor(set, nextConsumer, cfg, featuregate.GetRegistry())
 }
 
 func createMetricsProcessor(
 	_ context.Context,
 	set component.ProcessorCreateSettings,
-	cfg component.Config,
+	cfg *Config,
 	nextConsumer consumer.Metrics,
 ) (component.MetricsProcessor, error) {
-	return newBatchMetricsProcessor(set, nextConsumer, cfg.(*Config), featuregate.GetRegistry())
+	return newBatchMetricsProcessor(set, nextConsumer, cfg, featuregate.GetRegistry())
 }
 
 func createLogsProcessor(
 	_ context.Context,
 	set component.ProcessorCreateSettings,
-	cfg component.Config,
+	cfg *Config,
 	nextConsumer consumer.Logs,
 ) (component.LogsProcessor, error) {
-	return newBatchLogsProcessor(set, nextConsumer, cfg.(*Config), featuregate.GetRegistry())
+	return newBatchLogsProcessor(set, nextConsumer, cfg, featuregate.GetRegistry())
 }
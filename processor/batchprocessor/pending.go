@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor // import "go.opentelemetry.io/collector/processor/batchprocessor"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// pendingLimiter bounds the number of items accepted by the processor and not exported yet.
+// A nil pendingLimiter does not bound anything.
+type pendingLimiter struct {
+	max int
+
+	mu      sync.Mutex
+	pending int
+	// released is closed, and replaced, every time pending items are released.
+	released chan struct{}
+}
+
+func newPendingLimiter(max int) *pendingLimiter {
+	if max <= 0 {
+		return nil
+	}
+	return &pendingLimiter{max: max, released: make(chan struct{})}
+}
+
+// acquire waits until n more items can be pending, or ctx is done. Requests larger than the limit are
+// accepted when nothing is pending, so that they are not refused forever.
+func (pl *pendingLimiter) acquire(ctx context.Context, n int) error {
+	if pl == nil || n == 0 {
+		return nil
+	}
+	for {
+		pl.mu.Lock()
+		if pl.pending == 0 || pl.pending+n <= pl.max {
+			pl.pending += n
+			pl.mu.Unlock()
+			return nil
+		}
+		released := pl.released
+		pl.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for %d pending items to be exported: %w", pl.max, ctx.Err())
+		}
+	}
+}
+
+// release marks n items as exported.
+func (pl *pendingLimiter) release(n int) {
+	if pl == nil || n == 0 {
+		return
+	}
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	pl.pending -= n
+	close(pl.released)
+	pl.released = make(chan struct{})
+}
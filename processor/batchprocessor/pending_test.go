@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingLimiterNil(t *testing.T) {
+	pl := newPendingLimiter(0)
+	assert.Nil(t, pl)
+	assert.NoError(t, pl.acquire(context.Background(), 100))
+	pl.release(100)
+}
+
+func TestPendingLimiter(t *testing.T) {
+	pl := newPendingLimiter(10)
+	assert.NoError(t, pl.acquire(context.Background(), 6))
+	assert.NoError(t, pl.acquire(context.Background(), 4))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.EqualError(t, pl.acquire(ctx, 1), "waiting for 10 pending items to be exported: context deadline exceeded")
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- pl.acquire(context.Background(), 5)
+	}()
+	pl.release(4)
+	select {
+	case <-acquired:
+		t.Fatal("acquired more items than the limit")
+	case <-time.After(10 * time.Millisecond):
+	}
+	pl.release(6)
+	assert.NoError(t, <-acquired)
+}
+
+func TestPendingLimiterLargeRequest(t *testing.T) {
+	pl := newPendingLimiter(10)
+	assert.NoError(t, pl.acquire(context.Background(), 100))
+	pl.release(100)
+	assert.NoError(t, pl.acquire(context.Background(), 10))
+}
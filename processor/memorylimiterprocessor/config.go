@@ -48,11 +48,34 @@ type Config struct {
 	// MemorySpikePercentage is the maximum, in percents against the total memory,
 	// spike expected between the measurements of memory usage.
 	MemorySpikePercentage uint32 `mapstructure:"spike_limit_percentage"`
+
+	// MaxRequestSize limits the size of a single request, independently of the memory usage, so that
+	// oversized payloads are rejected before they cause allocation spikes further in the pipeline.
+	MaxRequestSize MaxRequestSize `mapstructure:"max_request_size"`
+}
+
+// MaxRequestSize defines the limits of the size of a single request. Zero values disable the limits.
+type MaxRequestSize struct {
+	// Items is the maximum number of spans, metric data points or log records of a request.
+	Items int `mapstructure:"items"`
+
+	// MiB is the maximum estimated size, in MiB, of a request, measured as the size of its OTLP
+	// protobuf encoding.
+	MiB uint32 `mapstructure:"mib"`
 }
 
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.MaxRequestSize.Items < 0 {
+		return errMaxRequestItemsOutOfRange
+	}
 	return nil
 }
+
+// FixedMemoryLimitMiB returns the memory limit in MiB, zero if the limit is a percentage of the total memory.
+// The service uses it to estimate the resources held by the pipelines.
+func (cfg *Config) FixedMemoryLimitMiB() uint64 {
+	return uint64(cfg.MemoryLimitMiB)
+}
@@ -47,5 +47,22 @@ func TestUnmarshalConfig(t *testing.T) {
 			CheckInterval:       5 * time.Second,
 			MemoryLimitMiB:      4000,
 			MemorySpikeLimitMiB: 500,
+			MaxRequestSize: MaxRequestSize{
+				Items: 100000,
+				MiB:   32,
+			},
 		}, cfg)
 }
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MaxRequestSize.Items = -1
+	assert.Equal(t, errMaxRequestItemsOutOfRange, cfg.Validate())
+}
+
+func TestConfigFixedMemoryLimitMiB(t *testing.T) {
+	assert.Equal(t, uint64(4000), (&Config{MemoryLimitMiB: 4000}).FixedMemoryLimitMiB())
+	assert.Zero(t, (&Config{MemoryLimitPercentage: 80}).FixedMemoryLimitMiB())
+}
@@ -16,6 +16,7 @@ package memorylimiterprocessor // import "go.opentelemetry.io/collector/processo
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"go.opentelemetry.io/collector/component"
@@ -120,7 +121,11 @@ func (f *factory) getMemoryLimiter(set component.ProcessorCreateSettings, cfg co
 		return memLimiter, nil
 	}
 
-	memLimiter, err := newMemoryLimiter(set, cfg.(*Config))
+	oCfg, ok := cfg.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("invalid configuration type %T for the memory limiter processor", cfg)
+	}
+	memLimiter, err := newMemoryLimiter(set, oCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -23,7 +23,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/memorylimiter"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
@@ -64,7 +66,7 @@ func TestCreateProcessor(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, tp)
 	// test if we can shutdown a monitoring routine that has not started
-	assert.ErrorIs(t, tp.Shutdown(context.Background()), errShutdownNotStarted)
+	assert.ErrorIs(t, tp.Shutdown(context.Background()), memorylimiter.ErrShutdownNotStarted)
 	assert.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
 
 	mp, err = factory.CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
@@ -87,5 +89,14 @@ func TestCreateProcessor(t *testing.T) {
 	assert.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
 	assert.NoError(t, lp.Shutdown(context.Background()))
 	// calling it again should throw an error
-	assert.ErrorIs(t, lp.Shutdown(context.Background()), errShutdownNotStarted)
+	assert.ErrorIs(t, lp.Shutdown(context.Background()), memorylimiter.ErrShutdownNotStarted)
+}
+
+func TestCreateProcessorInvalidConfigType(t *testing.T) {
+	factory := NewFactory()
+	cfg := &struct{ config.ProcessorSettings }{}
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	assert.Nil(t, tp)
+	assert.EqualError(t, err, "invalid configuration type *struct { config.ProcessorSettings } for the memory limiter processor")
 }
@@ -18,15 +18,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"runtime"
-	"sync"
-	"time"
 
-	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/internal/iruntime"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/memorylimiter"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -40,79 +37,51 @@ const (
 var (
 	// errForcedDrop will be returned to callers of ConsumeTraceData to indicate
 	// that data is being dropped due to high memory usage.
-	errForcedDrop = errors.New("data dropped due to high memory usage")
+	errForcedDrop = consumererror.NewLimitExceeded(errors.New("data dropped due to high memory usage"))
 
-	// Construction errors
-
-	errCheckIntervalOutOfRange = errors.New(
-		"checkInterval must be greater than zero")
-
-	errLimitOutOfRange = errors.New(
-		"memAllocLimit or memoryLimitPercentage must be greater than zero")
-
-	errMemSpikeLimitOutOfRange = errors.New(
-		"memSpikeLimit must be smaller than memAllocLimit")
-
-	errPercentageLimitOutOfRange = errors.New(
-		"memoryLimitPercentage and memorySpikePercentage must be greater than zero and less than or equal to hundred",
-	)
+	errMaxRequestItemsOutOfRange = errors.New("max_request_size items must not be negative")
+)
 
-	errShutdownNotStarted = errors.New("no existing monitoring routine is running")
+var (
+	tracesMarshaler  = &ptrace.ProtoMarshaler{}
+	metricsMarshaler = &pmetric.ProtoMarshaler{}
+	logsMarshaler    = &plog.ProtoMarshaler{}
 )
 
-// make it overridable by tests
-var getMemoryFn = iruntime.TotalMemory
+// memoryChecker reports whether the memory usage is above the soft limit, see memorylimiter.MemoryLimiter.
+type memoryChecker interface {
+	component.Component
+	MustRefuse() bool
+}
 
 type memoryLimiter struct {
-	usageChecker memUsageChecker
-
-	memCheckWait time.Duration
-	ballastSize  uint64
-
-	// forceDrop is used atomically to indicate when data should be dropped.
-	forceDrop *atomic.Bool
-
-	ticker *time.Ticker
+	limiter memoryChecker
 
-	lastGCDone time.Time
-
-	// The function to read the mem values is set as a reference to help with
-	// testing different values.
-	readMemStatsFn func(m *runtime.MemStats)
-
-	// Fields used for logging.
-	logger                 *zap.Logger
-	configMismatchedLogged bool
+	// maxRequestItems and maxRequestBytes are the limits of the size of a single request, zero if disabled.
+	maxRequestItems int
+	maxRequestBytes int
 
 	obsrep *obsreport.Processor
-
-	refCounterLock sync.Mutex
-	refCounter     int
 }
 
-// Minimum interval between forced GC when in soft limited mode. We don't want to
-// do GCs too frequently since it is a CPU-heavy operation.
-const minGCIntervalWhenSoftLimited = 10 * time.Second
-
 // newMemoryLimiter returns a new memorylimiter processor.
 func newMemoryLimiter(set component.ProcessorCreateSettings, cfg *Config) (*memoryLimiter, error) {
-	if cfg.CheckInterval <= 0 {
-		return nil, errCheckIntervalOutOfRange
-	}
-	if cfg.MemoryLimitMiB == 0 && cfg.MemoryLimitPercentage == 0 {
-		return nil, errLimitOutOfRange
-	}
-
-	logger := set.Logger
-	usageChecker, err := getMemUsageChecker(cfg, logger)
+	limiter, err := memorylimiter.New(memorylimiter.Settings{
+		CheckInterval:         cfg.CheckInterval,
+		MemoryLimitMiB:        cfg.MemoryLimitMiB,
+		MemorySpikeLimitMiB:   cfg.MemorySpikeLimitMiB,
+		MemoryLimitPercentage: cfg.MemoryLimitPercentage,
+		MemorySpikePercentage: cfg.MemorySpikePercentage,
+	}, set.Logger)
 	if err != nil {
 		return nil, err
 	}
 
-	logger.Info("Memory limiter configured",
-		zap.Uint64("limit_mib", usageChecker.memAllocLimit/mibBytes),
-		zap.Uint64("spike_limit_mib", usageChecker.memSpikeLimit/mibBytes),
-		zap.Duration("check_interval", cfg.CheckInterval))
+	if cfg.MaxRequestSize.Items > 0 || cfg.MaxRequestSize.MiB > 0 {
+		set.Logger.Info("Memory limiter request size limited",
+			zap.Int("max_request_items", cfg.MaxRequestSize.Items),
+			zap.Uint32("max_request_mib", cfg.MaxRequestSize.MiB))
+	}
 
 	obsrep, err := obsreport.NewProcessor(obsreport.ProcessorSettings{
 		ProcessorID:             set.ID,
@@ -122,64 +91,45 @@ func newMemoryLimiter(set component.ProcessorCreateSettings, cfg *Config) (*memo
 		return nil, err
 	}
 
-	ml := &memoryLimiter{
-		usageChecker:   *usageChecker,
-		memCheckWait:   cfg.CheckInterval,
-		ticker:         time.NewTicker(cfg.CheckInterval),
-		readMemStatsFn: runtime.ReadMemStats,
-		logger:         logger,
-		forceDrop:      atomic.NewBool(false),
-		obsrep:         obsrep,
-	}
-
-	return ml, nil
+	return &memoryLimiter{
+		limiter:         limiter,
+		maxRequestItems: cfg.MaxRequestSize.Items,
+		maxRequestBytes: int(cfg.MaxRequestSize.MiB) * mibBytes,
+		obsrep:          obsrep,
+	}, nil
 }
 
-func getMemUsageChecker(cfg *Config, logger *zap.Logger) (*memUsageChecker, error) {
-	memAllocLimit := uint64(cfg.MemoryLimitMiB) * mibBytes
-	memSpikeLimit := uint64(cfg.MemorySpikeLimitMiB) * mibBytes
-	if cfg.MemoryLimitMiB != 0 {
-		return newFixedMemUsageChecker(memAllocLimit, memSpikeLimit)
-	}
-	totalMemory, err := getMemoryFn()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get total memory, use fixed memory settings (limit_mib): %w", err)
-	}
-	logger.Info("Using percentage memory limiter",
-		zap.Uint64("total_memory_mib", totalMemory/mibBytes),
-		zap.Uint32("limit_percentage", cfg.MemoryLimitPercentage),
-		zap.Uint32("spike_limit_percentage", cfg.MemorySpikePercentage))
-	return newPercentageMemUsageChecker(totalMemory, uint64(cfg.MemoryLimitPercentage), uint64(cfg.MemorySpikePercentage))
+func (ml *memoryLimiter) start(ctx context.Context, host component.Host) error {
+	return ml.limiter.Start(ctx, host)
 }
 
-func (ml *memoryLimiter) start(_ context.Context, host component.Host) error {
-	extensions := host.GetExtensions()
-	for _, extension := range extensions {
-		if ext, ok := extension.(interface{ GetBallastSize() uint64 }); ok {
-			ml.ballastSize = ext.GetBallastSize()
-			break
-		}
-	}
-	ml.startMonitoring()
-	return nil
+func (ml *memoryLimiter) shutdown(ctx context.Context) error {
+	return ml.limiter.Shutdown(ctx)
 }
 
-func (ml *memoryLimiter) shutdown(context.Context) error {
-	ml.refCounterLock.Lock()
-	defer ml.refCounterLock.Unlock()
-
-	if ml.refCounter == 0 {
-		return errShutdownNotStarted
-	} else if ml.refCounter == 1 {
-		ml.ticker.Stop()
+// checkRequestSize returns a permanent error if the request with the given number of items is larger than
+// the limits. The size of the request is only computed if its limit is enabled.
+func (ml *memoryLimiter) checkRequestSize(numItems int, size func() int) error {
+	if ml.maxRequestItems > 0 && numItems > ml.maxRequestItems {
+		return consumererror.NewPermanent(fmt.Errorf(
+			"request of %d items exceeds the max_request_size of %d items", numItems, ml.maxRequestItems))
+	}
+	if ml.maxRequestBytes > 0 {
+		if bytes := size(); bytes > ml.maxRequestBytes {
+			return consumererror.NewPermanent(fmt.Errorf(
+				"request of %d bytes exceeds the max_request_size of %d bytes", bytes, ml.maxRequestBytes))
+		}
 	}
-	ml.refCounter--
 	return nil
 }
 
 func (ml *memoryLimiter) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
 	numSpans := td.SpanCount()
-	if ml.forceDrop.Load() {
+	if err := ml.checkRequestSize(numSpans, func() int { return tracesMarshaler.TracesSize(td) }); err != nil {
+		ml.obsrep.TracesRefused(ctx, numSpans)
+		return td, err
+	}
+	if ml.limiter.MustRefuse() {
 		// TODO: actually to be 100% sure that this is "refused" and not "dropped"
 		// 	it is necessary to check the pipeline to see if this is directly connected
 		// 	to a receiver (ie.: a receiver is on the call stack). For now it
@@ -198,7 +148,11 @@ func (ml *memoryLimiter) processTraces(ctx context.Context, td ptrace.Traces) (p
 
 func (ml *memoryLimiter) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
 	numDataPoints := md.DataPointCount()
-	if ml.forceDrop.Load() {
+	if err := ml.checkRequestSize(numDataPoints, func() int { return metricsMarshaler.MetricsSize(md) }); err != nil {
+		ml.obsrep.MetricsRefused(ctx, numDataPoints)
+		return md, err
+	}
+	if ml.limiter.MustRefuse() {
 		// TODO: actually to be 100% sure that this is "refused" and not "dropped"
 		// 	it is necessary to check the pipeline to see if this is directly connected
 		// 	to a receiver (ie.: a receiver is on the call stack). For now it
@@ -216,7 +170,11 @@ func (ml *memoryLimiter) processMetrics(ctx context.Context, md pmetric.Metrics)
 
 func (ml *memoryLimiter) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
 	numRecords := ld.LogRecordCount()
-	if ml.forceDrop.Load() {
+	if err := ml.checkRequestSize(numRecords, func() int { return logsMarshaler.LogsSize(ld) }); err != nil {
+		ml.obsrep.LogsRefused(ctx, numRecords)
+		return ld, err
+	}
+	if ml.limiter.MustRefuse() {
 		// TODO: actually to be 100% sure that this is "refused" and not "dropped"
 		// 	it is necessary to check the pipeline to see if this is directly connected
 		// 	to a receiver (ie.: a receiver is on the call stack). For now it
@@ -232,120 +190,3 @@ func (ml *memoryLimiter) processLogs(ctx context.Context, ld plog.Logs) (plog.Lo
 	ml.obsrep.LogsAccepted(ctx, numRecords)
 	return ld, nil
 }
-
-func (ml *memoryLimiter) readMemStats() *runtime.MemStats {
-	ms := &runtime.MemStats{}
-	ml.readMemStatsFn(ms)
-	// If proper configured ms.Alloc should be at least ml.ballastSize but since
-	// a misconfiguration is possible check for that here.
-	if ms.Alloc >= ml.ballastSize {
-		ms.Alloc -= ml.ballastSize
-	} else if !ml.configMismatchedLogged {
-		// This indicates misconfiguration. Log it once.
-		ml.configMismatchedLogged = true
-		ml.logger.Warn(`"size_mib" in ballast extension is likely incorrectly configured.`)
-	}
-
-	return ms
-}
-
-// startMonitoring starts a single ticker'd goroutine per instance
-// that will check memory usage every checkInterval period.
-func (ml *memoryLimiter) startMonitoring() {
-	ml.refCounterLock.Lock()
-	defer ml.refCounterLock.Unlock()
-
-	ml.refCounter++
-	if ml.refCounter == 1 {
-		go func() {
-			for range ml.ticker.C {
-				ml.checkMemLimits()
-			}
-		}()
-	}
-}
-
-func memstatToZapField(ms *runtime.MemStats) zap.Field {
-	return zap.Uint64("cur_mem_mib", ms.Alloc/mibBytes)
-}
-
-func (ml *memoryLimiter) doGCandReadMemStats() *runtime.MemStats {
-	runtime.GC()
-	ml.lastGCDone = time.Now()
-	ms := ml.readMemStats()
-	ml.logger.Info("Memory usage after GC.", memstatToZapField(ms))
-	return ms
-}
-
-func (ml *memoryLimiter) checkMemLimits() {
-	ms := ml.readMemStats()
-
-	ml.logger.Debug("Currently used memory.", memstatToZapField(ms))
-
-	if ml.usageChecker.aboveHardLimit(ms) {
-		ml.logger.Warn("Memory usage is above hard limit. Forcing a GC.", memstatToZapField(ms))
-		ms = ml.doGCandReadMemStats()
-	}
-
-	// Remember current dropping state.
-	wasForcingDrop := ml.forceDrop.Load()
-
-	// Check if the memory usage is above the soft limit.
-	mustForceDrop := ml.usageChecker.aboveSoftLimit(ms)
-
-	if wasForcingDrop && !mustForceDrop {
-		// Was previously dropping but enough memory is available now, no need to limit.
-		ml.logger.Info("Memory usage back within limits. Resuming normal operation.", memstatToZapField(ms))
-	}
-
-	if !wasForcingDrop && mustForceDrop {
-		// We are above soft limit, do a GC if it wasn't done recently and see if
-		// it brings memory usage below the soft limit.
-		if time.Since(ml.lastGCDone) > minGCIntervalWhenSoftLimited {
-			ml.logger.Info("Memory usage is above soft limit. Forcing a GC.", memstatToZapField(ms))
-			ms = ml.doGCandReadMemStats()
-			// Check the limit again to see if GC helped.
-			mustForceDrop = ml.usageChecker.aboveSoftLimit(ms)
-		}
-
-		if mustForceDrop {
-			ml.logger.Warn("Memory usage is above soft limit. Dropping data.", memstatToZapField(ms))
-		}
-	}
-
-	ml.forceDrop.Store(mustForceDrop)
-}
-
-type memUsageChecker struct {
-	memAllocLimit uint64
-	memSpikeLimit uint64
-}
-
-func (d memUsageChecker) aboveSoftLimit(ms *runtime.MemStats) bool {
-	return ms.Alloc >= d.memAllocLimit-d.memSpikeLimit
-}
-
-func (d memUsageChecker) aboveHardLimit(ms *runtime.MemStats) bool {
-	return ms.Alloc >= d.memAllocLimit
-}
-
-func newFixedMemUsageChecker(memAllocLimit, memSpikeLimit uint64) (*memUsageChecker, error) {
-	if memSpikeLimit >= memAllocLimit {
-		return nil, errMemSpikeLimitOutOfRange
-	}
-	if memSpikeLimit == 0 {
-		// If spike limit is unspecified use 20% of mem limit.
-		memSpikeLimit = memAllocLimit / 5
-	}
-	return &memUsageChecker{
-		memAllocLimit: memAllocLimit,
-		memSpikeLimit: memSpikeLimit,
-	}, nil
-}
-
-func newPercentageMemUsageChecker(totalMemory uint64, percentageLimit, percentageSpike uint64) (*memUsageChecker, error) {
-	if percentageLimit > 100 || percentageLimit <= 0 || percentageSpike > 100 || percentageSpike <= 0 {
-		return nil, errPercentageLimitOutOfRange
-	}
-	return newFixedMemUsageChecker(percentageLimit*totalMemory/100, percentageSpike*totalMemory/100)
-}
@@ -16,22 +16,21 @@ package memorylimiterprocessor
 
 import (
 	"context"
-	"runtime"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
-	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
-	"go.opentelemetry.io/collector/internal/iruntime"
+	"go.opentelemetry.io/collector/internal/memorylimiter"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -57,7 +56,7 @@ func TestNew(t *testing.T) {
 			args: args{
 				nextConsumer: sink,
 			},
-			wantErr: errCheckIntervalOutOfRange,
+			wantErr: memorylimiter.ErrCheckIntervalOutOfRange,
 		},
 		{
 			name: "zero_memAllocLimit",
@@ -65,7 +64,7 @@ func TestNew(t *testing.T) {
 				nextConsumer:  sink,
 				checkInterval: 100 * time.Millisecond,
 			},
-			wantErr: errLimitOutOfRange,
+			wantErr: memorylimiter.ErrLimitOutOfRange,
 		},
 		{
 			name: "memSpikeLimit_gt_memAllocLimit",
@@ -75,7 +74,7 @@ func TestNew(t *testing.T) {
 				memoryLimitMiB:      1,
 				memorySpikeLimitMiB: 2,
 			},
-			wantErr: errMemSpikeLimitOutOfRange,
+			wantErr: memorylimiter.ErrMemSpikeLimitOutOfRange,
 		},
 		{
 			name: "success",
@@ -104,20 +103,13 @@ func TestNew(t *testing.T) {
 	}
 }
 
-// TestMetricsMemoryPressureResponse manipulates results from querying memory and
-// check expected side effects.
+// TestMetricsMemoryPressureResponse checks that data is dropped while the memory
+// usage is above the soft limit.
 func TestMetricsMemoryPressureResponse(t *testing.T) {
-	var currentMemAlloc uint64
+	limiter := &fakeMemoryChecker{mustRefuse: atomic.NewBool(false)}
 	ml := &memoryLimiter{
-		usageChecker: memUsageChecker{
-			memAllocLimit: 1024,
-		},
-		forceDrop: atomic.NewBool(false),
-		readMemStatsFn: func(ms *runtime.MemStats) {
-			ms.Alloc = currentMemAlloc
-		},
-		obsrep: newObsReport(t),
-		logger: zap.NewNop(),
+		limiter: limiter,
+		obsrep:  newObsReport(t),
 	}
 	mp, err := processorhelper.NewMetricsProcessor(
 		context.Background(),
@@ -134,61 +126,25 @@ func TestMetricsMemoryPressureResponse(t *testing.T) {
 	ctx := context.Background()
 	md := pmetric.NewMetrics()
 
-	// Below memAllocLimit.
-	currentMemAlloc = 800
-	ml.checkMemLimits()
-	assert.NoError(t, mp.ConsumeMetrics(ctx, md))
-
-	// Above memAllocLimit.
-	currentMemAlloc = 1800
-	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, mp.ConsumeMetrics(ctx, md))
-
-	// Check ballast effect
-	ml.ballastSize = 1000
-
-	// Below memAllocLimit accounting for ballast.
-	currentMemAlloc = 800 + ml.ballastSize
-	ml.checkMemLimits()
+	// Below the soft limit.
 	assert.NoError(t, mp.ConsumeMetrics(ctx, md))
 
-	// Above memAllocLimit even accountiing for ballast.
-	currentMemAlloc = 1800 + ml.ballastSize
-	ml.checkMemLimits()
+	// Above the soft limit.
+	limiter.mustRefuse.Store(true)
 	assert.Equal(t, errForcedDrop, mp.ConsumeMetrics(ctx, md))
 
-	// Restore ballast to default.
-	ml.ballastSize = 0
-
-	// Check spike limit
-	ml.usageChecker.memSpikeLimit = 512
-
-	// Below memSpikeLimit.
-	currentMemAlloc = 500
-	ml.checkMemLimits()
+	// Back within the limits.
+	limiter.mustRefuse.Store(false)
 	assert.NoError(t, mp.ConsumeMetrics(ctx, md))
-
-	// Above memSpikeLimit.
-	currentMemAlloc = 550
-	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, mp.ConsumeMetrics(ctx, md))
-
 }
 
-// TestTraceMemoryPressureResponse manipulates results from querying memory and
-// check expected side effects.
+// TestTraceMemoryPressureResponse checks that data is dropped while the memory
+// usage is above the soft limit.
 func TestTraceMemoryPressureResponse(t *testing.T) {
-	var currentMemAlloc uint64
+	limiter := &fakeMemoryChecker{mustRefuse: atomic.NewBool(false)}
 	ml := &memoryLimiter{
-		usageChecker: memUsageChecker{
-			memAllocLimit: 1024,
-		},
-		forceDrop: atomic.NewBool(false),
-		readMemStatsFn: func(ms *runtime.MemStats) {
-			ms.Alloc = currentMemAlloc
-		},
-		obsrep: newObsReport(t),
-		logger: zap.NewNop(),
+		limiter: limiter,
+		obsrep:  newObsReport(t),
 	}
 	tp, err := processorhelper.NewTracesProcessor(
 		context.Background(),
@@ -205,61 +161,25 @@ func TestTraceMemoryPressureResponse(t *testing.T) {
 	ctx := context.Background()
 	td := ptrace.NewTraces()
 
-	// Below memAllocLimit.
-	currentMemAlloc = 800
-	ml.checkMemLimits()
+	// Below the soft limit.
 	assert.NoError(t, tp.ConsumeTraces(ctx, td))
 
-	// Above memAllocLimit.
-	currentMemAlloc = 1800
-	ml.checkMemLimits()
+	// Above the soft limit.
+	limiter.mustRefuse.Store(true)
 	assert.Equal(t, errForcedDrop, tp.ConsumeTraces(ctx, td))
 
-	// Check ballast effect
-	ml.ballastSize = 1000
-
-	// Below memAllocLimit accounting for ballast.
-	currentMemAlloc = 800 + ml.ballastSize
-	ml.checkMemLimits()
-	assert.NoError(t, tp.ConsumeTraces(ctx, td))
-
-	// Above memAllocLimit even accountiing for ballast.
-	currentMemAlloc = 1800 + ml.ballastSize
-	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, tp.ConsumeTraces(ctx, td))
-
-	// Restore ballast to default.
-	ml.ballastSize = 0
-
-	// Check spike limit
-	ml.usageChecker.memSpikeLimit = 512
-
-	// Below memSpikeLimit.
-	currentMemAlloc = 500
-	ml.checkMemLimits()
+	// Back within the limits.
+	limiter.mustRefuse.Store(false)
 	assert.NoError(t, tp.ConsumeTraces(ctx, td))
-
-	// Above memSpikeLimit.
-	currentMemAlloc = 550
-	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, tp.ConsumeTraces(ctx, td))
-
 }
 
-// TestLogMemoryPressureResponse manipulates results from querying memory and
-// check expected side effects.
+// TestLogMemoryPressureResponse checks that data is dropped while the memory
+// usage is above the soft limit.
 func TestLogMemoryPressureResponse(t *testing.T) {
-	var currentMemAlloc uint64
+	limiter := &fakeMemoryChecker{mustRefuse: atomic.NewBool(false)}
 	ml := &memoryLimiter{
-		usageChecker: memUsageChecker{
-			memAllocLimit: 1024,
-		},
-		forceDrop: atomic.NewBool(false),
-		readMemStatsFn: func(ms *runtime.MemStats) {
-			ms.Alloc = currentMemAlloc
-		},
-		obsrep: newObsReport(t),
-		logger: zap.NewNop(),
+		limiter: limiter,
+		obsrep:  newObsReport(t),
 	}
 	lp, err := processorhelper.NewLogsProcessor(
 		context.Background(),
@@ -276,174 +196,112 @@ func TestLogMemoryPressureResponse(t *testing.T) {
 	ctx := context.Background()
 	ld := plog.NewLogs()
 
-	// Below memAllocLimit.
-	currentMemAlloc = 800
-	ml.checkMemLimits()
+	// Below the soft limit.
 	assert.NoError(t, lp.ConsumeLogs(ctx, ld))
 
-	// Above memAllocLimit.
-	currentMemAlloc = 1800
-	ml.checkMemLimits()
+	// Above the soft limit.
+	limiter.mustRefuse.Store(true)
 	assert.Equal(t, errForcedDrop, lp.ConsumeLogs(ctx, ld))
 
-	// Check ballast effect
-	ml.ballastSize = 1000
-
-	// Below memAllocLimit accounting for ballast.
-	currentMemAlloc = 800 + ml.ballastSize
-	ml.checkMemLimits()
+	// Back within the limits.
+	limiter.mustRefuse.Store(false)
 	assert.NoError(t, lp.ConsumeLogs(ctx, ld))
-
-	// Above memAllocLimit even accountiing for ballast.
-	currentMemAlloc = 1800 + ml.ballastSize
-	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, lp.ConsumeLogs(ctx, ld))
-
-	// Restore ballast to default.
-	ml.ballastSize = 0
-
-	// Check spike limit
-	ml.usageChecker.memSpikeLimit = 512
-
-	// Below memSpikeLimit.
-	currentMemAlloc = 500
-	ml.checkMemLimits()
-	assert.NoError(t, lp.ConsumeLogs(ctx, ld))
-
-	// Above memSpikeLimit.
-	currentMemAlloc = 550
-	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, lp.ConsumeLogs(ctx, ld))
 }
 
-func TestGetDecision(t *testing.T) {
-	t.Run("fixed_limit", func(t *testing.T) {
-		d, err := getMemUsageChecker(&Config{MemoryLimitMiB: 100, MemorySpikeLimitMiB: 20}, zap.NewNop())
-		require.NoError(t, err)
-		assert.Equal(t, &memUsageChecker{
-			memAllocLimit: 100 * mibBytes,
-			memSpikeLimit: 20 * mibBytes,
-		}, d)
-	})
-	t.Run("fixed_limit_error", func(t *testing.T) {
-		d, err := getMemUsageChecker(&Config{MemoryLimitMiB: 20, MemorySpikeLimitMiB: 100}, zap.NewNop())
-		require.Error(t, err)
-		assert.Nil(t, d)
-	})
-
-	t.Cleanup(func() {
-		getMemoryFn = iruntime.TotalMemory
-	})
-	getMemoryFn = func() (uint64, error) {
-		return 100 * mibBytes, nil
+func TestMaxRequestSize(t *testing.T) {
+	newTraces := func(numSpans int) ptrace.Traces {
+		td := ptrace.NewTraces()
+		spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+		for i := 0; i < numSpans; i++ {
+			spans.AppendEmpty().SetName("span")
+		}
+		return td
+	}
+	newMetrics := func(numPoints int) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints()
+		for i := 0; i < numPoints; i++ {
+			dps.AppendEmpty().SetIntValue(int64(i))
+		}
+		return md
+	}
+	newLogs := func(numRecords int) plog.Logs {
+		ld := plog.NewLogs()
+		lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+		for i := 0; i < numRecords; i++ {
+			lrs.AppendEmpty().Body().SetStr("log")
+		}
+		return ld
 	}
-	t.Run("percentage_limit", func(t *testing.T) {
-		d, err := getMemUsageChecker(&Config{MemoryLimitPercentage: 50, MemorySpikePercentage: 10}, zap.NewNop())
-		require.NoError(t, err)
-		assert.Equal(t, &memUsageChecker{
-			memAllocLimit: 50 * mibBytes,
-			memSpikeLimit: 10 * mibBytes,
-		}, d)
-	})
-	t.Run("percentage_limit_error", func(t *testing.T) {
-		d, err := getMemUsageChecker(&Config{MemoryLimitPercentage: 101, MemorySpikePercentage: 10}, zap.NewNop())
-		require.Error(t, err)
-		assert.Nil(t, d)
-		d, err = getMemUsageChecker(&Config{MemoryLimitPercentage: 99, MemorySpikePercentage: 101}, zap.NewNop())
-		require.Error(t, err)
-		assert.Nil(t, d)
-	})
-}
-
-func TestDropDecision(t *testing.T) {
-	decison1000Limit30Spike30, err := newPercentageMemUsageChecker(1000, 60, 30)
-	require.NoError(t, err)
-	decison1000Limit60Spike50, err := newPercentageMemUsageChecker(1000, 60, 50)
-	require.NoError(t, err)
-	decison1000Limit40Spike20, err := newPercentageMemUsageChecker(1000, 40, 20)
-	require.NoError(t, err)
-	decison1000Limit40Spike60, err := newPercentageMemUsageChecker(1000, 40, 60)
-	require.Error(t, err)
-	assert.Nil(t, decison1000Limit40Spike60)
 
 	tests := []struct {
-		name         string
-		usageChecker memUsageChecker
-		ms           *runtime.MemStats
-		shouldDrop   bool
+		name            string
+		maxRequestItems int
+		maxRequestBytes int
+		numItems        int
+		wantErr         string
 	}{
 		{
-			name:         "should drop over limit",
-			usageChecker: *decison1000Limit30Spike30,
-			ms:           &runtime.MemStats{Alloc: 600},
-			shouldDrop:   true,
+			name:     "no limits",
+			numItems: 10,
 		},
 		{
-			name:         "should not drop",
-			usageChecker: *decison1000Limit30Spike30,
-			ms:           &runtime.MemStats{Alloc: 100},
-			shouldDrop:   false,
+			name:            "below items limit",
+			maxRequestItems: 10,
+			numItems:        10,
 		},
 		{
-			name: "should not drop spike, fixed usageChecker",
-			usageChecker: memUsageChecker{
-				memAllocLimit: 600,
-				memSpikeLimit: 500,
-			},
-			ms:         &runtime.MemStats{Alloc: 300},
-			shouldDrop: true,
+			name:            "above items limit",
+			maxRequestItems: 10,
+			numItems:        11,
+			wantErr:         "request of 11 items exceeds the max_request_size of 10 items",
 		},
 		{
-			name:         "should drop, spike, percentage usageChecker",
-			usageChecker: *decison1000Limit60Spike50,
-			ms:           &runtime.MemStats{Alloc: 300},
-			shouldDrop:   true,
+			name:            "below size limit",
+			maxRequestBytes: mibBytes,
+			numItems:        10,
 		},
 		{
-			name:         "should drop, spike, percentage usageChecker",
-			usageChecker: *decison1000Limit40Spike20,
-			ms:           &runtime.MemStats{Alloc: 250},
-			shouldDrop:   true,
+			name:            "above size limit",
+			maxRequestBytes: 100,
+			numItems:        100,
+			wantErr:         "exceeds the max_request_size of 100 bytes",
 		},
 	}
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			shouldDrop := test.usageChecker.aboveSoftLimit(test.ms)
-			assert.Equal(t, test.shouldDrop, shouldDrop)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ml := &memoryLimiter{
+				limiter:         &fakeMemoryChecker{mustRefuse: atomic.NewBool(false)},
+				maxRequestItems: tt.maxRequestItems,
+				maxRequestBytes: tt.maxRequestBytes,
+				obsrep:          newObsReport(t),
+			}
+			ctx := context.Background()
+			_, tErr := ml.processTraces(ctx, newTraces(tt.numItems))
+			_, mErr := ml.processMetrics(ctx, newMetrics(tt.numItems))
+			_, lErr := ml.processLogs(ctx, newLogs(tt.numItems))
+			for _, err := range []error{tErr, mErr, lErr} {
+				if tt.wantErr == "" {
+					assert.NoError(t, err)
+					continue
+				}
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.True(t, consumererror.IsPermanent(err))
+			}
 		})
 	}
 }
 
-func TestBallastSize(t *testing.T) {
-	cfg := createDefaultConfig().(*Config)
-	cfg.CheckInterval = 10 * time.Second
-	cfg.MemoryLimitMiB = 1024
-	got, err := newMemoryLimiter(componenttest.NewNopProcessorCreateSettings(), cfg)
-	require.NoError(t, err)
-	require.NoError(t, got.start(context.Background(), &host{ballastSize: 113}))
-	assert.Equal(t, uint64(113), got.ballastSize)
-	require.NoError(t, got.shutdown(context.Background()))
-}
-
-type host struct {
-	ballastSize uint64
-	component.Host
-}
-
-func (h *host) GetExtensions() map[component.ID]component.Component {
-	ret := make(map[component.ID]component.Component)
-	ret[component.NewID("ballast")] = &ballastExtension{ballastSize: h.ballastSize}
-	return ret
-}
-
-type ballastExtension struct {
-	ballastSize uint64
+// fakeMemoryChecker refuses data on demand.
+type fakeMemoryChecker struct {
 	component.StartFunc
 	component.ShutdownFunc
+	mustRefuse *atomic.Bool
 }
 
-func (be *ballastExtension) GetBallastSize() uint64 {
-	return be.ballastSize
+func (f *fakeMemoryChecker) MustRefuse() bool {
+	return f.mustRefuse.Load()
 }
 
 func newObsReport(t *testing.T) *obsreport.Processor {
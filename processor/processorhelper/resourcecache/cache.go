@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcecache // import "go.opentelemetry.io/collector/processor/processorhelper/resourcecache"
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	cacheScope = "go.opentelemetry.io/collector/processor/processorhelper/resourcecache"
+
+	hitsMetricName      = "processor_resource_cache_hits"
+	missesMetricName    = "processor_resource_cache_misses"
+	evictionsMetricName = "processor_resource_cache_evictions"
+
+	processorAttribute = "processor"
+	reasonAttribute    = "reason"
+
+	reasonSize    = "size"
+	reasonExpired = "expired"
+)
+
+// Config defines the settings of a Cache, meant to be embedded in the configuration of processors.
+type Config struct {
+	// MaxEntries is the maximum number of resources in the cache. When full, the least recently used resource is
+	// evicted.
+	MaxEntries int `mapstructure:"max_entries"`
+
+	// TTL is how long a resource stays in the cache after it is added. Zero keeps the resources until they are
+	// evicted because the cache is full.
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+// NewDefaultConfig returns the default settings of a Cache.
+func NewDefaultConfig() Config {
+	return Config{
+		MaxEntries: 1000,
+		TTL:        5 * time.Minute,
+	}
+}
+
+// Validate checks if the configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MaxEntries <= 0 {
+		return errors.New("max_entries must be positive")
+	}
+	if cfg.TTL < 0 {
+		return errors.New("ttl must not be negative")
+	}
+	return nil
+}
+
+// Cache is a least recently used cache of values of type V, keyed by the fingerprint of the resources, whose
+// entries expire after Config.TTL. The hits, misses and evictions are recorded in the
+// processor_resource_cache_hits, processor_resource_cache_misses and processor_resource_cache_evictions metrics.
+// It is safe for concurrent use.
+type Cache[V any] struct {
+	maxEntries int
+	ttl        time.Duration
+	now        func() time.Time
+
+	hits      syncint64.Counter
+	misses    syncint64.Counter
+	evictions syncint64.Counter
+	attrs     []attribute.KeyValue
+	// sizeAttrs and expiredAttrs are the attributes of the evictions for each reason.
+	sizeAttrs    []attribute.KeyValue
+	expiredAttrs []attribute.KeyValue
+
+	mu      sync.Mutex
+	entries map[Key]*list.Element
+	lru     *list.List
+}
+
+type entry[V any] struct {
+	key     Key
+	value   V
+	expires time.Time
+}
+
+// New creates a Cache for the processor created with the given settings.
+func New[V any](cfg Config, set component.ProcessorCreateSettings) (*Cache[V], error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	meter := set.MeterProvider.Meter(cacheScope)
+	hits, err := meter.SyncInt64().Counter(
+		hitsMetricName,
+		instrument.WithDescription("Number of lookups of resources found in the cache."),
+		instrument.WithUnit(unit.Dimensionless),
+	)
+	if err != nil {
+		return nil, err
+	}
+	misses, err := meter.SyncInt64().Counter(
+		missesMetricName,
+		instrument.WithDescription("Number of lookups of resources missing from the cache."),
+		instrument.WithUnit(unit.Dimensionless),
+	)
+	if err != nil {
+		return nil, err
+	}
+	evictions, err := meter.SyncInt64().Counter(
+		evictionsMetricName,
+		instrument.WithDescription("Number of resources removed from the cache because it was full or they expired."),
+		instrument.WithUnit(unit.Dimensionless),
+	)
+	if err != nil {
+		return nil, err
+	}
+	processor := attribute.String(processorAttribute, set.ID.String())
+	return &Cache[V]{
+		maxEntries:   cfg.MaxEntries,
+		ttl:          cfg.TTL,
+		now:          time.Now,
+		hits:         hits,
+		misses:       misses,
+		evictions:    evictions,
+		attrs:        []attribute.KeyValue{processor},
+		sizeAttrs:    []attribute.KeyValue{processor, attribute.String(reasonAttribute, reasonSize)},
+		expiredAttrs: []attribute.KeyValue{processor, attribute.String(reasonAttribute, reasonExpired)},
+		entries:      make(map[Key]*list.Element),
+		lru:          list.New(),
+	}, nil
+}
+
+// Get returns the value cached for the resource, and whether it was found.
+func (c *Cache[V]) Get(ctx context.Context, res pcommon.Resource) (V, bool) {
+	return c.GetKey(ctx, Fingerprint(res))
+}
+
+// GetKey returns the value cached for the resource with the given fingerprint, and whether it was found.
+func (c *Cache[V]) GetKey(ctx context.Context, key Key) (V, bool) {
+	var value V
+	c.mu.Lock()
+	elem, ok := c.entries[key]
+	if ok && c.expired(elem.Value.(*entry[V])) {
+		c.remove(elem)
+		c.mu.Unlock()
+		c.evictions.Add(ctx, 1, c.expiredAttrs...)
+		ok = false
+	} else {
+		if ok {
+			c.lru.MoveToFront(elem)
+			// The entry may be updated by PutKey once the lock is released.
+			value = elem.Value.(*entry[V]).value
+		}
+		c.mu.Unlock()
+	}
+
+	if !ok {
+		c.misses.Add(ctx, 1, c.attrs...)
+		return value, false
+	}
+	c.hits.Add(ctx, 1, c.attrs...)
+	return value, true
+}
+
+// Put caches the value for the resource, replacing any value already cached.
+func (c *Cache[V]) Put(ctx context.Context, res pcommon.Resource, value V) {
+	c.PutKey(ctx, Fingerprint(res), value)
+}
+
+// PutKey caches the value for the resource with the given fingerprint, replacing any value already cached.
+func (c *Cache[V]) PutKey(ctx context.Context, key Key, value V) {
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = c.now().Add(c.ttl)
+	}
+
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		e := elem.Value.(*entry[V])
+		e.value = value
+		e.expires = expires
+		c.lru.MoveToFront(elem)
+		c.mu.Unlock()
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&entry[V]{key: key, value: value, expires: expires})
+	evicted := 0
+	for c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+		evicted++
+	}
+	c.mu.Unlock()
+
+	if evicted > 0 {
+		c.evictions.Add(ctx, int64(evicted), c.sizeAttrs...)
+	}
+}
+
+// GetOrLoad returns the value cached for the resource. When it is not found, the value returned by load is
+// cached and returned. Errors returned by load are not cached. Concurrent lookups of the same missing resource
+// may call load more than once.
+func (c *Cache[V]) GetOrLoad(ctx context.Context, res pcommon.Resource, load func(context.Context, pcommon.Resource) (V, error)) (V, error) {
+	key := Fingerprint(res)
+	if value, ok := c.GetKey(ctx, key); ok {
+		return value, nil
+	}
+	value, err := load(ctx, res)
+	if err != nil {
+		return value, err
+	}
+	c.PutKey(ctx, key, value)
+	return value, nil
+}
+
+// Len returns the number of resources in the cache, including the expired ones not evicted yet.
+func (c *Cache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+func (c *Cache[V]) expired(e *entry[V]) bool {
+	return !e.expires.IsZero() && !c.now().Before(e.expires)
+}
+
+func (c *Cache[V]) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*entry[V]).key)
+}
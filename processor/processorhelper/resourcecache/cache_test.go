@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcecache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func newResource(pod string) pcommon.Resource {
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("k8s.pod.name", pod)
+	return res
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := NewDefaultConfig()
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MaxEntries = 0
+	assert.EqualError(t, cfg.Validate(), "max_entries must be positive")
+
+	cfg = NewDefaultConfig()
+	cfg.TTL = -time.Second
+	assert.EqualError(t, cfg.Validate(), "ttl must not be negative")
+
+	_, err := New[string](Config{}, componenttest.NewNopProcessorCreateSettings())
+	assert.Error(t, err)
+}
+
+func TestCache(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	set := componenttest.NewNopProcessorCreateSettings()
+	set.ID = component.NewID("enrich")
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	cache, err := New[string](Config{MaxEntries: 2, TTL: time.Minute}, set)
+	require.NoError(t, err)
+	now := time.Unix(0, 0)
+	cache.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	_, ok := cache.Get(ctx, newResource("a"))
+	assert.False(t, ok)
+
+	cache.Put(ctx, newResource("a"), "value-a")
+	cache.Put(ctx, newResource("b"), "value-b")
+	v, ok := cache.Get(ctx, newResource("a"))
+	assert.True(t, ok)
+	assert.Equal(t, "value-a", v)
+
+	// "b" is the least recently used resource.
+	cache.Put(ctx, newResource("c"), "value-c")
+	assert.Equal(t, 2, cache.Len())
+	_, ok = cache.Get(ctx, newResource("b"))
+	assert.False(t, ok)
+
+	// Replacing a value does not evict anything.
+	cache.Put(ctx, newResource("c"), "value-c2")
+	v, ok = cache.Get(ctx, newResource("c"))
+	assert.True(t, ok)
+	assert.Equal(t, "value-c2", v)
+
+	now = now.Add(time.Minute)
+	_, ok = cache.Get(ctx, newResource("a"))
+	assert.False(t, ok)
+	assert.Equal(t, 1, cache.Len())
+
+	rm, err := reader.Collect(ctx)
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+	processor := attribute.String(processorAttribute, "enrich")
+	values := map[string]map[attribute.Set]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		sum, ok := m.Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		values[m.Name] = map[attribute.Set]int64{}
+		for _, dp := range sum.DataPoints {
+			values[m.Name][dp.Attributes] = dp.Value
+		}
+	}
+	assert.Equal(t, map[string]map[attribute.Set]int64{
+		hitsMetricName:   {attribute.NewSet(processor): 2},
+		missesMetricName: {attribute.NewSet(processor): 3},
+		evictionsMetricName: {
+			attribute.NewSet(processor, attribute.String(reasonAttribute, reasonSize)):    1,
+			attribute.NewSet(processor, attribute.String(reasonAttribute, reasonExpired)): 1,
+		},
+	}, values)
+}
+
+func TestCacheWithoutTTL(t *testing.T) {
+	cache, err := New[int](Config{MaxEntries: 1}, componenttest.NewNopProcessorCreateSettings())
+	require.NoError(t, err)
+	now := time.Unix(0, 0)
+	cache.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	cache.Put(ctx, newResource("a"), 1)
+	now = now.Add(24 * time.Hour)
+	v, ok := cache.Get(ctx, newResource("a"))
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+}
+
+func TestCacheGetOrLoad(t *testing.T) {
+	cache, err := New[string](NewDefaultConfig(), componenttest.NewNopProcessorCreateSettings())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	loads := 0
+	load := func(_ context.Context, res pcommon.Resource) (string, error) {
+		loads++
+		pod, _ := res.Attributes().Get("k8s.pod.name")
+		return "node-of-" + pod.Str(), nil
+	}
+	for i := 0; i < 3; i++ {
+		v, err := cache.GetOrLoad(ctx, newResource("a"), load)
+		require.NoError(t, err)
+		assert.Equal(t, "node-of-a", v)
+	}
+	assert.Equal(t, 1, loads)
+
+	// Errors are not cached.
+	errLoad := errors.New("lookup failed")
+	_, err = cache.GetOrLoad(ctx, newResource("b"), func(context.Context, pcommon.Resource) (string, error) {
+		return "", errLoad
+	})
+	assert.ErrorIs(t, err, errLoad)
+	_, ok := cache.Get(ctx, newResource("b"))
+	assert.False(t, ok)
+}
+
+func TestCacheConcurrentGetPut(t *testing.T) {
+	cache, err := New[int](NewDefaultConfig(), componenttest.NewNopProcessorCreateSettings())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	key := Fingerprint(newResource("a"))
+	cache.PutKey(ctx, key, 0)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 1000; i++ {
+			cache.PutKey(ctx, key, i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			_, ok := cache.GetKey(ctx, key)
+			assert.True(t, ok)
+		}
+	}()
+	wg.Wait()
+
+	v, ok := cache.GetKey(ctx, key)
+	assert.True(t, ok)
+	assert.Equal(t, 1000, v)
+}
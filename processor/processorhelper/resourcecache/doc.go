@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resourcecache provides a size bounded cache with expiration keyed by resource, for the processors
+// repeatedly resolving data for the same resources, e.g. the metadata of a Kubernetes pod or the location of an
+// IP address.
+package resourcecache // import "go.opentelemetry.io/collector/processor/processorhelper/resourcecache"
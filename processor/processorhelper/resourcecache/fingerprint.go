@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcecache // import "go.opentelemetry.io/collector/processor/processorhelper/resourcecache"
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Key is the fingerprint of a resource.
+type Key [16]byte
+
+// Fingerprint returns the fingerprint of the attributes of the resource. Resources with the same attributes have
+// the same fingerprint, whatever the order of the attributes.
+func Fingerprint(res pcommon.Resource) Key {
+	attrs := res.Attributes()
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+
+	h := fnv.New128a()
+	var buf [8]byte
+	writeString := func(s string) {
+		// Length prefixes avoid collisions between e.g. {"ab": "c"} and {"a": "bc"}.
+		binary.LittleEndian.PutUint64(buf[:], uint64(len(s)))
+		_, _ = h.Write(buf[:])
+		_, _ = h.Write([]byte(s))
+	}
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		writeString(k)
+		writeString(v.Type().String())
+		writeString(v.AsString())
+	}
+
+	var key Key
+	h.Sum(key[:0])
+	return key
+}
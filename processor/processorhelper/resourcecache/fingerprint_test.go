@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcecache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestFingerprint(t *testing.T) {
+	res1 := pcommon.NewResource()
+	res1.Attributes().PutStr("service.name", "checkout")
+	res1.Attributes().PutStr("k8s.pod.name", "checkout-1")
+
+	res2 := pcommon.NewResource()
+	res2.Attributes().PutStr("k8s.pod.name", "checkout-1")
+	res2.Attributes().PutStr("service.name", "checkout")
+	assert.Equal(t, Fingerprint(res1), Fingerprint(res2))
+
+	res3 := pcommon.NewResource()
+	res3.Attributes().PutStr("service.name", "checkout")
+	res3.Attributes().PutStr("k8s.pod.name", "checkout-2")
+	assert.NotEqual(t, Fingerprint(res1), Fingerprint(res3))
+
+	res4 := pcommon.NewResource()
+	res4.Attributes().PutStr("ab", "c")
+	res5 := pcommon.NewResource()
+	res5.Attributes().PutStr("a", "bc")
+	assert.NotEqual(t, Fingerprint(res4), Fingerprint(res5))
+
+	res6 := pcommon.NewResource()
+	res6.Attributes().PutInt("port", 8080)
+	res7 := pcommon.NewResource()
+	res7.Attributes().PutStr("port", "8080")
+	assert.NotEqual(t, Fingerprint(res6), Fingerprint(res7))
+
+	assert.Equal(t, Fingerprint(pcommon.NewResource()), Fingerprint(pcommon.NewResource()))
+}
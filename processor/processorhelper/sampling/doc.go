@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sampling implements consistent probability sampling as specified by OpenTelemetry,
+// so that processors and connectors sampling the same data make consistent decisions.
+//
+// Every item has a 56-bit randomness value, taken from the R-value of the OpenTelemetry tracestate
+// or from the least significant 7 bytes of the trace ID. An item is sampled if its randomness is
+// greater than or equal to the rejection threshold, whose T-value is recorded in the tracestate.
+package sampling // import "go.opentelemetry.io/collector/processor/processorhelper/sampling"
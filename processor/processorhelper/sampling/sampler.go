@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "go.opentelemetry.io/collector/processor/processorhelper/sampling"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// SampleSpan returns whether the span is sampled with the given threshold. The randomness of the
+// span is the R-value of its tracestate, if any, otherwise its trace ID. If the span was already
+// sampled with a higher threshold by a previous stage, that threshold is used instead.
+// A sampled span has the threshold recorded in its tracestate, so that its adjusted count is known
+// downstream.
+func SampleSpan(span ptrace.Span, threshold Threshold) (bool, error) {
+	ots, err := ParseOpenTelemetryTraceState(span.TraceState().AsRaw())
+	if err != nil {
+		return false, err
+	}
+
+	randomness, ok := ots.Randomness()
+	if !ok {
+		randomness = TraceIDToRandomness(span.TraceID())
+	}
+	if existing, ok := ots.Threshold(); ok && ThresholdLessThan(threshold, existing) {
+		threshold = existing
+	}
+	if !threshold.ShouldSample(randomness) {
+		return false, nil
+	}
+
+	if existing, ok := ots.Threshold(); !ok || existing != threshold {
+		ots.SetThreshold(threshold)
+		span.TraceState().FromRaw(UpdateTraceState(span.TraceState().AsRaw(), ots))
+	}
+	return true, nil
+}
+
+// SampleLogRecord returns whether the log record is sampled with the given threshold. The randomness
+// of the log record is its trace ID, so that logs are sampled consistently with their trace, or a hash
+// of its body if it has no trace ID.
+func SampleLogRecord(lr plog.LogRecord, threshold Threshold) bool {
+	if traceID := lr.TraceID(); !traceID.IsEmpty() {
+		return threshold.ShouldSample(TraceIDToRandomness(traceID))
+	}
+	return threshold.ShouldSample(BytesToRandomness([]byte(lr.Body().AsString())))
+}
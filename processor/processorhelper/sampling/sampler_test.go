@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestSampleSpan(t *testing.T) {
+	half, err := TValueToThreshold("8")
+	require.NoError(t, err)
+	quarter, err := TValueToThreshold("c")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		traceID            pcommon.TraceID
+		traceState         string
+		threshold          Threshold
+		expectedSampled    bool
+		expectedTraceState string
+	}{
+		{
+			name:               "sampled by trace id",
+			traceID:            [16]byte{8: 0xff, 9: 0xff},
+			threshold:          half,
+			expectedSampled:    true,
+			expectedTraceState: "ot=th:8",
+		},
+		{
+			name:               "not sampled by trace id",
+			traceID:            [16]byte{8: 0xff, 9: 0x10},
+			threshold:          half,
+			expectedSampled:    false,
+			expectedTraceState: "",
+		},
+		{
+			name:               "sampled by r-value",
+			traceID:            [16]byte{},
+			traceState:         "ot=rv:c0000000000000,vendor=x",
+			threshold:          half,
+			expectedSampled:    true,
+			expectedTraceState: "ot=th:8;rv:c0000000000000,vendor=x",
+		},
+		{
+			name:               "previous stage threshold is kept",
+			traceID:            [16]byte{8: 0xff, 9: 0xff},
+			traceState:         "ot=th:c",
+			threshold:          half,
+			expectedSampled:    true,
+			expectedTraceState: "ot=th:c",
+		},
+		{
+			name:               "higher threshold replaces the previous one",
+			traceID:            [16]byte{8: 0xff, 9: 0xff},
+			traceState:         "ot=th:8",
+			threshold:          quarter,
+			expectedSampled:    true,
+			expectedTraceState: "ot=th:c",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span := ptrace.NewSpan()
+			span.SetTraceID(tt.traceID)
+			span.TraceState().FromRaw(tt.traceState)
+
+			sampled, err := SampleSpan(span, tt.threshold)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSampled, sampled)
+			if tt.expectedSampled {
+				assert.Equal(t, tt.expectedTraceState, span.TraceState().AsRaw())
+			} else {
+				assert.Equal(t, tt.traceState, span.TraceState().AsRaw())
+			}
+		})
+	}
+}
+
+func TestSampleSpanInvalidTraceState(t *testing.T) {
+	span := ptrace.NewSpan()
+	span.TraceState().FromRaw("ot=th:xyz")
+	_, err := SampleSpan(span, AlwaysSampleThreshold)
+	assert.Error(t, err)
+}
+
+func TestSampleLogRecord(t *testing.T) {
+	half, err := TValueToThreshold("8")
+	require.NoError(t, err)
+
+	lr := plog.NewLogRecord()
+	lr.SetTraceID([16]byte{9: 0xff})
+	assert.True(t, SampleLogRecord(lr, half))
+	lr.SetTraceID([16]byte{9: 0x7f})
+	assert.False(t, SampleLogRecord(lr, half))
+
+	// Without trace ID the decision depends on the body, and is the same for the same body.
+	lr = plog.NewLogRecord()
+	lr.Body().SetStr("message")
+	assert.Equal(t, half.ShouldSample(BytesToRandomness([]byte("message"))), SampleLogRecord(lr, half))
+	assert.True(t, SampleLogRecord(lr, AlwaysSampleThreshold))
+}
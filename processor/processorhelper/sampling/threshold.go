@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "go.opentelemetry.io/collector/processor/processorhelper/sampling"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	// numHexDigits is the number of hex digits of a 56-bit value.
+	numHexDigits = 14
+
+	// MaxAdjustedCount is the number of distinct randomness values, the adjusted count of an item
+	// sampled with the smallest representable probability.
+	MaxAdjustedCount = uint64(1) << 56
+
+	// MinSamplingProbability is the smallest representable sampling probability.
+	MinSamplingProbability = 1.0 / float64(MaxAdjustedCount)
+
+	maxRandomness = MaxAdjustedCount - 1
+)
+
+var (
+	errProbabilityRange = errors.New("sampling probability out of the range [2^-56, 1]")
+	errTValueSyntax     = errors.New("t-value must have between 1 and 14 hex digits")
+	errRValueSyntax     = errors.New("r-value must have exactly 14 hex digits")
+)
+
+// AlwaysSampleThreshold is the threshold that samples every item.
+var AlwaysSampleThreshold = Threshold{}
+
+// Threshold is the rejection threshold of consistent probability sampling:
+// an item is sampled if its randomness is greater than or equal to the threshold.
+type Threshold struct {
+	// unsigned is in the range [0, MaxAdjustedCount).
+	unsigned uint64
+}
+
+// ProbabilityToThreshold returns the threshold that samples items with the given probability.
+func ProbabilityToThreshold(probability float64) (Threshold, error) {
+	if !(probability >= MinSamplingProbability && probability <= 1) {
+		return Threshold{}, errProbabilityRange
+	}
+	// Scaling by a power of two is exact, unlike 1 - probability which loses the low bits of
+	// small probabilities, so the only rounding is to the nearest adjusted count.
+	sampled := uint64(math.Round(math.Ldexp(probability, 56)))
+	return Threshold{unsigned: MaxAdjustedCount - sampled}, nil
+}
+
+// TValueToThreshold parses the T-value encoding of a threshold: up to 14 hex digits, with
+// trailing zeros removed.
+func TValueToThreshold(s string) (Threshold, error) {
+	if len(s) == 0 || len(s) > numHexDigits {
+		return Threshold{}, errTValueSyntax
+	}
+	unsigned, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return Threshold{}, fmt.Errorf("t-value: %w", err)
+	}
+	// The missing digits are trailing zeros.
+	return Threshold{unsigned: unsigned << (4 * (numHexDigits - len(s)))}, nil
+}
+
+// TValue returns the T-value encoding of the threshold.
+func (t Threshold) TValue() string {
+	if t.unsigned == 0 {
+		return "0"
+	}
+	return strings.TrimRight(fmt.Sprintf("%0*x", numHexDigits, t.unsigned), "0")
+}
+
+// Probability returns the sampling probability of the threshold.
+func (t Threshold) Probability() float64 {
+	return float64(MaxAdjustedCount-t.unsigned) / float64(MaxAdjustedCount)
+}
+
+// AdjustedCount returns the number of items that every sampled item represents.
+func (t Threshold) AdjustedCount() float64 {
+	return 1 / t.Probability()
+}
+
+// ShouldSample returns whether an item with the given randomness is sampled.
+func (t Threshold) ShouldSample(r Randomness) bool {
+	return r.unsigned >= t.unsigned
+}
+
+// ThresholdLessThan returns whether a samples more items than b.
+func ThresholdLessThan(a, b Threshold) bool {
+	return a.unsigned < b.unsigned
+}
+
+// Randomness is the 56-bit random value of an item that sampling decisions are based on.
+type Randomness struct {
+	// unsigned is in the range [0, MaxAdjustedCount).
+	unsigned uint64
+}
+
+// TraceIDToRandomness returns the randomness of the least significant 7 bytes of the trace ID.
+func TraceIDToRandomness(id pcommon.TraceID) Randomness {
+	return Randomness{unsigned: binary.BigEndian.Uint64(id[8:]) & maxRandomness}
+}
+
+// BytesToRandomness returns a randomness derived from a hash of the given bytes, for items
+// which have no trace ID. Equal bytes always have the same randomness.
+func BytesToRandomness(b []byte) Randomness {
+	h := fnv.New64a()
+	_, _ = h.Write(b)
+	return Randomness{unsigned: h.Sum64() & maxRandomness}
+}
+
+// RValueToRandomness parses the R-value encoding of a randomness: exactly 14 hex digits.
+func RValueToRandomness(s string) (Randomness, error) {
+	if len(s) != numHexDigits {
+		return Randomness{}, errRValueSyntax
+	}
+	unsigned, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return Randomness{}, fmt.Errorf("r-value: %w", err)
+	}
+	return Randomness{unsigned: unsigned}, nil
+}
+
+// RValue returns the R-value encoding of the randomness.
+func (r Randomness) RValue() string {
+	return fmt.Sprintf("%0*x", numHexDigits, r.unsigned)
+}
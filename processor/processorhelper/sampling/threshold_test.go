@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestProbabilityToThreshold(t *testing.T) {
+	tests := []struct {
+		probability float64
+		tvalue      string
+	}{
+		{probability: 1, tvalue: "0"},
+		{probability: 0.5, tvalue: "8"},
+		{probability: 0.25, tvalue: "c"},
+		{probability: 0.75, tvalue: "4"},
+		{probability: 0.0625, tvalue: "f"},
+		{probability: MinSamplingProbability, tvalue: "ffffffffffffff"},
+		// Near 2^-56, the probabilities are rounded to the nearest multiple of 2^-56.
+		{probability: 1.4 * MinSamplingProbability, tvalue: "ffffffffffffff"},
+		{probability: 1.6 * MinSamplingProbability, tvalue: "fffffffffffffe"},
+		{probability: 3 * MinSamplingProbability, tvalue: "fffffffffffffd"},
+		{probability: 0x10 * MinSamplingProbability, tvalue: "fffffffffffff"},
+		// Near 1, the probabilities are exact multiples of 2^-56.
+		{probability: math.Nextafter(1, 0), tvalue: "00000000000008"},
+		{probability: 1 - 0x100*MinSamplingProbability, tvalue: "000000000001"},
+	}
+	for _, tt := range tests {
+		th, err := ProbabilityToThreshold(tt.probability)
+		require.NoError(t, err)
+		assert.Equal(t, tt.tvalue, th.TValue(), "probability %v", tt.probability)
+		assert.InDelta(t, tt.probability, th.Probability(), 1e-12)
+	}
+
+	for _, p := range []float64{0, -1, 1.5, MinSamplingProbability / 2} {
+		_, err := ProbabilityToThreshold(p)
+		assert.Error(t, err, "probability %v", p)
+	}
+}
+
+func TestTValueToThreshold(t *testing.T) {
+	th, err := TValueToThreshold("8")
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, th.Probability())
+	assert.Equal(t, 2.0, th.AdjustedCount())
+	assert.Equal(t, "8", th.TValue())
+
+	th, err = TValueToThreshold("0")
+	require.NoError(t, err)
+	assert.Equal(t, AlwaysSampleThreshold, th)
+
+	for _, s := range []string{"", "123456789abcdef", "xyz", "-1"} {
+		_, err = TValueToThreshold(s)
+		assert.Error(t, err, "t-value %q", s)
+	}
+}
+
+func TestRandomness(t *testing.T) {
+	r, err := RValueToRandomness("0123456789abcd")
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcd", r.RValue())
+
+	for _, s := range []string{"", "0123456789abc", "0123456789abcde", "0123456789abcx"} {
+		_, err = RValueToRandomness(s)
+		assert.Error(t, err, "r-value %q", s)
+	}
+
+	traceID := pcommon.TraceID([16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd})
+	assert.Equal(t, r, TraceIDToRandomness(traceID))
+
+	assert.Equal(t, BytesToRandomness([]byte("body")), BytesToRandomness([]byte("body")))
+	assert.Less(t, BytesToRandomness([]byte("body")).unsigned, MaxAdjustedCount)
+}
+
+func TestShouldSample(t *testing.T) {
+	half, err := TValueToThreshold("8")
+	require.NoError(t, err)
+
+	below, err := RValueToRandomness("7fffffffffffff")
+	require.NoError(t, err)
+	atThreshold, err := RValueToRandomness("80000000000000")
+	require.NoError(t, err)
+
+	assert.False(t, half.ShouldSample(below))
+	assert.True(t, half.ShouldSample(atThreshold))
+	assert.True(t, AlwaysSampleThreshold.ShouldSample(below))
+	assert.True(t, ThresholdLessThan(AlwaysSampleThreshold, half))
+	assert.False(t, ThresholdLessThan(half, half))
+}
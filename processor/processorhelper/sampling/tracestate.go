@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "go.opentelemetry.io/collector/processor/processorhelper/sampling"
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	otelTraceStateKey = "ot"
+	thresholdSubKey   = "th"
+	randomnessSubKey  = "rv"
+)
+
+// OpenTelemetryTraceState is the OpenTelemetry ("ot") entry of a W3C tracestate, which carries the
+// sampling threshold (T-value) and the explicit randomness (R-value) of a trace.
+type OpenTelemetryTraceState struct {
+	threshold     Threshold
+	hasThreshold  bool
+	randomness    Randomness
+	hasRandomness bool
+	// extra holds the sub-keys not related to sampling, in their original order.
+	extra []string
+}
+
+// ParseOpenTelemetryTraceState parses the OpenTelemetry entry of a W3C tracestate.
+// A tracestate without an OpenTelemetry entry results in an empty OpenTelemetryTraceState.
+func ParseOpenTelemetryTraceState(tracestate string) (OpenTelemetryTraceState, error) {
+	var ots OpenTelemetryTraceState
+	value, ok := otelEntry(tracestate)
+	if !ok || value == "" {
+		return ots, nil
+	}
+	for _, field := range strings.Split(value, ";") {
+		key, val, found := strings.Cut(field, ":")
+		if !found {
+			return OpenTelemetryTraceState{}, fmt.Errorf("invalid OpenTelemetry tracestate field %q", field)
+		}
+		var err error
+		switch key {
+		case thresholdSubKey:
+			ots.threshold, err = TValueToThreshold(val)
+			ots.hasThreshold = true
+		case randomnessSubKey:
+			ots.randomness, err = RValueToRandomness(val)
+			ots.hasRandomness = true
+		default:
+			ots.extra = append(ots.extra, field)
+		}
+		if err != nil {
+			return OpenTelemetryTraceState{}, err
+		}
+	}
+	return ots, nil
+}
+
+// Threshold returns the sampling threshold, if there is one.
+func (ots *OpenTelemetryTraceState) Threshold() (Threshold, bool) {
+	return ots.threshold, ots.hasThreshold
+}
+
+// SetThreshold sets the sampling threshold.
+func (ots *OpenTelemetryTraceState) SetThreshold(t Threshold) {
+	ots.threshold = t
+	ots.hasThreshold = true
+}
+
+// ClearThreshold removes the sampling threshold, e.g. when the item is not sampled consistently.
+func (ots *OpenTelemetryTraceState) ClearThreshold() {
+	ots.threshold = Threshold{}
+	ots.hasThreshold = false
+}
+
+// Randomness returns the explicit randomness, if there is one.
+func (ots *OpenTelemetryTraceState) Randomness() (Randomness, bool) {
+	return ots.randomness, ots.hasRandomness
+}
+
+// String returns the value of the OpenTelemetry tracestate entry.
+func (ots *OpenTelemetryTraceState) String() string {
+	var fields []string
+	if ots.hasThreshold {
+		fields = append(fields, thresholdSubKey+":"+ots.threshold.TValue())
+	}
+	if ots.hasRandomness {
+		fields = append(fields, randomnessSubKey+":"+ots.randomness.RValue())
+	}
+	fields = append(fields, ots.extra...)
+	return strings.Join(fields, ";")
+}
+
+// UpdateTraceState returns the W3C tracestate with its OpenTelemetry entry replaced by ots.
+// As required by W3C, the updated entry is moved to the front of the list.
+func UpdateTraceState(tracestate string, ots OpenTelemetryTraceState) string {
+	var members []string
+	if value := ots.String(); value != "" {
+		members = append(members, otelTraceStateKey+"="+value)
+	}
+	for _, member := range splitTraceState(tracestate) {
+		if key, _, _ := strings.Cut(member, "="); key != otelTraceStateKey {
+			members = append(members, member)
+		}
+	}
+	return strings.Join(members, ",")
+}
+
+// otelEntry returns the value of the OpenTelemetry entry of the tracestate.
+func otelEntry(tracestate string) (string, bool) {
+	for _, member := range splitTraceState(tracestate) {
+		if key, value, found := strings.Cut(member, "="); found && key == otelTraceStateKey {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// splitTraceState returns the non-empty members of the tracestate.
+func splitTraceState(tracestate string) []string {
+	var members []string
+	for _, member := range strings.Split(tracestate, ",") {
+		if member = strings.TrimSpace(member); member != "" {
+			members = append(members, member)
+		}
+	}
+	return members
+}
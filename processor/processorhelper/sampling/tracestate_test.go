@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOpenTelemetryTraceState(t *testing.T) {
+	ots, err := ParseOpenTelemetryTraceState("")
+	require.NoError(t, err)
+	_, ok := ots.Threshold()
+	assert.False(t, ok)
+	assert.Equal(t, "", ots.String())
+
+	ots, err = ParseOpenTelemetryTraceState("vendor=x, ot=th:c;rv:0123456789abcd;foo:bar")
+	require.NoError(t, err)
+	th, ok := ots.Threshold()
+	require.True(t, ok)
+	assert.Equal(t, 0.25, th.Probability())
+	rv, ok := ots.Randomness()
+	require.True(t, ok)
+	assert.Equal(t, "0123456789abcd", rv.RValue())
+	assert.Equal(t, "th:c;rv:0123456789abcd;foo:bar", ots.String())
+
+	for _, ts := range []string{"ot=th", "ot=th:xyz", "ot=rv:123"} {
+		_, err = ParseOpenTelemetryTraceState(ts)
+		assert.Error(t, err, "tracestate %q", ts)
+	}
+}
+
+func TestUpdateTraceState(t *testing.T) {
+	ots, err := ParseOpenTelemetryTraceState("vendor=x,ot=th:c")
+	require.NoError(t, err)
+
+	half, err := TValueToThreshold("8")
+	require.NoError(t, err)
+	ots.SetThreshold(half)
+	assert.Equal(t, "ot=th:8,vendor=x", UpdateTraceState("vendor=x,ot=th:c", ots))
+
+	ots.ClearThreshold()
+	assert.Equal(t, "vendor=x", UpdateTraceState("vendor=x,ot=th:c", ots))
+
+	ots.SetThreshold(AlwaysSampleThreshold)
+	assert.Equal(t, "ot=th:0", UpdateTraceState("", ots))
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereplayreceiver // import "go.opentelemetry.io/collector/receiver/filereplayreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	// formatJSON reads one OTLP JSON encoded request per line.
+	formatJSON = "json"
+	// formatProto reads OTLP protobuf encoded requests, each prefixed by its length.
+	formatProto = "proto"
+
+	compressionGzip = "gzip"
+)
+
+// Config defines configuration for the file replay receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Include is the list of glob patterns of the files to read.
+	Include []string `mapstructure:"include"`
+
+	// Format of the files, either "json" (the default) or "proto". It must match the format of the file exporter.
+	Format string `mapstructure:"format"`
+
+	// Compression of the files. Only "gzip" is supported, the files are not compressed by default.
+	Compression string `mapstructure:"compression"`
+
+	// PollInterval is the interval at which the files are checked for new data.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// StorageID if not empty, the component specified as a storage extension is used to persist the
+	// position of the receiver in the files, so it resumes from there after a restart.
+	StorageID *component.ID `mapstructure:"storage"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Include) == 0 {
+		return errors.New("include must not be empty")
+	}
+	for _, pattern := range cfg.Include {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid include pattern %q: %w", pattern, err)
+		}
+	}
+	if cfg.Format != formatJSON && cfg.Format != formatProto {
+		return fmt.Errorf("format %q is not supported, must be one of %q or %q", cfg.Format, formatJSON, formatProto)
+	}
+	if cfg.Compression != "" && cfg.Compression != compressionGzip {
+		return fmt.Errorf("compression %q is not supported, must be %q", cfg.Compression, compressionGzip)
+	}
+	if cfg.PollInterval <= 0 {
+		return errors.New("poll_interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereplayreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+
+	storageID := component.NewID("file_storage")
+	assert.Equal(t,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(component.NewID(typeStr)),
+			Include:          []string{"/var/lib/otelcol/file/traces*.pb.gz"},
+			Format:           formatProto,
+			Compression:      compressionGzip,
+			PollInterval:     5 * time.Second,
+			StorageID:        &storageID,
+		}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr string
+	}{
+		{
+			name:        "no_include",
+			modify:      func(cfg *Config) { cfg.Include = nil },
+			expectedErr: "include must not be empty",
+		},
+		{
+			name:        "invalid_include",
+			modify:      func(cfg *Config) { cfg.Include = []string{"["} },
+			expectedErr: `invalid include pattern "[": syntax error in pattern`,
+		},
+		{
+			name:        "invalid_format",
+			modify:      func(cfg *Config) { cfg.Format = "yaml" },
+			expectedErr: `format "yaml" is not supported, must be one of "json" or "proto"`,
+		},
+		{
+			name:        "invalid_compression",
+			modify:      func(cfg *Config) { cfg.Compression = "zstd" },
+			expectedErr: `compression "zstd" is not supported, must be "gzip"`,
+		},
+		{
+			name:        "zero_poll_interval",
+			modify:      func(cfg *Config) { cfg.PollInterval = 0 },
+			expectedErr: "poll_interval must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Include = []string{"*.json"}
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereplayreceiver // import "go.opentelemetry.io/collector/receiver/filereplayreceiver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr             = "filereplay"
+	defaultPollInterval = time.Second
+)
+
+// NewFactory creates a factory for the file replay receiver.
+func NewFactory() component.ReceiverFactory {
+	return component.NewReceiverFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithTracesReceiver(component.NewCreateTracesReceiverFunc(createTracesReceiver), component.StabilityLevelDevelopment),
+		component.WithMetricsReceiver(component.NewCreateMetricsReceiverFunc(createMetricsReceiver), component.StabilityLevelDevelopment),
+		component.WithLogsReceiver(component.NewCreateLogsReceiverFunc(createLogsReceiver), component.StabilityLevelDevelopment),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(component.NewID(typeStr)),
+		Format:           formatJSON,
+		PollInterval:     defaultPollInterval,
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg *Config,
+	nextConsumer consumer.Traces,
+) (component.TracesReceiver, error) {
+	return newTracesReceiver(cfg, set, nextConsumer)
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg *Config,
+	nextConsumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	return newMetricsReceiver(cfg, set, nextConsumer)
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg *Config,
+	nextConsumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	return newLogsReceiver(cfg, set, nextConsumer)
+}
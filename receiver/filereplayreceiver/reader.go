@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereplayreceiver // import "go.opentelemetry.io/collector/receiver/filereplayreceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// fingerprintSize is the number of bytes at the beginning of a file used to identify it,
+	// so that a file keeps its checkpoint when it is renamed by the rotation of the file exporter.
+	fingerprintSize = 1024
+
+	checkpointsKey = "checkpoints"
+)
+
+// The data type of a protobuf record is identified by the first byte of its frame, as written by the file exporter.
+const (
+	recordTypeTraces  byte = 1
+	recordTypeMetrics byte = 2
+	recordTypeLogs    byte = 3
+)
+
+// checkpoint is the position of the receiver in a file.
+type checkpoint struct {
+	// Fingerprint holds the first bytes of the file, up to fingerprintSize.
+	Fingerprint []byte `json:"fingerprint"`
+	// Offset is the position after the last record passed to the next consumer,
+	// in the decompressed data if the file is compressed.
+	Offset int64 `json:"offset"`
+}
+
+func (r *replayReceiver) loadCheckpoints(ctx context.Context) error {
+	buf, err := r.storageClient.Get(ctx, checkpointsKey)
+	if err != nil || buf == nil {
+		return err
+	}
+	if err = json.Unmarshal(buf, &r.checkpoints); err != nil {
+		return fmt.Errorf("failed to load checkpoints: %w", err)
+	}
+	return nil
+}
+
+func (r *replayReceiver) saveCheckpoints(ctx context.Context) error {
+	buf, err := json.Marshal(r.checkpoints)
+	if err != nil {
+		return err
+	}
+	return r.storageClient.Set(ctx, checkpointsKey, buf)
+}
+
+// poll reads the new records of all the files matching the include patterns, and saves the checkpoints.
+// Checkpoints of files that are not matched anymore are forgotten.
+func (r *replayReceiver) poll(ctx context.Context) {
+	var checkpoints []*checkpoint
+	for _, path := range r.matchFiles() {
+		fingerprint, err := readFingerprint(path)
+		if err != nil {
+			r.settings.Logger.Warn("Failed to read file", zap.String("file", path), zap.Error(err))
+			continue
+		}
+		if len(fingerprint) == 0 {
+			continue
+		}
+		cp := r.takeCheckpoint(fingerprint)
+		checkpoints = append(checkpoints, cp)
+		if err = r.readFile(ctx, path, cp); err != nil {
+			r.settings.Logger.Warn("Failed to replay file", zap.String("file", path), zap.Error(err))
+		}
+	}
+	r.checkpoints = checkpoints
+	if err := r.saveCheckpoints(ctx); err != nil {
+		r.settings.Logger.Warn("Failed to save checkpoints", zap.Error(err))
+	}
+}
+
+// matchFiles returns the list of files matching the include patterns, sorted by modification time then by
+// name. The files rotated by the file exporter are then sorted in chronological order, before the current file.
+func (r *replayReceiver) matchFiles() []string {
+	modTimes := map[string]time.Time{}
+	var paths []string
+	for _, pattern := range r.cfg.Include {
+		// The patterns are validated in the configuration.
+		matches, _ := filepath.Glob(pattern)
+		for _, path := range matches {
+			if _, ok := modTimes[path]; ok {
+				continue
+			}
+			fi, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			modTimes[path] = fi.ModTime()
+			paths = append(paths, path)
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if ti, tj := modTimes[paths[i]], modTimes[paths[j]]; !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return paths[i] < paths[j]
+	})
+	return paths
+}
+
+// takeCheckpoint removes from the known checkpoints and returns the checkpoint of the file with the
+// given fingerprint, or a new checkpoint if the file is not known.
+func (r *replayReceiver) takeCheckpoint(fingerprint []byte) *checkpoint {
+	for i, cp := range r.checkpoints {
+		if bytes.HasPrefix(fingerprint, cp.Fingerprint) {
+			r.checkpoints = append(r.checkpoints[:i], r.checkpoints[i+1:]...)
+			cp.Fingerprint = fingerprint
+			return cp
+		}
+	}
+	return &checkpoint{Fingerprint: fingerprint}
+}
+
+func readFingerprint(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	buf := make([]byte, fingerprintSize)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// readFile passes the records of the file after the checkpoint to the next consumer, advancing the checkpoint.
+// It stops at the first incomplete record, which is expected to be completed by the file exporter, or when the
+// next consumer returns an error, the record is then retried on the next poll.
+func (r *replayReceiver) readFile(ctx context.Context, path string, cp *checkpoint) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var rd io.Reader = f
+	if r.cfg.Compression == compressionGzip {
+		var gz *gzip.Reader
+		if gz, err = gzip.NewReader(f); err != nil {
+			return ignoreIncomplete(err)
+		}
+		defer gz.Close()
+		if _, err = io.CopyN(io.Discard, gz, cp.Offset); err != nil {
+			return ignoreIncomplete(err)
+		}
+		rd = gz
+	} else if _, err = f.Seek(cp.Offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	br := bufio.NewReader(rd)
+	for ctx.Err() == nil {
+		recordType, record, n, err := r.readRecord(br)
+		if err != nil {
+			return ignoreIncomplete(err)
+		}
+		// The records of the other data types are skipped, JSON records are skipped when decoded.
+		if len(record) > 0 && (recordType == 0 || recordType == r.recordType) {
+			if err = r.consume(ctx, record); err != nil {
+				return err
+			}
+		}
+		cp.Offset += n
+	}
+	return nil
+}
+
+// readRecord returns the type of the next record, the record and the number of bytes it takes in the file.
+// The type of JSON records is not known until they are decoded, it is then zero.
+func (r *replayReceiver) readRecord(br *bufio.Reader) (byte, []byte, int64, error) {
+	if r.cfg.Format == formatProto {
+		var header struct {
+			RecordType byte
+			Size       uint32
+		}
+		if err := binary.Read(br, binary.BigEndian, &header); err != nil {
+			return 0, nil, 0, err
+		}
+		record := make([]byte, header.Size)
+		if _, err := io.ReadFull(br, record); err != nil {
+			return 0, nil, 0, err
+		}
+		return header.RecordType, record, 5 + int64(header.Size), nil
+	}
+
+	line, err := br.ReadBytes('\n')
+	if err != nil {
+		// A line without a new line at the end is incomplete.
+		return 0, nil, 0, err
+	}
+	return 0, bytes.TrimSpace(line), int64(len(line)), nil
+}
+
+// ignoreIncomplete returns nil if err is caused by reaching the end of the data written so far.
+func ignoreIncomplete(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereplayreceiver // import "go.opentelemetry.io/collector/receiver/filereplayreceiver"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const receiverTransport = "file"
+
+var (
+	errNoStorageClient    = errors.New("no storage client extension found")
+	errWrongExtensionType = errors.New("requested extension is not a storage extension")
+)
+
+// replayReceiver periodically reads the new records of the configured files and passes them to the next consumer.
+// Every data type has its own receiver and checkpoints, records of other data types are skipped.
+type replayReceiver struct {
+	cfg      *Config
+	settings component.ReceiverCreateSettings
+	dataType component.DataType
+	obsrecv  *obsreport.Receiver
+	// recordType identifies the protobuf records of dataType.
+	recordType byte
+
+	// consume decodes a record and passes it to the next consumer. Only the errors of the next
+	// consumer are returned, records that cannot be decoded are skipped.
+	consume func(ctx context.Context, record []byte) error
+
+	storageClient storage.Client
+	checkpoints   []*checkpoint
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newReplayReceiver(cfg *Config, set component.ReceiverCreateSettings, dataType component.DataType, recordType byte) (*replayReceiver, error) {
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             set.ID,
+		Transport:              receiverTransport,
+		ReceiverCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &replayReceiver{
+		cfg:           cfg,
+		settings:      set,
+		dataType:      dataType,
+		obsrecv:       obsrecv,
+		recordType:    recordType,
+		storageClient: storage.NewNopClient(),
+	}, nil
+}
+
+func newTracesReceiver(cfg *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Traces) (*replayReceiver, error) {
+	r, err := newReplayReceiver(cfg, set, component.DataTypeTraces, recordTypeTraces)
+	if err != nil {
+		return nil, err
+	}
+	var unmarshaler ptrace.Unmarshaler = &ptrace.JSONUnmarshaler{}
+	if cfg.Format == formatProto {
+		unmarshaler = &ptrace.ProtoUnmarshaler{}
+	}
+	r.consume = func(ctx context.Context, record []byte) error {
+		td, err := unmarshaler.UnmarshalTraces(record)
+		if err != nil {
+			r.settings.Logger.Warn("Skipping record that cannot be decoded", zap.Error(err))
+			return nil
+		}
+		numSpans := td.SpanCount()
+		if numSpans == 0 {
+			return nil
+		}
+		ctx = r.obsrecv.StartTracesOp(ctx)
+		err = nextConsumer.ConsumeTraces(ctx, td)
+		r.obsrecv.EndTracesOp(ctx, cfg.Format, numSpans, err)
+		return err
+	}
+	return r, nil
+}
+
+func newMetricsReceiver(cfg *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Metrics) (*replayReceiver, error) {
+	r, err := newReplayReceiver(cfg, set, component.DataTypeMetrics, recordTypeMetrics)
+	if err != nil {
+		return nil, err
+	}
+	var unmarshaler pmetric.Unmarshaler = &pmetric.JSONUnmarshaler{}
+	if cfg.Format == formatProto {
+		unmarshaler = &pmetric.ProtoUnmarshaler{}
+	}
+	r.consume = func(ctx context.Context, record []byte) error {
+		md, err := unmarshaler.UnmarshalMetrics(record)
+		if err != nil {
+			r.settings.Logger.Warn("Skipping record that cannot be decoded", zap.Error(err))
+			return nil
+		}
+		numPoints := md.DataPointCount()
+		if numPoints == 0 {
+			return nil
+		}
+		ctx = r.obsrecv.StartMetricsOp(ctx)
+		err = nextConsumer.ConsumeMetrics(ctx, md)
+		r.obsrecv.EndMetricsOp(ctx, cfg.Format, numPoints, err)
+		return err
+	}
+	return r, nil
+}
+
+func newLogsReceiver(cfg *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Logs) (*replayReceiver, error) {
+	r, err := newReplayReceiver(cfg, set, component.DataTypeLogs, recordTypeLogs)
+	if err != nil {
+		return nil, err
+	}
+	var unmarshaler plog.Unmarshaler = &plog.JSONUnmarshaler{}
+	if cfg.Format == formatProto {
+		unmarshaler = &plog.ProtoUnmarshaler{}
+	}
+	r.consume = func(ctx context.Context, record []byte) error {
+		ld, err := unmarshaler.UnmarshalLogs(record)
+		if err != nil {
+			r.settings.Logger.Warn("Skipping record that cannot be decoded", zap.Error(err))
+			return nil
+		}
+		numRecords := ld.LogRecordCount()
+		if numRecords == 0 {
+			return nil
+		}
+		ctx = r.obsrecv.StartLogsOp(ctx)
+		err = nextConsumer.ConsumeLogs(ctx, ld)
+		r.obsrecv.EndLogsOp(ctx, cfg.Format, numRecords, err)
+		return err
+	}
+	return r, nil
+}
+
+// Start loads the checkpoints and starts polling the files.
+func (r *replayReceiver) Start(ctx context.Context, host component.Host) error {
+	if r.cfg.StorageID != nil {
+		client, err := toStorageClient(ctx, *r.cfg.StorageID, host, r.settings.ID, r.dataType)
+		if err != nil {
+			return err
+		}
+		r.storageClient = client
+	}
+	if err := r.loadCheckpoints(ctx); err != nil {
+		return err
+	}
+
+	var pollCtx context.Context
+	pollCtx, r.cancel = context.WithCancel(context.Background())
+	r.wg.Add(1)
+	go r.pollLoop(pollCtx)
+	return nil
+}
+
+// Shutdown stops polling the files and releases the storage client.
+func (r *replayReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return r.storageClient.Close(ctx)
+}
+
+func (r *replayReceiver) pollLoop(ctx context.Context) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		r.poll(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func toStorageClient(ctx context.Context, storageID component.ID, host component.Host, ownerID component.ID, dataType component.DataType) (storage.Client, error) {
+	ext, found := host.GetExtensions()[storageID]
+	if !found {
+		return nil, errNoStorageClient
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, errWrongExtensionType
+	}
+	return storageExt.GetClient(ctx, component.KindReceiver, ownerID, string(dataType))
+}
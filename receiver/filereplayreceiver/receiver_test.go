@@ -0,0 +1,387 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereplayreceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestConfig(include ...string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = include
+	return cfg
+}
+
+func tracesLine(t *testing.T, spanCount int) []byte {
+	buf, err := (&ptrace.JSONMarshaler{}).MarshalTraces(testdata.GenerateTraces(spanCount))
+	require.NoError(t, err)
+	return append(buf, '\n')
+}
+
+// protoFrame returns the record framed like the file exporter does with the proto format.
+func protoFrame(recordType byte, record []byte) []byte {
+	frame := make([]byte, 5, 5+len(record))
+	frame[0] = recordType
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(record)))
+	return append(frame, record...)
+}
+
+func appendFile(t *testing.T, path string, data []byte) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = f.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func TestReplayJSONSkipsOtherDataTypes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	md := testdata.GenerateMetrics(1)
+	metrics, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	logs, err := (&plog.JSONMarshaler{}).MarshalLogs(testdata.GenerateLogs(3))
+	require.NoError(t, err)
+	appendFile(t, path, tracesLine(t, 2))
+	appendFile(t, path, append(metrics, '\n'))
+	appendFile(t, path, append(logs, '\n'))
+
+	set := componenttest.NewNopReceiverCreateSettings()
+	tracesSink := new(consumertest.TracesSink)
+	tr, err := newTracesReceiver(newTestConfig(path), set, tracesSink)
+	require.NoError(t, err)
+	tr.poll(context.Background())
+	assert.Equal(t, 2, tracesSink.SpanCount())
+
+	metricsSink := new(consumertest.MetricsSink)
+	mr, err := newMetricsReceiver(newTestConfig(path), set, metricsSink)
+	require.NoError(t, err)
+	mr.poll(context.Background())
+	assert.Equal(t, md.DataPointCount(), metricsSink.DataPointCount())
+
+	logsSink := new(consumertest.LogsSink)
+	lr, err := newLogsReceiver(newTestConfig(path), set, logsSink)
+	require.NoError(t, err)
+	lr.poll(context.Background())
+	assert.Equal(t, 3, logsSink.LogRecordCount())
+}
+
+func TestReplayIncompleteRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	line := tracesLine(t, 1)
+	appendFile(t, path, line[:10])
+
+	sink := new(consumertest.TracesSink)
+	r, err := newTracesReceiver(newTestConfig(path), componenttest.NewNopReceiverCreateSettings(), sink)
+	require.NoError(t, err)
+	r.poll(context.Background())
+	assert.Equal(t, 0, sink.SpanCount())
+
+	appendFile(t, path, line[10:])
+	r.poll(context.Background())
+	assert.Equal(t, 1, sink.SpanCount())
+	require.Len(t, r.checkpoints, 1)
+	assert.EqualValues(t, len(line), r.checkpoints[0].Offset)
+
+	// Nothing new is read.
+	r.poll(context.Background())
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestReplayProtoGzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.pb.gz")
+	td := testdata.GenerateTraces(2)
+	record, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	for i := 0; i < 2; i++ {
+		_, err = gz.Write(protoFrame(recordTypeTraces, record))
+		require.NoError(t, err)
+	}
+	// The stream is flushed but not closed, as the file exporter does while writing.
+	require.NoError(t, gz.Flush())
+	appendFile(t, path, buf.Bytes())
+
+	cfg := newTestConfig(path)
+	cfg.Format = formatProto
+	cfg.Compression = compressionGzip
+	sink := new(consumertest.TracesSink)
+	r, err := newTracesReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), sink)
+	require.NoError(t, err)
+	r.poll(context.Background())
+	require.Len(t, sink.AllTraces(), 2)
+	assert.Equal(t, td, sink.AllTraces()[0])
+	assert.Equal(t, td, sink.AllTraces()[1])
+
+	r.poll(context.Background())
+	assert.Len(t, sink.AllTraces(), 2)
+}
+
+func TestReplayProtoMixedDataTypes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.pb")
+	traces, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(testdata.GenerateTraces(2))
+	require.NoError(t, err)
+	md := testdata.GenerateMetrics(1)
+	metrics, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	logs, err := (&plog.ProtoMarshaler{}).MarshalLogs(testdata.GenerateLogs(3))
+	require.NoError(t, err)
+	appendFile(t, path, protoFrame(recordTypeTraces, traces))
+	appendFile(t, path, protoFrame(recordTypeMetrics, metrics))
+	appendFile(t, path, protoFrame(recordTypeLogs, logs))
+
+	cfg := newTestConfig(path)
+	cfg.Format = formatProto
+	set := componenttest.NewNopReceiverCreateSettings()
+
+	tracesSink := new(consumertest.TracesSink)
+	tr, err := newTracesReceiver(cfg, set, tracesSink)
+	require.NoError(t, err)
+	tr.poll(context.Background())
+	assert.Len(t, tracesSink.AllTraces(), 1)
+	assert.Equal(t, 2, tracesSink.SpanCount())
+
+	metricsSink := new(consumertest.MetricsSink)
+	mr, err := newMetricsReceiver(cfg, set, metricsSink)
+	require.NoError(t, err)
+	mr.poll(context.Background())
+	assert.Len(t, metricsSink.AllMetrics(), 1)
+	assert.Equal(t, md.DataPointCount(), metricsSink.DataPointCount())
+
+	logsSink := new(consumertest.LogsSink)
+	lr, err := newLogsReceiver(cfg, set, logsSink)
+	require.NoError(t, err)
+	lr.poll(context.Background())
+	assert.Len(t, logsSink.AllLogs(), 1)
+	assert.Equal(t, 3, logsSink.LogRecordCount())
+
+	// Every receiver read the whole file.
+	for _, r := range []*replayReceiver{tr, mr, lr} {
+		require.Len(t, r.checkpoints, 1)
+		assert.EqualValues(t, 15+len(traces)+len(metrics)+len(logs), r.checkpoints[0].Offset)
+	}
+}
+
+func TestReplayProtoTruncatedRecord(t *testing.T) {
+	record, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(testdata.GenerateTraces(1))
+	require.NoError(t, err)
+	frame := protoFrame(recordTypeTraces, record)
+
+	tests := []struct {
+		name  string
+		split int
+	}{
+		{
+			name:  "truncated header",
+			split: 3,
+		},
+		{
+			name:  "truncated record",
+			split: 5 + len(record)/2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "data.pb")
+			appendFile(t, path, frame)
+			appendFile(t, path, frame[:tt.split])
+
+			cfg := newTestConfig(path)
+			cfg.Format = formatProto
+			sink := new(consumertest.TracesSink)
+			r, err := newTracesReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), sink)
+			require.NoError(t, err)
+
+			// The truncated record is left for the next poll.
+			r.poll(context.Background())
+			assert.Equal(t, 1, sink.SpanCount())
+			require.Len(t, r.checkpoints, 1)
+			assert.EqualValues(t, len(frame), r.checkpoints[0].Offset)
+
+			appendFile(t, path, frame[tt.split:])
+			r.poll(context.Background())
+			assert.Equal(t, 2, sink.SpanCount())
+			assert.EqualValues(t, 2*len(frame), r.checkpoints[0].Offset)
+		})
+	}
+}
+
+func TestReplayRotatedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+	appendFile(t, path, tracesLine(t, 1))
+
+	sink := new(consumertest.TracesSink)
+	r, err := newTracesReceiver(newTestConfig(filepath.Join(dir, "data*.json")), componenttest.NewNopReceiverCreateSettings(), sink)
+	require.NoError(t, err)
+	r.poll(context.Background())
+	assert.Equal(t, 1, sink.SpanCount())
+
+	// The rotated file keeps its checkpoint, only the new file is read.
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "data-2022-11-01T00-00-00.000.json")))
+	appendFile(t, path, tracesLine(t, 2))
+	r.poll(context.Background())
+	assert.Equal(t, 3, sink.SpanCount())
+	assert.Len(t, r.checkpoints, 2)
+
+	// The checkpoints of removed files are forgotten.
+	require.NoError(t, os.Remove(filepath.Join(dir, "data-2022-11-01T00-00-00.000.json")))
+	r.poll(context.Background())
+	assert.Equal(t, 3, sink.SpanCount())
+	assert.Len(t, r.checkpoints, 1)
+}
+
+func TestReplayNextConsumerError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	appendFile(t, path, tracesLine(t, 1))
+
+	r, err := newTracesReceiver(newTestConfig(path), componenttest.NewNopReceiverCreateSettings(), consumertest.NewErr(errors.New("my error")))
+	require.NoError(t, err)
+	r.poll(context.Background())
+	require.Len(t, r.checkpoints, 1)
+	assert.EqualValues(t, 0, r.checkpoints[0].Offset)
+}
+
+func TestReplaySkipsInvalidRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	appendFile(t, path, []byte("not json\n"))
+	appendFile(t, path, tracesLine(t, 1))
+
+	sink := new(consumertest.TracesSink)
+	r, err := newTracesReceiver(newTestConfig(path), componenttest.NewNopReceiverCreateSettings(), sink)
+	require.NoError(t, err)
+	r.poll(context.Background())
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestReplayResumesFromStorage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	appendFile(t, path, tracesLine(t, 1))
+
+	storageID := component.NewID("storage")
+	host := &storageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{storageID: &memoryStorage{}},
+	}
+	cfg := newTestConfig(path)
+	cfg.PollInterval = 10 * time.Millisecond
+	cfg.StorageID = &storageID
+
+	sink := new(consumertest.TracesSink)
+	for i := 1; i <= 2; i++ {
+		r, err := newTracesReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), sink)
+		require.NoError(t, err)
+		require.NoError(t, r.Start(context.Background(), host))
+		appendFile(t, path, tracesLine(t, 1))
+		assert.Eventually(t, func() bool { return sink.SpanCount() == i+1 }, time.Second, 10*time.Millisecond)
+		require.NoError(t, r.Shutdown(context.Background()))
+	}
+	assert.Equal(t, 3, sink.SpanCount())
+}
+
+func TestReplayInvalidStorage(t *testing.T) {
+	storageID := component.NewID("storage")
+	cfg := newTestConfig("*.json")
+	cfg.StorageID = &storageID
+
+	r, err := newTracesReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.ErrorIs(t, r.Start(context.Background(), componenttest.NewNopHost()), errNoStorageClient)
+
+	host := &storageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{storageID: struct{ component.Component }{}},
+	}
+	assert.ErrorIs(t, r.Start(context.Background(), host), errWrongExtensionType)
+}
+
+type storageHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *storageHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+// memoryStorage is a storage extension keeping the data in memory, shared by all its clients.
+type memoryStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (m *memoryStorage) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return &memoryClient{storage: m}, nil
+}
+
+type memoryClient struct {
+	storage *memoryStorage
+}
+
+func (c *memoryClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.storage.mu.Lock()
+	defer c.storage.mu.Unlock()
+	return c.storage.data[key], nil
+}
+
+func (c *memoryClient) Set(_ context.Context, key string, value []byte) error {
+	c.storage.mu.Lock()
+	defer c.storage.mu.Unlock()
+	if c.storage.data == nil {
+		c.storage.data = map[string][]byte{}
+	}
+	c.storage.data[key] = value
+	return nil
+}
+
+func (c *memoryClient) Delete(_ context.Context, key string) error {
+	c.storage.mu.Lock()
+	defer c.storage.mu.Unlock()
+	delete(c.storage.data, key)
+	return nil
+}
+
+func (c *memoryClient) Batch(context.Context, ...storage.Operation) error {
+	return errors.New("not implemented")
+}
+
+func (c *memoryClient) Close(context.Context) error {
+	return nil
+}
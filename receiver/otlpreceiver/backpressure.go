@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const defaultBackpressureRetryDelay = time.Second
+
+// Backpressure configures the receiver to ask its clients to slow down when the sending queue of
+// one of the given exporters is above its backpressure watermark, see the `backpressure_watermark`
+// setting of the sending queue.
+type Backpressure struct {
+	// Exporters are the IDs of the exporters whose sending queues are watched. Only the exporters
+	// in the pipelines of the same data type as the request are taken into account.
+	Exporters []component.ID `mapstructure:"exporters"`
+
+	// RetryDelay is the delay the clients are asked to wait before retrying. Defaults to 1s.
+	RetryDelay time.Duration `mapstructure:"retry_delay"`
+}
+
+// backpressure refuses the requests with a retryable error while any of the watched exporters is under backpressure.
+type backpressure struct {
+	cfg       *Backpressure
+	reporters map[component.DataType][]exporterhelper.BackpressureReporter
+}
+
+func newBackpressure(cfg *Backpressure) *backpressure {
+	return &backpressure{cfg: cfg}
+}
+
+// start resolves the watched exporters, it must be called before the receiver accepts requests.
+func (bp *backpressure) start(host component.Host) error {
+	bp.reporters = map[component.DataType][]exporterhelper.BackpressureReporter{}
+	exporters := host.GetExporters()
+	for _, id := range bp.cfg.Exporters {
+		found := false
+		for dataType, exps := range exporters {
+			if _, ok := exps[id]; !ok {
+				continue
+			}
+			br, err := exporterhelper.GetBackpressureReporter(host, dataType, id)
+			if err != nil {
+				return err
+			}
+			bp.reporters[dataType] = append(bp.reporters[dataType], br)
+			found = true
+		}
+		if !found {
+			return fmt.Errorf("backpressure exporter %q not found", id)
+		}
+	}
+	return nil
+}
+
+// check returns an error if any of the exporters watched for the given data type is under backpressure.
+// The error carries a ResourceExhausted gRPC status with retry information, so that the OTLP exporters
+// of upstream collectors retry the request later, and is sent as a 429 response to the HTTP clients.
+func (bp *backpressure) check(dataType component.DataType) error {
+	for _, br := range bp.reporters[dataType] {
+		if br.UnderBackpressure() {
+			return bp.error()
+		}
+	}
+	return nil
+}
+
+func (bp *backpressure) error() error {
+	delay := bp.cfg.RetryDelay
+	if delay <= 0 {
+		delay = defaultBackpressureRetryDelay
+	}
+	st := status.New(codes.ResourceExhausted, "the sending queue of an exporter is nearly full, slow down")
+	if withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+func (bp *backpressure) wrapTraces(next consumer.Traces) consumer.Traces {
+	tc, _ := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		if err := bp.check(component.DataTypeTraces); err != nil {
+			return err
+		}
+		return next.ConsumeTraces(ctx, td)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return tc
+}
+
+func (bp *backpressure) wrapMetrics(next consumer.Metrics) consumer.Metrics {
+	mc, _ := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		if err := bp.check(component.DataTypeMetrics); err != nil {
+			return err
+		}
+		return next.ConsumeMetrics(ctx, md)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return mc
+}
+
+func (bp *backpressure) wrapLogs(next consumer.Logs) consumer.Logs {
+	lc, _ := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		if err := bp.check(component.DataTypeLogs); err != nil {
+			return err
+		}
+		return next.ConsumeLogs(ctx, ld)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return lc
+}
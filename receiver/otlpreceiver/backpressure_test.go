@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+var queuedExporterID = component.NewID("queued")
+
+type fakeBackpressureReporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	underBackpressure bool
+}
+
+func (f *fakeBackpressureReporter) UnderBackpressure() bool {
+	return f.underBackpressure
+}
+
+type exportersHost struct {
+	component.Host
+	exporters map[component.DataType]map[component.ID]component.Component
+}
+
+func (h *exportersHost) GetExporters() map[component.DataType]map[component.ID]component.Component {
+	return h.exporters
+}
+
+func newBackpressureHost(reporter *fakeBackpressureReporter) component.Host {
+	return &exportersHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[component.DataType]map[component.ID]component.Component{
+			component.DataTypeTraces: {queuedExporterID: reporter},
+		},
+	}
+}
+
+func TestBackpressure(t *testing.T) {
+	reporter := &fakeBackpressureReporter{}
+	bp := newBackpressure(&Backpressure{Exporters: []component.ID{queuedExporterID}, RetryDelay: 5 * time.Second})
+	require.NoError(t, bp.start(newBackpressureHost(reporter)))
+
+	sink := new(consumertest.TracesSink)
+	tc := bp.wrapTraces(sink)
+	assert.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, sink.AllTraces(), 1)
+
+	reporter.underBackpressure = true
+	err := tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	require.Error(t, err)
+	assert.Len(t, sink.AllTraces(), 1)
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, 5*time.Second, retryInfo.RetryDelay.AsDuration())
+
+	// The exporter is only in the traces pipelines, the other data types are not refused.
+	logsSink := new(consumertest.LogsSink)
+	assert.NoError(t, bp.wrapLogs(logsSink).ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.Len(t, logsSink.AllLogs(), 1)
+}
+
+func TestBackpressureExporterNotFound(t *testing.T) {
+	bp := newBackpressure(&Backpressure{Exporters: []component.ID{component.NewID("unknown")}})
+	assert.EqualError(t, bp.start(newBackpressureHost(&fakeBackpressureReporter{})), `backpressure exporter "unknown" not found`)
+}
+
+func TestBackpressureNotReporter(t *testing.T) {
+	host := &exportersHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[component.DataType]map[component.ID]component.Component{
+			component.DataTypeTraces: {queuedExporterID: struct {
+				component.StartFunc
+				component.ShutdownFunc
+			}{}},
+		},
+	}
+	bp := newBackpressure(&Backpressure{Exporters: []component.ID{queuedExporterID}})
+	assert.Error(t, bp.start(host))
+}
+
+func TestBackpressureDefaultRetryDelay(t *testing.T) {
+	st, ok := status.FromError(newBackpressure(&Backpressure{}).error())
+	require.True(t, ok)
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, defaultBackpressureRetryDelay, st.Details()[0].(*errdetails.RetryInfo).RetryDelay.AsDuration())
+}
+
+func TestSetRetryAfter(t *testing.T) {
+	withRetryInfo, err := status.New(codes.ResourceExhausted, "slow down").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(2500 * time.Millisecond)})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		statusCode int
+		status     *status.Status
+		expected   string
+	}{
+		{
+			name:       "retry_info",
+			statusCode: http.StatusTooManyRequests,
+			status:     withRetryInfo,
+			expected:   "3",
+		},
+		{
+			name:       "no_retry_info",
+			statusCode: http.StatusTooManyRequests,
+			status:     status.New(codes.ResourceExhausted, "slow down"),
+			expected:   "1",
+		},
+		{
+			name:       "no_status",
+			statusCode: http.StatusServiceUnavailable,
+			expected:   "1",
+		},
+		{
+			name:       "not_throttled",
+			statusCode: http.StatusBadRequest,
+			status:     status.New(codes.InvalidArgument, "invalid"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			setRetryAfter(rec, tt.statusCode, tt.status.Proto())
+			assert.Equal(t, tt.expected, rec.Header().Get("Retry-After"))
+		})
+	}
+}
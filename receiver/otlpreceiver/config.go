@@ -16,6 +16,7 @@ package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlprecei
 
 import (
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -34,6 +35,37 @@ const (
 type Protocols struct {
 	GRPC *configgrpc.GRPCServerSettings `mapstructure:"grpc"`
 	HTTP *confighttp.HTTPServerSettings `mapstructure:"http"`
+
+	// AdditionalGRPC configures more gRPC listeners, for example to bind to both an IPv4 and an IPv6
+	// address. Each listener has its own endpoint and TLS settings.
+	AdditionalGRPC []configgrpc.GRPCServerSettings `mapstructure:"additional_grpc"`
+
+	// AdditionalHTTP configures more HTTP listeners, each with its own endpoint and TLS settings.
+	AdditionalHTTP []confighttp.HTTPServerSettings `mapstructure:"additional_http"`
+}
+
+// grpcServers returns the settings of all the gRPC listeners.
+func (p *Protocols) grpcServers() []*configgrpc.GRPCServerSettings {
+	var servers []*configgrpc.GRPCServerSettings
+	if p.GRPC != nil {
+		servers = append(servers, p.GRPC)
+	}
+	for i := range p.AdditionalGRPC {
+		servers = append(servers, &p.AdditionalGRPC[i])
+	}
+	return servers
+}
+
+// httpServers returns the settings of all the HTTP listeners.
+func (p *Protocols) httpServers() []*confighttp.HTTPServerSettings {
+	var servers []*confighttp.HTTPServerSettings
+	if p.HTTP != nil {
+		servers = append(servers, p.HTTP)
+	}
+	for i := range p.AdditionalHTTP {
+		servers = append(servers, &p.AdditionalHTTP[i])
+	}
+	return servers
 }
 
 // Config defines configuration for OTLP receiver.
@@ -41,6 +73,17 @@ type Config struct {
 	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
 	Protocols `mapstructure:"protocols"`
+
+	// ResponseMetadata configures the metadata attached to the responses.
+	ResponseMetadata *ResponseMetadata `mapstructure:"response_metadata"`
+
+	// Backpressure configures the receiver to ask its clients to slow down when the sending queues
+	// of exporters are nearly full.
+	Backpressure *Backpressure `mapstructure:"backpressure"`
+
+	// MemoryLimiter is the ID of a memory limiter extension. While the extension refuses data, the
+	// requests are refused before being read, with a 429 HTTP status or a RESOURCE_EXHAUSTED gRPC status.
+	MemoryLimiter *component.ID `mapstructure:"memory_limiter"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -48,9 +91,37 @@ var _ confmap.Unmarshaler = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
-	if cfg.GRPC == nil && cfg.HTTP == nil {
+	grpcServers, httpServers := cfg.grpcServers(), cfg.httpServers()
+	if len(grpcServers) == 0 && len(httpServers) == 0 {
 		return errors.New("must specify at least one protocol when using the OTLP receiver")
 	}
+
+	endpoints := make([]string, 0, len(grpcServers)+len(httpServers))
+	for _, s := range grpcServers {
+		endpoints = append(endpoints, s.NetAddr.Endpoint)
+	}
+	for _, s := range httpServers {
+		endpoints = append(endpoints, s.Endpoint)
+	}
+	seen := map[string]struct{}{}
+	for _, endpoint := range endpoints {
+		if endpoint == "" {
+			continue
+		}
+		if _, ok := seen[endpoint]; ok {
+			return fmt.Errorf("endpoint %q is used by more than one listener", endpoint)
+		}
+		seen[endpoint] = struct{}{}
+	}
+
+	if cfg.Backpressure != nil {
+		if len(cfg.Backpressure.Exporters) == 0 {
+			return errors.New("backpressure requires at least one exporter")
+		}
+		if cfg.Backpressure.RetryDelay < 0 {
+			return errors.New("backpressure retry delay must not be negative")
+		}
+	}
 	return nil
 }
 
@@ -70,5 +141,15 @@ func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
 		cfg.HTTP = nil
 	}
 
+	// The additional gRPC listeners get the same defaults as the main one.
+	for i := range cfg.AdditionalGRPC {
+		if cfg.AdditionalGRPC[i].NetAddr.Transport == "" {
+			cfg.AdditionalGRPC[i].NetAddr.Transport = defaultGRPCTransport
+		}
+		if cfg.AdditionalGRPC[i].ReadBufferSize == 0 {
+			cfg.AdditionalGRPC[i].ReadBufferSize = defaultGRPCReadBufferSize
+		}
+	}
+
 	return nil
 }
@@ -171,6 +171,99 @@ func TestUnmarshalConfigUnix(t *testing.T) {
 		}, cfg)
 }
 
+func TestUnmarshalConfigMultipleEndpoints(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "multiple_endpoints.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.NoError(t, component.ValidateConfig(cfg))
+	tlsSetting := &configtls.TLSServerSetting{
+		TLSSetting: configtls.TLSSetting{
+			CertFile: "test.crt",
+			KeyFile:  "test.key",
+		},
+	}
+	assert.Equal(t,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(component.NewID(typeStr)),
+			Protocols: Protocols{
+				GRPC: &configgrpc.GRPCServerSettings{
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "127.0.0.1:4317",
+						Transport: "tcp",
+					},
+					ReadBufferSize: 512 * 1024,
+				},
+				HTTP: &confighttp.HTTPServerSettings{
+					Endpoint: "127.0.0.1:4318",
+				},
+				AdditionalGRPC: []configgrpc.GRPCServerSettings{{
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "[::1]:4317",
+						Transport: "tcp",
+					},
+					TLSSetting:     tlsSetting,
+					ReadBufferSize: 512 * 1024,
+				}},
+				AdditionalHTTP: []confighttp.HTTPServerSettings{{
+					Endpoint:   "[::1]:4318",
+					TLSSetting: tlsSetting,
+				}},
+			},
+		}, cfg)
+}
+
+func TestUnmarshalConfigDuplicateEndpoints(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "duplicate_endpoints.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.EqualError(t, component.ValidateConfig(cfg), `endpoint "127.0.0.1:4317" is used by more than one listener`)
+}
+
+func TestUnmarshalConfigResponseMetadata(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "response_metadata.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+
+	provider := component.NewID("ratelimiter")
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.ResponseMetadata = &ResponseMetadata{
+		Headers:  map[string]string{"x-quota-hint": "1000"},
+		Provider: &provider,
+	}
+	assert.Equal(t, expected, cfg)
+}
+
+func TestUnmarshalConfigBackpressure(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "backpressure.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.Backpressure = &Backpressure{
+		Exporters:  []component.ID{component.NewID("otlp")},
+		RetryDelay: 5 * time.Second,
+	}
+	assert.Equal(t, expected, cfg)
+}
+
+func TestValidateConfigBackpressure(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Backpressure = &Backpressure{}
+	assert.EqualError(t, component.ValidateConfig(cfg), "backpressure requires at least one exporter")
+
+	cfg.Backpressure = &Backpressure{Exporters: []component.ID{component.NewID("otlp")}, RetryDelay: -time.Second}
+	assert.EqualError(t, component.ValidateConfig(cfg), "backpressure retry delay must not be negative")
+}
+
 func TestUnmarshalConfigTypoDefaultProtocol(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "typo_default_proto_config.yaml"))
 	require.NoError(t, err)
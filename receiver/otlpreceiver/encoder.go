@@ -16,6 +16,7 @@ package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlprecei
 
 import (
 	"bytes"
+	"mime"
 
 	"github.com/gogo/protobuf/jsonpb"
 	"github.com/gogo/protobuf/proto"
@@ -37,6 +38,22 @@ var (
 	jsonMarshaler = &jsonpb.Marshaler{}
 )
 
+// encoderForContentType returns the encoder of the media type of the Content-Type header,
+// ignoring its parameters (e.g. "application/json; charset=utf-8").
+func encoderForContentType(contentType string) (encoder, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+	switch mediaType {
+	case pbContentType:
+		return pbEncoder, true
+	case jsonContentType:
+		return jsEncoder, true
+	}
+	return nil, false
+}
+
 type encoder interface {
 	unmarshalTracesRequest(buf []byte) (ptraceotlp.ExportRequest, error)
 	unmarshalMetricsRequest(buf []byte) (pmetricotlp.ExportRequest, error)
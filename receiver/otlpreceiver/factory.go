@@ -31,6 +31,10 @@ const (
 
 	defaultGRPCEndpoint = "0.0.0.0:4317"
 	defaultHTTPEndpoint = "0.0.0.0:4318"
+
+	defaultGRPCTransport = "tcp"
+	// We almost write 0 bytes, so no need to tune WriteBufferSize.
+	defaultGRPCReadBufferSize = 512 * 1024
 )
 
 // NewFactory creates a new OTLP receiver factory.
@@ -38,9 +42,9 @@ func NewFactory() component.ReceiverFactory {
 	return component.NewReceiverFactory(
 		typeStr,
 		createDefaultConfig,
-		component.WithTracesReceiver(createTracesReceiver, component.StabilityLevelStable),
-		component.WithMetricsReceiver(createMetricsReceiver, component.StabilityLevelStable),
-		component.WithLogsReceiver(createLogReceiver, component.StabilityLevelBeta))
+		component.WithTracesReceiver(component.NewCreateTracesReceiverFunc(createTracesReceiver), component.StabilityLevelStable),
+		component.WithMetricsReceiver(component.NewCreateMetricsReceiverFunc(createMetricsReceiver), component.StabilityLevelStable),
+		component.WithLogsReceiver(component.NewCreateLogsReceiverFunc(createLogReceiver), component.StabilityLevelBeta))
 }
 
 // createDefaultConfig creates the default configuration for receiver.
@@ -51,10 +55,9 @@ func createDefaultConfig() component.Config {
 			GRPC: &configgrpc.GRPCServerSettings{
 				NetAddr: confignet.NetAddr{
 					Endpoint:  defaultGRPCEndpoint,
-					Transport: "tcp",
+					Transport: defaultGRPCTransport,
 				},
-				// We almost write 0 bytes, so no need to tune WriteBufferSize.
-				ReadBufferSize: 512 * 1024,
+				ReadBufferSize: defaultGRPCReadBufferSize,
 			},
 			HTTP: &confighttp.HTTPServerSettings{
 				Endpoint: defaultHTTPEndpoint,
@@ -67,11 +70,11 @@ func createDefaultConfig() component.Config {
 func createTracesReceiver(
 	_ context.Context,
 	set component.ReceiverCreateSettings,
-	cfg component.Config,
+	cfg *Config,
 	nextConsumer consumer.Traces,
 ) (component.TracesReceiver, error) {
 	r := receivers.GetOrAdd(cfg, func() component.Component {
-		return newOtlpReceiver(cfg.(*Config), set)
+		return newOtlpReceiver(cfg, set)
 	})
 
 	if err := r.Unwrap().(*otlpReceiver).registerTraceConsumer(nextConsumer); err != nil {
@@ -84,11 +87,11 @@ func createTracesReceiver(
 func createMetricsReceiver(
 	_ context.Context,
 	set component.ReceiverCreateSettings,
-	cfg component.Config,
+	cfg *Config,
 	consumer consumer.Metrics,
 ) (component.MetricsReceiver, error) {
 	r := receivers.GetOrAdd(cfg, func() component.Component {
-		return newOtlpReceiver(cfg.(*Config), set)
+		return newOtlpReceiver(cfg, set)
 	})
 
 	if err := r.Unwrap().(*otlpReceiver).registerMetricsConsumer(consumer); err != nil {
@@ -101,11 +104,11 @@ func createMetricsReceiver(
 func createLogReceiver(
 	_ context.Context,
 	set component.ReceiverCreateSettings,
-	cfg component.Config,
+	cfg *Config,
 	consumer consumer.Logs,
 ) (component.LogsReceiver, error) {
 	r := receivers.GetOrAdd(cfg, func() component.Component {
-		return newOtlpReceiver(cfg.(*Config), set)
+		return newOtlpReceiver(cfg, set)
 	})
 
 	if err := r.Unwrap().(*otlpReceiver).registerLogsConsumer(consumer); err != nil {
@@ -20,7 +20,9 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 const (
@@ -28,10 +30,13 @@ const (
 	receiverTransport  = "grpc"
 )
 
+var logsSizer = &plog.ProtoMarshaler{}
+
 // Receiver is the type used to handle spans from OpenTelemetry exporters.
 type Receiver struct {
 	nextConsumer consumer.Logs
 	obsrecv      *obsreport.Receiver
+	errTrans     receiverhelper.ErrorTranslator
 }
 
 // New creates a new Receiver reference.
@@ -48,11 +53,18 @@ func New(nextConsumer consumer.Logs, set component.ReceiverCreateSettings) (*Rec
 	return &Receiver{
 		nextConsumer: nextConsumer,
 		obsrecv:      obsrecv,
+		errTrans:     receiverhelper.NewErrorTranslator(),
 	}, nil
 }
 
 // Export implements the service Export logs func.
 func (r *Receiver) Export(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	// The logs have the same protobuf encoding as the request, so their size is the size of the received message.
+	return r.ExportWithBytes(ctx, req, int64(logsSizer.LogsSize(req.Logs())))
+}
+
+// ExportWithBytes exports the request, which was received as numBytes bytes, e.g. the body of an HTTP request.
+func (r *Receiver) ExportWithBytes(ctx context.Context, req plogotlp.ExportRequest, numBytes int64) (plogotlp.ExportResponse, error) {
 	ld := req.Logs()
 	numSpans := ld.LogRecordCount()
 	if numSpans == 0 {
@@ -61,7 +73,7 @@ func (r *Receiver) Export(ctx context.Context, req plogotlp.ExportRequest) (plog
 
 	ctx = r.obsrecv.StartLogsOp(ctx)
 	err := r.nextConsumer.ConsumeLogs(ctx, ld)
-	r.obsrecv.EndLogsOp(ctx, dataFormatProtobuf, numSpans, err)
+	r.obsrecv.EndLogsOpWithBytes(ctx, dataFormatProtobuf, numSpans, numBytes, err)
 
-	return plogotlp.NewExportResponse(), err
+	return plogotlp.NewExportResponse(), r.errTrans.GRPCError(err)
 }
@@ -20,7 +20,9 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 const (
@@ -28,10 +30,13 @@ const (
 	receiverTransport  = "grpc"
 )
 
+var metricsSizer = &pmetric.ProtoMarshaler{}
+
 // Receiver is the type used to handle metrics from OpenTelemetry exporters.
 type Receiver struct {
 	nextConsumer consumer.Metrics
 	obsrecv      *obsreport.Receiver
+	errTrans     receiverhelper.ErrorTranslator
 }
 
 // New creates a new Receiver reference.
@@ -48,11 +53,18 @@ func New(nextConsumer consumer.Metrics, set component.ReceiverCreateSettings) (*
 	return &Receiver{
 		nextConsumer: nextConsumer,
 		obsrecv:      obsrecv,
+		errTrans:     receiverhelper.NewErrorTranslator(),
 	}, nil
 }
 
 // Export implements the service Export metrics func.
 func (r *Receiver) Export(ctx context.Context, req pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+	// The metrics have the same protobuf encoding as the request, so their size is the size of the received message.
+	return r.ExportWithBytes(ctx, req, int64(metricsSizer.MetricsSize(req.Metrics())))
+}
+
+// ExportWithBytes exports the request, which was received as numBytes bytes, e.g. the body of an HTTP request.
+func (r *Receiver) ExportWithBytes(ctx context.Context, req pmetricotlp.ExportRequest, numBytes int64) (pmetricotlp.ExportResponse, error) {
 	md := req.Metrics()
 	dataPointCount := md.DataPointCount()
 	if dataPointCount == 0 {
@@ -61,7 +73,7 @@ func (r *Receiver) Export(ctx context.Context, req pmetricotlp.ExportRequest) (p
 
 	ctx = r.obsrecv.StartMetricsOp(ctx)
 	err := r.nextConsumer.ConsumeMetrics(ctx, md)
-	r.obsrecv.EndMetricsOp(ctx, dataFormatProtobuf, dataPointCount, err)
+	r.obsrecv.EndMetricsOpWithBytes(ctx, dataFormatProtobuf, dataPointCount, numBytes, err)
 
-	return pmetricotlp.NewExportResponse(), err
+	return pmetricotlp.NewExportResponse(), r.errTrans.GRPCError(err)
 }
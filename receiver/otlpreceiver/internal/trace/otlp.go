@@ -20,7 +20,9 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 const (
@@ -28,10 +30,13 @@ const (
 	receiverTransport  = "grpc"
 )
 
+var tracesSizer = &ptrace.ProtoMarshaler{}
+
 // Receiver is the type used to handle spans from OpenTelemetry exporters.
 type Receiver struct {
 	nextConsumer consumer.Traces
 	obsrecv      *obsreport.Receiver
+	errTrans     receiverhelper.ErrorTranslator
 }
 
 // New creates a new Receiver reference.
@@ -48,11 +53,18 @@ func New(nextConsumer consumer.Traces, set component.ReceiverCreateSettings) (*R
 	return &Receiver{
 		nextConsumer: nextConsumer,
 		obsrecv:      obsrecv,
+		errTrans:     receiverhelper.NewErrorTranslator(),
 	}, nil
 }
 
 // Export implements the service Export traces func.
 func (r *Receiver) Export(ctx context.Context, req ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	// The traces have the same protobuf encoding as the request, so their size is the size of the received message.
+	return r.ExportWithBytes(ctx, req, int64(tracesSizer.TracesSize(req.Traces())))
+}
+
+// ExportWithBytes exports the request, which was received as numBytes bytes, e.g. the body of an HTTP request.
+func (r *Receiver) ExportWithBytes(ctx context.Context, req ptraceotlp.ExportRequest, numBytes int64) (ptraceotlp.ExportResponse, error) {
 	td := req.Traces()
 	// We need to ensure that it propagates the receiver name as a tag
 	numSpans := td.SpanCount()
@@ -62,7 +74,7 @@ func (r *Receiver) Export(ctx context.Context, req ptraceotlp.ExportRequest) (pt
 
 	ctx = r.obsrecv.StartTracesOp(ctx)
 	err := r.nextConsumer.ConsumeTraces(ctx, td)
-	r.obsrecv.EndTracesOp(ctx, dataFormatProtobuf, numSpans, err)
+	r.obsrecv.EndTracesOpWithBytes(ctx, dataFormatProtobuf, numSpans, numBytes, err)
 
-	return ptraceotlp.NewExportResponse(), err
+	return ptraceotlp.NewExportResponse(), r.errTrans.GRPCError(err)
 }
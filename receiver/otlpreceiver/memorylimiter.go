@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/tap"
+
+	"go.opentelemetry.io/collector/extension/experimental/memorylimiter"
+)
+
+const memoryLimitedMsg = "memory usage of the collector is above the limit, slow down"
+
+// memoryLimitedTapHandle refuses the gRPC requests while the memory limiter extension is refusing data,
+// before the request messages are read.
+func memoryLimitedTapHandle(ml memorylimiter.Extension) tap.ServerInHandle {
+	return func(ctx context.Context, _ *tap.Info) (context.Context, error) {
+		if ml.MustRefuse() {
+			return ctx, status.Error(codes.ResourceExhausted, memoryLimitedMsg)
+		}
+		return ctx, nil
+	}
+}
+
+// memoryLimitedHTTPHandler refuses the HTTP requests with a 429 response while the memory limiter extension
+// is refusing data, before the request bodies are read.
+func memoryLimitedHTTPHandler(ml memorylimiter.Extension, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if !ml.MustRefuse() {
+			next.ServeHTTP(resp, req)
+			return
+		}
+		if encoder, ok := encoderForContentType(req.Header.Get("Content-Type")); ok {
+			writeStatusResponse(resp, encoder, http.StatusTooManyRequests, status.New(codes.ResourceExhausted, memoryLimitedMsg).Proto())
+			return
+		}
+		setRetryAfter(resp, http.StatusTooManyRequests, nil)
+		http.Error(resp, memoryLimitedMsg, http.StatusTooManyRequests)
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type fakeMemoryLimiter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	refuse bool
+}
+
+func (f *fakeMemoryLimiter) MustRefuse() bool {
+	return f.refuse
+}
+
+func TestMemoryLimitedTapHandle(t *testing.T) {
+	ml := &fakeMemoryLimiter{}
+	tap := memoryLimitedTapHandle(ml)
+
+	_, err := tap(context.Background(), nil)
+	assert.NoError(t, err)
+
+	ml.refuse = true
+	_, err = tap(context.Background(), nil)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestMemoryLimitedHTTPHandler(t *testing.T) {
+	ml := &fakeMemoryLimiter{}
+	handler := memoryLimitedHTTPHandler(ml, http.HandlerFunc(func(resp http.ResponseWriter, _ *http.Request) {
+		resp.WriteHeader(http.StatusOK)
+	}))
+
+	newRequest := func(contentType string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/v1/traces", nil)
+		req.Header.Set("Content-Type", contentType)
+		return req
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest(pbContentType))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	ml.refuse = true
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest(pbContentType))
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	st := &spb.Status{}
+	require.NoError(t, proto.Unmarshal(rec.Body.Bytes(), st))
+	assert.Equal(t, int32(codes.ResourceExhausted), st.Code)
+	assert.Equal(t, memoryLimitedMsg, st.Message)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest("text/plain"))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
@@ -20,8 +20,10 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
@@ -29,6 +31,7 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/memorylimiter"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
@@ -39,16 +42,21 @@ import (
 
 // otlpReceiver is the type that exposes Trace and Metrics reception.
 type otlpReceiver struct {
-	cfg        *Config
-	serverGRPC *grpc.Server
-	httpMux    *http.ServeMux
-	serverHTTP *http.Server
+	cfg         *Config
+	serversGRPC []*grpc.Server
+	httpMux     *http.ServeMux
+	serversHTTP []*http.Server
 
 	traceReceiver   *trace.Receiver
 	metricsReceiver *metrics.Receiver
 	logReceiver     *logs.Receiver
 	shutdownWG      sync.WaitGroup
 
+	// backpressure is nil unless the receiver is configured to propagate the backpressure of exporters.
+	backpressure *backpressure
+	// memoryLimiter is nil unless the receiver is configured to consult a memory limiter extension.
+	memoryLimiter memorylimiter.Extension
+
 	settings component.ReceiverCreateSettings
 }
 
@@ -60,14 +68,17 @@ func newOtlpReceiver(cfg *Config, settings component.ReceiverCreateSettings) *ot
 		cfg:      cfg,
 		settings: settings,
 	}
-	if cfg.HTTP != nil {
+	if len(cfg.httpServers()) > 0 {
 		r.httpMux = http.NewServeMux()
 	}
+	if cfg.Backpressure != nil {
+		r.backpressure = newBackpressure(cfg.Backpressure)
+	}
 
 	return r
 }
 
-func (r *otlpReceiver) startGRPCServer(cfg *configgrpc.GRPCServerSettings, host component.Host) error {
+func (r *otlpReceiver) startGRPCServer(server *grpc.Server, cfg *configgrpc.GRPCServerSettings, host component.Host) error {
 	r.settings.Logger.Info("Starting GRPC server", zap.String("endpoint", cfg.NetAddr.Endpoint))
 
 	gln, err := cfg.ToListener()
@@ -78,14 +89,14 @@ func (r *otlpReceiver) startGRPCServer(cfg *configgrpc.GRPCServerSettings, host
 	go func() {
 		defer r.shutdownWG.Done()
 
-		if errGrpc := r.serverGRPC.Serve(gln); errGrpc != nil && !errors.Is(errGrpc, grpc.ErrServerStopped) {
+		if errGrpc := server.Serve(gln); errGrpc != nil && !errors.Is(errGrpc, grpc.ErrServerStopped) {
 			host.ReportFatalError(errGrpc)
 		}
 	}()
 	return nil
 }
 
-func (r *otlpReceiver) startHTTPServer(cfg *confighttp.HTTPServerSettings, host component.Host) error {
+func (r *otlpReceiver) startHTTPServer(server *http.Server, cfg *confighttp.HTTPServerSettings, host component.Host) error {
 	r.settings.Logger.Info("Starting HTTP server", zap.String("endpoint", cfg.Endpoint))
 	var hln net.Listener
 	hln, err := cfg.ToListener()
@@ -96,7 +107,7 @@ func (r *otlpReceiver) startHTTPServer(cfg *confighttp.HTTPServerSettings, host
 	go func() {
 		defer r.shutdownWG.Done()
 
-		if errHTTP := r.serverHTTP.Serve(hln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+		if errHTTP := server.Serve(hln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
 			host.ReportFatalError(errHTTP)
 		}
 	}()
@@ -104,42 +115,65 @@ func (r *otlpReceiver) startHTTPServer(cfg *confighttp.HTTPServerSettings, host
 }
 
 func (r *otlpReceiver) startProtocolServers(host component.Host) error {
-	var err error
-	if r.cfg.GRPC != nil {
-		r.serverGRPC, err = r.cfg.GRPC.ToServer(host, r.settings.TelemetrySettings)
+	responseMetadata, err := newResponseMetadataFunc(r.cfg.ResponseMetadata, host)
+	if err != nil {
+		return err
+	}
+
+	// Every listener gets its own server, so that each of them can have its own TLS settings.
+	for _, grpcCfg := range r.cfg.grpcServers() {
+		var grpcOpts []grpc.ServerOption
+		if responseMetadata != nil {
+			grpcOpts = append(grpcOpts, grpc.ChainUnaryInterceptor(responseMetadata.unaryServerInterceptor))
+		}
+		if r.memoryLimiter != nil {
+			grpcOpts = append(grpcOpts, grpc.InTapHandle(memoryLimitedTapHandle(r.memoryLimiter)))
+		}
+		var serverGRPC *grpc.Server
+		serverGRPC, err = grpcCfg.ToServer(host, r.settings.TelemetrySettings, grpcOpts...)
 		if err != nil {
 			return err
 		}
+		r.serversGRPC = append(r.serversGRPC, serverGRPC)
 
 		if r.traceReceiver != nil {
-			ptraceotlp.RegisterGRPCServer(r.serverGRPC, r.traceReceiver)
+			ptraceotlp.RegisterGRPCServer(serverGRPC, r.traceReceiver)
 		}
 
 		if r.metricsReceiver != nil {
-			pmetricotlp.RegisterGRPCServer(r.serverGRPC, r.metricsReceiver)
+			pmetricotlp.RegisterGRPCServer(serverGRPC, r.metricsReceiver)
 		}
 
 		if r.logReceiver != nil {
-			plogotlp.RegisterGRPCServer(r.serverGRPC, r.logReceiver)
+			plogotlp.RegisterGRPCServer(serverGRPC, r.logReceiver)
 		}
 
-		err = r.startGRPCServer(r.cfg.GRPC, host)
+		err = r.startGRPCServer(serverGRPC, grpcCfg, host)
 		if err != nil {
 			return err
 		}
 	}
-	if r.cfg.HTTP != nil {
-		r.serverHTTP, err = r.cfg.HTTP.ToServer(
+	for _, httpCfg := range r.cfg.httpServers() {
+		var handler http.Handler = r.httpMux
+		if responseMetadata != nil {
+			handler = responseMetadata.httpHandler(handler)
+		}
+		if r.memoryLimiter != nil {
+			handler = memoryLimitedHTTPHandler(r.memoryLimiter, handler)
+		}
+		var serverHTTP *http.Server
+		serverHTTP, err = httpCfg.ToServer(
 			host,
 			r.settings.TelemetrySettings,
-			r.httpMux,
+			handler,
 			confighttp.WithErrorHandler(errorHandler),
 		)
 		if err != nil {
 			return err
 		}
+		r.serversHTTP = append(r.serversHTTP, serverHTTP)
 
-		err = r.startHTTPServer(r.cfg.HTTP, host)
+		err = r.startHTTPServer(serverHTTP, httpCfg, host)
 		if err != nil {
 			return err
 		}
@@ -151,6 +185,18 @@ func (r *otlpReceiver) startProtocolServers(host component.Host) error {
 // Start runs the trace receiver on the gRPC server. Currently
 // it also enables the metrics receiver too.
 func (r *otlpReceiver) Start(_ context.Context, host component.Host) error {
+	if r.backpressure != nil {
+		if err := r.backpressure.start(host); err != nil {
+			return err
+		}
+	}
+	if r.cfg.MemoryLimiter != nil {
+		ml, err := memorylimiter.GetExtension(host, *r.cfg.MemoryLimiter)
+		if err != nil {
+			return err
+		}
+		r.memoryLimiter = ml
+	}
 	return r.startProtocolServers(host)
 }
 
@@ -158,12 +204,12 @@ func (r *otlpReceiver) Start(_ context.Context, host component.Host) error {
 func (r *otlpReceiver) Shutdown(ctx context.Context) error {
 	var err error
 
-	if r.serverHTTP != nil {
-		err = r.serverHTTP.Shutdown(ctx)
+	for _, serverHTTP := range r.serversHTTP {
+		err = multierr.Append(err, serverHTTP.Shutdown(ctx))
 	}
 
-	if r.serverGRPC != nil {
-		r.serverGRPC.GracefulStop()
+	for _, serverGRPC := range r.serversGRPC {
+		serverGRPC.GracefulStop()
 	}
 
 	r.shutdownWG.Wait()
@@ -174,26 +220,18 @@ func (r *otlpReceiver) registerTraceConsumer(tc consumer.Traces) error {
 	if tc == nil {
 		return component.ErrNilNextConsumer
 	}
+	if r.backpressure != nil {
+		tc = r.backpressure.wrapTraces(tc)
+	}
 	var err error
 	r.traceReceiver, err = trace.New(tc, r.settings)
 	if err != nil {
 		return err
 	}
 	if r.httpMux != nil {
-		r.httpMux.HandleFunc("/v1/traces", func(resp http.ResponseWriter, req *http.Request) {
-			if req.Method != http.MethodPost {
-				handleUnmatchedMethod(resp)
-				return
-			}
-			switch req.Header.Get("Content-Type") {
-			case pbContentType:
-				handleTraces(resp, req, r.traceReceiver, pbEncoder)
-			case jsonContentType:
-				handleTraces(resp, req, r.traceReceiver, jsEncoder)
-			default:
-				handleUnmatchedContentType(resp)
-			}
-		})
+		r.httpMux.HandleFunc("/v1/traces", httpHandler(func(resp http.ResponseWriter, req *http.Request, encoder encoder) {
+			handleTraces(resp, req, r.traceReceiver, encoder)
+		}))
 	}
 	return nil
 }
@@ -202,6 +240,9 @@ func (r *otlpReceiver) registerMetricsConsumer(mc consumer.Metrics) error {
 	if mc == nil {
 		return component.ErrNilNextConsumer
 	}
+	if r.backpressure != nil {
+		mc = r.backpressure.wrapMetrics(mc)
+	}
 	var err error
 	r.metricsReceiver, err = metrics.New(mc, r.settings)
 	if err != nil {
@@ -209,20 +250,9 @@ func (r *otlpReceiver) registerMetricsConsumer(mc consumer.Metrics) error {
 	}
 
 	if r.httpMux != nil {
-		r.httpMux.HandleFunc("/v1/metrics", func(resp http.ResponseWriter, req *http.Request) {
-			if req.Method != http.MethodPost {
-				handleUnmatchedMethod(resp)
-				return
-			}
-			switch req.Header.Get("Content-Type") {
-			case pbContentType:
-				handleMetrics(resp, req, r.metricsReceiver, pbEncoder)
-			case jsonContentType:
-				handleMetrics(resp, req, r.metricsReceiver, jsEncoder)
-			default:
-				handleUnmatchedContentType(resp)
-			}
-		})
+		r.httpMux.HandleFunc("/v1/metrics", httpHandler(func(resp http.ResponseWriter, req *http.Request, encoder encoder) {
+			handleMetrics(resp, req, r.metricsReceiver, encoder)
+		}))
 	}
 	return nil
 }
@@ -231,6 +261,9 @@ func (r *otlpReceiver) registerLogsConsumer(lc consumer.Logs) error {
 	if lc == nil {
 		return component.ErrNilNextConsumer
 	}
+	if r.backpressure != nil {
+		lc = r.backpressure.wrapLogs(lc)
+	}
 	var err error
 	r.logReceiver, err = logs.New(lc, r.settings)
 	if err != nil {
@@ -238,24 +271,35 @@ func (r *otlpReceiver) registerLogsConsumer(lc consumer.Logs) error {
 	}
 
 	if r.httpMux != nil {
-		r.httpMux.HandleFunc("/v1/logs", func(resp http.ResponseWriter, req *http.Request) {
-			if req.Method != http.MethodPost {
-				handleUnmatchedMethod(resp)
-				return
-			}
-			switch req.Header.Get("Content-Type") {
-			case pbContentType:
-				handleLogs(resp, req, r.logReceiver, pbEncoder)
-			case jsonContentType:
-				handleLogs(resp, req, r.logReceiver, jsEncoder)
-			default:
-				handleUnmatchedContentType(resp)
-			}
-		})
+		r.httpMux.HandleFunc("/v1/logs", httpHandler(func(resp http.ResponseWriter, req *http.Request, encoder encoder) {
+			handleLogs(resp, req, r.logReceiver, encoder)
+		}))
 	}
 	return nil
 }
 
+// httpHandler returns the handler of an OTLP/HTTP endpoint, calling handle with the encoder of the Content-Type
+// of the request.
+func httpHandler(handle func(resp http.ResponseWriter, req *http.Request, encoder encoder)) http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			handleUnmatchedMethod(resp)
+			return
+		}
+		encoder, ok := encoderForContentType(req.Header.Get("Content-Type"))
+		if !ok {
+			handleUnmatchedContentType(resp)
+			return
+		}
+		// The supported encodings are decompressed by the confighttp server, which removes the header.
+		if contentEncoding := req.Header.Get("Content-Encoding"); contentEncoding != "" && !strings.EqualFold(contentEncoding, "identity") {
+			writeError(resp, encoder, fmt.Errorf("unsupported Content-Encoding %q, supported: [%s]", contentEncoding, strings.Join(confighttp.SupportedContentEncodings(), ", ")), http.StatusUnsupportedMediaType)
+			return
+		}
+		handle(resp, req, encoder)
+	}
+}
+
 func handleUnmatchedMethod(resp http.ResponseWriter) {
 	status := http.StatusMethodNotAllowed
 	writeResponse(resp, "text/plain", status, []byte(fmt.Sprintf("%v method not allowed, supported: [POST]", status)))
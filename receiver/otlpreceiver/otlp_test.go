@@ -17,6 +17,7 @@ package otlpreceiver
 import (
 	"bytes"
 	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/json"
 	"errors"
@@ -138,9 +139,10 @@ var traceOtlp = func() ptrace.Traces {
 
 func TestJsonHttp(t *testing.T) {
 	tests := []struct {
-		name     string
-		encoding string
-		err      error
+		name        string
+		encoding    string
+		contentType string
+		err         error
 	}{
 		{
 			name:     "JSONUncompressed",
@@ -150,6 +152,19 @@ func TestJsonHttp(t *testing.T) {
 			name:     "JSONGzipCompressed",
 			encoding: "gzip",
 		},
+		{
+			name:     "JSONDeflateCompressed",
+			encoding: "deflate",
+		},
+		{
+			name:     "JSONIdentityEncoding",
+			encoding: "identity",
+		},
+		{
+			name:        "JSONWithCharset",
+			encoding:    "gzip",
+			contentType: "application/json; charset=utf-8",
+		},
 		{
 			name:     "NotGRPCError",
 			encoding: "",
@@ -178,11 +193,43 @@ func TestJsonHttp(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			url := fmt.Sprintf("http://%s/v1/traces", addr)
 			sink.Reset()
-			testHTTPJSONRequest(t, url, sink, test.encoding, test.err)
+			contentType := test.contentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			testHTTPJSONRequest(t, url, sink, test.encoding, contentType, test.err)
 		})
 	}
 }
 
+func TestJsonHttpUnsupportedEncoding(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := &errOrSinkConsumer{TracesSink: new(consumertest.TracesSink)}
+	ocr := newHTTPReceiver(t, addr, sink, nil)
+
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/v1/traces", addr), bytes.NewBuffer(traceJSON))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "br")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	respBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	errStatus := &spb.Status{}
+	require.NoError(t, json.Unmarshal(respBytes, errStatus))
+	assert.Equal(t, int32(codes.InvalidArgument), errStatus.Code)
+	assert.Equal(t, `unsupported Content-Encoding "br", supported: [deflate, gzip, zlib, zstd]`, errStatus.Message)
+	assert.Len(t, sink.AllTraces(), 0)
+}
+
 func TestHandleInvalidRequests(t *testing.T) {
 	endpoint := testutil.GetAvailableLocalAddress(t)
 	cfg := &Config{
@@ -336,20 +383,23 @@ func TestHandleInvalidRequests(t *testing.T) {
 	require.NoError(t, err)
 }
 
-func testHTTPJSONRequest(t *testing.T, url string, sink *errOrSinkConsumer, encoding string, expectedErr error) {
+func testHTTPJSONRequest(t *testing.T, url string, sink *errOrSinkConsumer, encoding string, contentType string, expectedErr error) {
 	var buf *bytes.Buffer
 	var err error
 	switch encoding {
 	case "gzip":
 		buf, err = compressGzip(traceJSON)
 		require.NoError(t, err, "Error while gzip compressing trace: %v", err)
+	case "deflate":
+		buf, err = compressZlib(traceJSON)
+		require.NoError(t, err, "Error while zlib compressing trace: %v", err)
 	default:
 		buf = bytes.NewBuffer(traceJSON)
 	}
 	sink.SetConsumeError(expectedErr)
 	req, err := http.NewRequest("POST", url, buf)
 	require.NoError(t, err, "Error creating trace POST request: %v", err)
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Content-Encoding", encoding)
 
 	client := &http.Client{}
@@ -380,8 +430,8 @@ func testHTTPJSONRequest(t *testing.T, url string, sink *errOrSinkConsumer, enco
 			assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 			assert.True(t, proto.Equal(errStatus, s.Proto()))
 		} else {
-			assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
-			assert.True(t, proto.Equal(errStatus, &spb.Status{Code: int32(codes.Unknown), Message: "my error"}))
+			assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+			assert.True(t, proto.Equal(errStatus, &spb.Status{Code: int32(codes.Unavailable), Message: "my error"}))
 		}
 		require.Len(t, allTraces, 0)
 	}
@@ -503,8 +553,8 @@ func testHTTPProtobufRequest(
 			assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 			assert.True(t, proto.Equal(errStatus, s.Proto()))
 		} else {
-			assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
-			assert.True(t, proto.Equal(errStatus, &spb.Status{Code: int32(codes.Unknown), Message: "my error"}))
+			assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+			assert.True(t, proto.Equal(errStatus, &spb.Status{Code: int32(codes.Unavailable), Message: "my error"}))
 		}
 		require.Len(t, allTraces, 0)
 	}
@@ -642,7 +692,7 @@ func TestOTLPReceiverTrace_HandleNextConsumerResponse(t *testing.T) {
 				},
 				{
 					okToIngest:   false,
-					expectedCode: codes.Unknown,
+					expectedCode: codes.Unavailable,
 				},
 				{
 					okToIngest:   true,
@@ -708,6 +758,51 @@ func TestOTLPReceiverTrace_HandleNextConsumerResponse(t *testing.T) {
 	}
 }
 
+func TestOTLPReceiverRecordsBytes(t *testing.T) {
+	td := testdata.GenerateTraces(2)
+	traceBytes, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+
+	t.Run("grpc", func(t *testing.T) {
+		id := component.NewIDWithName(typeStr, "bytes")
+		tt, err := obsreporttest.SetupTelemetryWithID(id)
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+		addr := testutil.GetAvailableLocalAddress(t)
+		ocr := newGRPCReceiver(t, "bytes", addr, consumertest.NewNop(), nil)
+		require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+		t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+		cc, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+		require.NoError(t, err)
+		defer func() {
+			assert.NoError(t, cc.Close())
+		}()
+		require.NoError(t, exportTraces(cc, td))
+		require.NoError(t, obsreporttest.CheckReceiverBytes(tt, id, "grpc", int64(len(traceBytes)), 0))
+	})
+
+	t.Run("http", func(t *testing.T) {
+		tt, err := obsreporttest.SetupTelemetryWithID(otlpReceiverID)
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+		addr := testutil.GetAvailableLocalAddress(t)
+		ocr := newHTTPReceiver(t, addr, consumertest.NewNop(), nil)
+		require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+		t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+		// The size of the decompressed body is recorded.
+		req := createHTTPProtobufRequest(t, fmt.Sprintf("http://%s/v1/traces", addr), "gzip", traceBytes)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NoError(t, obsreporttest.CheckReceiverBytes(tt, otlpReceiverID, "grpc", int64(len(traceBytes)), 0))
+	})
+}
+
 func TestGRPCInvalidTLSCredentials(t *testing.T) {
 	cfg := &Config{
 		ReceiverSettings: config.NewReceiverSettings(component.NewID(typeStr)),
@@ -847,7 +942,7 @@ func TestHTTPMaxRequestBodySize_OK(t *testing.T) {
 }
 
 func TestHTTPMaxRequestBodySize_TooLarge(t *testing.T) {
-	testHTTPMaxRequestBodySizeJSON(t, traceJSON, len(traceJSON)-1, 400)
+	testHTTPMaxRequestBodySizeJSON(t, traceJSON, len(traceJSON)-1, 413)
 }
 
 func newGRPCReceiver(t *testing.T, name string, endpoint string, tc consumer.Traces, mc consumer.Metrics) component.Component {
@@ -897,6 +992,20 @@ func compressGzip(body []byte) (*bytes.Buffer, error) {
 	return &buf, nil
 }
 
+func compressZlib(body []byte) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+
+	zw := zlib.NewWriter(&buf)
+	defer zw.Close()
+
+	_, err := zw.Write(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
 type senderFunc func(td ptrace.Traces)
 
 func TestShutdown(t *testing.T) {
@@ -1066,3 +1175,44 @@ func (esc *errOrSinkConsumer) Reset() {
 		esc.MetricsSink.Reset()
 	}
 }
+
+func TestMultipleEndpoints(t *testing.T) {
+	grpcEndpoints := []string{testutil.GetAvailableLocalAddress(t), testutil.GetAvailableLocalAddress(t)}
+	httpEndpoints := []string{testutil.GetAvailableLocalAddress(t), testutil.GetAvailableLocalAddress(t)}
+	sink := new(consumertest.TracesSink)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = grpcEndpoints[0]
+	cfg.HTTP.Endpoint = httpEndpoints[0]
+	cfg.AdditionalGRPC = []configgrpc.GRPCServerSettings{{
+		NetAddr: confignet.NetAddr{Endpoint: grpcEndpoints[1], Transport: "tcp"},
+	}}
+	cfg.AdditionalHTTP = []confighttp.HTTPServerSettings{{Endpoint: httpEndpoints[1]}}
+	require.NoError(t, cfg.Validate())
+
+	ocr := newReceiver(t, factory, cfg, otlpReceiverID, sink, nil)
+	require.NotNil(t, ocr)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	td := testdata.GenerateTraces(1)
+	for _, endpoint := range grpcEndpoints {
+		cc, err := grpc.Dial(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+		require.NoError(t, err)
+		require.NoError(t, exportTraces(cc, td))
+		assert.NoError(t, cc.Close())
+	}
+
+	traceBytes, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	for _, endpoint := range httpEndpoints {
+		req := createHTTPProtobufRequest(t, fmt.Sprintf("http://%s/v1/traces", endpoint), "", traceBytes)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	assert.Len(t, sink.AllTraces(), 4)
+}
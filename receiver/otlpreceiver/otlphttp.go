@@ -17,7 +17,10 @@ package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlprecei
 import (
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -25,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 // Pre-computed status with code=Internal to be used in case of a marshaling error.
@@ -32,6 +36,13 @@ var fallbackMsg = []byte(`{"code": 13, "message": "failed to marshal error messa
 
 const fallbackContentType = "application/json"
 
+// defaultRetryAfter is the delay sent in the Retry-After header of the 429 and 503 responses whose status
+// does not carry any retry information.
+const defaultRetryAfter = time.Second
+
+// errTranslator translates the errors of the next consumer to the HTTP status codes, consistently with gRPC.
+var errTranslator = receiverhelper.NewErrorTranslator()
+
 func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver, encoder encoder) {
 	body, ok := readAndCloseBody(resp, req, encoder)
 	if !ok {
@@ -44,9 +55,9 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 		return
 	}
 
-	otlpResp, err := tracesReceiver.Export(req.Context(), otlpReq)
+	otlpResp, err := tracesReceiver.ExportWithBytes(req.Context(), otlpReq, int64(len(body)))
 	if err != nil {
-		writeError(resp, encoder, err, http.StatusInternalServerError)
+		writeError(resp, encoder, err, errTranslator.HTTPStatus(err))
 		return
 	}
 
@@ -70,9 +81,9 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 		return
 	}
 
-	otlpResp, err := metricsReceiver.Export(req.Context(), otlpReq)
+	otlpResp, err := metricsReceiver.ExportWithBytes(req.Context(), otlpReq, int64(len(body)))
 	if err != nil {
-		writeError(resp, encoder, err, http.StatusInternalServerError)
+		writeError(resp, encoder, err, errTranslator.HTTPStatus(err))
 		return
 	}
 
@@ -96,9 +107,9 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 		return
 	}
 
-	otlpResp, err := logsReceiver.Export(req.Context(), otlpReq)
+	otlpResp, err := logsReceiver.ExportWithBytes(req.Context(), otlpReq, int64(len(body)))
 	if err != nil {
-		writeError(resp, encoder, err, http.StatusInternalServerError)
+		writeError(resp, encoder, err, errTranslator.HTTPStatus(err))
 		return
 	}
 
@@ -136,13 +147,8 @@ func writeError(w http.ResponseWriter, encoder encoder, err error, statusCode in
 // by the OTLP protocol.
 func errorHandler(w http.ResponseWriter, r *http.Request, errMsg string, statusCode int) {
 	s := errorMsgToStatus(errMsg, statusCode)
-	contentType := r.Header.Get("Content-Type")
-	switch contentType {
-	case pbContentType:
-		writeStatusResponse(w, pbEncoder, statusCode, s.Proto())
-		return
-	case jsonContentType:
-		writeStatusResponse(w, jsEncoder, statusCode, s.Proto())
+	if encoder, ok := encoderForContentType(r.Header.Get("Content-Type")); ok {
+		writeStatusResponse(w, encoder, statusCode, s.Proto())
 		return
 	}
 	writeResponse(w, fallbackContentType, http.StatusInternalServerError, fallbackMsg)
@@ -155,9 +161,30 @@ func writeStatusResponse(w http.ResponseWriter, encoder encoder, statusCode int,
 		return
 	}
 
+	setRetryAfter(w, statusCode, rsp)
 	writeResponse(w, encoder.contentType(), statusCode, msg)
 }
 
+// setRetryAfter sets the Retry-After header of the 429 and 503 responses, to the retry delay of the status
+// if any, otherwise to defaultRetryAfter, so that the clients back off before retrying the request.
+func setRetryAfter(w http.ResponseWriter, statusCode int, rsp *spb.Status) {
+	if statusCode != http.StatusTooManyRequests && statusCode != http.StatusServiceUnavailable {
+		return
+	}
+	delay := defaultRetryAfter
+	if rsp != nil {
+		for _, detail := range status.FromProto(rsp).Details() {
+			if retryInfo, ok := detail.(*errdetails.RetryInfo); ok && retryInfo.RetryDelay.AsDuration() > 0 {
+				delay = retryInfo.RetryDelay.AsDuration()
+				break
+			}
+		}
+	}
+	// The header is a whole number of seconds, round up so that the clients never retry too early.
+	seconds := int64((delay + time.Second - 1) / time.Second)
+	w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+}
+
 func writeResponse(w http.ResponseWriter, contentType string, statusCode int, msg []byte) {
 	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(statusCode)
@@ -166,7 +193,7 @@ func writeResponse(w http.ResponseWriter, contentType string, statusCode int, ms
 }
 
 func errorMsgToStatus(errMsg string, statusCode int) *status.Status {
-	if statusCode == http.StatusBadRequest {
+	if statusCode == http.StatusBadRequest || statusCode == http.StatusUnsupportedMediaType {
 		return status.New(codes.InvalidArgument, errMsg)
 	}
 	return status.New(codes.Unknown, errMsg)
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// ResponseMetadata configures the metadata attached to the responses of the receiver, sent as
+// HTTP response headers and gRPC trailing metadata.
+type ResponseMetadata struct {
+	// Headers are attached to every response.
+	Headers map[string]string `mapstructure:"headers"`
+
+	// Provider is the ID of an extension implementing ResponseMetadataProvider, which is asked
+	// for the metadata of every response, e.g. rate-limit quota hints for the client.
+	Provider *component.ID `mapstructure:"provider"`
+}
+
+// ResponseMetadataProvider is implemented by extensions that supply metadata for the responses
+// of the OTLP receiver.
+type ResponseMetadataProvider interface {
+	component.Extension
+
+	// ResponseMetadata returns the metadata to attach to the response of the request with the given context.
+	// It is called before the request is processed, and must be safe for concurrent use.
+	ResponseMetadata(ctx context.Context) map[string]string
+}
+
+// responseMetadataFunc returns the metadata to attach to the response of the request with the given context.
+type responseMetadataFunc func(ctx context.Context) map[string]string
+
+// newResponseMetadataFunc returns the responseMetadataFunc for cfg, or nil if no metadata is configured.
+func newResponseMetadataFunc(cfg *ResponseMetadata, host component.Host) (responseMetadataFunc, error) {
+	if cfg == nil || (len(cfg.Headers) == 0 && cfg.Provider == nil) {
+		return nil, nil
+	}
+
+	var provider ResponseMetadataProvider
+	if cfg.Provider != nil {
+		ext, ok := host.GetExtensions()[*cfg.Provider]
+		if !ok {
+			return nil, fmt.Errorf("response metadata provider %q not found", cfg.Provider)
+		}
+		if provider, ok = ext.(ResponseMetadataProvider); !ok {
+			return nil, fmt.Errorf("extension %q is not a response metadata provider", cfg.Provider)
+		}
+	}
+
+	headers := cfg.Headers
+	return func(ctx context.Context) map[string]string {
+		if provider == nil {
+			return headers
+		}
+		md := make(map[string]string, len(headers))
+		for k, v := range headers {
+			md[k] = v
+		}
+		for k, v := range provider.ResponseMetadata(ctx) {
+			md[k] = v
+		}
+		return md
+	}, nil
+}
+
+// unaryServerInterceptor sets the response metadata as trailing metadata of the gRPC responses.
+func (f responseMetadataFunc) unaryServerInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if md := f(ctx); len(md) > 0 {
+		// An error only happens if the stream is not a server stream, which is not possible here.
+		_ = grpc.SetTrailer(ctx, metadata.New(md))
+	}
+	return handler(ctx, req)
+}
+
+// httpHandler sets the response metadata as headers of the HTTP responses.
+func (f responseMetadataFunc) httpHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range f(r.Context()) {
+			w.Header().Set(k, v)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+var quotaProviderID = component.NewID("quota")
+
+type quotaProvider struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (quotaProvider) ResponseMetadata(context.Context) map[string]string {
+	return map[string]string{"x-quota-remaining": "42"}
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func newResponseMetadataHost() component.Host {
+	return &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{quotaProviderID: quotaProvider{}},
+	}
+}
+
+func newResponseMetadataConfig() *ResponseMetadata {
+	return &ResponseMetadata{
+		Headers:  map[string]string{"x-quota-limit": "100", "x-quota-remaining": "100"},
+		Provider: &quotaProviderID,
+	}
+}
+
+func TestResponseMetadataGRPC(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = addr
+	cfg.HTTP = nil
+	cfg.ResponseMetadata = newResponseMetadataConfig()
+	r := newReceiver(t, factory, cfg, otlpReceiverID, consumertest.NewNop(), nil)
+	require.NoError(t, r.Start(context.Background(), newResponseMetadataHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	cc, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+
+	var trailer metadata.MD
+	req := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(1))
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), req, grpc.Trailer(&trailer))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"100"}, trailer.Get("x-quota-limit"))
+	assert.Equal(t, []string{"42"}, trailer.Get("x-quota-remaining"))
+}
+
+func TestResponseMetadataHTTP(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.HTTP.Endpoint = addr
+	cfg.GRPC = nil
+	cfg.ResponseMetadata = newResponseMetadataConfig()
+	r := newReceiver(t, factory, cfg, otlpReceiverID, consumertest.NewNop(), nil)
+	require.NoError(t, r.Start(context.Background(), newResponseMetadataHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	resp, err := http.Post(fmt.Sprintf("http://%s/v1/traces", addr), "application/json", bytes.NewReader(traceJSON))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "100", resp.Header.Get("x-quota-limit"))
+	assert.Equal(t, "42", resp.Header.Get("x-quota-remaining"))
+}
+
+func TestResponseMetadataInvalidProvider(t *testing.T) {
+	nopID := component.NewID("nop")
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{nopID: struct{ component.Component }{}},
+	}
+	tests := []struct {
+		name     string
+		provider component.ID
+		expected string
+	}{
+		{
+			name:     "missing",
+			provider: component.NewID("missing"),
+			expected: `response metadata provider "missing" not found`,
+		},
+		{
+			name:     "wrong_type",
+			provider: nopID,
+			expected: `extension "nop" is not a response metadata provider`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := tt.provider
+			_, err := newResponseMetadataFunc(&ResponseMetadata{Provider: &provider}, host)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestResponseMetadataNotConfigured(t *testing.T) {
+	f, err := newResponseMetadataFunc(nil, componenttest.NewNopHost())
+	require.NoError(t, err)
+	assert.Nil(t, f)
+
+	f, err = newResponseMetadataFunc(&ResponseMetadata{}, componenttest.NewNopHost())
+	require.NoError(t, err)
+	assert.Nil(t, f)
+}
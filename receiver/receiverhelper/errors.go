@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receiverhelper // import "go.opentelemetry.io/collector/receiver/receiverhelper"
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// ErrorTranslation is the status returned to the clients for a class of consumer errors.
+type ErrorTranslation struct {
+	// GRPCCode is the code of the gRPC status.
+	GRPCCode codes.Code
+	// HTTPStatus is the HTTP status code.
+	HTTPStatus int
+}
+
+// ErrorTranslator translates the errors returned by the next consumer into the statuses sent back to the clients,
+// so that the clients see the same signals regardless of the receiver. The zero value is not usable,
+// use NewErrorTranslator and override the translations if needed.
+type ErrorTranslator struct {
+	// Retryable is used for the errors that are not classified, the client may retry the request.
+	Retryable ErrorTranslation
+	// Permanent is used for the errors wrapped with consumererror.NewPermanent, the client must not retry the request.
+	Permanent ErrorTranslation
+	// LimitExceeded is used for the errors wrapped with consumererror.NewLimitExceeded,
+	// the client may retry the request after backing off.
+	LimitExceeded ErrorTranslation
+}
+
+// NewErrorTranslator returns the ErrorTranslator used by the core receivers, following the OTLP specification.
+func NewErrorTranslator() ErrorTranslator {
+	return ErrorTranslator{
+		Retryable:     ErrorTranslation{GRPCCode: codes.Unavailable, HTTPStatus: http.StatusServiceUnavailable},
+		Permanent:     ErrorTranslation{GRPCCode: codes.InvalidArgument, HTTPStatus: http.StatusBadRequest},
+		LimitExceeded: ErrorTranslation{GRPCCode: codes.ResourceExhausted, HTTPStatus: http.StatusTooManyRequests},
+	}
+}
+
+// GRPCStatus returns the gRPC status for the given error, nil if the error is nil.
+// Errors that already carry a gRPC status are returned unchanged.
+func (et ErrorTranslator) GRPCStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+	if s, ok := status.FromError(err); ok {
+		return s
+	}
+	return status.New(et.translation(err).GRPCCode, err.Error())
+}
+
+// GRPCError returns the error to return from a gRPC handler for the given consumer error, nil if the error is nil.
+func (et ErrorTranslator) GRPCError(err error) error {
+	return et.GRPCStatus(err).Err()
+}
+
+// HTTPStatus returns the HTTP status code for the given error, http.StatusOK if the error is nil.
+// Errors that carry a gRPC status are translated using the class with the same gRPC code.
+func (et ErrorTranslator) HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	if s, ok := status.FromError(err); ok {
+		for _, t := range []ErrorTranslation{et.LimitExceeded, et.Permanent, et.Retryable} {
+			if t.GRPCCode == s.Code() {
+				return t.HTTPStatus
+			}
+		}
+		return http.StatusInternalServerError
+	}
+	return et.translation(err).HTTPStatus
+}
+
+func (et ErrorTranslator) translation(err error) ErrorTranslation {
+	switch {
+	case consumererror.IsLimitExceeded(err):
+		return et.LimitExceeded
+	case consumererror.IsPermanent(err):
+		return et.Permanent
+	}
+	return et.Retryable
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receiverhelper
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestErrorTranslator(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		grpcCode   codes.Code
+		httpStatus int
+	}{
+		{
+			name:       "nil",
+			err:        nil,
+			grpcCode:   codes.OK,
+			httpStatus: http.StatusOK,
+		},
+		{
+			name:       "retryable",
+			err:        errors.New("backend unavailable"),
+			grpcCode:   codes.Unavailable,
+			httpStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "permanent",
+			err:        fmt.Errorf("export failed: %w", consumererror.NewPermanent(errors.New("invalid data"))),
+			grpcCode:   codes.InvalidArgument,
+			httpStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "limit_exceeded",
+			err:        consumererror.NewLimitExceeded(errors.New("high memory usage")),
+			grpcCode:   codes.ResourceExhausted,
+			httpStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:       "grpc_status",
+			err:        status.Error(codes.ResourceExhausted, "too many requests"),
+			grpcCode:   codes.ResourceExhausted,
+			httpStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:       "unknown_grpc_status",
+			err:        status.Error(codes.Internal, "internal"),
+			grpcCode:   codes.Internal,
+			httpStatus: http.StatusInternalServerError,
+		},
+	}
+
+	et := NewErrorTranslator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.grpcCode, et.GRPCStatus(tt.err).Code())
+			assert.Equal(t, tt.grpcCode, status.Code(et.GRPCError(tt.err)))
+			assert.Equal(t, tt.httpStatus, et.HTTPStatus(tt.err))
+		})
+	}
+}
+
+func TestErrorTranslatorOverride(t *testing.T) {
+	et := NewErrorTranslator()
+	et.LimitExceeded = ErrorTranslation{GRPCCode: codes.Unavailable, HTTPStatus: http.StatusServiceUnavailable}
+
+	err := consumererror.NewLimitExceeded(errors.New("queue is full"))
+	assert.Equal(t, codes.Unavailable, et.GRPCStatus(err).Code())
+	assert.Equal(t, http.StatusServiceUnavailable, et.HTTPStatus(err))
+	assert.Equal(t, "Limit exceeded: queue is full", et.GRPCStatus(err).Message())
+}
@@ -17,6 +17,8 @@ package scraperhelper // import "go.opentelemetry.io/collector/receiver/scraperh
 import (
 	"context"
 	"errors"
+	"math/rand"
+	"sync"
 	"time"
 
 	"go.uber.org/multierr"
@@ -36,14 +38,29 @@ import (
 type ScraperControllerSettings struct {
 	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 	CollectionInterval      time.Duration            `mapstructure:"collection_interval"`
+	// MaxConcurrentScrapes is the maximum number of scrapers running at the same time at every collection,
+	// so that a slow scraper does not delay the others. Zero means no limit, one, the default, runs the scrapers
+	// sequentially.
+	MaxConcurrentScrapes int `mapstructure:"max_concurrent_scrapes"`
+	// Timeout is the deadline of the context given to every scraper at every collection. Zero means no deadline.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// InitialDelay delays the start of the collections after the receiver started.
+	InitialDelay time.Duration `mapstructure:"initial_delay"`
+	// Jitter delays every collection by a random fraction, in the [0, jitter) range, of the collection interval,
+	// so that the collectors scraping the same targets do not all scrape them at the same time.
+	Jitter float64 `mapstructure:"jitter"`
+	// AlignToInterval schedules the collections at the wall clock multiples of the collection interval,
+	// e.g. at the start of every minute for an interval of one minute, before the jitter is applied.
+	AlignToInterval bool `mapstructure:"align_to_interval"`
 }
 
 // NewDefaultScraperControllerSettings returns default scraper controller
-// settings with a collection interval of one minute.
+// settings with a collection interval of one minute, running the scrapers sequentially.
 func NewDefaultScraperControllerSettings(cfgType component.Type) ScraperControllerSettings {
 	return ScraperControllerSettings{
-		ReceiverSettings:   config.NewReceiverSettings(component.NewID(cfgType)),
-		CollectionInterval: time.Minute,
+		ReceiverSettings:     config.NewReceiverSettings(component.NewID(cfgType)),
+		CollectionInterval:   time.Minute,
+		MaxConcurrentScrapes: 1,
 	}
 }
 
@@ -74,12 +91,18 @@ type controller struct {
 	id                 component.ID
 	logger             *zap.Logger
 	collectionInterval time.Duration
+	maxConcurrent      int
+	timeout            time.Duration
+	initialDelay       time.Duration
+	jitter             float64
+	alignToInterval    bool
 	nextConsumer       consumer.Metrics
 
 	scrapers    []Scraper
 	obsScrapers []*obsreport.Scraper
 
 	tickerCh <-chan time.Time
+	rand     *rand.Rand
 
 	initialized bool
 	done        chan struct{}
@@ -104,6 +127,22 @@ func NewScraperControllerReceiver(
 		return nil, errors.New("collection_interval must be a positive duration")
 	}
 
+	if cfg.MaxConcurrentScrapes < 0 {
+		return nil, errors.New("max_concurrent_scrapes must not be negative")
+	}
+
+	if cfg.Timeout < 0 {
+		return nil, errors.New("timeout must not be negative")
+	}
+
+	if cfg.InitialDelay < 0 {
+		return nil, errors.New("initial_delay must not be negative")
+	}
+
+	if cfg.Jitter < 0 || cfg.Jitter >= 1 {
+		return nil, errors.New("jitter must be in the [0, 1) range")
+	}
+
 	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
 		ReceiverID:             set.ID,
 		Transport:              "",
@@ -117,7 +156,13 @@ func NewScraperControllerReceiver(
 		id:                 set.ID,
 		logger:             set.Logger,
 		collectionInterval: cfg.CollectionInterval,
+		maxConcurrent:      cfg.MaxConcurrentScrapes,
+		timeout:            cfg.Timeout,
+		initialDelay:       cfg.InitialDelay,
+		jitter:             cfg.Jitter,
+		alignToInterval:    cfg.AlignToInterval,
 		nextConsumer:       nextConsumer,
+		rand:               rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec
 		done:               make(chan struct{}),
 		terminated:         make(chan struct{}),
 		obsrecv:            obsrecv,
@@ -177,10 +222,23 @@ func (sc *controller) Shutdown(ctx context.Context) error {
 }
 
 // startScraping initiates a ticker that calls Scrape based on the configured
-// collection interval.
+// collection interval, once the initial delay and the wait for the interval
+// alignment are elapsed.
 func (sc *controller) startScraping() {
 	go func() {
+		defer func() {
+			sc.terminated <- struct{}{}
+		}()
+
+		if !sc.wait(sc.initialDelay) {
+			return
+		}
+
 		if sc.tickerCh == nil {
+			if sc.alignToInterval && !sc.wait(sc.untilAligned(time.Now())) {
+				return
+			}
+
 			ticker := time.NewTicker(sc.collectionInterval)
 			defer ticker.Stop()
 
@@ -190,34 +248,73 @@ func (sc *controller) startScraping() {
 		for {
 			select {
 			case <-sc.tickerCh:
+				if !sc.wait(sc.nextJitter()) {
+					return
+				}
 				sc.scrapeMetricsAndReport(context.Background())
 			case <-sc.done:
-				sc.terminated <- struct{}{}
 				return
 			}
 		}
 	}()
 }
 
+// wait waits for the given duration, and returns false if the scraping was stopped in the meantime.
+func (sc *controller) wait(d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-sc.done:
+		return false
+	}
+}
+
+// untilAligned returns the duration from now until the next wall clock multiple of the collection interval.
+func (sc *controller) untilAligned(now time.Time) time.Duration {
+	return now.Truncate(sc.collectionInterval).Add(sc.collectionInterval).Sub(now)
+}
+
+// nextJitter returns a random delay for the next collection, if a jitter is configured.
+func (sc *controller) nextJitter() time.Duration {
+	if sc.jitter <= 0 {
+		return 0
+	}
+	return time.Duration(sc.rand.Float64() * sc.jitter * float64(sc.collectionInterval))
+}
+
 // scrapeMetricsAndReport calls the Scrape function for each of the configured
-// Scrapers, records observability information, and passes the scraped metrics
-// to the next component.
+// Scrapers, up to maxConcurrent at the same time, records observability information,
+// and passes the scraped metrics to the next component.
 func (sc *controller) scrapeMetricsAndReport(ctx context.Context) {
-	metrics := pmetric.NewMetrics()
+	scraped := make([]pmetric.Metrics, len(sc.scrapers))
 
-	for i, scraper := range sc.scrapers {
-		scrp := sc.obsScrapers[i]
-		ctx = scrp.StartMetricsOp(ctx)
-		md, err := scraper.Scrape(ctx)
+	limit := sc.maxConcurrent
+	if limit == 0 || limit > len(sc.scrapers) {
+		limit = len(sc.scrapers)
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := range sc.scrapers {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			scraped[i] = sc.scrape(ctx, i)
+		}(i)
+	}
+	wg.Wait()
 
-		if err != nil {
-			sc.logger.Error("Error scraping metrics", zap.Error(err), zap.Stringer("scraper", scraper.ID()))
-			if !scrapererror.IsPartialScrapeError(err) {
-				scrp.EndMetricsOp(ctx, 0, err)
-				continue
-			}
-		}
-		scrp.EndMetricsOp(ctx, md.MetricCount(), err)
+	// The metrics are kept in the order of the scrapers, whatever the order they completed in.
+	metrics := pmetric.NewMetrics()
+	for _, md := range scraped {
 		md.ResourceMetrics().MoveAndAppendTo(metrics.ResourceMetrics())
 	}
 
@@ -227,6 +324,31 @@ func (sc *controller) scrapeMetricsAndReport(ctx context.Context) {
 	sc.obsrecv.EndMetricsOp(ctx, "", dataPointCount, err)
 }
 
+// scrape calls the Scrape function of the i-th Scraper and records observability information.
+func (sc *controller) scrape(ctx context.Context, i int) pmetric.Metrics {
+	scraper := sc.scrapers[i]
+	scrp := sc.obsScrapers[i]
+
+	if sc.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, sc.timeout)
+		defer cancel()
+	}
+
+	ctx = scrp.StartMetricsOp(ctx)
+	md, err := scraper.Scrape(ctx)
+
+	if err != nil {
+		sc.logger.Error("Error scraping metrics", zap.Error(err), zap.Stringer("scraper", scraper.ID()))
+		if !scrapererror.IsPartialScrapeError(err) {
+			scrp.EndMetricsOp(ctx, 0, err)
+			return pmetric.NewMetrics()
+		}
+	}
+	scrp.EndMetricsOp(ctx, md.MetricCount(), err)
+	return md
+}
+
 // stopScraping stops the ticker
 func (sc *controller) stopScraping() {
 	close(sc.done)
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/codes"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/atomic"
 	"go.uber.org/multierr"
 
 	"go.opentelemetry.io/collector/component"
@@ -112,6 +113,30 @@ func TestScrapeController(t *testing.T) {
 			scraperControllerSettings: &ScraperControllerSettings{CollectionInterval: -time.Millisecond},
 			expectedNewErr:            "collection_interval must be a positive duration",
 		},
+		{
+			name:                      "AddMetricsScrapers_NegativeMaxConcurrentScrapesError",
+			scrapers:                  2,
+			scraperControllerSettings: &ScraperControllerSettings{CollectionInterval: time.Minute, MaxConcurrentScrapes: -1},
+			expectedNewErr:            "max_concurrent_scrapes must not be negative",
+		},
+		{
+			name:                      "AddMetricsScrapers_NegativeTimeoutError",
+			scrapers:                  2,
+			scraperControllerSettings: &ScraperControllerSettings{CollectionInterval: time.Minute, Timeout: -time.Second},
+			expectedNewErr:            "timeout must not be negative",
+		},
+		{
+			name:                      "AddMetricsScrapers_NegativeInitialDelayError",
+			scrapers:                  2,
+			scraperControllerSettings: &ScraperControllerSettings{CollectionInterval: time.Minute, InitialDelay: -time.Second},
+			expectedNewErr:            "initial_delay must not be negative",
+		},
+		{
+			name:                      "AddMetricsScrapers_InvalidJitterError",
+			scrapers:                  2,
+			scraperControllerSettings: &ScraperControllerSettings{CollectionInterval: time.Minute, Jitter: 1},
+			expectedNewErr:            "jitter must be in the [0, 1) range",
+		},
 		{
 			name:      "AddMetricsScrapers_ScrapeError",
 			scrapers:  2,
@@ -360,3 +385,130 @@ func TestSingleScrapePerTick(t *testing.T) {
 		return
 	}
 }
+
+func TestConcurrentScrapes(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		maxConcurrent int
+		want          int64
+	}{
+		{name: "Unlimited", maxConcurrent: 0, want: 3},
+		{name: "Limited", maxConcurrent: 2, want: 2},
+		{name: "Sequential", maxConcurrent: 1, want: 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := NewDefaultScraperControllerSettings("")
+			cfg.MaxConcurrentScrapes = test.maxConcurrent
+
+			// Every scraper waits for the others to start, until the expected concurrency is reached.
+			running := atomic.NewInt64(0)
+			maxRunning := atomic.NewInt64(0)
+			options := []ScraperControllerOption{WithTickerChannel(make(chan time.Time))}
+			for i := 0; i < 3; i++ {
+				scp, err := NewScraper("scraper", func(context.Context) (pmetric.Metrics, error) {
+					n := running.Inc()
+					defer running.Dec()
+					for cur := maxRunning.Load(); n > cur && !maxRunning.CompareAndSwap(cur, n); cur = maxRunning.Load() {
+					}
+					assert.Eventually(t, func() bool { return maxRunning.Load() >= test.want }, time.Second, time.Millisecond)
+					md := pmetric.NewMetrics()
+					md.ResourceMetrics().AppendEmpty()
+					return md, nil
+				})
+				require.NoError(t, err)
+				options = append(options, AddScraper(scp))
+			}
+
+			sink := new(consumertest.MetricsSink)
+			r, err := NewScraperControllerReceiver(&cfg, componenttest.NewNopReceiverCreateSettings(), sink, options...)
+			require.NoError(t, err)
+
+			r.(*controller).scrapeMetricsAndReport(context.Background())
+			assert.Equal(t, test.want, maxRunning.Load())
+			require.Len(t, sink.AllMetrics(), 1)
+			assert.Equal(t, 3, sink.AllMetrics()[0].ResourceMetrics().Len())
+		})
+	}
+}
+
+func TestDefaultScraperControllerSettings(t *testing.T) {
+	cfg := NewDefaultScraperControllerSettings("receiver")
+	assert.Equal(t, time.Minute, cfg.CollectionInterval)
+	assert.Equal(t, 1, cfg.MaxConcurrentScrapes)
+}
+
+func TestScrapeTimeout(t *testing.T) {
+	cfg := NewDefaultScraperControllerSettings("")
+	cfg.Timeout = time.Second
+
+	var deadline time.Time
+	scp, err := NewScraper("scraper", func(ctx context.Context) (pmetric.Metrics, error) {
+		deadline, _ = ctx.Deadline()
+		return pmetric.NewMetrics(), nil
+	})
+	require.NoError(t, err)
+	r, err := NewScraperControllerReceiver(&cfg, componenttest.NewNopReceiverCreateSettings(), new(consumertest.MetricsSink), AddScraper(scp))
+	require.NoError(t, err)
+
+	start := time.Now()
+	r.(*controller).scrapeMetricsAndReport(context.Background())
+	assert.WithinDuration(t, start.Add(time.Second), deadline, 100*time.Millisecond)
+}
+
+func TestInitialDelay(t *testing.T) {
+	cfg := NewDefaultScraperControllerSettings("")
+	cfg.InitialDelay = 100 * time.Millisecond
+
+	scrapeMetricsCh := make(chan int, 10)
+	tsm := &testScrapeMetrics{ch: scrapeMetricsCh}
+	scp, err := NewScraper("", tsm.scrape)
+	require.NoError(t, err)
+
+	tickerCh := make(chan time.Time)
+	r, err := NewScraperControllerReceiver(&cfg, componenttest.NewNopReceiverCreateSettings(), new(consumertest.MetricsSink), AddScraper(scp), WithTickerChannel(tickerCh))
+	require.NoError(t, err)
+
+	start := time.Now()
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	tickerCh <- time.Now()
+	assert.Equal(t, 1, <-scrapeMetricsCh)
+	assert.GreaterOrEqual(t, time.Since(start), cfg.InitialDelay)
+	require.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestShutdownDuringInitialDelay(t *testing.T) {
+	cfg := NewDefaultScraperControllerSettings("")
+	cfg.InitialDelay = time.Hour
+
+	r, err := NewScraperControllerReceiver(&cfg, componenttest.NewNopReceiverCreateSettings(), new(consumertest.MetricsSink))
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestUntilAligned(t *testing.T) {
+	cfg := NewDefaultScraperControllerSettings("")
+	r, err := NewScraperControllerReceiver(&cfg, componenttest.NewNopReceiverCreateSettings(), new(consumertest.MetricsSink))
+	require.NoError(t, err)
+
+	sc := r.(*controller)
+	now := time.Date(2022, 11, 1, 10, 30, 15, 0, time.UTC)
+	assert.Equal(t, 45*time.Second, sc.untilAligned(now))
+	assert.Equal(t, time.Minute, sc.untilAligned(now.Truncate(time.Minute)))
+}
+
+func TestNextJitter(t *testing.T) {
+	cfg := NewDefaultScraperControllerSettings("")
+	r, err := NewScraperControllerReceiver(&cfg, componenttest.NewNopReceiverCreateSettings(), new(consumertest.MetricsSink))
+	require.NoError(t, err)
+	assert.Zero(t, r.(*controller).nextJitter())
+
+	cfg.Jitter = 0.5
+	r, err = NewScraperControllerReceiver(&cfg, componenttest.NewNopReceiverCreateSettings(), new(consumertest.MetricsSink))
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		jitter := r.(*controller).nextJitter()
+		assert.GreaterOrEqual(t, jitter, time.Duration(0))
+		assert.Less(t, jitter, 30*time.Second)
+	}
+}
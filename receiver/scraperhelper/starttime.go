@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scraperhelper // import "go.opentelemetry.io/collector/receiver/scraperhelper"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// StartTimeAdjuster sets the StartTimestamp of cumulative data points produced by a scraper,
+// tracking each series across scrapes to detect resets.
+//
+// The first time a series is observed its start time is the one given to NewStartTimeAdjuster.
+// When the value of a monotonic cumulative sum, or the count or sum of a histogram, an exponential histogram
+// or a summary decreases, the series is considered reset and its start time becomes the timestamp of the
+// previous observation. Non-monotonic sums, whose value may legitimately decrease, keep their first start time.
+// Data points that already have a StartTimestamp are left unchanged.
+//
+// A StartTimeAdjuster must receive the full output of a single scraper, series that are missing from
+// a call to AdjustMetrics are forgotten. It is not safe for concurrent use.
+type StartTimeAdjuster struct {
+	startTime pcommon.Timestamp
+	series    map[string]*seriesState
+}
+
+// seriesState holds the last observation of a cumulative series.
+type seriesState struct {
+	startTime pcommon.Timestamp
+	timestamp pcommon.Timestamp
+	// count is the value of a sum, or the count of a distribution.
+	count float64
+	// sum is the sum of a distribution.
+	sum  float64
+	seen bool
+}
+
+// NewStartTimeAdjuster creates a StartTimeAdjuster using startTime for newly observed series,
+// usually the time when the scraper or the scraped process started.
+func NewStartTimeAdjuster(startTime pcommon.Timestamp) *StartTimeAdjuster {
+	return &StartTimeAdjuster{
+		startTime: startTime,
+		series:    map[string]*seriesState{},
+	}
+}
+
+// AdjustMetrics sets the StartTimestamp of the cumulative sum, histogram, exponential histogram
+// and summary data points in md.
+func (a *StartTimeAdjuster) AdjustMetrics(md pmetric.Metrics) {
+	for _, s := range a.series {
+		s.seen = false
+	}
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceKey := attributesKey(rm.Resource().Attributes())
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			scopeKey := resourceKey + "/" + sm.Scope().Name()
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				a.adjustMetric(scopeKey, ms.At(k))
+			}
+		}
+	}
+
+	for key, s := range a.series {
+		if !s.seen {
+			delete(a.series, key)
+		}
+	}
+}
+
+func (a *StartTimeAdjuster) adjustMetric(scopeKey string, metric pmetric.Metric) {
+	metricKey := scopeKey + "/" + metric.Name()
+	switch metric.Type() {
+	case pmetric.MetricTypeSum:
+		sum := metric.Sum()
+		if sum.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+			return
+		}
+		dps := sum.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			value := dp.DoubleValue()
+			if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+				value = float64(dp.IntValue())
+			}
+			a.adjustPoint(metricKey, dp.Attributes(), dp.Timestamp(), value, 0, sum.IsMonotonic(), dp.StartTimestamp, dp.SetStartTimestamp)
+		}
+	case pmetric.MetricTypeHistogram:
+		histogram := metric.Histogram()
+		if histogram.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+			return
+		}
+		dps := histogram.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			a.adjustPoint(metricKey, dp.Attributes(), dp.Timestamp(), float64(dp.Count()), dp.Sum(), true, dp.StartTimestamp, dp.SetStartTimestamp)
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		histogram := metric.ExponentialHistogram()
+		if histogram.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+			return
+		}
+		dps := histogram.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			a.adjustPoint(metricKey, dp.Attributes(), dp.Timestamp(), float64(dp.Count()), dp.Sum(), true, dp.StartTimestamp, dp.SetStartTimestamp)
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			a.adjustPoint(metricKey, dp.Attributes(), dp.Timestamp(), float64(dp.Count()), dp.Sum(), true, dp.StartTimestamp, dp.SetStartTimestamp)
+		}
+	}
+}
+
+func (a *StartTimeAdjuster) adjustPoint(
+	metricKey string,
+	attrs pcommon.Map,
+	timestamp pcommon.Timestamp,
+	count float64,
+	sum float64,
+	detectReset bool,
+	getStartTime func() pcommon.Timestamp,
+	setStartTime func(pcommon.Timestamp),
+) {
+	key := metricKey + "/" + attributesKey(attrs)
+	s, ok := a.series[key]
+	switch {
+	case !ok:
+		s = &seriesState{startTime: a.startTime}
+		a.series[key] = s
+	case detectReset && (count < s.count || sum < s.sum):
+		// The series was reset after the previous observation.
+		s.startTime = s.timestamp
+	}
+	s.timestamp = timestamp
+	s.count = count
+	s.sum = sum
+	s.seen = true
+
+	if getStartTime() == 0 {
+		setStartTime(s.startTime)
+	}
+}
+
+// attributesKey returns a string uniquely identifying the attributes, independent of their order.
+func attributesKey(attrs pcommon.Map) string {
+	// fmt prints maps sorted by key.
+	return fmt.Sprint(attrs.AsRaw())
+}
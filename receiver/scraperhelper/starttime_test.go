@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scraperhelper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newCumulativeSum(ts pcommon.Timestamp, value int64, monotonic bool) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.SetIsMonotonic(monotonic)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("method", "GET")
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(value)
+	return md
+}
+
+func startTimeOf(md pmetric.Metrics) pcommon.Timestamp {
+	return md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).StartTimestamp()
+}
+
+func TestStartTimeAdjuster(t *testing.T) {
+	adjuster := NewStartTimeAdjuster(pcommon.Timestamp(100))
+
+	md := newCumulativeSum(1000, 10, true)
+	adjuster.AdjustMetrics(md)
+	assert.Equal(t, pcommon.Timestamp(100), startTimeOf(md))
+
+	md = newCumulativeSum(2000, 20, true)
+	adjuster.AdjustMetrics(md)
+	assert.Equal(t, pcommon.Timestamp(100), startTimeOf(md))
+
+	// The counter was reset between the two last scrapes.
+	md = newCumulativeSum(3000, 5, true)
+	adjuster.AdjustMetrics(md)
+	assert.Equal(t, pcommon.Timestamp(2000), startTimeOf(md))
+
+	md = newCumulativeSum(4000, 7, true)
+	adjuster.AdjustMetrics(md)
+	assert.Equal(t, pcommon.Timestamp(2000), startTimeOf(md))
+}
+
+func TestStartTimeAdjusterNonMonotonicSum(t *testing.T) {
+	adjuster := NewStartTimeAdjuster(pcommon.Timestamp(100))
+
+	md := newCumulativeSum(1000, 10, false)
+	adjuster.AdjustMetrics(md)
+	assert.Equal(t, pcommon.Timestamp(100), startTimeOf(md))
+
+	// A decrease of a non-monotonic sum is not a reset.
+	md = newCumulativeSum(2000, 5, false)
+	adjuster.AdjustMetrics(md)
+	assert.Equal(t, pcommon.Timestamp(100), startTimeOf(md))
+}
+
+func TestStartTimeAdjusterKeepsExistingStartTime(t *testing.T) {
+	adjuster := NewStartTimeAdjuster(pcommon.Timestamp(100))
+
+	md := newCumulativeSum(1000, 10, true)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).SetStartTimestamp(500)
+	adjuster.AdjustMetrics(md)
+	assert.Equal(t, pcommon.Timestamp(500), startTimeOf(md))
+}
+
+func TestStartTimeAdjusterForgetsMissingSeries(t *testing.T) {
+	adjuster := NewStartTimeAdjuster(pcommon.Timestamp(100))
+
+	adjuster.AdjustMetrics(newCumulativeSum(1000, 10, true))
+	assert.Len(t, adjuster.series, 1)
+
+	adjuster.AdjustMetrics(pmetric.NewMetrics())
+	assert.Len(t, adjuster.series, 0)
+}
+
+func TestStartTimeAdjusterDistributions(t *testing.T) {
+	// Each test case appends a cumulative data point to the metric, and returns a function
+	// setting its timestamp, count and sum, and returning its start time after the adjustment.
+	tests := []struct {
+		name     string
+		appendDP func(m pmetric.Metric) (set func(ts pcommon.Timestamp, count uint64, sum float64), startTime func() pcommon.Timestamp)
+	}{
+		{
+			name: "histogram",
+			appendDP: func(m pmetric.Metric) (func(pcommon.Timestamp, uint64, float64), func() pcommon.Timestamp) {
+				h := m.SetEmptyHistogram()
+				h.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := h.DataPoints().AppendEmpty()
+				return func(ts pcommon.Timestamp, count uint64, sum float64) {
+					dp.SetTimestamp(ts)
+					dp.SetCount(count)
+					dp.SetSum(sum)
+				}, dp.StartTimestamp
+			},
+		},
+		{
+			name: "exponential_histogram",
+			appendDP: func(m pmetric.Metric) (func(pcommon.Timestamp, uint64, float64), func() pcommon.Timestamp) {
+				h := m.SetEmptyExponentialHistogram()
+				h.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := h.DataPoints().AppendEmpty()
+				return func(ts pcommon.Timestamp, count uint64, sum float64) {
+					dp.SetTimestamp(ts)
+					dp.SetCount(count)
+					dp.SetSum(sum)
+				}, dp.StartTimestamp
+			},
+		},
+		{
+			name: "summary",
+			appendDP: func(m pmetric.Metric) (func(pcommon.Timestamp, uint64, float64), func() pcommon.Timestamp) {
+				dp := m.SetEmptySummary().DataPoints().AppendEmpty()
+				return func(ts pcommon.Timestamp, count uint64, sum float64) {
+					dp.SetTimestamp(ts)
+					dp.SetCount(count)
+					dp.SetSum(sum)
+				}, dp.StartTimestamp
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adjuster := NewStartTimeAdjuster(pcommon.Timestamp(100))
+			adjust := func(ts pcommon.Timestamp, count uint64, sum float64) pcommon.Timestamp {
+				md := pmetric.NewMetrics()
+				m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+				m.SetName("latency")
+				set, startTime := tt.appendDP(m)
+				set(ts, count, sum)
+				adjuster.AdjustMetrics(md)
+				return startTime()
+			}
+
+			assert.Equal(t, pcommon.Timestamp(100), adjust(1000, 10, 50))
+			assert.Equal(t, pcommon.Timestamp(100), adjust(2000, 20, 100))
+			// The count decreased.
+			assert.Equal(t, pcommon.Timestamp(2000), adjust(3000, 5, 150))
+			assert.Equal(t, pcommon.Timestamp(2000), adjust(4000, 6, 160))
+			// The count is unchanged but the sum decreased.
+			assert.Equal(t, pcommon.Timestamp(4000), adjust(5000, 6, 20))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// adminPathPrefix returns the path prefix under which the handler of the extension identified by id is served
+// on the admin server.
+func adminPathPrefix(id component.ID) string {
+	return "/" + id.String() + "/"
+}
+
+// startAdminServer starts serving the handlers of the extensions implementing component.HTTPHandlerProvider on
+// the admin endpoint of the service, if one is configured.
+func (srv *service) startAdminServer() error {
+	cfg := srv.config.Service.Admin
+	if cfg == nil {
+		return nil
+	}
+
+	handlers := srv.host.extensions.HTTPHandlers()
+	ids := make([]component.ID, 0, len(handlers))
+	for id := range handlers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+
+	mux := http.NewServeMux()
+	for _, id := range ids {
+		prefix := adminPathPrefix(id)
+		mux.Handle(prefix, http.StripPrefix(strings.TrimSuffix(prefix, "/"), handlers[id]))
+		srv.telemetrySettings.Logger.Info("Serving extension on the admin endpoint", zap.Stringer("extension", id), zap.String("path", prefix))
+	}
+
+	ln, err := cfg.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind admin endpoint %q: %w", cfg.Endpoint, err)
+	}
+	adminSrv, err := cfg.ToServer(srv.host, srv.telemetrySettings, mux)
+	if err != nil {
+		_ = ln.Close()
+		return fmt.Errorf("failed to create admin server: %w", err)
+	}
+	srv.adminServer = adminSrv
+
+	go func() {
+		if serveErr := adminSrv.Serve(ln); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			srv.telemetrySettings.Logger.Error("Admin endpoint failed", zap.Error(serveErr))
+		}
+	}()
+	srv.telemetrySettings.Logger.Info("Admin endpoint started", zap.String("endpoint", ln.Addr().String()))
+	return nil
+}
+
+// shutdownAdminServer stops the admin server, if it was started.
+func (srv *service) shutdownAdminServer() error {
+	if srv.adminServer == nil {
+		return nil
+	}
+	err := srv.adminServer.Close()
+	srv.adminServer = nil
+	return err
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+type handlerExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (handlerExtension) HTTPHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.URL.Path)
+	})
+}
+
+func newHandlerExtensionFactory() component.ExtensionFactory {
+	return component.NewExtensionFactory(
+		"handler",
+		func() component.Config {
+			return &struct {
+				config.ExtensionSettings `mapstructure:",squash"`
+			}{ExtensionSettings: config.NewExtensionSettings(component.NewID("handler"))}
+		},
+		func(context.Context, component.ExtensionCreateSettings, component.Config) (component.Extension, error) {
+			return handlerExtension{}, nil
+		},
+		component.StabilityLevelDevelopment)
+}
+
+func TestServiceAdminServer(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	handlerFactory := newHandlerExtensionFactory()
+	factories.Extensions[handlerFactory.Type()] = handlerFactory
+
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	srv := createExampleService(t, factories, func(cfg *Config) {
+		extID := component.NewIDWithName("handler", "a")
+		cfg.Extensions[extID] = handlerFactory.CreateDefaultConfig()
+		cfg.Service.Extensions = append(cfg.Service.Extensions, extID)
+		cfg.Service.Admin = &confighttp.HTTPServerSettings{Endpoint: endpoint}
+	})
+
+	require.NoError(t, srv.Start(context.Background()))
+
+	resp, err := http.Get("http://" + endpoint + "/handler/a/status")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, resp.Body.Close())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "/status", string(body))
+
+	// Extensions that do not provide a handler are not served.
+	resp, err = http.Get("http://" + endpoint + "/nop/")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	require.NoError(t, srv.Shutdown(context.Background()))
+	_, err = http.Get("http://" + endpoint + "/handler/a/status")
+	assert.Error(t, err)
+}
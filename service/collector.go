@@ -24,6 +24,8 @@ import (
 	"os/signal"
 	"syscall"
 
+	ocmetric "go.opencensus.io/metric"
+	"go.opencensus.io/metric/metricproducer"
 	"go.uber.org/atomic"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -65,6 +67,10 @@ func (s State) String() string {
 //   Collector can be shutdown if parser gets a shutdown error.
 // - Run runs runAndWaitForShutdownEvent and waits for a shutdown event.
 //   SIGINT and SIGTERM, errors, and (*Collector).Shutdown can trigger the shutdown events.
+//   SIGHUP, config watch events and (*Collector).Reload trigger a config reload.
+//   With the service.reload feature gate, a reload rebuilds the pipelines in place, keeping the
+//   extensions and telemetry, and an invalid configuration leaves the running pipelines untouched.
+//   The control endpoint, if enabled, calls (*Collector).Reload and (*Collector).Shutdown.
 // - Upon shutdown, pipelines are notified, then pipelines and extensions are shut down.
 // - Users can call (*Collector).Shutdown anytime to shut down the collector.
 
@@ -78,11 +84,17 @@ type Collector struct {
 	// shutdownChan is used to terminate the collector.
 	shutdownChan chan struct{}
 
+	// reloadChan is used to reload the collector configuration.
+	reloadChan chan struct{}
+
 	// signalsChannel is used to receive termination signals from the OS.
 	signalsChannel chan os.Signal
 
 	// asyncErrorChannel is used to signal a fatal error from any component.
 	asyncErrorChannel chan error
+
+	// reloadMetrics counts the configuration reloads, they are exported while the collector runs.
+	reloadMetrics *reloadInstruments
 }
 
 // New creates and returns a new instance of Collector.
@@ -91,6 +103,12 @@ func New(set CollectorSettings) (*Collector, error) {
 		return nil, errors.New("invalid nil config provider")
 	}
 
+	if set.ControlEndpoint != "" {
+		if err := validateControlEndpoint(set.ControlEndpoint); err != nil {
+			return nil, err
+		}
+	}
+
 	if set.telemetry == nil {
 		set.telemetry = newColTelemetry(featuregate.GetRegistry())
 	}
@@ -99,10 +117,12 @@ func New(set CollectorSettings) (*Collector, error) {
 		set:          set,
 		state:        atomic.NewInt32(int32(StateStarting)),
 		shutdownChan: make(chan struct{}),
+		reloadChan:   make(chan struct{}, 1),
 		// Per signal.Notify documentation, a size of the channel equaled with
 		// the number of signals getting notified on is recommended.
 		signalsChannel:    make(chan os.Signal, 3),
 		asyncErrorChannel: make(chan error),
+		reloadMetrics:     newReloadInstruments(ocmetric.NewRegistry()),
 	}, nil
 }
 
@@ -123,6 +143,15 @@ func (col *Collector) Shutdown() {
 	}
 }
 
+// Reload requests the collector server to reload its configuration.
+// Requests made while a reload is already pending are coalesced.
+func (col *Collector) Reload() {
+	select {
+	case col.reloadChan <- struct{}{}:
+	default:
+	}
+}
+
 // setupConfigurationComponents loads the config and starts the components. If all the steps succeeds it
 // sets the col.service with the service currently running.
 func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
@@ -137,6 +166,14 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	return col.startService(ctx, cfg)
+}
+
+// startService creates and starts the service for the given configuration.
+func (col *Collector) startService(ctx context.Context, cfg *Config) error {
+	col.setCollectorState(StateStarting)
+
+	var err error
 	col.service, err = newService(&settings{
 		BuildInfo:         col.set.BuildInfo,
 		Factories:         col.set.Factories,
@@ -160,7 +197,26 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 	return nil
 }
 
+// restartService shuts down the running service, and starts a new one for the given configuration.
+func (col *Collector) restartService(ctx context.Context, cfg *Config) error {
+	col.setCollectorState(StateClosing)
+
+	if err := col.service.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown the retiring config: %w", err)
+	}
+
+	if err := col.startService(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to setup configuration components: %w", err)
+	}
+
+	return nil
+}
+
 func (col *Collector) reloadConfiguration(ctx context.Context) error {
+	if featuregate.GetRegistry().IsEnabled(reloadFeatureGateID) {
+		return col.reloadPipelines(ctx)
+	}
+
 	col.service.telemetrySettings.Logger.Warn("Config updated, restart service")
 	col.setCollectorState(StateClosing)
 
@@ -183,6 +239,17 @@ func (col *Collector) Run(ctx context.Context) error {
 		return err
 	}
 
+	metricproducer.GlobalManager().AddProducer(col.reloadMetrics.registry)
+	defer metricproducer.GlobalManager().DeleteProducer(col.reloadMetrics.registry)
+
+	controlSrv, err := col.startControlServer(col.service.telemetrySettings.Logger)
+	if err != nil {
+		return multierr.Append(err, col.shutdown(ctx))
+	}
+	if controlSrv != nil {
+		defer controlSrv.Close()
+	}
+
 	// Always notify with SIGHUP for configuration reloading.
 	signal.Notify(col.signalsChannel, syscall.SIGHUP)
 	defer signal.Stop(col.signalsChannel)
@@ -214,6 +281,11 @@ LOOP:
 			if err := col.reloadConfiguration(ctx); err != nil {
 				return err
 			}
+		case <-col.reloadChan:
+			col.service.telemetrySettings.Logger.Info("Received reload request")
+			if err := col.reloadConfiguration(ctx); err != nil {
+				return err
+			}
 		case <-col.shutdownChan:
 			col.service.telemetrySettings.Logger.Info("Received shutdown request")
 			break LOOP
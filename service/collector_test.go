@@ -621,6 +621,7 @@ func assertZPages(t *testing.T, zpagesAddr string) {
 		"/debug/pipelinez",
 		"/debug/servicez",
 		"/debug/extensionz",
+		"/debug/configwarningz",
 	}
 
 	testZPagePathFn := func(t *testing.T, path string) {
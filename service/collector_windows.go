@@ -19,7 +19,6 @@ package service // import "go.opentelemetry.io/collector/service"
 
 import (
 	"context"
-	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -142,18 +141,24 @@ func openEventLog(serviceName string) (*eventlog.Log, error) {
 
 func newWithWindowsEventLogCore(set CollectorSettings, flags *flag.FlagSet, elog *eventlog.Log) (*Collector, error) {
 	if set.ConfigProvider == nil {
-		var err error
-
-		configFlags := getConfigFlag(flags)
-		if len(configFlags) == 0 {
-			return nil, errors.New("at least one config flag must be provided")
+		cfgSet, err := newConfigProviderSettingsFromFlags(flags, func(msg string) {
+			_ = elog.Warning(2, msg)
+		})
+		if err != nil {
+			return nil, err
 		}
 
-		set.ConfigProvider, err = NewConfigProvider(newDefaultConfigProviderSettings(configFlags))
+		set.ConfigProvider, err = NewConfigProvider(cfgSet)
 		if err != nil {
 			return nil, err
 		}
 	}
+	if set.ControlEndpoint == "" {
+		var err error
+		if set.ControlEndpoint, set.ControlToken, err = getControlFlags(flags); err != nil {
+			return nil, err
+		}
+	}
 	set.LoggingOptions = append(
 		[]zap.Option{zap.WrapCore(withWindowsCore(elog))},
 		set.LoggingOptions...,
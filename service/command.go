@@ -15,7 +15,7 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
-	"errors"
+	"fmt"
 
 	"github.com/spf13/cobra"
 
@@ -34,18 +34,24 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 				return err
 			}
 			if set.ConfigProvider == nil {
-				var err error
-
-				configFlags := getConfigFlag(flagSet)
-				if len(configFlags) == 0 {
-					return errors.New("at least one config flag must be provided")
+				cfgSet, err := newConfigProviderSettingsFromFlags(flagSet, func(msg string) {
+					fmt.Fprintln(cmd.ErrOrStderr(), "Warning:", msg)
+				})
+				if err != nil {
+					return err
 				}
 
-				set.ConfigProvider, err = NewConfigProvider(newDefaultConfigProviderSettings(configFlags))
+				set.ConfigProvider, err = NewConfigProvider(cfgSet)
 				if err != nil {
 					return err
 				}
 			}
+			if set.ControlEndpoint == "" {
+				var err error
+				if set.ControlEndpoint, set.ControlToken, err = getControlFlags(flagSet); err != nil {
+					return err
+				}
+			}
 			col, err := New(set)
 			if err != nil {
 				return err
@@ -54,6 +60,7 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 		},
 	}
 	rootCmd.AddCommand(newBuildSubCommand(set))
+	rootCmd.AddCommand(newGenerateConfigSubCommand(set))
 	rootCmd.Flags().AddGoFlagSet(flagSet)
 	return rootCmd
 }
@@ -16,6 +16,7 @@ package service // import "go.opentelemetry.io/collector/service"
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -23,12 +24,20 @@ import (
 	"go.opentelemetry.io/collector/component"
 )
 
+type componentWithStability struct {
+	Name component.Type
+	// ModuleInfo is the Go module providing the component, from the manifest of the build info.
+	component.ModuleInfo `yaml:",inline"`
+	Stability            map[string]string
+}
+
 type componentsOutput struct {
 	BuildInfo  component.BuildInfo
-	Receivers  []component.Type
-	Processors []component.Type
-	Exporters  []component.Type
-	Extensions []component.Type
+	Receivers  []componentWithStability
+	Processors []componentWithStability
+	Exporters  []componentWithStability
+	Extensions []componentWithStability
+	Connectors []componentWithStability
 }
 
 // newBuildSubCommand constructs a new cobra.Command sub command using the given CollectorSettings.
@@ -40,19 +49,52 @@ func newBuildSubCommand(set CollectorSettings) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			components := componentsOutput{}
-			for ext := range set.Factories.Extensions {
-				components.Extensions = append(components.Extensions, ext)
+			for _, ext := range sortedTypes(set.Factories.Extensions) {
+				components.Extensions = append(components.Extensions, newComponentWithStability(set.BuildInfo, component.KindExtension, ext, map[string]string{
+					"extension": set.Factories.Extensions[ext].ExtensionStability().String(),
+				}))
 			}
-			for prs := range set.Factories.Processors {
-				components.Processors = append(components.Processors, prs)
+			for _, prs := range sortedTypes(set.Factories.Processors) {
+				f := set.Factories.Processors[prs]
+				components.Processors = append(components.Processors, newComponentWithStability(set.BuildInfo, component.KindProcessor, prs, map[string]string{
+					"traces":  f.TracesProcessorStability().String(),
+					"metrics": f.MetricsProcessorStability().String(),
+					"logs":    f.LogsProcessorStability().String(),
+				}))
 			}
-			for rcv := range set.Factories.Receivers {
-				components.Receivers = append(components.Receivers, rcv)
+			for _, rcv := range sortedTypes(set.Factories.Receivers) {
+				f := set.Factories.Receivers[rcv]
+				components.Receivers = append(components.Receivers, newComponentWithStability(set.BuildInfo, component.KindReceiver, rcv, map[string]string{
+					"traces":  f.TracesReceiverStability().String(),
+					"metrics": f.MetricsReceiverStability().String(),
+					"logs":    f.LogsReceiverStability().String(),
+				}))
 			}
-			for exp := range set.Factories.Exporters {
-				components.Exporters = append(components.Exporters, exp)
+			for _, exp := range sortedTypes(set.Factories.Exporters) {
+				f := set.Factories.Exporters[exp]
+				components.Exporters = append(components.Exporters, newComponentWithStability(set.BuildInfo, component.KindExporter, exp, map[string]string{
+					"traces":  f.TracesExporterStability().String(),
+					"metrics": f.MetricsExporterStability().String(),
+					"logs":    f.LogsExporterStability().String(),
+				}))
+			}
+			for _, conn := range sortedTypes(set.Factories.Connectors) {
+				f := set.Factories.Connectors[conn]
+				components.Connectors = append(components.Connectors, newComponentWithStability(set.BuildInfo, component.KindConnector, conn, map[string]string{
+					"traces-to-traces":   f.TracesToTracesStability().String(),
+					"traces-to-metrics":  f.TracesToMetricsStability().String(),
+					"traces-to-logs":     f.TracesToLogsStability().String(),
+					"metrics-to-traces":  f.MetricsToTracesStability().String(),
+					"metrics-to-metrics": f.MetricsToMetricsStability().String(),
+					"metrics-to-logs":    f.MetricsToLogsStability().String(),
+					"logs-to-traces":     f.LogsToTracesStability().String(),
+					"logs-to-metrics":    f.LogsToMetricsStability().String(),
+					"logs-to-logs":       f.LogsToLogsStability().String(),
+				}))
 			}
 			components.BuildInfo = set.BuildInfo
+			// The modules of the manifest are listed with their components.
+			components.BuildInfo.Manifest = component.Manifest{}
 			yamlData, err := yaml.Marshal(components)
 			if err != nil {
 				return err
@@ -63,3 +105,19 @@ func newBuildSubCommand(set CollectorSettings) *cobra.Command {
 	}
 	return buildCmd
 }
+
+// newComponentWithStability returns the description of a component, with the Go module providing it if it is in the manifest.
+func newComponentWithStability(buildInfo component.BuildInfo, kind component.Kind, componentType component.Type, stability map[string]string) componentWithStability {
+	c := componentWithStability{Name: componentType, Stability: stability}
+	c.ModuleInfo, _ = buildInfo.Manifest.Module(kind, componentType)
+	return c
+}
+
+func sortedTypes[F component.Factory](factories map[component.Type]F) []component.Type {
+	types := make([]component.Type, 0, len(factories))
+	for t := range factories {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
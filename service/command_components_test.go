@@ -36,8 +36,14 @@ func TestNewBuildSubCommand(t *testing.T) {
 	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
 	require.NoError(t, err)
 
+	buildInfo := component.NewDefaultBuildInfo()
+	buildInfo.Manifest = component.Manifest{
+		Receivers: map[component.Type]component.ModuleInfo{
+			"nop": {Module: "go.opentelemetry.io/collector/component", Version: "v0.65.0"},
+		},
+	}
 	set := CollectorSettings{
-		BuildInfo:      component.NewDefaultBuildInfo(),
+		BuildInfo:      buildInfo,
 		Factories:      factories,
 		ConfigProvider: cfgProvider,
 		telemetry:      newColTelemetry(featuregate.NewRegistry()),
@@ -45,12 +51,31 @@ func TestNewBuildSubCommand(t *testing.T) {
 	cmd := NewCommand(set)
 	cmd.SetArgs([]string{"components"})
 
+	stable := map[string]string{"traces": "Stable", "metrics": "Stable", "logs": "Stable"}
 	ExpectedYamlStruct := componentsOutput{
-		BuildInfo:  component.NewDefaultBuildInfo(),
-		Receivers:  []component.Type{"nop"},
-		Processors: []component.Type{"nop"},
-		Exporters:  []component.Type{"nop"},
-		Extensions: []component.Type{"nop"},
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Receivers: []componentWithStability{{
+			Name:       "nop",
+			ModuleInfo: component.ModuleInfo{Module: "go.opentelemetry.io/collector/component", Version: "v0.65.0"},
+			Stability:  stable,
+		}},
+		Processors: []componentWithStability{{Name: "nop", Stability: stable}},
+		Exporters:  []componentWithStability{{Name: "nop", Stability: stable}},
+		Extensions: []componentWithStability{{Name: "nop", Stability: map[string]string{"extension": "Stable"}}},
+		Connectors: []componentWithStability{{
+			Name: "nop",
+			Stability: map[string]string{
+				"traces-to-traces":   "Stable",
+				"traces-to-metrics":  "Stable",
+				"traces-to-logs":     "Stable",
+				"metrics-to-traces":  "Stable",
+				"metrics-to-metrics": "Stable",
+				"metrics-to-logs":    "Stable",
+				"logs-to-traces":     "Stable",
+				"logs-to-metrics":    "Stable",
+				"logs-to-logs":       "Stable",
+			},
+		}},
 	}
 	ExpectedOutput, err := yaml.Marshal(ExpectedYamlStruct)
 	require.NoError(t, err)
@@ -0,0 +1,265 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/service/internal/components"
+)
+
+// allDataTypes lists the data types of the pipelines generate-config can create, in the order they are tried.
+var allDataTypes = []component.DataType{component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs}
+
+// newGenerateConfigSubCommand constructs a new cobra.Command sub command emitting a starter configuration.
+func newGenerateConfigSubCommand(set CollectorSettings) *cobra.Command {
+	var receivers, processors, exporters, extensions, pipelines []string
+	cmd := &cobra.Command{
+		Use:   "generate-config",
+		Short: "Outputs a starter configuration with the default settings of the given components",
+		Long: "Outputs a starter configuration with the default settings of the given components, " +
+			"connected in one pipeline per data type. Only the settings with a non-zero default value are written.",
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := buildStarterConfig(set.Factories, receivers, processors, exporters, extensions, pipelines)
+			if err != nil {
+				return err
+			}
+			yamlData, err := yaml.Marshal(cfg)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), string(yamlData))
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVar(&receivers, "receivers", nil, "Comma separated list of the receivers of the pipelines.")
+	cmd.Flags().StringSliceVar(&processors, "processors", nil, "Comma separated list of the processors of the pipelines, in order.")
+	cmd.Flags().StringSliceVar(&exporters, "exporters", nil, "Comma separated list of the exporters of the pipelines.")
+	cmd.Flags().StringSliceVar(&extensions, "extensions", nil, "Comma separated list of the extensions to enable.")
+	cmd.Flags().StringSliceVar(&pipelines, "pipelines", nil,
+		"Comma separated list of the data types of the pipelines. Defaults to all the data types supported by the components.")
+	return cmd
+}
+
+// buildStarterConfig returns the configuration, as a map ready to be encoded in YAML, with the default settings of
+// the given components and one pipeline for every data type.
+func buildStarterConfig(factories component.Factories, receivers, processors, exporters, extensions, pipelines []string) (map[string]interface{}, error) {
+	if len(receivers) == 0 || len(exporters) == 0 {
+		return nil, fmt.Errorf("at least one receiver and one exporter must be given")
+	}
+
+	supported := map[component.DataType]bool{}
+	for _, dt := range allDataTypes {
+		supported[dt] = true
+	}
+	out := map[string]interface{}{}
+	var err error
+	if out["receivers"], err = defaultConfigs(component.KindReceiver, receivers, factories.Receivers, components.ReceiverStability, supported); err != nil {
+		return nil, err
+	}
+	if len(processors) > 0 {
+		if out["processors"], err = defaultConfigs(component.KindProcessor, processors, factories.Processors, components.ProcessorStability, supported); err != nil {
+			return nil, err
+		}
+	}
+	if out["exporters"], err = defaultConfigs(component.KindExporter, exporters, factories.Exporters, components.ExporterStability, supported); err != nil {
+		return nil, err
+	}
+	if len(extensions) > 0 {
+		if out["extensions"], err = defaultConfigs(component.KindExtension, extensions, factories.Extensions, nil, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	var dataTypes []component.DataType
+	if len(pipelines) == 0 {
+		for _, dt := range allDataTypes {
+			if supported[dt] {
+				dataTypes = append(dataTypes, dt)
+			}
+		}
+		if len(dataTypes) == 0 {
+			return nil, fmt.Errorf("the given components do not support any common data type")
+		}
+	}
+	for _, p := range pipelines {
+		dt := component.DataType(p)
+		if _, ok := supported[dt]; !ok {
+			return nil, fmt.Errorf("unknown pipeline data type %q", p)
+		}
+		if !supported[dt] {
+			return nil, fmt.Errorf("not all the given components support the %q data type", p)
+		}
+		dataTypes = append(dataTypes, dt)
+	}
+
+	service := map[string]interface{}{}
+	if len(extensions) > 0 {
+		service["extensions"] = extensions
+	}
+	pipelinesOut := map[string]interface{}{}
+	for _, dt := range dataTypes {
+		pipeline := map[string]interface{}{
+			"receivers": receivers,
+			"exporters": exporters,
+		}
+		if len(processors) > 0 {
+			pipeline["processors"] = processors
+		}
+		pipelinesOut[string(dt)] = pipeline
+	}
+	service["pipelines"] = pipelinesOut
+	out["service"] = service
+
+	if err = validateStarterConfig(factories, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// validateStarterConfig checks that the configuration, once written in YAML, loads and is valid, as the collector
+// would check it on start.
+func validateStarterConfig(factories component.Factories, out map[string]interface{}) error {
+	yamlData, err := yaml.Marshal(out)
+	if err != nil {
+		return fmt.Errorf("failed to encode the generated configuration: %w", err)
+	}
+	var rawConf map[string]interface{}
+	if err = yaml.Unmarshal(yamlData, &rawConf); err != nil {
+		return fmt.Errorf("failed to decode the generated configuration: %w", err)
+	}
+	cfgSet, err := unmarshal(confmap.NewFromStringMap(rawConf), factories)
+	if err != nil {
+		return fmt.Errorf("the generated configuration cannot be loaded: %w", err)
+	}
+	cfg := &Config{
+		Receivers:  cfgSet.Receivers.GetReceivers(),
+		Processors: cfgSet.Processors.GetProcessors(),
+		Exporters:  cfgSet.Exporters.GetExporters(),
+		Extensions: cfgSet.Extensions.GetExtensions(),
+		Connectors: cfgSet.Connectors.GetConnectors(),
+		Service:    cfgSet.Service,
+	}
+	if err = cfg.Validate(); err != nil {
+		return fmt.Errorf("the generated configuration is invalid: %w", err)
+	}
+	return nil
+}
+
+// defaultConfigs returns the default configuration of every component, encoded with confmap. When stability is
+// not nil, the data types not supported by a component are marked as false in supported.
+func defaultConfigs[F component.Factory](
+	kind component.Kind,
+	ids []string,
+	factories map[component.Type]F,
+	stability func(F, component.DataType) component.StabilityLevel,
+	supported map[component.DataType]bool,
+) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	for _, idStr := range ids {
+		id := component.ID{}
+		if err := id.UnmarshalText([]byte(idStr)); err != nil {
+			return nil, err
+		}
+		factory, ok := factories[id.Type()]
+		if !ok {
+			return nil, fmt.Errorf("unknown %s type %q", kindName(kind), id.Type())
+		}
+		if stability != nil {
+			for _, dt := range allDataTypes {
+				if stability(factory, dt) == component.StabilityLevelUndefined {
+					supported[dt] = false
+				}
+			}
+		}
+		cfg := factory.CreateDefaultConfig()
+		conf := confmap.New()
+		if err := conf.Marshal(cfg); err != nil {
+			return nil, fmt.Errorf("failed to encode the default configuration of %q: %w", id, err)
+		}
+		zeroConf := confmap.New()
+		if err := zeroConf.Marshal(zeroConfig(cfg)); err != nil {
+			return nil, fmt.Errorf("failed to encode the default configuration of %q: %w", id, err)
+		}
+		out[idStr] = nonZeroSettings(conf.ToStringMap(), zeroConf.ToStringMap())
+	}
+	return out, nil
+}
+
+// zeroConfig returns the zero value of the type of the given configuration.
+func zeroConfig(cfg component.Config) interface{} {
+	t := reflect.TypeOf(cfg)
+	if t.Kind() == reflect.Ptr {
+		return reflect.New(t.Elem()).Interface()
+	}
+	return reflect.Zero(t).Interface()
+}
+
+// nonZeroSettings returns the settings that are not zero values and differ from the zero value of the configuration.
+// The omitted settings keep their default value when the configuration is loaded, and leaving them out drops the
+// settings that are unset by default, e.g. a deprecated setting that conflicts with its replacement, or an endpoint
+// to fill in. Settings that cannot be written in YAML, like functions, are dropped too.
+func nonZeroSettings(settings, zero map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for key, val := range settings {
+		if sub, ok := val.(map[string]interface{}); ok {
+			zeroSub, _ := zero[key].(map[string]interface{})
+			if sub = nonZeroSettings(sub, zeroSub); len(sub) > 0 {
+				out[key] = sub
+			}
+			continue
+		}
+		if isEmptySetting(val) || reflect.DeepEqual(val, zero[key]) {
+			continue
+		}
+		out[key] = val
+	}
+	return out
+}
+
+func isEmptySetting(val interface{}) bool {
+	if val == nil {
+		return true
+	}
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Func, reflect.Chan:
+		return true
+	case reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+func kindName(kind component.Kind) string {
+	switch kind {
+	case component.KindReceiver:
+		return "receiver"
+	case component.KindProcessor:
+		return "processor"
+	case component.KindExporter:
+		return "exporter"
+	case component.KindExtension:
+		return "extension"
+	}
+	return "component"
+}
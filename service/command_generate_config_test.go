@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func TestNewGenerateConfigSubCommand(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	set := CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: factories,
+		telemetry: newColTelemetry(featuregate.NewRegistry()),
+	}
+	cmd := NewCommand(set)
+	cmd.SetArgs([]string{"generate-config", "--receivers", "nop", "--processors", "nop", "--exporters", "nop,nop/2", "--extensions", "nop", "--pipelines", "traces"})
+
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, cmd.Execute())
+
+	var rawConf map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(b.Bytes(), &rawConf))
+	cfg, err := unmarshal(confmap.NewFromStringMap(rawConf), factories)
+	require.NoError(t, err)
+
+	assert.Len(t, cfg.Receivers.GetReceivers(), 1)
+	assert.Len(t, cfg.Processors.GetProcessors(), 1)
+	assert.Len(t, cfg.Exporters.GetExporters(), 2)
+	assert.Len(t, cfg.Extensions.GetExtensions(), 1)
+	assert.Equal(t, []component.ID{component.NewID("nop")}, cfg.Service.Extensions)
+	require.Len(t, cfg.Service.Pipelines, 1)
+	pipeline := cfg.Service.Pipelines[component.NewID(component.DataTypeTraces)]
+	require.NotNil(t, pipeline)
+	assert.Equal(t, []component.ID{component.NewID("nop")}, pipeline.Receivers)
+	assert.Equal(t, []component.ID{component.NewID("nop")}, pipeline.Processors)
+	assert.Equal(t, []component.ID{component.NewID("nop"), component.NewIDWithName("nop", "2")}, pipeline.Exporters)
+}
+
+func TestGenerateConfigAllDataTypes(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	cfg, err := buildStarterConfig(factories, []string{"nop"}, nil, []string{"nop"}, nil, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, cfg, "processors")
+	assert.NotContains(t, cfg, "extensions")
+	pipelines := cfg["service"].(map[string]interface{})["pipelines"].(map[string]interface{})
+	assert.Len(t, pipelines, 3)
+	assert.Contains(t, pipelines, "traces")
+	assert.Contains(t, pipelines, "metrics")
+	assert.Contains(t, pipelines, "logs")
+}
+
+func TestGenerateConfigErrors(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		receivers  []string
+		processors []string
+		exporters  []string
+		extensions []string
+		pipelines  []string
+		expected   string
+	}{
+		{
+			name:      "no_receivers",
+			exporters: []string{"nop"},
+			expected:  "at least one receiver and one exporter must be given",
+		},
+		{
+			name:      "unknown_receiver",
+			receivers: []string{"unknown"},
+			exporters: []string{"nop"},
+			expected:  `unknown receiver type "unknown"`,
+		},
+		{
+			name:       "unknown_processor",
+			receivers:  []string{"nop"},
+			processors: []string{"unknown"},
+			exporters:  []string{"nop"},
+			expected:   `unknown processor type "unknown"`,
+		},
+		{
+			name:       "unknown_extension",
+			receivers:  []string{"nop"},
+			exporters:  []string{"nop"},
+			extensions: []string{"unknown"},
+			expected:   `unknown extension type "unknown"`,
+		},
+		{
+			name:      "invalid_id",
+			receivers: []string{"nop/"},
+			exporters: []string{"nop"},
+			expected:  "the part after / should not be empty",
+		},
+		{
+			name:      "unknown_pipeline",
+			receivers: []string{"nop"},
+			exporters: []string{"nop"},
+			pipelines: []string{"profiles"},
+			expected:  `unknown pipeline data type "profiles"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildStarterConfig(factories, tt.receivers, tt.processors, tt.exporters, tt.extensions, tt.pipelines)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+type starterExporterConfig struct {
+	config.ExporterSettings `mapstructure:",squash"`
+
+	Endpoint string `mapstructure:"endpoint"`
+	// LogLevel is set to its zero value, info, and conflicts with Verbosity when both are set.
+	LogLevel  zapcore.Level        `mapstructure:"loglevel"`
+	Verbosity string               `mapstructure:"verbosity"`
+	Timeout   time.Duration        `mapstructure:"timeout"`
+	Headers   map[string]string    `mapstructure:"headers"`
+	Hook      func() error         `mapstructure:"hook"`
+	Nested    *starterNestedConfig `mapstructure:"nested"`
+	required  bool
+}
+
+type starterNestedConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	Size    int  `mapstructure:"size"`
+}
+
+func (cfg *starterExporterConfig) Unmarshal(conf *confmap.Conf) error {
+	if conf.IsSet("loglevel") && conf.IsSet("verbosity") {
+		return errors.New("'loglevel' and 'verbosity' are incompatible")
+	}
+	return conf.Unmarshal(cfg, confmap.WithErrorUnused())
+}
+
+func (cfg *starterExporterConfig) Validate() error {
+	if cfg.required && cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	return nil
+}
+
+func newStarterExporterFactory(required bool) component.ExporterFactory {
+	return component.NewExporterFactory(
+		"starter",
+		func() component.Config {
+			return &starterExporterConfig{
+				ExporterSettings: config.NewExporterSettings(component.NewID("starter")),
+				Verbosity:        "normal",
+				Timeout:          5 * time.Second,
+				Headers:          map[string]string{},
+				Hook:             func() error { return nil },
+				Nested:           &starterNestedConfig{Size: 10},
+				required:         required,
+			}
+		},
+		component.WithTracesExporter(func(context.Context, component.ExporterCreateSettings, component.Config) (component.TracesExporter, error) {
+			return nil, nil
+		}, component.StabilityLevelStable),
+	)
+}
+
+func TestGenerateConfigOmitsZeroSettings(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	factories.Exporters["starter"] = newStarterExporterFactory(false)
+
+	cfg, err := buildStarterConfig(factories, []string{"nop"}, nil, []string{"starter"}, nil, []string{"traces"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"starter": map[string]interface{}{
+			"verbosity": "normal",
+			"timeout":   5 * time.Second,
+			"nested":    map[string]interface{}{"size": 10},
+		},
+	}, cfg["exporters"])
+}
+
+func TestGenerateConfigInvalidDefaultConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	factories.Exporters["starter"] = newStarterExporterFactory(true)
+
+	_, err = buildStarterConfig(factories, []string{"nop"}, nil, []string{"starter"}, nil, []string{"traces"})
+	assert.ErrorContains(t, err, "the generated configuration is invalid")
+	assert.ErrorContains(t, err, "endpoint must be specified")
+}
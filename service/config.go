@@ -17,9 +17,11 @@ package service // import "go.opentelemetry.io/collector/service"
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
 
@@ -43,6 +45,10 @@ type Config struct {
 	// Extensions is a map of ComponentID to extensions.
 	Extensions map[component.ID]component.Config
 
+	// Connectors is a map of ComponentID to connectors.
+	// A connector is referenced as an exporter in some pipelines and as a receiver in others.
+	Connectors map[component.ID]component.Config
+
 	Service ConfigService
 }
 
@@ -92,6 +98,20 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	// Validate the connector configuration.
+	for connID, connCfg := range cfg.Connectors {
+		if err := component.ValidateConfig(connCfg); err != nil {
+			return fmt.Errorf("connector %q has invalid configuration: %w", connID, err)
+		}
+		// The pipelines reference connectors and receivers or exporters the same way.
+		if _, ok := cfg.Receivers[connID]; ok {
+			return fmt.Errorf("connector %q has the same ID as a receiver", connID)
+		}
+		if _, ok := cfg.Exporters[connID]; ok {
+			return fmt.Errorf("connector %q has the same ID as an exporter", connID)
+		}
+	}
+
 	return cfg.validateService()
 }
 
@@ -104,6 +124,30 @@ func (cfg *Config) validateService() error {
 		}
 	}
 
+	if cfg.Service.Startup.Timeout < 0 {
+		return errors.New("service startup timeout must not be negative")
+	}
+
+	if cfg.Service.Startup.WaitForExporters < 0 {
+		return errors.New("service startup wait_for_exporters must not be negative")
+	}
+
+	if cfg.Service.Shutdown.Timeout < 0 {
+		return errors.New("service shutdown timeout must not be negative")
+	}
+
+	if cfg.Service.Admin != nil && cfg.Service.Admin.Endpoint == "" {
+		return errors.New("service admin endpoint must not be empty")
+	}
+
+	if cfg.Service.Runtime.GoMaxProcs < 0 {
+		return errors.New("service runtime gomaxprocs must not be negative")
+	}
+
+	if err := cfg.Service.ComponentStabilityPolicy.Validate(); err != nil {
+		return err
+	}
+
 	// Must have at least one pipeline.
 	if len(cfg.Service.Pipelines) == 0 {
 		return errMissingServicePipelines
@@ -123,8 +167,8 @@ func (cfg *Config) validateService() error {
 
 		// Validate pipeline receiver name references.
 		for _, ref := range pipeline.Receivers {
-			// Check that the name referenced in the pipeline's receivers exists in the top-level receivers.
-			if cfg.Receivers[ref] == nil {
+			// Check that the name referenced in the pipeline's receivers exists in the top-level receivers or connectors.
+			if cfg.Receivers[ref] == nil && cfg.Connectors[ref] == nil {
 				return fmt.Errorf("pipeline %q references receiver %q which does not exist", pipelineID, ref)
 			}
 		}
@@ -151,16 +195,73 @@ func (cfg *Config) validateService() error {
 
 		// Validate pipeline exporter name references.
 		for _, ref := range pipeline.Exporters {
-			// Check that the name referenced in the pipeline's Exporters exists in the top-level Exporters.
-			if cfg.Exporters[ref] == nil {
+			// Check that the name referenced in the pipeline's Exporters exists in the top-level Exporters or Connectors.
+			if cfg.Exporters[ref] == nil && cfg.Connectors[ref] == nil {
 				return fmt.Errorf("pipeline %q references exporter %q which does not exist", pipelineID, ref)
 			}
 		}
 
+		if err := validateFailurePolicy(pipelineID, pipeline); err != nil {
+			return err
+		}
+
 		if err := cfg.Service.Telemetry.Validate(); err != nil {
 			fmt.Printf("telemetry config validation failed, %v\n", err)
 		}
 	}
+	return cfg.validateConnectorReferences()
+}
+
+// validateFailurePolicy checks that the failure policy of the pipeline has a known mode and only ignores the
+// errors of exporters of the pipeline.
+func validateFailurePolicy(pipelineID component.ID, pipeline *ConfigServicePipeline) error {
+	switch pipeline.FailurePolicy.Mode {
+	case "", config.PipelineFailureModeAll, config.PipelineFailureModeAny:
+	default:
+		return fmt.Errorf("pipeline %q failure policy mode %q must be %q or %q", pipelineID, pipeline.FailurePolicy.Mode, config.PipelineFailureModeAll, config.PipelineFailureModeAny)
+	}
+	for _, ref := range pipeline.FailurePolicy.IgnoreErrorsFor {
+		found := false
+		for _, expID := range pipeline.Exporters {
+			if expID == ref {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("pipeline %q failure policy ignores errors for %q which is not an exporter of the pipeline", pipelineID, ref)
+		}
+	}
+	return nil
+}
+
+// validateConnectorReferences checks that every connector used as an exporter in a pipeline is also used
+// as a receiver in a pipeline, and vice versa.
+func (cfg *Config) validateConnectorReferences() error {
+	exportingPipelines := make(map[component.ID]component.ID)
+	receivingPipelines := make(map[component.ID]component.ID)
+	for pipelineID, pipeline := range cfg.Service.Pipelines {
+		for _, ref := range pipeline.Exporters {
+			if _, ok := cfg.Connectors[ref]; ok {
+				exportingPipelines[ref] = pipelineID
+			}
+		}
+		for _, ref := range pipeline.Receivers {
+			if _, ok := cfg.Connectors[ref]; ok {
+				receivingPipelines[ref] = pipelineID
+			}
+		}
+	}
+	for connID, pipelineID := range exportingPipelines {
+		if _, ok := receivingPipelines[connID]; !ok {
+			return fmt.Errorf("connector %q is used as exporter in pipeline %q but is not used as receiver in any pipeline", connID, pipelineID)
+		}
+	}
+	for connID, pipelineID := range receivingPipelines {
+		if _, ok := exportingPipelines[connID]; !ok {
+			return fmt.Errorf("connector %q is used as receiver in pipeline %q but is not used as exporter in any pipeline", connID, pipelineID)
+		}
+	}
 	return nil
 }
 
@@ -174,6 +275,87 @@ type ConfigService struct {
 
 	// Pipelines are the set of data pipelines configured for the service.
 	Pipelines map[component.ID]*ConfigServicePipeline `mapstructure:"pipelines"`
+
+	// Startup configures how the components of the pipelines are started.
+	Startup ConfigServiceStartup `mapstructure:"startup"`
+
+	// Shutdown configures how the components are shut down.
+	Shutdown ConfigServiceShutdown `mapstructure:"shutdown"`
+
+	// Admin configures the HTTP server on which the extensions implementing component.HTTPHandlerProvider are
+	// served, each under the "/<extension ID>/" path prefix. Disabled by default.
+	Admin *confighttp.HTTPServerSettings `mapstructure:"admin"`
+
+	// Runtime configures the Go runtime of the collector process.
+	Runtime ConfigServiceRuntime `mapstructure:"runtime"`
+
+	// ComponentStabilityPolicy configures the minimum stability level of the configured components.
+	ComponentStabilityPolicy ConfigServiceStabilityPolicy `mapstructure:"component_stability_policy"`
+}
+
+// ConfigServiceStartup defines how the components of the pipelines are started.
+type ConfigServiceStartup struct {
+	// Parallel starts independent components concurrently: first all the exporters, then the processors
+	// of all the pipelines (each pipeline in order), then all the receivers.
+	// By default components are started one after the other.
+	Parallel bool `mapstructure:"parallel"`
+
+	// Timeout limits the time to start all the components of the pipelines. Zero means no limit.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// WaitForExporters is the maximum time to wait, once the components are started, for the exporters
+	// implementing component.ConnectionWaiter to connect to their destination, before the service is ready
+	// and the extensions implementing component.PipelineWatcher are notified. The exporters still not connected
+	// after this time are logged, and the service gets ready anyway. Zero, the default, does not wait.
+	WaitForExporters time.Duration `mapstructure:"wait_for_exporters"`
+}
+
+// ConfigServiceShutdown defines how the components are shut down.
+type ConfigServiceShutdown struct {
+	// Timeout limits the time given to each component of the pipelines and to each extension to shut down.
+	// Components still running when it expires are logged and given up on. Zero means no limit.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// DumpGoroutines logs the stacks of all the goroutines along with the first component that does not shut
+	// down before the timeout, to help identify where it hangs.
+	DumpGoroutines bool `mapstructure:"dump_goroutines"`
+}
+
+// ConfigServiceRuntime defines how the Go runtime of the collector process is configured.
+type ConfigServiceRuntime struct {
+	// GoMaxProcs overrides the maximum number of CPUs executing Go code simultaneously.
+	// By default (zero) it is derived from the cgroup CPU quota of the process, rounded down
+	// with a minimum of 1, unless the GOMAXPROCS environment variable is set.
+	GoMaxProcs int `mapstructure:"gomaxprocs"`
+}
+
+// ConfigServiceStabilityPolicy defines what happens when a configured component is below a stability level.
+type ConfigServiceStabilityPolicy struct {
+	// MinLevel is the minimum stability level of the components, for the signals of the pipelines they are
+	// used in. One of "unmaintained", "deprecated", "development", "alpha", "beta" or "stable".
+	// By default (empty) no minimum is enforced.
+	MinLevel string `mapstructure:"min_level"`
+
+	// Action is either "warn" (the default), which logs the components below MinLevel, or "refuse",
+	// which prevents the collector from starting.
+	Action string `mapstructure:"action"`
+}
+
+// Validate checks that the minimum level and the action are known.
+func (p *ConfigServiceStabilityPolicy) Validate() error {
+	if p.MinLevel != "" {
+		if _, err := parseStabilityLevel(p.MinLevel); err != nil {
+			return err
+		}
+	}
+	switch p.Action {
+	case "", stabilityActionWarn, stabilityActionRefuse:
+		return nil
+	}
+	return fmt.Errorf("service component stability policy action %q must be %q or %q", p.Action, stabilityActionWarn, stabilityActionRefuse)
 }
 
 type ConfigServicePipeline = config.Pipeline
+
+// ConfigServicePipelineFailurePolicy defines which failures of the exporters of a pipeline fail the pipeline.
+type ConfigServicePipelineFailurePolicy = config.PipelineFailurePolicy
@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/confmap/provider/envprovider"
 	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
 	"go.opentelemetry.io/collector/confmap/provider/httpprovider"
+	"go.opentelemetry.io/collector/confmap/provider/httpsprovider"
 	"go.opentelemetry.io/collector/confmap/provider/yamlprovider"
 )
 
@@ -75,7 +76,7 @@ func newDefaultConfigProviderSettings(uris []string) ConfigProviderSettings {
 	return ConfigProviderSettings{
 		ResolverSettings: confmap.ResolverSettings{
 			URIs:       uris,
-			Providers:  makeMapProvidersMap(fileprovider.New(), envprovider.New(), yamlprovider.New(), httpprovider.New()),
+			Providers:  makeMapProvidersMap(fileprovider.New(), envprovider.New(), yamlprovider.New(), httpprovider.New(), httpsprovider.New()),
 			Converters: []confmap.Converter{expandconverter.New()},
 		},
 	}
@@ -114,6 +115,7 @@ func (cm *configProvider) Get(ctx context.Context, factories component.Factories
 		Processors: cfg.Processors.GetProcessors(),
 		Exporters:  cfg.Exporters.GetExporters(),
 		Extensions: cfg.Extensions.GetExtensions(),
+		Connectors: cfg.Connectors.GetConnectors(),
 		Service:    cfg.Service,
 	}, nil
 }
@@ -124,3 +124,30 @@ func TestConfigProviderFile(t *testing.T) {
 	require.NoError(t, err)
 	assert.EqualValues(t, configNop, cfg)
 }
+
+func TestConfigProviderFileAndYaml(t *testing.T) {
+	fp := fileprovider.New()
+	yp := yamlprovider.New()
+	set := ConfigProviderSettings{
+		ResolverSettings: confmap.ResolverSettings{
+			URIs: []string{
+				"file:" + filepath.Join("testdata", "otelcol-nop.yaml"),
+				"yaml:service::telemetry::metrics::address: localhost:9999",
+			},
+			Providers: map[string]confmap.Provider{fp.Scheme(): fp, yp.Scheme(): yp},
+		},
+	}
+
+	cp, err := NewConfigProvider(set)
+	require.NoError(t, err)
+
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	cfg, err := cp.Get(context.Background(), factories)
+	require.NoError(t, err)
+	// The inline yaml overrides the value from the file, everything else comes from the file.
+	assert.Equal(t, "localhost:9999", cfg.Service.Telemetry.Metrics.Address)
+	assert.EqualValues(t, configNop.Receivers, cfg.Receivers)
+	assert.EqualValues(t, configNop.Service.Pipelines, cfg.Service.Pipelines)
+}
@@ -18,12 +18,14 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
@@ -33,6 +35,7 @@ var (
 	errInvalidExpConfig  = errors.New("invalid exporter config")
 	errInvalidProcConfig = errors.New("invalid processor config")
 	errInvalidExtConfig  = errors.New("invalid extension config")
+	errInvalidConnConfig = errors.New("invalid connector config")
 )
 
 type nopRecvConfig struct {
@@ -71,6 +74,15 @@ func (nc *nopExtConfig) Validate() error {
 	return nc.validateErr
 }
 
+type nopConnConfig struct {
+	config.ConnectorSettings
+	validateErr error
+}
+
+func (nc *nopConnConfig) Validate() error {
+	return nc.validateErr
+}
+
 func TestConfigValidate(t *testing.T) {
 	var testCases = []struct {
 		name     string // test case name (also file name containing config yaml)
@@ -118,6 +130,69 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New(`service references extension "nop/2" which does not exist`),
 		},
+		{
+			name: "negative-startup-timeout",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Startup.Timeout = -time.Second
+				return cfg
+			},
+			expected: errors.New("service startup timeout must not be negative"),
+		},
+		{
+			name: "negative-startup-wait-for-exporters",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Startup.WaitForExporters = -time.Second
+				return cfg
+			},
+			expected: errors.New("service startup wait_for_exporters must not be negative"),
+		},
+		{
+			name: "empty-admin-endpoint",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Admin = &confighttp.HTTPServerSettings{}
+				return cfg
+			},
+			expected: errors.New("service admin endpoint must not be empty"),
+		},
+		{
+			name: "negative-shutdown-timeout",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Shutdown.Timeout = -time.Second
+				return cfg
+			},
+			expected: errors.New("service shutdown timeout must not be negative"),
+		},
+		{
+			name: "negative-runtime-gomaxprocs",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Runtime.GoMaxProcs = -1
+				return cfg
+			},
+			expected: errors.New("service runtime gomaxprocs must not be negative"),
+		},
+		{
+			name: "invalid-stability-policy-min-level",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.ComponentStabilityPolicy.MinLevel = "gold"
+				return cfg
+			},
+			expected: errors.New(`service component stability policy has unknown min_level "gold"`),
+		},
+		{
+			name: "invalid-stability-policy-action",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.ComponentStabilityPolicy.Action = "ignore"
+				return cfg
+			},
+			expected: errors.New(`service component stability policy action "ignore" must be "warn" or "refuse"`),
+		},
 		{
 			name: "invalid-receiver-reference",
 			cfgFn: func() *Config {
@@ -158,6 +233,39 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New(`pipeline "traces" references exporter "nop/2" which does not exist`),
 		},
+		{
+			name: "valid-failure-policy",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				pipe := cfg.Service.Pipelines[component.NewID("traces")]
+				pipe.FailurePolicy = ConfigServicePipelineFailurePolicy{
+					Mode:            "any",
+					IgnoreErrorsFor: []component.ID{component.NewID("nop")},
+				}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-failure-policy-mode",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				pipe := cfg.Service.Pipelines[component.NewID("traces")]
+				pipe.FailurePolicy.Mode = "some"
+				return cfg
+			},
+			expected: errors.New(`pipeline "traces" failure policy mode "some" must be "all" or "any"`),
+		},
+		{
+			name: "invalid-failure-policy-ignore-errors-for",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				pipe := cfg.Service.Pipelines[component.NewID("traces")]
+				pipe.FailurePolicy.IgnoreErrorsFor = []component.ID{component.NewIDWithName("nop", "2")}
+				return cfg
+			},
+			expected: errors.New(`pipeline "traces" failure policy ignores errors for "nop/2" which is not an exporter of the pipeline`),
+		},
 		{
 			name: "missing-pipeline-receivers",
 			cfgFn: func() *Config {
@@ -248,6 +356,53 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New(`unknown pipeline datatype "wrongtype" for wrongtype`),
 		},
+		{
+			name:     "valid-connector",
+			cfgFn:    generateConfigWithConnector,
+			expected: nil,
+		},
+		{
+			name: "invalid-connector-config",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithConnector()
+				cfg.Connectors[component.NewIDWithName("nop", "conn")] = &nopConnConfig{
+					ConnectorSettings: config.NewConnectorSettings(component.NewIDWithName("nop", "conn")),
+					validateErr:       errInvalidConnConfig,
+				}
+				return cfg
+			},
+			expected: fmt.Errorf(`connector "nop/conn" has invalid configuration: %w`, errInvalidConnConfig),
+		},
+		{
+			name: "connector-same-id-as-receiver",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithConnector()
+				cfg.Receivers[component.NewIDWithName("nop", "conn")] = &nopRecvConfig{
+					ReceiverSettings: config.NewReceiverSettings(component.NewIDWithName("nop", "conn")),
+				}
+				return cfg
+			},
+			expected: errors.New(`connector "nop/conn" has the same ID as a receiver`),
+		},
+		{
+			name: "connector-not-used-as-receiver",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithConnector()
+				delete(cfg.Service.Pipelines, component.NewIDWithName("metrics", "out"))
+				return cfg
+			},
+			expected: errors.New(`connector "nop/conn" is used as exporter in pipeline "traces" but is not used as receiver in any pipeline`),
+		},
+		{
+			name: "connector-not-used-as-exporter",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithConnector()
+				pipe := cfg.Service.Pipelines[component.NewID("traces")]
+				pipe.Exporters = []component.ID{component.NewID("nop")}
+				return cfg
+			},
+			expected: errors.New(`connector "nop/conn" is used as receiver in pipeline "metrics/out" but is not used as exporter in any pipeline`),
+		},
 		{
 			name: "invalid-telemetry-metric-config",
 			cfgFn: func() *Config {
@@ -318,3 +473,20 @@ func generateConfig() *Config {
 		},
 	}
 }
+
+func generateConfigWithConnector() *Config {
+	cfg := generateConfig()
+	connID := component.NewIDWithName("nop", "conn")
+	cfg.Connectors = map[component.ID]component.Config{
+		connID: &nopConnConfig{
+			ConnectorSettings: config.NewConnectorSettings(connID),
+		},
+	}
+	pipe := cfg.Service.Pipelines[component.NewID("traces")]
+	pipe.Exporters = append(pipe.Exporters, connID)
+	cfg.Service.Pipelines[component.NewIDWithName("metrics", "out")] = &ConfigServicePipeline{
+		Receivers: []component.ID{connID},
+		Exporters: []component.ID{component.NewID("nop")},
+	}
+	return cfg
+}
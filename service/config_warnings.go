@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// configWarning is a warning about the configuration of a component, as reported in the logs and by the
+// configwarningz zpage.
+type configWarning struct {
+	Kind    string `json:"kind"`
+	ID      string `json:"id"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// collectConfigWarnings returns the warnings about the configurations of the components, sorted by kind,
+// component ID and path.
+func collectConfigWarnings(cfg *Config) []configWarning {
+	var warnings []configWarning
+	collect := func(kind string, configs map[component.ID]component.Config) {
+		for id, compCfg := range configs {
+			for _, w := range component.CollectConfigWarnings(compCfg) {
+				warnings = append(warnings, configWarning{Kind: kind, ID: id.String(), Path: w.Path, Message: w.Message})
+			}
+		}
+	}
+	collect("receiver", cfg.Receivers)
+	collect("processor", cfg.Processors)
+	collect("exporter", cfg.Exporters)
+	collect("connector", cfg.Connectors)
+	collect("extension", cfg.Extensions)
+
+	sort.SliceStable(warnings, func(i, j int) bool {
+		if warnings[i].Kind != warnings[j].Kind {
+			return warnings[i].Kind < warnings[j].Kind
+		}
+		if warnings[i].ID != warnings[j].ID {
+			return warnings[i].ID < warnings[j].ID
+		}
+		return warnings[i].Path < warnings[j].Path
+	})
+	return warnings
+}
+
+func logConfigWarnings(logger *zap.Logger, warnings []configWarning) {
+	for _, w := range warnings {
+		logger.Warn("Component configuration warning",
+			zap.String("kind", w.Kind),
+			zap.String("id", w.ID),
+			zap.String("path", w.Path),
+			zap.String("message", w.Message))
+	}
+}
+
+func (host *serviceHost) handleConfigWarningzRequest(w http.ResponseWriter, r *http.Request) {
+	warnings := host.configWarnings
+	if warnings == nil {
+		warnings = []configWarning{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		Warnings []configWarning `json:"warnings"`
+	}{Warnings: warnings})
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+type warningsConfig struct {
+	config.ReceiverSettings
+	warnings []component.ConfigWarning
+}
+
+func (c *warningsConfig) Warnings() []component.ConfigWarning {
+	return c.warnings
+}
+
+func TestCollectConfigWarnings(t *testing.T) {
+	cfg := &Config{
+		Receivers: map[component.ID]component.Config{
+			component.NewID("otlp"): &warningsConfig{warnings: []component.ConfigWarning{
+				{Path: "protocols::http::endpoint", Message: "deprecated"},
+				{Path: "protocols::grpc::endpoint", Message: "listens on all interfaces"},
+			}},
+			component.NewID("nop"): &warningsConfig{},
+		},
+		Exporters: map[component.ID]component.Config{
+			component.NewID("otlp"): &warningsConfig{warnings: []component.ConfigWarning{{Message: "insecure"}}},
+		},
+	}
+
+	warnings := collectConfigWarnings(cfg)
+	assert.Equal(t, []configWarning{
+		{Kind: "exporter", ID: "otlp", Message: "insecure"},
+		{Kind: "receiver", ID: "otlp", Path: "protocols::grpc::endpoint", Message: "listens on all interfaces"},
+		{Kind: "receiver", ID: "otlp", Path: "protocols::http::endpoint", Message: "deprecated"},
+	}, warnings)
+
+	core, observed := observer.New(zapcore.WarnLevel)
+	logConfigWarnings(zap.New(core), warnings)
+	require.Equal(t, 3, observed.Len())
+	assert.Equal(t, map[string]interface{}{
+		"kind":    "exporter",
+		"id":      "otlp",
+		"path":    "",
+		"message": "insecure",
+	}, observed.All()[0].ContextMap())
+}
+
+func TestConfigWarningzRequest(t *testing.T) {
+	host := &serviceHost{}
+
+	rr := httptest.NewRecorder()
+	host.handleConfigWarningzRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/configwarningz", nil))
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"warnings": []}`, rr.Body.String())
+
+	host.configWarnings = []configWarning{{Kind: "receiver", ID: "otlp", Path: "endpoint", Message: "deprecated"}}
+	rr = httptest.NewRecorder()
+	host.handleConfigWarningzRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/configwarningz", nil))
+	var body struct {
+		Warnings []configWarning `json:"warnings"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Equal(t, host.configWarnings, body.Warnings)
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	controlReloadPath = "/-/reload"
+	controlQuitPath   = "/-/quit"
+)
+
+// startControlServer starts serving the control endpoints on col.set.ControlEndpoint.
+// It returns a nil server if no control endpoint is configured.
+func (col *Collector) startControlServer(logger *zap.Logger) (*http.Server, error) {
+	if col.set.ControlEndpoint == "" {
+		return nil, nil
+	}
+	ln, err := net.Listen("tcp", col.set.ControlEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind control endpoint %q: %w", col.set.ControlEndpoint, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(controlReloadPath, col.controlHandler(col.Reload))
+	mux.Handle(controlQuitPath, col.controlHandler(col.Shutdown))
+	srv := &http.Server{Handler: mux} // nolint:gosec
+
+	go func() {
+		if serveErr := srv.Serve(ln); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			logger.Error("Control endpoint failed", zap.Error(serveErr))
+		}
+	}()
+	logger.Info("Control endpoint started", zap.String("endpoint", ln.Addr().String()))
+	return srv, nil
+}
+
+// controlHandler returns an http.Handler that calls action for authorized POST requests from the local host.
+func (col *Collector) controlHandler(action func()) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isLoopback(r.RemoteAddr) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		if col.set.ControlToken != "" {
+			expected := "Bearer " + col.set.ControlToken
+			if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(expected)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+		}
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		action()
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+// validateControlEndpoint checks that the control endpoint only listens on a loopback interface.
+func validateControlEndpoint(endpoint string) error {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid control endpoint %q: %w", endpoint, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("invalid control endpoint %q: the host must be localhost or a loopback address", endpoint)
+}
+
+func isLoopback(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
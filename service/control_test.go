@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+func TestCollectorControlEndpoint(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	col, err := New(CollectorSettings{
+		BuildInfo:       component.NewDefaultBuildInfo(),
+		Factories:       factories,
+		ConfigProvider:  cfgProvider,
+		ControlEndpoint: endpoint,
+		ControlToken:    "secret",
+		telemetry:       newColTelemetry(featuregate.NewRegistry()),
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	post := func(path string, token string) int {
+		req, reqErr := http.NewRequest(http.MethodPost, "http://"+endpoint+path, nil)
+		require.NoError(t, reqErr)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, reqErr := http.DefaultClient.Do(req)
+		require.NoError(t, reqErr)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, post(controlReloadPath, ""))
+	assert.Equal(t, http.StatusAccepted, post(controlReloadPath, "secret"))
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	assert.Equal(t, http.StatusAccepted, post(controlQuitPath, "secret"))
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
+func TestCollectorControlEndpointNotLoopback(t *testing.T) {
+	cfgProvider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+
+	_, err = New(CollectorSettings{
+		ConfigProvider:  cfgProvider,
+		ControlEndpoint: "0.0.0.0:13134",
+	})
+	assert.EqualError(t, err, `invalid control endpoint "0.0.0.0:13134": the host must be localhost or a loopback address`)
+}
+
+func TestControlHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		method         string
+		remoteAddr     string
+		authorization  string
+		expectedStatus int
+	}{
+		{
+			name:           "accepted",
+			method:         http.MethodPost,
+			remoteAddr:     "127.0.0.1:5000",
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "accepted ipv6",
+			method:         http.MethodPost,
+			remoteAddr:     "[::1]:5000",
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "accepted with token",
+			token:          "secret",
+			method:         http.MethodPost,
+			remoteAddr:     "127.0.0.1:5000",
+			authorization:  "Bearer secret",
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "remote client",
+			method:         http.MethodPost,
+			remoteAddr:     "192.0.2.1:5000",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "missing token",
+			token:          "secret",
+			method:         http.MethodPost,
+			remoteAddr:     "127.0.0.1:5000",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "wrong token",
+			token:          "secret",
+			method:         http.MethodPost,
+			remoteAddr:     "127.0.0.1:5000",
+			authorization:  "Bearer other",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "get",
+			method:         http.MethodGet,
+			remoteAddr:     "127.0.0.1:5000",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col := &Collector{set: CollectorSettings{ControlToken: tt.token}}
+			called := false
+			handler := col.controlHandler(func() { called = true })
+
+			req := httptest.NewRequest(tt.method, controlReloadPath, nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedStatus == http.StatusAccepted, called)
+		})
+	}
+}
+
+func TestValidateControlEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"localhost:13134", "127.0.0.1:13134", "[::1]:13134"} {
+		assert.NoError(t, validateControlEndpoint(endpoint), endpoint)
+	}
+	for _, endpoint := range []string{":13134", "0.0.0.0:13134", "192.0.2.1:13134", "example.com:13134", "localhost"} {
+		assert.Error(t, validateControlEndpoint(endpoint), endpoint)
+	}
+}
+
+func TestCollectorReloadCoalesces(t *testing.T) {
+	col := &Collector{reloadChan: make(chan struct{}, 1)}
+	col.Reload()
+	col.Reload()
+	assert.Len(t, col.reloadChan, 1)
+}
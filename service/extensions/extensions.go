@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"time"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -32,8 +33,12 @@ const zExtensionName = "zextensionname"
 
 // Extensions is a map of extensions created from extension configs.
 type Extensions struct {
-	telemetry component.TelemetrySettings
-	extMap    map[component.ID]component.Extension
+	telemetry   component.TelemetrySettings
+	extMap      map[component.ID]component.Extension
+	instanceIDs map[component.ID]*component.InstanceID
+
+	shutdownTimeout        time.Duration
+	shutdownDumpGoroutines bool
 }
 
 // Start starts all extensions.
@@ -42,25 +47,51 @@ func (bes *Extensions) Start(ctx context.Context, host component.Host) error {
 	for extID, ext := range bes.extMap {
 		extLogger := extensionLogger(bes.telemetry.Logger, extID)
 		extLogger.Info("Extension is starting...")
+		instanceID := bes.instanceIDs[extID]
+		bes.NotifyComponentStatusChange(instanceID, component.NewStatusEvent(component.StatusStarting))
 		if err := ext.Start(ctx, components.NewHostWrapper(host, extLogger)); err != nil {
+			bes.NotifyComponentStatusChange(instanceID, component.NewPermanentErrorEvent(err))
 			return err
 		}
+		bes.NotifyComponentStatusChange(instanceID, component.NewStatusEvent(component.StatusOK))
 		extLogger.Info("Extension started.")
 	}
 	return nil
 }
 
-// Shutdown stops all extensions.
+// Shutdown stops all extensions. Extensions that do not stop before their timeout, or the deadline of ctx,
+// are given up on.
 func (bes *Extensions) Shutdown(ctx context.Context) error {
 	bes.telemetry.Logger.Info("Stopping extensions...")
 	var errs error
-	for _, ext := range bes.extMap {
-		errs = multierr.Append(errs, ext.Shutdown(ctx))
+	shutdowner := components.NewShutdowner(bes.shutdownTimeout, bes.shutdownDumpGoroutines)
+	for extID, ext := range bes.extMap {
+		errs = multierr.Append(errs, shutdowner.Shutdown(ctx, ext, extensionLogger(bes.telemetry.Logger, extID)))
 	}
 
 	return errs
 }
 
+// NotifyComponentStatusChange notifies all extensions implementing component.StatusWatcher
+// about a change in the status of the component identified by source.
+func (bes *Extensions) NotifyComponentStatusChange(source *component.InstanceID, event *component.StatusEvent) {
+	for _, ext := range bes.extMap {
+		if sw, ok := ext.(component.StatusWatcher); ok {
+			sw.ComponentStatusChanged(source, event)
+		}
+	}
+}
+
+// NotifyServiceStateChange notifies all extensions implementing component.ServiceStateWatcher
+// that the service entered state at the given time.
+func (bes *Extensions) NotifyServiceStateChange(state component.ServiceState, timestamp time.Time) {
+	for _, ext := range bes.extMap {
+		if sw, ok := ext.(component.ServiceStateWatcher); ok {
+			sw.ServiceStateChanged(state, timestamp)
+		}
+	}
+}
+
 func (bes *Extensions) NotifyPipelineReady() error {
 	for extID, ext := range bes.extMap {
 		if pw, ok := ext.(component.PipelineWatcher); ok {
@@ -91,6 +122,17 @@ func (bes *Extensions) GetExtensions() map[component.ID]component.Component {
 	return result
 }
 
+// HTTPHandlers returns the handlers of the extensions implementing component.HTTPHandlerProvider.
+func (bes *Extensions) HTTPHandlers() map[component.ID]http.Handler {
+	handlers := make(map[component.ID]http.Handler)
+	for extID, ext := range bes.extMap {
+		if hp, ok := ext.(component.HTTPHandlerProvider); ok {
+			handlers[extID] = hp.HTTPHandler()
+		}
+	}
+	return handlers
+}
+
 func (bes *Extensions) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	extensionName := r.URL.Query().Get(zExtensionName)
 
@@ -127,13 +169,27 @@ type Settings struct {
 
 	// Factories maps extension type names in the config to the respective component.ExtensionFactory.
 	Factories map[component.Type]component.ExtensionFactory
+
+	// ShutdownTimeout limits the time given to each extension to shut down. Zero means no limit.
+	ShutdownTimeout time.Duration
+
+	// ShutdownDumpGoroutines logs the stacks of all the goroutines when an extension does not shut down
+	// in time, once per call to Shutdown.
+	ShutdownDumpGoroutines bool
+
+	// ReportFatalError is called with the error of the extensions reporting a StatusFatalError. Optional.
+	ReportFatalError func(error)
 }
 
 // New creates a new Extensions from Config.
 func New(ctx context.Context, set Settings, cfg Config) (*Extensions, error) {
 	exts := &Extensions{
-		telemetry: set.Telemetry,
-		extMap:    make(map[component.ID]component.Extension),
+		telemetry:   set.Telemetry,
+		extMap:      make(map[component.ID]component.Extension),
+		instanceIDs: make(map[component.ID]*component.InstanceID),
+
+		shutdownTimeout:        set.ShutdownTimeout,
+		shutdownDumpGoroutines: set.ShutdownDumpGoroutines,
 	}
 	for _, extID := range cfg {
 		extCfg, existsCfg := set.Configs[extID]
@@ -152,6 +208,13 @@ func New(ctx context.Context, set Settings, cfg Config) (*Extensions, error) {
 			BuildInfo:         set.BuildInfo,
 		}
 		extSet.TelemetrySettings.Logger = extensionLogger(set.Telemetry.Logger, extID)
+		instanceID := &component.InstanceID{ID: extID, Kind: component.KindExtension}
+		extSet.TelemetrySettings.ReportComponentStatus = func(ev *component.StatusEvent) {
+			exts.NotifyComponentStatusChange(instanceID, ev)
+			if ev.Status() == component.StatusFatalError && set.ReportFatalError != nil {
+				set.ReportFatalError(ev.Err())
+			}
+		}
 
 		ext, err := factory.CreateExtension(ctx, extSet, extCfg)
 		if err != nil {
@@ -164,6 +227,7 @@ func New(ctx context.Context, set Settings, cfg Config) (*Extensions, error) {
 		}
 
 		exts.extMap[extID] = ext
+		exts.instanceIDs[extID] = instanceID
 	}
 
 	return exts, nil
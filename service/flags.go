@@ -17,19 +17,38 @@ package service // import "go.opentelemetry.io/collector/service"
 import (
 	"errors"
 	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
+	"go.opentelemetry.io/collector/confmap/converter/legacyflagsconverter"
+	"go.opentelemetry.io/collector/confmap/provider/httpsprovider"
 	"go.opentelemetry.io/collector/featuregate"
 )
 
 const (
-	configFlag       = "config"
-	featureGatesFlag = "feature-gates"
+	configFlag                  = "config"
+	configBaseFlag              = "config-base"
+	configOverlayFlag           = "config-overlay"
+	configHTTPSCAFileFlag       = "config-https-ca-file"
+	configHTTPSCertFileFlag     = "config-https-cert-file"
+	configHTTPSKeyFileFlag      = "config-https-key-file"
+	configHTTPSTokenFileFlag    = "config-https-token-file"
+	configHTTPSPollIntervalFlag = "config-https-poll-interval"
+	controlEndpointFlag         = "control-endpoint"
+	controlTokenFileFlag        = "control-token-file"
+	featureGatesFlag            = "feature-gates"
+	memBallastSizeMiBFlag       = "mem-ballast-size-mib"
+	metricsAddrFlag             = "metrics-addr"
 )
 
 type configFlagValue struct {
-	values []string
-	sets   []string
+	bases    []string
+	values   []string
+	overlays []string
+	sets     []string
 }
 
 func (s *configFlagValue) Set(val string) error {
@@ -41,6 +60,23 @@ func (s *configFlagValue) String() string {
 	return "[" + strings.Join(s.values, ", ") + "]"
 }
 
+// uriListFlagValue appends the flag values to a list of the configFlagValue.
+type uriListFlagValue struct {
+	values *[]string
+}
+
+func (s uriListFlagValue) Set(val string) error {
+	*s.values = append(*s.values, val)
+	return nil
+}
+
+func (s uriListFlagValue) String() string {
+	if s.values == nil {
+		return "[]"
+	}
+	return "[" + strings.Join(*s.values, ", ") + "]"
+}
+
 func flags() *flag.FlagSet {
 	flagSet := new(flag.FlagSet)
 
@@ -48,9 +84,17 @@ func flags() *flag.FlagSet {
 	flagSet.Var(cfgs, configFlag, "Locations to the config file(s), note that only a"+
 		" single location can be set per flag entry e.g. `--config=file:/path/to/first --config=file:path/to/second`.")
 
+	flagSet.Var(uriListFlagValue{values: &cfgs.bases}, configBaseFlag, "Locations to the base config file(s),"+
+		" merged before the locations of the --config flags, regardless of the order of the flags.")
+
+	flagSet.Var(uriListFlagValue{values: &cfgs.overlays}, configOverlayFlag, "Locations to the config overlay(s),"+
+		" merged in order after all the config files. Unlike in the config files, a null value in an overlay removes"+
+		" the key from the config, e.g. `receivers: {jaeger: null}` removes the jaeger receiver.")
+
 	flagSet.Func("set",
 		"Set arbitrary component config property. The component has to be defined in the config file and the flag"+
-			" has a higher precedence. Array config properties are overridden and maps are joined. Example --set=processors.batch.timeout=2s",
+			" has a higher precedence, including over the overlays. Array config properties are overridden and maps are joined."+
+			" Example --set=processors.batch.timeout=2s",
 		func(s string) error {
 			idx := strings.Index(s, "=")
 			if idx == -1 {
@@ -61,17 +105,142 @@ func flags() *flag.FlagSet {
 			return nil
 		})
 
+	flagSet.String(configHTTPSCAFileFlag, "",
+		"Path to the PEM encoded CA certificates verifying the servers of the https config locations,"+
+			" instead of the system certificates.")
+
+	flagSet.String(configHTTPSCertFileFlag, "",
+		"Path to the PEM encoded client certificate presented to the servers of the https config locations"+
+			" requiring mutual TLS. Requires --"+configHTTPSKeyFileFlag+".")
+
+	flagSet.String(configHTTPSKeyFileFlag, "",
+		"Path to the PEM encoded key of the client certificate. Requires --"+configHTTPSCertFileFlag+".")
+
+	flagSet.String(configHTTPSTokenFileFlag, "",
+		"Path to a file holding the bearer token sent to the servers of the https config locations.")
+
+	flagSet.Duration(configHTTPSPollIntervalFlag, 0,
+		"Interval at which the https config locations are downloaded again, the configuration is reloaded once"+
+			" it changed. Disabled if zero.")
+
 	flagSet.Var(featuregate.FlagValue{}, featureGatesFlag,
 		"Comma-delimited list of feature gate identifiers. Prefix with '-' to disable the feature. '+' or no prefix will enable the feature.")
 
+	flagSet.String(controlEndpointFlag, "",
+		"Localhost address serving the /-/reload and /-/quit endpoints, which reload the configuration and gracefully"+
+			" stop the collector on POST requests, e.g. `--control-endpoint=localhost:13134`. Disabled if empty.")
+
+	flagSet.String(controlTokenFileFlag, "",
+		"Path to a file holding the bearer token required by the control endpoints. No token is required if empty.")
+
+	flagSet.Uint64(memBallastSizeMiBFlag, 0,
+		"Deprecated: set `size_mib` of the `memory_ballast` extension in the config instead.")
+
+	flagSet.String(metricsAddrFlag, "",
+		"Deprecated: set `service::telemetry::metrics::address` in the config instead.")
+
 	return flagSet
 }
 
+// getConfigFlag returns the locations of the base config files followed by the locations of the config files.
 func getConfigFlag(flagSet *flag.FlagSet) []string {
 	cfv := flagSet.Lookup(configFlag).Value.(*configFlagValue)
-	return append(cfv.values, cfv.sets...)
+	return append(append([]string{}, cfv.bases...), cfv.values...)
+}
+
+// getConfigOverlayFlag returns the locations of the overlays.
+func getConfigOverlayFlag(flagSet *flag.FlagSet) []string {
+	return flagSet.Lookup(configFlag).Value.(*configFlagValue).overlays
+}
+
+// getSetFlag returns the properties set by the --set flags, as locations.
+func getSetFlag(flagSet *flag.FlagSet) []string {
+	return flagSet.Lookup(configFlag).Value.(*configFlagValue).sets
+}
+
+// getControlFlags returns the control endpoint and the token read from the control token file.
+func getControlFlags(flagSet *flag.FlagSet) (endpoint string, token string, err error) {
+	endpoint = flagSet.Lookup(controlEndpointFlag).Value.String()
+	tokenFile := flagSet.Lookup(controlTokenFileFlag).Value.String()
+	if tokenFile == "" {
+		return endpoint, "", nil
+	}
+	if endpoint == "" {
+		return "", "", fmt.Errorf("--%s requires --%s", controlTokenFileFlag, controlEndpointFlag)
+	}
+	b, err := os.ReadFile(filepath.Clean(tokenFile))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read control token file: %w", err)
+	}
+	token = strings.TrimSpace(string(b))
+	if token == "" {
+		return "", "", fmt.Errorf("control token file %q is empty", tokenFile)
+	}
+	return endpoint, token, nil
+}
+
+// getConfigHTTPSFlags returns the options of the https config provider set by the flags.
+func getConfigHTTPSFlags(flagSet *flag.FlagSet) ([]httpsprovider.Option, error) {
+	var opts []httpsprovider.Option
+	if caFile := flagSet.Lookup(configHTTPSCAFileFlag).Value.String(); caFile != "" {
+		opts = append(opts, httpsprovider.WithCAFile(caFile))
+	}
+	certFile := flagSet.Lookup(configHTTPSCertFileFlag).Value.String()
+	keyFile := flagSet.Lookup(configHTTPSKeyFileFlag).Value.String()
+	if (certFile == "") != (keyFile == "") {
+		return nil, fmt.Errorf("--%s and --%s must be set together", configHTTPSCertFileFlag, configHTTPSKeyFileFlag)
+	}
+	if certFile != "" {
+		opts = append(opts, httpsprovider.WithClientCertificate(certFile, keyFile))
+	}
+	if tokenFile := flagSet.Lookup(configHTTPSTokenFileFlag).Value.String(); tokenFile != "" {
+		b, err := os.ReadFile(filepath.Clean(tokenFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config https token file: %w", err)
+		}
+		token := strings.TrimSpace(string(b))
+		if token == "" {
+			return nil, fmt.Errorf("config https token file %q is empty", tokenFile)
+		}
+		opts = append(opts, httpsprovider.WithBearerToken(token))
+	}
+	if interval := flagSet.Lookup(configHTTPSPollIntervalFlag).Value.(flag.Getter).Get().(time.Duration); interval > 0 {
+		opts = append(opts, httpsprovider.WithPollInterval(interval))
+	}
+	return opts, nil
 }
 
 func getFeatureGatesFlag(flagSet *flag.FlagSet) featuregate.FlagValue {
 	return flagSet.Lookup(featureGatesFlag).Value.(featuregate.FlagValue)
 }
+
+func getLegacyFlags(flagSet *flag.FlagSet) legacyflagsconverter.Flags {
+	return legacyflagsconverter.Flags{
+		MemBallastSizeMiB: flagSet.Lookup(memBallastSizeMiBFlag).Value.(flag.Getter).Get().(uint64),
+		MetricsAddr:       flagSet.Lookup(metricsAddrFlag).Value.String(),
+	}
+}
+
+// newConfigProviderSettingsFromFlags returns the default ConfigProviderSettings for the config flags,
+// with the legacy flags converted into the config. warn is called for every deprecated flag that is set.
+func newConfigProviderSettingsFromFlags(flagSet *flag.FlagSet, warn func(msg string)) (ConfigProviderSettings, error) {
+	configFlags, overlayFlags, setFlags := getConfigFlag(flagSet), getConfigOverlayFlag(flagSet), getSetFlag(flagSet)
+	if len(configFlags) == 0 && len(overlayFlags) == 0 && len(setFlags) == 0 {
+		return ConfigProviderSettings{}, errors.New("at least one config flag must be provided")
+	}
+
+	httpsOpts, err := getConfigHTTPSFlags(flagSet)
+	if err != nil {
+		return ConfigProviderSettings{}, err
+	}
+
+	set := newDefaultConfigProviderSettings(configFlags)
+	if len(httpsOpts) > 0 {
+		https := httpsprovider.New(httpsOpts...)
+		set.ResolverSettings.Providers[https.Scheme()] = https
+	}
+	set.ResolverSettings.OverlayURIs = overlayFlags
+	set.ResolverSettings.OverrideURIs = setFlags
+	set.ResolverSettings.Converters = append(set.ResolverSettings.Converters, legacyflagsconverter.New(getLegacyFlags(flagSet), warn))
+	return set, nil
+}
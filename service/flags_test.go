@@ -15,48 +15,69 @@
 package service
 
 import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/converter/legacyflagsconverter"
 )
 
 func TestSetFlag(t *testing.T) {
 	tests := []struct {
-		name            string
-		args            []string
-		expectedConfigs []string
-		expectedErr     string
+		name             string
+		args             []string
+		expectedConfigs  []string
+		expectedOverlays []string
+		expectedSets     []string
+		expectedErr      string
 	}{
 		{
-			name:            "simple set",
-			args:            []string{"--set=key=value"},
-			expectedConfigs: []string{"yaml:key: value"},
+			name:         "simple set",
+			args:         []string{"--set=key=value"},
+			expectedSets: []string{"yaml:key: value"},
 		},
 		{
-			name:            "complex nested key",
-			args:            []string{"--set=outer.inner=value"},
-			expectedConfigs: []string{"yaml:outer::inner: value"},
+			name:         "complex nested key",
+			args:         []string{"--set=outer.inner=value"},
+			expectedSets: []string{"yaml:outer::inner: value"},
 		},
 		{
-			name:            "set array",
-			args:            []string{"--set=key=[a, b, c]"},
-			expectedConfigs: []string{"yaml:key: [a, b, c]"},
+			name:         "set array",
+			args:         []string{"--set=key=[a, b, c]"},
+			expectedSets: []string{"yaml:key: [a, b, c]"},
 		},
 		{
-			name:            "set map",
-			args:            []string{"--set=key={a: c}"},
-			expectedConfigs: []string{"yaml:key: {a: c}"},
+			name:         "set map",
+			args:         []string{"--set=key={a: c}"},
+			expectedSets: []string{"yaml:key: {a: c}"},
 		},
 		{
 			name:            "set and config",
 			args:            []string{"--set=key=value", "--config=file:testdata/otelcol-nop.yaml"},
-			expectedConfigs: []string{"file:testdata/otelcol-nop.yaml", "yaml:key: value"},
+			expectedConfigs: []string{"file:testdata/otelcol-nop.yaml"},
+			expectedSets:    []string{"yaml:key: value"},
 		},
 		{
 			name:            "config and set",
 			args:            []string{"--config=file:testdata/otelcol-nop.yaml", "--set=key=value"},
-			expectedConfigs: []string{"file:testdata/otelcol-nop.yaml", "yaml:key: value"},
+			expectedConfigs: []string{"file:testdata/otelcol-nop.yaml"},
+			expectedSets:    []string{"yaml:key: value"},
+		},
+		{
+			name: "base, config, overlays and set",
+			args: []string{"--set=key=value", "--config-overlay=file:overlay1.yaml", "--config=file:config.yaml",
+				"--config-base=file:base.yaml", "--config-overlay=file:overlay2.yaml"},
+			expectedConfigs:  []string{"file:base.yaml", "file:config.yaml"},
+			expectedOverlays: []string{"file:overlay1.yaml", "file:overlay2.yaml"},
+			expectedSets:     []string{"yaml:key: value"},
 		},
 		{
 			name:        "invalid set",
@@ -74,7 +95,192 @@ func TestSetFlag(t *testing.T) {
 				return
 			}
 			require.NoError(t, err)
-			assert.Equal(t, tt.expectedConfigs, getConfigFlag(flgs))
+			assert.Equal(t, tt.expectedConfigs, nilIfEmpty(getConfigFlag(flgs)))
+			assert.Equal(t, tt.expectedOverlays, nilIfEmpty(getConfigOverlayFlag(flgs)))
+			assert.Equal(t, tt.expectedSets, nilIfEmpty(getSetFlag(flgs)))
+		})
+	}
+}
+
+func TestLegacyFlags(t *testing.T) {
+	flgs := flags()
+	require.NoError(t, flgs.Parse([]string{"--config=file:testdata/otelcol-nop.yaml", "--mem-ballast-size-mib=64", "--metrics-addr=localhost:9999"}))
+	assert.Equal(t, legacyflagsconverter.Flags{MemBallastSizeMiB: 64, MetricsAddr: "localhost:9999"}, getLegacyFlags(flgs))
+
+	var warnings []string
+	set, err := newConfigProviderSettingsFromFlags(flgs, func(msg string) { warnings = append(warnings, msg) })
+	require.NoError(t, err)
+	conf, err := confmap.NewResolver(set.ResolverSettings)
+	require.NoError(t, err)
+	resolved, err := conf.Resolve(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(64), resolved.Get("extensions::memory_ballast::size_mib"))
+	assert.Contains(t, resolved.Get("service::extensions"), "memory_ballast")
+	assert.Equal(t, "localhost:9999", resolved.Get("service::telemetry::metrics::address"))
+	assert.Len(t, warnings, 2)
+}
+
+func TestLegacyFlagsNotSet(t *testing.T) {
+	flgs := flags()
+	require.NoError(t, flgs.Parse([]string{"--config=file:testdata/otelcol-nop.yaml"}))
+	assert.Equal(t, legacyflagsconverter.Flags{}, getLegacyFlags(flgs))
+}
+
+func TestConfigProviderSettingsNoConfigFlag(t *testing.T) {
+	_, err := newConfigProviderSettingsFromFlags(flags(), func(string) {})
+	assert.EqualError(t, err, "at least one config flag must be provided")
+}
+
+func nilIfEmpty(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
+
+func TestConfigOverlayFlags(t *testing.T) {
+	flgs := flags()
+	require.NoError(t, flgs.Parse([]string{
+		"--config-base=file:testdata/otelcol-nop.yaml",
+		"--config-overlay=file:testdata/otelcol-overlay-no-extensions.yaml",
+		"--set=receivers.nop=null",
+		"--set=service.telemetry.metrics.address=localhost:9999",
+	}))
+
+	set, err := newConfigProviderSettingsFromFlags(flgs, func(string) {})
+	require.NoError(t, err)
+	resolver, err := confmap.NewResolver(set.ResolverSettings)
+	require.NoError(t, err)
+	resolved, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+
+	assert.False(t, resolved.IsSet("extensions::nop"))
+	assert.False(t, resolved.IsSet("service::pipelines::logs"))
+	assert.True(t, resolved.IsSet("service::pipelines::traces"))
+	// Unlike in the overlays, a null value set by --set does not remove the property.
+	assert.True(t, resolved.IsSet("receivers::nop"))
+	assert.Nil(t, resolved.Get("receivers::nop"))
+	assert.Equal(t, "localhost:9999", resolved.Get("service::telemetry::metrics::address"))
+}
+
+func TestConfigHTTPSFlags(t *testing.T) {
+	cfg, err := os.ReadFile(filepath.Join("testdata", "otelcol-nop.yaml"))
+	require.NoError(t, err)
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, err = w.Write(cfg)
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}), 0600))
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0600))
+
+	flgs := flags()
+	require.NoError(t, flgs.Parse([]string{
+		"--config=" + ts.URL,
+		"--config-https-ca-file=" + caFile,
+		"--config-https-token-file=" + tokenFile,
+	}))
+	set, err := newConfigProviderSettingsFromFlags(flgs, func(string) {})
+	require.NoError(t, err)
+	resolver, err := confmap.NewResolver(set.ResolverSettings)
+	require.NoError(t, err)
+	resolved, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.True(t, resolved.IsSet("service::pipelines::traces"))
+	assert.NoError(t, resolver.Shutdown(context.Background()))
+}
+
+func TestConfigHTTPSFlagsError(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "cert without key",
+			args:        []string{"--config-https-cert-file=client.crt"},
+			expectedErr: "--config-https-cert-file and --config-https-key-file must be set together",
+		},
+		{
+			name:        "missing token file",
+			args:        []string{"--config-https-token-file=" + filepath.Join(t.TempDir(), "missing")},
+			expectedErr: "failed to read config https token file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flgs := flags()
+			require.NoError(t, flgs.Parse(append([]string{"--config=file:testdata/otelcol-nop.yaml"}, tt.args...)))
+			_, err := newConfigProviderSettingsFromFlags(flgs, func(string) {})
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestControlFlags(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0600))
+	emptyTokenFile := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.WriteFile(emptyTokenFile, nil, 0600))
+
+	tests := []struct {
+		name             string
+		args             []string
+		expectedEndpoint string
+		expectedToken    string
+		expectedErr      string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:             "endpoint",
+			args:             []string{"--control-endpoint=localhost:13134"},
+			expectedEndpoint: "localhost:13134",
+		},
+		{
+			name:             "endpoint and token",
+			args:             []string{"--control-endpoint=localhost:13134", "--control-token-file=" + tokenFile},
+			expectedEndpoint: "localhost:13134",
+			expectedToken:    "secret",
+		},
+		{
+			name:        "token without endpoint",
+			args:        []string{"--control-token-file=" + tokenFile},
+			expectedErr: "--control-token-file requires --control-endpoint",
+		},
+		{
+			name:        "empty token",
+			args:        []string{"--control-endpoint=localhost:13134", "--control-token-file=" + emptyTokenFile},
+			expectedErr: "is empty",
+		},
+		{
+			name:        "missing token file",
+			args:        []string{"--control-endpoint=localhost:13134", "--control-token-file=" + filepath.Join(t.TempDir(), "missing")},
+			expectedErr: "failed to read control token file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flgs := flags()
+			require.NoError(t, flgs.Parse(tt.args))
+
+			endpoint, token, err := getControlFlags(flgs)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedEndpoint, endpoint)
+			assert.Equal(t, tt.expectedToken, token)
 		})
 	}
 }
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/internal/components"
+)
+
+// healthResponse is the body of the responses of the health endpoint.
+type healthResponse struct {
+	// Status is the state of the service: Starting, Ready, Degraded or Stopping.
+	Status string `json:"status"`
+	// Since is the time at which the service entered its state.
+	Since time.Time `json:"since"`
+	// Components are the components of the pipelines that reported a status, sorted by kind and id.
+	Components []componentHealth `json:"components"`
+}
+
+// componentHealth is the last status reported by a component of the pipelines.
+type componentHealth struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	Pipelines []string  `json:"pipelines"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// healthTracker keeps the last status reported by every component of the pipelines, for the health endpoint.
+type healthTracker struct {
+	mu       sync.Mutex
+	statuses map[*component.InstanceID]*component.StatusEvent
+}
+
+func newHealthTracker() *healthTracker {
+	return &healthTracker{statuses: make(map[*component.InstanceID]*component.StatusEvent)}
+}
+
+// componentStatusChanged records the status of the component, and forgets the stopped ones, e.g. the
+// components of the pipelines replaced by a reload.
+func (ht *healthTracker) componentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	ht.mu.Lock()
+	defer ht.mu.Unlock()
+	if event.Status() == component.StatusStopped {
+		delete(ht.statuses, source)
+		return
+	}
+	ht.statuses[source] = event
+}
+
+// components returns the last status of the components, sorted by kind and id.
+func (ht *healthTracker) components() []componentHealth {
+	ht.mu.Lock()
+	defer ht.mu.Unlock()
+
+	comps := make([]componentHealth, 0, len(ht.statuses))
+	for source, event := range ht.statuses {
+		ch := componentHealth{
+			ID:        source.ID.String(),
+			Kind:      kindString(source.Kind),
+			Pipelines: make([]string, 0, len(source.PipelineIDs)),
+			Status:    event.Status().String(),
+			Timestamp: event.Timestamp(),
+		}
+		for _, pipelineID := range source.PipelineIDs {
+			ch.Pipelines = append(ch.Pipelines, pipelineID.String())
+		}
+		sort.Strings(ch.Pipelines)
+		if event.Err() != nil {
+			ch.Error = event.Err().Error()
+		}
+		comps = append(comps, ch)
+	}
+	sort.Slice(comps, func(i, j int) bool {
+		if comps[i].Kind != comps[j].Kind {
+			return comps[i].Kind < comps[j].Kind
+		}
+		return comps[i].ID < comps[j].ID
+	})
+	return comps
+}
+
+func kindString(kind component.Kind) string {
+	switch kind {
+	case component.KindReceiver:
+		return components.ZapKindReceiver
+	case component.KindProcessor:
+		return components.ZapKindProcessor
+	case component.KindExporter:
+		return components.ZapKindExporter
+	case component.KindExtension:
+		return components.ZapKindExtension
+	case component.KindConnector:
+		return components.ZapKindConnector
+	}
+	return ""
+}
+
+// healthHandler serves the state of the service and the status of its components. The pipelines are ready,
+// and the response has a 200 status code, while the service is ready or degraded; a degraded service keeps
+// processing data while some components report an error.
+func healthHandler(state *serviceState, tracker *healthTracker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		st, since := state.current()
+		resp := healthResponse{
+			Status:     st.String(),
+			Since:      since,
+			Components: tracker.components(),
+		}
+		code := http.StatusServiceUnavailable
+		if st == component.ServiceStateReady || st == component.ServiceStateDegraded {
+			code = http.StatusOK
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(resp)
+		}
+	})
+}
+
+// startHealthServer starts serving the health endpoint of the service, if one is configured.
+func (srv *service) startHealthServer() error {
+	cfg := srv.config.Service.Telemetry.Health
+	if cfg.Endpoint == "" {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(cfg.HealthPath(), healthHandler(srv.host.state, srv.health))
+
+	ln, err := net.Listen("tcp", cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to bind health endpoint %q: %w", cfg.Endpoint, err)
+	}
+	healthSrv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	srv.healthServer = healthSrv
+
+	go func() {
+		if serveErr := healthSrv.Serve(ln); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			srv.telemetrySettings.Logger.Error("Health endpoint failed", zap.Error(serveErr))
+		}
+	}()
+	srv.telemetrySettings.Logger.Info("Health endpoint started",
+		zap.String("endpoint", ln.Addr().String()),
+		zap.String("path", cfg.HealthPath()))
+	return nil
+}
+
+// shutdownHealthServer stops the health server, if it was started.
+func (srv *service) shutdownHealthServer() error {
+	if srv.healthServer == nil {
+		return nil
+	}
+	err := srv.healthServer.Close()
+	srv.healthServer = nil
+	return err
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+func TestHealthTracker(t *testing.T) {
+	ht := newHealthTracker()
+	exporter := &component.InstanceID{
+		ID:          component.NewIDWithName("otlp", "2"),
+		Kind:        component.KindExporter,
+		PipelineIDs: []component.ID{component.NewID("traces"), component.NewID("logs")},
+	}
+	receiver := &component.InstanceID{ID: component.NewID("otlp"), Kind: component.KindReceiver}
+
+	ht.componentStatusChanged(receiver, component.NewStatusEvent(component.StatusOK))
+	ev := component.NewRecoverableErrorEvent(errors.New("unavailable"))
+	ht.componentStatusChanged(exporter, ev)
+	assert.Equal(t, []componentHealth{
+		{
+			ID:        "otlp/2",
+			Kind:      "exporter",
+			Pipelines: []string{"logs", "traces"},
+			Status:    "StatusRecoverableError",
+			Error:     "unavailable",
+			Timestamp: ev.Timestamp(),
+		},
+		{
+			ID:        "otlp",
+			Kind:      "receiver",
+			Pipelines: []string{},
+			Status:    "StatusOK",
+			Timestamp: ht.statuses[receiver].Timestamp(),
+		},
+	}, ht.components())
+
+	// The stopped components are forgotten.
+	ht.componentStatusChanged(exporter, component.NewStatusEvent(component.StatusStopped))
+	comps := ht.components()
+	require.Len(t, comps, 1)
+	assert.Equal(t, "receiver", comps[0].Kind)
+}
+
+func TestHealthHandler(t *testing.T) {
+	st := newServiceState(zap.NewNop(), func(component.ServiceState, time.Time) {})
+	ht := newHealthTracker()
+	handler := healthHandler(st, ht)
+	receiver := &component.InstanceID{ID: component.NewID("otlp"), Kind: component.KindReceiver}
+
+	get := func(t *testing.T) (int, healthResponse) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var resp healthResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return rec.Code, resp
+	}
+
+	code, resp := get(t)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "Starting", resp.Status)
+
+	st.set(component.ServiceStateReady)
+	code, resp = get(t)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Ready", resp.Status)
+
+	// A degraded service keeps processing data.
+	ev := component.NewPermanentErrorEvent(errors.New("bind"))
+	st.componentStatusChanged(receiver, ev)
+	ht.componentStatusChanged(receiver, ev)
+	code, resp = get(t)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Degraded", resp.Status)
+	require.Len(t, resp.Components, 1)
+	assert.Equal(t, "bind", resp.Components[0].Error)
+
+	st.set(component.ServiceStateStopping)
+	code, resp = get(t)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "Stopping", resp.Status)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/health", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestServiceHealthServer(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	srv := createExampleService(t, factories, func(cfg *Config) {
+		cfg.Service.Telemetry.Health = telemetry.HealthConfig{Endpoint: endpoint, Path: "/healthz"}
+	})
+
+	require.NoError(t, srv.Start(context.Background()))
+
+	resp, err := http.Get("http://" + endpoint + "/healthz")
+	require.NoError(t, err)
+	var health healthResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&health))
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Ready", health.Status)
+	// The receiver, the processor and the exporter of each of the 3 pipelines.
+	require.Len(t, health.Components, 9)
+	for _, comp := range health.Components {
+		assert.Equal(t, "StatusOK", comp.Status)
+	}
+
+	require.NoError(t, srv.Shutdown(context.Background()))
+	_, err = http.Get("http://" + endpoint + "/healthz")
+	assert.Error(t, err)
+}
@@ -15,20 +15,34 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"sync"
+	"time"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/internal/eventbus"
 	"go.opentelemetry.io/collector/service/internal/pipelines"
 )
 
 var _ component.Host = (*serviceHost)(nil)
+var _ component.EventBus = (*serviceHost)(nil)
+var _ component.ServiceStateHost = (*serviceHost)(nil)
 
 type serviceHost struct {
 	asyncErrorChannel chan error
 	factories         component.Factories
 	buildInfo         component.BuildInfo
 
-	pipelines  *pipelines.Pipelines
-	extensions *extensions.Extensions
+	// pipelinesMu guards pipelines, which are replaced on reload while the zPages handlers and the
+	// components read them.
+	pipelinesMu sync.RWMutex
+	pipelines   *pipelines.Pipelines
+	extensions  *extensions.Extensions
+	eventBus    *eventbus.Bus
+	state       *serviceState
+
+	// configWarnings are the warnings about the component configurations at startup.
+	configWarnings []configWarning
 }
 
 // ReportFatalError is used to report to the host that the receiver encountered
@@ -48,6 +62,8 @@ func (host *serviceHost) GetFactory(kind component.Kind, componentType component
 		return host.factories.Exporters[componentType]
 	case component.KindExtension:
 		return host.factories.Extensions[componentType]
+	case component.KindConnector:
+		return host.factories.Connectors[componentType]
 	}
 	return nil
 }
@@ -57,5 +73,31 @@ func (host *serviceHost) GetExtensions() map[component.ID]component.Component {
 }
 
 func (host *serviceHost) GetExporters() map[component.DataType]map[component.ID]component.Component {
-	return host.pipelines.GetExporters()
+	return host.getPipelines().GetExporters()
+}
+
+// getPipelines returns the current pipelines of the service.
+func (host *serviceHost) getPipelines() *pipelines.Pipelines {
+	host.pipelinesMu.RLock()
+	defer host.pipelinesMu.RUnlock()
+	return host.pipelines
+}
+
+// setPipelines replaces the pipelines of the service.
+func (host *serviceHost) setPipelines(p *pipelines.Pipelines) {
+	host.pipelinesMu.Lock()
+	defer host.pipelinesMu.Unlock()
+	host.pipelines = p
+}
+
+func (host *serviceHost) PublishEvent(topic string, event any) {
+	host.eventBus.Publish(topic, event)
+}
+
+func (host *serviceHost) SubscribeEvents(topic string, fn func(event any)) func() {
+	return host.eventBus.Subscribe(topic, fn)
+}
+
+func (host *serviceHost) ServiceState() (component.ServiceState, time.Time) {
+	return host.state.current()
 }
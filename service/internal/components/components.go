@@ -30,3 +30,78 @@ func LogStabilityLevel(logger *zap.Logger, sl component.StabilityLevel) {
 		logger.Info(sl.LogMessage(), zap.String(ZapStabilityKey, sl.String()))
 	}
 }
+
+// ReceiverStability returns the stability level of the receivers of the data type created by the factory.
+// It is undefined if the factory does not declare it, usually because the data type is not supported.
+func ReceiverStability(factory component.ReceiverFactory, dt component.DataType) component.StabilityLevel {
+	switch dt {
+	case component.DataTypeTraces:
+		return factory.TracesReceiverStability()
+	case component.DataTypeMetrics:
+		return factory.MetricsReceiverStability()
+	case component.DataTypeLogs:
+		return factory.LogsReceiverStability()
+	}
+	return component.StabilityLevelUndefined
+}
+
+// ProcessorStability returns the stability level of the processors of the data type created by the factory.
+func ProcessorStability(factory component.ProcessorFactory, dt component.DataType) component.StabilityLevel {
+	switch dt {
+	case component.DataTypeTraces:
+		return factory.TracesProcessorStability()
+	case component.DataTypeMetrics:
+		return factory.MetricsProcessorStability()
+	case component.DataTypeLogs:
+		return factory.LogsProcessorStability()
+	}
+	return component.StabilityLevelUndefined
+}
+
+// ExporterStability returns the stability level of the exporters of the data type created by the factory.
+func ExporterStability(factory component.ExporterFactory, dt component.DataType) component.StabilityLevel {
+	switch dt {
+	case component.DataTypeTraces:
+		return factory.TracesExporterStability()
+	case component.DataTypeMetrics:
+		return factory.MetricsExporterStability()
+	case component.DataTypeLogs:
+		return factory.LogsExporterStability()
+	}
+	return component.StabilityLevelUndefined
+}
+
+// ConnectorStability returns the stability level of the connectors from the expDT to the rcvDT data types
+// created by the factory.
+func ConnectorStability(factory component.ConnectorFactory, expDT, rcvDT component.DataType) component.StabilityLevel {
+	switch expDT {
+	case component.DataTypeTraces:
+		switch rcvDT {
+		case component.DataTypeTraces:
+			return factory.TracesToTracesStability()
+		case component.DataTypeMetrics:
+			return factory.TracesToMetricsStability()
+		case component.DataTypeLogs:
+			return factory.TracesToLogsStability()
+		}
+	case component.DataTypeMetrics:
+		switch rcvDT {
+		case component.DataTypeTraces:
+			return factory.MetricsToTracesStability()
+		case component.DataTypeMetrics:
+			return factory.MetricsToMetricsStability()
+		case component.DataTypeLogs:
+			return factory.MetricsToLogsStability()
+		}
+	case component.DataTypeLogs:
+		switch rcvDT {
+		case component.DataTypeTraces:
+			return factory.LogsToTracesStability()
+		case component.DataTypeMetrics:
+			return factory.LogsToMetricsStability()
+		case component.DataTypeLogs:
+			return factory.LogsToLogsStability()
+		}
+	}
+	return component.StabilityLevelUndefined
+}
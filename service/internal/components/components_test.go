@@ -54,3 +54,25 @@ func TestLogStabilityLevel(t *testing.T) {
 		require.Equal(t, tt.expectedLogs, logs.Len())
 	}
 }
+
+func TestStability(t *testing.T) {
+	recvFactory := component.NewReceiverFactory("test", nil,
+		component.WithMetricsReceiver(nil, component.StabilityLevelBeta))
+	require.Equal(t, component.StabilityLevelBeta, ReceiverStability(recvFactory, component.DataTypeMetrics))
+	require.Equal(t, component.StabilityLevelUndefined, ReceiverStability(recvFactory, component.DataTypeTraces))
+
+	procFactory := component.NewProcessorFactory("test", nil,
+		component.WithLogsProcessor(nil, component.StabilityLevelAlpha))
+	require.Equal(t, component.StabilityLevelAlpha, ProcessorStability(procFactory, component.DataTypeLogs))
+	require.Equal(t, component.StabilityLevelUndefined, ProcessorStability(procFactory, component.DataTypeMetrics))
+
+	expFactory := component.NewExporterFactory("test", nil,
+		component.WithTracesExporter(nil, component.StabilityLevelStable))
+	require.Equal(t, component.StabilityLevelStable, ExporterStability(expFactory, component.DataTypeTraces))
+	require.Equal(t, component.StabilityLevelUndefined, ExporterStability(expFactory, component.DataTypeLogs))
+
+	connFactory := component.NewConnectorFactory("test", nil,
+		component.WithTracesToMetrics(nil, component.StabilityLevelDevelopment))
+	require.Equal(t, component.StabilityLevelDevelopment, ConnectorStability(connFactory, component.DataTypeTraces, component.DataTypeMetrics))
+	require.Equal(t, component.StabilityLevelUndefined, ConnectorStability(connFactory, component.DataTypeMetrics, component.DataTypeTraces))
+}
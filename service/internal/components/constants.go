@@ -20,8 +20,12 @@ const (
 	ZapKindProcessor = "processor"
 	ZapKindExporter  = "exporter"
 	ZapKindExtension = "extension"
+	ZapKindConnector = "connector"
 	ZapKindPipeline  = "pipeline"
 	ZapNameKey       = "name"
 	ZapDataTypeKey   = "data_type"
 	ZapStabilityKey  = "stability"
+
+	ZapExporterInPipelineKey = "exporter_in_pipeline"
+	ZapReceiverInPipelineKey = "receiver_in_pipeline"
 )
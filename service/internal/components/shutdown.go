@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package components // import "go.opentelemetry.io/collector/service/internal/components"
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Shutdowner shuts down components one after the other, giving each of them its own timeout. A component
+// that does not return in time is logged as an error and is left behind: its Shutdown keeps running in the
+// background. If dumpGoroutines is set, the stacks of all the goroutines are logged along with the first such
+// component only. A Shutdowner is not safe for concurrent use.
+type Shutdowner struct {
+	timeout        time.Duration
+	dumpGoroutines bool
+	dumped         bool
+}
+
+// NewShutdowner returns a Shutdowner giving timeout to each component to shut down. Zero means no limit.
+func NewShutdowner(timeout time.Duration, dumpGoroutines bool) *Shutdowner {
+	return &Shutdowner{timeout: timeout, dumpGoroutines: dumpGoroutines}
+}
+
+// Shutdown shuts down comp, giving up when its timeout, or the deadline of ctx, is exceeded.
+func (s *Shutdowner) Shutdown(ctx context.Context, comp component.Component, logger *zap.Logger) error {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	if ctx.Done() == nil {
+		return comp.Shutdown(ctx)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- comp.Shutdown(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+	// The component may have returned at the deadline, select picks randomly between the ready cases.
+	select {
+	case err := <-done:
+		return err
+	default:
+	}
+
+	fields := []zap.Field{zap.Error(ctx.Err())}
+	if s.dumpGoroutines && !s.dumped {
+		s.dumped = true
+		fields = append(fields, zap.ByteString("goroutines", goroutineDump()))
+	}
+	logger.Error("Component did not shut down before the deadline", fields...)
+	return fmt.Errorf("component did not shut down before the deadline: %w", ctx.Err())
+}
+
+// goroutineDump returns the stacks of all the goroutines.
+func goroutineDump() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
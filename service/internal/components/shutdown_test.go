@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package components
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type shutdownFuncComponent struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func TestShutdowner(t *testing.T) {
+	errShutdown := errors.New("shutdown failed")
+	comp := shutdownFuncComponent{ShutdownFunc: func(context.Context) error { return errShutdown }}
+
+	observed, logs := observer.New(zapcore.InfoLevel)
+	assert.ErrorIs(t, NewShutdowner(0, true).Shutdown(context.Background(), comp, zap.New(observed)), errShutdown)
+	assert.ErrorIs(t, NewShutdowner(time.Minute, true).Shutdown(context.Background(), comp, zap.New(observed)), errShutdown)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	assert.ErrorIs(t, NewShutdowner(0, true).Shutdown(ctx, comp, zap.New(observed)), errShutdown)
+	assert.Equal(t, 0, logs.Len())
+}
+
+func TestShutdownerTimeout(t *testing.T) {
+	for _, dumpGoroutines := range []bool{false, true} {
+		release := make(chan struct{})
+		hanging := shutdownFuncComponent{ShutdownFunc: func(context.Context) error {
+			<-release
+			return nil
+		}}
+
+		observed, logs := observer.New(zapcore.InfoLevel)
+		shutdowner := NewShutdowner(10*time.Millisecond, dumpGoroutines)
+		err := shutdowner.Shutdown(context.Background(), hanging, zap.New(observed))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// The next component gets its own timeout, and the goroutines are dumped only once.
+		var deadline time.Time
+		next := shutdownFuncComponent{ShutdownFunc: func(ctx context.Context) error {
+			deadline, _ = ctx.Deadline()
+			return nil
+		}}
+		assert.NoError(t, shutdowner.Shutdown(context.Background(), next, zap.New(observed)))
+		assert.False(t, deadline.IsZero())
+		assert.ErrorIs(t, shutdowner.Shutdown(context.Background(), hanging, zap.New(observed)), context.DeadlineExceeded)
+		close(release)
+
+		require.Equal(t, 2, logs.Len())
+		for i, entry := range logs.All() {
+			assert.Equal(t, "Component did not shut down before the deadline", entry.Message)
+			_, hasDump := entry.ContextMap()["goroutines"]
+			assert.Equal(t, dumpGoroutines && i == 0, hasDump)
+		}
+	}
+}
+
+func TestShutdownerContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	comp := shutdownFuncComponent{ShutdownFunc: func(context.Context) error {
+		<-release
+		return nil
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, NewShutdowner(time.Minute, false).Shutdown(ctx, comp, zap.NewNop()), context.DeadlineExceeded)
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diagnostics periodically logs a summary of the internal telemetry of the collector,
+// for the deployments where the metrics of the collector itself cannot be collected.
+package diagnostics // import "go.opentelemetry.io/collector/service/internal/diagnostics"
+
+import (
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricproducer"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+// componentKeys are the label keys identifying the component reporting a metric.
+var componentKeys = []string{obsmetrics.ReceiverKey, obsmetrics.ProcessorKey, obsmetrics.ExporterKey}
+
+// seriesKey identifies a metric of a component, e.g. the accepted_spans of the receiver/otlp.
+type seriesKey struct {
+	component string
+	metric    string
+}
+
+// Reporter logs, at every interval, the change of the cumulative metrics (accepted, refused, dropped, sent...)
+// and the current value of the gauges (e.g. queue sizes) of every component, along with memory and goroutines.
+type Reporter struct {
+	logger   *zap.Logger
+	interval time.Duration
+	read     func() []*metricdata.Metric
+
+	// last holds the previous value of the cumulative metrics, to report their change.
+	last map[seriesKey]int64
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewReporter returns a Reporter logging the self-diagnostics with the given logger at every interval.
+func NewReporter(logger *zap.Logger, interval time.Duration) *Reporter {
+	return &Reporter{
+		logger:   logger,
+		interval: interval,
+		read:     readAllMetrics,
+		last:     make(map[seriesKey]int64),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start starts logging the self-diagnostics periodically.
+func (r *Reporter) Start() {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				r.report()
+			case <-r.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Shutdown stops logging the self-diagnostics.
+func (r *Reporter) Shutdown() {
+	close(r.stopCh)
+	r.wg.Wait()
+}
+
+func (r *Reporter) report() {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	fields := []zap.Field{
+		zap.Int("goroutines", runtime.NumGoroutine()),
+		zap.Uint64("heap_alloc_bytes", ms.HeapAlloc),
+		zap.Uint64("sys_bytes", ms.Sys),
+	}
+
+	components := make(map[string]map[string]int64)
+	for key, value := range r.collect() {
+		if components[key.component] == nil {
+			components[key.component] = make(map[string]int64)
+		}
+		components[key.component][key.metric] = value
+	}
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fields = append(fields, zap.Any(name, components[name]))
+	}
+
+	r.logger.Info("Self-diagnostics", fields...)
+}
+
+// collect returns the change since the previous call of the cumulative metrics, and the current value of the gauges,
+// of every component. The time series of a component metric (e.g. one per transport) are summed.
+func (r *Reporter) collect() map[seriesKey]int64 {
+	values := make(map[seriesKey]int64)
+	cumulative := make(map[seriesKey]bool)
+	for _, m := range r.read() {
+		if m == nil {
+			continue
+		}
+		for _, kind := range componentKeys {
+			if !strings.HasPrefix(m.Descriptor.Name, kind+obsmetrics.NameSep) {
+				continue
+			}
+			idx := labelIndex(m.Descriptor.LabelKeys, kind)
+			if idx < 0 {
+				continue
+			}
+			metricName := strings.TrimPrefix(m.Descriptor.Name, kind+obsmetrics.NameSep)
+			for _, ts := range m.TimeSeries {
+				if len(ts.Points) == 0 || idx >= len(ts.LabelValues) {
+					continue
+				}
+				value, ok := pointValue(ts.Points[len(ts.Points)-1])
+				if !ok {
+					continue
+				}
+				key := seriesKey{component: kind + obsmetrics.NameSep + ts.LabelValues[idx].Value, metric: metricName}
+				values[key] += value
+				cumulative[key] = isCumulative(m.Descriptor.Type)
+			}
+		}
+	}
+
+	for key, value := range values {
+		if !cumulative[key] {
+			continue
+		}
+		values[key] = value - r.last[key]
+		r.last[key] = value
+	}
+	return values
+}
+
+func readAllMetrics() []*metricdata.Metric {
+	var metrics []*metricdata.Metric
+	for _, producer := range metricproducer.GlobalManager().GetAll() {
+		metrics = append(metrics, producer.Read()...)
+	}
+	return metrics
+}
+
+func labelIndex(keys []metricdata.LabelKey, key string) int {
+	for i, k := range keys {
+		if k.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func pointValue(p metricdata.Point) (int64, bool) {
+	switch v := p.Value.(type) {
+	case int64:
+		return v, true
+	case float64:
+		return int64(v), true
+	}
+	return 0, false
+}
+
+func isCumulative(t metricdata.Type) bool {
+	return t == metricdata.TypeCumulativeInt64 || t == metricdata.TypeCumulativeFloat64
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newMetric(name string, typ metricdata.Type, labelKey string, series map[string]interface{}) *metricdata.Metric {
+	m := &metricdata.Metric{
+		Descriptor: metricdata.Descriptor{
+			Name:      name,
+			Type:      typ,
+			LabelKeys: []metricdata.LabelKey{{Key: labelKey}},
+		},
+	}
+	for labelValue, value := range series {
+		m.TimeSeries = append(m.TimeSeries, &metricdata.TimeSeries{
+			LabelValues: []metricdata.LabelValue{metricdata.NewLabelValue(labelValue)},
+			Points:      []metricdata.Point{{Value: value}},
+		})
+	}
+	return m
+}
+
+func TestReporterCollect(t *testing.T) {
+	accepted := int64(10)
+	r := NewReporter(zap.NewNop(), time.Minute)
+	r.read = func() []*metricdata.Metric {
+		return []*metricdata.Metric{
+			newMetric("receiver/accepted_spans", metricdata.TypeCumulativeInt64, "receiver", map[string]interface{}{"otlp": accepted}),
+			newMetric("exporter/queue_size", metricdata.TypeGaugeInt64, "exporter", map[string]interface{}{"otlp": int64(3)}),
+			newMetric("processor/dropped_spans", metricdata.TypeCumulativeFloat64, "processor", map[string]interface{}{"memory_limiter": 2.0}),
+			// Not reported by a component.
+			newMetric("process/uptime", metricdata.TypeCumulativeFloat64, "", map[string]interface{}{"": 1.0}),
+		}
+	}
+
+	assert.Equal(t, map[seriesKey]int64{
+		{component: "receiver/otlp", metric: "accepted_spans"}:           10,
+		{component: "exporter/otlp", metric: "queue_size"}:               3,
+		{component: "processor/memory_limiter", metric: "dropped_spans"}: 2,
+	}, r.collect())
+
+	// Cumulative metrics report the change since the previous collection, gauges the current value.
+	accepted = 25
+	assert.Equal(t, map[seriesKey]int64{
+		{component: "receiver/otlp", metric: "accepted_spans"}:           15,
+		{component: "exporter/otlp", metric: "queue_size"}:               3,
+		{component: "processor/memory_limiter", metric: "dropped_spans"}: 0,
+	}, r.collect())
+}
+
+func TestReporterSumsSeries(t *testing.T) {
+	r := NewReporter(zap.NewNop(), time.Minute)
+	r.read = func() []*metricdata.Metric {
+		m := newMetric("receiver/accepted_spans", metricdata.TypeCumulativeInt64, "receiver", map[string]interface{}{"otlp": int64(1)})
+		m.Descriptor.LabelKeys = append(m.Descriptor.LabelKeys, metricdata.LabelKey{Key: "transport"})
+		m.TimeSeries[0].LabelValues = append(m.TimeSeries[0].LabelValues, metricdata.NewLabelValue("grpc"))
+		m.TimeSeries = append(m.TimeSeries, &metricdata.TimeSeries{
+			LabelValues: []metricdata.LabelValue{metricdata.NewLabelValue("otlp"), metricdata.NewLabelValue("http")},
+			Points:      []metricdata.Point{{Value: int64(4)}},
+		})
+		return []*metricdata.Metric{m}
+	}
+
+	assert.Equal(t, map[seriesKey]int64{
+		{component: "receiver/otlp", metric: "accepted_spans"}: 5,
+	}, r.collect())
+}
+
+func TestReporterLogs(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	r := NewReporter(zap.New(core), 10*time.Millisecond)
+	r.read = func() []*metricdata.Metric {
+		return []*metricdata.Metric{
+			newMetric("exporter/queue_size", metricdata.TypeGaugeInt64, "exporter", map[string]interface{}{"otlp": int64(3)}),
+		}
+	}
+
+	r.Start()
+	require.Eventually(t, func() bool { return logs.FilterMessage("Self-diagnostics").Len() > 0 }, 5*time.Second, 10*time.Millisecond)
+	r.Shutdown()
+
+	fields := logs.FilterMessage("Self-diagnostics").All()[0].ContextMap()
+	assert.Contains(t, fields, "goroutines")
+	assert.Contains(t, fields, "heap_alloc_bytes")
+	assert.Equal(t, map[string]int64{"queue_size": 3}, fields["exporter/otlp"])
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eventbus implements the component.EventBus of the service host.
+package eventbus // import "go.opentelemetry.io/collector/service/internal/eventbus"
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// subscriberBufferSize is the number of events buffered for a subscriber, the following ones are dropped
+// until the subscriber consumes them.
+const subscriberBufferSize = 64
+
+type subscriber struct {
+	fn     func(event any)
+	events chan any
+	stopCh chan struct{}
+}
+
+// Bus delivers the events published on a topic to the subscribers of the topic without blocking the publisher.
+type Bus struct {
+	logger *zap.Logger
+
+	mu          sync.RWMutex
+	stopped     bool
+	subscribers map[string]map[*subscriber]struct{}
+
+	wg sync.WaitGroup
+}
+
+// New returns a Bus logging the dropped events with the given logger.
+func New(logger *zap.Logger) *Bus {
+	return &Bus{
+		logger:      logger,
+		subscribers: make(map[string]map[*subscriber]struct{}),
+	}
+}
+
+// Publish delivers the event to the current subscribers of the topic. The event is dropped for the subscribers
+// whose buffer is full.
+func (b *Bus) Publish(topic string, event any) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for s := range b.subscribers[topic] {
+		select {
+		case s.events <- event:
+		default:
+			b.logger.Warn("Dropping event, the subscriber is not keeping up.", zap.String("topic", topic))
+		}
+	}
+}
+
+// Subscribe registers fn to be called, from a dedicated goroutine, with the events published on the topic.
+// The returned function removes the subscription, it can be called more than once.
+func (b *Bus) Subscribe(topic string, fn func(event any)) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.stopped {
+		return func() {}
+	}
+
+	s := &subscriber{
+		fn:     fn,
+		events: make(chan any, subscriberBufferSize),
+		stopCh: make(chan struct{}),
+	}
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = make(map[*subscriber]struct{})
+	}
+	b.subscribers[topic][s] = struct{}{}
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for {
+			select {
+			case <-s.stopCh:
+				return
+			case ev := <-s.events:
+				s.fn(ev)
+			}
+		}
+	}()
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[topic][s]; !ok {
+			return
+		}
+		delete(b.subscribers[topic], s)
+		if len(b.subscribers[topic]) == 0 {
+			delete(b.subscribers, topic)
+		}
+		close(s.stopCh)
+	}
+}
+
+// Shutdown removes all the subscriptions and waits for the events being delivered.
+func (b *Bus) Shutdown() {
+	b.mu.Lock()
+	b.stopped = true
+	for _, subs := range b.subscribers {
+		for s := range subs {
+			close(s.stopCh)
+		}
+	}
+	b.subscribers = make(map[string]map[*subscriber]struct{})
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
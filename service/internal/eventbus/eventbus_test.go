@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestBus(t *testing.T) {
+	b := New(zap.NewNop())
+
+	received := make(chan any, 10)
+	unsubscribe := b.Subscribe("targets", func(event any) {
+		received <- event
+	})
+	b.Subscribe("other", func(event any) {
+		assert.Fail(t, "unexpected event", event)
+	})
+
+	b.Publish("targets", "a")
+	b.Publish("targets", "b")
+	assert.Equal(t, "a", <-received)
+	assert.Equal(t, "b", <-received)
+
+	unsubscribe()
+	unsubscribe()
+	b.Publish("targets", "c")
+	b.Shutdown()
+	assert.Len(t, received, 0)
+}
+
+func TestBusDropsWhenSubscriberIsSlow(t *testing.T) {
+	b := New(zap.NewNop())
+
+	delivering := make(chan struct{}, 1)
+	release := make(chan struct{})
+	received := make(chan any, 2*subscriberBufferSize)
+	b.Subscribe("targets", func(event any) {
+		select {
+		case delivering <- struct{}{}:
+		default:
+		}
+		<-release
+		received <- event
+	})
+
+	b.Publish("targets", 0)
+	<-delivering
+	for i := 1; i < 2*subscriberBufferSize; i++ {
+		b.Publish("targets", i)
+	}
+	close(release)
+
+	// The event being delivered and the buffered ones are received, the others are dropped.
+	assert.Eventually(t, func() bool {
+		return len(received) == subscriberBufferSize+1
+	}, time.Second, 10*time.Millisecond)
+	b.Shutdown()
+	assert.Len(t, received, subscriberBufferSize+1)
+}
+
+func TestBusSubscribeAfterShutdown(t *testing.T) {
+	b := New(zap.NewNop())
+	b.Shutdown()
+
+	unsubscribe := b.Subscribe("targets", func(event any) {
+		assert.Fail(t, "unexpected event", event)
+	})
+	b.Publish("targets", "a")
+	unsubscribe()
+}
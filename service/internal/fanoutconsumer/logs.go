@@ -19,8 +19,6 @@ package fanoutconsumer // import "go.opentelemetry.io/collector/service/internal
 import (
 	"context"
 
-	"go.uber.org/multierr"
-
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
@@ -28,19 +26,25 @@ import (
 // NewLogs wraps multiple log consumers in a single one.
 // It fanouts the incoming data to all the consumers, and does smart routing:
 //   - Clones only to the consumer that needs to mutate the data.
-//   - If all consumers needs to mutate the data one will get the original data.
+//   - If all consumers needs to mutate the data one will get the original data, in which case the returned
+//     consumer reports that it mutates the data, so that it is not shared with read-only consumers upstream.
 func NewLogs(lcs []consumer.Logs) consumer.Logs {
-	if len(lcs) == 1 {
+	return NewLogsWithPolicy(lcs, Policy{})
+}
+
+// NewLogsWithPolicy is NewLogs, where the errors of the consumers fail the returned consumer according to the policy.
+func NewLogsWithPolicy(lcs []consumer.Logs, policy Policy) consumer.Logs {
+	if len(lcs) == 1 && policy.isDefault() {
 		// Don't wrap if no need to do it.
 		return lcs[0]
 	}
-	var pass []consumer.Logs
-	var clone []consumer.Logs
+	var pass []int
+	var clone []int
 	for i := 0; i < len(lcs)-1; i++ {
 		if !lcs[i].Capabilities().MutatesData {
-			pass = append(pass, lcs[i])
+			pass = append(pass, i)
 		} else {
-			clone = append(clone, lcs[i])
+			clone = append(clone, i)
 		}
 	}
 	// Give the original data to the last consumer if no other read-only consumer,
@@ -48,35 +52,50 @@ func NewLogs(lcs []consumer.Logs) consumer.Logs {
 	// a mutating and a non-mutating consumer since the non-mutating consumer may process
 	// data async and the mutating consumer may change the data before that.
 	if len(pass) == 0 || !lcs[len(lcs)-1].Capabilities().MutatesData {
-		pass = append(pass, lcs[len(lcs)-1])
+		pass = append(pass, len(lcs)-1)
 	} else {
-		clone = append(clone, lcs[len(lcs)-1])
+		clone = append(clone, len(lcs)-1)
+	}
+	return &logsConsumer{
+		consumers:   lcs,
+		pass:        pass,
+		clone:       clone,
+		mutatesData: lcs[pass[0]].Capabilities().MutatesData,
+		policy:      policy,
 	}
-	return &logsConsumer{pass: pass, clone: clone}
 }
 
 type logsConsumer struct {
-	pass  []consumer.Logs
-	clone []consumer.Logs
+	consumers []consumer.Logs
+	// pass and clone are the indexes of the consumers getting the original data and a copy of it.
+	pass  []int
+	clone []int
+	// mutatesData is true when the original data is given to a mutating consumer, which only happens when all the
+	// consumers mutate the data, since pass then contains a single mutating consumer, otherwise only read-only ones.
+	mutatesData bool
+	policy      Policy
 }
 
 func (lsc *logsConsumer) Capabilities() consumer.Capabilities {
-	return consumer.Capabilities{MutatesData: false}
+	return consumer.Capabilities{MutatesData: lsc.mutatesData}
 }
 
 // ConsumeLogs exports the plog.Logs to all consumers wrapped by the current one.
 func (lsc *logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
-	var errs error
+	errs := errorCollector{policy: &lsc.policy}
+	if !lsc.policy.isDefault() {
+		errs.items = ld.LogRecordCount()
+	}
 	// Initially pass to clone exporter to avoid the case where the optimization of sending
 	// the incoming data to a mutating consumer is used that may change the incoming data before
 	// cloning.
-	for _, lc := range lsc.clone {
+	for _, i := range lsc.clone {
 		clonedLogs := plog.NewLogs()
 		ld.CopyTo(clonedLogs)
-		errs = multierr.Append(errs, lc.ConsumeLogs(ctx, clonedLogs))
+		errs.add(ctx, i, lsc.consumers[i].ConsumeLogs(ctx, clonedLogs))
 	}
-	for _, lc := range lsc.pass {
-		errs = multierr.Append(errs, lc.ConsumeLogs(ctx, ld))
+	for _, i := range lsc.pass {
+		errs.add(ctx, i, lsc.consumers[i].ConsumeLogs(ctx, ld))
 	}
-	return errs
+	return errs.result(ctx)
 }
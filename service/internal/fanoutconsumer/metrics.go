@@ -17,8 +17,6 @@ package fanoutconsumer // import "go.opentelemetry.io/collector/service/internal
 import (
 	"context"
 
-	"go.uber.org/multierr"
-
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
@@ -26,19 +24,25 @@ import (
 // NewMetrics wraps multiple metrics consumers in a single one.
 // It fanouts the incoming data to all the consumers, and does smart routing:
 //   - Clones only to the consumer that needs to mutate the data.
-//   - If all consumers needs to mutate the data one will get the original data.
+//   - If all consumers needs to mutate the data one will get the original data, in which case the returned
+//     consumer reports that it mutates the data, so that it is not shared with read-only consumers upstream.
 func NewMetrics(mcs []consumer.Metrics) consumer.Metrics {
-	if len(mcs) == 1 {
+	return NewMetricsWithPolicy(mcs, Policy{})
+}
+
+// NewMetricsWithPolicy is NewMetrics, where the errors of the consumers fail the returned consumer according to the policy.
+func NewMetricsWithPolicy(mcs []consumer.Metrics, policy Policy) consumer.Metrics {
+	if len(mcs) == 1 && policy.isDefault() {
 		// Don't wrap if no need to do it.
 		return mcs[0]
 	}
-	var pass []consumer.Metrics
-	var clone []consumer.Metrics
+	var pass []int
+	var clone []int
 	for i := 0; i < len(mcs)-1; i++ {
 		if !mcs[i].Capabilities().MutatesData {
-			pass = append(pass, mcs[i])
+			pass = append(pass, i)
 		} else {
-			clone = append(clone, mcs[i])
+			clone = append(clone, i)
 		}
 	}
 	// Give the original data to the last consumer if no other read-only consumer,
@@ -46,35 +50,50 @@ func NewMetrics(mcs []consumer.Metrics) consumer.Metrics {
 	// a mutating and a non-mutating consumer since the non-mutating consumer may process
 	// data async and the mutating consumer may change the data before that.
 	if len(pass) == 0 || !mcs[len(mcs)-1].Capabilities().MutatesData {
-		pass = append(pass, mcs[len(mcs)-1])
+		pass = append(pass, len(mcs)-1)
 	} else {
-		clone = append(clone, mcs[len(mcs)-1])
+		clone = append(clone, len(mcs)-1)
+	}
+	return &metricsConsumer{
+		consumers:   mcs,
+		pass:        pass,
+		clone:       clone,
+		mutatesData: mcs[pass[0]].Capabilities().MutatesData,
+		policy:      policy,
 	}
-	return &metricsConsumer{pass: pass, clone: clone}
 }
 
 type metricsConsumer struct {
-	pass  []consumer.Metrics
-	clone []consumer.Metrics
+	consumers []consumer.Metrics
+	// pass and clone are the indexes of the consumers getting the original data and a copy of it.
+	pass  []int
+	clone []int
+	// mutatesData is true when the original data is given to a mutating consumer, which only happens when all the
+	// consumers mutate the data, since pass then contains a single mutating consumer, otherwise only read-only ones.
+	mutatesData bool
+	policy      Policy
 }
 
 func (msc *metricsConsumer) Capabilities() consumer.Capabilities {
-	return consumer.Capabilities{MutatesData: false}
+	return consumer.Capabilities{MutatesData: msc.mutatesData}
 }
 
 // ConsumeMetrics exports the pmetric.Metrics to all consumers wrapped by the current one.
 func (msc *metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
-	var errs error
+	errs := errorCollector{policy: &msc.policy}
+	if !msc.policy.isDefault() {
+		errs.items = md.DataPointCount()
+	}
 	// Initially pass to clone exporter to avoid the case where the optimization of sending
 	// the incoming data to a mutating consumer is used that may change the incoming data before
 	// cloning.
-	for _, mc := range msc.clone {
+	for _, i := range msc.clone {
 		clonedMetrics := pmetric.NewMetrics()
 		md.CopyTo(clonedMetrics)
-		errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, clonedMetrics))
+		errs.add(ctx, i, msc.consumers[i].ConsumeMetrics(ctx, clonedMetrics))
 	}
-	for _, mc := range msc.pass {
-		errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, md))
+	for _, i := range msc.pass {
+		errs.add(ctx, i, msc.consumers[i].ConsumeMetrics(ctx, md))
 	}
-	return errs
+	return errs.result(ctx)
 }
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+
+import (
+	"context"
+
+	"go.uber.org/multierr"
+)
+
+// Policy determines which errors of the consumers fail the fan-out consumer.
+// The zero value fails the fan-out if any consumer fails.
+type Policy struct {
+	// RequireAny makes the fan-out succeed as long as at least one consumer accepted the data.
+	RequireAny bool
+
+	// Ignored lists the indexes, in the consumers of the fan-out, of the consumers whose errors never fail the fan-out.
+	Ignored map[int]bool
+
+	// OnDropped is called, if set, with the index of the consumer, the number of items and the error of every
+	// failure that does not fail the fan-out because of the policy.
+	OnDropped func(ctx context.Context, index int, items int, err error)
+}
+
+func (p Policy) isDefault() bool {
+	return !p.RequireAny && len(p.Ignored) == 0
+}
+
+// indexedError is the error returned by the consumer at index.
+type indexedError struct {
+	index int
+	err   error
+}
+
+// errorCollector combines the errors of the consumers of a single call according to the policy.
+type errorCollector struct {
+	policy   *Policy
+	items    int
+	errs     error
+	failed   []indexedError
+	accepted bool
+}
+
+func (c *errorCollector) add(ctx context.Context, index int, err error) {
+	switch {
+	case err == nil:
+		c.accepted = true
+	case c.policy.Ignored[index]:
+		c.drop(ctx, index, err)
+	case c.policy.RequireAny:
+		c.failed = append(c.failed, indexedError{index: index, err: err})
+		c.errs = multierr.Append(c.errs, err)
+	default:
+		c.errs = multierr.Append(c.errs, err)
+	}
+}
+
+// result returns the error of the fan-out.
+func (c *errorCollector) result(ctx context.Context) error {
+	if c.policy.RequireAny && c.accepted {
+		for _, f := range c.failed {
+			c.drop(ctx, f.index, f.err)
+		}
+		return nil
+	}
+	return c.errs
+}
+
+func (c *errorCollector) drop(ctx context.Context, index int, err error) {
+	if c.policy.OnDropped != nil {
+		c.policy.OnDropped(ctx, index, c.items, err)
+	}
+}
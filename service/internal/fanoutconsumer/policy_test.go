@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+type droppedError struct {
+	index int
+	items int
+}
+
+func recordDropped(policy Policy) (Policy, *[]droppedError) {
+	var dropped []droppedError
+	policy.OnDropped = func(_ context.Context, index int, items int, err error) {
+		dropped = append(dropped, droppedError{index: index, items: items})
+	}
+	return policy, &dropped
+}
+
+func TestPolicyDefaultFailsOnAnyError(t *testing.T) {
+	p1 := consumertest.NewErr(errors.New("my error"))
+	p2 := new(consumertest.TracesSink)
+
+	policy, dropped := recordDropped(Policy{})
+	tfc := NewTracesWithPolicy([]consumer.Traces{p1, p2}, policy)
+
+	assert.Error(t, tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.Equal(t, 2, p2.SpanCount())
+	assert.Empty(t, *dropped)
+}
+
+func TestPolicyRequireAny(t *testing.T) {
+	p1 := mutatingErr{Consumer: consumertest.NewErr(errors.New("my error"))}
+	p2 := new(consumertest.TracesSink)
+
+	policy, dropped := recordDropped(Policy{RequireAny: true})
+	tfc := NewTracesWithPolicy([]consumer.Traces{p1, p2}, policy)
+
+	assert.NoError(t, tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.Equal(t, 2, p2.SpanCount())
+	assert.Equal(t, []droppedError{{index: 0, items: 2}}, *dropped)
+}
+
+func TestPolicyRequireAnyAllFail(t *testing.T) {
+	p1 := consumertest.NewErr(errors.New("my error"))
+	p2 := consumertest.NewErr(errors.New("other error"))
+
+	policy, dropped := recordDropped(Policy{RequireAny: true})
+	mfc := NewMetricsWithPolicy([]consumer.Metrics{p1, p2}, policy)
+
+	assert.EqualError(t, mfc.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)), "my error; other error")
+	assert.Empty(t, *dropped)
+}
+
+func TestPolicyIgnored(t *testing.T) {
+	p1 := consumertest.NewErr(errors.New("my error"))
+	p2 := new(consumertest.LogsSink)
+	p3 := consumertest.NewErr(errors.New("other error"))
+
+	policy, dropped := recordDropped(Policy{Ignored: map[int]bool{0: true}})
+	lfc := NewLogsWithPolicy([]consumer.Logs{p1, p2}, policy)
+	assert.NoError(t, lfc.ConsumeLogs(context.Background(), testdata.GenerateLogs(3)))
+	assert.Equal(t, 3, p2.LogRecordCount())
+	assert.Equal(t, []droppedError{{index: 0, items: 3}}, *dropped)
+
+	// Errors of the consumers that are not ignored still fail the fan-out.
+	lfc = NewLogsWithPolicy([]consumer.Logs{p1, p3}, policy)
+	assert.EqualError(t, lfc.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)), "other error")
+}
+
+func TestPolicySingleConsumer(t *testing.T) {
+	p1 := consumertest.NewErr(errors.New("my error"))
+
+	tfc := NewTracesWithPolicy([]consumer.Traces{p1}, Policy{})
+	assert.Same(t, p1, tfc)
+
+	policy, dropped := recordDropped(Policy{Ignored: map[int]bool{0: true}})
+	tfc = NewTracesWithPolicy([]consumer.Traces{p1}, policy)
+	assert.NoError(t, tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Equal(t, []droppedError{{index: 0, items: 1}}, *dropped)
+}
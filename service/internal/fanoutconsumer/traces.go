@@ -17,8 +17,6 @@ package fanoutconsumer // import "go.opentelemetry.io/collector/service/internal
 import (
 	"context"
 
-	"go.uber.org/multierr"
-
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -26,19 +24,25 @@ import (
 // NewTraces wraps multiple trace consumers in a single one.
 // It fanouts the incoming data to all the consumers, and does smart routing:
 //   - Clones only to the consumer that needs to mutate the data.
-//   - If all consumers needs to mutate the data one will get the original data.
+//   - If all consumers needs to mutate the data one will get the original data, in which case the returned
+//     consumer reports that it mutates the data, so that it is not shared with read-only consumers upstream.
 func NewTraces(tcs []consumer.Traces) consumer.Traces {
-	if len(tcs) == 1 {
+	return NewTracesWithPolicy(tcs, Policy{})
+}
+
+// NewTracesWithPolicy is NewTraces, where the errors of the consumers fail the returned consumer according to the policy.
+func NewTracesWithPolicy(tcs []consumer.Traces, policy Policy) consumer.Traces {
+	if len(tcs) == 1 && policy.isDefault() {
 		// Don't wrap if no need to do it.
 		return tcs[0]
 	}
-	var pass []consumer.Traces
-	var clone []consumer.Traces
+	var pass []int
+	var clone []int
 	for i := 0; i < len(tcs)-1; i++ {
 		if !tcs[i].Capabilities().MutatesData {
-			pass = append(pass, tcs[i])
+			pass = append(pass, i)
 		} else {
-			clone = append(clone, tcs[i])
+			clone = append(clone, i)
 		}
 	}
 	// Give the original data to the last consumer if no other read-only consumer,
@@ -46,35 +50,50 @@ func NewTraces(tcs []consumer.Traces) consumer.Traces {
 	// a mutating and a non-mutating consumer since the non-mutating consumer may process
 	// data async and the mutating consumer may change the data before that.
 	if len(pass) == 0 || !tcs[len(tcs)-1].Capabilities().MutatesData {
-		pass = append(pass, tcs[len(tcs)-1])
+		pass = append(pass, len(tcs)-1)
 	} else {
-		clone = append(clone, tcs[len(tcs)-1])
+		clone = append(clone, len(tcs)-1)
+	}
+	return &tracesConsumer{
+		consumers:   tcs,
+		pass:        pass,
+		clone:       clone,
+		mutatesData: tcs[pass[0]].Capabilities().MutatesData,
+		policy:      policy,
 	}
-	return &tracesConsumer{pass: pass, clone: clone}
 }
 
 type tracesConsumer struct {
-	pass  []consumer.Traces
-	clone []consumer.Traces
+	consumers []consumer.Traces
+	// pass and clone are the indexes of the consumers getting the original data and a copy of it.
+	pass  []int
+	clone []int
+	// mutatesData is true when the original data is given to a mutating consumer, which only happens when all the
+	// consumers mutate the data, since pass then contains a single mutating consumer, otherwise only read-only ones.
+	mutatesData bool
+	policy      Policy
 }
 
 func (tsc *tracesConsumer) Capabilities() consumer.Capabilities {
-	return consumer.Capabilities{MutatesData: false}
+	return consumer.Capabilities{MutatesData: tsc.mutatesData}
 }
 
 // ConsumeTraces exports the ptrace.Traces to all consumers wrapped by the current one.
 func (tsc *tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
-	var errs error
+	errs := errorCollector{policy: &tsc.policy}
+	if !tsc.policy.isDefault() {
+		errs.items = td.SpanCount()
+	}
 	// Initially pass to clone exporter to avoid the case where the optimization of sending
 	// the incoming data to a mutating consumer is used that may change the incoming data before
 	// cloning.
-	for _, tc := range tsc.clone {
+	for _, i := range tsc.clone {
 		clonedTraces := ptrace.NewTraces()
 		td.CopyTo(clonedTraces)
-		errs = multierr.Append(errs, tc.ConsumeTraces(ctx, clonedTraces))
+		errs.add(ctx, i, tsc.consumers[i].ConsumeTraces(ctx, clonedTraces))
 	}
-	for _, tc := range tsc.pass {
-		errs = multierr.Append(errs, tc.ConsumeTraces(ctx, td))
+	for _, i := range tsc.pass {
+		errs.add(ctx, i, tsc.consumers[i].ConsumeTraces(ctx, td))
 	}
-	return errs
+	return errs.result(ctx)
 }
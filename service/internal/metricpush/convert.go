@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricpush // import "go.opentelemetry.io/collector/service/internal/metricpush"
+
+import (
+	"math"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const scopeName = "go.opentelemetry.io/collector/service"
+
+// toMetrics converts the metric families gathered from a Prometheus registry to pdata. Counters become monotonic
+// cumulative sums started at start, gauges and untyped metrics become gauges, and histograms and summaries keep
+// their type. The labels of the samples become the attributes of the data points.
+func toMetrics(families []*dto.MetricFamily, resource map[string]string, start, now time.Time) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	for k, v := range resource {
+		rm.Resource().Attributes().PutStr(k, v)
+	}
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+
+	startTs := pcommon.NewTimestampFromTime(start)
+	nowTs := pcommon.NewTimestampFromTime(now)
+	for _, family := range families {
+		if len(family.GetMetric()) == 0 {
+			continue
+		}
+		m := sm.Metrics().AppendEmpty()
+		m.SetName(family.GetName())
+		m.SetDescription(family.GetHelp())
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			sum := m.SetEmptySum()
+			sum.SetIsMonotonic(true)
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			for _, sample := range family.GetMetric() {
+				dp := sum.DataPoints().AppendEmpty()
+				setNumberDataPoint(dp, sample, sample.GetCounter().GetValue(), startTs, nowTs)
+			}
+		case dto.MetricType_HISTOGRAM:
+			hist := m.SetEmptyHistogram()
+			hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			for _, sample := range family.GetMetric() {
+				dp := hist.DataPoints().AppendEmpty()
+				setAttributes(dp.Attributes(), sample)
+				dp.SetStartTimestamp(startTs)
+				dp.SetTimestamp(nowTs)
+				setHistogram(dp, sample.GetHistogram())
+			}
+		case dto.MetricType_SUMMARY:
+			summary := m.SetEmptySummary()
+			for _, sample := range family.GetMetric() {
+				dp := summary.DataPoints().AppendEmpty()
+				setAttributes(dp.Attributes(), sample)
+				dp.SetStartTimestamp(startTs)
+				dp.SetTimestamp(nowTs)
+				dp.SetCount(sample.GetSummary().GetSampleCount())
+				dp.SetSum(sample.GetSummary().GetSampleSum())
+				for _, q := range sample.GetSummary().GetQuantile() {
+					qv := dp.QuantileValues().AppendEmpty()
+					qv.SetQuantile(q.GetQuantile())
+					qv.SetValue(q.GetValue())
+				}
+			}
+		default:
+			gauge := m.SetEmptyGauge()
+			for _, sample := range family.GetMetric() {
+				value := sample.GetGauge().GetValue()
+				if family.GetType() == dto.MetricType_UNTYPED {
+					value = sample.GetUntyped().GetValue()
+				}
+				dp := gauge.DataPoints().AppendEmpty()
+				setNumberDataPoint(dp, sample, value, 0, nowTs)
+			}
+		}
+	}
+	return md
+}
+
+func setNumberDataPoint(dp pmetric.NumberDataPoint, sample *dto.Metric, value float64, start, now pcommon.Timestamp) {
+	setAttributes(dp.Attributes(), sample)
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(now)
+	dp.SetDoubleValue(value)
+}
+
+func setAttributes(attrs pcommon.Map, sample *dto.Metric) {
+	for _, label := range sample.GetLabel() {
+		attrs.PutStr(label.GetName(), label.GetValue())
+	}
+}
+
+// setHistogram converts the cumulative buckets of a Prometheus histogram to the bucket counts of pdata.
+// The +Inf bucket, implicit in Prometheus, is the last bucket count.
+func setHistogram(dp pmetric.HistogramDataPoint, hist *dto.Histogram) {
+	dp.SetCount(hist.GetSampleCount())
+	dp.SetSum(hist.GetSampleSum())
+	var bounds []float64
+	var counts []uint64
+	var prev uint64
+	for _, b := range hist.GetBucket() {
+		if math.IsInf(b.GetUpperBound(), 1) {
+			continue
+		}
+		bounds = append(bounds, b.GetUpperBound())
+		counts = append(counts, b.GetCumulativeCount()-prev)
+		prev = b.GetCumulativeCount()
+	}
+	counts = append(counts, hist.GetSampleCount()-prev)
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(counts)
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricpush
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestToMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "sent", Help: "sent items"}, []string{"exporter"})
+	counter.WithLabelValues("otlp").Add(3)
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "queue_size", Help: "queue size"})
+	gauge.Set(7)
+	hist := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "duration", Help: "duration", Buckets: []float64{1, 10}})
+	hist.Observe(0.5)
+	hist.Observe(5)
+	hist.Observe(50)
+	registry.MustRegister(counter, gauge, hist)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	start := time.Unix(100, 0)
+	now := time.Unix(200, 0)
+	md := toMetrics(families, map[string]string{"service.name": "otelcol"}, start, now)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	name, ok := rm.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "otelcol", name.Str())
+	sm := rm.ScopeMetrics().At(0)
+	assert.Equal(t, scopeName, sm.Scope().Name())
+
+	metrics := map[string]pmetric.Metric{}
+	for i := 0; i < sm.Metrics().Len(); i++ {
+		metrics[sm.Metrics().At(i).Name()] = sm.Metrics().At(i)
+	}
+	require.Len(t, metrics, 3)
+
+	sent := metrics["sent"]
+	require.Equal(t, pmetric.MetricTypeSum, sent.Type())
+	assert.Equal(t, "sent items", sent.Description())
+	assert.True(t, sent.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, sent.Sum().AggregationTemporality())
+	dp := sent.Sum().DataPoints().At(0)
+	assert.Equal(t, 3.0, dp.DoubleValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(start), dp.StartTimestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), dp.Timestamp())
+	exporter, ok := dp.Attributes().Get("exporter")
+	require.True(t, ok)
+	assert.Equal(t, "otlp", exporter.Str())
+
+	queueSize := metrics["queue_size"]
+	require.Equal(t, pmetric.MetricTypeGauge, queueSize.Type())
+	assert.Equal(t, 7.0, queueSize.Gauge().DataPoints().At(0).DoubleValue())
+
+	duration := metrics["duration"]
+	require.Equal(t, pmetric.MetricTypeHistogram, duration.Type())
+	hdp := duration.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(3), hdp.Count())
+	assert.Equal(t, 55.5, hdp.Sum())
+	assert.Equal(t, []float64{1, 10}, hdp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{1, 1, 1}, hdp.BucketCounts().AsRaw())
+}
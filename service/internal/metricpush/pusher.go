@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metricpush periodically pushes the internal metrics of the collector to an OTLP endpoint, for the
+// deployments where the collector cannot be scraped, e.g. serverless environments. The metrics are gathered from
+// the Prometheus registry shared by the OpenCensus and OpenTelemetry instrumentations, which the periodic reader of
+// the OpenTelemetry SDK cannot read, so they are converted to pdata and sent with the shared OTLP client.
+package metricpush // import "go.opentelemetry.io/collector/service/internal/metricpush"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/service/internal/otlpclient"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+const (
+	defaultInterval = 60 * time.Second
+	defaultTimeout  = 30 * time.Second
+)
+
+// Pusher gathers the internal metrics from a Prometheus registry at every interval, and pushes them to an
+// OTLP endpoint.
+type Pusher struct {
+	logger   *zap.Logger
+	gatherer prometheus.Gatherer
+	resource map[string]string
+	interval time.Duration
+	timeout  time.Duration
+	start    time.Time
+	client   *otlpclient.Client
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewPusher returns a Pusher of the metrics of the gatherer configured by cfg. The resource attributes identify
+// the collector.
+func NewPusher(cfg *telemetry.PeriodicReaderConfig, gatherer prometheus.Gatherer, resource map[string]string, logger *zap.Logger) (*Pusher, error) {
+	p := &Pusher{
+		logger:   logger,
+		gatherer: gatherer,
+		resource: resource,
+		interval: cfg.Interval,
+		timeout:  cfg.Timeout,
+		start:    time.Now(),
+		stopCh:   make(chan struct{}),
+	}
+	if p.interval == 0 {
+		p.interval = defaultInterval
+	}
+	if p.timeout == 0 {
+		p.timeout = defaultTimeout
+	}
+
+	otlp := cfg.Exporter.OTLP
+	tlsCfg, err := otlp.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	if p.client, err = otlpclient.New(otlpclient.Settings{
+		HTTP:     otlp.Protocol == telemetry.OTLPProtocolHTTPProtobuf,
+		Endpoint: otlp.Endpoint,
+		Headers:  otlp.Headers,
+		TLS:      tlsCfg,
+	}); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Start starts pushing the internal metrics periodically.
+func (p *Pusher) Start() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := p.push(); err != nil {
+					p.logger.Warn("Failed to push the internal metrics", zap.Error(err))
+				}
+			case <-p.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Shutdown stops the periodic pushes, and pushes the internal metrics one last time so that the latest values
+// are not lost when the collector stops.
+func (p *Pusher) Shutdown() error {
+	close(p.stopCh)
+	p.wg.Wait()
+	err := p.push()
+	if closeErr := p.client.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (p *Pusher) push() error {
+	families, err := p.gatherer.Gather()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	return p.client.ExportMetrics(ctx, toMetrics(families, p.resource, p.start, time.Now()))
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlpclient sends OTLP export requests over gRPC or HTTP. It is shared by the exporters of the internal
+// telemetry of the collector, which cannot depend on the exporter components.
+package otlpclient // import "go.opentelemetry.io/collector/service/internal/otlpclient"
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// Settings configures a Client.
+type Settings struct {
+	// HTTP sends the requests as protobuf over HTTP, rather than over gRPC.
+	HTTP bool
+	// Endpoint is the host:port of the gRPC server, or the URL the HTTP requests are posted to.
+	Endpoint string
+	// Headers are sent with every request.
+	Headers map[string]string
+	// TLS is the TLS config of the connections, nil for insecure connections.
+	TLS *tls.Config
+}
+
+// Client sends OTLP export requests to an endpoint.
+type Client struct {
+	endpoint string
+	headers  map[string]string
+
+	// conn is nil when the requests are sent over HTTP.
+	conn          *grpc.ClientConn
+	metricsClient pmetricotlp.GRPCClient
+	tracesClient  ptraceotlp.GRPCClient
+
+	httpClient *http.Client
+}
+
+// New returns a Client configured by set. The gRPC connection is established lazily.
+func New(set Settings) (*Client, error) {
+	c := &Client{endpoint: set.Endpoint, headers: set.Headers}
+	if set.HTTP {
+		c.httpClient = http.DefaultClient
+		if set.TLS != nil {
+			transport := http.DefaultTransport.(*http.Transport).Clone()
+			transport.TLSClientConfig = set.TLS
+			c.httpClient = &http.Client{Transport: transport}
+		}
+		return c, nil
+	}
+	creds := insecure.NewCredentials()
+	if set.TLS != nil {
+		creds = credentials.NewTLS(set.TLS)
+	}
+	conn, err := grpc.Dial(set.Endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	c.conn = conn
+	c.metricsClient = pmetricotlp.NewGRPCClient(conn)
+	c.tracesClient = ptraceotlp.NewGRPCClient(conn)
+	return c, nil
+}
+
+// ExportMetrics sends the metrics.
+func (c *Client) ExportMetrics(ctx context.Context, md pmetric.Metrics) error {
+	req := pmetricotlp.NewExportRequestFromMetrics(md)
+	if c.conn != nil {
+		_, err := c.metricsClient.Export(c.outgoingContext(ctx), req)
+		return err
+	}
+	body, err := req.MarshalProto()
+	if err != nil {
+		return err
+	}
+	return c.post(ctx, body)
+}
+
+// ExportTraces sends the traces.
+func (c *Client) ExportTraces(ctx context.Context, td ptrace.Traces) error {
+	req := ptraceotlp.NewExportRequestFromTraces(td)
+	if c.conn != nil {
+		_, err := c.tracesClient.Export(c.outgoingContext(ctx), req)
+		return err
+	}
+	body, err := req.MarshalProto()
+	if err != nil {
+		return err
+	}
+	return c.post(ctx, body)
+}
+
+// Close closes the gRPC connection, if any.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+func (c *Client) outgoingContext(ctx context.Context) context.Context {
+	return metadata.NewOutgoingContext(ctx, metadata.New(c.headers))
+}
+
+func (c *Client) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("exporting to %s responded with HTTP Status Code %d", c.endpoint, resp.StatusCode)
+	}
+	return nil
+}
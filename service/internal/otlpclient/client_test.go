@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpclient
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+type metricsServer struct {
+	received chan pmetric.Metrics
+	headers  chan metadata.MD
+}
+
+func (s *metricsServer) Export(ctx context.Context, req pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.headers <- md
+	s.received <- req.Metrics()
+	return pmetricotlp.NewExportResponse(), nil
+}
+
+func TestClientGRPC(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	ms := &metricsServer{received: make(chan pmetric.Metrics, 1), headers: make(chan metadata.MD, 1)}
+	pmetricotlp.RegisterGRPCServer(srv, ms)
+	go func() { _ = srv.Serve(ln) }()
+	defer srv.Stop()
+
+	client, err := New(Settings{Endpoint: ln.Addr().String(), Headers: map[string]string{"X-Token": "secret"}})
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("test")
+	require.NoError(t, client.ExportMetrics(context.Background(), md))
+	assert.Equal(t, []string{"secret"}, (<-ms.headers).Get("x-token"))
+	assert.Equal(t, 1, (<-ms.received).MetricCount())
+	assert.NoError(t, client.Close())
+}
+
+func TestClientHTTP(t *testing.T) {
+	received := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("X-Token"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		received <- body
+	}))
+	defer srv.Close()
+
+	client, err := New(Settings{HTTP: true, Endpoint: srv.URL, Headers: map[string]string{"X-Token": "secret"}})
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("test")
+	require.NoError(t, client.ExportMetrics(context.Background(), md))
+	metricsReq := pmetricotlp.NewExportRequest()
+	require.NoError(t, metricsReq.UnmarshalProto(<-received))
+	assert.Equal(t, 1, metricsReq.Metrics().MetricCount())
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("test")
+	require.NoError(t, client.ExportTraces(context.Background(), td))
+	tracesReq := ptraceotlp.NewExportRequest()
+	require.NoError(t, tracesReq.UnmarshalProto(<-received))
+	assert.Equal(t, 1, tracesReq.Traces().SpanCount())
+
+	assert.NoError(t, client.Close())
+}
+
+func TestClientHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client, err := New(Settings{HTTP: true, Endpoint: srv.URL})
+	require.NoError(t, err)
+	assert.EqualError(t, client.ExportMetrics(context.Background(), pmetric.NewMetrics()),
+		"exporting to "+srv.URL+" responded with HTTP Status Code 503")
+}
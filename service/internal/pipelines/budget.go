@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines // import "go.opentelemetry.io/collector/service/internal/pipelines"
+
+import (
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+// batchingConfig is implemented by the configs of the processors batching the data, e.g. the batch processor.
+type batchingConfig interface {
+	// MaxBatchSize returns the largest number of items of the batches of the given data type, zero if unknown.
+	MaxBatchSize(dt component.DataType) uint64
+}
+
+// memoryLimitConfig is implemented by the configs of the processors limiting the memory usage,
+// e.g. the memory limiter processor.
+type memoryLimitConfig interface {
+	// FixedMemoryLimitMiB returns the memory limit in MiB, zero if unknown.
+	FixedMemoryLimitMiB() uint64
+}
+
+// sendingQueueConfig is implemented by the configs of the exporters embedding exporterhelper.QueueSettings.
+type sendingQueueConfig interface {
+	// SendingQueueBudget returns the number of batches the queue can hold and the number of its consumers,
+	// zero if the queue is disabled.
+	SendingQueueBudget() (queueSize int, numConsumers int)
+}
+
+// pipelineBudget is a best effort estimation of the resources a pipeline may hold at once, computed from the
+// configuration of its components. The components report their settings by implementing batchingConfig,
+// memoryLimitConfig or sendingQueueConfig, so that any exporter embedding the sending queue settings of
+// exporterhelper is accounted for.
+type pipelineBudget struct {
+	// Receivers is the number of receivers feeding the pipeline.
+	Receivers int
+	// FanOut is the number of exporters each batch of data is sent to.
+	FanOut int
+	// QueueCapacity is the total number of batches the sending queues of the exporters can hold.
+	QueueCapacity int
+	// QueueConsumers is the total number of consumers of the sending queues of the exporters.
+	QueueConsumers int
+	// MaxBatchSize is the largest batch size configured by a processor, zero if no processor batches the data.
+	MaxBatchSize uint64
+	// MemoryLimitMiB is the smallest memory limit configured by a processor, zero if no limit is configured.
+	MemoryLimitMiB uint64
+}
+
+// QueuedItemsCeiling returns the maximum number of items the sending queues can hold, zero if unknown.
+func (pb pipelineBudget) QueuedItemsCeiling() uint64 {
+	return uint64(pb.QueueCapacity) * pb.MaxBatchSize
+}
+
+func buildPipelineBudget(pipelineID component.ID, pipeline *config.Pipeline, set Settings) pipelineBudget {
+	pb := pipelineBudget{
+		Receivers: len(pipeline.Receivers),
+		FanOut:    len(pipeline.Exporters),
+	}
+
+	for _, procID := range pipeline.Processors {
+		cfg := set.ProcessorConfigs[procID]
+		if bc, ok := cfg.(batchingConfig); ok {
+			if size := bc.MaxBatchSize(pipelineID.Type()); size > pb.MaxBatchSize {
+				pb.MaxBatchSize = size
+			}
+		}
+		if mc, ok := cfg.(memoryLimitConfig); ok {
+			if limit := mc.FixedMemoryLimitMiB(); limit > 0 && (pb.MemoryLimitMiB == 0 || limit < pb.MemoryLimitMiB) {
+				pb.MemoryLimitMiB = limit
+			}
+		}
+	}
+
+	for _, expID := range pipeline.Exporters {
+		if qc, ok := set.ExporterConfigs[expID].(sendingQueueConfig); ok {
+			queueSize, numConsumers := qc.SendingQueueBudget()
+			pb.QueueCapacity += queueSize
+			pb.QueueConsumers += numConsumers
+		}
+	}
+	return pb
+}
+
+func (bps *Pipelines) logBudgets() {
+	for _, pipelineID := range bps.sortedPipelineIDs() {
+		pb := bps.pipelines[pipelineID].budget
+		bps.telemetry.Logger.Info("Pipeline budget",
+			zap.Stringer("pipeline", pipelineID),
+			zap.Int("receivers", pb.Receivers),
+			zap.Int("fan_out", pb.FanOut),
+			zap.Int("queue_capacity", pb.QueueCapacity),
+			zap.Int("queue_consumers", pb.QueueConsumers),
+			zap.Uint64("max_batch_size", pb.MaxBatchSize),
+			zap.Uint64("queued_items_ceiling", pb.QueuedItemsCeiling()),
+			zap.Uint64("memory_limit_mib", pb.MemoryLimitMiB))
+	}
+}
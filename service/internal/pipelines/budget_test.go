@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+type testQueueSettings struct {
+	Enabled      bool
+	NumConsumers int
+	QueueSize    int
+}
+
+func (qs *testQueueSettings) SendingQueueBudget() (int, int) {
+	if !qs.Enabled {
+		return 0, 0
+	}
+	return qs.QueueSize, qs.NumConsumers
+}
+
+type testExporterConfig struct {
+	config.ExporterSettings
+	testQueueSettings
+}
+
+type testPlainExporterConfig struct {
+	config.ExporterSettings
+	Endpoint string
+}
+
+type testBatchConfig struct {
+	config.ProcessorSettings
+	SendBatchMaxSize uint64
+	Logs             uint64
+}
+
+func (cfg *testBatchConfig) MaxBatchSize(dt component.DataType) uint64 {
+	if dt == component.DataTypeLogs && cfg.Logs > 0 {
+		return cfg.Logs
+	}
+	return cfg.SendBatchMaxSize
+}
+
+type testMemoryLimiterConfig struct {
+	config.ProcessorSettings
+	MemoryLimitMiB uint64
+}
+
+func (cfg *testMemoryLimiterConfig) FixedMemoryLimitMiB() uint64 {
+	return cfg.MemoryLimitMiB
+}
+
+func TestBuildPipelineBudget(t *testing.T) {
+	batchID := component.NewID("batch")
+	limiterID := component.NewID("memory_limiter")
+	limiterSmallID := component.NewIDWithName("memory_limiter", "small")
+	queuedID := component.NewID("queued")
+	queuedOtherID := component.NewIDWithName("queued", "other")
+	disabledID := component.NewIDWithName("queued", "disabled")
+	plainID := component.NewID("plain")
+
+	set := Settings{
+		ProcessorConfigs: map[component.ID]component.Config{
+			batchID: &testBatchConfig{
+				SendBatchMaxSize: 10000,
+				Logs:             500,
+			},
+			limiterID:      &testMemoryLimiterConfig{MemoryLimitMiB: 4000},
+			limiterSmallID: &testMemoryLimiterConfig{MemoryLimitMiB: 1000},
+		},
+		ExporterConfigs: map[component.ID]component.Config{
+			queuedID:      &testExporterConfig{testQueueSettings: testQueueSettings{Enabled: true, NumConsumers: 10, QueueSize: 5000}},
+			queuedOtherID: &testExporterConfig{testQueueSettings: testQueueSettings{Enabled: true, NumConsumers: 2, QueueSize: 100}},
+			disabledID:    &testExporterConfig{testQueueSettings: testQueueSettings{Enabled: false, NumConsumers: 10, QueueSize: 5000}},
+			plainID:       &testPlainExporterConfig{},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		pipelineID component.ID
+		pipeline   *config.Pipeline
+		expected   pipelineBudget
+	}{
+		{
+			name:       "no_settings",
+			pipelineID: component.NewID(component.DataTypeTraces),
+			pipeline: &config.Pipeline{
+				Receivers: []component.ID{component.NewID("otlp")},
+				Exporters: []component.ID{plainID},
+			},
+			expected: pipelineBudget{Receivers: 1, FanOut: 1},
+		},
+		{
+			name:       "full",
+			pipelineID: component.NewID(component.DataTypeTraces),
+			pipeline: &config.Pipeline{
+				Receivers:  []component.ID{component.NewID("otlp"), component.NewID("jaeger")},
+				Processors: []component.ID{limiterID, limiterSmallID, batchID},
+				Exporters:  []component.ID{queuedID, queuedOtherID, disabledID, plainID},
+			},
+			expected: pipelineBudget{
+				Receivers:      2,
+				FanOut:         4,
+				QueueCapacity:  5100,
+				QueueConsumers: 12,
+				MaxBatchSize:   10000,
+				MemoryLimitMiB: 1000,
+			},
+		},
+		{
+			name:       "signal_override",
+			pipelineID: component.NewID(component.DataTypeLogs),
+			pipeline: &config.Pipeline{
+				Receivers:  []component.ID{component.NewID("otlp")},
+				Processors: []component.ID{batchID},
+				Exporters:  []component.ID{queuedOtherID},
+			},
+			expected: pipelineBudget{
+				Receivers:      1,
+				FanOut:         1,
+				QueueCapacity:  100,
+				QueueConsumers: 2,
+				MaxBatchSize:   500,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, buildPipelineBudget(tt.pipelineID, tt.pipeline, set))
+		})
+	}
+}
+
+func TestPipelineBudgetQueuedItemsCeiling(t *testing.T) {
+	assert.Equal(t, uint64(0), pipelineBudget{QueueCapacity: 100}.QueuedItemsCeiling())
+	assert.Equal(t, uint64(51_000_000), pipelineBudget{QueueCapacity: 5100, MaxBatchSize: 10000}.QueuedItemsCeiling())
+}
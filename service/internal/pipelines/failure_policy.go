@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines // import "go.opentelemetry.io/collector/service/internal/pipelines"
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+)
+
+// droppedByPolicyMeasures are the measures of the items dropped by the failure policy, by pipeline data type.
+var droppedByPolicyMeasures = map[component.DataType]*stats.Int64Measure{
+	component.DataTypeTraces:  obsmetrics.ExporterDroppedByPolicySpans,
+	component.DataTypeMetrics: obsmetrics.ExporterDroppedByPolicyMetricPoints,
+	component.DataTypeLogs:    obsmetrics.ExporterDroppedByPolicyLogRecords,
+}
+
+// buildFailurePolicy returns the policy of the fan-out consumer to the exporters of the pipeline. The failures
+// of the exporters that do not fail the pipeline because of the policy are counted per exporter.
+func buildFailurePolicy(logger *zap.Logger, pipelineID component.ID, pipeline *config.Pipeline, exporters []builtComponent) fanoutconsumer.Policy {
+	policy := fanoutconsumer.Policy{RequireAny: pipeline.FailurePolicy.Mode == config.PipelineFailureModeAny}
+	for i, exp := range exporters {
+		for _, id := range pipeline.FailurePolicy.IgnoreErrorsFor {
+			if exp.id != id {
+				continue
+			}
+			if policy.Ignored == nil {
+				policy.Ignored = make(map[int]bool)
+			}
+			policy.Ignored[i] = true
+		}
+	}
+	if !policy.RequireAny && len(policy.Ignored) == 0 {
+		return policy
+	}
+
+	measure := droppedByPolicyMeasures[pipelineID.Type()]
+	policy.OnDropped = func(ctx context.Context, index int, items int, err error) {
+		expID := exporters[index].id
+		logger.Debug("Exporter failure dropped by the failure policy of the pipeline",
+			zap.Stringer("pipeline", pipelineID), zap.Stringer("exporter", expID), zap.Int("items", items), zap.Error(err))
+		_ = stats.RecordWithTags(ctx,
+			[]tag.Mutator{tag.Upsert(obsmetrics.TagKeyExporter, expID.String(), tag.WithTTL(tag.TTLNoPropagation))},
+			measure.M(int64(items)))
+	}
+	return policy
+}
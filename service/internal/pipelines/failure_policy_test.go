@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestBuildFailurePolicy(t *testing.T) {
+	nopReceiverFactory := componenttest.NewNopReceiverFactory()
+	nopExporterFactory := componenttest.NewNopExporterFactory()
+	failExporterFactory := newFailExporterFactory()
+
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverFactories: map[component.Type]component.ReceiverFactory{
+			nopReceiverFactory.Type(): nopReceiverFactory,
+		},
+		ReceiverConfigs: map[component.ID]component.Config{
+			component.NewID(nopReceiverFactory.Type()): nopReceiverFactory.CreateDefaultConfig(),
+		},
+		ExporterFactories: map[component.Type]component.ExporterFactory{
+			nopExporterFactory.Type():  nopExporterFactory,
+			failExporterFactory.Type(): failExporterFactory,
+		},
+		ExporterConfigs: map[component.ID]component.Config{
+			component.NewID(nopExporterFactory.Type()):               nopExporterFactory.CreateDefaultConfig(),
+			component.NewID(failExporterFactory.Type()):              failExporterFactory.CreateDefaultConfig(),
+			component.NewIDWithName(failExporterFactory.Type(), "1"): failExporterFactory.CreateDefaultConfig(),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		exporters []component.ID
+		policy    config.PipelineFailurePolicy
+		wantErr   bool
+	}{
+		{
+			name:      "all",
+			exporters: []component.ID{component.NewID("nop"), component.NewID("fail")},
+			wantErr:   true,
+		},
+		{
+			name:      "any",
+			exporters: []component.ID{component.NewID("nop"), component.NewID("fail")},
+			policy:    config.PipelineFailurePolicy{Mode: config.PipelineFailureModeAny},
+		},
+		{
+			name:      "any_all_failed",
+			exporters: []component.ID{component.NewID("fail"), component.NewIDWithName("fail", "1")},
+			policy:    config.PipelineFailurePolicy{Mode: config.PipelineFailureModeAny},
+			wantErr:   true,
+		},
+		{
+			name:      "ignore_errors_for",
+			exporters: []component.ID{component.NewID("nop"), component.NewID("fail")},
+			policy:    config.PipelineFailurePolicy{IgnoreErrorsFor: []component.ID{component.NewID("fail")}},
+		},
+		{
+			name:      "ignore_errors_for_other",
+			exporters: []component.ID{component.NewID("fail"), component.NewIDWithName("fail", "1")},
+			policy:    config.PipelineFailurePolicy{IgnoreErrorsFor: []component.ID{component.NewID("fail")}},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set.PipelineConfigs = map[component.ID]*config.Pipeline{
+				component.NewID("traces"): {
+					Receivers:     []component.ID{component.NewID("nop")},
+					Exporters:     tt.exporters,
+					FailurePolicy: tt.policy,
+				},
+			}
+			pipelines, err := Build(context.Background(), set)
+			require.NoError(t, err)
+
+			err = pipelines.pipelines[component.NewID("traces")].lastConsumer.(consumer.Traces).ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProcessorKeyFailurePolicy(t *testing.T) {
+	pipelineID := component.NewID("traces")
+	pipeline := &config.Pipeline{
+		Processors: []component.ID{component.NewID("nop")},
+		Exporters:  []component.ID{component.NewID("nop"), component.NewID("fail")},
+	}
+	defaultKey := processorKey(pipelineID, pipeline, 0)
+
+	pipeline.FailurePolicy.Mode = config.PipelineFailureModeAll
+	assert.Equal(t, defaultKey, processorKey(pipelineID, pipeline, 0))
+
+	pipeline.FailurePolicy.Mode = config.PipelineFailureModeAny
+	anyKey := processorKey(pipelineID, pipeline, 0)
+	assert.NotEqual(t, defaultKey, anyKey)
+
+	pipeline.FailurePolicy.IgnoreErrorsFor = []component.ID{component.NewID("fail")}
+	assert.NotEqual(t, anyKey, processorKey(pipelineID, pipeline, 0))
+}
+
+type failComponent struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumertest.Consumer
+}
+
+func newFailExporterFactory() component.ExporterFactory {
+	return component.NewExporterFactory("fail", func() component.Config {
+		return &struct {
+			config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+		}{
+			ExporterSettings: config.NewExporterSettings(component.NewID("fail")),
+		}
+	},
+		component.WithTracesExporter(func(context.Context, component.ExporterCreateSettings, component.Config) (component.TracesExporter, error) {
+			return &failComponent{Consumer: consumertest.NewErr(errors.New("my error"))}, nil
+		}, component.StabilityLevelUndefined),
+	)
+}
@@ -19,6 +19,10 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -26,12 +30,23 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
 	"go.opentelemetry.io/collector/service/internal/components"
 	"go.opentelemetry.io/collector/service/internal/fanoutconsumer"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
+const sharedProcessorsFeatureGateID = "service.sharedProcessors"
+
+func init() {
+	featuregate.GetRegistry().MustRegisterID(
+		sharedProcessorsFeatureGateID,
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("controls whether a processor is instantiated once for all the pipelines of the same data type that send its output through the same processors to the same exporters"),
+	)
+}
+
 const (
 	zPipelineName  = "zpipelinename"
 	zComponentName = "zcomponentname"
@@ -44,16 +59,22 @@ type baseConsumer interface {
 }
 
 type builtComponent struct {
-	id   component.ID
-	comp component.Component
+	id         component.ID
+	comp       component.Component
+	instanceID *component.InstanceID
+	// mutatesData is only set for the connectors a pipeline exports to, see connectorMutatesData.
+	mutatesData bool
 }
 
 type builtPipeline struct {
 	lastConsumer baseConsumer
 
+	// receivers and exporters include the connectors the pipeline receives from and exports to.
 	receivers  []builtComponent
 	processors []builtComponent
 	exporters  []builtComponent
+
+	budget pipelineBudget
 }
 
 // Pipelines is set of all pipelines created from exporter configs.
@@ -63,83 +84,508 @@ type Pipelines struct {
 	allReceivers map[component.DataType]map[component.ID]component.Component
 	allExporters map[component.DataType]map[component.ID]component.Component
 
+	receiverInstances map[component.DataType]map[component.ID]*component.InstanceID
+	exporterInstances map[component.DataType]map[component.ID]*component.InstanceID
+
+	allConnectors      map[connectorKey]component.Component
+	connectorInstances map[connectorKey]*component.InstanceID
+
 	pipelines map[component.ID]*builtPipeline
+	// pipelineOrder lists every pipeline after the pipelines it sends data to through connectors.
+	pipelineOrder []component.ID
+
+	reportStatus func(*component.InstanceID, *component.StatusEvent)
+
+	startParallel bool
+	startTimeout  time.Duration
+	// starting tracks the start goroutines, which may still run after StartAll timed out.
+	starting sync.WaitGroup
+
+	shutdownTimeout        time.Duration
+	shutdownDumpGoroutines bool
+}
+
+// connectorKey identifies the connector created for a pair of exporter and receiver data types.
+type connectorKey struct {
+	id      component.ID
+	expType component.DataType
+	rcvType component.DataType
 }
 
 // StartAll starts all pipelines.
 //
-// Start with exporters, processors (in reverse configured order), then receivers.
+// Start with exporters, processors (in reverse configured order), connectors, then receivers.
 // This is important so that components that are earlier in the pipeline and reference components that are
 // later in the pipeline do not start sending data to later components which are not yet started.
+//
+// Components of the same kind are started in a deterministic order, or concurrently if parallel start is enabled.
+// Processors of the same pipeline are always started one after the other.
+//
+// The start timeout only bounds the wait for the components to start, the components get ctx, which outlives StartAll.
+// The components still starting when the timeout expires are waited for by the shutdown.
 func (bps *Pipelines) StartAll(ctx context.Context, host component.Host) error {
+	waitCtx := ctx
+	if bps.startTimeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, bps.startTimeout)
+		defer cancel()
+	}
+
 	bps.telemetry.Logger.Info("Starting exporters...")
-	for dt, expByID := range bps.allExporters {
-		for expID, exp := range expByID {
+	var starts []func() error
+	for _, dt := range sortedDataTypes(bps.allExporters) {
+		expByID := bps.allExporters[dt]
+		for _, expID := range sortedIDs(expByID) {
+			exp, instanceID := expByID[expID], bps.exporterInstances[dt][expID]
 			expLogger := exporterLogger(bps.telemetry.Logger, expID, dt)
-			expLogger.Info("Exporter is starting...")
-			if err := exp.Start(ctx, components.NewHostWrapper(host, expLogger)); err != nil {
-				return err
-			}
-			expLogger.Info("Exporter started.")
+			starts = append(starts, func() error {
+				expLogger.Info("Exporter is starting...")
+				if err := bps.startComponent(ctx, host, expLogger, exp, instanceID); err != nil {
+					return err
+				}
+				expLogger.Info("Exporter started.")
+				return nil
+			})
 		}
 	}
+	if err := bps.runStarts(waitCtx, starts); err != nil {
+		return err
+	}
 
 	bps.telemetry.Logger.Info("Starting processors...")
-	for pipelineID, bp := range bps.pipelines {
-		for i := len(bp.processors) - 1; i >= 0; i-- {
-			procLogger := processorLogger(bps.telemetry.Logger, bp.processors[i].id, pipelineID)
-			procLogger.Info("Processor is starting...")
-			if err := bp.processors[i].comp.Start(ctx, components.NewHostWrapper(host, procLogger)); err != nil {
+	starts = nil
+	// The pipelines sharing processors are started together, so that every processor is started with the first
+	// pipeline referencing it, after the processors that follow it.
+	for _, pipelineIDs := range bps.processorGroups() {
+		pipelineIDs := pipelineIDs
+		starts = append(starts, func() error {
+			refs := make(processorRefs)
+			for _, pipelineID := range pipelineIDs {
+				bp := bps.pipelines[pipelineID]
+				for i := len(bp.processors) - 1; i >= 0; i-- {
+					if !refs.acquire(bp.processors[i].instanceID) {
+						continue
+					}
+					procLogger := processorLogger(bps.telemetry.Logger, bp.processors[i].id, pipelineID)
+					procLogger.Info("Processor is starting...")
+					if err := bps.startComponent(ctx, host, procLogger, bp.processors[i].comp, bp.processors[i].instanceID); err != nil {
+						return err
+					}
+					procLogger.Info("Processor started.")
+				}
+			}
+			return nil
+		})
+	}
+	if err := bps.runStarts(waitCtx, starts); err != nil {
+		return err
+	}
+
+	bps.telemetry.Logger.Info("Starting connectors...")
+	starts = nil
+	for _, key := range bps.sortedConnectorKeys() {
+		conn, instanceID := bps.allConnectors[key], bps.connectorInstances[key]
+		connLogger := connectorLogger(bps.telemetry.Logger, key)
+		starts = append(starts, func() error {
+			connLogger.Info("Connector is starting...")
+			if err := bps.startComponent(ctx, host, connLogger, conn, instanceID); err != nil {
 				return err
 			}
-			procLogger.Info("Processor started.")
-		}
+			connLogger.Info("Connector started.")
+			return nil
+		})
+	}
+	if err := bps.runStarts(waitCtx, starts); err != nil {
+		return err
 	}
 
 	bps.telemetry.Logger.Info("Starting receivers...")
-	for dt, recvByID := range bps.allReceivers {
-		for recvID, recv := range recvByID {
+	starts = nil
+	for _, dt := range sortedDataTypes(bps.allReceivers) {
+		recvByID := bps.allReceivers[dt]
+		for _, recvID := range sortedIDs(recvByID) {
+			recv, instanceID := recvByID[recvID], bps.receiverInstances[dt][recvID]
 			recvLogger := receiverLogger(bps.telemetry.Logger, recvID, dt)
-			recvLogger.Info("Receiver is starting...")
-			if err := recv.Start(ctx, components.NewHostWrapper(host, recvLogger)); err != nil {
+			starts = append(starts, func() error {
+				recvLogger.Info("Receiver is starting...")
+				if err := bps.startComponent(ctx, host, recvLogger, recv, instanceID); err != nil {
+					return err
+				}
+				recvLogger.Info("Receiver started.")
+				return nil
+			})
+		}
+	}
+	return bps.runStarts(waitCtx, starts)
+}
+
+// runStarts calls the start functions, one after the other or concurrently if parallel start is enabled.
+// It returns when all of them returned, or when ctx is done.
+func (bps *Pipelines) runStarts(ctx context.Context, starts []func() error) error {
+	if !bps.startParallel {
+		for _, start := range starts {
+			if err := bps.waitStart(ctx, start); err != nil {
 				return err
 			}
-			recvLogger.Info("Receiver started.")
 		}
+		return nil
+	}
+
+	errCh := make(chan error, len(starts))
+	for _, start := range starts {
+		bps.goStart(start, errCh)
+	}
+	var errs error
+	for range starts {
+		select {
+		case err := <-errCh:
+			errs = multierr.Append(errs, err)
+		case <-ctx.Done():
+			return fmt.Errorf("failed to start components: %w", ctx.Err())
+		}
+	}
+	return errs
+}
+
+// waitStart calls start and waits for it to return, or for ctx to be done.
+func (bps *Pipelines) waitStart(ctx context.Context, start func() error) error {
+	if ctx.Done() == nil {
+		// ctx is never done, no need to wait on a separate goroutine.
+		return start()
+	}
+	errCh := make(chan error, 1)
+	bps.goStart(start, errCh)
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("failed to start components: %w", ctx.Err())
+	}
+}
+
+// goStart calls start on a new goroutine and sends its result to errCh, which must not block.
+func (bps *Pipelines) goStart(start func() error, errCh chan<- error) {
+	bps.starting.Add(1)
+	go func() {
+		defer bps.starting.Done()
+		errCh <- start()
+	}()
+}
+
+// waitForStarts waits for the components that are still starting, or for ctx to be done.
+func (bps *Pipelines) waitForStarts(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		bps.starting.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for the components that are still starting: %w", ctx.Err())
 	}
-	return nil
 }
 
 // ShutdownAll stops all pipelines.
 //
 // Shutdown order is the reverse of starting: receivers, processors, then exporters.
+// A connector is stopped after the processors of all the pipelines that send data to it,
+// and before the processors of the pipelines it sends data to.
 // This gives senders a chance to send all their data to a not "shutdown" component.
+//
+// The components that are still starting, because StartAll timed out, are waited for before stopping anything.
 func (bps *Pipelines) ShutdownAll(ctx context.Context) error {
+	return bps.shutdownAll(ctx, nil)
+}
+
+// DrainAndShutdownAll stops all pipelines like ShutdownAll, but flushes the queues of the exporters once the
+// receivers, processors and connectors are stopped, before stopping the exporters. The flush is bounded by
+// flushTimeout; the data left in the queues when it expires is handled by the shutdown of the exporters.
+func (bps *Pipelines) DrainAndShutdownAll(ctx context.Context, flushTimeout time.Duration) error {
+	return bps.shutdownAll(ctx, func() {
+		flushCtx, cancel := context.WithTimeout(ctx, flushTimeout)
+		defer cancel()
+		bps.flushExporters(flushCtx)
+	})
+}
+
+// flushExporters waits until the exporters implementing Flush, such as the ones with a sending queue, have sent
+// their queued data.
+func (bps *Pipelines) flushExporters(ctx context.Context) {
+	bps.telemetry.Logger.Info("Flushing exporters...")
+	for _, dt := range sortedDataTypes(bps.allExporters) {
+		expByID := bps.allExporters[dt]
+		for _, expID := range sortedIDs(expByID) {
+			f, ok := expByID[expID].(interface{ Flush(context.Context) error })
+			if !ok {
+				continue
+			}
+			if err := f.Flush(ctx); err != nil {
+				bps.telemetry.Logger.Warn("Failed to flush exporter",
+					zap.String(components.ZapKindKey, components.ZapKindExporter),
+					zap.String(components.ZapNameKey, expID.String()),
+					zap.String(components.ZapDataTypeKey, string(dt)),
+					zap.Error(err))
+			}
+		}
+	}
+}
+
+// WaitForExporters waits until the exporters implementing component.ConnectionWaiter are connected to their
+// destination, or until ctx is done. It returns an error listing the exporters that are not connected.
+func (bps *Pipelines) WaitForExporters(ctx context.Context) error {
+	bps.telemetry.Logger.Info("Waiting for exporters to connect...")
 	var errs error
+	for _, dt := range sortedDataTypes(bps.allExporters) {
+		expByID := bps.allExporters[dt]
+		for _, expID := range sortedIDs(expByID) {
+			cw, ok := expByID[expID].(component.ConnectionWaiter)
+			if !ok {
+				continue
+			}
+			if err := cw.WaitForConnection(ctx); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("exporter %q for %s is not connected: %w", expID, dt, err))
+			}
+		}
+	}
+	return errs
+}
+
+// shutdownAll stops all pipelines, calling beforeExporters, if not nil, before stopping the exporters.
+func (bps *Pipelines) shutdownAll(ctx context.Context, beforeExporters func()) error {
+	errs := bps.waitForStarts(ctx)
+	shutdowner := components.NewShutdowner(bps.shutdownTimeout, bps.shutdownDumpGoroutines)
 	bps.telemetry.Logger.Info("Stopping receivers...")
-	for _, recvByID := range bps.allReceivers {
-		for _, recv := range recvByID {
-			errs = multierr.Append(errs, recv.Shutdown(ctx))
+	for _, dt := range sortedDataTypes(bps.allReceivers) {
+		recvByID := bps.allReceivers[dt]
+		for _, recvID := range sortedIDs(recvByID) {
+			errs = multierr.Append(errs, bps.shutdownComponent(ctx, shutdowner, recvByID[recvID], bps.receiverInstances[dt][recvID]))
 		}
 	}
 
-	bps.telemetry.Logger.Info("Stopping processors...")
+	bps.telemetry.Logger.Info("Stopping processors and connectors...")
+	stopped := make(map[*component.InstanceID]bool)
+	refs := make(processorRefs)
 	for _, bp := range bps.pipelines {
 		for _, p := range bp.processors {
-			errs = multierr.Append(errs, p.comp.Shutdown(ctx))
+			refs.acquire(p.instanceID)
+		}
+	}
+	for i := len(bps.pipelineOrder) - 1; i >= 0; i-- {
+		bp := bps.pipelines[bps.pipelineOrder[i]]
+		for _, r := range bp.receivers {
+			if r.instanceID.Kind != component.KindConnector || stopped[r.instanceID] {
+				continue
+			}
+			stopped[r.instanceID] = true
+			errs = multierr.Append(errs, bps.shutdownComponent(ctx, shutdowner, r.comp, r.instanceID))
+		}
+		for _, p := range bp.processors {
+			// A processor shared with other pipelines is stopped with the last of them, once nothing sends to it.
+			if !refs.release(p.instanceID) {
+				continue
+			}
+			errs = multierr.Append(errs, bps.shutdownComponent(ctx, shutdowner, p.comp, p.instanceID))
 		}
 	}
 
+	if beforeExporters != nil {
+		beforeExporters()
+	}
+
 	bps.telemetry.Logger.Info("Stopping exporters...")
-	for _, expByID := range bps.allExporters {
-		for _, exp := range expByID {
-			errs = multierr.Append(errs, exp.Shutdown(ctx))
+	for _, dt := range sortedDataTypes(bps.allExporters) {
+		expByID := bps.allExporters[dt]
+		for _, expID := range sortedIDs(expByID) {
+			errs = multierr.Append(errs, bps.shutdownComponent(ctx, shutdowner, expByID[expID], bps.exporterInstances[dt][expID]))
 		}
 	}
 
 	return errs
 }
 
+func (bps *Pipelines) sortedPipelineIDs() []component.ID {
+	ids := make([]component.ID, 0, len(bps.pipelines))
+	for id := range bps.pipelines {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+	return ids
+}
+
+// processorGroups returns the IDs of the pipelines, grouped with the pipelines they share processors with.
+// Since a processor is only shared along with the processors that follow it, every processor instance
+// belongs to a single group.
+func (bps *Pipelines) processorGroups() [][]component.ID {
+	groupOf := make(map[*component.InstanceID]int)
+	var groups [][]component.ID
+	for _, pipelineID := range bps.sortedPipelineIDs() {
+		bp := bps.pipelines[pipelineID]
+		group := len(groups)
+		for _, p := range bp.processors {
+			if g, ok := groupOf[p.instanceID]; ok {
+				group = g
+				break
+			}
+		}
+		if group == len(groups) {
+			groups = append(groups, nil)
+		}
+		groups[group] = append(groups[group], pipelineID)
+		for _, p := range bp.processors {
+			groupOf[p.instanceID] = group
+		}
+	}
+	return groups
+}
+
+// processorRefs counts the references of the pipelines to the processors they share.
+type processorRefs map[*component.InstanceID]int
+
+// acquire adds a reference to the processor, and returns true if it is the first one.
+func (r processorRefs) acquire(instanceID *component.InstanceID) bool {
+	r[instanceID]++
+	return r[instanceID] == 1
+}
+
+// release removes a reference to the processor, and returns true if it was the last one.
+func (r processorRefs) release(instanceID *component.InstanceID) bool {
+	r[instanceID]--
+	return r[instanceID] == 0
+}
+
+// checkAcyclic checks that the components of the pipelines, linked through the shared processors and the
+// connectors, form a directed acyclic graph, so that no data can be sent back to a component it went through.
+func (bps *Pipelines) checkAcyclic() error {
+	next := make(map[*component.InstanceID][]*component.InstanceID)
+	for _, pipelineID := range bps.sortedPipelineIDs() {
+		bp := bps.pipelines[pipelineID]
+		var from []*component.InstanceID
+		for _, r := range bp.receivers {
+			from = append(from, r.instanceID)
+		}
+		for _, p := range bp.processors {
+			for _, f := range from {
+				next[f] = append(next[f], p.instanceID)
+			}
+			from = []*component.InstanceID{p.instanceID}
+		}
+		for _, e := range bp.exporters {
+			for _, f := range from {
+				next[f] = append(next[f], e.instanceID)
+			}
+		}
+	}
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[*component.InstanceID]int, len(next))
+	var visit func(instanceID *component.InstanceID) error
+	visit = func(instanceID *component.InstanceID) error {
+		state[instanceID] = visiting
+		for _, nextID := range next[instanceID] {
+			switch state[nextID] {
+			case visiting:
+				return fmt.Errorf("cycle detected: %q sends data back to %q", instanceID.ID, nextID.ID)
+			case visited:
+				continue
+			}
+			if err := visit(nextID); err != nil {
+				return err
+			}
+		}
+		state[instanceID] = visited
+		return nil
+	}
+	for _, pipelineID := range bps.sortedPipelineIDs() {
+		for _, r := range bps.pipelines[pipelineID].receivers {
+			if state[r.instanceID] == visited {
+				continue
+			}
+			if err := visit(r.instanceID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (bps *Pipelines) sortedConnectorKeys() []connectorKey {
+	keys := make([]connectorKey, 0, len(bps.allConnectors))
+	for key := range bps.allConnectors {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].id != keys[j].id {
+			return keys[i].id.String() < keys[j].id.String()
+		}
+		if keys[i].expType != keys[j].expType {
+			return keys[i].expType < keys[j].expType
+		}
+		return keys[i].rcvType < keys[j].rcvType
+	})
+	return keys
+}
+
+func sortedDataTypes(m map[component.DataType]map[component.ID]component.Component) []component.DataType {
+	dts := make([]component.DataType, 0, len(m))
+	for dt := range m {
+		dts = append(dts, dt)
+	}
+	sort.Slice(dts, func(i, j int) bool {
+		return dts[i] < dts[j]
+	})
+	return dts
+}
+
+func sortedIDs(m map[component.ID]component.Component) []component.ID {
+	ids := make([]component.ID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+	return ids
+}
+
+// startComponent starts comp, reporting its status changes on behalf of it.
+func (bps *Pipelines) startComponent(ctx context.Context, host component.Host, logger *zap.Logger, comp component.Component, instanceID *component.InstanceID) error {
+	bps.reportStatus(instanceID, component.NewStatusEvent(component.StatusStarting))
+	if err := comp.Start(ctx, components.NewHostWrapper(host, logger)); err != nil {
+		bps.reportStatus(instanceID, component.NewPermanentErrorEvent(err))
+		return err
+	}
+	bps.reportStatus(instanceID, component.NewStatusEvent(component.StatusOK))
+	return nil
+}
+
+// shutdownComponent shuts down comp, reporting its status changes on behalf of it.
+// It gives up on comp when its timeout is exceeded, see components.Shutdowner.
+func (bps *Pipelines) shutdownComponent(ctx context.Context, shutdowner *components.Shutdowner, comp component.Component, instanceID *component.InstanceID) error {
+	bps.reportStatus(instanceID, component.NewStatusEvent(component.StatusStopping))
+	if err := shutdowner.Shutdown(ctx, comp, instanceLogger(bps.telemetry.Logger, instanceID)); err != nil {
+		bps.reportStatus(instanceID, component.NewPermanentErrorEvent(err))
+		return err
+	}
+	bps.reportStatus(instanceID, component.NewStatusEvent(component.StatusStopped))
+	return nil
+}
+
+// telemetryFor returns the TelemetrySettings of the component instance identified by instanceID.
+func (bps *Pipelines) telemetryFor(instanceID *component.InstanceID) component.TelemetrySettings {
+	set := bps.telemetry
+	set.ReportComponentStatus = func(ev *component.StatusEvent) {
+		bps.reportStatus(instanceID, ev)
+	}
+	return set
+}
+
 func (bps *Pipelines) GetExporters() map[component.DataType]map[component.ID]component.Component {
 	exportersMap := make(map[component.DataType]map[component.ID]component.Component)
 
@@ -201,25 +647,77 @@ type Settings struct {
 	// ExporterConfigs is a map of component.ID to component.Config.
 	ExporterConfigs map[component.ID]component.Config
 
+	// ConnectorFactories maps connector type names in the config to the respective component.ConnectorFactory.
+	ConnectorFactories map[component.Type]component.ConnectorFactory
+
+	// ConnectorConfigs is a map of component.ID to component.Config.
+	ConnectorConfigs map[component.ID]component.Config
+
 	// PipelineConfigs is a map of component.ID to config.Pipeline.
 	PipelineConfigs map[component.ID]*config.Pipeline
+
+	// ReportComponentStatus is called with every status change of the built components. Optional.
+	ReportComponentStatus func(*component.InstanceID, *component.StatusEvent)
+
+	// StartParallel starts the independent components concurrently, see Pipelines.StartAll.
+	StartParallel bool
+
+	// StartTimeout limits the time to start all the components. Zero means no limit.
+	StartTimeout time.Duration
+
+	// ShutdownTimeout limits the time given to each component to shut down. Zero means no limit.
+	ShutdownTimeout time.Duration
+
+	// ShutdownDumpGoroutines logs the stacks of all the goroutines when a component does not shut down
+	// in time, once per call to ShutdownAll.
+	ShutdownDumpGoroutines bool
 }
 
 // Build builds all pipelines from config.
 func Build(ctx context.Context, set Settings) (*Pipelines, error) {
+	if err := validateReceiverSignals(set); err != nil {
+		return nil, err
+	}
+	connTypes, err := buildConnectorDataTypes(set)
+	if err != nil {
+		return nil, err
+	}
+	pipelineOrder, err := sortPipelines(set, connTypes)
+	if err != nil {
+		return nil, err
+	}
+
 	exps := &Pipelines{
-		telemetry:    set.Telemetry,
-		allReceivers: make(map[component.DataType]map[component.ID]component.Component),
-		allExporters: make(map[component.DataType]map[component.ID]component.Component),
-		pipelines:    make(map[component.ID]*builtPipeline, len(set.PipelineConfigs)),
+		telemetry:     set.Telemetry,
+		allReceivers:  make(map[component.DataType]map[component.ID]component.Component),
+		allExporters:  make(map[component.DataType]map[component.ID]component.Component),
+		allConnectors: make(map[connectorKey]component.Component),
+		pipelines:     make(map[component.ID]*builtPipeline, len(set.PipelineConfigs)),
+		pipelineOrder: pipelineOrder,
+		reportStatus:  set.ReportComponentStatus,
+
+		startParallel: set.StartParallel,
+		startTimeout:  set.StartTimeout,
+
+		shutdownTimeout:        set.ShutdownTimeout,
+		shutdownDumpGoroutines: set.ShutdownDumpGoroutines,
 	}
+	if exps.reportStatus == nil {
+		exps.reportStatus = func(*component.InstanceID, *component.StatusEvent) {}
+	}
+	exps.receiverInstances, exps.exporterInstances = buildInstanceIDs(set.PipelineConfigs, set.ConnectorConfigs)
+	exps.connectorInstances = buildConnectorInstanceIDs(set.PipelineConfigs, connTypes)
 
 	receiversConsumers := make(map[component.DataType]map[component.ID][]baseConsumer)
+	procByKey := make(map[string]builtComponent)
+	shareProcessors := featuregate.GetRegistry().IsEnabled(sharedProcessorsFeatureGateID)
 
 	// Iterate over all pipelines, and create exporters, then processors.
 	// Receivers cannot be created since we need to know all consumers, a.k.a. we need all pipelines build up to the
-	// first processor.
-	for pipelineID, pipeline := range set.PipelineConfigs {
+	// first processor. For the same reason, the pipelines that a connector sends data to are built before the
+	// pipelines that use the connector as an exporter.
+	for _, pipelineID := range exps.pipelineOrder {
+		pipeline := set.PipelineConfigs[pipelineID]
 		// The data type of the pipeline defines what data type each exporter is expected to receive.
 		if _, ok := exps.allExporters[pipelineID.Type()]; !ok {
 			exps.allExporters[pipelineID.Type()] = make(map[component.ID]component.Component)
@@ -227,37 +725,63 @@ func Build(ctx context.Context, set Settings) (*Pipelines, error) {
 		expByID := exps.allExporters[pipelineID.Type()]
 
 		bp := &builtPipeline{
-			receivers:  make([]builtComponent, len(pipeline.Receivers)),
+			receivers:  make([]builtComponent, 0, len(pipeline.Receivers)),
 			processors: make([]builtComponent, len(pipeline.Processors)),
-			exporters:  make([]builtComponent, len(pipeline.Exporters)),
+			exporters:  make([]builtComponent, 0, len(pipeline.Exporters)),
+			budget:     buildPipelineBudget(pipelineID, pipeline, set),
 		}
 		exps.pipelines[pipelineID] = bp
 
 		// Iterate over all Exporters for this pipeline.
-		for i, expID := range pipeline.Exporters {
+		for _, expID := range pipeline.Exporters {
+			if _, isConn := set.ConnectorConfigs[expID]; isConn {
+				// A connector is created for every data type of the pipelines it sends data to.
+				for _, rcvType := range connTypes[expID][pipelineID.Type()] {
+					key := connectorKey{id: expID, expType: pipelineID.Type(), rcvType: rcvType}
+					instanceID := exps.connectorInstances[key]
+					if conn, ok := exps.allConnectors[key]; ok {
+						mutatesData := connectorMutatesData(conn, key, receiversConsumers[rcvType][expID])
+						bp.exporters = append(bp.exporters, builtComponent{id: expID, comp: conn, instanceID: instanceID, mutatesData: mutatesData})
+						continue
+					}
+
+					conn, err := buildConnector(ctx, exps.telemetryFor(instanceID), set.BuildInfo, set.ConnectorConfigs, set.ConnectorFactories, key, receiversConsumers[rcvType][expID])
+					if err != nil {
+						return nil, err
+					}
+
+					mutatesData := connectorMutatesData(conn, key, receiversConsumers[rcvType][expID])
+					bp.exporters = append(bp.exporters, builtComponent{id: expID, comp: conn, instanceID: instanceID, mutatesData: mutatesData})
+					exps.allConnectors[key] = conn
+				}
+				continue
+			}
+
 			// If already created an exporter for this [DataType, ComponentID] nothing to do, will reuse this instance.
+			instanceID := exps.exporterInstances[pipelineID.Type()][expID]
 			if exp, ok := expByID[expID]; ok {
-				bp.exporters[i] = builtComponent{id: expID, comp: exp}
+				bp.exporters = append(bp.exporters, builtComponent{id: expID, comp: exp, instanceID: instanceID})
 				continue
 			}
 
-			exp, err := buildExporter(ctx, set.Telemetry, set.BuildInfo, set.ExporterConfigs, set.ExporterFactories, expID, pipelineID)
+			exp, err := buildExporter(ctx, exps.telemetryFor(instanceID), set.BuildInfo, set.ExporterConfigs, set.ExporterFactories, expID, pipelineID)
 			if err != nil {
 				return nil, err
 			}
 
-			bp.exporters[i] = builtComponent{id: expID, comp: exp}
+			bp.exporters = append(bp.exporters, builtComponent{id: expID, comp: exp, instanceID: instanceID})
 			expByID[expID] = exp
 		}
 
 		// Build a fan out consumer to all exporters.
+		policy := buildFailurePolicy(set.Telemetry.Logger, pipelineID, pipeline, bp.exporters)
 		switch pipelineID.Type() {
 		case component.DataTypeTraces:
-			bp.lastConsumer = buildFanOutExportersTracesConsumer(bp.exporters)
+			bp.lastConsumer = buildFanOutExportersTracesConsumer(bp.exporters, policy)
 		case component.DataTypeMetrics:
-			bp.lastConsumer = buildFanOutExportersMetricsConsumer(bp.exporters)
+			bp.lastConsumer = buildFanOutExportersMetricsConsumer(bp.exporters, policy)
 		case component.DataTypeLogs:
-			bp.lastConsumer = buildFanOutExportersLogsConsumer(bp.exporters)
+			bp.lastConsumer = buildFanOutExportersLogsConsumer(bp.exporters, policy)
 		default:
 			return nil, fmt.Errorf("create fan-out exporter in pipeline %q, data type %q is not supported", pipelineID, pipelineID.Type())
 		}
@@ -266,15 +790,31 @@ func Build(ctx context.Context, set Settings) (*Pipelines, error) {
 		// Build the processors backwards, starting from the last one.
 		// The last processor points to fan out consumer to all Exporters, then the processor itself becomes a
 		// consumer for the one that precedes it in the pipeline and so on.
+		// With the service.sharedProcessors feature gate, a processor is shared with the pipelines of the same data
+		// type that send the data it processes through the same processors to the same exporters, so that it is
+		// instantiated once for all of them.
 		for i := len(pipeline.Processors) - 1; i >= 0; i-- {
 			procID := pipeline.Processors[i]
 
-			proc, err := buildProcessor(ctx, set.Telemetry, set.BuildInfo, set.ProcessorConfigs, set.ProcessorFactories, procID, pipelineID, bp.lastConsumer)
+			key := processorKey(pipelineID, pipeline, i)
+			if built, ok := procByKey[key]; ok {
+				built.instanceID.PipelineIDs = append(built.instanceID.PipelineIDs, pipelineID)
+				bp.processors[i] = built
+				bp.lastConsumer = built.comp.(baseConsumer)
+				mutatesConsumedData = mutatesConsumedData || bp.lastConsumer.Capabilities().MutatesData
+				continue
+			}
+
+			instanceID := &component.InstanceID{ID: procID, Kind: component.KindProcessor, PipelineIDs: []component.ID{pipelineID}}
+			proc, err := buildProcessor(ctx, exps.telemetryFor(instanceID), set.BuildInfo, set.ProcessorConfigs, set.ProcessorFactories, procID, pipelineID, bp.lastConsumer)
 			if err != nil {
 				return nil, err
 			}
 
-			bp.processors[i] = builtComponent{id: procID, comp: proc}
+			bp.processors[i] = builtComponent{id: procID, comp: proc, instanceID: instanceID}
+			if shareProcessors {
+				procByKey[key] = bp.processors[i]
+			}
 			bp.lastConsumer = proc.(baseConsumer)
 			mutatesConsumedData = mutatesConsumedData || bp.lastConsumer.Capabilities().MutatesData
 		}
@@ -313,25 +853,120 @@ func Build(ctx context.Context, set Settings) (*Pipelines, error) {
 		bp := exps.pipelines[pipelineID]
 
 		// Iterate over all Receivers for this pipeline.
-		for i, recvID := range pipeline.Receivers {
+		for _, recvID := range pipeline.Receivers {
+			if _, isConn := set.ConnectorConfigs[recvID]; isConn {
+				// The connectors were created with the pipelines that send data to them.
+				for _, key := range exps.sortedConnectorKeys() {
+					if key.id == recvID && key.rcvType == pipelineID.Type() {
+						bp.receivers = append(bp.receivers, builtComponent{id: recvID, comp: exps.allConnectors[key], instanceID: exps.connectorInstances[key]})
+					}
+				}
+				continue
+			}
+
 			// If already created a receiver for this [DataType, ComponentID] nothing to do.
+			instanceID := exps.receiverInstances[pipelineID.Type()][recvID]
 			if exp, ok := recvByID[recvID]; ok {
-				bp.receivers[i] = builtComponent{id: recvID, comp: exp}
+				bp.receivers = append(bp.receivers, builtComponent{id: recvID, comp: exp, instanceID: instanceID})
 				continue
 			}
 
-			recv, err := buildReceiver(ctx, set.Telemetry, set.BuildInfo, set.ReceiverConfigs, set.ReceiverFactories, recvID, pipelineID, receiversConsumers[pipelineID.Type()][recvID])
+			recv, err := buildReceiver(ctx, exps.telemetryFor(instanceID), set.BuildInfo, set.ReceiverConfigs, set.ReceiverFactories, recvID, pipelineID, receiversConsumers[pipelineID.Type()][recvID])
 			if err != nil {
 				return nil, err
 			}
 
-			bp.receivers[i] = builtComponent{id: recvID, comp: recv}
+			bp.receivers = append(bp.receivers, builtComponent{id: recvID, comp: recv, instanceID: instanceID})
 			recvByID[recvID] = recv
 		}
 	}
+	if err := exps.checkAcyclic(); err != nil {
+		return nil, err
+	}
+	exps.logBudgets()
 	return exps, nil
 }
 
+// validateReceiverSignals checks, before any component is created, that every receiver supports the data type
+// of all the pipelines that reference it. A receiver is only instantiated for the data types of these pipelines,
+// so that a receiver supporting more signals can be used in pipelines of a subset of them.
+func validateReceiverSignals(set Settings) error {
+	for _, pipelineID := range sortedPipelineConfigIDs(set.PipelineConfigs) {
+		for _, recvID := range set.PipelineConfigs[pipelineID].Receivers {
+			if _, isConn := set.ConnectorConfigs[recvID]; isConn {
+				// Checked by buildConnectorDataTypes.
+				continue
+			}
+			factory, ok := set.ReceiverFactories[recvID.Type()]
+			if !ok {
+				// Reported when building the receiver.
+				continue
+			}
+			if !component.ReceiverSupportsDataType(factory, pipelineID.Type()) {
+				return fmt.Errorf("pipeline %q references receiver %q which does not support %s", pipelineID, recvID, pipelineID.Type())
+			}
+		}
+	}
+	return nil
+}
+
+func containsDataType(dataTypes []component.DataType, dt component.DataType) bool {
+	for _, t := range dataTypes {
+		if t == dt {
+			return true
+		}
+	}
+	return false
+}
+
+// buildInstanceIDs returns the InstanceID of every receiver and exporter, which are shared by all the pipelines
+// of the same data type that use them. Connectors are skipped, see buildConnectorInstanceIDs.
+func buildInstanceIDs(pipelineCfgs map[component.ID]*config.Pipeline, connectorCfgs map[component.ID]component.Config) (
+	receivers map[component.DataType]map[component.ID]*component.InstanceID,
+	exporters map[component.DataType]map[component.ID]*component.InstanceID,
+) {
+	receivers = make(map[component.DataType]map[component.ID]*component.InstanceID)
+	exporters = make(map[component.DataType]map[component.ID]*component.InstanceID)
+	add := func(instances map[component.DataType]map[component.ID]*component.InstanceID, kind component.Kind, id component.ID, pipelineID component.ID) {
+		if _, ok := instances[pipelineID.Type()]; !ok {
+			instances[pipelineID.Type()] = make(map[component.ID]*component.InstanceID)
+		}
+		instanceID, ok := instances[pipelineID.Type()][id]
+		if !ok {
+			instanceID = &component.InstanceID{ID: id, Kind: kind}
+			instances[pipelineID.Type()][id] = instanceID
+		}
+		instanceID.PipelineIDs = append(instanceID.PipelineIDs, pipelineID)
+	}
+
+	// Sort the pipelines so that PipelineIDs are deterministic.
+	for _, pipelineID := range sortedPipelineConfigIDs(pipelineCfgs) {
+		for _, recvID := range pipelineCfgs[pipelineID].Receivers {
+			if _, isConn := connectorCfgs[recvID]; !isConn {
+				add(receivers, component.KindReceiver, recvID, pipelineID)
+			}
+		}
+		for _, expID := range pipelineCfgs[pipelineID].Exporters {
+			if _, isConn := connectorCfgs[expID]; !isConn {
+				add(exporters, component.KindExporter, expID, pipelineID)
+			}
+		}
+	}
+	return receivers, exporters
+}
+
+// sortedPipelineConfigIDs returns the IDs of the pipelines, sorted so that the results derived from them are deterministic.
+func sortedPipelineConfigIDs(pipelineCfgs map[component.ID]*config.Pipeline) []component.ID {
+	pipelineIDs := make([]component.ID, 0, len(pipelineCfgs))
+	for pipelineID := range pipelineCfgs {
+		pipelineIDs = append(pipelineIDs, pipelineID)
+	}
+	sort.Slice(pipelineIDs, func(i, j int) bool {
+		return pipelineIDs[i].String() < pipelineIDs[j].String()
+	})
+	return pipelineIDs
+}
+
 func buildExporter(
 	ctx context.Context,
 	settings component.TelemetrySettings,
@@ -357,7 +992,7 @@ func buildExporter(
 		BuildInfo:         buildInfo,
 	}
 	set.TelemetrySettings.Logger = exporterLogger(settings.Logger, id, pipelineID.Type())
-	components.LogStabilityLevel(set.TelemetrySettings.Logger, getExporterStabilityLevel(factory, pipelineID.Type()))
+	components.LogStabilityLevel(set.TelemetrySettings.Logger, components.ExporterStability(factory, pipelineID.Type()))
 
 	exp, err := createExporter(ctx, set, cfg, id, pipelineID, factory)
 	if err != nil {
@@ -381,31 +1016,63 @@ func createExporter(ctx context.Context, set component.ExporterCreateSettings, c
 	return nil, fmt.Errorf("error creating exporter %q in pipeline %q, data type %q is not supported", id, pipelineID, pipelineID.Type())
 }
 
-func buildFanOutExportersTracesConsumer(exporters []builtComponent) consumer.Traces {
+func buildFanOutExportersTracesConsumer(exporters []builtComponent, policy fanoutconsumer.Policy) consumer.Traces {
 	consumers := make([]consumer.Traces, 0, len(exporters))
 	for _, exp := range exporters {
-		consumers = append(consumers, exp.comp.(consumer.Traces))
+		next := exp.comp.(consumer.Traces)
+		if exp.mutatesData && !next.Capabilities().MutatesData {
+			next = capabilityconsumer.NewTraces(next, consumer.Capabilities{MutatesData: true})
+		}
+		consumers = append(consumers, next)
 	}
 	// Create a junction point that fans out to all allExporters.
-	return fanoutconsumer.NewTraces(consumers)
+	return fanoutconsumer.NewTracesWithPolicy(consumers, policy)
 }
 
-func buildFanOutExportersMetricsConsumer(exporters []builtComponent) consumer.Metrics {
+func buildFanOutExportersMetricsConsumer(exporters []builtComponent, policy fanoutconsumer.Policy) consumer.Metrics {
 	consumers := make([]consumer.Metrics, 0, len(exporters))
 	for _, exp := range exporters {
-		consumers = append(consumers, exp.comp.(consumer.Metrics))
+		next := exp.comp.(consumer.Metrics)
+		if exp.mutatesData && !next.Capabilities().MutatesData {
+			next = capabilityconsumer.NewMetrics(next, consumer.Capabilities{MutatesData: true})
+		}
+		consumers = append(consumers, next)
 	}
 	// Create a junction point that fans out to all allExporters.
-	return fanoutconsumer.NewMetrics(consumers)
+	return fanoutconsumer.NewMetricsWithPolicy(consumers, policy)
 }
 
-func buildFanOutExportersLogsConsumer(exporters []builtComponent) consumer.Logs {
+func buildFanOutExportersLogsConsumer(exporters []builtComponent, policy fanoutconsumer.Policy) consumer.Logs {
 	consumers := make([]consumer.Logs, 0, len(exporters))
 	for _, exp := range exporters {
-		consumers = append(consumers, exp.comp.(consumer.Logs))
+		next := exp.comp.(consumer.Logs)
+		if exp.mutatesData && !next.Capabilities().MutatesData {
+			next = capabilityconsumer.NewLogs(next, consumer.Capabilities{MutatesData: true})
+		}
+		consumers = append(consumers, next)
 	}
 	// Create a junction point that fans out to all allExporters.
-	return fanoutconsumer.NewLogs(consumers)
+	return fanoutconsumer.NewLogsWithPolicy(consumers, policy)
+}
+
+// instanceLogger returns a logger annotated with the kind and the name of the component instance identified by instanceID.
+func instanceLogger(logger *zap.Logger, instanceID *component.InstanceID) *zap.Logger {
+	kind := ""
+	switch instanceID.Kind {
+	case component.KindReceiver:
+		kind = components.ZapKindReceiver
+	case component.KindProcessor:
+		kind = components.ZapKindProcessor
+	case component.KindExporter:
+		kind = components.ZapKindExporter
+	case component.KindExtension:
+		kind = components.ZapKindExtension
+	case component.KindConnector:
+		kind = components.ZapKindConnector
+	}
+	return logger.With(
+		zap.String(components.ZapKindKey, kind),
+		zap.String(components.ZapNameKey, instanceID.ID.String()))
 }
 
 func exporterLogger(logger *zap.Logger, id component.ID, dt component.DataType) *zap.Logger {
@@ -415,16 +1082,36 @@ func exporterLogger(logger *zap.Logger, id component.ID, dt component.DataType)
 		zap.String(components.ZapNameKey, id.String()))
 }
 
-func getExporterStabilityLevel(factory component.ExporterFactory, dt component.DataType) component.StabilityLevel {
-	switch dt {
-	case component.DataTypeTraces:
-		return factory.TracesExporterStability()
-	case component.DataTypeMetrics:
-		return factory.MetricsExporterStability()
-	case component.DataTypeLogs:
-		return factory.LogsExporterStability()
+// processorKey identifies the processor at index i of a pipeline by what happens to the data it processes: the
+// data type of the pipeline, the processor, the processors that follow it and the exporters of the pipeline.
+// Pipelines with the same key for a processor share its instance.
+func processorKey(pipelineID component.ID, pipeline *config.Pipeline, i int) string {
+	var b strings.Builder
+	b.WriteString(string(pipelineID.Type()))
+	for _, procID := range pipeline.Processors[i:] {
+		b.WriteString("|")
+		b.WriteString(procID.String())
+	}
+	expIDs := make([]string, 0, len(pipeline.Exporters))
+	for _, expID := range pipeline.Exporters {
+		expIDs = append(expIDs, expID.String())
 	}
-	return component.StabilityLevelUndefined
+	sort.Strings(expIDs)
+	b.WriteString("->")
+	b.WriteString(strings.Join(expIDs, ","))
+	// Pipelines with different failure policies have different fan-out consumers after the processors.
+	if policy := pipeline.FailurePolicy; policy.Mode == config.PipelineFailureModeAny || len(policy.IgnoreErrorsFor) > 0 {
+		ignored := make([]string, 0, len(policy.IgnoreErrorsFor))
+		for _, expID := range policy.IgnoreErrorsFor {
+			ignored = append(ignored, expID.String())
+		}
+		sort.Strings(ignored)
+		b.WriteString("|any=")
+		b.WriteString(strconv.FormatBool(policy.Mode == config.PipelineFailureModeAny))
+		b.WriteString("|ignore=")
+		b.WriteString(strings.Join(ignored, ","))
+	}
+	return b.String()
 }
 
 func buildProcessor(ctx context.Context,
@@ -452,7 +1139,7 @@ func buildProcessor(ctx context.Context,
 		BuildInfo:         buildInfo,
 	}
 	set.TelemetrySettings.Logger = processorLogger(settings.Logger, id, pipelineID)
-	components.LogStabilityLevel(set.TelemetrySettings.Logger, getProcessorStabilityLevel(factory, pipelineID.Type()))
+	components.LogStabilityLevel(set.TelemetrySettings.Logger, components.ProcessorStability(factory, pipelineID.Type()))
 
 	proc, err := createProcessor(ctx, set, procCfg, id, pipelineID, next, factory)
 	if err != nil {
@@ -482,18 +1169,6 @@ func processorLogger(logger *zap.Logger, procID component.ID, pipelineID compone
 		zap.String(components.ZapKindPipeline, pipelineID.String()))
 }
 
-func getProcessorStabilityLevel(factory component.ProcessorFactory, dt component.DataType) component.StabilityLevel {
-	switch dt {
-	case component.DataTypeTraces:
-		return factory.TracesProcessorStability()
-	case component.DataTypeMetrics:
-		return factory.MetricsProcessorStability()
-	case component.DataTypeLogs:
-		return factory.LogsProcessorStability()
-	}
-	return component.StabilityLevelUndefined
-}
-
 func buildReceiver(ctx context.Context,
 	settings component.TelemetrySettings,
 	buildInfo component.BuildInfo,
@@ -519,7 +1194,7 @@ func buildReceiver(ctx context.Context,
 		BuildInfo:         buildInfo,
 	}
 	set.TelemetrySettings.Logger = receiverLogger(settings.Logger, id, pipelineID.Type())
-	components.LogStabilityLevel(set.TelemetrySettings.Logger, getReceiverStabilityLevel(factory, pipelineID.Type()))
+	components.LogStabilityLevel(set.TelemetrySettings.Logger, components.ReceiverStability(factory, pipelineID.Type()))
 
 	recv, err := createReceiver(ctx, set, cfg, id, pipelineID, nexts, factory)
 	if err != nil {
@@ -532,23 +1207,11 @@ func buildReceiver(ctx context.Context,
 func createReceiver(ctx context.Context, set component.ReceiverCreateSettings, cfg component.Config, id component.ID, pipelineID component.ID, nexts []baseConsumer, factory component.ReceiverFactory) (component.Component, error) {
 	switch pipelineID.Type() {
 	case component.DataTypeTraces:
-		var consumers []consumer.Traces
-		for _, next := range nexts {
-			consumers = append(consumers, next.(consumer.Traces))
-		}
-		return factory.CreateTracesReceiver(ctx, set, cfg, fanoutconsumer.NewTraces(consumers))
+		return factory.CreateTracesReceiver(ctx, set, cfg, buildFanOutTracesConsumer(nexts))
 	case component.DataTypeMetrics:
-		var consumers []consumer.Metrics
-		for _, next := range nexts {
-			consumers = append(consumers, next.(consumer.Metrics))
-		}
-		return factory.CreateMetricsReceiver(ctx, set, cfg, fanoutconsumer.NewMetrics(consumers))
+		return factory.CreateMetricsReceiver(ctx, set, cfg, buildFanOutMetricsConsumer(nexts))
 	case component.DataTypeLogs:
-		var consumers []consumer.Logs
-		for _, next := range nexts {
-			consumers = append(consumers, next.(consumer.Logs))
-		}
-		return factory.CreateLogsReceiver(ctx, set, cfg, fanoutconsumer.NewLogs(consumers))
+		return factory.CreateLogsReceiver(ctx, set, cfg, buildFanOutLogsConsumer(nexts))
 	}
 	return nil, fmt.Errorf("error creating receiver %q in pipeline %q, data type %q is not supported", id, pipelineID, pipelineID.Type())
 }
@@ -560,16 +1223,272 @@ func receiverLogger(logger *zap.Logger, id component.ID, dt component.DataType)
 		zap.String(components.ZapKindPipeline, string(dt)))
 }
 
-func getReceiverStabilityLevel(factory component.ReceiverFactory, dt component.DataType) component.StabilityLevel {
-	switch dt {
+// connectorDataTypes are the data types of the pipelines, in the order connectors are created for them.
+var connectorDataTypes = []component.DataType{component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs}
+
+// buildConnectorDataTypes returns, for every connector and every data type of the pipelines that use it as an
+// exporter, the data types of the pipelines that use it as a receiver which the connector supports.
+// Every use of a connector must be part of at least one supported pair of data types.
+func buildConnectorDataTypes(set Settings) (map[component.ID]map[component.DataType][]component.DataType, error) {
+	// For every connector and data type, the first pipeline that uses it, to report errors.
+	expPipelines := make(map[component.ID]map[component.DataType]component.ID)
+	rcvPipelines := make(map[component.ID]map[component.DataType]component.ID)
+	add := func(pipelines map[component.ID]map[component.DataType]component.ID, connID component.ID, pipelineID component.ID) {
+		if _, ok := pipelines[connID]; !ok {
+			pipelines[connID] = make(map[component.DataType]component.ID)
+		}
+		if _, ok := pipelines[connID][pipelineID.Type()]; !ok {
+			pipelines[connID][pipelineID.Type()] = pipelineID
+		}
+	}
+	for _, pipelineID := range sortedPipelineConfigIDs(set.PipelineConfigs) {
+		for _, expID := range set.PipelineConfigs[pipelineID].Exporters {
+			if _, isConn := set.ConnectorConfigs[expID]; isConn {
+				add(expPipelines, expID, pipelineID)
+			}
+		}
+		for _, recvID := range set.PipelineConfigs[pipelineID].Receivers {
+			if _, isConn := set.ConnectorConfigs[recvID]; isConn {
+				add(rcvPipelines, recvID, pipelineID)
+			}
+		}
+	}
+
+	connTypes := make(map[component.ID]map[component.DataType][]component.DataType, len(expPipelines))
+	supported := make(map[component.ID]map[component.DataType]bool)
+	for connID, expByType := range expPipelines {
+		factory, ok := set.ConnectorFactories[connID.Type()]
+		if !ok {
+			return nil, fmt.Errorf("connector factory not available for: %q", connID)
+		}
+		connTypes[connID] = make(map[component.DataType][]component.DataType)
+		supported[connID] = make(map[component.DataType]bool)
+		for expType, pipelineID := range expByType {
+			for _, rcvType := range connectorDataTypes {
+				if _, ok := rcvPipelines[connID][rcvType]; !ok {
+					continue
+				}
+				if components.ConnectorStability(factory, expType, rcvType) == component.StabilityLevelUndefined {
+					continue
+				}
+				connTypes[connID][expType] = append(connTypes[connID][expType], rcvType)
+				supported[connID][rcvType] = true
+			}
+			if len(connTypes[connID][expType]) == 0 {
+				return nil, fmt.Errorf("connector %q used as exporter in pipeline %q cannot send %s to any pipeline it is used as receiver in", connID, pipelineID, expType)
+			}
+		}
+	}
+	for connID, rcvByType := range rcvPipelines {
+		for rcvType, pipelineID := range rcvByType {
+			if !supported[connID][rcvType] {
+				return nil, fmt.Errorf("connector %q used as receiver in pipeline %q cannot receive %s from any pipeline it is used as exporter in", connID, pipelineID, rcvType)
+			}
+		}
+	}
+	return connTypes, nil
+}
+
+// sortPipelines returns the pipelines ordered so that every pipeline comes after the pipelines it sends data to
+// through connectors. It returns an error if connectors create a cycle between pipelines.
+func sortPipelines(set Settings, connTypes map[component.ID]map[component.DataType][]component.DataType) ([]component.ID, error) {
+	pipelineIDs := sortedPipelineConfigIDs(set.PipelineConfigs)
+	receivingPipelines := make(map[component.ID][]component.ID)
+	for _, pipelineID := range pipelineIDs {
+		for _, recvID := range set.PipelineConfigs[pipelineID].Receivers {
+			if _, isConn := connTypes[recvID]; isConn {
+				receivingPipelines[recvID] = append(receivingPipelines[recvID], pipelineID)
+			}
+		}
+	}
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[component.ID]int, len(pipelineIDs))
+	order := make([]component.ID, 0, len(pipelineIDs))
+	var visit func(pipelineID component.ID) error
+	visit = func(pipelineID component.ID) error {
+		state[pipelineID] = visiting
+		for _, expID := range set.PipelineConfigs[pipelineID].Exporters {
+			rcvTypes := connTypes[expID][pipelineID.Type()]
+			for _, nextID := range receivingPipelines[expID] {
+				if !containsDataType(rcvTypes, nextID.Type()) {
+					continue
+				}
+				switch state[nextID] {
+				case visiting:
+					return fmt.Errorf("cycle detected: connector %q sends data from pipeline %q back to pipeline %q", expID, pipelineID, nextID)
+				case visited:
+					continue
+				}
+				if err := visit(nextID); err != nil {
+					return err
+				}
+			}
+		}
+		state[pipelineID] = visited
+		order = append(order, pipelineID)
+		return nil
+	}
+	for _, pipelineID := range pipelineIDs {
+		if state[pipelineID] == visited {
+			continue
+		}
+		if err := visit(pipelineID); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// buildConnectorInstanceIDs returns the InstanceID of every connector, which is shared by all the pipelines
+// that send data to it and that it sends data to.
+func buildConnectorInstanceIDs(pipelineCfgs map[component.ID]*config.Pipeline, connTypes map[component.ID]map[component.DataType][]component.DataType) map[connectorKey]*component.InstanceID {
+	instances := make(map[connectorKey]*component.InstanceID)
+	add := func(key connectorKey, pipelineID component.ID) {
+		instanceID, ok := instances[key]
+		if !ok {
+			instanceID = &component.InstanceID{ID: key.id, Kind: component.KindConnector}
+			instances[key] = instanceID
+		}
+		instanceID.PipelineIDs = append(instanceID.PipelineIDs, pipelineID)
+	}
+	for _, pipelineID := range sortedPipelineConfigIDs(pipelineCfgs) {
+		for _, recvID := range pipelineCfgs[pipelineID].Receivers {
+			for _, expType := range connectorDataTypes {
+				if containsDataType(connTypes[recvID][expType], pipelineID.Type()) {
+					add(connectorKey{id: recvID, expType: expType, rcvType: pipelineID.Type()}, pipelineID)
+				}
+			}
+		}
+		for _, expID := range pipelineCfgs[pipelineID].Exporters {
+			for _, rcvType := range connTypes[expID][pipelineID.Type()] {
+				add(connectorKey{id: expID, expType: pipelineID.Type(), rcvType: rcvType}, pipelineID)
+			}
+		}
+	}
+	return instances
+}
+
+func buildConnector(ctx context.Context,
+	settings component.TelemetrySettings,
+	buildInfo component.BuildInfo,
+	cfgs map[component.ID]component.Config,
+	factories map[component.Type]component.ConnectorFactory,
+	key connectorKey,
+	nexts []baseConsumer,
+) (component.Component, error) {
+	cfg, existsCfg := cfgs[key.id]
+	if !existsCfg {
+		return nil, fmt.Errorf("connector %q is not configured", key.id)
+	}
+
+	factory, existsFactory := factories[key.id.Type()]
+	if !existsFactory {
+		return nil, fmt.Errorf("connector factory not available for: %q", key.id)
+	}
+
+	set := component.ConnectorCreateSettings{
+		ID:                key.id,
+		TelemetrySettings: settings,
+		BuildInfo:         buildInfo,
+	}
+	set.TelemetrySettings.Logger = connectorLogger(settings.Logger, key)
+	components.LogStabilityLevel(set.TelemetrySettings.Logger, components.ConnectorStability(factory, key.expType, key.rcvType))
+
+	conn, err := createConnector(ctx, set, cfg, key, nexts, factory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %q connector, from %s to %s: %w", key.id, key.expType, key.rcvType, err)
+	}
+
+	return conn, nil
+}
+
+// connectorMutatesData returns whether the data consumed by a connector may be mutated. A connector sending the data
+// it consumes to pipelines of the same data type may not report that it mutates the data when these pipelines do,
+// so the capabilities of the fan-out to these pipelines are taken into account: it gives the original data to one
+// of them only when all of them mutate the data, otherwise the data is cloned for the mutating pipelines only,
+// and the connector can share the original data with the read-only exporters of the pipeline.
+func connectorMutatesData(conn component.Component, key connectorKey, nexts []baseConsumer) bool {
+	if conn.(baseConsumer).Capabilities().MutatesData {
+		return true
+	}
+	if key.expType != key.rcvType || len(nexts) == 0 {
+		return false
+	}
+	for _, next := range nexts {
+		if !next.Capabilities().MutatesData {
+			return false
+		}
+	}
+	return true
+}
+
+func createConnector(ctx context.Context, set component.ConnectorCreateSettings, cfg component.Config, key connectorKey, nexts []baseConsumer, factory component.ConnectorFactory) (component.Component, error) {
+	switch key.expType {
 	case component.DataTypeTraces:
-		return factory.TracesReceiverStability()
+		switch key.rcvType {
+		case component.DataTypeTraces:
+			return factory.CreateTracesToTraces(ctx, set, cfg, buildFanOutTracesConsumer(nexts))
+		case component.DataTypeMetrics:
+			return factory.CreateTracesToMetrics(ctx, set, cfg, buildFanOutMetricsConsumer(nexts))
+		case component.DataTypeLogs:
+			return factory.CreateTracesToLogs(ctx, set, cfg, buildFanOutLogsConsumer(nexts))
+		}
 	case component.DataTypeMetrics:
-		return factory.MetricsReceiverStability()
+		switch key.rcvType {
+		case component.DataTypeTraces:
+			return factory.CreateMetricsToTraces(ctx, set, cfg, buildFanOutTracesConsumer(nexts))
+		case component.DataTypeMetrics:
+			return factory.CreateMetricsToMetrics(ctx, set, cfg, buildFanOutMetricsConsumer(nexts))
+		case component.DataTypeLogs:
+			return factory.CreateMetricsToLogs(ctx, set, cfg, buildFanOutLogsConsumer(nexts))
+		}
 	case component.DataTypeLogs:
-		return factory.LogsReceiverStability()
+		switch key.rcvType {
+		case component.DataTypeTraces:
+			return factory.CreateLogsToTraces(ctx, set, cfg, buildFanOutTracesConsumer(nexts))
+		case component.DataTypeMetrics:
+			return factory.CreateLogsToMetrics(ctx, set, cfg, buildFanOutMetricsConsumer(nexts))
+		case component.DataTypeLogs:
+			return factory.CreateLogsToLogs(ctx, set, cfg, buildFanOutLogsConsumer(nexts))
+		}
+	}
+	return nil, fmt.Errorf("error creating connector %q from %s to %s, data type is not supported", key.id, key.expType, key.rcvType)
+}
+
+func connectorLogger(logger *zap.Logger, key connectorKey) *zap.Logger {
+	return logger.With(
+		zap.String(components.ZapKindKey, components.ZapKindConnector),
+		zap.String(components.ZapNameKey, key.id.String()),
+		zap.String(components.ZapExporterInPipelineKey, string(key.expType)),
+		zap.String(components.ZapReceiverInPipelineKey, string(key.rcvType)))
+}
+
+func buildFanOutTracesConsumer(nexts []baseConsumer) consumer.Traces {
+	consumers := make([]consumer.Traces, 0, len(nexts))
+	for _, next := range nexts {
+		consumers = append(consumers, next.(consumer.Traces))
+	}
+	return fanoutconsumer.NewTraces(consumers)
+}
+
+func buildFanOutMetricsConsumer(nexts []baseConsumer) consumer.Metrics {
+	consumers := make([]consumer.Metrics, 0, len(nexts))
+	for _, next := range nexts {
+		consumers = append(consumers, next.(consumer.Metrics))
+	}
+	return fanoutconsumer.NewMetrics(consumers)
+}
+
+func buildFanOutLogsConsumer(nexts []baseConsumer) consumer.Logs {
+	consumers := make([]consumer.Logs, 0, len(nexts))
+	for _, next := range nexts {
+		consumers = append(consumers, next.(consumer.Logs))
 	}
-	return component.StabilityLevelUndefined
+	return fanoutconsumer.NewLogs(consumers)
 }
 
 func (bps *Pipelines) getPipelinesSummaryTableData() zpages.SummaryPipelinesTableData {
@@ -577,25 +1496,23 @@ func (bps *Pipelines) getPipelinesSummaryTableData() zpages.SummaryPipelinesTabl
 	sumData.Rows = make([]zpages.SummaryPipelinesTableRowData, 0, len(bps.pipelines))
 	for c, p := range bps.pipelines {
 		// TODO: Change the template to use ID.
-		var recvs []string
-		for _, bRecv := range p.receivers {
-			recvs = append(recvs, bRecv.id.String())
-		}
 		var procs []string
 		for _, bProc := range p.processors {
 			procs = append(procs, bProc.id.String())
 		}
-		var exps []string
-		for _, bExp := range p.exporters {
-			exps = append(exps, bExp.id.String())
-		}
 		row := zpages.SummaryPipelinesTableRowData{
 			FullName:    c.String(),
 			InputType:   string(c.Type()),
 			MutatesData: p.lastConsumer.Capabilities().MutatesData,
-			Receivers:   recvs,
+			Receivers:   builtComponentNames(p.receivers),
 			Processors:  procs,
-			Exporters:   exps,
+			Exporters:   builtComponentNames(p.exporters),
+
+			FanOut:             p.budget.FanOut,
+			QueueCapacity:      p.budget.QueueCapacity,
+			MaxBatchSize:       p.budget.MaxBatchSize,
+			QueuedItemsCeiling: p.budget.QueuedItemsCeiling(),
+			MemoryLimitMiB:     p.budget.MemoryLimitMiB,
 		}
 		sumData.Rows = append(sumData.Rows, row)
 	}
@@ -605,3 +1522,16 @@ func (bps *Pipelines) getPipelinesSummaryTableData() zpages.SummaryPipelinesTabl
 	})
 	return sumData
 }
+
+// builtComponentNames returns the IDs of the components, listing only once the consecutive connectors
+// created for the same ID.
+func builtComponentNames(comps []builtComponent) []string {
+	var names []string
+	for i, comp := range comps {
+		if i > 0 && comps[i-1].id == comp.id {
+			continue
+		}
+		names = append(names, comp.id.String())
+	}
+	return names
+}
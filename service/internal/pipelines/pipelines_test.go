@@ -17,8 +17,11 @@ package pipelines
 import (
 	"context"
 	"errors"
+	"fmt"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -30,6 +33,7 @@ import (
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/service/internal/configunmarshaler"
 	"go.opentelemetry.io/collector/service/internal/testcomponents"
@@ -201,6 +205,336 @@ func TestBuild(t *testing.T) {
 	}
 }
 
+func TestBuildConnectors(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+
+	cfg := loadConfig(t, filepath.Join("testdata", "pipelines_connector.yaml"), factories)
+	pipelines, err := Build(context.Background(), toSettings(factories, cfg))
+	require.NoError(t, err)
+
+	// The pipelines that a connector sends data to are built first.
+	order := make(map[component.ID]int)
+	for i, pipelineID := range pipelines.pipelineOrder {
+		order[pipelineID] = i
+	}
+	assert.Less(t, order[component.NewIDWithName("traces", "out")], order[component.NewIDWithName("traces", "mid")])
+	assert.Less(t, order[component.NewIDWithName("traces", "mid")], order[component.NewIDWithName("traces", "in")])
+
+	// A connector is created for every pair of data types it joins.
+	require.Len(t, pipelines.allConnectors, 4)
+	instanceID := pipelines.connectorInstances[connectorKey{id: component.NewID("exampleconnector"), expType: component.DataTypeTraces, rcvType: component.DataTypeTraces}]
+	assert.Equal(t, &component.InstanceID{
+		ID:          component.NewID("exampleconnector"),
+		Kind:        component.KindConnector,
+		PipelineIDs: []component.ID{component.NewIDWithName("traces", "in"), component.NewIDWithName("traces", "mid")},
+	}, instanceID)
+
+	assert.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+	for _, conn := range pipelines.allConnectors {
+		assert.True(t, conn.(*testcomponents.ExampleConnector).Started)
+	}
+
+	recvID := component.NewID("examplereceiver")
+	assert.NoError(t, pipelines.allReceivers[component.DataTypeTraces][recvID].(*testcomponents.ExampleReceiver).ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.NoError(t, pipelines.allReceivers[component.DataTypeMetrics][recvID].(*testcomponents.ExampleReceiver).ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	assert.NoError(t, pipelines.allReceivers[component.DataTypeLogs][recvID].(*testcomponents.ExampleReceiver).ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+
+	assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+	for _, conn := range pipelines.allConnectors {
+		assert.True(t, conn.(*testcomponents.ExampleConnector).Stopped)
+	}
+
+	expID := component.NewID("exampleexporter")
+	traceExporter := pipelines.GetExporters()[component.DataTypeTraces][expID].(*testcomponents.ExampleExporter)
+	require.Len(t, traceExporter.Traces, 1)
+	assert.EqualValues(t, testdata.GenerateTraces(1), traceExporter.Traces[0])
+	metricsExporter := pipelines.GetExporters()[component.DataTypeMetrics][expID].(*testcomponents.ExampleExporter)
+	require.Len(t, metricsExporter.Metrics, 1)
+	assert.EqualValues(t, testdata.GenerateMetrics(1), metricsExporter.Metrics[0])
+	logsExporter := pipelines.GetExporters()[component.DataTypeLogs][expID].(*testcomponents.ExampleExporter)
+	require.Len(t, logsExporter.Logs, 1)
+	assert.EqualValues(t, testdata.GenerateLogs(1), logsExporter.Logs[0])
+}
+
+func TestBuildSharedProcessors(t *testing.T) {
+	require.NoError(t, featuregate.GetRegistry().Apply(map[string]bool{sharedProcessorsFeatureGateID: true}))
+	defer func() {
+		require.NoError(t, featuregate.GetRegistry().Apply(map[string]bool{sharedProcessorsFeatureGateID: false}))
+	}()
+
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+
+	cfg := loadConfig(t, filepath.Join("testdata", "pipelines_shared_processor.yaml"), factories)
+	pipelines, err := Build(context.Background(), toSettings(factories, cfg))
+	require.NoError(t, err)
+
+	// The pipelines sending data through the same processors to the same exporters share the processor instances.
+	shared := pipelines.pipelines[component.NewID("traces")].processors[1]
+	assert.Equal(t, shared, pipelines.pipelines[component.NewIDWithName("traces", "1")].processors[0])
+	assert.Equal(t, []component.ID{component.NewID("traces"), component.NewIDWithName("traces", "1")}, shared.instanceID.PipelineIDs)
+
+	// A processor sending data to other exporters is a different instance.
+	other := pipelines.pipelines[component.NewIDWithName("traces", "2")].processors[0]
+	assert.NotSame(t, shared.comp, other.comp)
+	assert.Equal(t, []component.ID{component.NewIDWithName("traces", "2")}, other.instanceID.PipelineIDs)
+
+	assert.Equal(t, [][]component.ID{
+		{component.NewID("traces"), component.NewIDWithName("traces", "1")},
+		{component.NewIDWithName("traces", "2")},
+	}, pipelines.processorGroups())
+
+	statuses := map[*component.InstanceID][]component.Status{}
+	pipelines.reportStatus = func(source *component.InstanceID, ev *component.StatusEvent) {
+		statuses[source] = append(statuses[source], ev.Status())
+	}
+	require.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+	assert.True(t, shared.comp.(*testcomponents.ExampleProcessor).Started)
+
+	for _, recvID := range []component.ID{component.NewID("examplereceiver"), component.NewIDWithName("examplereceiver", "1")} {
+		assert.NoError(t, pipelines.allReceivers[component.DataTypeTraces][recvID].(*testcomponents.ExampleReceiver).ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	}
+
+	require.NoError(t, pipelines.ShutdownAll(context.Background()))
+	assert.True(t, shared.comp.(*testcomponents.ExampleProcessor).Stopped)
+
+	// The shared processor is started and stopped once.
+	assert.Equal(t, []component.Status{component.StatusStarting, component.StatusOK, component.StatusStopping, component.StatusStopped}, statuses[shared.instanceID])
+
+	assert.Len(t, pipelines.GetExporters()[component.DataTypeTraces][component.NewID("exampleexporter")].(*testcomponents.ExampleExporter).Traces, 2)
+	assert.Len(t, pipelines.GetExporters()[component.DataTypeTraces][component.NewIDWithName("exampleexporter", "1")].(*testcomponents.ExampleExporter).Traces, 1)
+}
+
+func TestBuildSharedProcessorsDisabled(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+
+	cfg := loadConfig(t, filepath.Join("testdata", "pipelines_shared_processor.yaml"), factories)
+	pipelines, err := Build(context.Background(), toSettings(factories, cfg))
+	require.NoError(t, err)
+
+	// Without the feature gate, each pipeline has its own processor instances.
+	first := pipelines.pipelines[component.NewID("traces")].processors[1]
+	second := pipelines.pipelines[component.NewIDWithName("traces", "1")].processors[0]
+	assert.NotSame(t, first.comp, second.comp)
+	assert.Equal(t, []component.ID{component.NewID("traces")}, first.instanceID.PipelineIDs)
+	assert.Equal(t, []component.ID{component.NewIDWithName("traces", "1")}, second.instanceID.PipelineIDs)
+}
+
+func TestProcessorRefs(t *testing.T) {
+	shared := &component.InstanceID{ID: component.NewID("exampleprocessor"), Kind: component.KindProcessor}
+	refs := make(processorRefs)
+	assert.True(t, refs.acquire(shared))
+	assert.False(t, refs.acquire(shared))
+	assert.False(t, refs.release(shared))
+	assert.True(t, refs.release(shared))
+}
+
+func TestCheckAcyclic(t *testing.T) {
+	recv := builtComponent{instanceID: &component.InstanceID{ID: component.NewID("examplereceiver"), Kind: component.KindReceiver}}
+	conn := builtComponent{instanceID: &component.InstanceID{ID: component.NewID("exampleconnector"), Kind: component.KindConnector}}
+	proc := builtComponent{instanceID: &component.InstanceID{ID: component.NewID("exampleprocessor"), Kind: component.KindProcessor}}
+	exp := builtComponent{instanceID: &component.InstanceID{ID: component.NewID("exampleexporter"), Kind: component.KindExporter}}
+
+	bps := &Pipelines{pipelines: map[component.ID]*builtPipeline{
+		component.NewID("traces"):               {receivers: []builtComponent{recv}, processors: []builtComponent{proc}, exporters: []builtComponent{conn}},
+		component.NewIDWithName("traces", "in"): {receivers: []builtComponent{conn}, exporters: []builtComponent{exp}},
+	}}
+	assert.NoError(t, bps.checkAcyclic())
+
+	// The processor shared with the pipeline receiving from the connector sends data back to the connector.
+	bps.pipelines[component.NewIDWithName("traces", "in")].processors = []builtComponent{proc}
+	assert.EqualError(t, bps.checkAcyclic(), `cycle detected: "exampleconnector" sends data back to "exampleprocessor"`)
+}
+
+func TestConnectorMutatesData(t *testing.T) {
+	readOnly := capabilitiesComponent{Consumer: consumertest.NewNop()}
+	mutating := capabilitiesComponent{Consumer: consumertest.NewNop(), mutatesData: true}
+	tracesToTraces := connectorKey{id: component.NewID("conn"), expType: component.DataTypeTraces, rcvType: component.DataTypeTraces}
+	tracesToLogs := connectorKey{id: component.NewID("conn"), expType: component.DataTypeTraces, rcvType: component.DataTypeLogs}
+
+	tests := []struct {
+		name     string
+		conn     capabilitiesComponent
+		key      connectorKey
+		nexts    []baseConsumer
+		expected bool
+	}{
+		{
+			name:     "mutating connector",
+			conn:     mutating,
+			key:      tracesToTraces,
+			nexts:    []baseConsumer{readOnly},
+			expected: true,
+		},
+		{
+			name:     "read-only pipeline",
+			conn:     readOnly,
+			key:      tracesToTraces,
+			nexts:    []baseConsumer{readOnly},
+			expected: false,
+		},
+		{
+			name:     "mutating pipeline",
+			conn:     readOnly,
+			key:      tracesToTraces,
+			nexts:    []baseConsumer{mutating},
+			expected: true,
+		},
+		{
+			name:     "mutating and read-only pipelines",
+			conn:     readOnly,
+			key:      tracesToTraces,
+			nexts:    []baseConsumer{mutating, readOnly},
+			expected: false,
+		},
+		{
+			name:     "all mutating pipelines",
+			conn:     readOnly,
+			key:      tracesToTraces,
+			nexts:    []baseConsumer{mutating, mutating},
+			expected: true,
+		},
+		{
+			name:     "mutating pipeline of another data type",
+			conn:     readOnly,
+			key:      tracesToLogs,
+			nexts:    []baseConsumer{mutating},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, connectorMutatesData(tt.conn, tt.key, tt.nexts))
+		})
+	}
+}
+
+func TestBuildConnectorErrors(t *testing.T) {
+	connFactory := testcomponents.ExampleConnectorFactory
+	connID := component.NewID("exampleconnector")
+	conn1ID := component.NewIDWithName("exampleconnector", "1")
+	nopID := component.NewID("nop")
+
+	tests := []struct {
+		name          string
+		connectorIDs  []component.ID
+		pipelines     map[component.ID]*config.Pipeline
+		expectedError string
+	}{
+		{
+			name:         "cycle",
+			connectorIDs: []component.ID{connID, conn1ID},
+			pipelines: map[component.ID]*config.Pipeline{
+				component.NewIDWithName("traces", "a"): {
+					Receivers: []component.ID{nopID, conn1ID},
+					Exporters: []component.ID{connID},
+				},
+				component.NewIDWithName("traces", "b"): {
+					Receivers: []component.ID{connID},
+					Exporters: []component.ID{conn1ID},
+				},
+			},
+			expectedError: `cycle detected: connector "exampleconnector/1" sends data from pipeline "traces/b" back to pipeline "traces/a"`,
+		},
+		{
+			name:         "self_cycle",
+			connectorIDs: []component.ID{connID},
+			pipelines: map[component.ID]*config.Pipeline{
+				component.NewID("traces"): {
+					Receivers: []component.ID{nopID, connID},
+					Exporters: []component.ID{connID},
+				},
+			},
+			expectedError: `cycle detected: connector "exampleconnector" sends data from pipeline "traces" back to pipeline "traces"`,
+		},
+		{
+			name:         "not_supported_as_exporter",
+			connectorIDs: []component.ID{connID},
+			pipelines: map[component.ID]*config.Pipeline{
+				component.NewIDWithName("traces", "in"): {
+					Receivers: []component.ID{nopID},
+					Exporters: []component.ID{connID},
+				},
+				component.NewIDWithName("logs", "out"): {
+					Receivers: []component.ID{connID},
+					Exporters: []component.ID{nopID},
+				},
+			},
+			expectedError: `connector "exampleconnector" used as exporter in pipeline "traces/in" cannot send traces to any pipeline it is used as receiver in`,
+		},
+		{
+			name:         "not_supported_as_receiver",
+			connectorIDs: []component.ID{connID},
+			pipelines: map[component.ID]*config.Pipeline{
+				component.NewIDWithName("traces", "in"): {
+					Receivers: []component.ID{nopID},
+					Exporters: []component.ID{connID},
+				},
+				component.NewIDWithName("traces", "out"): {
+					Receivers: []component.ID{connID},
+					Exporters: []component.ID{nopID},
+				},
+				component.NewIDWithName("logs", "out"): {
+					Receivers: []component.ID{connID},
+					Exporters: []component.ID{nopID},
+				},
+			},
+			expectedError: `connector "exampleconnector" used as receiver in pipeline "logs/out" cannot receive logs from any pipeline it is used as exporter in`,
+		},
+		{
+			name:         "unknown_connector_factory",
+			connectorIDs: []component.ID{component.NewID("unknown")},
+			pipelines: map[component.ID]*config.Pipeline{
+				component.NewIDWithName("traces", "in"): {
+					Receivers: []component.ID{nopID},
+					Exporters: []component.ID{component.NewID("unknown")},
+				},
+				component.NewIDWithName("traces", "out"): {
+					Receivers: []component.ID{component.NewID("unknown")},
+					Exporters: []component.ID{nopID},
+				},
+			},
+			expectedError: `connector factory not available for: "unknown"`,
+		},
+	}
+
+	nopReceiverFactory := componenttest.NewNopReceiverFactory()
+	nopExporterFactory := componenttest.NewNopExporterFactory()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := Settings{
+				Telemetry: componenttest.NewNopTelemetrySettings(),
+				BuildInfo: component.NewDefaultBuildInfo(),
+				ReceiverFactories: map[component.Type]component.ReceiverFactory{
+					nopReceiverFactory.Type(): nopReceiverFactory,
+				},
+				ReceiverConfigs: map[component.ID]component.Config{
+					nopID: nopReceiverFactory.CreateDefaultConfig(),
+				},
+				ExporterFactories: map[component.Type]component.ExporterFactory{
+					nopExporterFactory.Type(): nopExporterFactory,
+				},
+				ExporterConfigs: map[component.ID]component.Config{
+					nopID: nopExporterFactory.CreateDefaultConfig(),
+				},
+				ConnectorFactories: map[component.Type]component.ConnectorFactory{
+					connFactory.Type(): connFactory,
+				},
+				ConnectorConfigs: make(map[component.ID]component.Config),
+				PipelineConfigs:  test.pipelines,
+			}
+			for _, id := range test.connectorIDs {
+				set.ConnectorConfigs[id] = connFactory.CreateDefaultConfig()
+			}
+
+			_, err := Build(context.Background(), set)
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
+
 func TestBuildErrors(t *testing.T) {
 	nopReceiverFactory := componenttest.NewNopReceiverFactory()
 	nopProcessorFactory := componenttest.NewNopProcessorFactory()
@@ -514,6 +848,66 @@ func TestBuildErrors(t *testing.T) {
 	}
 }
 
+func TestBuildReceiverSignalNotSupported(t *testing.T) {
+	nopReceiverFactory := componenttest.NewNopReceiverFactory()
+	nopExporterFactory := componenttest.NewNopExporterFactory()
+	var dataTypes []component.DataType
+	// The receiver declares no stability level, which does not prevent its use.
+	tracesMetricsReceiverFactory := component.NewReceiverFactory("tracesmetrics", nopReceiverFactory.CreateDefaultConfig,
+		component.WithTracesReceiver(func(ctx context.Context, set component.ReceiverCreateSettings, cfg component.Config, next consumer.Traces) (component.TracesReceiver, error) {
+			dataTypes = append(dataTypes, component.DataTypeTraces)
+			return nopReceiverFactory.CreateTracesReceiver(ctx, set, cfg, next)
+		}, component.StabilityLevelUndefined),
+		component.WithMetricsReceiver(func(ctx context.Context, set component.ReceiverCreateSettings, cfg component.Config, next consumer.Metrics) (component.MetricsReceiver, error) {
+			dataTypes = append(dataTypes, component.DataTypeMetrics)
+			return nopReceiverFactory.CreateMetricsReceiver(ctx, set, cfg, next)
+		}, component.StabilityLevelUndefined))
+
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverFactories: map[component.Type]component.ReceiverFactory{
+			tracesMetricsReceiverFactory.Type(): tracesMetricsReceiverFactory,
+		},
+		ReceiverConfigs: map[component.ID]component.Config{
+			component.NewID("tracesmetrics"): tracesMetricsReceiverFactory.CreateDefaultConfig(),
+		},
+		ExporterFactories: map[component.Type]component.ExporterFactory{
+			nopExporterFactory.Type(): nopExporterFactory,
+		},
+		ExporterConfigs: map[component.ID]component.Config{
+			component.NewID("nop"): nopExporterFactory.CreateDefaultConfig(),
+		},
+		PipelineConfigs: map[component.ID]*config.Pipeline{
+			component.NewID("traces"): {
+				Receivers: []component.ID{component.NewID("tracesmetrics")},
+				Exporters: []component.ID{component.NewID("nop")},
+			},
+		},
+	}
+
+	// A receiver can be used for a subset of the data types it supports, it is only created for these.
+	_, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	assert.Equal(t, []component.DataType{component.DataTypeTraces}, dataTypes)
+
+	dataTypes = nil
+	set.PipelineConfigs[component.NewID("metrics")] = &config.Pipeline{
+		Receivers: []component.ID{component.NewID("tracesmetrics")},
+		Exporters: []component.ID{component.NewID("nop")},
+	}
+	_, err = Build(context.Background(), set)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []component.DataType{component.DataTypeMetrics, component.DataTypeTraces}, dataTypes)
+
+	set.PipelineConfigs[component.NewID("logs")] = &config.Pipeline{
+		Receivers: []component.ID{component.NewID("tracesmetrics")},
+		Exporters: []component.ID{component.NewID("nop")},
+	}
+	_, err = Build(context.Background(), set)
+	assert.EqualError(t, err, `pipeline "logs" references receiver "tracesmetrics" which does not support logs`)
+}
+
 func TestFailToStartAndShutdown(t *testing.T) {
 	errReceiverFactory := newErrReceiverFactory()
 	errProcessorFactory := newErrProcessorFactory()
@@ -596,6 +990,227 @@ func TestFailToStartAndShutdown(t *testing.T) {
 	}
 }
 
+func TestReportComponentStatus(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+
+	cfg := loadConfig(t, filepath.Join("testdata", "pipelines_exporter_multi_pipeline.yaml"), factories)
+
+	statuses := map[*component.InstanceID][]component.Status{}
+	set := toSettings(factories, cfg)
+	set.ReportComponentStatus = func(source *component.InstanceID, ev *component.StatusEvent) {
+		statuses[source] = append(statuses[source], ev.Status())
+	}
+	pipelines, err := Build(context.Background(), set)
+	require.NoError(t, err)
+
+	expID := component.NewID("exampleexporter")
+	instanceID := pipelines.exporterInstances[component.DataTypeTraces][expID]
+	require.NotNil(t, instanceID)
+	assert.Equal(t, component.KindExporter, instanceID.Kind)
+	assert.Equal(t, []component.ID{component.NewID("traces"), component.NewIDWithName("traces", "1")}, instanceID.PipelineIDs)
+
+	require.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, pipelines.ShutdownAll(context.Background()))
+
+	expected := []component.Status{component.StatusStarting, component.StatusOK, component.StatusStopping, component.StatusStopped}
+	assert.Equal(t, expected, statuses[instanceID])
+	assert.Equal(t, expected, statuses[pipelines.receiverInstances[component.DataTypeLogs][component.NewID("examplereceiver")]])
+	assert.Equal(t, expected, statuses[pipelines.pipelines[component.NewID("metrics")].processors[0].instanceID])
+	assert.Len(t, statuses, 9)
+}
+
+func TestStartAllParallel(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("parallel=%v", parallel), func(t *testing.T) {
+			// Each exporter waits for the other one to start, which only succeeds if they are started concurrently.
+			started := &sync.WaitGroup{}
+			started.Add(2)
+			nopReceiverFactory := componenttest.NewNopReceiverFactory()
+			barrierExporterFactory := newBarrierExporterFactory(started)
+			set := Settings{
+				Telemetry: componenttest.NewNopTelemetrySettings(),
+				BuildInfo: component.NewDefaultBuildInfo(),
+				ReceiverFactories: map[component.Type]component.ReceiverFactory{
+					nopReceiverFactory.Type(): nopReceiverFactory,
+				},
+				ReceiverConfigs: map[component.ID]component.Config{
+					component.NewID(nopReceiverFactory.Type()): nopReceiverFactory.CreateDefaultConfig(),
+				},
+				ExporterFactories: map[component.Type]component.ExporterFactory{
+					barrierExporterFactory.Type(): barrierExporterFactory,
+				},
+				ExporterConfigs: map[component.ID]component.Config{
+					component.NewID(barrierExporterFactory.Type()):              barrierExporterFactory.CreateDefaultConfig(),
+					component.NewIDWithName(barrierExporterFactory.Type(), "1"): barrierExporterFactory.CreateDefaultConfig(),
+				},
+				PipelineConfigs: map[component.ID]*config.Pipeline{
+					component.NewID(component.DataTypeTraces): {
+						Receivers: []component.ID{component.NewID("nop")},
+						Exporters: []component.ID{component.NewID("barrier"), component.NewIDWithName("barrier", "1")},
+					},
+				},
+				StartParallel: parallel,
+				StartTimeout:  time.Second,
+			}
+
+			pipelines, err := Build(context.Background(), set)
+			require.NoError(t, err)
+			err = pipelines.StartAll(context.Background(), componenttest.NewNopHost())
+			if parallel {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, context.DeadlineExceeded)
+				// The shutdown waits for the exporter that is still starting.
+				shutdownErr := make(chan error, 1)
+				go func() {
+					shutdownErr <- pipelines.ShutdownAll(context.Background())
+				}()
+				select {
+				case <-shutdownErr:
+					t.Fatal("shutdown returned while an exporter was starting")
+				case <-time.After(50 * time.Millisecond):
+				}
+				started.Done()
+				assert.NoError(t, <-shutdownErr)
+				return
+			}
+			assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+		})
+	}
+}
+
+func TestStartAllContextOutlivesStartTimeout(t *testing.T) {
+	var startCtx context.Context
+	nopReceiverFactory := componenttest.NewNopReceiverFactory()
+	ctxExporterFactory := newContextExporterFactory(&startCtx)
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverFactories: map[component.Type]component.ReceiverFactory{
+			nopReceiverFactory.Type(): nopReceiverFactory,
+		},
+		ReceiverConfigs: map[component.ID]component.Config{
+			component.NewID(nopReceiverFactory.Type()): nopReceiverFactory.CreateDefaultConfig(),
+		},
+		ExporterFactories: map[component.Type]component.ExporterFactory{
+			ctxExporterFactory.Type(): ctxExporterFactory,
+		},
+		ExporterConfigs: map[component.ID]component.Config{
+			component.NewID(ctxExporterFactory.Type()): ctxExporterFactory.CreateDefaultConfig(),
+		},
+		PipelineConfigs: map[component.ID]*config.Pipeline{
+			component.NewID(component.DataTypeTraces): {
+				Receivers: []component.ID{component.NewID("nop")},
+				Exporters: []component.ID{component.NewID("ctx")},
+			},
+		},
+		StartTimeout: time.Second,
+	}
+
+	pipelines, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, pipelines.StartAll(ctx, componenttest.NewNopHost()))
+
+	// The context of the components is not bound by the start timeout.
+	require.NotNil(t, startCtx)
+	_, hasDeadline := startCtx.Deadline()
+	assert.False(t, hasDeadline)
+	assert.NoError(t, startCtx.Err())
+	cancel()
+	assert.ErrorIs(t, startCtx.Err(), context.Canceled)
+	assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+}
+
+func TestDrainAndShutdownAll(t *testing.T) {
+	for _, drain := range []bool{false, true} {
+		t.Run(fmt.Sprintf("drain=%v", drain), func(t *testing.T) {
+			var events []string
+			nopReceiverFactory := componenttest.NewNopReceiverFactory()
+			flushExporterFactory := newFlushExporterFactory(&events)
+			set := Settings{
+				Telemetry: componenttest.NewNopTelemetrySettings(),
+				BuildInfo: component.NewDefaultBuildInfo(),
+				ReceiverFactories: map[component.Type]component.ReceiverFactory{
+					nopReceiverFactory.Type(): nopReceiverFactory,
+				},
+				ReceiverConfigs: map[component.ID]component.Config{
+					component.NewID(nopReceiverFactory.Type()): nopReceiverFactory.CreateDefaultConfig(),
+				},
+				ExporterFactories: map[component.Type]component.ExporterFactory{
+					flushExporterFactory.Type(): flushExporterFactory,
+				},
+				ExporterConfigs: map[component.ID]component.Config{
+					component.NewID(flushExporterFactory.Type()): flushExporterFactory.CreateDefaultConfig(),
+				},
+				PipelineConfigs: map[component.ID]*config.Pipeline{
+					component.NewID(component.DataTypeTraces): {
+						Receivers: []component.ID{component.NewID("nop")},
+						Exporters: []component.ID{component.NewID("flush")},
+					},
+				},
+			}
+
+			pipelines, err := Build(context.Background(), set)
+			require.NoError(t, err)
+			require.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+			if drain {
+				assert.NoError(t, pipelines.DrainAndShutdownAll(context.Background(), time.Second))
+				assert.Equal(t, []string{"flush with deadline", "shutdown"}, events)
+			} else {
+				assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+				assert.Equal(t, []string{"shutdown"}, events)
+			}
+		})
+	}
+}
+
+func TestWaitForExporters(t *testing.T) {
+	for _, connected := range []bool{false, true} {
+		t.Run(fmt.Sprintf("connected=%v", connected), func(t *testing.T) {
+			nopReceiverFactory := componenttest.NewNopReceiverFactory()
+			connExporterFactory := newConnectionExporterFactory(connected)
+			set := Settings{
+				Telemetry: componenttest.NewNopTelemetrySettings(),
+				BuildInfo: component.NewDefaultBuildInfo(),
+				ReceiverFactories: map[component.Type]component.ReceiverFactory{
+					nopReceiverFactory.Type(): nopReceiverFactory,
+				},
+				ReceiverConfigs: map[component.ID]component.Config{
+					component.NewID(nopReceiverFactory.Type()): nopReceiverFactory.CreateDefaultConfig(),
+				},
+				ExporterFactories: map[component.Type]component.ExporterFactory{
+					connExporterFactory.Type(): connExporterFactory,
+				},
+				ExporterConfigs: map[component.ID]component.Config{
+					component.NewID(connExporterFactory.Type()): connExporterFactory.CreateDefaultConfig(),
+				},
+				PipelineConfigs: map[component.ID]*config.Pipeline{
+					component.NewID(component.DataTypeTraces): {
+						Receivers: []component.ID{component.NewID("nop")},
+						Exporters: []component.ID{component.NewID("conn")},
+					},
+				},
+			}
+
+			pipelines, err := Build(context.Background(), set)
+			require.NoError(t, err)
+			require.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			err = pipelines.WaitForExporters(ctx)
+			if connected {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, `exporter "conn" for traces is not connected: context deadline exceeded`)
+			}
+			assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+		})
+	}
+}
+
 func newBadReceiverFactory() component.ReceiverFactory {
 	return component.NewReceiverFactory("bf", func() component.Config {
 		return &struct {
@@ -686,6 +1301,62 @@ func newErrExporterFactory() component.ExporterFactory {
 	)
 }
 
+func newBarrierExporterFactory(started *sync.WaitGroup) component.ExporterFactory {
+	return component.NewExporterFactory("barrier", func() component.Config {
+		return &struct {
+			config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+		}{
+			ExporterSettings: config.NewExporterSettings(component.NewID("barrier")),
+		}
+	},
+		component.WithTracesExporter(func(context.Context, component.ExporterCreateSettings, component.Config) (component.TracesExporter, error) {
+			return &barrierComponent{started: started}, nil
+		}, component.StabilityLevelUndefined),
+	)
+}
+
+func newFlushExporterFactory(events *[]string) component.ExporterFactory {
+	return component.NewExporterFactory("flush", func() component.Config {
+		return &struct {
+			config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+		}{
+			ExporterSettings: config.NewExporterSettings(component.NewID("flush")),
+		}
+	},
+		component.WithTracesExporter(func(context.Context, component.ExporterCreateSettings, component.Config) (component.TracesExporter, error) {
+			return &flushComponent{events: events}, nil
+		}, component.StabilityLevelUndefined),
+	)
+}
+
+func newContextExporterFactory(startCtx *context.Context) component.ExporterFactory {
+	return component.NewExporterFactory("ctx", func() component.Config {
+		return &struct {
+			config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+		}{
+			ExporterSettings: config.NewExporterSettings(component.NewID("ctx")),
+		}
+	},
+		component.WithTracesExporter(func(context.Context, component.ExporterCreateSettings, component.Config) (component.TracesExporter, error) {
+			return &contextComponent{startCtx: startCtx}, nil
+		}, component.StabilityLevelUndefined),
+	)
+}
+
+func newConnectionExporterFactory(connected bool) component.ExporterFactory {
+	return component.NewExporterFactory("conn", func() component.Config {
+		return &struct {
+			config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+		}{
+			ExporterSettings: config.NewExporterSettings(component.NewID("conn")),
+		}
+	},
+		component.WithTracesExporter(func(context.Context, component.ExporterCreateSettings, component.Config) (component.TracesExporter, error) {
+			return &connectionComponent{connected: connected}, nil
+		}, component.StabilityLevelUndefined),
+	)
+}
+
 func toSettings(factories component.Factories, cfg *configSettings) Settings {
 	return Settings{
 		Telemetry:          componenttest.NewNopTelemetrySettings(),
@@ -696,6 +1367,8 @@ func toSettings(factories component.Factories, cfg *configSettings) Settings {
 		ProcessorConfigs:   cfg.Processors.GetProcessors(),
 		ExporterFactories:  factories.Exporters,
 		ExporterConfigs:    cfg.Exporters.GetExporters(),
+		ConnectorFactories: factories.Connectors,
+		ConnectorConfigs:   cfg.Connectors.GetConnectors(),
 		PipelineConfigs:    cfg.Service.Pipelines,
 	}
 }
@@ -716,11 +1389,23 @@ func (e errComponent) Shutdown(context.Context) error {
 	return errors.New("my error")
 }
 
+type capabilitiesComponent struct {
+	consumertest.Consumer
+	component.StartFunc
+	component.ShutdownFunc
+	mutatesData bool
+}
+
+func (c capabilitiesComponent) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: c.mutatesData}
+}
+
 // TODO: Remove this by not reading the input from the files, or by providing something similar outside service package.
 type configSettings struct {
 	Receivers  *configunmarshaler.Receivers  `mapstructure:"receivers"`
 	Processors *configunmarshaler.Processors `mapstructure:"processors"`
 	Exporters  *configunmarshaler.Exporters  `mapstructure:"exporters"`
+	Connectors *configunmarshaler.Connectors `mapstructure:"connectors"`
 	Service    *serviceSettings              `mapstructure:"service"`
 }
 
@@ -736,7 +1421,92 @@ func loadConfig(t *testing.T, fileName string, factories component.Factories) *c
 		Receivers:  configunmarshaler.NewReceivers(factories.Receivers),
 		Processors: configunmarshaler.NewProcessors(factories.Processors),
 		Exporters:  configunmarshaler.NewExporters(factories.Exporters),
+		Connectors: configunmarshaler.NewConnectors(factories.Connectors),
 	}
 	require.NoError(t, conf.Unmarshal(cfg, confmap.WithErrorUnused()))
 	return cfg
 }
+
+// barrierComponent waits in Start until all the barrierComponents sharing the same WaitGroup are starting.
+type barrierComponent struct {
+	consumertest.Consumer
+	started *sync.WaitGroup
+}
+
+func (b *barrierComponent) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (b *barrierComponent) Start(context.Context, component.Host) error {
+	b.started.Done()
+	b.started.Wait()
+	return nil
+}
+
+func (b *barrierComponent) Shutdown(context.Context) error {
+	return nil
+}
+
+type contextComponent struct {
+	consumertest.Consumer
+	startCtx *context.Context
+}
+
+func (c *contextComponent) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *contextComponent) Start(ctx context.Context, _ component.Host) error {
+	*c.startCtx = ctx
+	return nil
+}
+
+func (c *contextComponent) Shutdown(context.Context) error {
+	return nil
+}
+
+type flushComponent struct {
+	consumertest.Consumer
+	events *[]string
+}
+
+func (f *flushComponent) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (f *flushComponent) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (f *flushComponent) Flush(ctx context.Context) error {
+	if _, ok := ctx.Deadline(); ok {
+		*f.events = append(*f.events, "flush with deadline")
+	} else {
+		*f.events = append(*f.events, "flush")
+	}
+	return nil
+}
+
+func (f *flushComponent) Shutdown(context.Context) error {
+	*f.events = append(*f.events, "shutdown")
+	return nil
+}
+
+type connectionComponent struct {
+	consumertest.Consumer
+	component.StartFunc
+	component.ShutdownFunc
+	connected bool
+}
+
+func (c *connectionComponent) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *connectionComponent) WaitForConnection(ctx context.Context) error {
+	if c.connected {
+		return nil
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
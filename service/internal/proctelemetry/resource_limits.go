@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proctelemetry // import "go.opentelemetry.io/collector/service/internal/proctelemetry"
+
+import (
+	"runtime"
+
+	"go.opencensus.io/metric"
+	"go.opencensus.io/stats"
+)
+
+// RegisterResourceLimitsMetrics creates the metrics reporting the resources available to this process:
+// GOMAXPROCS, the CPU quota (only when defined, cpuQuota <= 0 means no quota) and the total memory
+// (only when detected, totalMemory == 0 means unknown).
+func RegisterResourceLimitsMetrics(registry *metric.Registry, cpuQuota float64, totalMemory uint64) error {
+	maxProcs, err := registry.AddInt64DerivedGauge(
+		"process/runtime/gomaxprocs",
+		metric.WithDescription("Maximum number of CPUs that can be executing Go code simultaneously (see 'go doc runtime.GOMAXPROCS')"),
+		metric.WithUnit(stats.UnitDimensionless))
+	if err != nil {
+		return err
+	}
+	if err = maxProcs.UpsertEntry(func() int64 { return int64(runtime.GOMAXPROCS(0)) }); err != nil {
+		return err
+	}
+
+	cpuQuotaGauge, err := registry.AddFloat64DerivedGauge(
+		"process/cpu_quota",
+		metric.WithDescription("Number of CPUs the process is allowed to use, as defined by its cgroup"),
+		metric.WithUnit(stats.UnitDimensionless))
+	if err != nil {
+		return err
+	}
+	if cpuQuota > 0 {
+		if err = cpuQuotaGauge.UpsertEntry(func() float64 { return cpuQuota }); err != nil {
+			return err
+		}
+	}
+
+	totalMemoryGauge, err := registry.AddInt64DerivedGauge(
+		"process/memory/total",
+		metric.WithDescription("Total memory available to the process, honoring its cgroup memory limit"),
+		metric.WithUnit(stats.UnitBytes))
+	if err != nil {
+		return err
+	}
+	if totalMemory > 0 {
+		if err = totalMemoryGauge.UpsertEntry(func() int64 { return int64(totalMemory) }); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
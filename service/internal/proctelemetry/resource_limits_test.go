@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proctelemetry
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric"
+)
+
+func TestResourceLimitsTelemetry(t *testing.T) {
+	registry := metric.NewRegistry()
+	require.NoError(t, RegisterResourceLimitsMetrics(registry, 1.5, 1024))
+
+	metrics := registry.Read()
+
+	m := findMetric(metrics, "process/runtime/gomaxprocs")
+	require.NotNil(t, m)
+	require.Len(t, m.TimeSeries, 1)
+	assert.Equal(t, int64(runtime.GOMAXPROCS(0)), m.TimeSeries[0].Points[0].Value)
+
+	m = findMetric(metrics, "process/cpu_quota")
+	require.NotNil(t, m)
+	require.Len(t, m.TimeSeries, 1)
+	assert.Equal(t, 1.5, m.TimeSeries[0].Points[0].Value)
+
+	m = findMetric(metrics, "process/memory/total")
+	require.NotNil(t, m)
+	require.Len(t, m.TimeSeries, 1)
+	assert.Equal(t, int64(1024), m.TimeSeries[0].Points[0].Value)
+}
+
+func TestResourceLimitsTelemetryUndefined(t *testing.T) {
+	registry := metric.NewRegistry()
+	require.NoError(t, RegisterResourceLimitsMetrics(registry, -1, 0))
+
+	for _, m := range registry.Read() {
+		if m.Descriptor.Name == "process/runtime/gomaxprocs" {
+			assert.Len(t, m.TimeSeries, 1)
+			continue
+		}
+		assert.Len(t, m.TimeSeries, 0, m.Descriptor.Name)
+	}
+}
+
+func TestResourceLimitsTelemetryFailToRegister(t *testing.T) {
+	for _, metricName := range []string{"process/runtime/gomaxprocs", "process/cpu_quota", "process/memory/total"} {
+		t.Run(metricName, func(t *testing.T) {
+			registry := metric.NewRegistry()
+			_, err := registry.AddFloat64Gauge(metricName)
+			require.NoError(t, err)
+			assert.Error(t, RegisterResourceLimitsMetrics(registry, 1, 1))
+		})
+	}
+}
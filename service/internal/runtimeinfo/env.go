@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimeinfo // import "go.opentelemetry.io/collector/service/internal/runtimeinfo"
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// runtimeEnvNames are the environment variables of the Go runtime, which are shown with their values.
+var runtimeEnvNames = map[string]bool{
+	"GOMAXPROCS": true,
+	"GOMEMLIMIT": true,
+	"GOGC":       true,
+	"GODEBUG":    true,
+}
+
+// otelEnvPrefix is the prefix of the OpenTelemetry environment variables, which are shown with their values redacted,
+// as they may hold credentials, e.g. OTEL_EXPORTER_OTLP_HEADERS.
+const otelEnvPrefix = "OTEL_"
+
+// Env returns the environment variables of the process that affect the Collector, sorted by name: the variables of
+// the Go runtime, and the names of the OpenTelemetry variables, with their values redacted. The other variables are
+// not returned, since they may hold secrets.
+func Env() [][2]string {
+	var env [][2]string
+	for _, kv := range os.Environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		switch {
+		case runtimeEnvNames[name]:
+			env = append(env, [2]string{name, value})
+		case strings.HasPrefix(name, otelEnvPrefix):
+			env = append(env, [2]string{name, redacted})
+		}
+	}
+	sort.Slice(env, func(i, j int) bool { return env[i][0] < env[j][0] })
+	return env
+}
@@ -15,26 +15,59 @@
 package runtimeinfo // import "go.opentelemetry.io/collector/service/internal/runtimeinfo"
 
 import (
+	"os"
 	"runtime"
+	"strconv"
 	"time"
+
+	"go.opentelemetry.io/collector/internal/iruntime"
 )
 
 var (
-	// InfoVar is a singleton instance of the Info struct.
-	runtimeInfoVar [][2]string
+	// startTime is the time when the process started.
+	startTime time.Time
 )
 
 func init() {
-	runtimeInfoVar = [][2]string{
-		{"StartTimestamp", time.Now().String()},
+	startTime = time.Now()
+}
+
+// Info returns the runtime information like uptime, os, arch, etc.
+func Info() [][2]string {
+	return [][2]string{
+		{"StartTimestamp", startTime.String()},
+		{"Uptime", time.Since(startTime).Round(time.Second).String()},
 		{"Go", runtime.Version()},
 		{"OS", runtime.GOOS},
 		{"Arch", runtime.GOARCH},
+		{"NumCPU", strconv.Itoa(runtime.NumCPU())},
+		{"GOMAXPROCS", strconv.Itoa(runtime.GOMAXPROCS(0))},
+		{"GOGC", gcPercent()},
+		{"GOMEMLIMIT", memoryLimit()},
+		{"TotalMemory", totalMemory()},
 		// Add other valuable runtime information here.
 	}
 }
 
-// Info returns the runtime information like uptime, os, arch, etc.
-func Info() [][2]string {
-	return runtimeInfoVar
+// gcPercent returns the GC target percentage set by the GOGC environment variable, parsed the same way as the
+// runtime does. The runtime has no getter for the current value, and setting it to read it back would affect the
+// whole process.
+func gcPercent() string {
+	gogc := os.Getenv("GOGC")
+	if gogc == "off" {
+		return gogc
+	}
+	if pct, err := strconv.Atoi(gogc); err == nil {
+		return strconv.Itoa(pct)
+	}
+	return "100"
+}
+
+// totalMemory returns the memory available to the process, honoring cgroup limits on linux.
+func totalMemory() string {
+	total, err := iruntime.TotalMemory()
+	if err != nil {
+		return "unknown: " + err.Error()
+	}
+	return strconv.FormatUint(total, 10)
 }
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimeinfo
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfo(t *testing.T) {
+	t.Setenv("GOGC", "50")
+
+	info := map[string]string{}
+	for _, kv := range Info() {
+		info[kv[0]] = kv[1]
+	}
+
+	assert.Equal(t, runtime.Version(), info["Go"])
+	assert.Equal(t, strconv.Itoa(runtime.GOMAXPROCS(0)), info["GOMAXPROCS"])
+	assert.Equal(t, "50", info["GOGC"])
+
+	t.Setenv("GOGC", "off")
+	assert.Equal(t, "off", gcPercent())
+	t.Setenv("GOGC", "")
+	assert.Equal(t, "100", gcPercent())
+	assert.Contains(t, info, "GOMEMLIMIT")
+	assert.Contains(t, info, "Uptime")
+	assert.Contains(t, info, "TotalMemory")
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.19
+// +build go1.19
+
+package runtimeinfo // import "go.opentelemetry.io/collector/service/internal/runtimeinfo"
+
+import (
+	"math"
+	"runtime/debug"
+	"strconv"
+)
+
+// memoryLimit returns the soft memory limit currently applied by the runtime, which may differ from
+// the GOMEMLIMIT environment variable if it was changed with debug.SetMemoryLimit.
+func memoryLimit() string {
+	// A negative input does not adjust the limit and returns the current one.
+	limit := debug.SetMemoryLimit(-1)
+	if limit == math.MaxInt64 {
+		return "unset"
+	}
+	return strconv.FormatInt(limit, 10)
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testcomponents // import "go.opentelemetry.io/collector/service/internal/testcomponents"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const connType = "exampleconnector"
+
+// ExampleConnectorConfig config for ExampleConnector.
+type ExampleConnectorConfig struct {
+	config.ConnectorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+}
+
+// ExampleConnectorFactory is factory for ExampleConnector, which forwards the data it consumes
+// to pipelines of the same data type.
+var ExampleConnectorFactory = component.NewConnectorFactory(
+	connType,
+	createConnectorDefaultConfig,
+	component.WithTracesToTraces(createTracesToTracesConnector, component.StabilityLevelDevelopment),
+	component.WithMetricsToMetrics(createMetricsToMetricsConnector, component.StabilityLevelDevelopment),
+	component.WithLogsToLogs(createLogsToLogsConnector, component.StabilityLevelDevelopment),
+)
+
+func createConnectorDefaultConfig() component.Config {
+	return &ExampleConnectorConfig{
+		ConnectorSettings: config.NewConnectorSettings(component.NewID(connType)),
+	}
+}
+
+func createTracesToTracesConnector(_ context.Context, _ component.ConnectorCreateSettings, _ component.Config, next consumer.Traces) (component.TracesConnector, error) {
+	return &ExampleConnector{Traces: next}, nil
+}
+
+func createMetricsToMetricsConnector(_ context.Context, _ component.ConnectorCreateSettings, _ component.Config, next consumer.Metrics) (component.MetricsConnector, error) {
+	return &ExampleConnector{Metrics: next}, nil
+}
+
+func createLogsToLogsConnector(_ context.Context, _ component.ConnectorCreateSettings, _ component.Config, next consumer.Logs) (component.LogsConnector, error) {
+	return &ExampleConnector{Logs: next}, nil
+}
+
+// ExampleConnector forwards the data it consumes to the next consumer of the same data type.
+type ExampleConnector struct {
+	consumer.Traces
+	consumer.Metrics
+	consumer.Logs
+	Started bool
+	Stopped bool
+}
+
+// Start tells the connector to start.
+func (c *ExampleConnector) Start(_ context.Context, _ component.Host) error {
+	c.Started = true
+	return nil
+}
+
+func (c *ExampleConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// Shutdown is invoked during shutdown.
+func (c *ExampleConnector) Shutdown(context.Context) error {
+	c.Stopped = true
+	return nil
+}
@@ -30,5 +30,8 @@ func ExampleComponents() (component.Factories, error) {
 		Exporters: map[component.Type]component.ExporterFactory{
 			ExampleExporterFactory.Type(): ExampleExporterFactory,
 		},
+		Connectors: map[component.Type]component.ConnectorFactory{
+			ExampleConnectorFactory.Type(): ExampleConnectorFactory,
+		},
 	}, nil
 }
@@ -105,6 +105,17 @@ type SummaryPipelinesTableRowData struct {
 	Receivers   []string
 	Processors  []string
 	Exporters   []string
+
+	// FanOut is the number of exporters the data is sent to.
+	FanOut int
+	// QueueCapacity is the total number of batches the sending queues can hold.
+	QueueCapacity int
+	// MaxBatchSize is the largest configured batch size, zero if the data is not batched.
+	MaxBatchSize uint64
+	// QueuedItemsCeiling is the estimated maximum number of items held by the sending queues, zero if unknown.
+	QueuedItemsCeiling uint64
+	// MemoryLimitMiB is the smallest configured memory limit, zero if no limit is configured.
+	MemoryLimitMiB uint64
 }
 
 // WriteHTMLPipelinesSummaryTable writes the summary table for one component type (receivers, processors, exporters).
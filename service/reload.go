@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ocmetric "go.opencensus.io/metric"
+	"go.opencensus.io/metric/metricdata"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+const (
+	// reloadFeatureGateID is the feature gate that controls whether the pipelines are reloaded in place
+	// when the configuration changes, instead of restarting the whole service.
+	reloadFeatureGateID = "service.reload"
+
+	// reloadFlushTimeout bounds the time spent flushing the queues of the retiring exporters during a reload.
+	reloadFlushTimeout = 30 * time.Second
+
+	reloadResultKey     = "result"
+	reloadResultSuccess = "success"
+	reloadResultFailure = "failure"
+)
+
+func init() {
+	featuregate.GetRegistry().MustRegisterID(
+		reloadFeatureGateID,
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("controls whether the collector rebuilds its pipelines in place when the configuration changes, instead of restarting the service"),
+	)
+}
+
+type reloadInstruments struct {
+	registry *ocmetric.Registry
+	reloads  *ocmetric.Int64Cumulative
+}
+
+func newReloadInstruments(registry *ocmetric.Registry) *reloadInstruments {
+	insts := &reloadInstruments{
+		registry: registry,
+	}
+	insts.reloads, _ = registry.AddInt64Cumulative(
+		"service/config_reloads",
+		ocmetric.WithDescription("Number of configuration reloads, by result"),
+		ocmetric.WithLabelKeys(reloadResultKey),
+		ocmetric.WithUnit(metricdata.UnitDimensionless))
+	return insts
+}
+
+// record records the result of a configuration reload.
+func (ri *reloadInstruments) record(err error) {
+	result := reloadResultSuccess
+	if err != nil {
+		result = reloadResultFailure
+	}
+	if entry, entryErr := ri.reloads.GetEntry(metricdata.NewLabelValue(result)); entryErr == nil {
+		entry.Inc(1)
+	}
+}
+
+// reloadPipelines reloads the configuration, and rebuilds the pipelines in place when possible. An invalid
+// configuration is reported and leaves the running pipelines untouched; the returned error is fatal.
+func (col *Collector) reloadPipelines(ctx context.Context) error {
+	logger := col.service.telemetrySettings.Logger
+	logger.Info("Config updated, reloading pipelines")
+
+	cfg, err := col.set.ConfigProvider.Get(ctx, col.set.Factories)
+	if err == nil {
+		if err = cfg.Validate(); err != nil {
+			err = fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+	if err != nil {
+		col.reloadMetrics.record(err)
+		logger.Error("Failed to reload the config, keeping the current one", zap.Error(err))
+		return nil
+	}
+
+	if !col.service.canReloadPipelines(cfg) {
+		logger.Info("Extensions, telemetry or runtime settings changed, restarting service")
+		err = col.restartService(ctx, cfg)
+		col.reloadMetrics.record(err)
+		return err
+	}
+
+	col.setCollectorState(StateStarting)
+	if err = col.service.reloadPipelines(ctx, cfg, reloadFlushTimeout); err != nil {
+		col.reloadMetrics.record(err)
+		if col.service.config != cfg {
+			// The new pipelines could not be built, the current ones are still running.
+			col.setCollectorState(StateRunning)
+			logger.Error("Failed to reload the pipelines, keeping the current ones", zap.Error(err))
+			return nil
+		}
+		return fmt.Errorf("failed to reload pipelines: %w", err)
+	}
+	col.reloadMetrics.record(nil)
+	col.setCollectorState(StateRunning)
+	logger.Info("Pipelines reloaded")
+	return nil
+}
@@ -0,0 +1,250 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+// failingCfgProvider fails to get the configuration after the first time.
+type failingCfgProvider struct {
+	ConfigProvider
+	watcher chan error
+	calls   int
+}
+
+func (p *failingCfgProvider) Get(ctx context.Context, factories component.Factories) (*Config, error) {
+	p.calls++
+	if p.calls > 1 {
+		return nil, errors.New("invalid config")
+	}
+	return p.ConfigProvider.Get(ctx, factories)
+}
+
+func (p *failingCfgProvider) Watch() <-chan error {
+	return p.watcher
+}
+
+func enableReloadFeatureGate(t *testing.T) {
+	require.NoError(t, featuregate.GetRegistry().Apply(map[string]bool{reloadFeatureGateID: true}))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GetRegistry().Apply(map[string]bool{reloadFeatureGateID: false}))
+	})
+}
+
+func reloadCount(col *Collector, result string) int64 {
+	for _, m := range col.reloadMetrics.registry.Read() {
+		for _, ts := range m.TimeSeries {
+			if len(ts.LabelValues) == 1 && ts.LabelValues[0].Value == result && len(ts.Points) > 0 {
+				return ts.Points[len(ts.Points)-1].Value.(int64)
+			}
+		}
+	}
+	return 0
+}
+
+func TestCollectorReloadPipelinesInPlace(t *testing.T) {
+	enableReloadFeatureGate(t)
+
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	provider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+
+	watcher := make(chan error, 1)
+	col, err := New(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: &mockCfgProvider{ConfigProvider: provider, watcher: watcher},
+		telemetry:      newColTelemetry(featuregate.NewRegistry()),
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+	srv := col.service
+	pipelines := srv.host.getPipelines()
+
+	watcher <- nil
+
+	assert.Eventually(t, func() bool {
+		return reloadCount(col, reloadResultSuccess) == 1
+	}, 2*time.Second, 200*time.Millisecond)
+	assert.Equal(t, StateRunning, col.GetState())
+	// The zPages look the new pipelines up through the host.
+	assert.NotSame(t, pipelines, srv.host.getPipelines())
+	rr := httptest.NewRecorder()
+	srv.host.handlePipelinezRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/pipelinez", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+	// The service, with its extensions and telemetry, was kept.
+	assert.Same(t, srv, col.service)
+}
+
+func TestCollectorReloadInvalidConfigKeepsPipelines(t *testing.T) {
+	enableReloadFeatureGate(t)
+
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	provider, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
+	require.NoError(t, err)
+
+	watcher := make(chan error, 1)
+	col, err := New(CollectorSettings{
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Factories:      factories,
+		ConfigProvider: &failingCfgProvider{ConfigProvider: provider, watcher: watcher},
+		telemetry:      newColTelemetry(featuregate.NewRegistry()),
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+	srv := col.service
+	pipelines := srv.host.getPipelines()
+
+	watcher <- nil
+
+	assert.Eventually(t, func() bool {
+		return reloadCount(col, reloadResultFailure) == 1
+	}, 2*time.Second, 200*time.Millisecond)
+	assert.Equal(t, StateRunning, col.GetState())
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+	assert.Same(t, srv, col.service)
+	assert.Same(t, pipelines, col.service.host.getPipelines())
+}
+
+func TestServiceReloadPipelinesStartContext(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	nopFactory := factories.Receivers["nop"]
+	var startCtxs []context.Context
+	newReceiver := func() component.Component {
+		return struct {
+			component.StartFunc
+			component.ShutdownFunc
+		}{
+			StartFunc: func(ctx context.Context, _ component.Host) error {
+				startCtxs = append(startCtxs, ctx)
+				return nil
+			},
+		}
+	}
+	factories.Receivers["nop"] = component.NewReceiverFactory(
+		"nop",
+		nopFactory.CreateDefaultConfig,
+		component.WithTracesReceiver(func(context.Context, component.ReceiverCreateSettings, component.Config, consumer.Traces) (component.TracesReceiver, error) {
+			return newReceiver(), nil
+		}, component.StabilityLevelStable),
+		component.WithMetricsReceiver(func(context.Context, component.ReceiverCreateSettings, component.Config, consumer.Metrics) (component.MetricsReceiver, error) {
+			return newReceiver(), nil
+		}, component.StabilityLevelStable),
+		component.WithLogsReceiver(func(context.Context, component.ReceiverCreateSettings, component.Config, consumer.Logs) (component.LogsReceiver, error) {
+			return newReceiver(), nil
+		}, component.StabilityLevelStable))
+	srv := createExampleService(t, factories)
+
+	require.NoError(t, srv.Start(context.Background()))
+	startCtxs = nil
+
+	// The reload context carries no HostServices, and is cancelled once the reload is done.
+	reloadCtx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, srv.reloadPipelines(reloadCtx, srv.config, time.Second))
+	cancel()
+	require.NotEmpty(t, startCtxs)
+	for _, ctx := range startCtxs {
+		assert.NoError(t, ctx.Err())
+		assert.NotNil(t, component.HostServicesFromContext(ctx).TelemetrySettings.Logger)
+	}
+
+	assert.NoError(t, srv.Shutdown(context.Background()))
+	for _, ctx := range startCtxs {
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	}
+}
+
+func TestServiceReloadPipelinesForgetsRetiredComponents(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	nopFactory := factories.Receivers["nop"]
+	shutdownErr := errors.New("shutdown failed")
+	factories.Receivers["nop"] = component.NewReceiverFactory(
+		"nop",
+		nopFactory.CreateDefaultConfig,
+		component.WithTracesReceiver(func(context.Context, component.ReceiverCreateSettings, component.Config, consumer.Traces) (component.TracesReceiver, error) {
+			return struct {
+				component.StartFunc
+				component.ShutdownFunc
+			}{
+				ShutdownFunc: func(context.Context) error { return shutdownErr },
+			}, nil
+		}, component.StabilityLevelStable),
+		component.WithMetricsReceiver(nopFactory.CreateMetricsReceiver, component.StabilityLevelStable),
+		component.WithLogsReceiver(nopFactory.CreateLogsReceiver, component.StabilityLevelStable))
+	srv := createExampleService(t, factories)
+
+	require.NoError(t, srv.Start(context.Background()))
+
+	// The retired receiver fails to shut down, the service is ready anyway with the new pipelines.
+	require.NoError(t, srv.reloadPipelines(context.Background(), srv.config, time.Second))
+	state, _ := srv.host.ServiceState()
+	assert.Equal(t, component.ServiceStateReady, state)
+
+	assert.ErrorIs(t, srv.Shutdown(context.Background()), shutdownErr)
+}
+
+func TestServiceCanReloadPipelines(t *testing.T) {
+	srv := &service{config: generateConfig()}
+
+	cfg := generateConfig()
+	cfg.Service.Pipelines[component.NewID("traces")].Processors = nil
+	assert.True(t, srv.canReloadPipelines(cfg))
+
+	cfg = generateConfig()
+	cfg.Service.Extensions = nil
+	assert.False(t, srv.canReloadPipelines(cfg))
+
+	cfg = generateConfig()
+	cfg.Service.Telemetry.Metrics.Address = "localhost:9999"
+	assert.False(t, srv.canReloadPipelines(cfg))
+}
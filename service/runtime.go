@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"math"
+	"os"
+	"runtime"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/internal/iruntime"
+)
+
+// resourceLimits are the resources available to the process, detected at startup.
+type resourceLimits struct {
+	// cpuQuota is the number of CPUs the process is allowed to use, or -1 if there is no quota.
+	cpuQuota float64
+	// totalMemory is the memory available to the process, or 0 if it could not be detected.
+	totalMemory uint64
+}
+
+// detectResourceLimits detects the CPU quota and the memory available to the process, honoring cgroup limits on linux.
+func detectResourceLimits(logger *zap.Logger) resourceLimits {
+	limits := resourceLimits{cpuQuota: -1}
+
+	quota, defined, err := iruntime.CPUQuota()
+	switch {
+	case err != nil:
+		logger.Warn("Failed to detect the CPU quota", zap.Error(err))
+	case defined:
+		limits.cpuQuota = quota
+	}
+
+	if limits.totalMemory, err = iruntime.TotalMemory(); err != nil {
+		logger.Warn("Failed to detect the total memory", zap.Error(err))
+	}
+
+	return limits
+}
+
+// setGoMaxProcs configures GOMAXPROCS from the runtime configuration or from the detected CPU quota,
+// and returns a function that restores the previous value.
+func setGoMaxProcs(logger *zap.Logger, cfg ConfigServiceRuntime, limits resourceLimits) func() {
+	prev := runtime.GOMAXPROCS(0)
+	undo := func() { runtime.GOMAXPROCS(prev) }
+
+	maxProcs := cfg.GoMaxProcs
+	if maxProcs == 0 {
+		if v := os.Getenv("GOMAXPROCS"); v != "" {
+			logger.Info("Honoring the GOMAXPROCS environment variable", zap.String("GOMAXPROCS", v))
+			return undo
+		}
+		if limits.cpuQuota <= 0 {
+			return undo
+		}
+		maxProcs = maxProcsFromQuota(limits.cpuQuota)
+	}
+
+	runtime.GOMAXPROCS(maxProcs)
+	logger.Info("Set GOMAXPROCS", zap.Int("GOMAXPROCS", maxProcs), zap.Float64("cpu_quota", limits.cpuQuota))
+	return undo
+}
+
+// maxProcsFromQuota rounds down the CPU quota, with a minimum of 1.
+func maxProcsFromQuota(quota float64) int {
+	maxProcs := int(math.Floor(quota))
+	if maxProcs < 1 {
+		return 1
+	}
+	return maxProcs
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestMaxProcsFromQuota(t *testing.T) {
+	tests := []struct {
+		quota    float64
+		expected int
+	}{
+		{quota: 0.5, expected: 1},
+		{quota: 1, expected: 1},
+		{quota: 2.7, expected: 2},
+		{quota: 16, expected: 16},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, maxProcsFromQuota(tt.quota), tt.quota)
+	}
+}
+
+func TestSetGoMaxProcs(t *testing.T) {
+	prev := runtime.GOMAXPROCS(0)
+
+	tests := []struct {
+		name     string
+		env      string
+		cfg      ConfigServiceRuntime
+		limits   resourceLimits
+		expected int
+	}{
+		{
+			name:     "no_quota",
+			limits:   resourceLimits{cpuQuota: -1},
+			expected: prev,
+		},
+		{
+			name:     "quota",
+			limits:   resourceLimits{cpuQuota: 2.7},
+			expected: 2,
+		},
+		{
+			name:     "env_overrides_quota",
+			env:      "3",
+			limits:   resourceLimits{cpuQuota: 2.7},
+			expected: prev,
+		},
+		{
+			name:     "config_overrides_quota_and_env",
+			env:      "3",
+			cfg:      ConfigServiceRuntime{GoMaxProcs: 5},
+			limits:   resourceLimits{cpuQuota: 2.7},
+			expected: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GOMAXPROCS", tt.env)
+			undo := setGoMaxProcs(zap.NewNop(), tt.cfg, tt.limits)
+			assert.Equal(t, tt.expected, runtime.GOMAXPROCS(0))
+			undo()
+			assert.Equal(t, prev, runtime.GOMAXPROCS(0))
+		})
+	}
+}
@@ -17,7 +17,10 @@ package service // import "go.opentelemetry.io/collector/service"
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"reflect"
 	"runtime"
+	"time"
 
 	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/multierr"
@@ -26,6 +29,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/internal/diagnostics"
+	"go.opentelemetry.io/collector/service/internal/eventbus"
 	"go.opentelemetry.io/collector/service/internal/pipelines"
 	"go.opentelemetry.io/collector/service/internal/proctelemetry"
 	"go.opentelemetry.io/collector/service/telemetry"
@@ -39,6 +44,17 @@ type service struct {
 	telemetrySettings    component.TelemetrySettings
 	host                 *serviceHost
 	telemetryInitializer *telemetryInitializer
+	resourceLimits       resourceLimits
+	restoreGoMaxProcs    func()
+	diagnostics          *diagnostics.Reporter
+	adminServer          *http.Server
+	healthServer         *http.Server
+	health               *healthTracker
+	// componentsCtx is the context passed to the Start of the components, carrying the HostServices. It is
+	// reused to start the pipelines rebuilt by reloadPipelines.
+	componentsCtx context.Context
+	// cancelComponents cancels the context passed to the Start of the components, once the shutdown begins.
+	cancelComponents context.CancelFunc
 }
 
 func newService(set *settings) (*service, error) {
@@ -51,11 +67,14 @@ func newService(set *settings) (*service, error) {
 			asyncErrorChannel: set.AsyncErrorChannel,
 		},
 		telemetryInitializer: set.telemetry,
+		health:               newHealthTracker(),
 	}
 
 	var err error
 	srv.telemetry, err = telemetry.New(context.Background(), telemetry.Settings{
-		ZapOptions: set.LoggingOptions}, set.Config.Service.Telemetry)
+		ZapOptions: set.LoggingOptions,
+		BuildInfo:  set.BuildInfo,
+	}, set.Config.Service.Telemetry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get logger: %w", err)
 	}
@@ -66,13 +85,27 @@ func newService(set *settings) (*service, error) {
 		MetricsLevel:   set.Config.Service.Telemetry.Metrics.Level,
 	}
 
+	srv.host.eventBus = eventbus.New(srv.telemetrySettings.Logger)
+	srv.host.state = newServiceState(srv.telemetrySettings.Logger, func(state component.ServiceState, timestamp time.Time) {
+		srv.host.extensions.NotifyServiceStateChange(state, timestamp)
+	})
+
+	srv.resourceLimits = detectResourceLimits(srv.telemetrySettings.Logger)
+	srv.restoreGoMaxProcs = setGoMaxProcs(srv.telemetrySettings.Logger, set.Config.Service.Runtime, srv.resourceLimits)
+
 	if err = srv.telemetryInitializer.init(set.BuildInfo, srv.telemetrySettings.Logger, set.Config.Service.Telemetry, set.AsyncErrorChannel); err != nil {
+		srv.restoreGoMaxProcs()
 		return nil, fmt.Errorf("failed to initialize telemetry: %w", err)
 	}
 	srv.telemetrySettings.MeterProvider = srv.telemetryInitializer.mp
 
+	if interval := set.Config.Service.Telemetry.Diagnostics.Interval; interval > 0 {
+		srv.diagnostics = diagnostics.NewReporter(srv.telemetrySettings.Logger, interval)
+	}
+
 	// process the configuration and initialize the pipeline
 	if err = srv.initExtensionsAndPipeline(set); err != nil {
+		srv.restoreGoMaxProcs()
 		// If pipeline initialization fails then shut down the telemetry server
 		if shutdownErr := srv.telemetryInitializer.shutdown(); shutdownErr != nil {
 			err = multierr.Append(err, fmt.Errorf("failed to shutdown collector telemetry: %w", shutdownErr))
@@ -89,19 +122,40 @@ func (srv *service) Start(ctx context.Context) error {
 	srv.telemetrySettings.Logger.Info("Starting "+srv.buildInfo.Command+"...",
 		zap.String("Version", srv.buildInfo.Version),
 		zap.Int("NumCPU", runtime.NumCPU()),
+		zap.Int("GOMAXPROCS", runtime.GOMAXPROCS(0)),
 	)
 
+	ctx = component.ContextWithHostServices(ctx, component.HostServices{TelemetrySettings: srv.telemetrySettings})
+	ctx, srv.cancelComponents = context.WithCancel(ctx)
+	srv.componentsCtx = ctx
+
+	// The health endpoint is served while the components start, so that probes see that the service is starting.
+	if err := srv.startHealthServer(); err != nil {
+		return err
+	}
+
 	if err := srv.host.extensions.Start(ctx, srv.host); err != nil {
 		return fmt.Errorf("failed to start extensions: %w", err)
 	}
 
-	if err := srv.host.pipelines.StartAll(ctx, srv.host); err != nil {
+	if err := srv.startAdminServer(); err != nil {
+		return err
+	}
+
+	if err := srv.host.getPipelines().StartAll(ctx, srv.host); err != nil {
 		return fmt.Errorf("cannot start pipelines: %w", err)
 	}
 
+	srv.waitForExporters(ctx, srv.config)
+
 	if err := srv.host.extensions.NotifyPipelineReady(); err != nil {
 		return err
 	}
+	srv.host.state.set(component.ServiceStateReady)
+
+	if srv.diagnostics != nil {
+		srv.diagnostics.Start()
+	}
 
 	srv.telemetrySettings.Logger.Info("Everything is ready. Begin running and processing data.")
 	return nil
@@ -113,12 +167,25 @@ func (srv *service) Shutdown(ctx context.Context) error {
 
 	// Begin shutdown sequence.
 	srv.telemetrySettings.Logger.Info("Starting shutdown...")
+	srv.host.state.set(component.ServiceStateStopping)
+	if srv.cancelComponents != nil {
+		srv.cancelComponents()
+	}
+
+	if srv.diagnostics != nil {
+		srv.diagnostics.Shutdown()
+	}
+
+	if err := srv.shutdownAdminServer(); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown admin server: %w", err))
+	}
 
 	if err := srv.host.extensions.NotifyPipelineNotReady(); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to notify that pipeline is not ready: %w", err))
 	}
 
-	if err := srv.host.pipelines.ShutdownAll(ctx); err != nil {
+	// Each component of the pipelines and each extension is given its own shutdown timeout.
+	if err := srv.host.getPipelines().ShutdownAll(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown pipelines: %w", err))
 	}
 
@@ -126,7 +193,14 @@ func (srv *service) Shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown extensions: %w", err))
 	}
 
+	srv.host.eventBus.Shutdown()
+
+	if err := srv.shutdownHealthServer(); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown health server: %w", err))
+	}
+
 	srv.telemetrySettings.Logger.Info("Shutdown complete.")
+	srv.restoreGoMaxProcs()
 
 	if err := srv.telemetry.Shutdown(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown telemetry: %w", err))
@@ -137,38 +211,139 @@ func (srv *service) Shutdown(ctx context.Context) error {
 }
 
 func (srv *service) initExtensionsAndPipeline(set *settings) error {
+	if err := checkStabilityPolicy(srv.telemetrySettings.Logger, srv.config, srv.host.factories); err != nil {
+		return err
+	}
+	srv.host.configWarnings = collectConfigWarnings(srv.config)
+	logConfigWarnings(srv.telemetrySettings.Logger, srv.host.configWarnings)
+
 	var err error
 	extensionsSettings := extensions.Settings{
 		Telemetry: srv.telemetrySettings,
 		BuildInfo: srv.buildInfo,
 		Configs:   srv.config.Extensions,
 		Factories: srv.host.factories.Extensions,
+
+		ShutdownTimeout:        srv.config.Service.Shutdown.Timeout,
+		ShutdownDumpGoroutines: srv.config.Service.Shutdown.DumpGoroutines,
+
+		ReportFatalError: srv.host.ReportFatalError,
 	}
 	if srv.host.extensions, err = extensions.New(context.Background(), extensionsSettings, srv.config.Service.Extensions); err != nil {
 		return fmt.Errorf("failed build extensions: %w", err)
 	}
 
-	pipelinesSettings := pipelines.Settings{
+	builtPipelines, err := pipelines.Build(context.Background(), srv.pipelinesSettings(srv.config))
+	if err != nil {
+		return fmt.Errorf("cannot build pipelines: %w", err)
+	}
+	srv.host.setPipelines(builtPipelines)
+
+	if set.Config.Service.Telemetry.Metrics.Level != configtelemetry.LevelNone && set.Config.Service.Telemetry.Metrics.Address != "" {
+		// The process telemetry initialization requires the ballast size, which is available after the extensions are initialized.
+		if err = proctelemetry.RegisterProcessMetrics(srv.telemetryInitializer.ocRegistry, getBallastSize(srv.host)); err != nil {
+			return fmt.Errorf("failed to register process metrics: %w", err)
+		}
+		if err = proctelemetry.RegisterResourceLimitsMetrics(srv.telemetryInitializer.ocRegistry, srv.resourceLimits.cpuQuota, srv.resourceLimits.totalMemory); err != nil {
+			return fmt.Errorf("failed to register resource limits metrics: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (srv *service) pipelinesSettings(cfg *Config) pipelines.Settings {
+	return pipelines.Settings{
 		Telemetry:          srv.telemetrySettings,
 		BuildInfo:          srv.buildInfo,
 		ReceiverFactories:  srv.host.factories.Receivers,
-		ReceiverConfigs:    srv.config.Receivers,
+		ReceiverConfigs:    cfg.Receivers,
 		ProcessorFactories: srv.host.factories.Processors,
-		ProcessorConfigs:   srv.config.Processors,
+		ProcessorConfigs:   cfg.Processors,
 		ExporterFactories:  srv.host.factories.Exporters,
-		ExporterConfigs:    srv.config.Exporters,
-		PipelineConfigs:    srv.config.Service.Pipelines,
+		ExporterConfigs:    cfg.Exporters,
+		ConnectorFactories: srv.host.factories.Connectors,
+		ConnectorConfigs:   cfg.Connectors,
+		PipelineConfigs:    cfg.Service.Pipelines,
+
+		ReportComponentStatus: srv.reportComponentStatus,
+
+		StartParallel: cfg.Service.Startup.Parallel,
+		StartTimeout:  cfg.Service.Startup.Timeout,
+
+		ShutdownTimeout:        cfg.Service.Shutdown.Timeout,
+		ShutdownDumpGoroutines: cfg.Service.Shutdown.DumpGoroutines,
+	}
+}
+
+// canReloadPipelines returns true if the pipelines of the service can be replaced with the ones of the given
+// configuration without restarting the service, i.e. if only the settings of the pipelines changed.
+func (srv *service) canReloadPipelines(cfg *Config) bool {
+	return reflect.DeepEqual(srv.config.Extensions, cfg.Extensions) &&
+		reflect.DeepEqual(srv.config.Service.Extensions, cfg.Service.Extensions) &&
+		reflect.DeepEqual(srv.config.Service.Telemetry, cfg.Service.Telemetry) &&
+		reflect.DeepEqual(srv.config.Service.Runtime, cfg.Service.Runtime) &&
+		reflect.DeepEqual(srv.config.Service.Shutdown, cfg.Service.Shutdown) &&
+		reflect.DeepEqual(srv.config.Service.Admin, cfg.Service.Admin)
+}
+
+// reloadPipelines replaces the pipelines of the running service with the ones of the given configuration, keeping
+// the extensions and the telemetry. The new pipelines are built before the current ones are stopped, so that the
+// current pipelines keep running if they cannot be built. The current pipelines are drained: their receivers are
+// stopped first, then the queues of their exporters are flushed for up to flushTimeout. The new pipelines are started
+// with the same context as the components started by Start, ctx only bounds the reload.
+func (srv *service) reloadPipelines(ctx context.Context, cfg *Config, flushTimeout time.Duration) error {
+	if err := checkStabilityPolicy(srv.telemetrySettings.Logger, cfg, srv.host.factories); err != nil {
+		return err
 	}
-	if srv.host.pipelines, err = pipelines.Build(context.Background(), pipelinesSettings); err != nil {
+	newPipelines, err := pipelines.Build(ctx, srv.pipelinesSettings(cfg))
+	if err != nil {
 		return fmt.Errorf("cannot build pipelines: %w", err)
 	}
 
-	if set.Config.Service.Telemetry.Metrics.Level != configtelemetry.LevelNone && set.Config.Service.Telemetry.Metrics.Address != "" {
-		// The process telemetry initialization requires the ballast size, which is available after the extensions are initialized.
-		if err = proctelemetry.RegisterProcessMetrics(srv.telemetryInitializer.ocRegistry, getBallastSize(srv.host)); err != nil {
-			return fmt.Errorf("failed to register process metrics: %w", err)
-		}
+	srv.host.state.set(component.ServiceStateStarting)
+	if err = srv.host.extensions.NotifyPipelineNotReady(); err != nil {
+		return fmt.Errorf("failed to notify that pipeline is not ready: %w", err)
 	}
+	if err = srv.host.getPipelines().DrainAndShutdownAll(ctx, flushTimeout); err != nil {
+		srv.telemetrySettings.Logger.Warn("Failed to shutdown the retiring pipelines", zap.Error(err))
+	}
+	srv.host.state.forgetComponents()
 
+	srv.config = cfg
+	srv.host.setPipelines(newPipelines)
+	if err = srv.host.getPipelines().StartAll(srv.componentsCtx, srv.host); err != nil {
+		return fmt.Errorf("cannot start pipelines: %w", err)
+	}
+	srv.waitForExporters(ctx, cfg)
+	if err = srv.host.extensions.NotifyPipelineReady(); err != nil {
+		return err
+	}
+	srv.host.state.set(component.ServiceStateReady)
 	return nil
 }
+
+// waitForExporters waits for the exporters of the started pipelines to connect to their destination,
+// if configured, and logs the ones that are still not connected once the wait is over.
+func (srv *service) waitForExporters(ctx context.Context, cfg *Config) {
+	if cfg.Service.Startup.WaitForExporters <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, cfg.Service.Startup.WaitForExporters)
+	defer cancel()
+	if err := srv.host.getPipelines().WaitForExporters(ctx); err != nil {
+		srv.telemetrySettings.Logger.Warn("Exporters are not connected, the pipelines are ready anyway", zap.Error(err))
+	}
+}
+
+// reportComponentStatus tracks the status changes of the components of the pipelines in the state of the service
+// and for the health endpoint, and notifies the extensions about them. A fatal error is reported to the host,
+// as with ReportFatalError.
+func (srv *service) reportComponentStatus(source *component.InstanceID, event *component.StatusEvent) {
+	srv.host.state.componentStatusChanged(source, event)
+	srv.health.componentStatusChanged(source, event)
+	srv.host.extensions.NotifyComponentStatusChange(source, event)
+	if event.Status() == component.StatusFatalError {
+		srv.host.ReportFatalError(event.Err())
+	}
+}
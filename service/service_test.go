@@ -16,6 +16,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"path/filepath"
@@ -26,6 +27,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/featuregate"
 )
 
@@ -93,6 +95,122 @@ func TestServiceGetExporters(t *testing.T) {
 
 // TestServiceTelemetryCleanupOnError tests that if newService errors due to an invalid config telemetry is cleaned up
 // and another service with a valid config can be started right after.
+func TestServiceEventBus(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	srv := createExampleService(t, factories)
+
+	assert.NoError(t, srv.Start(context.Background()))
+
+	topic := component.NewTopic[string]("test/targets")
+	received := make(chan string, 1)
+	_, ok := component.Subscribe(srv.host, topic, func(target string) {
+		received <- target
+	})
+	require.True(t, ok)
+	assert.True(t, component.Publish(srv.host, topic, "localhost:8888"))
+	assert.Equal(t, "localhost:8888", <-received)
+
+	assert.NoError(t, srv.Shutdown(context.Background()))
+	// The subscriptions are removed on shutdown.
+	assert.True(t, component.Publish(srv.host, topic, "localhost:9999"))
+	assert.Len(t, received, 0)
+}
+
+func TestServiceState(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	srv := createExampleService(t, factories)
+
+	state, _ := srv.host.ServiceState()
+	assert.Equal(t, component.ServiceStateStarting, state)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	state, _ = srv.host.ServiceState()
+	assert.Equal(t, component.ServiceStateReady, state)
+
+	assert.NoError(t, srv.Shutdown(context.Background()))
+	state, _ = srv.host.ServiceState()
+	assert.Equal(t, component.ServiceStateStopping, state)
+}
+
+func TestServiceStartContext(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	var startCtx context.Context
+	factories.Extensions["nop"] = component.NewExtensionFactory(
+		"nop",
+		factories.Extensions["nop"].CreateDefaultConfig,
+		func(context.Context, component.ExtensionCreateSettings, component.Config) (component.Extension, error) {
+			return struct {
+				component.StartFunc
+				component.ShutdownFunc
+			}{
+				StartFunc: func(ctx context.Context, _ component.Host) error {
+					startCtx = ctx
+					return nil
+				},
+			}, nil
+		},
+		component.StabilityLevelStable)
+	srv := createExampleService(t, factories)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	require.NotNil(t, startCtx)
+	assert.NoError(t, startCtx.Err())
+	assert.NotNil(t, component.HostServicesFromContext(startCtx).TelemetrySettings.Logger)
+
+	// The context is cancelled once the shutdown begins.
+	assert.NoError(t, srv.Shutdown(context.Background()))
+	assert.ErrorIs(t, startCtx.Err(), context.Canceled)
+}
+
+func TestServiceReportFatalErrorEvent(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	var extReportStatus, rcvReportStatus component.StatusFunc
+	nopExtFactory := factories.Extensions["nop"]
+	factories.Extensions["nop"] = component.NewExtensionFactory(
+		"nop",
+		nopExtFactory.CreateDefaultConfig,
+		func(ctx context.Context, set component.ExtensionCreateSettings, cfg component.Config) (component.Extension, error) {
+			extReportStatus = set.ReportComponentStatus
+			return nopExtFactory.CreateExtension(ctx, set, cfg)
+		},
+		component.StabilityLevelStable)
+	nopRcvFactory := factories.Receivers["nop"]
+	factories.Receivers["nop"] = component.NewReceiverFactory(
+		"nop",
+		nopRcvFactory.CreateDefaultConfig,
+		component.WithTracesReceiver(func(ctx context.Context, set component.ReceiverCreateSettings, cfg component.Config, next consumer.Traces) (component.TracesReceiver, error) {
+			rcvReportStatus = set.ReportComponentStatus
+			return nopRcvFactory.CreateTracesReceiver(ctx, set, cfg, next)
+		}, component.StabilityLevelStable),
+		component.WithMetricsReceiver(nopRcvFactory.CreateMetricsReceiver, component.StabilityLevelStable),
+		component.WithLogsReceiver(nopRcvFactory.CreateLogsReceiver, component.StabilityLevelStable))
+	srv := createExampleService(t, factories)
+	asyncErrorChannel := make(chan error, 1)
+	srv.host.asyncErrorChannel = asyncErrorChannel
+
+	require.NoError(t, srv.Start(context.Background()))
+	require.NotNil(t, extReportStatus)
+	require.NotNil(t, rcvReportStatus)
+
+	// Non fatal errors are not reported to the host.
+	rcvReportStatus(component.NewPermanentErrorEvent(errors.New("bind")))
+	assert.Len(t, asyncErrorChannel, 0)
+
+	rcvErr := errors.New("receiver failed")
+	rcvReportStatus(component.NewFatalErrorEvent(rcvErr))
+	assert.Equal(t, rcvErr, <-asyncErrorChannel)
+
+	extErr := errors.New("extension failed")
+	extReportStatus(component.NewFatalErrorEvent(extErr))
+	assert.Equal(t, extErr, <-asyncErrorChannel)
+
+	assert.NoError(t, srv.Shutdown(context.Background()))
+}
+
 func TestServiceTelemetryCleanupOnError(t *testing.T) {
 	factories, err := componenttest.NopFactories()
 	require.NoError(t, err)
@@ -165,7 +283,7 @@ func TestServiceTelemetryReusable(t *testing.T) {
 	require.NoError(t, err)
 
 	// URL of the telemetry service metrics endpoint
-	telemetryURL := fmt.Sprintf("http://%s/metrics", telemetry.server.Addr)
+	telemetryURL := fmt.Sprintf("http://%s/metrics", telemetry.servers[0].Addr)
 
 	// Start the service
 	require.NoError(t, srvOne.Start(context.Background()))
@@ -203,12 +321,15 @@ func TestServiceTelemetryReusable(t *testing.T) {
 	require.NoError(t, srvTwo.Shutdown(context.Background()))
 }
 
-func createExampleService(t *testing.T, factories component.Factories) *service {
+func createExampleService(t *testing.T, factories component.Factories, cfgFns ...func(*Config)) *service {
 	// Read yaml config from file
 	prov, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
 	require.NoError(t, err)
 	cfg, err := prov.Get(context.Background(), factories)
 	require.NoError(t, err)
+	for _, fn := range cfgFns {
+		fn(cfg)
+	}
 
 	telemetry := newColTelemetry(featuregate.NewRegistry())
 	srv, err := newService(&settings{
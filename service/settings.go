@@ -65,6 +65,14 @@ type CollectorSettings struct {
 	// SkipSettingGRPCLogger avoids setting the grpc logger
 	SkipSettingGRPCLogger bool
 
+	// ControlEndpoint is the localhost address serving the "/-/reload" and "/-/quit" endpoints,
+	// which respectively reload the configuration and gracefully shut down the collector.
+	// The endpoints are disabled if empty.
+	ControlEndpoint string
+
+	// ControlToken, if set, is the bearer token that requests to the control endpoints must provide.
+	ControlToken string
+
 	// For testing purpose only.
 	telemetry *telemetryInitializer
 }
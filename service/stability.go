@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/internal/components"
+)
+
+const (
+	stabilityActionWarn   = "warn"
+	stabilityActionRefuse = "refuse"
+)
+
+// parseStabilityLevel returns the stability level with the given case-insensitive name.
+func parseStabilityLevel(name string) (component.StabilityLevel, error) {
+	for level := component.StabilityLevelUnmaintained; level <= component.StabilityLevelStable; level++ {
+		if strings.EqualFold(name, level.String()) {
+			return level, nil
+		}
+	}
+	return component.StabilityLevelUndefined, fmt.Errorf("service component stability policy has unknown min_level %q", name)
+}
+
+// checkStabilityPolicy reports the configured components that are below the minimum stability level
+// of the policy. Depending on the action of the policy, it logs a warning or returns an error.
+func checkStabilityPolicy(logger *zap.Logger, cfg *Config, factories component.Factories) error {
+	policy := cfg.Service.ComponentStabilityPolicy
+	if policy.MinLevel == "" {
+		return nil
+	}
+	minLevel, err := parseStabilityLevel(policy.MinLevel)
+	if err != nil {
+		return err
+	}
+
+	var violations []string
+	check := func(kind string, id component.ID, signal component.DataType, level component.StabilityLevel) {
+		if level >= minLevel {
+			return
+		}
+		violation := fmt.Sprintf("%s %q is %s", kind, id, level)
+		if signal != "" {
+			violation = fmt.Sprintf("%s %q is %s for %s", kind, id, level, signal)
+		}
+		violations = append(violations, violation)
+	}
+
+	for _, id := range cfg.Service.Extensions {
+		if factory, ok := factories.Extensions[id.Type()]; ok {
+			check("extension", id, "", factory.ExtensionStability())
+		}
+	}
+	connExporterSignals := make(map[component.ID]map[component.DataType]bool)
+	connReceiverSignals := make(map[component.ID]map[component.DataType]bool)
+	addSignal := func(signals map[component.ID]map[component.DataType]bool, id component.ID, signal component.DataType) {
+		if signals[id] == nil {
+			signals[id] = make(map[component.DataType]bool)
+		}
+		signals[id][signal] = true
+	}
+	for pipelineID, pipeline := range cfg.Service.Pipelines {
+		signal := pipelineID.Type()
+		for _, id := range pipeline.Receivers {
+			if _, isConn := cfg.Connectors[id]; isConn {
+				addSignal(connReceiverSignals, id, signal)
+				continue
+			}
+			if factory, ok := factories.Receivers[id.Type()]; ok {
+				check("receiver", id, signal, components.ReceiverStability(factory, signal))
+			}
+		}
+		for _, id := range pipeline.Processors {
+			if factory, ok := factories.Processors[id.Type()]; ok {
+				check("processor", id, signal, components.ProcessorStability(factory, signal))
+			}
+		}
+		for _, id := range pipeline.Exporters {
+			if _, isConn := cfg.Connectors[id]; isConn {
+				addSignal(connExporterSignals, id, signal)
+				continue
+			}
+			if factory, ok := factories.Exporters[id.Type()]; ok {
+				check("exporter", id, signal, components.ExporterStability(factory, signal))
+			}
+		}
+	}
+	// A connector is created for every pair of signals it joins, that it supports.
+	for id, expSignals := range connExporterSignals {
+		factory, ok := factories.Connectors[id.Type()]
+		if !ok {
+			continue
+		}
+		for expSignal := range expSignals {
+			for rcvSignal := range connReceiverSignals[id] {
+				if level := components.ConnectorStability(factory, expSignal, rcvSignal); level != component.StabilityLevelUndefined {
+					check("connector", id, component.DataType(string(expSignal)+" to "+string(rcvSignal)), level)
+				}
+			}
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	// A component can be used in several pipelines of the same signal.
+	sort.Strings(violations)
+	violations = dedupSorted(violations)
+
+	if policy.Action == stabilityActionRefuse {
+		return errors.New("components below the minimum stability level " + minLevel.String() + ": " + strings.Join(violations, ", "))
+	}
+	for _, violation := range violations {
+		logger.Warn("Component is below the minimum stability level",
+			zap.String("min_level", minLevel.String()),
+			zap.String("component", violation))
+	}
+	return nil
+}
+
+func dedupSorted(values []string) []string {
+	out := values[:0]
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestParseStabilityLevel(t *testing.T) {
+	level, err := parseStabilityLevel("beta")
+	require.NoError(t, err)
+	assert.Equal(t, component.StabilityLevelBeta, level)
+
+	level, err = parseStabilityLevel("Stable")
+	require.NoError(t, err)
+	assert.Equal(t, component.StabilityLevelStable, level)
+
+	_, err = parseStabilityLevel("undefined")
+	assert.Error(t, err)
+}
+
+func TestCheckStabilityPolicy(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	nopFactory := componenttest.NewNopReceiverFactory()
+	alphaFactory := component.NewReceiverFactory("alpha", nopFactory.CreateDefaultConfig,
+		component.WithTracesReceiver(nopFactory.CreateTracesReceiver, component.StabilityLevelAlpha))
+	factories.Receivers[alphaFactory.Type()] = alphaFactory
+
+	cfg := &Config{
+		Service: ConfigService{
+			Extensions: []component.ID{component.NewID("nop")},
+			Pipelines: map[component.ID]*ConfigServicePipeline{
+				component.NewID("traces"): {
+					Receivers:  []component.ID{component.NewID("nop"), component.NewID("alpha")},
+					Processors: []component.ID{component.NewID("nop")},
+					Exporters:  []component.ID{component.NewID("nop")},
+				},
+				component.NewIDWithName("traces", "2"): {
+					Receivers: []component.ID{component.NewID("alpha")},
+					Exporters: []component.ID{component.NewID("nop")},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		policy       ConfigServiceStabilityPolicy
+		expectedErr  string
+		expectedLogs int
+	}{
+		{
+			name: "no policy",
+		},
+		{
+			name:   "all components above min level",
+			policy: ConfigServiceStabilityPolicy{MinLevel: "alpha", Action: stabilityActionRefuse},
+		},
+		{
+			name:         "warn",
+			policy:       ConfigServiceStabilityPolicy{MinLevel: "beta"},
+			expectedLogs: 1,
+		},
+		{
+			name:        "refuse",
+			policy:      ConfigServiceStabilityPolicy{MinLevel: "beta", Action: stabilityActionRefuse},
+			expectedErr: `components below the minimum stability level Beta: receiver "alpha" is Alpha for traces`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.WarnLevel)
+			cfg.Service.ComponentStabilityPolicy = tt.policy
+			err := checkStabilityPolicy(zap.New(core), cfg, factories)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedLogs, logs.Len())
+		})
+	}
+}
+
+func TestCheckStabilityPolicyConnector(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	nopFactory := componenttest.NewNopConnectorFactory()
+	alphaFactory := component.NewConnectorFactory("alpha", nopFactory.CreateDefaultConfig,
+		component.WithTracesToMetrics(nopFactory.CreateTracesToMetrics, component.StabilityLevelAlpha))
+	factories.Connectors[alphaFactory.Type()] = alphaFactory
+
+	cfg := &Config{
+		Connectors: map[component.ID]component.Config{
+			component.NewID("alpha"): alphaFactory.CreateDefaultConfig(),
+		},
+		Service: ConfigService{
+			Pipelines: map[component.ID]*ConfigServicePipeline{
+				component.NewID("traces"): {
+					Receivers: []component.ID{component.NewID("nop")},
+					Exporters: []component.ID{component.NewID("alpha")},
+				},
+				component.NewID("metrics"): {
+					Receivers: []component.ID{component.NewID("alpha")},
+					Exporters: []component.ID{component.NewID("nop")},
+				},
+				// Not supported by the connector, so not instantiated.
+				component.NewID("logs"): {
+					Receivers: []component.ID{component.NewID("alpha")},
+					Exporters: []component.ID{component.NewID("nop")},
+				},
+			},
+			ComponentStabilityPolicy: ConfigServiceStabilityPolicy{MinLevel: "beta", Action: stabilityActionRefuse},
+		},
+	}
+	assert.EqualError(t, checkStabilityPolicy(zap.NewNop(), cfg, factories),
+		`components below the minimum stability level Beta: connector "alpha" is Alpha for traces to metrics`)
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// serviceState is the state machine of the service: Starting → Ready ⇄ Degraded → Stopping.
+// A ready service is degraded while some of its components report an error status.
+type serviceState struct {
+	logger *zap.Logger
+	notify func(component.ServiceState, time.Time)
+
+	// notifyMu serializes the state changes with their notifications, so that they are notified in order,
+	// while mu only protects the state, so that it can be queried from the notifications.
+	notifyMu  sync.Mutex
+	mu        sync.Mutex
+	state     component.ServiceState
+	since     time.Time
+	erroneous map[*component.InstanceID]struct{}
+}
+
+func newServiceState(logger *zap.Logger, notify func(component.ServiceState, time.Time)) *serviceState {
+	return &serviceState{
+		logger:    logger,
+		notify:    notify,
+		state:     component.ServiceStateStarting,
+		since:     time.Now(),
+		erroneous: make(map[*component.InstanceID]struct{}),
+	}
+}
+
+// current returns the current state, and the time at which the service entered it.
+func (s *serviceState) current() (component.ServiceState, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state, s.since
+}
+
+// set moves the service to the given state. A service set ready is degraded if some components report an error.
+func (s *serviceState) set(state component.ServiceState) {
+	s.notifyMu.Lock()
+	defer s.notifyMu.Unlock()
+
+	s.mu.Lock()
+	if state == component.ServiceStateReady && len(s.erroneous) > 0 {
+		state = component.ServiceStateDegraded
+	}
+	changed, timestamp := s.transition(state)
+	s.mu.Unlock()
+
+	if changed {
+		s.notify(state, timestamp)
+	}
+}
+
+// componentStatusChanged tracks the components reporting an error, to degrade or restore a ready service.
+func (s *serviceState) componentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	s.notifyMu.Lock()
+	defer s.notifyMu.Unlock()
+
+	s.mu.Lock()
+	switch event.Status() {
+	case component.StatusRecoverableError, component.StatusPermanentError, component.StatusFatalError:
+		s.erroneous[source] = struct{}{}
+	default:
+		delete(s.erroneous, source)
+	}
+
+	state := s.state
+	switch {
+	case state == component.ServiceStateReady && len(s.erroneous) > 0:
+		state = component.ServiceStateDegraded
+	case state == component.ServiceStateDegraded && len(s.erroneous) == 0:
+		state = component.ServiceStateReady
+	}
+	changed, timestamp := s.transition(state)
+	s.mu.Unlock()
+
+	if changed {
+		s.notify(state, timestamp)
+	}
+}
+
+// forgetComponents forgets the errors reported by the components tracked so far, e.g. by the components of the
+// pipelines replaced by a reload, which do not report their recovery once they are stopped.
+func (s *serviceState) forgetComponents() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.erroneous = make(map[*component.InstanceID]struct{})
+}
+
+// transition moves the service to the given state, and returns whether it changed and when.
+// It must be called with mu held.
+func (s *serviceState) transition(state component.ServiceState) (bool, time.Time) {
+	if state == s.state {
+		return false, s.since
+	}
+	now := time.Now()
+	s.logger.Info("Service state changed",
+		zap.Stringer("from", s.state),
+		zap.Stringer("to", state),
+		zap.Time("since", s.since),
+		zap.Duration("duration", now.Sub(s.since)))
+	s.state, s.since = state, now
+	return true, now
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestServiceStateTransitions(t *testing.T) {
+	var notified []component.ServiceState
+	st := newServiceState(zap.NewNop(), func(state component.ServiceState, _ time.Time) {
+		notified = append(notified, state)
+	})
+
+	exporter := &component.InstanceID{ID: component.NewID("exporter"), Kind: component.KindExporter}
+	receiver := &component.InstanceID{ID: component.NewID("receiver"), Kind: component.KindReceiver}
+
+	// The errors reported while starting do not change the state.
+	st.componentStatusChanged(exporter, component.NewRecoverableErrorEvent(errors.New("unavailable")))
+	state, _ := st.current()
+	assert.Equal(t, component.ServiceStateStarting, state)
+
+	// The service is degraded as soon as it is ready, until all the components recover.
+	st.set(component.ServiceStateReady)
+	st.componentStatusChanged(receiver, component.NewPermanentErrorEvent(errors.New("bind")))
+	st.componentStatusChanged(exporter, component.NewStatusEvent(component.StatusOK))
+	state, _ = st.current()
+	assert.Equal(t, component.ServiceStateDegraded, state)
+	st.componentStatusChanged(receiver, component.NewStatusEvent(component.StatusOK))
+	state, _ = st.current()
+	assert.Equal(t, component.ServiceStateReady, state)
+
+	st.set(component.ServiceStateStopping)
+	st.componentStatusChanged(receiver, component.NewPermanentErrorEvent(errors.New("shutdown")))
+	state, since := st.current()
+	assert.Equal(t, component.ServiceStateStopping, state)
+	assert.WithinDuration(t, time.Now(), since, time.Second)
+
+	assert.Equal(t, []component.ServiceState{
+		component.ServiceStateDegraded,
+		component.ServiceStateReady,
+		component.ServiceStateStopping,
+	}, notified)
+}
+
+func TestServiceStateNotifiedOnce(t *testing.T) {
+	count := 0
+	st := newServiceState(zap.NewNop(), func(component.ServiceState, time.Time) {
+		count++
+	})
+	st.set(component.ServiceStateStarting)
+	assert.Equal(t, 0, count)
+	st.set(component.ServiceStateReady)
+	st.set(component.ServiceStateReady)
+	assert.Equal(t, 1, count)
+}
+
+func TestServiceStateForgetComponents(t *testing.T) {
+	st := newServiceState(zap.NewNop(), func(component.ServiceState, time.Time) {})
+	receiver := &component.InstanceID{ID: component.NewID("receiver"), Kind: component.KindReceiver}
+
+	st.set(component.ServiceStateReady)
+	st.componentStatusChanged(receiver, component.NewPermanentErrorEvent(errors.New("shutdown")))
+	state, _ := st.current()
+	assert.Equal(t, component.ServiceStateDegraded, state)
+
+	// The service is not degraded by the components it forgot once it is ready again.
+	st.set(component.ServiceStateStarting)
+	st.forgetComponents()
+	st.set(component.ServiceStateReady)
+	state, _ = st.current()
+	assert.Equal(t, component.ServiceStateReady, state)
+}
@@ -36,16 +36,20 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
 	otelview "go.opentelemetry.io/otel/sdk/metric/view"
 	"go.opentelemetry.io/otel/sdk/resource"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/processor/batchprocessor"
 	semconv "go.opentelemetry.io/collector/semconv/v1.5.0"
+	"go.opentelemetry.io/collector/service/internal/metricpush"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
 
@@ -69,7 +73,8 @@ type telemetryInitializer struct {
 	ocRegistry *ocmetric.Registry
 	mp         metric.MeterProvider
 
-	server     *http.Server
+	servers    []*http.Server
+	pushers    []*metricpush.Pusher
 	doInitOnce sync.Once
 }
 
@@ -84,22 +89,31 @@ func (tel *telemetryInitializer) init(buildInfo component.BuildInfo, logger *zap
 	var err error
 	tel.doInitOnce.Do(
 		func() {
-			if cfg.Metrics.Level == configtelemetry.LevelNone || cfg.Metrics.Address == "" {
+			if len(cfg.Metrics.Addresses()) == 0 && len(cfg.Metrics.PeriodicReaders()) == 0 {
 				logger.Info(
 					"Skipping telemetry setup.",
 					zap.String(zapKeyTelemetryAddress, cfg.Metrics.Address),
 					zap.String(zapKeyTelemetryLevel, cfg.Metrics.Level.String()),
 				)
+				if cfg.Metrics.Level != configtelemetry.LevelNone && cfg.Diagnostics.Interval > 0 {
+					// The self-diagnostics are computed from the internal metrics, record them even if they are not exposed.
+					err = tel.registerViews(cfg)
+				}
 				return
 			}
 
 			err = tel.initOnce(buildInfo, logger, cfg)
 			if err == nil {
-				go func() {
-					if serveErr := tel.server.ListenAndServe(); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
-						asyncErrorChannel <- serveErr
-					}
-				}()
+				for _, server := range tel.servers {
+					go func(server *http.Server) {
+						if serveErr := server.ListenAndServe(); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+							asyncErrorChannel <- serveErr
+						}
+					}(server)
+				}
+				for _, pusher := range tel.pushers {
+					pusher.Start()
+				}
 			}
 
 		},
@@ -127,7 +141,7 @@ func (tel *telemetryInitializer) initOnce(buildInfo component.BuildInfo, logger
 	// to the OpenTelemetry Go SDK without breaking existing metrics.
 	promRegistry := prometheus.NewRegistry()
 	if tel.registry.IsEnabled(obsreportconfig.UseOtelForInternalMetricsfeatureGateID) {
-		err = tel.initOpenTelemetry(telAttrs, promRegistry)
+		err = tel.initOpenTelemetry(cfg, telAttrs, promRegistry)
 		if err != nil {
 			return err
 		}
@@ -138,18 +152,32 @@ func (tel *telemetryInitializer) initOnce(buildInfo component.BuildInfo, logger
 		return err
 	}
 
+	for _, readerCfg := range cfg.Metrics.PeriodicReaders() {
+		pusher, err := metricpush.NewPusher(readerCfg, promRegistry, telAttrs, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create the periodic reader of the internal metrics: %w", err)
+		}
+		tel.pushers = append(tel.pushers, pusher)
+	}
+
+	if len(cfg.Metrics.Addresses()) == 0 {
+		return nil
+	}
+
 	logger.Info(
 		"Serving Prometheus metrics",
-		zap.String(zapKeyTelemetryAddress, cfg.Metrics.Address),
+		zap.Strings(zapKeyTelemetryAddress, cfg.Metrics.Addresses()),
 		zap.String(zapKeyTelemetryLevel, cfg.Metrics.Level.String()),
 	)
 
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", pe)
 
-	tel.server = &http.Server{
-		Addr:    cfg.Metrics.Address,
-		Handler: mux,
+	for _, addr := range cfg.Metrics.Addresses() {
+		tel.servers = append(tel.servers, &http.Server{
+			Addr:    addr,
+			Handler: mux,
+		})
 	}
 
 	return nil
@@ -190,13 +218,7 @@ func (tel *telemetryInitializer) initOpenCensus(cfg telemetry.Config, telAttrs m
 	tel.ocRegistry = ocmetric.NewRegistry()
 	metricproducer.GlobalManager().AddProducer(tel.ocRegistry)
 
-	var views []*view.View
-	obsMetrics := obsreportconfig.Configure(cfg.Metrics.Level)
-	views = append(views, batchprocessor.MetricViews()...)
-	views = append(views, obsMetrics.Views...)
-
-	tel.views = views
-	if err := view.Register(views...); err != nil {
+	if err := tel.registerViews(cfg); err != nil {
 		return nil, err
 	}
 
@@ -221,7 +243,68 @@ func (tel *telemetryInitializer) initOpenCensus(cfg telemetry.Config, telAttrs m
 	return pe, nil
 }
 
-func (tel *telemetryInitializer) initOpenTelemetry(attrs map[string]string, promRegistry prometheus.Registerer) error {
+// registerViews registers the OpenCensus views of the internal metrics.
+func (tel *telemetryInitializer) registerViews(cfg telemetry.Config) error {
+	var views []*view.View
+	obsMetrics := obsreportconfig.Configure(cfg.Metrics.Level)
+	views = append(views, batchprocessor.MetricViews()...)
+	views = append(views, configtls.MetricViews()...)
+	views = append(views, obsMetrics.Views...)
+
+	tel.views = overrideViewBuckets(views, cfg.Metrics.Views)
+	return view.Register(tel.views...)
+}
+
+// overrideViewBuckets returns the views, replacing the distribution buckets of the ones configured in overrides.
+func overrideViewBuckets(views []*view.View, overrides []telemetry.MetricViewConfig) []*view.View {
+	if len(overrides) == 0 {
+		return views
+	}
+	buckets := make(map[string][]float64, len(overrides))
+	for _, o := range overrides {
+		buckets[o.Instrument] = o.Buckets
+	}
+
+	ret := make([]*view.View, 0, len(views))
+	for _, v := range views {
+		if b, ok := buckets[v.Name]; ok && v.Aggregation != nil && v.Aggregation.Type == view.AggTypeDistribution {
+			overridden := *v
+			overridden.Aggregation = view.Distribution(b...)
+			v = &overridden
+		}
+		ret = append(ret, v)
+	}
+	return ret
+}
+
+// otelViewsWithBucketOverrides returns the OpenTelemetry views of the configured overrides, followed by
+// the default views that do not apply to any of the overridden instruments.
+func otelViewsWithBucketOverrides(defaults []otelview.View, overrides []telemetry.MetricViewConfig) ([]otelview.View, error) {
+	var views []otelview.View
+	for _, o := range overrides {
+		v, err := otelview.New(
+			otelview.MatchInstrumentName(o.Instrument),
+			otelview.WithSetAggregation(aggregation.ExplicitBucketHistogram{Boundaries: o.Buckets}),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error creating otel metrics view for %q: %w", o.Instrument, err)
+		}
+		views = append(views, v)
+	}
+
+DEFAULTS:
+	for _, v := range defaults {
+		for _, o := range overrides {
+			if _, match := v.TransformInstrument(otelview.Instrument{Name: o.Instrument}); match {
+				continue DEFAULTS
+			}
+		}
+		views = append(views, v)
+	}
+	return views, nil
+}
+
+func (tel *telemetryInitializer) initOpenTelemetry(cfg telemetry.Config, attrs map[string]string, promRegistry prometheus.Registerer) error {
 	// Initialize the ocRegistry, still used by the process metrics.
 	tel.ocRegistry = ocmetric.NewRegistry()
 
@@ -238,6 +321,11 @@ func (tel *telemetryInitializer) initOpenTelemetry(attrs map[string]string, prom
 	}
 	views = append(views, batchViews...)
 
+	views, err = otelViewsWithBucketOverrides(views, cfg.Metrics.Views)
+	if err != nil {
+		return err
+	}
+
 	res, err := resource.New(context.Background(), resource.WithAttributes(resAttrs...))
 	if err != nil {
 		return fmt.Errorf("error creating otel resources: %w", err)
@@ -260,15 +348,20 @@ func (tel *telemetryInitializer) initOpenTelemetry(attrs map[string]string, prom
 }
 
 func (tel *telemetryInitializer) shutdown() error {
+	var errs error
+	// The pushers push the metrics one last time, before the views are unregistered.
+	for _, pusher := range tel.pushers {
+		errs = multierr.Append(errs, pusher.Shutdown())
+	}
+
 	metricproducer.GlobalManager().DeleteProducer(tel.ocRegistry)
 
 	view.Unregister(tel.views...)
 
-	if tel.server != nil {
-		return tel.server.Close()
+	for _, server := range tel.servers {
+		errs = multierr.Append(errs, server.Close())
 	}
-
-	return nil
+	return errs
 }
 
 func sanitizePrometheusKey(str string) string {
@@ -16,10 +16,13 @@ package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/config/configtls"
 )
 
 // Config defines the configurable settings for service telemetry.
@@ -28,6 +31,12 @@ type Config struct {
 	Metrics MetricsConfig `mapstructure:"metrics"`
 	Traces  TracesConfig  `mapstructure:"traces"`
 
+	// Diagnostics configures a periodic log summarizing the collector's own telemetry.
+	Diagnostics DiagnosticsConfig `mapstructure:"diagnostics"`
+
+	// Health configures a built-in HTTP endpoint exposing the readiness of the pipelines, e.g. for Kubernetes probes.
+	Health HealthConfig `mapstructure:"health"`
+
 	// Resource specifies user-defined attributes to include with all emitted telemetry.
 	// Note that some attributes are added automatically (e.g. service.version) even
 	// if they are not specified here. In order to suppress such attributes the
@@ -115,9 +124,108 @@ type MetricsConfig struct {
 	Level configtelemetry.Level `mapstructure:"level"`
 
 	// Address is the [address]:port that metrics exposition should be bound to.
+	// It is ignored if Readers is set.
+	Address string `mapstructure:"address"`
+
+	// Readers configures the endpoints exposing the internal metrics. If unset, the metrics
+	// are exposed on Address. An explicit empty list exposes the metrics nowhere, so that no
+	// port is bound by the collector.
+	Readers []MetricReader `mapstructure:"readers"`
+
+	// Views overrides the bucket boundaries of the internal histograms, e.g. when the defaults
+	// are too coarse for the measured values.
+	Views []MetricViewConfig `mapstructure:"views"`
+}
+
+// MetricViewConfig overrides how one internal metric is aggregated.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type MetricViewConfig struct {
+	// Instrument is the name of the internal metric, without the "otelcol_" prefix of the
+	// Prometheus endpoint, e.g. "processor/batch/batch_send_size".
+	Instrument string `mapstructure:"instrument"`
+
+	// Buckets are the explicit bucket boundaries of the histogram, in increasing order.
+	Buckets []float64 `mapstructure:"buckets"`
+}
+
+// MetricReader configures one way of exposing the internal metrics. Exactly one of its fields must be set.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type MetricReader struct {
+	// Prometheus exposes the internal metrics on a Prometheus scrape endpoint.
+	Prometheus *PrometheusReaderConfig `mapstructure:"prometheus"`
+
+	// Periodic pushes the internal metrics at a regular interval, for environments where the
+	// collector cannot be scraped.
+	Periodic *PeriodicReaderConfig `mapstructure:"periodic"`
+}
+
+// PrometheusReaderConfig configures a Prometheus scrape endpoint for the internal metrics.
+type PrometheusReaderConfig struct {
+	// Address is the [address]:port that the endpoint should be bound to.
 	Address string `mapstructure:"address"`
 }
 
+// PeriodicReaderConfig configures the periodic push of the internal metrics.
+type PeriodicReaderConfig struct {
+	// Interval is the period between two pushes. Zero uses the default of 60s.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Timeout is the maximum duration of a push. Zero uses the default of 30s.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Exporter configures where the internal metrics are pushed.
+	Exporter PushExporterConfig `mapstructure:"exporter"`
+}
+
+// PushExporterConfig configures the destination of pushed internal telemetry. Exactly one of its fields must be set.
+type PushExporterConfig struct {
+	// OTLP pushes the internal telemetry to an OTLP endpoint.
+	OTLP *OTLPExporterConfig `mapstructure:"otlp"`
+}
+
+const (
+	// OTLPProtocolGRPC is the OTLPExporterConfig.Protocol sending OTLP over gRPC.
+	OTLPProtocolGRPC = "grpc"
+	// OTLPProtocolHTTPProtobuf is the OTLPExporterConfig.Protocol sending OTLP protobuf over HTTP.
+	OTLPProtocolHTTPProtobuf = "http/protobuf"
+)
+
+// OTLPExporterConfig configures an OTLP endpoint receiving the internal telemetry.
+type OTLPExporterConfig struct {
+	// Protocol is either "grpc" or "http/protobuf". Defaults to "grpc".
+	Protocol string `mapstructure:"protocol"`
+
+	// Endpoint is the host:port of the gRPC server, or the URL the HTTP requests are posted to,
+	// e.g. "https://otlp.example.com:4318/v1/metrics" or "https://otlp.example.com:4318/v1/traces".
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Headers are sent with every request, e.g. to authenticate the collector.
+	Headers map[string]string `mapstructure:"headers"`
+
+	// TLSSetting configures the TLS connection. HTTP requests use TLS if the endpoint is an https URL.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls"`
+}
+
+// Addresses returns the addresses that the internal metrics are exposed on.
+func (c *MetricsConfig) Addresses() []string {
+	if c.Level == configtelemetry.LevelNone {
+		return nil
+	}
+	if c.Readers == nil {
+		if c.Address == "" {
+			return nil
+		}
+		return []string{c.Address}
+	}
+	var addrs []string
+	for _, r := range c.Readers {
+		if r.Prometheus != nil {
+			addrs = append(addrs, r.Prometheus.Address)
+		}
+	}
+	return addrs
+}
+
 // TracesConfig exposes the common Telemetry configuration for collector's internal spans.
 // Experimental: *NOTE* this structure is subject to change or removal in the future.
 type TracesConfig struct {
@@ -125,14 +233,248 @@ type TracesConfig struct {
 	// tracecontext and  b3 are supported. By default, the value is set to empty list and
 	// context propagation is disabled.
 	Propagators []string `mapstructure:"propagators"`
+
+	// Sampling configures which fraction of the collector's internal spans are sampled,
+	// per component kind. Spans that are not sampled are still recorded, so they remain
+	// visible in the zpages extension, but they are not exported.
+	Sampling TracesSamplingConfig `mapstructure:"sampling"`
+
+	// Limits caps the number of attributes, events and links recorded on each internal span.
+	Limits SpanLimitsConfig `mapstructure:"limits"`
+
+	// Exporter configures the export of the sampled internal spans, in batches, to an OTLP endpoint.
+	// The resource of the spans is built from the resource attributes of the telemetry configuration.
+	// By default, the internal spans are not exported.
+	Exporter *PushExporterConfig `mapstructure:"exporter"`
+}
+
+// TracesSamplingConfig defines the sampling ratio of the collector's internal spans per component kind.
+// The sampling decision is made when the span starts, and child spans follow the decision of their parent.
+// Each ratio must be in the [0, 1] range; by default no internal spans are sampled.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type TracesSamplingConfig struct {
+	// Receivers is the ratio of sampled spans started by receivers.
+	Receivers float64 `mapstructure:"receivers"`
+
+	// Processors is the ratio of sampled spans started by processors.
+	Processors float64 `mapstructure:"processors"`
+
+	// Exporters is the ratio of sampled spans started by exporters.
+	Exporters float64 `mapstructure:"exporters"`
+
+	// Scrapers is the ratio of sampled spans started by scrapers.
+	Scrapers float64 `mapstructure:"scrapers"`
+
+	// Errors configures the export of the spans that were not sampled but ended with an error status.
+	Errors TracesErrorSamplingConfig `mapstructure:"errors"`
+}
+
+// TracesErrorSamplingConfig defines, per component kind, the ratio of the spans that were not sampled
+// when they started but are exported anyway because they ended with an error status. For example,
+// a ratio of 1 for the exporters exports all the exporter spans that failed.
+// Each ratio must be in the [0, 1] range; by default no error spans are exported this way.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type TracesErrorSamplingConfig struct {
+	// Receivers is the ratio of exported error spans started by receivers.
+	Receivers float64 `mapstructure:"receivers"`
+
+	// Processors is the ratio of exported error spans started by processors.
+	Processors float64 `mapstructure:"processors"`
+
+	// Exporters is the ratio of exported error spans started by exporters.
+	Exporters float64 `mapstructure:"exporters"`
+
+	// Scrapers is the ratio of exported error spans started by scrapers.
+	Scrapers float64 `mapstructure:"scrapers"`
+}
+
+// SpanLimitsConfig defines the limits applied to each internal span. Zero, the default, keeps the
+// limit of the OpenTelemetry SDK, which can be set with the OTEL_SPAN_*_LIMIT environment variables;
+// a negative value removes the limit.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type SpanLimitsConfig struct {
+	// AttributeCount is the maximum number of attributes of a span.
+	AttributeCount int `mapstructure:"attribute_count"`
+
+	// AttributeValueLength is the maximum length of the string attribute values of a span.
+	AttributeValueLength int `mapstructure:"attribute_value_length"`
+
+	// EventCount is the maximum number of events of a span.
+	EventCount int `mapstructure:"event_count"`
+
+	// LinkCount is the maximum number of links of a span.
+	LinkCount int `mapstructure:"link_count"`
+}
+
+// DiagnosticsConfig defines the periodic self-diagnostics log line. It summarizes, for every component,
+// the change of the accepted, refused, sent and dropped items and the current queue sizes, along with the
+// memory and the goroutines of the process. The items are only counted if the metrics level is not none.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type DiagnosticsConfig struct {
+	// Interval is the period at which the self-diagnostics are logged. Zero, the default, disables them.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// HealthConfig defines the built-in health endpoint. It responds to GET requests with the state of the service
+// and the last status reported by every component of the pipelines, with a 200 status code once the pipelines
+// are ready, even if some components report an error, and a 503 status code otherwise.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type HealthConfig struct {
+	// Endpoint is the [address]:port that the health endpoint is bound to. Empty, the default, disables it.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Path is the URL path of the health endpoint. Empty defaults to "/health".
+	Path string `mapstructure:"path"`
+}
+
+// HealthPath returns the URL path of the health endpoint.
+func (c *HealthConfig) HealthPath() string {
+	if c.Path == "" {
+		return "/health"
+	}
+	return c.Path
 }
 
 // Validate checks whether the current configuration is valid
 func (c *Config) Validate() error {
 
-	// Check when service telemetry metric level is not none, the metrics address should not be empty
-	if c.Metrics.Level != configtelemetry.LevelNone && c.Metrics.Address == "" {
-		return fmt.Errorf("collector telemetry metric address should exist when metric level is not none")
+	if err := c.Metrics.Validate(); err != nil {
+		return err
+	}
+
+	if c.Diagnostics.Interval < 0 {
+		return fmt.Errorf("collector telemetry diagnostics interval must not be negative")
+	}
+
+	if c.Health.Path != "" && !strings.HasPrefix(c.Health.Path, "/") {
+		return fmt.Errorf("collector telemetry health path %q must start with /", c.Health.Path)
+	}
+
+	if c.Traces.Exporter != nil {
+		if err := c.Traces.Exporter.Validate(); err != nil {
+			return fmt.Errorf("collector telemetry traces %w", err)
+		}
+	}
+
+	return c.Traces.Sampling.Validate()
+}
+
+// Validate checks that the internal metrics are exposed as configured, and that no endpoint
+// is configured if the internal metrics are disabled.
+func (c *MetricsConfig) Validate() error {
+	seen := map[string]bool{}
+	for i, v := range c.Views {
+		if v.Instrument == "" {
+			return fmt.Errorf("collector telemetry metric view %d must have an instrument", i)
+		}
+		if seen[v.Instrument] {
+			return fmt.Errorf("collector telemetry metric view for %q is duplicated", v.Instrument)
+		}
+		seen[v.Instrument] = true
+		if len(v.Buckets) == 0 {
+			return fmt.Errorf("collector telemetry metric view for %q must have buckets", v.Instrument)
+		}
+		for j := 1; j < len(v.Buckets); j++ {
+			if v.Buckets[j] <= v.Buckets[j-1] {
+				return fmt.Errorf("collector telemetry metric view for %q must have strictly increasing buckets", v.Instrument)
+			}
+		}
+	}
+
+	if c.Readers == nil {
+		// Check when service telemetry metric level is not none, the metrics address should not be empty
+		if c.Level != configtelemetry.LevelNone && c.Address == "" {
+			return fmt.Errorf("collector telemetry metric address should exist when metric level is not none")
+		}
+		return nil
+	}
+
+	if c.Level == configtelemetry.LevelNone && len(c.Readers) > 0 {
+		return fmt.Errorf("collector telemetry metric readers must be empty when metric level is none")
+	}
+	for i, r := range c.Readers {
+		switch {
+		case r.Prometheus != nil && r.Periodic != nil:
+			return fmt.Errorf("collector telemetry metric reader %d must configure only one of prometheus or periodic", i)
+		case r.Prometheus != nil:
+			if r.Prometheus.Address == "" {
+				return fmt.Errorf("collector telemetry metric reader %d must have a prometheus address", i)
+			}
+		case r.Periodic != nil:
+			if err := r.Periodic.Validate(); err != nil {
+				return fmt.Errorf("collector telemetry metric reader %d: %w", i, err)
+			}
+		default:
+			return fmt.Errorf("collector telemetry metric reader %d must configure prometheus or periodic", i)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the periodic reader pushes the internal metrics to a valid OTLP endpoint.
+func (c *PeriodicReaderConfig) Validate() error {
+	if c.Interval < 0 {
+		return fmt.Errorf("periodic interval must not be negative")
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("periodic timeout must not be negative")
+	}
+	if err := c.Exporter.Validate(); err != nil {
+		return fmt.Errorf("periodic %w", err)
+	}
+	return nil
+}
+
+// Validate checks that the exporter configures an OTLP endpoint with a supported protocol.
+func (c *PushExporterConfig) Validate() error {
+	otlp := c.OTLP
+	if otlp == nil {
+		return fmt.Errorf("exporter must configure otlp")
+	}
+	switch otlp.Protocol {
+	case "", OTLPProtocolGRPC, OTLPProtocolHTTPProtobuf:
+	default:
+		return fmt.Errorf("otlp protocol %q is not supported, must be %q or %q", otlp.Protocol, OTLPProtocolGRPC, OTLPProtocolHTTPProtobuf)
+	}
+	if otlp.Endpoint == "" {
+		return fmt.Errorf("otlp endpoint must not be empty")
+	}
+	return nil
+}
+
+// PeriodicReaders returns the periodic readers of the internal metrics.
+func (c *MetricsConfig) PeriodicReaders() []*PeriodicReaderConfig {
+	if c.Level == configtelemetry.LevelNone {
+		return nil
+	}
+	var readers []*PeriodicReaderConfig
+	for _, r := range c.Readers {
+		if r.Periodic != nil {
+			readers = append(readers, r.Periodic)
+		}
+	}
+	return readers
+}
+
+// Validate checks that all the sampling ratios are in the [0, 1] range.
+func (c *TracesSamplingConfig) Validate() error {
+	ratios := []struct {
+		kind  string
+		ratio float64
+	}{
+		{kind: "receivers", ratio: c.Receivers},
+		{kind: "processors", ratio: c.Processors},
+		{kind: "exporters", ratio: c.Exporters},
+		{kind: "scrapers", ratio: c.Scrapers},
+		{kind: "errors::receivers", ratio: c.Errors.Receivers},
+		{kind: "errors::processors", ratio: c.Errors.Processors},
+		{kind: "errors::exporters", ratio: c.Errors.Exporters},
+		{kind: "errors::scrapers", ratio: c.Errors.Scrapers},
+	}
+	for _, r := range ratios {
+		if r.ratio < 0 || r.ratio > 1 {
+			return fmt.Errorf("collector telemetry traces sampling ratio for %s must be in the [0, 1] range, got %v", r.kind, r.ratio)
+		}
 	}
 
 	return nil
@@ -16,12 +16,33 @@ package telemetry
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
 )
 
+func TestMetricsConfigAddresses(t *testing.T) {
+	cfg := MetricsConfig{Level: configtelemetry.LevelBasic, Address: ":8888"}
+	assert.Equal(t, []string{":8888"}, cfg.Addresses())
+
+	cfg.Readers = []MetricReader{}
+	assert.Empty(t, cfg.Addresses())
+
+	cfg.Readers = []MetricReader{{Prometheus: &PrometheusReaderConfig{Address: ":9999"}}}
+	assert.Equal(t, []string{":9999"}, cfg.Addresses())
+
+	periodic := &PeriodicReaderConfig{Exporter: PushExporterConfig{OTLP: &OTLPExporterConfig{Endpoint: "localhost:4317"}}}
+	cfg.Readers = append(cfg.Readers, MetricReader{Periodic: periodic})
+	assert.Equal(t, []string{":9999"}, cfg.Addresses())
+	assert.Equal(t, []*PeriodicReaderConfig{periodic}, cfg.PeriodicReaders())
+
+	cfg.Level = configtelemetry.LevelNone
+	assert.Empty(t, cfg.Addresses())
+	assert.Empty(t, cfg.PeriodicReaders())
+}
+
 func TestLoadConfig(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -48,6 +69,317 @@ func TestLoadConfig(t *testing.T) {
 			},
 			success: false,
 		},
+		{
+			name: "no metric readers",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "",
+					Readers: []MetricReader{},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "prometheus metric reader",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Readers: []MetricReader{{Prometheus: &PrometheusReaderConfig{Address: "127.0.0.1:3333"}}},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "metric reader without prometheus",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Readers: []MetricReader{{}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "prometheus metric reader without address",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Readers: []MetricReader{{Prometheus: &PrometheusReaderConfig{}}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "periodic metric reader",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelBasic,
+					Readers: []MetricReader{{Periodic: &PeriodicReaderConfig{
+						Interval: 10 * time.Second,
+						Exporter: PushExporterConfig{OTLP: &OTLPExporterConfig{Protocol: OTLPProtocolHTTPProtobuf, Endpoint: "https://localhost:4318/v1/metrics"}},
+					}}},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "periodic metric reader without otlp",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Readers: []MetricReader{{Periodic: &PeriodicReaderConfig{}}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "periodic metric reader without endpoint",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Readers: []MetricReader{{Periodic: &PeriodicReaderConfig{Exporter: PushExporterConfig{OTLP: &OTLPExporterConfig{}}}}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "periodic metric reader with invalid protocol",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelBasic,
+					Readers: []MetricReader{{Periodic: &PeriodicReaderConfig{
+						Exporter: PushExporterConfig{OTLP: &OTLPExporterConfig{Protocol: "http/json", Endpoint: "localhost:4318"}},
+					}}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "periodic metric reader with negative interval",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelBasic,
+					Readers: []MetricReader{{Periodic: &PeriodicReaderConfig{
+						Interval: -time.Second,
+						Exporter: PushExporterConfig{OTLP: &OTLPExporterConfig{Endpoint: "localhost:4317"}},
+					}}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "metric reader with prometheus and periodic",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelBasic,
+					Readers: []MetricReader{{
+						Prometheus: &PrometheusReaderConfig{Address: "127.0.0.1:3333"},
+						Periodic:   &PeriodicReaderConfig{Exporter: PushExporterConfig{OTLP: &OTLPExporterConfig{Endpoint: "localhost:4317"}}},
+					}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "metric views",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					Views:   []MetricViewConfig{{Instrument: "processor/batch/batch_send_size", Buckets: []float64{1, 10, 100}}},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "metric view without instrument",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					Views:   []MetricViewConfig{{Buckets: []float64{1}}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "metric view without buckets",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					Views:   []MetricViewConfig{{Instrument: "processor/batch/batch_send_size"}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "metric view with unsorted buckets",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					Views:   []MetricViewConfig{{Instrument: "processor/batch/batch_send_size", Buckets: []float64{10, 1}}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "duplicated metric views",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					Views: []MetricViewConfig{
+						{Instrument: "processor/batch/batch_send_size", Buckets: []float64{1}},
+						{Instrument: "processor/batch/batch_send_size", Buckets: []float64{2}},
+					},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "metric readers with level none",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelNone,
+					Readers: []MetricReader{{Prometheus: &PrometheusReaderConfig{Address: "127.0.0.1:3333"}}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "valid traces sampling",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelNone,
+				},
+				Traces: TracesConfig{
+					Sampling: TracesSamplingConfig{
+						Receivers: 0.01,
+						Exporters: 1,
+					},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "invalid traces sampling",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelNone,
+				},
+				Traces: TracesConfig{
+					Sampling: TracesSamplingConfig{
+						Processors: 1.5,
+					},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "invalid traces error sampling",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelNone,
+				},
+				Traces: TracesConfig{
+					Sampling: TracesSamplingConfig{
+						Errors: TracesErrorSamplingConfig{
+							Exporters: -1,
+						},
+					},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "traces exporter",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelNone,
+				},
+				Traces: TracesConfig{
+					Exporter: &PushExporterConfig{OTLP: &OTLPExporterConfig{Endpoint: "localhost:4317"}},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "traces exporter without otlp",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelNone,
+				},
+				Traces: TracesConfig{
+					Exporter: &PushExporterConfig{},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "traces exporter without endpoint",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelNone,
+				},
+				Traces: TracesConfig{
+					Exporter: &PushExporterConfig{OTLP: &OTLPExporterConfig{Protocol: OTLPProtocolHTTPProtobuf}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "valid diagnostics",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelNone,
+				},
+				Diagnostics: DiagnosticsConfig{
+					Interval: time.Minute,
+				},
+			},
+			success: true,
+		},
+		{
+			name: "invalid diagnostics",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelNone,
+				},
+				Diagnostics: DiagnosticsConfig{
+					Interval: -time.Minute,
+				},
+			},
+			success: false,
+		},
+		{
+			name: "valid health",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelNone,
+				},
+				Health: HealthConfig{
+					Endpoint: "localhost:13133",
+					Path:     "/healthz",
+				},
+			},
+			success: true,
+		},
+		{
+			name: "invalid health path",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelNone,
+				},
+				Health: HealthConfig{
+					Endpoint: "localhost:13133",
+					Path:     "healthz",
+				},
+			},
+			success: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -61,3 +393,8 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthPath(t *testing.T) {
+	assert.Equal(t, "/health", (&HealthConfig{}).HealthPath())
+	assert.Equal(t, "/healthz", (&HealthConfig{Path: "/healthz"}).HealthPath())
+}
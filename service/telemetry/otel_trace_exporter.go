@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/internal/otlpclient"
+)
+
+// spanExporter exports the internal spans of the collector to an OTLP endpoint, with the OTLP client shared with
+// the periodic readers of the internal metrics.
+type spanExporter struct {
+	client *otlpclient.Client
+}
+
+var _ sdktrace.SpanExporter = (*spanExporter)(nil)
+
+func newSpanExporter(cfg *PushExporterConfig) (*spanExporter, error) {
+	otlp := cfg.OTLP
+	tlsCfg, err := otlp.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := otlpclient.New(otlpclient.Settings{
+		HTTP:     otlp.Protocol == OTLPProtocolHTTPProtobuf,
+		Endpoint: otlp.Endpoint,
+		Headers:  otlp.Headers,
+		TLS:      tlsCfg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &spanExporter{client: client}, nil
+}
+
+func (e *spanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	return e.client.ExportTraces(ctx, toTraces(spans))
+}
+
+func (e *spanExporter) Shutdown(context.Context) error {
+	return e.client.Close()
+}
+
+// toTraces converts spans of the SDK to pdata. The spans share the resource of the tracer provider, so the
+// resource of the first span is used for all of them.
+func toTraces(spans []sdktrace.ReadOnlySpan) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	if res := spans[0].Resource(); res != nil {
+		putAttributes(rs.Resource().Attributes(), res.Attributes())
+	}
+
+	scopes := map[string]ptrace.ScopeSpans{}
+	for _, span := range spans {
+		scope := span.InstrumentationScope()
+		key := scope.Name + "/" + scope.Version
+		ss, ok := scopes[key]
+		if !ok {
+			ss = rs.ScopeSpans().AppendEmpty()
+			ss.Scope().SetName(scope.Name)
+			ss.Scope().SetVersion(scope.Version)
+			ss.SetSchemaUrl(scope.SchemaURL)
+			scopes[key] = ss
+		}
+		setSpan(ss.Spans().AppendEmpty(), span)
+	}
+	return td
+}
+
+func setSpan(dest ptrace.Span, span sdktrace.ReadOnlySpan) {
+	sc := span.SpanContext()
+	dest.SetTraceID(pcommon.TraceID(sc.TraceID()))
+	dest.SetSpanID(pcommon.SpanID(sc.SpanID()))
+	dest.TraceState().FromRaw(sc.TraceState().String())
+	if span.Parent().HasSpanID() {
+		dest.SetParentSpanID(pcommon.SpanID(span.Parent().SpanID()))
+	}
+	dest.SetName(span.Name())
+	// The span kinds of the API have the values of the OTLP span kinds.
+	dest.SetKind(ptrace.SpanKind(span.SpanKind()))
+	dest.SetStartTimestamp(pcommon.NewTimestampFromTime(span.StartTime()))
+	dest.SetEndTimestamp(pcommon.NewTimestampFromTime(span.EndTime()))
+	putAttributes(dest.Attributes(), span.Attributes())
+	dest.SetDroppedAttributesCount(uint32(span.DroppedAttributes()))
+
+	for _, event := range span.Events() {
+		de := dest.Events().AppendEmpty()
+		de.SetName(event.Name)
+		de.SetTimestamp(pcommon.NewTimestampFromTime(event.Time))
+		putAttributes(de.Attributes(), event.Attributes)
+		de.SetDroppedAttributesCount(uint32(event.DroppedAttributeCount))
+	}
+	dest.SetDroppedEventsCount(uint32(span.DroppedEvents()))
+
+	for _, link := range span.Links() {
+		dl := dest.Links().AppendEmpty()
+		dl.SetTraceID(pcommon.TraceID(link.SpanContext.TraceID()))
+		dl.SetSpanID(pcommon.SpanID(link.SpanContext.SpanID()))
+		dl.TraceState().FromRaw(link.SpanContext.TraceState().String())
+		putAttributes(dl.Attributes(), link.Attributes)
+		dl.SetDroppedAttributesCount(uint32(link.DroppedAttributeCount))
+	}
+	dest.SetDroppedLinksCount(uint32(span.DroppedLinks()))
+
+	switch span.Status().Code {
+	case codes.Ok:
+		dest.Status().SetCode(ptrace.StatusCodeOk)
+	case codes.Error:
+		dest.Status().SetCode(ptrace.StatusCodeError)
+	}
+	dest.Status().SetMessage(span.Status().Description)
+}
+
+func putAttributes(dest pcommon.Map, attrs []attribute.KeyValue) {
+	dest.EnsureCapacity(len(attrs))
+	for _, kv := range attrs {
+		key := string(kv.Key)
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			dest.PutBool(key, kv.Value.AsBool())
+		case attribute.INT64:
+			dest.PutInt(key, kv.Value.AsInt64())
+		case attribute.FLOAT64:
+			dest.PutDouble(key, kv.Value.AsFloat64())
+		case attribute.STRING:
+			dest.PutStr(key, kv.Value.AsString())
+		case attribute.BOOLSLICE:
+			s := dest.PutEmptySlice(key)
+			for _, v := range kv.Value.AsBoolSlice() {
+				s.AppendEmpty().SetBool(v)
+			}
+		case attribute.INT64SLICE:
+			s := dest.PutEmptySlice(key)
+			for _, v := range kv.Value.AsInt64Slice() {
+				s.AppendEmpty().SetInt(v)
+			}
+		case attribute.FLOAT64SLICE:
+			s := dest.PutEmptySlice(key)
+			for _, v := range kv.Value.AsFloat64Slice() {
+				s.AppendEmpty().SetDouble(v)
+			}
+		case attribute.STRINGSLICE:
+			s := dest.PutEmptySlice(key)
+			for _, v := range kv.Value.AsStringSlice() {
+				s.AppendEmpty().SetStr(v)
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+func TestSpanExporterHTTP(t *testing.T) {
+	received := make(chan ptrace.Traces, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("X-Token"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := ptraceotlp.NewExportRequest()
+		assert.NoError(t, req.UnmarshalProto(body))
+		received <- req.Traces()
+	}))
+	defer srv.Close()
+
+	exp, err := newSpanExporter(&PushExporterConfig{OTLP: &OTLPExporterConfig{
+		Protocol: OTLPProtocolHTTPProtobuf,
+		Endpoint: srv.URL + "/v1/traces",
+		Headers:  map[string]string{"X-Token": "secret"},
+	}})
+	require.NoError(t, err)
+
+	buildInfo := component.BuildInfo{Command: "otelcol", Version: "1.2.3"}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithResource(newResource(buildInfo, nil)),
+		sdktrace.WithSyncer(exp),
+	)
+	ctx, parent := tp.Tracer("obsreport").Start(context.Background(), "receiver/otlp/TraceDataReceived")
+	_, child := tp.Tracer("obsreport").Start(ctx, "exporter/otlp/traces", trace.WithSpanKind(trace.SpanKindClient))
+	child.SetAttributes(attribute.Int64("sent_items", 5), attribute.StringSlice("names", []string{"a", "b"}))
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+
+	td := <-received
+	require.Equal(t, 1, td.SpanCount())
+	rs := td.ResourceSpans().At(0)
+	serviceName, ok := rs.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "otelcol", serviceName.Str())
+	serviceVersion, ok := rs.Resource().Attributes().Get("service.version")
+	require.True(t, ok)
+	assert.Equal(t, "1.2.3", serviceVersion.Str())
+
+	ss := rs.ScopeSpans().At(0)
+	assert.Equal(t, "obsreport", ss.Scope().Name())
+	span := ss.Spans().At(0)
+	assert.Equal(t, "exporter/otlp/traces", span.Name())
+	assert.Equal(t, ptrace.SpanKindClient, span.Kind())
+	assert.Equal(t, [16]byte(parent.SpanContext().TraceID()), [16]byte(span.TraceID()))
+	assert.Equal(t, [8]byte(parent.SpanContext().SpanID()), [8]byte(span.ParentSpanID()))
+	assert.Equal(t, ptrace.StatusCodeError, span.Status().Code())
+	assert.Equal(t, "failed", span.Status().Message())
+	assert.Equal(t, map[string]interface{}{"sent_items": int64(5), "names": []interface{}{"a", "b"}}, span.Attributes().AsRaw())
+
+	parent.End()
+	<-received
+	require.NoError(t, tp.Shutdown(context.Background()))
+}
+
+func TestSpanExporterHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	exp, err := newSpanExporter(&PushExporterConfig{OTLP: &OTLPExporterConfig{
+		Protocol: OTLPProtocolHTTPProtobuf,
+		Endpoint: srv.URL,
+	}})
+	require.NoError(t, err)
+
+	rec := &spanRecorder{}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()), sdktrace.WithSyncer(rec))
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+
+	assert.Error(t, exp.ExportSpans(context.Background(), rec.spans))
+	assert.NoError(t, exp.ExportSpans(context.Background(), nil))
+	assert.NoError(t, exp.Shutdown(context.Background()))
+}
+
+type spanRecorder struct {
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (r *spanRecorder) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	r.spans = append(r.spans, spans...)
+	return nil
+}
+
+func (r *spanRecorder) Shutdown(context.Context) error {
+	return nil
+}
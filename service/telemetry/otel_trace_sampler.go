@@ -15,7 +15,15 @@
 package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
 
 import (
+	"context"
+	"hash/fnv"
+	"strings"
+
+	"go.opentelemetry.io/otel/codes"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
 type recordSampler struct{}
@@ -28,12 +36,147 @@ func (r recordSampler) Description() string {
 	return "Always record sampler"
 }
 
-func alwaysRecord() sdktrace.Sampler {
+// componentSampler samples root spans according to the ratio configured for the kind of
+// component that started them. Spans that are not sampled are still recorded.
+type componentSampler struct {
+	// samplers are keyed by the span name prefix used by obsreport for each component kind.
+	samplers map[string]sdktrace.Sampler
+}
+
+func newComponentSampler(cfg TracesSamplingConfig) sdktrace.Sampler {
+	return &componentSampler{
+		samplers: map[string]sdktrace.Sampler{
+			obsmetrics.ReceiverPrefix:  sdktrace.TraceIDRatioBased(cfg.Receivers),
+			obsmetrics.ProcessorPrefix: sdktrace.TraceIDRatioBased(cfg.Processors),
+			obsmetrics.ExporterPrefix:  sdktrace.TraceIDRatioBased(cfg.Exporters),
+			obsmetrics.ScraperPrefix:   sdktrace.TraceIDRatioBased(cfg.Scrapers),
+		},
+	}
+}
+
+func (c *componentSampler) ShouldSample(parameters sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	for prefix, sampler := range c.samplers {
+		if !strings.HasPrefix(parameters.Name, prefix) {
+			continue
+		}
+		res := sampler.ShouldSample(parameters)
+		if res.Decision == sdktrace.Drop {
+			res.Decision = sdktrace.RecordOnly
+		}
+		return res
+	}
+	return recordSampler{}.ShouldSample(parameters)
+}
+
+func (c *componentSampler) Description() string {
+	return "Component kind ratio based sampler"
+}
+
+func alwaysRecord(cfg TracesSamplingConfig) sdktrace.Sampler {
 	rs := &recordSampler{}
 	return sdktrace.ParentBased(
-		rs,
+		newComponentSampler(cfg),
 		sdktrace.WithRemoteParentSampled(sdktrace.AlwaysSample()),
 		sdktrace.WithRemoteParentNotSampled(rs),
 		sdktrace.WithLocalParentSampled(sdktrace.AlwaysSample()),
 		sdktrace.WithRemoteParentSampled(rs))
 }
+
+// errorSamplingSeed is hashed with the trace IDs by the errorSpanProcessor, so that its decisions are
+// independent from those of the componentSampler, which compares the trace IDs with the ratio as they are.
+// Otherwise the spans that were not sampled could only be kept with a higher error ratio than the ratio.
+var errorSamplingSeed = []byte("otelcol-error-sampling")
+
+// errorSpanProcessor forwards to the next span processor the sampled spans, and the spans that were not
+// sampled but ended with an error status, according to the error ratio of the component kind that
+// started them. The latter are marked as sampled so that the batch span processor exports them.
+type errorSpanProcessor struct {
+	next sdktrace.SpanProcessor
+	// ratios are keyed by the span name prefix used by obsreport for each component kind.
+	ratios map[string]float64
+}
+
+func newErrorSpanProcessor(cfg TracesErrorSamplingConfig, next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+	return &errorSpanProcessor{
+		next: next,
+		ratios: map[string]float64{
+			obsmetrics.ReceiverPrefix:  cfg.Receivers,
+			obsmetrics.ProcessorPrefix: cfg.Processors,
+			obsmetrics.ExporterPrefix:  cfg.Exporters,
+			obsmetrics.ScraperPrefix:   cfg.Scrapers,
+		},
+	}
+}
+
+func (p *errorSpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p *errorSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.SpanContext().IsSampled() {
+		p.next.OnEnd(s)
+		return
+	}
+	if s.Status().Code != codes.Error {
+		return
+	}
+	for prefix, ratio := range p.ratios {
+		if !strings.HasPrefix(s.Name(), prefix) {
+			continue
+		}
+		if errorSampled(s.SpanContext().TraceID(), ratio) {
+			p.next.OnEnd(sampledSpan{ReadOnlySpan: s})
+		}
+		return
+	}
+}
+
+// errorSampled returns whether the error spans of the trace are sampled with the given ratio. The decision
+// is the same for all the spans of the trace.
+func errorSampled(traceID trace.TraceID, ratio float64) bool {
+	if ratio >= 1 {
+		return true
+	}
+	if ratio <= 0 {
+		return false
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(errorSamplingSeed)
+	_, _ = h.Write(traceID[:])
+	return h.Sum64()>>1 < uint64(ratio*(1<<63))
+}
+
+func (p *errorSpanProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *errorSpanProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// sampledSpan is a span whose span context is marked as sampled.
+type sampledSpan struct {
+	sdktrace.ReadOnlySpan
+}
+
+func (s sampledSpan) SpanContext() trace.SpanContext {
+	return s.ReadOnlySpan.SpanContext().WithTraceFlags(s.ReadOnlySpan.SpanContext().TraceFlags().WithSampled(true))
+}
+
+// spanLimits returns the span limits of the SDK, overridden by the limits that are set in cfg.
+func spanLimits(cfg SpanLimitsConfig) sdktrace.SpanLimits {
+	limits := sdktrace.NewSpanLimits()
+	if cfg.AttributeCount != 0 {
+		limits.AttributeCountLimit = cfg.AttributeCount
+	}
+	if cfg.AttributeValueLength != 0 {
+		limits.AttributeValueLengthLimit = cfg.AttributeValueLength
+	}
+	if cfg.EventCount != 0 {
+		limits.EventCountLimit = cfg.EventCount
+	}
+	if cfg.LinkCount != 0 {
+		limits.LinkCountLimit = cfg.LinkCount
+	}
+	return limits
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestComponentSampler(t *testing.T) {
+	sampler := newComponentSampler(TracesSamplingConfig{
+		Receivers: 0,
+		Exporters: 1,
+	})
+
+	tests := []struct {
+		name     string
+		spanName string
+		decision sdktrace.SamplingDecision
+	}{
+		{
+			name:     "receiver not sampled",
+			spanName: "receiver/otlp/TraceDataReceived",
+			decision: sdktrace.RecordOnly,
+		},
+		{
+			name:     "exporter sampled",
+			spanName: "exporter/otlp/traces",
+			decision: sdktrace.RecordAndSample,
+		},
+		{
+			name:     "unknown component kind",
+			spanName: "other",
+			decision: sdktrace.RecordOnly,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := sampler.ShouldSample(sdktrace.SamplingParameters{
+				TraceID: trace.TraceID{1, 2, 3},
+				Name:    tt.spanName,
+			})
+			assert.Equal(t, tt.decision, res.Decision)
+		})
+	}
+}
+
+func TestErrorSpanProcessor(t *testing.T) {
+	cfg := TracesSamplingConfig{
+		Errors: TracesErrorSamplingConfig{
+			Exporters: 1,
+		},
+	}
+	tests := []struct {
+		name     string
+		spanName string
+		code     codes.Code
+		exported bool
+	}{
+		{
+			name:     "exporter error",
+			spanName: "exporter/otlp/traces",
+			code:     codes.Error,
+			exported: true,
+		},
+		{
+			name:     "exporter success",
+			spanName: "exporter/otlp/traces",
+			code:     codes.Ok,
+			exported: false,
+		},
+		{
+			name:     "receiver error",
+			spanName: "receiver/otlp/TraceDataReceived",
+			code:     codes.Error,
+			exported: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(
+				sdktrace.WithSampler(alwaysRecord(cfg)),
+				sdktrace.WithSpanProcessor(newErrorSpanProcessor(cfg.Errors, rec)),
+			)
+			_, span := tp.Tracer("test").Start(context.Background(), tt.spanName)
+			span.SetStatus(tt.code, "")
+			span.End()
+
+			ended := rec.Ended()
+			if !tt.exported {
+				assert.Empty(t, ended)
+				return
+			}
+			assert.Len(t, ended, 1)
+			assert.True(t, ended[0].SpanContext().IsSampled())
+		})
+	}
+}
+
+func TestErrorSpanProcessorRatio(t *testing.T) {
+	cfg := TracesSamplingConfig{
+		Exporters: 0.5,
+		Errors: TracesErrorSamplingConfig{
+			Exporters: 0.5,
+		},
+	}
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(alwaysRecord(cfg)),
+		sdktrace.WithSpanProcessor(newErrorSpanProcessor(cfg.Errors, rec)),
+	)
+	const spans = 10000
+	for i := 0; i < spans; i++ {
+		_, span := tp.Tracer("test").Start(context.Background(), "exporter/otlp/traces")
+		span.SetStatus(codes.Error, "")
+		span.End()
+	}
+
+	// Half of the spans are sampled, and half of the other ones are sampled as errors.
+	assert.InDelta(t, 0.75, float64(len(rec.Ended()))/spans, 0.03)
+}
+
+func TestErrorSampled(t *testing.T) {
+	traceID := trace.TraceID{1, 2, 3}
+	assert.True(t, errorSampled(traceID, 1))
+	assert.False(t, errorSampled(traceID, 0))
+	assert.Equal(t, errorSampled(traceID, 0.5), errorSampled(traceID, 0.5))
+}
+
+func TestSpanLimits(t *testing.T) {
+	limits := spanLimits(SpanLimitsConfig{
+		AttributeCount: 10,
+		LinkCount:      -1,
+	})
+	assert.Equal(t, 10, limits.AttributeCountLimit)
+	assert.Equal(t, -1, limits.LinkCountLimit)
+	assert.Equal(t, sdktrace.NewSpanLimits().EventCountLimit, limits.EventCountLimit)
+}
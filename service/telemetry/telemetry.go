@@ -17,11 +17,16 @@ package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/component"
+	semconv "go.opentelemetry.io/collector/semconv/v1.5.0"
 )
 
 type Telemetry struct {
@@ -47,6 +52,9 @@ func (t *Telemetry) Shutdown(ctx context.Context) error {
 // Settings holds configuration for building Telemetry.
 type Settings struct {
 	ZapOptions []zap.Option
+
+	// BuildInfo identifies the collector in the resource of the exported internal spans.
+	BuildInfo component.BuildInfo
 }
 
 // New creates a new Telemetry from Config.
@@ -55,16 +63,46 @@ func New(_ context.Context, set Settings, cfg Config) (*Telemetry, error) {
 	if err != nil {
 		return nil, err
 	}
-	tp := sdktrace.NewTracerProvider(
+	opts := []sdktrace.TracerProviderOption{
 		// needed for supporting the zpages extension
-		sdktrace.WithSampler(alwaysRecord()),
-	)
+		sdktrace.WithSampler(alwaysRecord(cfg.Traces.Sampling)),
+		sdktrace.WithRawSpanLimits(spanLimits(cfg.Traces.Limits)),
+	}
+	if cfg.Traces.Exporter != nil {
+		exp, err := newSpanExporter(cfg.Traces.Exporter)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts,
+			sdktrace.WithResource(newResource(set.BuildInfo, cfg.Resource)),
+			sdktrace.WithSpanProcessor(newErrorSpanProcessor(cfg.Traces.Sampling.Errors, sdktrace.NewBatchSpanProcessor(exp))),
+		)
+	}
+	tp := sdktrace.NewTracerProvider(opts...)
 	return &Telemetry{
 		logger:         logger,
 		tracerProvider: tp,
 	}, nil
 }
 
+// newResource returns the resource of the internal spans. The service name and version default to the ones
+// of the build, and attributes with a nil value are removed.
+func newResource(buildInfo component.BuildInfo, attrs map[string]*string) *resource.Resource {
+	var kvs []attribute.KeyValue
+	if _, ok := attrs[semconv.AttributeServiceName]; !ok {
+		kvs = append(kvs, attribute.String(semconv.AttributeServiceName, buildInfo.Command))
+	}
+	if _, ok := attrs[semconv.AttributeServiceVersion]; !ok {
+		kvs = append(kvs, attribute.String(semconv.AttributeServiceVersion, buildInfo.Version))
+	}
+	for k, v := range attrs {
+		if v != nil {
+			kvs = append(kvs, attribute.String(k, *v))
+		}
+	}
+	return resource.NewSchemaless(kvs...)
+}
+
 func newLogger(cfg LogsConfig, options []zap.Option) (*zap.Logger, error) {
 	// Copied from NewProductionConfig.
 	zapCfg := &zap.Config{
@@ -29,11 +29,15 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/instrument"
 	"go.opentelemetry.io/otel/metric/unit"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	otelview "go.opentelemetry.io/otel/sdk/metric/view"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/processor/batchprocessor"
 	semconv "go.opentelemetry.io/collector/semconv/v1.5.0"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
@@ -149,6 +153,9 @@ func TestTelemetryInit(t *testing.T) {
 			tel := newColTelemetry(registry)
 			buildInfo := component.NewDefaultBuildInfo()
 			cfg := telemetry.Config{
+				Metrics: telemetry.MetricsConfig{
+					Address: "localhost:8888",
+				},
 				Resource: map[string]*string{
 					semconv.AttributeServiceInstanceID: &testInstanceID,
 				},
@@ -165,7 +172,7 @@ func TestTelemetryInit(t *testing.T) {
 				view.Unregister(v)
 			}()
 
-			metrics := getMetricsFromPrometheus(t, tel.server.Handler)
+			metrics := getMetricsFromPrometheus(t, tel.servers[0].Handler)
 			require.Equal(t, len(tc.expectedMetrics), len(metrics))
 
 			for metricName, metricValue := range tc.expectedMetrics {
@@ -186,6 +193,55 @@ func TestTelemetryInit(t *testing.T) {
 	}
 }
 
+func TestTelemetryInitReaders(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		metrics         telemetry.MetricsConfig
+		expectedServers []string
+	}{
+		{
+			name:            "address",
+			metrics:         telemetry.MetricsConfig{Level: configtelemetry.LevelBasic, Address: "localhost:0"},
+			expectedServers: []string{"localhost:0"},
+		},
+		{
+			name:    "level none",
+			metrics: telemetry.MetricsConfig{Level: configtelemetry.LevelNone, Address: "localhost:0"},
+		},
+		{
+			name:    "no readers",
+			metrics: telemetry.MetricsConfig{Level: configtelemetry.LevelBasic, Address: "localhost:0", Readers: []telemetry.MetricReader{}},
+		},
+		{
+			name: "prometheus readers",
+			metrics: telemetry.MetricsConfig{
+				Level:   configtelemetry.LevelBasic,
+				Address: "localhost:0",
+				Readers: []telemetry.MetricReader{
+					{Prometheus: &telemetry.PrometheusReaderConfig{Address: "127.0.0.1:0"}},
+					{Prometheus: &telemetry.PrometheusReaderConfig{Address: "localhost:0"}},
+				},
+			},
+			expectedServers: []string{"127.0.0.1:0", "localhost:0"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tel := newColTelemetry(featuregate.NewRegistry())
+			asyncErrorChannel := make(chan error, len(tc.expectedServers))
+			require.NoError(t, tel.init(component.NewDefaultBuildInfo(), zap.NewNop(), telemetry.Config{Metrics: tc.metrics}, asyncErrorChannel))
+			defer func() {
+				require.NoError(t, tel.shutdown())
+			}()
+
+			var addrs []string
+			for _, server := range tel.servers {
+				addrs = append(addrs, server.Addr)
+			}
+			assert.Equal(t, tc.expectedServers, addrs)
+		})
+	}
+}
+
 func createTestMetrics(t *testing.T, mp metric.MeterProvider) *view.View {
 	// Creates a OTel Go counter
 	counter, err := mp.Meter("collector_test").SyncInt64().Counter(otelPrefix+counterName, instrument.WithUnit(unit.Milliseconds))
@@ -225,3 +281,46 @@ func getMetricsFromPrometheus(t *testing.T, handler http.Handler) map[string]*io
 	return parsed
 
 }
+
+func TestOverrideViewBuckets(t *testing.T) {
+	sizes := stats.Int64("sizes", "", stats.UnitDimensionless)
+	distribution := &view.View{Name: "distribution", Measure: sizes, Aggregation: view.Distribution(10, 100)}
+	sum := &view.View{Name: "sum", Measure: sizes, Aggregation: view.Sum()}
+	untouched := &view.View{Name: "untouched", Measure: sizes, Aggregation: view.Distribution(10, 100)}
+	views := []*view.View{distribution, sum, untouched}
+
+	assert.Equal(t, views, overrideViewBuckets(views, nil))
+
+	got := overrideViewBuckets(views, []telemetry.MetricViewConfig{
+		{Instrument: "distribution", Buckets: []float64{0.1, 0.5, 1}},
+		{Instrument: "sum", Buckets: []float64{0.1, 0.5, 1}},
+	})
+	require.Len(t, got, 3)
+	assert.Equal(t, []float64{0.1, 0.5, 1}, got[0].Aggregation.Buckets)
+	assert.Equal(t, []float64{10, 100}, distribution.Aggregation.Buckets, "the original view must not be modified")
+	assert.Same(t, sum, got[1])
+	assert.Same(t, untouched, got[2])
+}
+
+func TestOtelViewsWithBucketOverrides(t *testing.T) {
+	defaults, err := batchprocessor.OtelMetricsViews()
+	require.NoError(t, err)
+	require.Len(t, defaults, 2)
+
+	views, err := otelViewsWithBucketOverrides(defaults, nil)
+	require.NoError(t, err)
+	assert.Len(t, views, 2)
+
+	views, err = otelViewsWithBucketOverrides(defaults, []telemetry.MetricViewConfig{
+		{Instrument: "processor/batch/batch_send_size", Buckets: []float64{1, 2, 5}},
+		{Instrument: "custom", Buckets: []float64{0.001, 0.01}},
+	})
+	require.NoError(t, err)
+	// Both overrides, and the default view of processor/batch/batch_send_size_bytes.
+	require.Len(t, views, 3)
+	inst, match := views[0].TransformInstrument(otelview.Instrument{Name: "processor/batch/batch_send_size"})
+	require.True(t, match)
+	assert.Equal(t, aggregation.ExplicitBucketHistogram{Boundaries: []float64{1, 2, 5}}, inst.Aggregation)
+	_, match = views[2].TransformInstrument(otelview.Instrument{Name: "processor/batch/batch_send_size_bytes"})
+	assert.True(t, match)
+}
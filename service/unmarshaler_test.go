@@ -66,6 +66,25 @@ func TestUnmarshalEmptyAllSections(t *testing.T) {
 	}, cfg.Service.Telemetry.Logs)
 }
 
+func TestUnmarshalEmptyMetricReaders(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	conf := confmap.NewFromStringMap(map[string]interface{}{
+		"service": map[string]interface{}{
+			"telemetry": map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"readers": []interface{}{},
+				},
+			},
+		},
+	})
+	cfg, err := unmarshal(conf, factories)
+	require.NoError(t, err)
+	assert.NotNil(t, cfg.Service.Telemetry.Metrics.Readers)
+	assert.Empty(t, cfg.Service.Telemetry.Metrics.Addresses())
+}
+
 func TestUnmarshalUnknownTopLevel(t *testing.T) {
 	factories, err := componenttest.NopFactories()
 	assert.NoError(t, err)
@@ -29,13 +29,16 @@ const (
 	pipelinezPath  = "pipelinez"
 	extensionzPath = "extensionz"
 	featurezPath   = "featurez"
+
+	configWarningzPath = "configwarningz"
 )
 
 func (host *serviceHost) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, servicezPath), host.zPagesRequest)
-	mux.HandleFunc(path.Join(pathPrefix, pipelinezPath), host.pipelines.HandleZPages)
+	mux.HandleFunc(path.Join(pathPrefix, pipelinezPath), host.handlePipelinezRequest)
 	mux.HandleFunc(path.Join(pathPrefix, extensionzPath), host.extensions.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, featurezPath), handleFeaturezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, configWarningzPath), host.handleConfigWarningzRequest)
 }
 
 func (host *serviceHost) zPagesRequest(w http.ResponseWriter, r *http.Request) {
@@ -43,6 +46,7 @@ func (host *serviceHost) zPagesRequest(w http.ResponseWriter, r *http.Request) {
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Service " + host.buildInfo.Command})
 	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Build Info", Properties: getBuildInfoProperties(host.buildInfo)})
 	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Runtime Info", Properties: runtimeinfo.Info()})
+	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Environment", Properties: runtimeinfo.Env()})
 	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 		Name:              "Pipelines",
 		ComponentEndpoint: pipelinezPath,
@@ -58,9 +62,20 @@ func (host *serviceHost) zPagesRequest(w http.ResponseWriter, r *http.Request) {
 		ComponentEndpoint: featurezPath,
 		Link:              true,
 	})
+	zpages.WriteHTMLFeaturesTable(w, getFeaturesTableData())
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Config Warnings",
+		ComponentEndpoint: configWarningzPath,
+		Link:              true,
+	})
 	zpages.WriteHTMLPageFooter(w)
 }
 
+// handlePipelinezRequest looks the pipelines up on each request, so that the page shows the pipelines running after a reload.
+func (host *serviceHost) handlePipelinezRequest(w http.ResponseWriter, r *http.Request) {
+	host.getPipelines().HandleZPages(w, r)
+}
+
 func handleFeaturezRequest(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Feature Gates"})